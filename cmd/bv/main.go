@@ -1,55 +1,118 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/config"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/digest"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/web"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/charmbracelet/wish/activeterm"
+	wishtea "github.com/charmbracelet/wish/bubbletea"
+	"github.com/charmbracelet/wish/logging"
 )
 
 func main() {
+	// Subcommands come before the flags
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		os.Exit(runServe(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve-ssh" {
+		os.Exit(runServeSSH(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "report" {
+		os.Exit(runReport(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "release-notes" {
+		os.Exit(runReleaseNotes(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "blockers" {
+		os.Exit(runBlockers(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		os.Exit(runHistory(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "what-if" {
+		os.Exit(runWhatIf(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfig(os.Args[2:]))
+	}
+
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	exportHTML := flag.String("export-html", "", "Export a self-contained HTML report with graph and sortable table (e.g., report.html)")
+	exportSVG := flag.String("export-svg", "", "Export the dependency graph of open issues as an SVG image (e.g., graph.svg)")
+	exportICS := flag.String("export-ics", "", "Export due dates and milestone targets as an iCalendar file (e.g., deadlines.ics)")
+	exportGraphML := flag.String("export-graphml", "", "Export the dependency graph with metrics as GraphML for Gephi/yEd (e.g., graph.graphml)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
 	robotPriority := flag.Bool("robot-priority", false, "Output priority recommendations as JSON for AI agents")
-	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since)")
+	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since or --diff-from)")
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
 	recipeName := flag.String("recipe", "", "Apply named recipe (e.g., triage, actionable, high-impact)")
 	recipeShort := flag.String("r", "", "Shorthand for --recipe")
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
+	diffFrom := flag.String("diff-from", "", "Diff from an issues file or git revision (compares against --diff-to, or the current issues)")
+	diffTo := flag.String("diff-to", "", "Diff to an issues file or git revision (use with --diff-from)")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
 	profileStartup := flag.Bool("profile-startup", false, "Output detailed startup timing profile for diagnostics")
 	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup)")
 	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export")
 	workspaceConfig := flag.String("workspace", "", "Load issues from workspace config file (.bv/workspace.yaml)")
+	markdownDir := flag.String("markdown-dir", "", "Load issues from a directory of Markdown files with YAML front matter, one per issue (read-only)")
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
 	checkDrift := flag.Bool("check-drift", false, "Check for drift from baseline (exit codes: 0=OK, 1=critical, 2=warning)")
 	robotDriftCheck := flag.Bool("robot-drift", false, "Output drift check as JSON (use with --check-drift)")
+	noCache := flag.Bool("no-cache", false, "Don't read or write the on-disk insights cache")
+	me := flag.String("me", "", "Assignee name whose issues are yours, for the @ toggle and unblock alerts (default user.name, $BD_ACTOR, mapped git user.name, then $USER)")
+	noNotify := flag.Bool("no-notify", false, "Don't raise desktop notifications for unblocked issues or finished focus sessions (the status bar still shows them)")
+	var settings settingFlags
+	flag.Var(&settings, "set", "Override a config setting, e.g. --set view.default=board (repeatable; see 'bv config show')")
+	formatFlag := flag.String("format", "", "Print each issue through a Go template instead of opening the TUI (e.g., '{{.ID}}\\t{{.Status}}')")
 	flag.Parse()
 
 	// Handle -r shorthand
@@ -57,8 +120,36 @@ func main() {
 		*recipeName = *recipeShort
 	}
 
+	// Compile --format up front so a bad template fails before any work
+	var formatTmpl *template.Template
+	if *formatFlag != "" {
+		var err error
+		formatTmpl, err = export.ParseFormat(*formatFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --format template: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Persist graph metrics across runs so reopening unchanged data skips Phase 2
+	if !*noCache {
+		if dir, err := analysis.DefaultDiskCacheDir(); err == nil {
+			analysis.GetGlobalCache().SetDiskCache(analysis.NewDiskCache(dir))
+		}
+	}
+
 	if *help {
 		fmt.Println("Usage: bv [options]")
+		fmt.Println("       bv serve [--addr HOST:PORT]")
+		fmt.Println("       bv serve-ssh [--addr HOST:PORT] [--host-key PATH] [--authorized-keys PATH] [--insecure-allow-any]")
+		fmt.Println("       bv report [--period daily|weekly] [--since REV] [--stale-days N] [--post URL] [--format slack|json]")
+		fmt.Println("       bv export insights [-o FILE] [--limit N] [--force-full-analysis]")
+		fmt.Println("       bv release-notes --from REV|DATE [--to REV|DATE] [--template PATH] [--title TEXT] [-o FILE]")
+		fmt.Println("       bv lint [--strict] [--json] [--config PATH] [--set KEY=VALUE]...")
+		fmt.Println("       bv blockers [--limit N] [--json]")
+		fmt.Println("       bv history [--limit N] [--by commit|day] [--csv|--json]")
+		fmt.Println("       bv what-if --title TEXT [--blocked-by IDS] [--blocks IDS] [--parent ID] [--priority N] [--json]")
+		fmt.Println("       bv config show [--set KEY=VALUE]... | bv config init [--user] [--force]")
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
 		os.Exit(0)
@@ -102,6 +193,22 @@ func main() {
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
 		fmt.Println("")
+		fmt.Println("  --export-html <file>")
+		fmt.Println("      Generates a single-file HTML report: summary counts, top-impact issues,")
+		fmt.Println("      the dependency graph as SVG and a sortable table. Runs hooks like --export-md.")
+		fmt.Println("")
+		fmt.Println("  --export-svg <file>")
+		fmt.Println("      Draws the dependency graph of open issues as SVG, colored by status.")
+		fmt.Println("      Nodes link to issue_url from .bv/links.yaml when configured.")
+		fmt.Println("")
+		fmt.Println("  --export-ics <file>")
+		fmt.Println("      Writes open issues' due dates and milestone targets as all-day")
+		fmt.Println("      calendar events for import into team calendars.")
+		fmt.Println("")
+		fmt.Println("  --export-graphml <file>")
+		fmt.Println("      Writes the dependency graph as GraphML: issues as nodes with status,")
+		fmt.Println("      priority, type and graph metrics, dependencies as typed edges.")
+		fmt.Println("")
 		fmt.Println("  --no-hooks")
 		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
 		fmt.Println("")
@@ -124,12 +231,37 @@ func main() {
 		fmt.Println("      - resolved_cycles: Circular dependencies fixed")
 		fmt.Println("      - summary.health_trend: 'improving', 'degrading', or 'stable'")
 		fmt.Println("")
+		fmt.Println("  --diff-from <file|commit|date> [--diff-to <file|commit|date>]")
+		fmt.Println("      Compares two issue files or git revisions. Each side is a JSONL file")
+		fmt.Println("      if one exists at that path, otherwise a git revision.")
+		fmt.Println("      Without --diff-to, compares against the current issues.")
+		fmt.Println("      Besides the --diff-since output:")
+		fmt.Println("      - added_dependencies / removed_dependencies: Edges that changed")
+		fmt.Println("      - from_ready_count / to_ready_count: Ready work on each side")
+		fmt.Println("      - from_critical_path / to_critical_path: Longest open blocking chain")
+		fmt.Println("")
 		fmt.Println("  --as-of <commit|date>")
 		fmt.Println("      View issue state at a point in time.")
 		fmt.Println("      Useful for reviewing historical project state.")
 		fmt.Println("")
 		fmt.Println("  --robot-diff")
-		fmt.Println("      Output diff as JSON (use with --diff-since).")
+		fmt.Println("      Output diff as JSON (use with --diff-since or --diff-from).")
+		fmt.Println("")
+		fmt.Println("  --format TEMPLATE")
+		fmt.Println("      Print one line per issue through a Go template instead of the TUI.")
+		fmt.Println("      Respects --recipe and --repo. \\t and \\n are interpreted.")
+		fmt.Println("      Fields: ID, Title, Status, Type, Assignee, Priority, Labels, Impact,")
+		fmt.Println("      PageRank, Betweenness, Blockers, Dependents, Ready, Created, Updated,")
+		fmt.Println("      Due, and Issue (the full record).")
+		fmt.Println("      Helpers: join, upper, lower, truncate, pad, date, json, default.")
+		fmt.Println("      With --robot-plan or --robot-priority, formats each plan item or")
+		fmt.Println("      recommendation instead of emitting JSON.")
+		fmt.Println("      Example: bv -r actionable --format '{{.ID}}\\t{{pad 8 .Status}}\\t{{truncate 40 .Title}}'")
+		fmt.Println("")
+		fmt.Println("  serve [--addr HOST:PORT]")
+		fmt.Println("      Serves a read-only web view (list, board, SVG dependency graph)")
+		fmt.Println("      of the current project. Default address: 127.0.0.1:8080.")
+		fmt.Println("      Use --addr :8080 to share it on the network.")
 		fmt.Println("")
 		fmt.Println("  --robot-recipes")
 		fmt.Println("      Lists all available recipes as JSON.")
@@ -181,6 +313,16 @@ func main() {
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
 		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
 		fmt.Println("")
+		fmt.Println("  lint [--strict] [--json] [--config PATH] [--set KEY=VALUE]...")
+		fmt.Println("      Checks issue-graph hygiene for CI. Rules and their severities come")
+		fmt.Println("      from .bv/lint.yaml: cycles, dangling_dependency, self_dependency,")
+		fmt.Println("      duplicate_id, unknown_dependency_type, unassigned_priority,")
+		fmt.Println("      missing_estimate, wip_limit. Each is error, warning or off.")
+		fmt.Println("      wip_limit checks the [wip] limits from the settings files.")
+		fmt.Println("      Exit codes: 0 = clean or warnings only, 1 = errors (or warnings")
+		fmt.Println("      with --strict), 2 = bad config or no beads file.")
+		fmt.Println("      Output: {exit_code, findings: [{rule, severity, issue_id, message}], errors, warnings}")
+		fmt.Println("")
 		fmt.Println("  Drift Detection Configuration (.bv/drift.yaml)")
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
@@ -194,6 +336,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Layered configuration: user file, .beads_viewer.toml, BV_* and --set
+	cfg, err := loadConfig(settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	applyConfig(cfg)
+	*forceFullAnalysis = *forceFullAnalysis || cfg.Analysis.ForceFull
+
 	// Load recipes (needed for both --robot-recipes and --recipe)
 	recipeLoader, err := recipe.LoadDefault()
 	if err != nil {
@@ -240,12 +391,44 @@ func main() {
 		}
 	}
 
+	// Handle --diff-from with --diff-to: both sides come from files or git, so
+	// this works without a beads project in the current directory
+	if *diffTo != "" {
+		if *diffFrom == "" {
+			fmt.Fprintln(os.Stderr, "Error: --diff-to requires --diff-from")
+			os.Exit(1)
+		}
+		cwd, _ := os.Getwd()
+		from, err := loadDiffSnapshot(cwd, *diffFrom)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffFrom, err)
+			os.Exit(1)
+		}
+		to, err := loadDiffSnapshot(cwd, *diffTo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffTo, err)
+			os.Exit(1)
+		}
+		diff := analysis.CompareSnapshots(from, to)
+		if *robotDiff {
+			writeDiffJSON(diff)
+		} else {
+			printDiffReport(diff, fmt.Sprintf("Changes from %s to %s", *diffFrom, *diffTo))
+		}
+		os.Exit(0)
+	}
+
 	// Load issues from current directory or workspace (with timing for profile)
 	loadStart := time.Now()
 	var issues []model.Issue
 	var beadsPath string
+	var skippedLines []loader.LineError
 	var workspaceInfo *workspace.LoadSummary
 
+	if *workspaceConfig != "" && *markdownDir != "" {
+		fmt.Fprintln(os.Stderr, "Error: --workspace and --markdown-dir can't be combined")
+		os.Exit(1)
+	}
 	if *workspaceConfig != "" {
 		// Load from workspace configuration
 		loadedIssues, results, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
@@ -266,18 +449,45 @@ func main() {
 		}
 		// No live reload for workspace mode (multiple files)
 		beadsPath = ""
+	} else if *markdownDir != "" {
+		res, err := loader.LoadIssuesFromMarkdownDir(*markdownDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading Markdown issues: %v\n", err)
+			os.Exit(1)
+		}
+		issues = res.Issues
+		if len(res.Skipped) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: skipped %d Markdown files\n", len(res.Skipped))
+			for _, skipped := range res.Skipped {
+				fmt.Fprintf(os.Stderr, "  - %v\n", skipped)
+			}
+		}
+		// Read-only (see SetReadOnly below): no live reload, and bd can't
+		// write the files
+		beadsPath = ""
 	} else {
-		// Load from single repo (original behavior)
+		// Load from single repo, streaming with a progress splash for large files
+		cwd, _ := os.Getwd()
 		var err error
-		issues, err = loader.LoadIssues("")
+		beadsPath, err = loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+		if err == nil {
+			splash := loadSplash(beadsPath)
+			var res loader.StreamResult
+			res, err = loader.LoadIssuesFromFileWithProgress(beadsPath, splash)
+			if splash != nil {
+				fmt.Fprint(os.Stderr, "\r\033[K") // Clear the splash line
+			}
+			issues, skippedLines = res.Issues, res.Skipped
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
 			os.Exit(1)
 		}
-		// Get beads file path for live reload
-		cwd, _ := os.Getwd()
-		beadsPath, _ = loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+		if len(skippedLines) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: skipped %d unreadable lines in %s (first: %v)\n",
+				len(skippedLines), filepath.Base(beadsPath), skippedLines[0])
+		}
 	}
 	loadDuration := time.Since(loadStart)
 
@@ -510,6 +720,15 @@ func main() {
 		}
 		plan := analyzer.GetExecutionPlan()
 
+		if formatTmpl != nil {
+			var items []analysis.PlanItem
+			for _, track := range plan.Tracks {
+				items = append(items, track.Items...)
+			}
+			writeFormatted(formatTmpl, items)
+			os.Exit(0)
+		}
+
 		// Wrap with metadata
 		output := struct {
 			GeneratedAt string                 `json:"generated_at"`
@@ -536,6 +755,11 @@ func main() {
 		}
 		recommendations := analyzer.GenerateRecommendations()
 
+		if formatTmpl != nil {
+			writeFormatted(formatTmpl, recommendations)
+			os.Exit(0)
+		}
+
 		// Count high confidence recommendations
 		highConfidence := 0
 		for _, rec := range recommendations {
@@ -570,55 +794,33 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Handle --diff-since flag
-	if *diffSince != "" {
+	// Handle --diff-since flag (and --diff-from against the current issues)
+	if *diffSince != "" || *diffFrom != "" {
+		since := *diffSince
+		if since == "" {
+			since = *diffFrom
+		}
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
 			os.Exit(1)
 		}
 
-		gitLoader := loader.NewGitLoader(cwd)
-
-		// Load historical issues
-		historicalIssues, err := gitLoader.LoadAt(*diffSince)
+		fromSnapshot, err := loadDiffSnapshot(cwd, since)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffSince, err)
+			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", since, err)
 			os.Exit(1)
 		}
-
-		// Get revision info for timestamp
-		revision, err := gitLoader.ResolveRevision(*diffSince)
-		if err != nil {
-			revision = *diffSince
-		}
-
-		// Create snapshots
-		fromSnapshot := analysis.NewSnapshotAt(historicalIssues, time.Time{}, revision)
 		toSnapshot := analysis.NewSnapshot(issues)
 
 		// Compute diff
 		diff := analysis.CompareSnapshots(fromSnapshot, toSnapshot)
 
 		if *robotDiff {
-			// JSON output
-			output := struct {
-				GeneratedAt string                 `json:"generated_at"`
-				Diff        *analysis.SnapshotDiff `json:"diff"`
-			}{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-				Diff:        diff,
-			}
-
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding diff: %v\n", err)
-				os.Exit(1)
-			}
+			writeDiffJSON(diff)
 		} else {
 			// Human-readable output
-			printDiffSummary(diff, *diffSince)
+			printDiffSummary(diff, since)
 		}
 		os.Exit(0)
 	}
@@ -647,6 +849,7 @@ func main() {
 
 		// Launch TUI with historical issues (no live reload for historical view)
 		m := ui.NewModel(historicalIssues, activeRecipe, "")
+		configureModel(&m, cfg)
 		p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 		if _, err := p.Run(); err != nil {
 			fmt.Printf("Error running beads viewer: %v\n", err)
@@ -655,8 +858,21 @@ func main() {
 		os.Exit(0)
 	}
 
-	if *exportFile != "" {
-		fmt.Printf("Exporting to %s...\n", *exportFile)
+	if *exportFile != "" || *exportHTML != "" || *exportSVG != "" || *exportICS != "" || *exportGraphML != "" {
+		exportPath, exportFormat := *exportFile, "markdown"
+		if *exportHTML != "" {
+			exportPath, exportFormat = *exportHTML, "html"
+		}
+		if *exportSVG != "" {
+			exportPath, exportFormat = *exportSVG, "svg"
+		}
+		if *exportICS != "" {
+			exportPath, exportFormat = *exportICS, "ics"
+		}
+		if *exportGraphML != "" {
+			exportPath, exportFormat = *exportGraphML, "graphml"
+		}
+		fmt.Printf("Exporting to %s...\n", exportPath)
 
 		// Load and run pre-export hooks
 		cwd, _ := os.Getwd()
@@ -667,8 +883,8 @@ func main() {
 				fmt.Printf("Warning: failed to load hooks: %v\n", err)
 			} else if hookLoader.HasHooks() {
 				ctx := hooks.ExportContext{
-					ExportPath:   *exportFile,
-					ExportFormat: "markdown",
+					ExportPath:   exportPath,
+					ExportFormat: exportFormat,
 					IssueCount:   len(issues),
 					Timestamp:    time.Now(),
 				}
@@ -683,7 +899,26 @@ func main() {
 		}
 
 		// Perform the export
-		if err := export.SaveMarkdownToFile(issues, *exportFile); err != nil {
+		var err error
+		switch exportFormat {
+		case "html":
+			err = saveHTMLReport(issues, exportPath, filepath.Base(cwd))
+		case "svg":
+			err = saveGraphSVG(issues, exportPath, cfg.Links.IssueURL)
+		case "ics":
+			err = export.SaveICSToFile(issues, filepath.Base(cwd), exportPath)
+		case "graphml":
+			analyzer := analysis.NewAnalyzer(issues)
+			if *forceFullAnalysis {
+				cfg := analysis.FullAnalysisConfig()
+				analyzer.SetConfig(&cfg)
+			}
+			stats := analyzer.Analyze()
+			err = export.SaveGraphMLToFile(issues, &stats, exportPath)
+		default:
+			err = export.SaveMarkdownToFile(issues, exportPath)
+		}
+		if err != nil {
 			fmt.Printf("Error exporting: %v\n", err)
 			os.Exit(1)
 		}
@@ -705,6 +940,21 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --format: print the (recipe-filtered) issues for scripts
+	if formatTmpl != nil {
+		if activeRecipe != nil {
+			issues = applyRecipeFilters(issues, activeRecipe)
+			issues = applyRecipeSort(issues, activeRecipe)
+		}
+		var config *analysis.AnalysisConfig
+		if *forceFullAnalysis {
+			cfg := analysis.FullAnalysisConfig()
+			config = &cfg
+		}
+		writeFormatted(formatTmpl, export.BuildFormatRows(issues, config))
+		os.Exit(0)
+	}
+
 	if len(issues) == 0 {
 		fmt.Println("No issues found. Create some with 'bd create'!")
 		os.Exit(0)
@@ -718,7 +968,28 @@ func main() {
 
 	// Initial Model with live reload support
 	m := ui.NewModel(issues, activeRecipe, beadsPath)
+	configureModel(&m, cfg)
 	defer m.Stop() // Clean up file watcher
+	m.ReportSkippedLines(skippedLines)
+	if *markdownDir != "" {
+		m.SetReadOnly("loaded from Markdown files")
+	}
+	m.SetNotifyAssignee(currentUser(*me, cfg), !*noNotify)
+
+	// The worklog lives with the issues, like the collision pins; Markdown
+	// and workspace loads have no beads file and fall back to cwd
+	worklogDir := projectDir
+	if beadsPath != "" {
+		// beadsPath is <project>/.beads/<file>.jsonl
+		worklogDir = filepath.Dir(filepath.Dir(beadsPath))
+	}
+	if worklogDir != "" {
+		if wl, err := worklog.Load(worklog.DefaultPath(worklogDir)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading worklog: %v\n", err)
+		} else {
+			m.SetWorklog(wl)
+		}
+	}
 
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
@@ -739,10 +1010,1029 @@ func main() {
 	}
 }
 
+// writeFormatted prints items through a --format template, exiting on error
+func writeFormatted[T any](tmpl *template.Template, items []T) {
+	w := bufio.NewWriter(os.Stdout)
+	if err := export.WriteFormatted(w, tmpl, items); err != nil {
+		w.Flush()
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runReport implements "bv report": a digest of what changed over the last
+// day or week, printed or posted to a webhook
+func runReport(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	period := fs.String("period", "daily", "Period to cover: daily or weekly")
+	since := fs.String("since", "", "Start from this git revision or beads file instead of --period")
+	staleDays := fs.Int("stale-days", digest.DefaultStaleDays, "Days without updates before ready work counts as stale")
+	post := fs.String("post", "", "Post the digest to this Slack or generic webhook URL instead of printing it")
+	format := fs.String("format", "", "Output format: markdown (default when printing), slack or json (default when posting: slack for Slack URLs, else json)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+
+	now := time.Now()
+	var from *analysis.Snapshot
+	if *since != "" {
+		from, err = loadDiffSnapshot(cwd, *since)
+	} else {
+		from, err = loadPeriodStart(cwd, *period, now)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading the start of the report: %v\n", err)
+		return 1
+	}
+	d := digest.Build(filepath.Base(cwd), from, analysis.NewSnapshotAt(issues, now, ""), *staleDays)
+
+	if *post != "" {
+		if err := d.Post(context.Background(), *post, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println("Posted digest")
+		return 0
+	}
+
+	switch *format {
+	case "", "markdown":
+		fmt.Print(d.Markdown())
+	case digest.FormatSlack:
+		fmt.Print(d.SlackText())
+	case digest.FormatJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(d)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (want markdown, slack or json)\n", *format)
+		return 2
+	}
+	return 0
+}
+
+// loadPeriodStart loads the issues as committed a day or a week before now
+func loadPeriodStart(dir, period string, now time.Time) (*analysis.Snapshot, error) {
+	var start time.Time
+	switch period {
+	case "daily":
+		start = now.AddDate(0, 0, -1)
+	case "weekly":
+		start = now.AddDate(0, 0, -7)
+	default:
+		return nil, fmt.Errorf("unknown period %q (want daily or weekly)", period)
+	}
+	issues, err := loader.NewGitLoader(dir).LoadAtDate(start)
+	if err != nil {
+		return nil, fmt.Errorf("%w (use --since to pick a starting revision)", err)
+	}
+	return analysis.NewSnapshotAt(issues, start, ""), nil
+}
+
+// runExport implements "bv export <kind>". The only kind so far is
+// "insights": the graph analysis as versioned JSON for other tools.
+func runExport(args []string) int {
+	if len(args) == 0 || args[0] != "insights" {
+		fmt.Fprintln(os.Stderr, "Usage: bv export insights [-o FILE] [--limit N] [--force-full-analysis]")
+		return 2
+	}
+	fs := flag.NewFlagSet("export insights", flag.ContinueOnError)
+	output := fs.String("o", "", "Write to this file instead of stdout")
+	fs.StringVar(output, "output", "", "Same as -o")
+	limit := fs.Int("limit", 0, "Entries per top list (0 = every issue)")
+	forceFull := fs.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+
+	var cfg *analysis.AnalysisConfig
+	if *forceFull {
+		full := analysis.FullAnalysisConfig()
+		cfg = &full
+	}
+	doc := export.BuildInsightsDocument(issues, cfg, *limit, time.Now())
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer out.Close()
+	}
+	if err := export.WriteInsightsJSON(out, doc); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing insights: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runLint implements "bv lint": checks the issue graph against the rules in
+// .bv/lint.yaml and exits 1 on errors (or warnings with --strict), so CI can
+// gate merges on it
+func runLint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	configPath := fs.String("config", "", "Lint rules file (default .bv/lint.yaml)")
+	strict := fs.Bool("strict", false, "Fail on warnings as well as errors")
+	jsonOut := fs.Bool("json", false, "Output findings as JSON")
+	var settings settingFlags
+	fs.Var(&settings, "set", "Override a config setting, e.g. --set wip.in_progress=5 (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	if *configPath == "" {
+		*configPath = lint.ConfigPath(cwd)
+	}
+	config, err := lint.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	cfg, err := loadConfig(settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	config.WIP = cfg.WIP
+
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 2
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 2
+	}
+
+	result := lint.Run(issues, config)
+	if *jsonOut {
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			ExitCode    int    `json:"exit_code"`
+			lint.Result
+		}{time.Now().UTC().Format(time.RFC3339), result.ExitCode(*strict), result}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding lint result: %v\n", err)
+			return 2
+		}
+	} else {
+		fmt.Print(result.Summary())
+	}
+	return result.ExitCode(*strict)
+}
+
+// runBlockers implements `bv blockers`: the open issues holding up the most
+// open work, directly or through a chain, with who owns them
+func runBlockers(args []string) int {
+	fs := flag.NewFlagSet("blockers", flag.ContinueOnError)
+	limit := fs.Int("limit", 10, "How many blockers to list (0 for all)")
+	jsonOut := fs.Bool("json", false, "Output the leaderboard as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 2
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 2
+	}
+
+	board := analysis.BlockerLeaderboard(issues, *limit)
+	if *jsonOut {
+		output := struct {
+			GeneratedAt string                `json:"generated_at"`
+			Blockers    []analysis.TopBlocker `json:"blockers"`
+		}{time.Now().UTC().Format(time.RFC3339), board}
+		if output.Blockers == nil {
+			output.Blockers = []analysis.TopBlocker{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding blockers: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if len(board) == 0 {
+		fmt.Println("No open issue is holding up other open work.")
+		return 0
+	}
+	fmt.Printf("%-4s %-14s %8s %6s %-3s %-14s %s\n", "#", "ID", "HOLDS UP", "DIRECT", "P", "ASSIGNEE", "TITLE")
+	for i, b := range board {
+		assignee := "-"
+		if b.Assignee != "" {
+			assignee = "@" + b.Assignee
+		}
+		fmt.Printf("%-4d %-14s %8d %6d P%-2d %-14s %s\n", i+1, b.ID, b.Downstream, b.Direct, b.Priority, assignee, b.Title)
+	}
+	return 0
+}
+
+// runHistory implements `bv history`: metrics at each commit of the beads
+// file, charted in a TUI or written as CSV or JSON
+func runHistory(args []string) int {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	limit := fs.Int("limit", 200, "How many of the most recent commits to read (0 for all)")
+	by := fs.String("by", "commit", "One point per commit or per day: commit or day")
+	csvOut := fs.Bool("csv", false, "Write the metrics as CSV")
+	jsonOut := fs.Bool("json", false, "Write the metrics as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *by != "commit" && *by != "day" {
+		fmt.Fprintf(os.Stderr, "Error: --by must be commit or day, not %q\n", *by)
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	gitLoader := loader.NewGitLoader(cwd)
+	revisions, err := gitLoader.ListRevisions(*limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading git history: %v\n", err)
+		return 2
+	}
+
+	// git log lists newest first
+	var points []analysis.TrendPoint
+	for i := len(revisions) - 1; i >= 0; i-- {
+		rev := revisions[i]
+		issues, err := gitLoader.LoadAt(rev.SHA)
+		if err != nil {
+			continue // The beads file is unreadable or missing at this commit
+		}
+		points = append(points, analysis.MeasureTrendPoint(issues, rev.Timestamp, rev.SHA))
+	}
+
+	switch {
+	case *csvOut || *jsonOut:
+		if *by == "day" {
+			points = analysis.DailyTrend(points)
+		}
+		if *jsonOut {
+			if points == nil {
+				points = []analysis.TrendPoint{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(points); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding history: %v\n", err)
+				return 1
+			}
+			return 0
+		}
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"date", "revision", "total", "open", "ready", "blocked", "closed", "cycles", "health"})
+		for _, p := range points {
+			w.Write([]string{
+				p.Date.Format(time.RFC3339), p.Revision,
+				fmt.Sprint(p.Total), fmt.Sprint(p.Open), fmt.Sprint(p.Ready), fmt.Sprint(p.Blocked),
+				fmt.Sprint(p.Closed), fmt.Sprint(p.Cycles), fmt.Sprint(p.Health),
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+			return 1
+		}
+		return 0
+	case len(points) == 0:
+		fmt.Println("No commits of the beads file found.")
+		return 0
+	}
+
+	m := ui.NewTrendModel(points, ui.DefaultTheme(lipgloss.DefaultRenderer()))
+	if *by == "day" {
+		m.ToggleByDay()
+	}
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running history: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runWhatIf implements `bv what-if`: how filing a new issue with the given
+// links would change the critical path, ready work and epic forecasts
+func runWhatIf(args []string) int {
+	fs := flag.NewFlagSet("what-if", flag.ContinueOnError)
+	id := fs.String("id", "new", "ID to give the hypothetical issue")
+	title := fs.String("title", "", "Title of the hypothetical issue")
+	priority := fs.Int("priority", 2, "Priority of the hypothetical issue (0-4)")
+	parent := fs.String("parent", "", "Epic or issue it would go under")
+	blockedBy := fs.String("blocked-by", "", "Comma-separated issues it would wait on")
+	blocks := fs.String("blocks", "", "Comma-separated issues that would wait on it")
+	jsonOut := fs.Bool("json", false, "Output the comparison as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 2
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 2
+	}
+
+	splitIDs := func(list string) []string {
+		var ids []string
+		for _, id := range strings.Split(list, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	h := analysis.Hypothetical{
+		ID:        *id,
+		Title:     *title,
+		Priority:  *priority,
+		Parent:    *parent,
+		BlockedBy: splitIDs(*blockedBy),
+		Blocks:    splitIDs(*blocks),
+	}
+	result, err := analysis.SimulateNewIssue(issues, h, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if *jsonOut {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding what-if: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	printWhatIf(result, h)
+	return 0
+}
+
+// printWhatIf prints a what-if comparison for people
+func printWhatIf(r *analysis.WhatIfResult, h analysis.Hypothetical) {
+	title := fmt.Sprintf("What if %s", h.ID)
+	if h.Title != "" {
+		title += fmt.Sprintf(" %q", h.Title)
+	}
+	title += fmt.Sprintf(" (P%d) were filed", h.Priority)
+	fmt.Println(title)
+	fmt.Println("=" + repeatChar('=', len(title)))
+	if h.Parent != "" {
+		fmt.Printf("  under %s\n", h.Parent)
+	}
+	if len(h.BlockedBy) > 0 {
+		fmt.Printf("  waiting on %s\n", strings.Join(h.BlockedBy, ", "))
+	}
+	if len(h.Blocks) > 0 {
+		fmt.Printf("  blocking %s\n", strings.Join(h.Blocks, ", "))
+	}
+	fmt.Println()
+
+	path := func(ids []string) string {
+		if len(ids) == 0 {
+			return "(none)"
+		}
+		return strings.Join(ids, " → ")
+	}
+	fmt.Printf("Critical path: %d → %d issues\n", len(r.Before.CriticalPath), len(r.After.CriticalPath))
+	fmt.Printf("  before: %s\n", path(r.Before.CriticalPath))
+	fmt.Printf("  after:  %s\n", path(r.After.CriticalPath))
+	fmt.Printf("Ready work: %d → %d\n\n", r.Before.Ready, r.After.Ready)
+
+	if len(r.After.Epics) == 0 {
+		fmt.Println("No open epics to forecast.")
+		return
+	}
+	if r.Throughput > 0 {
+		fmt.Printf("Epic forecasts, at %.1f issues closed a day:\n", r.Throughput)
+	} else {
+		fmt.Println("Epic forecasts (nothing closed in the last two weeks, so no dates):")
+	}
+	date := func(t *time.Time) string {
+		if t == nil {
+			return "-"
+		}
+		return timefmt.Date(*t)
+	}
+	before := make(map[string]analysis.EpicForecast, len(r.Before.Epics))
+	for _, f := range r.Before.Epics {
+		before[f.ID] = f
+	}
+	unchanged := 0
+	for _, f := range r.After.Epics {
+		b := before[f.ID]
+		if b.Remaining == f.Remaining {
+			unchanged++
+			continue
+		}
+		line := fmt.Sprintf("  %s %s: %d → %d remaining, %s → %s", f.ID, f.Title, b.Remaining, f.Remaining, date(b.Projected), date(f.Projected))
+		if b.Projected != nil && f.Projected != nil {
+			line += fmt.Sprintf(" (+%d days)", int(f.Projected.Sub(*b.Projected).Hours()/24))
+		}
+		fmt.Println(line)
+	}
+	if unchanged > 0 {
+		fmt.Printf("  %d other epic(s) unchanged\n", unchanged)
+	}
+}
+
+// settingFlags collects repeated --set KEY=VALUE flags
+type settingFlags []string
+
+func (s *settingFlags) String() string { return strings.Join(*s, ",") }
+
+func (s *settingFlags) Set(v string) error {
+	if !strings.Contains(v, "=") {
+		return fmt.Errorf("expected KEY=VALUE, got %q", v)
+	}
+	*s = append(*s, v)
+	return nil
+}
+
+// loadConfig reads the layered configuration for the current directory and
+// applies --set overrides on top
+func loadConfig(settings settingFlags) (*config.Config, error) {
+	cwd, _ := os.Getwd()
+	cfg, err := config.Load(cwd)
+	if err != nil {
+		return nil, err
+	}
+	for _, kv := range settings {
+		key, value, _ := strings.Cut(kv, "=")
+		if err := cfg.Set(strings.TrimSpace(key), value); err != nil {
+			return nil, fmt.Errorf("--set: %w", err)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyConfig installs the process-wide settings: the theme palette and
+// character widths, time formats, when to run full graph analysis, the
+// impact score weights, WIP limits and custom dependency types
+func applyConfig(cfg *config.Config) {
+	ui.SetThemeOverrides(cfg.Theme.Mode, cfg.Theme.Colors)
+	_ = ui.SetAmbiguousWidth(cfg.View.AmbiguousWidth) // Validated when the config was loaded
+	_ = timefmt.Set(cfg.Time)
+	if cfg.Analysis.ForceFull {
+		analysis.SetFullAnalysisThreshold(math.MaxInt64)
+	} else {
+		analysis.SetFullAnalysisThreshold(int64(cfg.Analysis.FullBelowNodes))
+	}
+	// Validate already ran, so the weights are usable
+	_ = analysis.SetImpactWeights(cfg.Impact)
+	analysis.SetWIPLimits(cfg.WIP)
+	analysis.SetTeams(cfg.Teams)
+	model.SetCustomDependencyTypes(cfg.DependencyTypes)
+}
+
+// configureModel applies the per-view settings to a new TUI model
+func configureModel(m *ui.Model, cfg *config.Config) {
+	m.SetKeyTranslation(cfg.KeyTranslation())
+	m.SetListColumns(cfg.View.Columns)
+	_ = m.SetDensity(cfg.View.Density) // Validated when the config was loaded
+	m.SetCardLines(cfg.View.CardLines)
+	_ = m.SetDefaultView(cfg.View.Default)       // Validated when the config was loaded
+	_ = m.SetImagePreview(cfg.View.ImagePreview) // Validated when the config was loaded
+	if err := m.SetIssueURLTemplate(cfg.Links.IssueURL); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	var plugins []ui.Plugin
+	for _, name := range cfg.PluginNames() {
+		p := cfg.Plugins[name]
+		plugins = append(plugins, ui.Plugin{Name: name, Key: p.Key, Command: p.Command, Output: p.Output, Timeout: p.Timeout})
+	}
+	m.SetPlugins(plugins)
+	var templates []ui.IssueTemplate
+	for _, name := range cfg.TemplateNames() {
+		t := cfg.Templates[name]
+		templates = append(templates, ui.IssueTemplate{Name: name, Title: t.Title, Type: t.Type, Priority: t.Priority,
+			Labels: t.Labels, Assignee: t.Assignee, Description: t.Description, Checklist: t.Checklist, Fields: t.Fields})
+	}
+	m.SetIssueTemplates(templates)
+	m.SetScripts(cfg.ScriptEngine())
+	m.SetCustomFields(cfg.Fields.Columns, cfg.Fields.Details)
+	if err := m.SetFileWatch(cfg.Watch.Mode, cfg.Watch.PollInterval, cfg.Watch.Hash); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if path := layoutPath(); path != "" {
+		m.SetLayout(ui.LoadLayout(path), path)
+	}
+}
+
+// layoutPath returns where the TUI remembers pane sizes: layout.json next
+// to the user config file, or "" if there is no user config directory
+func layoutPath() string {
+	user := config.UserPath()
+	if user == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(user), "layout.json")
+}
+
+// runConfig implements "bv config show" and "bv config init"
+func runConfig(args []string) int {
+	usage := "Usage: bv config show [--set KEY=VALUE]... | bv config init [--user] [--force]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "show":
+		fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+		var settings settingFlags
+		fs.Var(&settings, "set", "Override a setting, as on the main command")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		cfg, err := loadConfig(settings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		cwd, _ := os.Getwd()
+		fmt.Printf("# Effective configuration\n# User file:    %s\n# Project file: %s\n\n", config.UserPath(), config.ProjectPath(cwd))
+		if err := cfg.Write(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "init":
+		fs := flag.NewFlagSet("config init", flag.ContinueOnError)
+		user := fs.Bool("user", false, "Write the user config file instead of the project's .beads_viewer.toml")
+		force := fs.Bool("force", false, "Overwrite an existing file")
+		if err := fs.Parse(args[1:]); err != nil {
+			return 2
+		}
+		cwd, _ := os.Getwd()
+		path := config.ProjectPath(cwd)
+		if *user {
+			if path = config.UserPath(); path == "" {
+				fmt.Fprintln(os.Stderr, "Error: can't locate the user config directory")
+				return 1
+			}
+		}
+		if _, err := os.Stat(path); err == nil && !*force {
+			fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", path)
+			return 1
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(path, []byte(config.Template), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote %s\n", path)
+		return 0
+	}
+	fmt.Fprintln(os.Stderr, usage)
+	return 2
+}
+
+// saveHTMLReport writes the static HTML report for issues to path
+func saveHTMLReport(issues []model.Issue, path, project string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := web.WriteReport(f, issues, project); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// saveGraphSVG draws the graph to path, linking nodes through the
+// configured issue URL template if there is one
+func saveGraphSVG(issues []model.Issue, path, issueURL string) error {
+	tmpl, err := ui.ParseIssueURLTemplate(issueURL)
+	if err != nil {
+		return err
+	}
+	var href func(model.Issue) string
+	if tmpl != nil {
+		href = func(issue model.Issue) string {
+			url, _ := ui.IssueURL(tmpl, issue) // A node without a link beats no image
+			return url
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	omitted, err := web.WriteGraphSVG(f, issues, false, href)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if omitted > 0 {
+		fmt.Printf("Note: %d lower-impact issues were left out of the graph\n", omitted)
+	}
+	return f.Close()
+}
+
+// runReleaseNotes implements "bv release-notes": Markdown notes for the
+// issues closed between two git revisions (usually tags) or dates
+func runReleaseNotes(args []string) int {
+	fs := flag.NewFlagSet("release-notes", flag.ContinueOnError)
+	from := fs.String("from", "", "Start: a git tag or revision, or a date (YYYY-MM-DD)")
+	to := fs.String("to", "", "End: a git tag or revision, or a date, inclusive (default now)")
+	tmplPath := fs.String("template", "", "Go text/template file to render with (default .bv/"+export.ReleaseNotesTemplateFile+" if present)")
+	title := fs.String("title", "", "Title of the notes (default \"Release notes\", or the --to tag)")
+	output := fs.String("o", "", "Write the notes to this file instead of stdout")
+	fs.StringVar(output, "output", "", "Same as -o")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *from == "" {
+		fmt.Fprintln(os.Stderr, "Error: --from is required")
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+
+	since, err := releaseBound(cwd, *from, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --from: %v\n", err)
+		return 1
+	}
+	until := time.Now()
+	if *to != "" {
+		if until, err = releaseBound(cwd, *to, true); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --to: %v\n", err)
+			return 1
+		}
+	}
+
+	tmplText := ""
+	path := *tmplPath
+	if path == "" {
+		candidate := filepath.Join(cwd, ".bv", export.ReleaseNotesTemplateFile)
+		if _, err := os.Stat(candidate); err == nil {
+			path = candidate
+		}
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading template: %v\n", err)
+			return 1
+		}
+		tmplText = string(data)
+	}
+
+	notes := export.BuildReleaseNotes(issues, since, until)
+	notes.From, notes.To = *from, *to
+	if notes.To == "" {
+		notes.To = until.Format("2006-01-02")
+	}
+	notes.Title = *title
+	if notes.Title == "" {
+		notes.Title = "Release notes"
+		if *to != "" {
+			notes.Title += " " + *to
+		}
+	}
+	text, err := export.RenderReleaseNotes(notes, tmplText)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		fmt.Print(text)
+		return 0
+	}
+	if err := os.WriteFile(*output, []byte(text), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *output, err)
+		return 1
+	}
+	fmt.Printf("Wrote release notes for %d issues to %s\n", notes.Total, *output)
+	return 0
+}
+
+// releaseBound turns a --from/--to value into a time. Dates cover whole
+// days, so a date --to ends at the following midnight; anything else is a
+// git revision, taken at its commit time.
+func releaseBound(dir, value string, end bool) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		t, err := time.ParseInLocation(layout, value, time.Local)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" && end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	info, err := loader.NewGitLoader(dir).DescribeRevision(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		// Include issues closed in the tagged commit itself
+		return info.Timestamp.Add(time.Second), nil
+	}
+	return info.Timestamp, nil
+}
+
+// currentUser picks whose issues are "mine", for the @ toggle and unblock
+// alerts: --me, then user.name from the config, then $BD_ACTOR like bd's
+// --actor, then git config user.name through user.git_names, then $USER
+func currentUser(flagValue string, cfg *config.Config) string {
+	cwd, _ := os.Getwd()
+	gitName := cfg.User.GitNames[loader.GitUserName(cwd)]
+	for _, name := range []string{flagValue, cfg.User.Name, os.Getenv("BD_ACTOR"), gitName, os.Getenv("USER"), os.Getenv("USERNAME")} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// runServe implements "bv serve": a read-only web view of the current project
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address to listen on (use :8080 to accept connections from other machines)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           web.New(beadsPath).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Printf("Serving %s at http://%s (Ctrl+C to stop)\n", beadsPath, displayAddr(*addr))
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runServeSSH implements "bv serve-ssh": the TUI served over SSH, one
+// session per connection, so a team can share one install on a server
+func runServeSSH(args []string) int {
+	fs := flag.NewFlagSet("serve-ssh", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:23234", "Address to listen on (use :23234 to accept connections from other machines)")
+	hostKey := fs.String("host-key", "", "SSH host key, created if missing (default bv/ssh_host_ed25519 in the user config dir)")
+	authorizedKeys := fs.String("authorized-keys", "", "Only accept public keys listed in this authorized_keys file (required off loopback)")
+	insecure := fs.Bool("insecure-allow-any", false, "Accept any public key even when listening beyond loopback")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if err := checkSSHAuth(*addr, *authorizedKeys, *insecure); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	cwd, _ := os.Getwd()
+	beadsPath, err := loader.FindJSONLPath(filepath.Join(cwd, ".beads"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
+		fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
+		return 1
+	}
+	cfg, err := loadConfig(nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	applyConfig(cfg)
+	if *hostKey == "" {
+		// Kept out of the project so the private key can't end up committed
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v (pass --host-key)\n", err)
+			return 1
+		}
+		*hostKey = filepath.Join(configDir, "bv", "ssh_host_ed25519")
+	}
+
+	server, err := newSSHServer(*addr, *hostKey, *authorizedKeys, beadsPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	host, port := displayAddr(*addr), ""
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host, port = host[:i], host[i+1:]
+	}
+	fmt.Printf("Serving %s over SSH: ssh -p %s %s (Ctrl+C to stop)\n", beadsPath, port, host)
+	if *authorizedKeys == "" {
+		fmt.Println("Warning: no --authorized-keys given, so anyone who can reach the port can connect")
+	}
+	if err := server.ListenAndServe(); err != nil && err != ssh.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// checkSSHAuth refuses to let "bv serve-ssh" accept any key on an address
+// other machines can reach, unless that was asked for explicitly
+func checkSSHAuth(addr, authorizedKeysPath string, insecure bool) error {
+	if authorizedKeysPath != "" || insecure {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid --addr %q: %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("listening on %s would let anyone connect: pass --authorized-keys, or --insecure-allow-any to accept any key", addr)
+}
+
+// newSSHServer creates the SSH server behind "bv serve-ssh". Each connection
+// gets its own model, styled for the client's terminal and sized to its pty;
+// sessions can't change the issues.
+func newSSHServer(addr, hostKeyPath, authorizedKeysPath, beadsPath string) (*ssh.Server, error) {
+	opts := []ssh.Option{
+		wish.WithAddress(addr),
+		wish.WithHostKeyPath(hostKeyPath),
+		wish.WithMiddleware(
+			wishtea.Middleware(sshSessionHandler(beadsPath)),
+			activeterm.Middleware(),
+			logging.Middleware(),
+		),
+	}
+	if authorizedKeysPath != "" {
+		if _, err := os.Stat(authorizedKeysPath); err != nil {
+			return nil, fmt.Errorf("authorized keys: %w", err)
+		}
+		opts = append(opts, wish.WithAuthorizedKeys(authorizedKeysPath))
+	}
+	return wish.NewServer(opts...)
+}
+
+// sshSessionHandler starts the TUI for one SSH session, reading the beads
+// file afresh so each connection sees the current issues
+func sshSessionHandler(beadsPath string) wishtea.Handler {
+	return func(sess ssh.Session) (tea.Model, []tea.ProgramOption) {
+		issues, err := loader.LoadIssuesFromFile(beadsPath)
+		if err != nil {
+			wish.Fatalln(sess, "Error loading beads:", err)
+			return nil, nil
+		}
+
+		m := ui.NewModelWithRenderer(issues, nil, beadsPath, wishtea.MakeRenderer(sess))
+		// Re-read settings too, so edits apply from the next connection
+		if cfg, err := config.Load(filepath.Dir(filepath.Dir(beadsPath))); err == nil {
+			configureModel(&m, cfg)
+		}
+		// Connections start from the host's layout but don't overwrite it
+		m.SetLayout(m.Layout(), "")
+		m.SetRemoteTerminal(sess)
+		m.SetNotifyAssignee(sess.User(), false) // Status bar alerts for the SSH user's issues
+		go func() {
+			<-sess.Context().Done()
+			m.Stop() // Clean up this session's file watcher
+		}()
+		return m, []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	}
+}
+
+// displayAddr turns a listen address like ":8080" into one a browser can open
+func displayAddr(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+	return addr
+}
+
+// loadDiffSnapshot loads one side of a diff: the JSONL file at spec if there
+// is one, otherwise the beads file at git revision spec in the repo at dir
+func loadDiffSnapshot(dir, spec string) (*analysis.Snapshot, error) {
+	if info, err := os.Stat(spec); err == nil && !info.IsDir() {
+		issues, err := loader.LoadIssuesFromFile(spec)
+		if err != nil {
+			return nil, err
+		}
+		return analysis.NewSnapshotAt(issues, info.ModTime(), spec), nil
+	}
+
+	gitLoader := loader.NewGitLoader(dir)
+	issues, err := gitLoader.LoadAt(spec)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := gitLoader.DescribeRevision(spec)
+	if err != nil {
+		return analysis.NewSnapshotAt(issues, time.Time{}, spec), nil
+	}
+	return analysis.NewSnapshotAt(issues, commit.Timestamp, commit.SHA), nil
+}
+
+// writeDiffJSON prints a diff for --robot-diff
+func writeDiffJSON(diff *analysis.SnapshotDiff) {
+	output := struct {
+		GeneratedAt string                 `json:"generated_at"`
+		Diff        *analysis.SnapshotDiff `json:"diff"`
+	}{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Diff:        diff,
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding diff: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 // printDiffSummary prints a human-readable diff summary
 func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
-	fmt.Printf("Changes since %s\n", since)
-	fmt.Println("=" + repeatChar('=', len("Changes since "+since)))
+	printDiffReport(diff, "Changes since "+since)
+}
+
+// printDiffReport prints a human-readable diff under title
+func printDiffReport(diff *analysis.SnapshotDiff, title string) {
+	fmt.Println(title)
+	fmt.Println("=" + repeatChar('=', len(title)))
 	fmt.Println()
 
 	// Health trend
@@ -778,6 +2068,12 @@ func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 	if diff.Summary.CyclesResolved > 0 {
 		fmt.Printf("  ✓ %d cycles resolved\n", diff.Summary.CyclesResolved)
 	}
+	if diff.Summary.DependenciesAdded > 0 {
+		fmt.Printf("  + %d dependencies added\n", diff.Summary.DependenciesAdded)
+	}
+	if diff.Summary.DependenciesRemoved > 0 {
+		fmt.Printf("  - %d dependencies removed\n", diff.Summary.DependenciesRemoved)
+	}
 	fmt.Println()
 
 	// New issues
@@ -834,6 +2130,18 @@ func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 		fmt.Println()
 	}
 
+	// Dependency changes (show first 10 of each)
+	printDependencyChanges("Added Dependencies:", "+", diff.AddedDependencies)
+	printDependencyChanges("Removed Dependencies:", "-", diff.RemovedDependencies)
+
+	// Ready work and critical path
+	fmt.Printf("Ready Work: %d → %d\n", diff.FromReadyCount, diff.ToReadyCount)
+	fmt.Printf("Critical Path: %d → %d issues\n", len(diff.FromCriticalPath), len(diff.ToCriticalPath))
+	if len(diff.ToCriticalPath) > 0 {
+		fmt.Printf("  %s\n", strings.Join(diff.ToCriticalPath, " → "))
+	}
+	fmt.Println()
+
 	// Metric deltas
 	fmt.Println("Metric Changes:")
 	if diff.MetricDeltas.TotalIssues != 0 {
@@ -850,6 +2158,26 @@ func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 	}
 }
 
+// printDependencyChanges lists up to 10 dependency edges under a heading
+func printDependencyChanges(heading, marker string, changes []analysis.DependencyChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Println(heading)
+	for i, c := range changes {
+		if i >= 10 {
+			fmt.Printf("  ... and %d more\n", len(changes)-10)
+			break
+		}
+		depType := c.Type
+		if depType == "" {
+			depType = "blocks"
+		}
+		fmt.Printf("  %s %s → %s (%s)\n", marker, c.IssueID, c.DependsOnID, depType)
+	}
+	fmt.Println()
+}
+
 // repeatChar creates a string of n repeated characters
 func repeatChar(c rune, n int) string {
 	result := make([]rune, n)
@@ -995,7 +2323,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		if f.HasBlockers != nil {
 			hasOpenBlockers := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+				if dep.Type.IsBlocking() && openBlockers[dep.DependsOnID] {
 					hasOpenBlockers = true
 					break
 				}
@@ -1009,7 +2337,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		if f.Actionable != nil && *f.Actionable {
 			hasOpenBlockers := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+				if dep.Type.IsBlocking() && openBlockers[dep.DependsOnID] {
 					hasOpenBlockers = true
 					break
 				}
@@ -1168,6 +2496,7 @@ func printProfileReport(profile *analysis.StartupProfile, loadDuration, totalWit
 	printMetricLine("Betweenness", profile.Betweenness, profile.BetweennessTO, profile.Config.ComputeBetweenness)
 	printMetricLine("Eigenvector", profile.Eigenvector, false, profile.Config.ComputeEigenvector)
 	printMetricLine("HITS", profile.HITS, profile.HITSTO, profile.Config.ComputeHITS)
+	printMetricLine("Closeness", profile.Closeness, profile.ClosenessTO, profile.Config.ComputeCloseness)
 	printMetricLine("Critical Path", profile.CriticalPath, false, profile.Config.ComputeCriticalPath)
 	printCyclesLine(profile)
 	fmt.Printf("  Total Phase 2:   %v\n\n", formatDuration(profile.Phase2))
@@ -1378,3 +2707,23 @@ func buildMetricItems(metrics map[string]float64, limit int) []baseline.MetricIt
 
 	return items
 }
+
+// splashMinBytes is the file size above which loading shows a progress splash
+const splashMinBytes = 2 * 1024 * 1024
+
+// loadSplash returns a progress callback that redraws a one-line loading
+// indicator on stderr, or nil when stderr isn't a terminal or the file is
+// small enough to load instantly
+func loadSplash(path string) func(loader.Progress) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < splashMinBytes {
+		return nil
+	}
+	if fi, err := os.Stderr.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	name := filepath.Base(path)
+	return func(p loader.Progress) {
+		fmt.Fprintf(os.Stderr, "\r\033[KLoading %s… %3.0f%% · %d issues", name, p.Fraction()*100, p.Issues)
+	}
+}
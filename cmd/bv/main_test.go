@@ -1,15 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+
+	"github.com/charmbracelet/wish/testsession"
 )
 
 func TestFilterByRepo_CaseInsensitiveAndFlexibleSeparators(t *testing.T) {
@@ -256,3 +262,121 @@ func repoRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestLoadDiffSnapshotFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	before := filepath.Join(dir, "before.jsonl")
+	after := filepath.Join(dir, "after.jsonl")
+	if err := os.WriteFile(before, []byte(`{"id":"A","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Child","status":"open","priority":2,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(after, []byte(`{"id":"A","title":"Root","status":"closed","priority":1,"issue_type":"task"}
+{"id":"B","title":"Child","status":"open","priority":2,"issue_type":"task"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	from, err := loadDiffSnapshot(dir, before)
+	if err != nil {
+		t.Fatalf("load before: %v", err)
+	}
+	to, err := loadDiffSnapshot(dir, after)
+	if err != nil {
+		t.Fatalf("load after: %v", err)
+	}
+	if from.Revision != before || from.Timestamp.IsZero() {
+		t.Errorf("expected file snapshot labelled by path with its mtime, got %q at %v", from.Revision, from.Timestamp)
+	}
+
+	diff := analysis.CompareSnapshots(from, to)
+	out := captureStdout(t, func() {
+		printDiffReport(diff, "Changes from before to after")
+	})
+	for _, s := range []string{"Changes from before to after", "Removed Dependencies:", "- B → A (blocks)", "Ready Work: 1 → 1", "Critical Path: 2 → 1 issues"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("diff report missing %q:\n%s", s, out)
+		}
+	}
+
+	if _, err := loadDiffSnapshot(dir, "no-such-revision"); err == nil {
+		t.Error("expected an error for a spec that is neither a file nor a revision")
+	}
+}
+
+func TestServeSSHSessionShowsIssues(t *testing.T) {
+	dir := t.TempDir()
+	beadsPath := filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beadsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"SSH-1","title":"Remote visible issue","status":"open","priority":1,"issue_type":"task"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := newSSHServer("", filepath.Join(dir, ".bv", "ssh_host_ed25519"), "", beadsPath)
+	if err != nil {
+		t.Fatalf("newSSHServer: %v", err)
+	}
+	sess := testsession.New(t, srv, nil)
+	defer sess.Close()
+	if err := sess.RequestPty("xterm-256color", 40, 120, nil); err != nil {
+		t.Fatalf("request pty: %v", err)
+	}
+	out := &syncBuffer{}
+	sess.Stdout = out
+	if err := sess.Shell(); err != nil {
+		t.Fatalf("shell: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), "Remote visible issue") {
+		if time.Now().After(deadline) {
+			t.Fatalf("issue never rendered over SSH; got:\n%q", out.String())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if _, err := newSSHServer("", filepath.Join(dir, "key"), filepath.Join(dir, "missing"), beadsPath); err == nil {
+		t.Error("expected an error for a missing authorized keys file")
+	}
+}
+
+func TestCheckSSHAuth(t *testing.T) {
+	tests := []struct {
+		addr, keys string
+		insecure   bool
+		ok         bool
+	}{
+		{"127.0.0.1:23234", "", false, true},
+		{"localhost:23234", "", false, true},
+		{"[::1]:23234", "", false, true},
+		{":23234", "", false, false},
+		{"0.0.0.0:23234", "", false, false},
+		{"10.0.0.5:23234", "", false, false},
+		{":23234", "/home/me/.ssh/authorized_keys", false, true},
+		{":23234", "", true, true},
+	}
+	for _, tt := range tests {
+		if err := checkSSHAuth(tt.addr, tt.keys, tt.insecure); (err == nil) != tt.ok {
+			t.Errorf("checkSSHAuth(%q, %q, %v) = %v, want ok=%v", tt.addr, tt.keys, tt.insecure, err, tt.ok)
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while the SSH client writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ActivityKind is what happened in an activity event
+type ActivityKind string
+
+const (
+	ActivityCreated   ActivityKind = "created"
+	ActivityStarted   ActivityKind = "started" // Moved to in_progress
+	ActivityCommented ActivityKind = "commented"
+	ActivityClosed    ActivityKind = "closed"
+	ActivityUpdated   ActivityKind = "updated" // A later change no other event explains
+)
+
+// ActivityEvent is one entry in the activity feed
+type ActivityEvent struct {
+	Time    time.Time    `json:"time"`
+	Kind    ActivityKind `json:"kind"`
+	IssueID string       `json:"issue_id"`
+	Title   string       `json:"title"`
+	Actor   string       `json:"actor,omitempty"`  // Comment author, otherwise the assignee
+	Detail  string       `json:"detail,omitempty"` // First line of a comment, or the status after an update
+}
+
+// updateSlack is how close an update must be to another event to count as
+// part of it
+const updateSlack = time.Minute
+
+// BuildActivityFeed reconstructs project activity from issue timestamps
+// and comments, newest first. Beads keeps no change log, so status changes
+// other than starting and closing show up as an "updated" event at the
+// issue's last update. Events before since are dropped; a zero since keeps
+// everything.
+func BuildActivityFeed(issues []model.Issue, since time.Time) []ActivityEvent {
+	var events []ActivityEvent
+	add := func(at time.Time, kind ActivityKind, issue model.Issue, actor, detail string) {
+		if at.IsZero() || at.Before(since) {
+			return
+		}
+		events = append(events, ActivityEvent{Time: at, Kind: kind, IssueID: issue.ID, Title: issue.Title, Actor: actor, Detail: detail})
+	}
+
+	for _, issue := range issues {
+		latest := issue.CreatedAt
+		add(issue.CreatedAt, ActivityCreated, issue, issue.Assignee, "")
+		if issue.StartedAt != nil {
+			add(*issue.StartedAt, ActivityStarted, issue, issue.Assignee, "")
+			latest = laterOf(latest, *issue.StartedAt)
+		}
+		for _, c := range issue.Comments {
+			if c == nil {
+				continue
+			}
+			text, _, _ := strings.Cut(strings.TrimSpace(c.Text), "\n")
+			add(c.CreatedAt, ActivityCommented, issue, c.Author, text)
+			latest = laterOf(latest, c.CreatedAt)
+		}
+		if issue.ClosedAt != nil && issue.Status.IsClosed() {
+			add(*issue.ClosedAt, ActivityClosed, issue, issue.Assignee, "")
+			latest = laterOf(latest, *issue.ClosedAt)
+		}
+		if issue.UpdatedAt.Sub(latest) > updateSlack {
+			add(issue.UpdatedAt, ActivityUpdated, issue, issue.Assignee, string(issue.Status))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Time.Equal(events[j].Time) {
+			return events[i].Time.After(events[j].Time)
+		}
+		return events[i].IssueID < events[j].IssueID
+	})
+	return events
+}
+
+func laterOf(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// ActivityActors returns the actors in events, most active first. Events
+// with no actor are left out.
+func ActivityActors(events []ActivityEvent) []string {
+	counts := make(map[string]int)
+	for _, e := range events {
+		if e.Actor != "" {
+			counts[e.Actor]++
+		}
+	}
+	actors := make([]string, 0, len(counts))
+	for a := range counts {
+		actors = append(actors, a)
+	}
+	sort.Slice(actors, func(i, j int) bool {
+		if counts[actors[i]] != counts[actors[j]] {
+			return counts[actors[i]] > counts[actors[j]]
+		}
+		return actors[i] < actors[j]
+	})
+	return actors
+}
@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildActivityFeed(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	hoursAgo := func(h int) time.Time { return now.Add(-time.Duration(h) * time.Hour) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	issues := []model.Issue{
+		{ID: "A", Title: "done", Assignee: "ann", Status: model.StatusClosed,
+			CreatedAt: hoursAgo(10), StartedAt: ptr(hoursAgo(8)), ClosedAt: ptr(hoursAgo(2)), UpdatedAt: hoursAgo(2)},
+		{ID: "B", Title: "discussed", Status: model.StatusOpen, CreatedAt: hoursAgo(9), UpdatedAt: hoursAgo(4),
+			Comments: []*model.Comment{{Author: "bob", Text: "first line\nsecond", CreatedAt: hoursAgo(4)}}},
+		{ID: "C", Title: "blocked later", Assignee: "cat", Status: model.StatusBlocked, CreatedAt: hoursAgo(30), UpdatedAt: hoursAgo(1)},
+	}
+
+	var got []string
+	for _, e := range BuildActivityFeed(issues, time.Time{}) {
+		got = append(got, e.IssueID+":"+string(e.Kind)+":"+e.Actor+":"+e.Detail)
+	}
+	want := []string{
+		"C:updated:cat:blocked",
+		"A:closed:ann:",
+		"B:commented:bob:first line",
+		"A:started:ann:",
+		"B:created::",
+		"A:created:ann:",
+		"C:created:cat:",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("feed = %v, want %v", got, want)
+	}
+
+	recent := BuildActivityFeed(issues, hoursAgo(3))
+	if len(recent) != 2 || recent[1].Kind != ActivityClosed {
+		t.Fatalf("expected the update and close since 3h ago, got %+v", recent)
+	}
+
+	if actors := ActivityActors(BuildActivityFeed(issues, time.Time{})); strings.Join(actors, ",") != "ann,cat,bob" {
+		t.Fatalf("actors = %v", actors)
+	}
+}
@@ -0,0 +1,72 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// AgingItem is an in-progress issue and how long it has been in progress
+type AgingItem struct {
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	Priority int           `json:"priority"`
+	Assignee string        `json:"assignee,omitempty"`
+	Since    time.Time     `json:"since"`
+	Age      time.Duration `json:"age"`
+
+	// Approximate is set when the issue has no started_at or in_progress
+	// history entry, so Since is its last update
+	Approximate bool `json:"approximate,omitempty"`
+}
+
+// AgingWIP is the data behind an aging-WIP chart: the work in progress by
+// age, against how long finished work took
+type AgingWIP struct {
+	Items []AgingItem `json:"items"` // Oldest first
+
+	// Cycle summarizes the cycle times of closed issues; its P50 and P85
+	// are the chart's reference lines. Count is 0 when none are known.
+	Cycle DurationStats `json:"cycle"`
+}
+
+// ComputeAgingWIP ages every in-progress issue from when it first went in
+// progress, the same start cycle time uses, so an age past Cycle.P85 means
+// the issue has already taken longer than 85% of finished work did.
+func ComputeAgingWIP(issues []model.Issue, now time.Time) AgingWIP {
+	var a AgingWIP
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status != model.StatusInProgress {
+			continue
+		}
+		item := AgingItem{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Assignee: issue.Assignee,
+		}
+		if started := startedTime(issue); started != nil {
+			item.Since = *started
+		} else {
+			item.Since, item.Approximate = issue.UpdatedAt, true
+		}
+		item.Age = max(now.Sub(item.Since), 0)
+		a.Items = append(a.Items, item)
+	}
+	sort.SliceStable(a.Items, func(i, j int) bool {
+		if a.Items[i].Age != a.Items[j].Age {
+			return a.Items[i].Age > a.Items[j].Age
+		}
+		return a.Items[i].ID < a.Items[j].ID
+	})
+	a.Cycle = ComputeFlowMetrics(issues).Cycle
+	return a
+}
+
+// Overdue reports whether item has been in progress longer than 85% of
+// finished work took. Always false when no cycle times are known.
+func (a AgingWIP) Overdue(item AgingItem) bool {
+	return a.Cycle.Count > 0 && item.Age > a.Cycle.P85
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeAgingWIP(t *testing.T) {
+	now := time.Date(2025, 6, 20, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	at := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	issues := []model.Issue{
+		{ID: "old", Status: model.StatusInProgress, Priority: 1, StartedAt: at(10 * day), CreatedAt: now.Add(-20 * day)},
+		{ID: "new", Status: model.StatusInProgress, Priority: 0, StartedAt: at(day), CreatedAt: now.Add(-2 * day)},
+		// Started per its history, then sent back to open and picked up again
+		{ID: "bounced", Status: model.StatusInProgress, Priority: 2, CreatedAt: now.Add(-9 * day),
+			StatusHistory: []model.StatusChange{
+				{Status: model.StatusInProgress, At: now.Add(-6 * day)},
+				{Status: model.StatusOpen, At: now.Add(-5 * day)},
+				{Status: model.StatusInProgress, At: now.Add(-2 * day)},
+			}},
+		{ID: "unknown", Status: model.StatusInProgress, Priority: 3, UpdatedAt: now.Add(-3 * day)},
+		{ID: "open", Status: model.StatusOpen, CreatedAt: now.Add(-30 * day)},
+		// Finished work took 2, 4 and 8 days
+		{ID: "c1", Status: model.StatusClosed, CreatedAt: now.Add(-40 * day), StartedAt: at(32 * day), ClosedAt: at(30 * day)},
+		{ID: "c2", Status: model.StatusClosed, CreatedAt: now.Add(-40 * day), StartedAt: at(34 * day), ClosedAt: at(30 * day)},
+		{ID: "c3", Status: model.StatusClosed, CreatedAt: now.Add(-40 * day), StartedAt: at(38 * day), ClosedAt: at(30 * day)},
+	}
+
+	a := ComputeAgingWIP(issues, now)
+	var ids []string
+	for _, item := range a.Items {
+		ids = append(ids, item.ID)
+	}
+	if got := fmt.Sprint(ids); got != "[old bounced unknown new]" {
+		t.Fatalf("expected in-progress issues oldest first, got %s", got)
+	}
+	if bounced := a.Items[1]; bounced.Age != 6*day || bounced.Approximate {
+		t.Errorf("expected bounced aged from its first start, got %+v", bounced)
+	}
+	if unknown := a.Items[2]; unknown.Age != 3*day || !unknown.Approximate {
+		t.Errorf("expected unknown aged from its last update, approximately, got %+v", unknown)
+	}
+	if a.Cycle.Count != 3 || a.Cycle.P50 != 4*day || a.Cycle.P85 != 8*day {
+		t.Errorf("unexpected cycle stats %+v", a.Cycle)
+	}
+	if !a.Overdue(a.Items[0]) || a.Overdue(a.Items[1]) {
+		t.Errorf("expected only old past the 85th percentile")
+	}
+	if (AgingWIP{Items: a.Items}).Overdue(a.Items[0]) {
+		t.Errorf("nothing is overdue without finished work to compare with")
+	}
+}
@@ -1,14 +1,11 @@
 package analysis
 
 import (
-	"math"
 	"math/rand"
-	"sort"
 	"time"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/network"
-	"gonum.org/v1/gonum/graph/path"
 	"gonum.org/v1/gonum/graph/simple"
 )
 
@@ -66,6 +63,12 @@ type BetweennessResult struct {
 //   - "A Faster Algorithm for Betweenness Centrality" (Brandes, 2001)
 //   - "Approximating Betweenness Centrality" (Bader et al., 2007)
 func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int) BetweennessResult {
+	return ApproxBetweennessWithProgress(g, sampleSize, nil)
+}
+
+// ApproxBetweennessWithProgress is ApproxBetweenness that calls progress (if
+// non-nil) after each pivot with the number of pivots done and the total.
+func ApproxBetweennessWithProgress(g *simple.DirectedGraph, sampleSize int, progress func(done, total int)) BetweennessResult {
 	start := time.Now()
 	nodes := graph.NodesOf(g.Nodes())
 	n := len(nodes)
@@ -89,6 +92,9 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int) BetweennessResul
 		result.Mode = BetweennessExact
 		result.SampleSize = n
 		result.Elapsed = time.Since(start)
+		if progress != nil {
+			progress(n, n)
+		}
 		return result
 	}
 
@@ -96,19 +102,24 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int) BetweennessResul
 	pivots := sampleNodes(nodes, sampleSize)
 
 	// Compute partial betweenness from sampled pivots only
-	partialBC := make(map[int64]float64)
-	for _, pivot := range pivots {
-		singleSourceBetweenness(g, pivot, partialBC)
+	bg := newBrandesGraph(g, nodes)
+	partial := make([]float64, n)
+	for i, pivot := range pivots {
+		bg.accumulate(bg.index[pivot.ID()], partial)
+		if progress != nil {
+			progress(i+1, len(pivots))
+		}
 	}
 
 	// Scale up: BC_approx = BC_partial * (n / k)
 	// This extrapolates from the sample to the full graph
 	scale := float64(n) / float64(sampleSize)
-	for id := range partialBC {
-		partialBC[id] *= scale
+	for i, score := range partial {
+		if score != 0 {
+			result.Scores[bg.ids[i]] = score * scale
+		}
 	}
 
-	result.Scores = partialBC
 	result.Elapsed = time.Since(start)
 	return result
 }
@@ -134,121 +145,75 @@ func sampleNodes(nodes []graph.Node, k int) []graph.Node {
 	return shuffled[:k]
 }
 
-// singleSourceBetweenness computes the betweenness contribution from a single source node.
-// This is the core of Brandes' algorithm, run once per pivot.
-//
-// The algorithm performs BFS from the source and accumulates dependency scores
-// in a reverse topological order traversal.
-func singleSourceBetweenness(g *simple.DirectedGraph, source graph.Node, bc map[int64]float64) {
-	sourceID := source.ID()
-	nodes := graph.NodesOf(g.Nodes())
-
-	// Use shortest paths from gonum
-	pt := path.DijkstraFrom(source, g)
-
-	// Build the DAG of shortest paths and compute sigma (number of shortest paths)
-	// and delta (dependency accumulation)
-	sigma := make(map[int64]float64)  // Number of shortest paths through node
-	dist := make(map[int64]float64)   // Distance from source
-	delta := make(map[int64]float64)  // Dependency of source on node
-	pred := make(map[int64][]int64)   // Predecessors on shortest paths
+// brandesGraph is an index-based adjacency list so each single-source pass
+// works on slices instead of maps keyed by node ID.
+type brandesGraph struct {
+	ids   []int64       // index -> node ID
+	index map[int64]int // node ID -> index
+	out   [][]int       // successors by index
+}
 
-	for _, n := range nodes {
-		nid := n.ID()
-		sigma[nid] = 0
-		dist[nid] = -1 // -1 means unreachable
-		delta[nid] = 0
-		pred[nid] = nil
+// newBrandesGraph indexes the nodes and edges of g
+func newBrandesGraph(g *simple.DirectedGraph, nodes []graph.Node) brandesGraph {
+	bg := brandesGraph{
+		ids:   make([]int64, len(nodes)),
+		index: make(map[int64]int, len(nodes)),
+		out:   make([][]int, len(nodes)),
 	}
-
-	sigma[sourceID] = 1
-	dist[sourceID] = 0
-
-	// BFS from source, building shortest path DAG
-	type nodeWithDist struct {
-		id   int64
-		dist float64
+	for i, n := range nodes {
+		bg.ids[i] = n.ID()
+		bg.index[n.ID()] = i
 	}
-	var stack []nodeWithDist
-
-	// First, compute distances using Dijkstra result
-	for _, n := range nodes {
-		nid := n.ID()
-		_, d := pt.To(nid)
-		// math.Inf(1) indicates unreachable
-		if d == math.Inf(1) || nid == sourceID {
-			continue
+	for i, id := range bg.ids {
+		from := g.From(id)
+		for from.Next() {
+			bg.out[i] = append(bg.out[i], bg.index[from.Node().ID()])
 		}
-		dist[nid] = d
-		stack = append(stack, nodeWithDist{id: nid, dist: d})
 	}
+	return bg
+}
 
-	// Sort by distance (BFS order)
-	sort.Slice(stack, func(i, j int) bool {
-		return stack[i].dist < stack[j].dist
-	})
-
-	// Build sigma and predecessors
-	// For each node in BFS order, find predecessors on shortest paths
-	for _, nd := range stack {
-		nid := nd.id
-		nodeDist := nd.dist
-
-		// Find all predecessors that are on a shortest path
-		to := g.To(nid)
-		for to.Next() {
-			predNode := to.Node()
-			predID := predNode.ID()
-			predDist := dist[predID]
-
-			if predDist < 0 {
-				continue // Unreachable from source
-			}
-
-			// Check if this edge is on a shortest path
-			// Edge (pred -> n) is on shortest path if dist[pred] + weight(pred,n) == dist[n]
-			// For unweighted graphs, weight = 1
-			if predDist+1 == nodeDist {
-				pred[nid] = append(pred[nid], predID)
-			}
-		}
-
-		// Compute sigma[nid] = sum of sigma[p] for all predecessors p
-		if len(pred[nid]) > 0 {
-			for _, p := range pred[nid] {
-				sigma[nid] += sigma[p]
+// accumulate adds the betweenness contribution of paths starting at source to bc.
+// This is the core of Brandes' algorithm, run once per pivot: a BFS counts the
+// shortest paths (sigma) to every node, then dependencies (delta) are
+// accumulated walking back from the farthest nodes.
+func (bg brandesGraph) accumulate(source int, bc []float64) {
+	n := len(bg.ids)
+	sigma := make([]float64, n)
+	delta := make([]float64, n)
+	dist := make([]int, n)
+	for i := range dist {
+		dist[i] = -1 // unreachable
+	}
+	pred := make([][]int, n)
+
+	sigma[source] = 1
+	dist[source] = 0
+	order := make([]int, 0, n) // BFS order, nearest first
+	queue := []int{source}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		order = append(order, v)
+		for _, w := range bg.out[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
 			}
-		} else if nid != sourceID {
-			// No predecessors means direct path from source (distance 1)
-			fromSource := g.To(nid)
-			for fromSource.Next() {
-				if fromSource.Node().ID() == sourceID {
-					sigma[nid] = 1
-					pred[nid] = []int64{sourceID}
-					break
-				}
+			if dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
 			}
 		}
 	}
 
-	// Accumulation: traverse in reverse BFS order (farthest first)
-	for i := len(stack) - 1; i >= 0; i-- {
-		nid := stack[i].id
-		if sigma[nid] == 0 {
-			continue
-		}
-
-		// For each predecessor p of nid
-		for _, pid := range pred[nid] {
-			// delta[p] += (sigma[p] / sigma[nid]) * (1 + delta[nid])
-			if sigma[nid] > 0 {
-				delta[pid] += (sigma[pid] / sigma[nid]) * (1 + delta[nid])
-			}
+	for i := len(order) - 1; i >= 0; i-- {
+		w := order[i]
+		for _, v := range pred[w] {
+			delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
 		}
-
-		// Add to betweenness (not for source node)
-		if nid != sourceID {
-			bc[nid] += delta[nid]
+		if w != source {
+			bc[w] += delta[w]
 		}
 	}
 }
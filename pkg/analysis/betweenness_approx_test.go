@@ -1,9 +1,14 @@
 package analysis
 
 import (
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/network"
 )
 
 func TestApproxBetweenness_SmallGraph(t *testing.T) {
@@ -58,6 +63,57 @@ func TestApproxBetweenness_LargeGraph_Approximate(t *testing.T) {
 	}
 }
 
+func TestBrandesAccumulateMatchesExact(t *testing.T) {
+	// Diamond with a tail plus a shortcut, so some pairs have several shortest paths
+	edges := [][2]string{{"B", "A"}, {"C", "A"}, {"D", "B"}, {"D", "C"}, {"E", "D"}, {"F", "E"}, {"F", "B"}}
+	deps := make(map[string][]*model.Dependency)
+	for _, e := range edges {
+		deps[e[0]] = append(deps[e[0]], &model.Dependency{IssueID: e[0], DependsOnID: e[1], Type: model.DepBlocks})
+	}
+	var issues []model.Issue
+	for _, id := range []string{"A", "B", "C", "D", "E", "F"} {
+		issues = append(issues, model.Issue{ID: id, Status: model.StatusOpen, Dependencies: deps[id]})
+	}
+	analyzer := NewAnalyzer(issues)
+
+	// Running Brandes from every node must reproduce exact betweenness
+	nodes := graph.NodesOf(analyzer.g.Nodes())
+	bg := newBrandesGraph(analyzer.g, nodes)
+	bc := make([]float64, len(nodes))
+	for i := range nodes {
+		bg.accumulate(i, bc)
+	}
+	exact := network.Betweenness(analyzer.g)
+	for i, id := range bg.ids {
+		if math.Abs(bc[i]-exact[id]) > 1e-9 {
+			t.Errorf("node %s: brandes %.4f, exact %.4f", analyzer.nodeToID[id], bc[i], exact[id])
+		}
+	}
+}
+
+func TestApproxBetweennessWithProgress(t *testing.T) {
+	issues := make([]model.Issue, 30)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("N%d", i), Status: model.StatusOpen}
+		if i > 0 {
+			issues[i].Dependencies = []*model.Dependency{{IssueID: issues[i].ID, DependsOnID: issues[i-1].ID, Type: model.DepBlocks}}
+		}
+	}
+	analyzer := NewAnalyzer(issues)
+
+	var calls, lastDone, lastTotal int
+	result := ApproxBetweennessWithProgress(analyzer.g, 8, func(done, total int) {
+		calls++
+		lastDone, lastTotal = done, total
+	})
+	if result.Mode != BetweennessApproximate {
+		t.Fatalf("expected approximate mode, got %s", result.Mode)
+	}
+	if calls != 8 || lastDone != 8 || lastTotal != 8 {
+		t.Errorf("expected one progress call per pivot ending at 8/8, got %d calls, last %d/%d", calls, lastDone, lastTotal)
+	}
+}
+
 func TestApproxBetweenness_EmptyGraph(t *testing.T) {
 	issues := []model.Issue{}
 	analyzer := NewAnalyzer(issues)
@@ -162,3 +218,26 @@ func generateChainGraph(n int) []model.Issue {
 func generateID(i int) string {
 	return string(rune('A'+i%26)) + string(rune('0'+i/26))
 }
+
+func TestPhase2ProgressIgnoresStaleUpdates(t *testing.T) {
+	stats := &GraphStats{}
+	stats.setStage("betweenness")
+	stats.setStageProgress("betweenness", 3, 10)
+	if p := stats.Progress(); p.Stage != "betweenness" || p.Done != 3 || p.Total != 10 {
+		t.Fatalf("unexpected progress %+v", p)
+	}
+
+	// A timed-out betweenness run must not overwrite the next stage
+	stats.setStage("hits")
+	stats.setStageProgress("betweenness", 4, 10)
+	if p := stats.Progress(); p.Stage != "hits" || p.Total != 0 {
+		t.Fatalf("expected stale update ignored, got %+v", p)
+	}
+
+	// Finished analysis reports no stage
+	done := NewAnalyzer([]model.Issue{{ID: "A", Status: model.StatusOpen}}).AnalyzeAsync()
+	done.WaitForPhase2()
+	if p := done.Progress(); p != (Phase2Progress{}) {
+		t.Fatalf("expected empty progress after Phase 2, got %+v", p)
+	}
+}
@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// TopBlocker is an open issue holding up other open work
+type TopBlocker struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Status   model.Status `json:"status"`
+	Priority int          `json:"priority"`
+	Assignee string       `json:"assignee,omitempty"`
+
+	// Direct counts the open issues waiting on it; Downstream adds
+	// everything waiting on those in turn
+	Direct     int `json:"direct"`
+	Downstream int `json:"downstream"`
+}
+
+// BlockerLeaderboard ranks the open issues that block other open work by how
+// much waits on them, directly or through a chain, then by how much waits
+// directly, then by priority. limit <= 0 returns every blocker.
+func BlockerLeaderboard(issues []model.Issue, limit int) []TopBlocker {
+	waiters := make(map[string][]string)
+	for id, blockers := range OpenBlockers(issues) {
+		for _, b := range blockers {
+			waiters[b] = append(waiters[b], id)
+		}
+	}
+
+	var board []TopBlocker
+	for _, issue := range issues {
+		direct := waiters[issue.ID]
+		if len(direct) == 0 {
+			continue
+		}
+		board = append(board, TopBlocker{
+			ID:         issue.ID,
+			Title:      issue.Title,
+			Status:     issue.Status,
+			Priority:   issue.Priority,
+			Assignee:   issue.Assignee,
+			Direct:     len(direct),
+			Downstream: countDownstream(issue.ID, waiters),
+		})
+	}
+	sort.Slice(board, func(i, j int) bool {
+		a, b := board[i], board[j]
+		if a.Downstream != b.Downstream {
+			return a.Downstream > b.Downstream
+		}
+		if a.Direct != b.Direct {
+			return a.Direct > b.Direct
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(board) > limit {
+		board = board[:limit]
+	}
+	return board
+}
+
+// countDownstream counts the issues waiting on id through any chain of
+// waiters, id itself excluded even when it sits on a cycle
+func countDownstream(id string, waiters map[string][]string) int {
+	seen := map[string]bool{id: true}
+	stack := []string{id}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range waiters[next] {
+			if !seen[w] {
+				seen[w] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+	return len(seen) - 1
+}
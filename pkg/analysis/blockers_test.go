@@ -0,0 +1,46 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBlockerLeaderboard(t *testing.T) {
+	on := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		// A holds up B and C directly and D through C
+		{ID: "A", Title: "Schema", Status: model.StatusInProgress, Assignee: "sam", Priority: 2},
+		{ID: "B", Status: model.StatusOpen, Dependencies: on("A")},
+		{ID: "C", Status: model.StatusOpen, Priority: 1, Dependencies: on("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: on("C", "E")},
+		// E holds up D alone; X is closed so holds nothing up
+		{ID: "E", Status: model.StatusOpen, Priority: 0},
+		{ID: "X", Status: model.StatusClosed},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: on("X")},
+		// Closed work waiting on F doesn't count
+		{ID: "F", Status: model.StatusOpen},
+		{ID: "Z", Status: model.StatusClosed, Dependencies: on("F")},
+	}
+
+	board := BlockerLeaderboard(issues, 0)
+	if len(board) != 3 {
+		t.Fatalf("expected A, C and E on the board, got %+v", board)
+	}
+	if a := board[0]; a.ID != "A" || a.Direct != 2 || a.Downstream != 3 || a.Assignee != "sam" {
+		t.Errorf("expected A first with 2 direct and 3 downstream, got %+v", a)
+	}
+	// C and E both hold up only D; E has the higher priority
+	if board[1].ID != "E" || board[2].ID != "C" {
+		t.Errorf("expected ties broken by priority, got %s then %s", board[1].ID, board[2].ID)
+	}
+	if top := BlockerLeaderboard(issues, 1); len(top) != 1 || top[0].ID != "A" {
+		t.Errorf("expected the limit applied, got %+v", top)
+	}
+}
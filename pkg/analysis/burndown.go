@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Granularity is the bucket size of a burndown chart
+type Granularity int
+
+const (
+	GranularityDay Granularity = iota
+	GranularityWeek
+)
+
+// String returns the display name of the granularity
+func (g Granularity) String() string {
+	if g == GranularityWeek {
+		return "week"
+	}
+	return "day"
+}
+
+// days returns the bucket length in calendar days
+func (g Granularity) days() int {
+	if g == GranularityWeek {
+		return 7
+	}
+	return 1
+}
+
+// BurnPoint is the state of the project at the end of one bucket
+type BurnPoint struct {
+	End         time.Time `json:"end"`          // Exclusive end of the bucket
+	Scope       int       `json:"scope"`        // Issues created before End
+	Done        int       `json:"done"`         // Issues closed before End
+	ScopePoints int       `json:"scope_points"` // Estimated minutes of Scope
+	DonePoints  int       `json:"done_points"`  // Estimated minutes of Done
+}
+
+// Open returns the number of issues still open at the end of the bucket
+func (p BurnPoint) Open() int {
+	return p.Scope - p.Done
+}
+
+// RemainingPoints returns the estimated minutes still open at the end of the bucket
+func (p BurnPoint) RemainingPoints() int {
+	return p.ScopePoints - p.DonePoints
+}
+
+// closedTime returns when an issue was closed, falling back to updated_at for
+// issues closed without a closed_at timestamp
+func closedTime(issue *model.Issue) time.Time {
+	if issue.ClosedAt != nil {
+		return *issue.ClosedAt
+	}
+	return issue.UpdatedAt
+}
+
+// ComputeBurndown returns the open scope over the last `buckets` buckets ending
+// today (in now's location), oldest first. Buckets are aligned to calendar
+// days so a day bucket always covers midnight to midnight.
+func ComputeBurndown(issues []model.Issue, now time.Time, g Granularity, buckets int) []BurnPoint {
+	if buckets <= 0 {
+		return nil
+	}
+
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	points := make([]BurnPoint, buckets)
+	for i := range points {
+		points[i].End = tomorrow.AddDate(0, 0, -(buckets-1-i)*g.days())
+	}
+
+	// bucketOf returns the first bucket ending after t; history before the chart lands in
+	// bucket 0 and future timestamps return buckets
+	bucketOf := func(t time.Time) int {
+		for i := range points {
+			if t.Before(points[i].End) {
+				return i
+			}
+		}
+		return buckets
+	}
+
+	// Record each event in its bucket, then accumulate
+	deltas := make([]BurnPoint, buckets)
+	for i := range issues {
+		issue := &issues[i]
+		est, _ := issue.EstimateMinutes()
+		if b := bucketOf(issue.CreatedAt); b < buckets {
+			deltas[b].Scope++
+			deltas[b].ScopePoints += est
+		}
+		if issue.Status.IsClosed() {
+			if b := bucketOf(closedTime(issue)); b < buckets {
+				deltas[b].Done++
+				deltas[b].DonePoints += est
+			}
+		}
+	}
+
+	var acc BurnPoint
+	for i := range points {
+		acc.Scope += deltas[i].Scope
+		acc.Done += deltas[i].Done
+		acc.ScopePoints += deltas[i].ScopePoints
+		acc.DonePoints += deltas[i].DonePoints
+		points[i].Scope = acc.Scope
+		points[i].Done = acc.Done
+		points[i].ScopePoints = acc.ScopePoints
+		points[i].DonePoints = acc.DonePoints
+	}
+	return points
+}
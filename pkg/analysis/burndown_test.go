@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeBurndownDaily(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return now.AddDate(0, 0, d) }
+	closedAt := day(-1)
+
+	issues := []model.Issue{
+		// Created before the chart: counted from the first bucket
+		{ID: "A", Status: model.StatusClosed, CreatedAt: day(-30), ClosedAt: &closedAt, EstimatedMinutes: minutes(60)},
+		{ID: "B", Status: model.StatusOpen, CreatedAt: day(-2), EstimatedMinutes: minutes(30)},
+		// Closed without closed_at falls back to updated_at
+		{ID: "C", Status: model.StatusClosed, CreatedAt: day(-3), UpdatedAt: day(0)},
+	}
+
+	points := ComputeBurndown(issues, now, GranularityDay, 4)
+	if len(points) != 4 {
+		t.Fatalf("expected 4 points, got %d", len(points))
+	}
+	wantOpen := []int{2, 3, 2, 1}
+	for i, p := range points {
+		if p.Open() != wantOpen[i] {
+			t.Errorf("bucket %d: open %d, want %d", i, p.Open(), wantOpen[i])
+		}
+	}
+	last := points[3]
+	if !last.End.Equal(time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected last bucket to end at midnight tonight, got %v", last.End)
+	}
+	if last.ScopePoints != 90 || last.RemainingPoints() != 30 {
+		t.Errorf("expected 90 scope / 30 remaining points, got %d / %d", last.ScopePoints, last.RemainingPoints())
+	}
+}
+
+func TestComputeBurndownWeekly(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -10)},
+		{ID: "B", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -1)},
+	}
+	points := ComputeBurndown(issues, now, GranularityWeek, 3)
+	got := []int{points[0].Open(), points[1].Open(), points[2].Open()}
+	if got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Fatalf("unexpected weekly open counts %v", got)
+	}
+	if d := points[2].End.Sub(points[1].End); d != 7*24*time.Hour {
+		t.Fatalf("expected week-long buckets, got %v", d)
+	}
+	if ComputeBurndown(issues, now, GranularityWeek, 0) != nil {
+		t.Fatalf("expected nil for zero buckets")
+	}
+}
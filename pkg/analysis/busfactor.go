@@ -0,0 +1,165 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultBusFactorMinIssues is the smallest number of open issues one person
+// must hold in a subgraph before it is reported as a bus-factor risk
+const DefaultBusFactorMinIssues = 3
+
+// BusFactorRisk is a subgraph whose open work all belongs to a single assignee
+type BusFactorRisk struct {
+	Assignee string `json:"assignee"`
+	RootID   string `json:"root_id"`
+	// Kind is "epic" when the subgraph is an epic's open descendants and their
+	// blockers, or "blockers" when it is the open blockers of a single issue
+	Kind       string   `json:"kind"`
+	IssueIDs   []string `json:"issue_ids"`  // Open issues in the subgraph held by Assignee
+	Unassigned int      `json:"unassigned"` // Open issues in the subgraph with no assignee
+}
+
+// ComputeBusFactorRisks finds epics and issues whose open dependency subgraph
+// is owned entirely by one person, holding at least minIssues issues. Risks
+// whose subgraph is already covered by a larger risk for the same person are
+// dropped, so a long chain is reported once rather than at every link.
+// Results are ordered by subgraph size, largest first.
+func ComputeBusFactorRisks(issues []model.Issue, minIssues int) []BusFactorRisk {
+	if minIssues < 1 {
+		minIssues = 1
+	}
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	children := make(map[string][]string)
+	blockers := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; !ok {
+				continue
+			}
+			switch {
+			case dep.Type == model.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			case isBlockingDep(dep.Type):
+				blockers[issue.ID] = append(blockers[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+
+	var candidates []BusFactorRisk
+	for i := range issues {
+		root := &issues[i]
+		if root.Status.IsClosed() {
+			continue
+		}
+		kind := "blockers"
+		var start []string
+		if root.IssueType == model.TypeEpic {
+			kind = "epic"
+			start = children[root.ID]
+		}
+		start = append(start, blockers[root.ID]...)
+		if len(start) == 0 {
+			continue
+		}
+		if risk, ok := soleOwner(start, issueMap, children, blockers, kind == "epic"); ok && len(risk.IssueIDs) >= minIssues {
+			risk.RootID = root.ID
+			risk.Kind = kind
+			candidates = append(candidates, risk)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].IssueIDs) != len(candidates[j].IssueIDs) {
+			return len(candidates[i].IssueIDs) > len(candidates[j].IssueIDs)
+		}
+		return candidates[i].RootID < candidates[j].RootID
+	})
+
+	// Drop risks nested inside a larger one for the same person
+	covered := make(map[string]map[string]bool)
+	var result []BusFactorRisk
+	for _, c := range candidates {
+		seen := covered[c.Assignee]
+		if seen == nil {
+			seen = make(map[string]bool)
+			covered[c.Assignee] = seen
+		}
+		if seen[c.RootID] {
+			continue
+		}
+		nested := true
+		for _, id := range c.IssueIDs {
+			if !seen[id] {
+				nested = false
+				break
+			}
+		}
+		if nested {
+			continue
+		}
+		for _, id := range c.IssueIDs {
+			seen[id] = true
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// soleOwner walks open issues reachable from start through blocking edges (and
+// parent-child edges when followChildren is set). It returns the subgraph's
+// assigned issues and false as soon as a second assignee appears.
+func soleOwner(start []string, issueMap map[string]*model.Issue, children, blockers map[string][]string, followChildren bool) (BusFactorRisk, bool) {
+	var risk BusFactorRisk
+	seen := make(map[string]bool, len(start))
+	stack := make([]string, 0, len(start))
+	for _, id := range start {
+		if !seen[id] {
+			seen[id] = true
+			stack = append(stack, id)
+		}
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		issue := issueMap[id]
+		if issue.Status.IsClosed() {
+			continue // Finished work no longer depends on anyone
+		}
+		switch {
+		case issue.Assignee == "":
+			risk.Unassigned++
+		case risk.Assignee == "":
+			risk.Assignee = issue.Assignee
+			risk.IssueIDs = append(risk.IssueIDs, id)
+		case issue.Assignee != risk.Assignee:
+			return BusFactorRisk{}, false
+		default:
+			risk.IssueIDs = append(risk.IssueIDs, id)
+		}
+
+		next := blockers[id]
+		if followChildren {
+			next = append(append([]string(nil), next...), children[id]...)
+		}
+		for _, n := range next {
+			if !seen[n] {
+				seen[n] = true
+				stack = append(stack, n)
+			}
+		}
+	}
+	if risk.Assignee == "" {
+		return BusFactorRisk{}, false
+	}
+	sort.Strings(risk.IssueIDs)
+	return risk, true
+}
@@ -0,0 +1,78 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeBusFactorRisks(t *testing.T) {
+	blocks := func(id string, on ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, o := range on {
+			deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: o, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	child := func(id, parent string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}
+	}
+
+	issues := []model.Issue{
+		// Epic whose open work is all ann's (plus one unassigned task); the closed
+		// child held by bob doesn't count
+		{ID: "E1", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "E1.1", Assignee: "ann", Status: model.StatusOpen, Dependencies: child("E1.1", "E1")},
+		{ID: "E1.2", Assignee: "ann", Status: model.StatusInProgress, Dependencies: append(child("E1.2", "E1"), blocks("E1.2", "X1")...)},
+		{ID: "E1.3", Assignee: "bob", Status: model.StatusClosed, Dependencies: child("E1.3", "E1")},
+		{ID: "E1.4", Status: model.StatusOpen, Dependencies: child("E1.4", "E1")},
+		{ID: "X1", Assignee: "ann", Status: model.StatusOpen},
+
+		// Chain C3 -> C2 -> C1 all owned by cat, blocking dan's release
+		{ID: "C1", Assignee: "cat", Status: model.StatusOpen},
+		{ID: "C2", Assignee: "cat", Status: model.StatusOpen, Dependencies: blocks("C2", "C1")},
+		{ID: "C3", Assignee: "cat", Status: model.StatusOpen, Dependencies: blocks("C3", "C2")},
+		{ID: "R", Assignee: "dan", Status: model.StatusOpen, Dependencies: blocks("R", "C3")},
+
+		// Shared epic: two owners, no risk
+		{ID: "E2", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "E2.1", Assignee: "ann", Status: model.StatusOpen, Dependencies: child("E2.1", "E2")},
+		{ID: "E2.2", Assignee: "bob", Status: model.StatusOpen, Dependencies: child("E2.2", "E2")},
+		{ID: "E2.3", Assignee: "bob", Status: model.StatusOpen, Dependencies: child("E2.3", "E2")},
+	}
+
+	risks := ComputeBusFactorRisks(issues, DefaultBusFactorMinIssues)
+	if len(risks) != 2 {
+		t.Fatalf("expected 2 risks, got %+v", risks)
+	}
+
+	var epic, chain BusFactorRisk
+	for _, r := range risks {
+		switch r.RootID {
+		case "E1":
+			epic = r
+		case "R":
+			chain = r
+		}
+	}
+	if epic.Assignee != "ann" || epic.Kind != "epic" || len(epic.IssueIDs) != 3 || epic.Unassigned != 1 {
+		t.Fatalf("unexpected epic risk %+v", epic)
+	}
+	// C3 and C2 are nested inside R's chain and are not reported again
+	if chain.Assignee != "cat" || chain.Kind != "blockers" || len(chain.IssueIDs) != 3 {
+		t.Fatalf("unexpected chain risk %+v", chain)
+	}
+}
+
+func TestComputeBusFactorRisksMinIssues(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Assignee: "ann", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	if risks := ComputeBusFactorRisks(issues, DefaultBusFactorMinIssues); len(risks) != 0 {
+		t.Fatalf("expected no risk below threshold, got %+v", risks)
+	}
+	if risks := ComputeBusFactorRisks(issues, 1); len(risks) != 1 || risks[0].RootID != "B" {
+		t.Fatalf("expected single-blocker risk at threshold 1, got %+v", risks)
+	}
+}
@@ -3,6 +3,7 @@ package analysis
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"hash"
 	"sort"
 	"strconv"
 	"sync"
@@ -19,6 +20,7 @@ type Cache struct {
 	stats      *GraphStats
 	computedAt time.Time
 	ttl        time.Duration
+	disk       *DiskCache // Optional persistent layer behind the in-memory entry
 }
 
 // DefaultCacheTTL is the default time-to-live for cached results.
@@ -92,6 +94,21 @@ func (c *Cache) Invalidate() {
 	c.computedAt = time.Time{}
 }
 
+// SetDiskCache adds a persistent layer that CachedAnalyzer consults on a memory
+// miss and writes to once Phase 2 completes. Pass nil to disable it.
+func (c *Cache) SetDiskCache(d *DiskCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.disk = d
+}
+
+// diskCache returns the persistent layer, or nil if none is set
+func (c *Cache) diskCache() *DiskCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.disk
+}
+
 // SetTTL updates the cache TTL.
 func (c *Cache) SetTTL(ttl time.Duration) {
 	c.mu.Lock()
@@ -134,82 +151,106 @@ func ComputeDataHash(issues []model.Issue) string {
 
 	h := sha256.New()
 	for _, issue := range sorted {
-		// Core identity
-		h.Write([]byte(issue.ID))
-		h.Write([]byte{0})
-
-		// Important scalar fields
-		h.Write([]byte(issue.Title))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Description))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Notes))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Design))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.AcceptanceCriteria))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Assignee))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.SourceRepo))
-		h.Write([]byte{0})
-		if issue.ExternalRef != nil {
-			h.Write([]byte(*issue.ExternalRef))
-		}
-		h.Write([]byte{0})
+		writeIssueHash(h, issue)
+		h.Write([]byte{1}) // issue separator
+	}
 
-		h.Write([]byte(issue.Status))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.IssueType))
-		h.Write([]byte{0})
+	return hex.EncodeToString(h.Sum(nil))[:16] // Use first 16 chars for brevity
+}
 
-		// Numeric fields
-		h.Write([]byte(strconv.Itoa(issue.Priority)))
-		h.Write([]byte{0})
-		if issue.EstimatedMinutes != nil {
-			h.Write([]byte(strconv.Itoa(*issue.EstimatedMinutes)))
+// writeIssueHash feeds every field of issue that analysis depends on into h
+func writeIssueHash(h hash.Hash, issue model.Issue) {
+	// Core identity
+	h.Write([]byte(issue.ID))
+	h.Write([]byte{0})
+
+	// Important scalar fields
+	h.Write([]byte(issue.Title))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Description))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Notes))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Design))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.AcceptanceCriteria))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Assignee))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.SourceRepo))
+	h.Write([]byte{0})
+	if issue.ExternalRef != nil {
+		h.Write([]byte(*issue.ExternalRef))
+	}
+	h.Write([]byte{0})
+
+	h.Write([]byte(issue.Status))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.IssueType))
+	h.Write([]byte{0})
+
+	// Numeric fields
+	h.Write([]byte(strconv.Itoa(issue.Priority)))
+	h.Write([]byte{0})
+	if est, ok := issue.EstimateMinutes(); ok {
+		h.Write([]byte(strconv.Itoa(est)))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(issue.CreatedAt.UTC().Format(time.RFC3339Nano)))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	h.Write([]byte{0})
+	if issue.ClosedAt != nil {
+		h.Write([]byte(issue.ClosedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	h.Write([]byte{0})
+
+	// Labels (sorted for determinism)
+	if len(issue.Labels) > 0 {
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		for _, lbl := range labels {
+			h.Write([]byte(lbl))
+			h.Write([]byte{0})
 		}
-		h.Write([]byte{0})
-		h.Write([]byte(issue.CreatedAt.UTC().Format(time.RFC3339Nano)))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.UpdatedAt.UTC().Format(time.RFC3339Nano)))
-		h.Write([]byte{0})
-		if issue.ClosedAt != nil {
-			h.Write([]byte(issue.ClosedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	h.Write([]byte{0})
+
+	// Dependencies (sorted)
+	if len(issue.Dependencies) > 0 {
+		deps := make([]string, 0, len(issue.Dependencies))
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			key := dep.DependsOnID + ":" + string(dep.Type)
+			if !dep.Type.IsBuiltin() && dep.Type.IsBlocking() {
+				key += ":blocking" // Declared blocking in config, which changes the graph
+			}
+			deps = append(deps, key)
 		}
-		h.Write([]byte{0})
+		sort.Strings(deps)
+		for _, dep := range deps {
+			h.Write([]byte(dep))
+			h.Write([]byte{0})
+		}
+	}
 
-		// Labels (sorted for determinism)
-		if len(issue.Labels) > 0 {
-			labels := append([]string(nil), issue.Labels...)
-			sort.Strings(labels)
-			for _, lbl := range labels {
-				h.Write([]byte(lbl))
-				h.Write([]byte{0})
-			}
+	// Fields added by other tools (sorted), which may be shown as columns
+	if len(issue.Extra) > 0 {
+		names := make([]string, 0, len(issue.Extra))
+		for name := range issue.Extra {
+			names = append(names, name)
 		}
+		sort.Strings(names)
 		h.Write([]byte{0})
-
-		// Dependencies (sorted)
-		if len(issue.Dependencies) > 0 {
-			deps := make([]string, 0, len(issue.Dependencies))
-			for _, dep := range issue.Dependencies {
-				if dep == nil {
-					continue
-				}
-				deps = append(deps, dep.DependsOnID+":"+string(dep.Type))
-			}
-			sort.Strings(deps)
-			for _, dep := range deps {
-				h.Write([]byte(dep))
-				h.Write([]byte{0})
-			}
+		for _, name := range names {
+			h.Write([]byte(name))
+			h.Write([]byte{0})
+			h.Write(issue.Extra[name])
+			h.Write([]byte{0})
 		}
-
-		h.Write([]byte{1}) // issue separator
 	}
-
-	return hex.EncodeToString(h.Sum(nil))[:16] // Use first 16 chars for brevity
 }
 
 // CachedAnalyzer wraps an Analyzer with caching support.
@@ -219,6 +260,9 @@ type CachedAnalyzer struct {
 	issues   []model.Issue
 	hash     string
 	cacheHit bool // Set by AnalyzeAsync to track if it was a cache hit
+
+	structureHash string // Lazily computed by StructureHash
+	reused        bool   // Set by AnalyzeReusing when the previous stats were kept
 }
 
 // NewCachedAnalyzer creates an analyzer that checks the cache before computing.
@@ -245,6 +289,16 @@ func (ca *CachedAnalyzer) AnalyzeAsync() *GraphStats {
 		return stats
 	}
 
+	// Then the disk, for results from a previous run over the same data
+	disk := ca.cache.diskCache()
+	if disk != nil {
+		if stats, ok := disk.Load(ca.hash, ca.Analyzer.analysisConfig()); ok {
+			ca.cacheHit = true
+			ca.cache.SetByHash(ca.hash, stats)
+			return stats
+		}
+	}
+
 	// Cache miss - compute fresh
 	ca.cacheHit = false
 	stats := ca.Analyzer.AnalyzeAsync()
@@ -253,11 +307,45 @@ func (ca *CachedAnalyzer) AnalyzeAsync() *GraphStats {
 	go func() {
 		stats.WaitForPhase2()
 		ca.cache.SetByHash(ca.hash, stats)
+		if disk != nil {
+			_ = disk.Save(ca.hash, stats) // Best effort; a failed write just means recomputing next time
+		}
 	}()
 
 	return stats
 }
 
+// AnalyzeReusing returns prev when the graph structure is unchanged since it was
+// computed (prevStructure is the ComputeStructureHash of prev's issues), and
+// falls back to AnalyzeAsync otherwise. The reused stats are also cached under
+// the new data hash.
+func (ca *CachedAnalyzer) AnalyzeReusing(prev *GraphStats, prevStructure string) *GraphStats {
+	if prev != nil && prevStructure != "" && prevStructure == ca.StructureHash() {
+		ca.cacheHit = true
+		ca.reused = true
+		go func() {
+			prev.WaitForPhase2()
+			ca.cache.SetByHash(ca.hash, prev)
+		}()
+		return prev
+	}
+	ca.reused = false
+	return ca.AnalyzeAsync()
+}
+
+// StructureHash returns the ComputeStructureHash of the analyzer's issues
+func (ca *CachedAnalyzer) StructureHash() string {
+	if ca.structureHash == "" {
+		ca.structureHash = ComputeStructureHash(ca.issues)
+	}
+	return ca.structureHash
+}
+
+// WasReused returns true if the last AnalyzeReusing call kept the previous stats.
+func (ca *CachedAnalyzer) WasReused() bool {
+	return ca.reused
+}
+
 // Analyze returns cached stats if available, otherwise computes synchronously.
 // Note: This returns a value copy that shares map references with the original.
 // This is safe because the maps are immutable after Phase 2 completion.
@@ -277,6 +365,7 @@ func (ca *CachedAnalyzer) Analyze() GraphStats {
 		eigenvector:       stats.eigenvector,
 		hubs:              stats.hubs,
 		authorities:       stats.authorities,
+		closeness:         stats.closeness,
 		criticalPathScore: stats.criticalPathScore,
 		cycles:            stats.cycles,
 		phase2Ready:       true,
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ChecklistItem is an item of a markdown task list: "- [ ] text" or
+// "- [x] text"
+type ChecklistItem struct {
+	Text string
+	Done bool
+	Line int // Line of the text it is on, from 0
+}
+
+// Checklist is the task list items of a text, in order
+type Checklist []ChecklistItem
+
+// Done returns how many items are checked off
+func (c Checklist) Done() int {
+	done := 0
+	for _, item := range c {
+		if item.Done {
+			done++
+		}
+	}
+	return done
+}
+
+// checklistPattern matches a task list item: a bullet or number, then the
+// box. The first group ends just before the box's mark.
+var checklistPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])\](?:\s+(.*))?$`)
+
+// ParseChecklist returns the task list items in markdown text, leaving out
+// any in fenced code blocks
+func ParseChecklist(text string) Checklist {
+	if !strings.Contains(text, "[") {
+		return nil
+	}
+	var items Checklist
+	inFence := false
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := checklistPattern.FindStringSubmatch(line); m != nil {
+			items = append(items, ChecklistItem{Text: strings.TrimSpace(m[3]), Done: m[2] != " ", Line: n})
+		}
+	}
+	return items
+}
+
+// ToggleChecklistItem checks off the nth task list item in text, or unchecks
+// it if done, leaving the rest of text as it was. It reports false when text
+// has no such item.
+func ToggleChecklistItem(text string, n int) (string, bool) {
+	items := ParseChecklist(text)
+	if n < 0 || n >= len(items) {
+		return text, false
+	}
+	lines := strings.Split(text, "\n")
+	line := lines[items[n].Line]
+	at := len(checklistPattern.FindStringSubmatch(strings.TrimSuffix(line, "\r"))[1])
+	mark := "x"
+	if items[n].Done {
+		mark = " "
+	}
+	lines[items[n].Line] = line[:at] + mark + line[at+1:]
+	return strings.Join(lines, "\n"), true
+}
@@ -0,0 +1,40 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecklist(t *testing.T) {
+	text := "Plan:\n\n- [ ] Write parser\n* [x] Sketch grammar\n  1. [X] Nested, numbered\n- [ ]\n" +
+		"```\n- [ ] Not a task, in code\n```\n- [] Not a box\n+ [ ] Last one\r\n"
+	items := ParseChecklist(text)
+	want := Checklist{
+		{Text: "Write parser", Line: 2},
+		{Text: "Sketch grammar", Done: true, Line: 3},
+		{Text: "Nested, numbered", Done: true, Line: 4},
+		{Text: "", Line: 5},
+		{Text: "Last one", Line: 10},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("expected %+v, got %+v", want, items)
+	}
+	if items.Done() != 2 {
+		t.Errorf("expected 2 done, got %d", items.Done())
+	}
+
+	toggled, ok := ToggleChecklistItem(text, 0)
+	if !ok || ParseChecklist(toggled).Done() != 3 || toggled[:len("Plan:\n\n- [x] Write")] != "Plan:\n\n- [x] Write" {
+		t.Errorf("expected the first item checked off:\n%s", toggled)
+	}
+	toggled, _ = ToggleChecklistItem(toggled, 2)
+	if got := ParseChecklist(toggled); got[2].Done || len(toggled) != len(text) {
+		t.Errorf("expected the third item unchecked and nothing else changed:\n%s", toggled)
+	}
+	if again, _ := ToggleChecklistItem(toggled, 4); !ParseChecklist(again)[4].Done {
+		t.Errorf("expected the CRLF item checked off:\n%q", again)
+	}
+	if _, ok := ToggleChecklistItem(text, 5); ok {
+		t.Errorf("expected no sixth item")
+	}
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/community"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// Cluster is a group of issues more tightly linked to each other than to
+// the rest: a workstream, whether or not an epic declares it
+type Cluster struct {
+	ID      int      `json:"id"`      // 1 for the largest
+	Members []string `json:"members"` // Sorted by ID
+
+	// Epic is the epic most members belong to, "" when most belong to
+	// none, and EpicShare the fraction of members in it
+	Epic      string  `json:"epic,omitempty"`
+	EpicShare float64 `json:"epic_share"`
+}
+
+// Clusters is the community structure of the dependency graph
+type Clusters struct {
+	Clusters   []Cluster      `json:"clusters"`
+	Of         map[string]int `json:"-"` // Issue ID → cluster ID, for clustered issues
+	Modularity float64        `json:"modularity"`
+}
+
+// clusterSeed fixes Louvain's random order so the same graph always splits
+// the same way
+const clusterSeed = 1
+
+// DetectClusters finds communities in the dependency graph with the Louvain
+// method, treating every link except parent-child as undirected: hierarchy
+// is what epics already declare, so leaving it out lets clusters disagree
+// with them. Issues with no links to a cluster are left out.
+func DetectClusters(issues []model.Issue) Clusters {
+	c := Clusters{Of: make(map[string]int)}
+
+	ids := make(map[string]int64, len(issues))
+	names := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		if _, dup := ids[issue.ID]; !dup {
+			ids[issue.ID] = int64(len(names))
+			names = append(names, issue.ID)
+		}
+	}
+	g := simple.NewUndirectedGraph()
+	for i := range names {
+		g.AddNode(simple.Node(int64(i)))
+	}
+	edges := 0
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type == model.DepParentChild {
+				continue
+			}
+			to, ok := ids[dep.DependsOnID]
+			from := ids[issue.ID]
+			if !ok || to == from || g.HasEdgeBetween(from, to) {
+				continue
+			}
+			g.SetEdge(g.NewEdge(simple.Node(from), simple.Node(to)))
+			edges++
+		}
+	}
+	if edges == 0 {
+		return c
+	}
+
+	src := rand.NewPCG(clusterSeed, clusterSeed)
+	communities := community.Modularize(g, 1, src).Communities()
+	c.Modularity = community.Q(g, communities, 1)
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	epics := epicMembership(issues, issueMap)
+	for _, nodes := range communities {
+		if len(nodes) < 2 {
+			continue
+		}
+		cl := Cluster{Members: memberIDs(nodes, names)}
+		cl.Epic, cl.EpicShare = dominantEpic(cl.Members, epics)
+		c.Clusters = append(c.Clusters, cl)
+	}
+	sort.Slice(c.Clusters, func(i, j int) bool {
+		a, b := c.Clusters[i], c.Clusters[j]
+		if len(a.Members) != len(b.Members) {
+			return len(a.Members) > len(b.Members)
+		}
+		return a.Members[0] < b.Members[0]
+	})
+	for i := range c.Clusters {
+		c.Clusters[i].ID = i + 1
+		for _, id := range c.Clusters[i].Members {
+			c.Of[id] = i + 1
+		}
+	}
+	return c
+}
+
+// Cluster returns the cluster with the given ID, or nil
+func (c Clusters) Cluster(id int) *Cluster {
+	if id < 1 || id > len(c.Clusters) {
+		return nil
+	}
+	return &c.Clusters[id-1]
+}
+
+func memberIDs(nodes []graph.Node, names []string) []string {
+	members := make([]string, len(nodes))
+	for i, n := range nodes {
+		members[i] = names[n.ID()]
+	}
+	sort.Strings(members)
+	return members
+}
+
+// dominantEpic returns the epic most members belong to ("" for none) and
+// its share of the members. Ties go to the smaller ID.
+func dominantEpic(members []string, epics map[string]string) (string, float64) {
+	counts := make(map[string]int)
+	for _, id := range members {
+		counts[epics[id]]++
+	}
+	best := ""
+	for epic, n := range counts {
+		if n > counts[best] || (n == counts[best] && epic < best) {
+			best = epic
+		}
+	}
+	return best, float64(counts[best]) / float64(len(members))
+}
@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDetectClusters(t *testing.T) {
+	link := func(id string, deps ...string) model.Issue {
+		issue := model.Issue{ID: id}
+		for i := 0; i < len(deps); i += 2 {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: deps[i], Type: model.DependencyType(deps[i+1])})
+		}
+		return issue
+	}
+	issues := []model.Issue{
+		{ID: "E1", IssueType: model.TypeEpic},
+		{ID: "E2", IssueType: model.TypeEpic},
+		// A triangle of work split across both epics, and a square in E2
+		link("A1", "E1", "parent-child", "A2", "blocks", "A3", "related"),
+		link("A2", "E1", "parent-child", "A3", "blocks"),
+		link("A3", "E2", "parent-child"),
+		link("B1", "E2", "parent-child", "B2", "blocks", "B4", "blocks"),
+		link("B2", "E2", "parent-child", "B3", "blocks"),
+		link("B3", "E2", "parent-child", "B4", "blocks", "A3", "blocks"),
+		link("B4", "E2", "parent-child", "B2", "related"),
+		link("LONE"),
+	}
+
+	c := DetectClusters(issues)
+	if len(c.Clusters) != 2 {
+		t.Fatalf("expected two clusters, got %+v", c.Clusters)
+	}
+	big, small := c.Clusters[0], c.Clusters[1]
+	if !reflect.DeepEqual(big.Members, []string{"B1", "B2", "B3", "B4"}) || big.Epic != "E2" || big.EpicShare != 1 {
+		t.Errorf("unexpected largest cluster %+v", big)
+	}
+	if !reflect.DeepEqual(small.Members, []string{"A1", "A2", "A3"}) || small.Epic != "E1" || small.EpicShare < 0.66 || small.EpicShare > 0.67 {
+		t.Errorf("expected the triangle mostly in E1, got %+v", small)
+	}
+	if c.Of["A3"] != 2 || c.Of["LONE"] != 0 || c.Of["E1"] != 0 {
+		t.Errorf("unexpected membership %v", c.Of)
+	}
+	if c.Modularity <= 0 {
+		t.Errorf("expected positive modularity, got %f", c.Modularity)
+	}
+	if again := DetectClusters(issues); !reflect.DeepEqual(again.Clusters, c.Clusters) {
+		t.Errorf("expected the same clusters on every run")
+	}
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IDCollision is an ID several records in the beads file share, as merging
+// diverged copies of the file leaves behind
+type IDCollision struct {
+	ID      string
+	Records []model.Issue // In file order
+	Kept    int           // Index in Records of the record in use
+	Pinned  bool          // Kept was chosen by hand rather than newest-wins
+}
+
+// ResolveIDCollisions keeps one record per ID so the views and the graph
+// see each issue once. pins maps an ID to the IssueHash of the record to
+// keep; otherwise the record updated last wins, the later in the file on a
+// tie. It returns the issues with the other records dropped, in file order,
+// and the collisions sorted by ID. Without collisions, issues is returned
+// as is.
+func ResolveIDCollisions(issues []model.Issue, pins map[string]string) ([]model.Issue, []IDCollision) {
+	count := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		count[issue.ID]++
+	}
+	byID := make(map[string]*IDCollision)
+	var collisions []*IDCollision
+	for _, issue := range issues {
+		if count[issue.ID] < 2 {
+			continue
+		}
+		c, ok := byID[issue.ID]
+		if !ok {
+			c = &IDCollision{ID: issue.ID}
+			byID[issue.ID] = c
+			collisions = append(collisions, c)
+		}
+		c.Records = append(c.Records, issue)
+	}
+	if len(collisions) == 0 {
+		return issues, nil
+	}
+
+	for _, c := range collisions {
+		for i, record := range c.Records {
+			if !record.UpdatedAt.Before(c.Records[c.Kept].UpdatedAt) {
+				c.Kept = i
+			}
+		}
+		if pin, ok := pins[c.ID]; ok {
+			for i, record := range c.Records {
+				if IssueHash(record) == pin {
+					c.Kept, c.Pinned = i, true
+					break
+				}
+			}
+		}
+	}
+
+	resolved := make([]model.Issue, 0, len(issues))
+	seen := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		c, ok := byID[issue.ID]
+		if !ok {
+			resolved = append(resolved, issue)
+			continue
+		}
+		if seen[issue.ID] == c.Kept {
+			resolved = append(resolved, issue)
+		}
+		seen[issue.ID]++
+	}
+
+	sorted := make([]IDCollision, len(collisions))
+	for i, c := range collisions {
+		sorted[i] = *c
+	}
+	sort.Slice(sorted, func(i, j int) bool { return naturalLess(sorted[i].ID, sorted[j].ID) })
+	return resolved, sorted
+}
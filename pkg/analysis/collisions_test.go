@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestResolveIDCollisions(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "B", Title: "B newer", UpdatedAt: day.Add(time.Hour)},
+		{ID: "A", Title: "A only"},
+		{ID: "B", Title: "B older", UpdatedAt: day},
+		{ID: "C", Title: "C first", UpdatedAt: day},
+		{ID: "C", Title: "C second", UpdatedAt: day},
+	}
+	resolved, collisions := ResolveIDCollisions(issues, nil)
+	var titles []string
+	for _, issue := range resolved {
+		titles = append(titles, issue.Title)
+	}
+	if got, want := titles, []string{"B newer", "A only", "C second"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if len(collisions) != 2 || collisions[0].ID != "B" || collisions[0].Kept != 0 || collisions[1].Kept != 1 ||
+		len(collisions[1].Records) != 2 || collisions[0].Pinned {
+		t.Fatalf("unexpected collisions %+v", collisions)
+	}
+
+	// A pin keeps the chosen record, even the older one
+	resolved, collisions = ResolveIDCollisions(issues, map[string]string{"B": IssueHash(issues[2]), "C": "gone"})
+	// Records stay in file order: B's kept one is now its second
+	if resolved[1].Title != "B older" || !collisions[0].Pinned || collisions[0].Kept != 1 {
+		t.Errorf("expected the pinned B kept, got %q / %+v", resolved[1].Title, collisions[0])
+	}
+	if resolved[2].Title != "C second" || collisions[1].Pinned {
+		t.Errorf("expected a stale pin to fall back to newest-wins, got %q", resolved[2].Title)
+	}
+
+	unique := issues[1:2]
+	if resolved, collisions := ResolveIDCollisions(unique, nil); &resolved[0] != &unique[0] || collisions != nil {
+		t.Errorf("expected issues without collisions returned as is")
+	}
+}
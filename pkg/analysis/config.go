@@ -29,6 +29,11 @@ type AnalysisConfig struct {
 	MaxCyclesToStore int
 	CyclesSkipReason string
 
+	// Closeness centrality (BFS from every node: O(V*(V+E)))
+	ComputeCloseness    bool
+	ClosenessTimeout    time.Duration
+	ClosenessSkipReason string
+
 	// Eigenvector centrality (usually fast)
 	ComputeEigenvector bool
 
@@ -50,6 +55,9 @@ func DefaultConfig() AnalysisConfig {
 		ComputeHITS: true,
 		HITSTimeout: 500 * time.Millisecond,
 
+		ComputeCloseness: true,
+		ClosenessTimeout: 500 * time.Millisecond,
+
 		ComputeCycles:    true,
 		CyclesTimeout:    500 * time.Millisecond,
 		MaxCyclesToStore: 100,
@@ -87,6 +95,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			ComputeHITS: true,
 			HITSTimeout: 2 * time.Second,
 
+			ComputeCloseness: true,
+			ClosenessTimeout: 2 * time.Second,
+
 			ComputeCycles:    true,
 			CyclesTimeout:    2 * time.Second,
 			MaxCyclesToStore: 1000,
@@ -108,6 +119,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			ComputeHITS: true,
 			HITSTimeout: 500 * time.Millisecond,
 
+			ComputeCloseness: true,
+			ClosenessTimeout: 500 * time.Millisecond,
+
 			ComputeCycles:    true,
 			CyclesTimeout:    500 * time.Millisecond,
 			MaxCyclesToStore: 100,
@@ -125,6 +139,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			ComputeHITS: true,
 			HITSTimeout: 300 * time.Millisecond,
 
+			ComputeCloseness: true,
+			ClosenessTimeout: 300 * time.Millisecond,
+
 			ComputeCycles:    true,
 			CyclesTimeout:    300 * time.Millisecond,
 			MaxCyclesToStore: 50,
@@ -163,6 +180,9 @@ func ConfigForSize(nodeCount, edgeCount int) AnalysisConfig {
 			CyclesSkipReason:    "graph too large (>2000 nodes)",
 			MaxCyclesToStore:    10,
 
+			ComputeCloseness:    false,
+			ClosenessSkipReason: "graph too large (>2000 nodes)",
+
 			ComputeEigenvector:  true,
 			ComputeCriticalPath: true,
 		}
@@ -194,6 +214,9 @@ func FullAnalysisConfig() AnalysisConfig {
 		ComputeHITS: true,
 		HITSTimeout: 30 * time.Second,
 
+		ComputeCloseness: true,
+		ClosenessTimeout: 30 * time.Second,
+
 		ComputeCycles:    true,
 		CyclesTimeout:    30 * time.Second,
 		MaxCyclesToStore: 10000,
@@ -225,6 +248,12 @@ func (c AnalysisConfig) SkippedMetrics() []SkippedMetric {
 			Reason: c.HITSSkipReason,
 		})
 	}
+	if !c.ComputeCloseness {
+		skipped = append(skipped, SkippedMetric{
+			Name:   "Closeness",
+			Reason: c.ClosenessSkipReason,
+		})
+	}
 	if !c.ComputeCycles {
 		skipped = append(skipped, SkippedMetric{
 			Name:   "Cycles",
@@ -116,6 +116,11 @@ func TestConfigForSize_XLGraph(t *testing.T) {
 		t.Error("Expected skip reason for cycles")
 	}
 
+	// Should skip closeness (all-pairs BFS)
+	if cfg.ComputeCloseness || cfg.ClosenessSkipReason == "" {
+		t.Error("Expected closeness disabled with a reason for XL graph")
+	}
+
 	// PageRank should still be enabled
 	if !cfg.ComputePageRank {
 		t.Error("Expected pagerank enabled for XL graph")
@@ -151,6 +156,7 @@ func TestSkippedMetrics(t *testing.T) {
 		BetweennessSkipReason: "test reason",
 		ComputePageRank:       true,
 		ComputeHITS:           true,
+		ComputeCloseness:      true,
 		ComputeCycles:         false,
 		CyclesSkipReason:      "cycles disabled",
 	}
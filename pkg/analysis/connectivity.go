@@ -0,0 +1,114 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Connectivity describes how issues hang together through dependencies of any
+// type, treating links as undirected
+type Connectivity struct {
+	// Islands are open issues with no dependencies and no dependents
+	Islands []string `json:"islands"`
+
+	// Components are groups of two or more linked issues, largest first.
+	// Components[0] is the main plan; the rest are detached from it.
+	Components [][]string `json:"components"`
+
+	componentOf map[string]int
+}
+
+// IsIsland reports whether id is an open issue with no links at all
+func (c Connectivity) IsIsland(id string) bool {
+	i := sort.SearchStrings(c.Islands, id)
+	return i < len(c.Islands) && c.Islands[i] == id
+}
+
+// IsDetached reports whether id belongs to a linked group that is not connected
+// to the main plan (the largest component)
+func (c Connectivity) IsDetached(id string) bool {
+	idx, ok := c.componentOf[id]
+	return ok && idx > 0
+}
+
+// DetachedComponents returns the components not connected to the main plan
+func (c Connectivity) DetachedComponents() [][]string {
+	if len(c.Components) < 2 {
+		return nil
+	}
+	return c.Components[1:]
+}
+
+// ComputeConnectivity finds island issues and connected components. Links to
+// issues that aren't loaded are ignored. Closed issues still connect the graph
+// but are never reported as islands.
+func ComputeConnectivity(issues []model.Issue) Connectivity {
+	known := make(map[string]bool, len(issues))
+	for i := range issues {
+		known[issues[i].ID] = true
+	}
+
+	// Union-find over linked issues
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(id string) string {
+		p, ok := parent[id]
+		if !ok {
+			parent[id] = id
+			return id
+		}
+		if p == id {
+			return id
+		}
+		root := find(p)
+		parent[id] = root
+		return root
+	}
+	for i := range issues {
+		issue := &issues[i]
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !known[dep.DependsOnID] || dep.DependsOnID == issue.ID {
+				continue
+			}
+			a, b := find(issue.ID), find(dep.DependsOnID)
+			if a != b {
+				parent[a] = b
+			}
+		}
+	}
+
+	var c Connectivity
+	groups := make(map[string][]string)
+	for i := range issues {
+		id := issues[i].ID
+		if _, linked := parent[id]; !linked {
+			if !issues[i].Status.IsClosed() {
+				c.Islands = append(c.Islands, id)
+			}
+			continue
+		}
+		root := find(id)
+		groups[root] = append(groups[root], id)
+	}
+	sort.Strings(c.Islands)
+
+	for _, members := range groups {
+		sort.Strings(members)
+		c.Components = append(c.Components, members)
+	}
+	sort.Slice(c.Components, func(i, j int) bool {
+		if len(c.Components[i]) != len(c.Components[j]) {
+			return len(c.Components[i]) > len(c.Components[j])
+		}
+		return c.Components[i][0] < c.Components[j][0]
+	})
+
+	c.componentOf = make(map[string]int, len(parent))
+	for idx, members := range c.Components {
+		for _, id := range members {
+			c.componentOf[id] = idx
+		}
+	}
+	return c
+}
@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeConnectivity(t *testing.T) {
+	dep := func(id, on string, typ model.DependencyType) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: typ}}
+	}
+	issues := []model.Issue{
+		// Main plan: A <- B <- C, D is a child of A
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: dep("B", "A", model.DepBlocks)},
+		{ID: "C", Status: model.StatusClosed, Dependencies: dep("C", "B", model.DepBlocks)},
+		{ID: "D", Status: model.StatusOpen, Dependencies: dep("D", "A", model.DepParentChild)},
+		// Detached pair linked only to each other
+		{ID: "X", Status: model.StatusOpen},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: dep("Y", "X", model.DepRelated)},
+		// Islands: no links (missing targets don't count); closed islands are ignored
+		{ID: "I1", Status: model.StatusOpen},
+		{ID: "I2", Status: model.StatusOpen, Dependencies: dep("I2", "missing", model.DepBlocks)},
+		{ID: "I3", Status: model.StatusClosed},
+	}
+
+	c := ComputeConnectivity(issues)
+	if len(c.Islands) != 2 || c.Islands[0] != "I1" || c.Islands[1] != "I2" {
+		t.Fatalf("unexpected islands %v", c.Islands)
+	}
+	if !c.IsIsland("I2") || c.IsIsland("A") || c.IsIsland("I3") {
+		t.Fatalf("unexpected IsIsland results")
+	}
+	if len(c.Components) != 2 || len(c.Components[0]) != 4 {
+		t.Fatalf("expected main component of 4 and one detached, got %v", c.Components)
+	}
+	detached := c.DetachedComponents()
+	if len(detached) != 1 || detached[0][0] != "X" || detached[0][1] != "Y" {
+		t.Fatalf("unexpected detached components %v", detached)
+	}
+	if !c.IsDetached("Y") || c.IsDetached("A") || c.IsDetached("I1") {
+		t.Fatalf("unexpected IsDetached results")
+	}
+}
@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// CrossEpicDependency is a blocking dependency between issues in different
+// epics: IssueID, in FromEpic, waits on DependsOnID, in ToEpic
+type CrossEpicDependency struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	FromEpic    string `json:"from_epic"`
+	ToEpic      string `json:"to_epic"`
+	Open        bool   `json:"open"` // The blocker isn't closed yet
+}
+
+// EpicCoupling counts the blocking dependencies between each pair of epics
+type EpicCoupling struct {
+	// Epics with at least one crossing, most coupled first
+	Epics []string `json:"epics"`
+
+	// Counts[from][to] is how many issues in epic from wait on epic to
+	Counts map[string]map[string]int `json:"counts"`
+
+	// Crossings are the dependencies, grouped by the epics they join in
+	// the order of Epics, then by issue
+	Crossings []CrossEpicDependency `json:"crossings"`
+}
+
+// Count returns how many issues in epic from wait on issues in epic to
+func (c EpicCoupling) Count(from, to string) int {
+	return c.Counts[from][to]
+}
+
+// ComputeEpicCoupling finds the blocking dependencies that cross epic
+// boundaries. An issue belongs to its nearest epic ancestor through
+// parent-child links; issues outside any epic are left out.
+func ComputeEpicCoupling(issues []model.Issue) EpicCoupling {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	epics := epicMembership(issues, issueMap)
+
+	c := EpicCoupling{Counts: make(map[string]map[string]int)}
+	total := make(map[string]int)
+	for _, issue := range issues {
+		from := epics[issue.ID]
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !isBlockingDep(dep.Type) {
+				continue
+			}
+			to := epics[dep.DependsOnID]
+			if from == "" || to == "" || from == to {
+				continue
+			}
+			blocker := issueMap[dep.DependsOnID]
+			c.Crossings = append(c.Crossings, CrossEpicDependency{
+				IssueID:     issue.ID,
+				DependsOnID: dep.DependsOnID,
+				FromEpic:    from,
+				ToEpic:      to,
+				Open:        !blocker.Status.IsClosed(),
+			})
+			if c.Counts[from] == nil {
+				c.Counts[from] = make(map[string]int)
+			}
+			c.Counts[from][to]++
+			total[from]++
+			total[to]++
+		}
+	}
+
+	for epic := range total {
+		c.Epics = append(c.Epics, epic)
+	}
+	sort.Slice(c.Epics, func(i, j int) bool {
+		if total[c.Epics[i]] != total[c.Epics[j]] {
+			return total[c.Epics[i]] > total[c.Epics[j]]
+		}
+		return c.Epics[i] < c.Epics[j]
+	})
+	rank := make(map[string]int, len(c.Epics))
+	for i, epic := range c.Epics {
+		rank[epic] = i
+	}
+	sort.SliceStable(c.Crossings, func(i, j int) bool {
+		a, b := c.Crossings[i], c.Crossings[j]
+		if a.FromEpic != b.FromEpic {
+			return rank[a.FromEpic] < rank[b.FromEpic]
+		}
+		if a.ToEpic != b.ToEpic {
+			return rank[a.ToEpic] < rank[b.ToEpic]
+		}
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.DependsOnID < b.DependsOnID
+	})
+	return c
+}
+
+// epicMembership maps each issue to its nearest epic ancestor through
+// parent-child links (an epic to itself), or "" when it has none
+func epicMembership(issues []model.Issue, issueMap map[string]*model.Issue) map[string]string {
+	parents := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				parents[issue.ID] = append(parents[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+
+	epics := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		// Breadth first, so the nearest epic wins
+		seen := map[string]bool{issue.ID: true}
+		queue := []string{issue.ID}
+		for len(queue) > 0 && epics[issue.ID] == "" {
+			id := queue[0]
+			queue = queue[1:]
+			if it, ok := issueMap[id]; ok && it.IssueType == model.TypeEpic {
+				epics[issue.ID] = id
+				break
+			}
+			for _, p := range parents[id] {
+				if !seen[p] {
+					seen[p] = true
+					queue = append(queue, p)
+				}
+			}
+		}
+	}
+	return epics
+}
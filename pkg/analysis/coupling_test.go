@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeEpicCoupling(t *testing.T) {
+	deps := func(pairs ...string) []*model.Dependency {
+		var out []*model.Dependency
+		for i := 0; i < len(pairs); i += 2 {
+			out = append(out, &model.Dependency{DependsOnID: pairs[i], Type: model.DependencyType(pairs[i+1])})
+		}
+		return out
+	}
+	issues := []model.Issue{
+		{ID: "EA", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "EB", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "EC", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "A1", Status: model.StatusOpen, Dependencies: deps("EA", "parent-child", "B1", "blocks", "A2", "blocks")},
+		// A grandchild of EA, blocked by EB and EC
+		{ID: "A2", Status: model.StatusOpen, Dependencies: deps("A1x", "parent-child", "B2", "blocks", "C1", "")},
+		{ID: "A1x", Status: model.StatusOpen, Dependencies: deps("EA", "parent-child")},
+		{ID: "B1", Status: model.StatusOpen, Dependencies: deps("EB", "parent-child")},
+		{ID: "B2", Status: model.StatusClosed, Dependencies: deps("EB", "parent-child", "A1", "related")},
+		{ID: "C1", Status: model.StatusOpen, Dependencies: deps("EC", "parent-child")},
+		// Outside any epic: not counted
+		{ID: "X", Status: model.StatusOpen, Dependencies: deps("A1", "blocks")},
+	}
+
+	c := ComputeEpicCoupling(issues)
+	if len(c.Crossings) != 3 {
+		t.Fatalf("expected 3 crossings, got %+v", c.Crossings)
+	}
+	if c.Count("EA", "EB") != 2 || c.Count("EA", "EC") != 1 || c.Count("EB", "EA") != 0 {
+		t.Errorf("unexpected counts %v", c.Counts)
+	}
+	if len(c.Epics) != 3 || c.Epics[0] != "EA" || c.Epics[1] != "EB" || c.Epics[2] != "EC" {
+		t.Errorf("expected epics ordered by coupling, got %v", c.Epics)
+	}
+	first := c.Crossings[0]
+	if first.IssueID != "A1" || first.DependsOnID != "B1" || !first.Open {
+		t.Errorf("expected A1 waiting on the open B1 first, got %+v", first)
+	}
+	if c.Crossings[1].DependsOnID != "B2" || c.Crossings[1].Open {
+		t.Errorf("expected A2 waiting on the closed B2 second, got %+v", c.Crossings[1])
+	}
+}
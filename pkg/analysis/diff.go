@@ -20,6 +20,10 @@ type Snapshot struct {
 	OpenCount    int `json:"open_count"`
 	ClosedCount  int `json:"closed_count"`
 	BlockedCount int `json:"blocked_count"`
+	ReadyCount   int `json:"ready_count"` // Open issues with no open blockers
+
+	// Longest chain of open issues linked by blocking dependencies
+	CriticalPath []string `json:"critical_path,omitempty"`
 }
 
 // NewSnapshot creates a snapshot from issues
@@ -65,6 +69,10 @@ func (s *Snapshot) computeCounts() {
 			s.OpenCount++
 		}
 	}
+
+	blockers := OpenBlockers(s.Issues)
+	s.ReadyCount = countReady(blockers)
+	s.CriticalPath = longestOpenChain(blockers)
 }
 
 // SnapshotDiff represents the differences between two snapshots
@@ -86,6 +94,16 @@ type SnapshotDiff struct {
 	NewCycles      [][]string `json:"new_cycles"`      // Cycles appearing in To
 	ResolvedCycles [][]string `json:"resolved_cycles"` // Cycles resolved (were in From, not in To)
 
+	// Dependency edge changes
+	AddedDependencies   []DependencyChange `json:"added_dependencies"`
+	RemovedDependencies []DependencyChange `json:"removed_dependencies"`
+
+	// Ready work and critical path on each side
+	FromReadyCount   int      `json:"from_ready_count"`
+	ToReadyCount     int      `json:"to_ready_count"`
+	FromCriticalPath []string `json:"from_critical_path"`
+	ToCriticalPath   []string `json:"to_critical_path"`
+
 	// Metric deltas
 	MetricDeltas MetricDeltas `json:"metric_deltas"`
 
@@ -115,25 +133,30 @@ type MetricDeltas struct {
 	OpenIssues     int     `json:"open_issues"`
 	ClosedIssues   int     `json:"closed_issues"`
 	BlockedIssues  int     `json:"blocked_issues"`
+	ReadyIssues    int     `json:"ready_issues"`
 	TotalEdges     int     `json:"total_edges"`
 	CycleCount     int     `json:"cycle_count"`
 	ComponentCount int     `json:"component_count"`
 	AvgPageRank    float64 `json:"avg_pagerank"`
 	AvgBetweenness float64 `json:"avg_betweenness"`
+
+	CriticalPathLength int `json:"critical_path_length"`
 }
 
 // DiffSummary provides quick overview of changes
 type DiffSummary struct {
-	TotalChanges     int    `json:"total_changes"`
-	IssuesAdded      int    `json:"issues_added"`
-	IssuesClosed     int    `json:"issues_closed"`
-	IssuesRemoved    int    `json:"issues_removed"`
-	IssuesReopened   int    `json:"issues_reopened"`
-	IssuesModified   int    `json:"issues_modified"`
-	CyclesIntroduced int    `json:"cycles_introduced"`
-	CyclesResolved   int    `json:"cycles_resolved"`
-	NetIssueChange   int    `json:"net_issue_change"`
-	HealthTrend      string `json:"health_trend"` // "improving", "degrading", "stable"
+	TotalChanges        int    `json:"total_changes"`
+	IssuesAdded         int    `json:"issues_added"`
+	IssuesClosed        int    `json:"issues_closed"`
+	IssuesRemoved       int    `json:"issues_removed"`
+	IssuesReopened      int    `json:"issues_reopened"`
+	IssuesModified      int    `json:"issues_modified"`
+	CyclesIntroduced    int    `json:"cycles_introduced"`
+	CyclesResolved      int    `json:"cycles_resolved"`
+	DependenciesAdded   int    `json:"dependencies_added"`
+	DependenciesRemoved int    `json:"dependencies_removed"`
+	NetIssueChange      int    `json:"net_issue_change"`
+	HealthTrend         string `json:"health_trend"` // "improving", "degrading", "stable"
 }
 
 // CompareSnapshots computes the diff between two snapshots
@@ -202,6 +225,11 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 	// Compare cycles
 	diff.NewCycles, diff.ResolvedCycles = compareCycles(from.Stats, to.Stats)
 
+	// Compare dependency edges, ready work and critical path
+	diff.AddedDependencies, diff.RemovedDependencies = compareDependencies(from.Issues, to.Issues)
+	diff.FromReadyCount, diff.ToReadyCount = from.ReadyCount, to.ReadyCount
+	diff.FromCriticalPath, diff.ToCriticalPath = from.CriticalPath, to.CriticalPath
+
 	// Calculate metric deltas
 	diff.MetricDeltas = calculateMetricDeltas(from, to)
 
@@ -218,6 +246,14 @@ func CompareSnapshots(from, to *Snapshot) *SnapshotDiff {
 	return diff
 }
 
+// dueDateString formats an optional due date for change reporting
+func dueDateString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
 // detectChanges identifies what fields changed between two issues
 func detectChanges(from, to model.Issue) []FieldChange {
 	var changes []FieldChange
@@ -262,6 +298,14 @@ func detectChanges(from, to model.Issue) []FieldChange {
 		})
 	}
 
+	if fromDue, toDue := dueDateString(from.DueDate), dueDateString(to.DueDate); fromDue != toDue {
+		changes = append(changes, FieldChange{
+			Field:    "due_date",
+			OldValue: fromDue,
+			NewValue: toDue,
+		})
+	}
+
 	// Check for text field changes (Description, Design, AC, Notes)
 	if from.Description != to.Description {
 		changes = append(changes, FieldChange{
@@ -406,6 +450,9 @@ func calculateMetricDeltas(from, to *Snapshot) MetricDeltas {
 	deltas.OpenIssues = to.OpenCount - from.OpenCount
 	deltas.ClosedIssues = to.ClosedCount - from.ClosedCount
 	deltas.BlockedIssues = to.BlockedCount - from.BlockedCount
+	deltas.ReadyIssues = to.ReadyCount - from.ReadyCount
+	deltas.TotalEdges = len(dependencyEdges(to.Issues)) - len(dependencyEdges(from.Issues))
+	deltas.CriticalPathLength = len(to.CriticalPath) - len(from.CriticalPath)
 
 	// Graph-level metrics from Stats
 	if from.Stats != nil && to.Stats != nil {
@@ -464,6 +511,9 @@ func calculateSummary(diff *SnapshotDiff) DiffSummary {
 		IssuesModified:   len(diff.ModifiedIssues),
 		CyclesIntroduced: len(diff.NewCycles),
 		CyclesResolved:   len(diff.ResolvedCycles),
+
+		DependenciesAdded:   len(diff.AddedDependencies),
+		DependenciesRemoved: len(diff.RemovedDependencies),
 	}
 
 	summary.TotalChanges = summary.IssuesAdded + summary.IssuesClosed +
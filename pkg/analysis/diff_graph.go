@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DependencyChange is a dependency edge that appeared or disappeared between snapshots
+type DependencyChange struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+}
+
+// dependencyEdges returns every dependency in issues keyed by issue, target and type
+func dependencyEdges(issues []model.Issue) map[DependencyChange]bool {
+	edges := make(map[DependencyChange]bool)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.DependsOnID == "" {
+				continue
+			}
+			edges[DependencyChange{IssueID: issue.ID, DependsOnID: dep.DependsOnID, Type: string(dep.Type)}] = true
+		}
+	}
+	return edges
+}
+
+// compareDependencies finds dependency edges added and removed between snapshots
+func compareDependencies(from, to []model.Issue) (added, removed []DependencyChange) {
+	fromEdges := dependencyEdges(from)
+	toEdges := dependencyEdges(to)
+
+	for edge := range toEdges {
+		if !fromEdges[edge] {
+			added = append(added, edge)
+		}
+	}
+	for edge := range fromEdges {
+		if !toEdges[edge] {
+			removed = append(removed, edge)
+		}
+	}
+
+	sortDependencyChanges(added)
+	sortDependencyChanges(removed)
+	return added, removed
+}
+
+func sortDependencyChanges(changes []DependencyChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].IssueID != changes[j].IssueID {
+			return changes[i].IssueID < changes[j].IssueID
+		}
+		if changes[i].DependsOnID != changes[j].DependsOnID {
+			return changes[i].DependsOnID < changes[j].DependsOnID
+		}
+		return changes[i].Type < changes[j].Type
+	})
+}
+
+// OpenBlockers maps each open issue to the open issues that block it.
+// Blockers that are closed or missing from the snapshot don't count.
+func OpenBlockers(issues []model.Issue) map[string][]string {
+	open := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed {
+			open[issue.ID] = true
+		}
+	}
+
+	blockers := make(map[string][]string, len(open))
+	for _, issue := range issues {
+		if !open[issue.ID] {
+			continue
+		}
+		blockers[issue.ID] = nil
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !isBlockingDep(dep.Type) || !open[dep.DependsOnID] || dep.DependsOnID == issue.ID {
+				continue
+			}
+			blockers[issue.ID] = append(blockers[issue.ID], dep.DependsOnID)
+		}
+		sort.Strings(blockers[issue.ID])
+	}
+	return blockers
+}
+
+// countReady returns how many open issues have no open blockers, matching
+// Analyzer.GetActionableIssues without building a graph
+func countReady(blockers map[string][]string) int {
+	ready := 0
+	for _, b := range blockers {
+		if len(b) == 0 {
+			ready++
+		}
+	}
+	return ready
+}
+
+// OpenCriticalPath returns the longest chain of open issues linked by
+// blocking dependencies, from the first issue to work on to the last
+func OpenCriticalPath(issues []model.Issue) []string {
+	return longestOpenChain(OpenBlockers(issues))
+}
+
+// longestOpenChain returns the longest chain of open issues linked by blocking
+// dependencies, ordered from the first issue to work on to the last. Edges
+// that close a cycle are skipped so the walk always terminates; ties go to
+// the chain ending at the lowest ID.
+func longestOpenChain(blockers map[string][]string) []string {
+	ids := make([]string, 0, len(blockers))
+	for id := range blockers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	chains := make(map[string][]string, len(ids))
+	visiting := make(map[string]bool)
+
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if chain, ok := chains[id]; ok {
+			return chain
+		}
+		visiting[id] = true
+		var longest []string
+		for _, b := range blockers[id] {
+			if visiting[b] {
+				continue
+			}
+			if chain := walk(b); len(chain) > len(longest) {
+				longest = chain
+			}
+		}
+		visiting[id] = false
+
+		chain := make([]string, len(longest), len(longest)+1)
+		copy(chain, longest)
+		chain = append(chain, id)
+		chains[id] = chain
+		return chain
+	}
+
+	var best []string
+	for _, id := range ids {
+		if chain := walk(id); len(chain) > len(best) {
+			best = chain
+		}
+	}
+	return best
+}
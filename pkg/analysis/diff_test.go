@@ -231,6 +231,17 @@ func TestDetectChanges(t *testing.T) {
 	}
 }
 
+func TestDetectChanges_DueDate(t *testing.T) {
+	due := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	from := model.Issue{ID: "TEST-1", Title: "Same"}
+	to := model.Issue{ID: "TEST-1", Title: "Same", DueDate: &due}
+
+	changes := detectChanges(from, to)
+	if len(changes) != 1 || changes[0].Field != "due_date" || changes[0].NewValue != "2025-03-10" {
+		t.Fatalf("expected due_date change, got %+v", changes)
+	}
+}
+
 func TestNormalizeCycle(t *testing.T) {
 	// Same cycle in different orders should normalize the same
 	cycle1 := []string{"A", "B", "C"}
@@ -415,3 +426,58 @@ func TestCompareSnapshots_DependencyTypeChange(t *testing.T) {
 		t.Error("expected dependency change to be detected when Type changes from related to blocks")
 	}
 }
+
+func TestCompareSnapshots_DependenciesReadyAndCriticalPath(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	from := NewSnapshot([]model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Dependencies: blocks("B")},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+	})
+	to := NewSnapshot([]model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusClosed},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+		{ID: "C", Title: "C", Status: model.StatusOpen},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Dependencies: blocks("B")},
+	})
+
+	if from.ReadyCount != 2 || to.ReadyCount != 2 {
+		t.Errorf("expected 2 ready on both sides, got %d and %d", from.ReadyCount, to.ReadyCount)
+	}
+	if got := from.CriticalPath; len(got) != 3 || got[0] != "A" || got[2] != "C" {
+		t.Errorf("expected from critical path A → B → C, got %v", got)
+	}
+	if got := to.CriticalPath; len(got) != 2 || got[0] != "B" || got[1] != "D" {
+		t.Errorf("expected to critical path B → D (A is closed), got %v", got)
+	}
+
+	diff := CompareSnapshots(from, to)
+	if len(diff.AddedDependencies) != 1 || diff.AddedDependencies[0] != (DependencyChange{IssueID: "D", DependsOnID: "B", Type: "blocks"}) {
+		t.Errorf("unexpected added dependencies: %+v", diff.AddedDependencies)
+	}
+	if len(diff.RemovedDependencies) != 2 {
+		t.Errorf("expected C→B and D→A removed, got %+v", diff.RemovedDependencies)
+	}
+	if diff.Summary.DependenciesAdded != 1 || diff.Summary.DependenciesRemoved != 2 {
+		t.Errorf("unexpected dependency summary: %+v", diff.Summary)
+	}
+	if diff.MetricDeltas.TotalEdges != -1 || diff.MetricDeltas.CriticalPathLength != -1 || diff.MetricDeltas.ReadyIssues != 0 {
+		t.Errorf("unexpected metric deltas: %+v", diff.MetricDeltas)
+	}
+	if diff.FromReadyCount != 2 || diff.ToReadyCount != 2 {
+		t.Errorf("unexpected ready counts %d → %d", diff.FromReadyCount, diff.ToReadyCount)
+	}
+}
+
+func TestLongestOpenChain_Cycle(t *testing.T) {
+	chain := longestOpenChain(map[string][]string{
+		"A": {"B"},
+		"B": {"A"},
+	})
+	if len(chain) != 2 {
+		t.Errorf("expected cycle to yield a two-issue chain, got %v", chain)
+	}
+}
@@ -0,0 +1,213 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// diskCacheVersion is bumped whenever the persisted metric set changes, so
+// files written by older versions are ignored rather than misread.
+const diskCacheVersion = 1
+
+// DefaultDiskCacheEntries is how many data hashes the disk cache keeps
+const DefaultDiskCacheEntries = 20
+
+// DiskCache persists finished analysis results across runs, one file per data
+// hash. The hash covers everything the metrics depend on, so entries never go
+// stale; old ones are pruned to keep the directory small.
+type DiskCache struct {
+	dir        string
+	maxEntries int
+}
+
+// diskCacheEntry is the on-disk form of GraphStats
+type diskCacheEntry struct {
+	Version          int                `json:"version"`
+	Hash             string             `json:"hash"`
+	ComputedAt       time.Time          `json:"computed_at"`
+	Config           AnalysisConfig     `json:"config"`
+	OutDegree        map[string]int     `json:"out_degree"`
+	InDegree         map[string]int     `json:"in_degree"`
+	TopologicalOrder []string           `json:"topological_order"`
+	Density          float64            `json:"density"`
+	NodeCount        int                `json:"node_count"`
+	EdgeCount        int                `json:"edge_count"`
+	PageRank         map[string]float64 `json:"pagerank"`
+	Betweenness      map[string]float64 `json:"betweenness"`
+	Eigenvector      map[string]float64 `json:"eigenvector"`
+	Hubs             map[string]float64 `json:"hubs"`
+	Authorities      map[string]float64 `json:"authorities"`
+	Closeness        map[string]float64 `json:"closeness"`
+	CriticalPath     map[string]float64 `json:"critical_path"`
+	Cycles           [][]string         `json:"cycles"`
+}
+
+// DefaultDiskCacheDir returns the per-user cache directory for bv insights
+func DefaultDiskCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "bv", "insights"), nil
+}
+
+// NewDiskCache creates a disk cache rooted at dir. The directory is created on first write.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir, maxEntries: DefaultDiskCacheEntries}
+}
+
+// path returns the file holding the entry for hash
+func (d *DiskCache) path(hash string) string {
+	return filepath.Join(d.dir, hash+".json")
+}
+
+// Load returns the stats stored for hash if they were computed with the same
+// metric selection as config. Any read or decode problem is a miss.
+func (d *DiskCache) Load(hash string, config AnalysisConfig) (*GraphStats, bool) {
+	data, err := os.ReadFile(d.path(hash))
+	if err != nil {
+		return nil, false
+	}
+	var e diskCacheEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	if e.Version != diskCacheVersion || e.Hash != hash || !sameMetricSelection(e.Config, config) {
+		return nil, false
+	}
+
+	stats := &GraphStats{
+		OutDegree:         e.OutDegree,
+		InDegree:          e.InDegree,
+		TopologicalOrder:  e.TopologicalOrder,
+		Density:           e.Density,
+		NodeCount:         e.NodeCount,
+		EdgeCount:         e.EdgeCount,
+		Config:            e.Config,
+		phase2Done:        make(chan struct{}),
+		pageRank:          e.PageRank,
+		betweenness:       e.Betweenness,
+		eigenvector:       e.Eigenvector,
+		hubs:              e.Hubs,
+		authorities:       e.Authorities,
+		closeness:         e.Closeness,
+		criticalPathScore: e.CriticalPath,
+		cycles:            e.Cycles,
+		phase2Ready:       true,
+	}
+	close(stats.phase2Done)
+	return stats, true
+}
+
+// Save writes finished stats for hash. Results with a timed-out metric are
+// not persisted, since a later run with more headroom might complete them.
+func (d *DiskCache) Save(hash string, stats *GraphStats) error {
+	stats.WaitForPhase2()
+	stats.mu.RLock()
+	timedOut := stats.timedOut
+	e := diskCacheEntry{
+		Version:          diskCacheVersion,
+		Hash:             hash,
+		ComputedAt:       time.Now(),
+		Config:           stats.Config,
+		OutDegree:        stats.OutDegree,
+		InDegree:         stats.InDegree,
+		TopologicalOrder: stats.TopologicalOrder,
+		Density:          stats.Density,
+		NodeCount:        stats.NodeCount,
+		EdgeCount:        stats.EdgeCount,
+		PageRank:         stats.pageRank,
+		Betweenness:      stats.betweenness,
+		Eigenvector:      stats.eigenvector,
+		Hubs:             stats.hubs,
+		Authorities:      stats.authorities,
+		Closeness:        stats.closeness,
+		CriticalPath:     stats.criticalPathScore,
+		Cycles:           stats.cycles,
+	}
+	data, err := json.Marshal(e)
+	stats.mu.RUnlock()
+	if timedOut {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("encoding insights cache: %w", err)
+	}
+
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return fmt.Errorf("creating insights cache dir: %w", err)
+	}
+	// Write to a temp file and rename so a concurrent reader never sees a partial file
+	tmp, err := os.CreateTemp(d.dir, hash+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing insights cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing insights cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing insights cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), d.path(hash)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("writing insights cache: %w", err)
+	}
+
+	d.prune()
+	return nil
+}
+
+// prune removes the oldest entries beyond maxEntries
+func (d *DiskCache) prune() {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		name    string
+		modTime time.Time
+	}
+	var files []file
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{e.Name(), info.ModTime()})
+	}
+	if len(files) <= d.maxEntries {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+	for _, f := range files[d.maxEntries:] {
+		os.Remove(filepath.Join(d.dir, f.name))
+	}
+}
+
+// sameMetricSelection reports whether two configs compute the same metrics
+// the same way. Timeouts and skip reasons don't affect the results.
+func sameMetricSelection(a, b AnalysisConfig) bool {
+	return a.ComputePageRank == b.ComputePageRank &&
+		a.ComputeBetweenness == b.ComputeBetweenness &&
+		a.BetweennessMode == b.BetweennessMode &&
+		a.BetweennessSampleSize == b.BetweennessSampleSize &&
+		a.ComputeEigenvector == b.ComputeEigenvector &&
+		a.ComputeHITS == b.ComputeHITS &&
+		a.ComputeCloseness == b.ComputeCloseness &&
+		a.ComputeCriticalPath == b.ComputeCriticalPath &&
+		a.ComputeCycles == b.ComputeCycles &&
+		a.MaxCyclesToStore == b.MaxCyclesToStore
+}
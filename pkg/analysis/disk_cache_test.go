@@ -0,0 +1,144 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func diskCacheTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Core", Status: model.StatusOpen},
+		{ID: "B", Title: "Dep", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Dep", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+}
+
+func TestDiskCacheRoundTrip(t *testing.T) {
+	issues := diskCacheTestIssues()
+	an := NewAnalyzer(issues)
+	stats := an.AnalyzeAsync()
+	hash := ComputeDataHash(issues)
+
+	d := NewDiskCache(t.TempDir())
+	if err := d.Save(hash, stats); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, ok := d.Load(hash, an.analysisConfig())
+	if !ok {
+		t.Fatal("expected disk cache hit")
+	}
+	if !loaded.IsPhase2Ready() {
+		t.Fatal("expected loaded stats to be Phase 2 ready")
+	}
+	loaded.WaitForPhase2() // Must not block
+	for _, id := range []string{"A", "B", "C"} {
+		if loaded.GetPageRankScore(id) != stats.GetPageRankScore(id) ||
+			loaded.GetCriticalPathScore(id) != stats.GetCriticalPathScore(id) ||
+			loaded.GetClosenessScore(id) != stats.GetClosenessScore(id) {
+			t.Errorf("metrics for %s differ after round trip", id)
+		}
+	}
+	if loaded.InDegree["A"] != 1 || len(loaded.TopologicalOrder) != 3 {
+		t.Errorf("Phase 1 data not restored: %+v", loaded.InDegree)
+	}
+
+	// A different metric selection, a different hash or a corrupt file is a miss
+	other := an.analysisConfig()
+	other.ComputeCloseness = !other.ComputeCloseness
+	if _, ok := d.Load(hash, other); ok {
+		t.Error("expected miss for a different metric selection")
+	}
+	if _, ok := d.Load("0123456789abcdef", an.analysisConfig()); ok {
+		t.Error("expected miss for unknown hash")
+	}
+	if err := os.WriteFile(d.path(hash), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Load(hash, an.analysisConfig()); ok {
+		t.Error("expected miss for corrupt file")
+	}
+}
+
+func TestDiskCacheSkipsTimedOutResults(t *testing.T) {
+	issues := diskCacheTestIssues()
+	stats := NewAnalyzer(issues).AnalyzeAsync()
+	stats.WaitForPhase2()
+	stats.mu.Lock()
+	stats.timedOut = true
+	stats.mu.Unlock()
+
+	d := NewDiskCache(t.TempDir())
+	hash := ComputeDataHash(issues)
+	if err := d.Save(hash, stats); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(d.path(hash)); !os.IsNotExist(err) {
+		t.Errorf("expected no cache file for timed-out results, got err=%v", err)
+	}
+}
+
+func TestDiskCachePrunesOldEntries(t *testing.T) {
+	d := NewDiskCache(t.TempDir())
+	d.maxEntries = 2
+	stats := NewAnalyzer(diskCacheTestIssues()).AnalyzeAsync()
+
+	for i, hash := range []string{"h1", "h2", "h3"} {
+		if err := d.Save(hash, stats); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		// Spread mtimes so pruning order is deterministic
+		mt := time.Now().Add(time.Duration(i-3) * time.Minute)
+		os.Chtimes(d.path(hash), mt, mt)
+	}
+	d.prune()
+
+	files, _ := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("expected 2 entries after pruning, got %d", len(files))
+	}
+	if _, err := os.Stat(d.path("h1")); !os.IsNotExist(err) {
+		t.Error("expected the oldest entry to be pruned")
+	}
+}
+
+func TestCachedAnalyzerUsesDiskCache(t *testing.T) {
+	issues := diskCacheTestIssues()
+	d := NewDiskCache(t.TempDir())
+
+	first := NewCache(DefaultCacheTTL)
+	first.SetDiskCache(d)
+	ca1 := NewCachedAnalyzer(issues, first)
+	ca1.AnalyzeAsync().WaitForPhase2()
+	if ca1.WasCacheHit() {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	// The write happens in the background after Phase 2
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(d.path(ca1.DataHash())); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("disk cache entry was never written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A fresh in-memory cache (a new process) picks the result up from disk
+	second := NewCache(DefaultCacheTTL)
+	second.SetDiskCache(d)
+	ca2 := NewCachedAnalyzer(issues, second)
+	stats := ca2.AnalyzeAsync()
+	if !ca2.WasCacheHit() || !stats.IsPhase2Ready() {
+		t.Fatal("expected a disk cache hit with Phase 2 ready")
+	}
+	if _, ok := second.GetByHash(ca2.DataHash()); !ok {
+		t.Error("expected the disk hit to populate the in-memory cache")
+	}
+}
@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DSM is a dependency structure matrix: the same issues on both axes, with
+// a cell wherever the row issue depends on the column issue
+type DSM struct {
+	// IDs orders the rows and columns, blockers and parents ahead of the
+	// issues that wait on them, so every mark falls below the diagonal
+	// except those of a cycle
+	IDs []string `json:"ids"`
+
+	// Cells[row][col] is the type of the row issue's dependency on the
+	// column issue
+	Cells map[string]map[string]model.DependencyType `json:"cells"`
+}
+
+// Cell returns the type of row's dependency on col, or "" when there is none
+func (d DSM) Cell(row, col string) model.DependencyType {
+	return d.Cells[row][col]
+}
+
+// BuildDSM builds the matrix of dependencies among issues, leaving out links
+// to issues outside the set
+func BuildDSM(issues []model.Issue) DSM {
+	d := DSM{Cells: make(map[string]map[string]model.DependencyType)}
+	inSet := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = true
+	}
+
+	// Count what each issue waits on, to order by levels: first the issues
+	// waiting on nothing, then those waiting only on the first level, ...
+	waits := make(map[string]int, len(issues))
+	waiters := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !inSet[dep.DependsOnID] || dep.DependsOnID == issue.ID {
+				continue
+			}
+			if d.Cells[issue.ID] == nil {
+				d.Cells[issue.ID] = make(map[string]model.DependencyType)
+			}
+			t := dep.Type
+			if t == "" {
+				t = model.DepBlocks // Legacy links without a type block
+			}
+			if _, dup := d.Cells[issue.ID][dep.DependsOnID]; dup {
+				continue
+			}
+			d.Cells[issue.ID][dep.DependsOnID] = t
+			if isBlockingDep(dep.Type) || dep.Type == model.DepParentChild {
+				waits[issue.ID]++
+				waiters[dep.DependsOnID] = append(waiters[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	var level []string
+	for _, issue := range issues {
+		if waits[issue.ID] == 0 {
+			level = append(level, issue.ID)
+		}
+	}
+	placed := make(map[string]bool, len(issues))
+	for len(level) > 0 {
+		sort.Strings(level)
+		var next []string
+		for _, id := range level {
+			placed[id] = true
+			d.IDs = append(d.IDs, id)
+			for _, w := range waiters[id] {
+				if waits[w]--; waits[w] == 0 {
+					next = append(next, w)
+				}
+			}
+		}
+		level = next
+	}
+
+	// Whatever is left waits on a cycle
+	var rest []string
+	for _, issue := range issues {
+		if !placed[issue.ID] {
+			rest = append(rest, issue.ID)
+		}
+	}
+	sort.Strings(rest)
+	d.IDs = append(d.IDs, rest...)
+	return d
+}
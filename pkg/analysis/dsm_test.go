@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildDSM(t *testing.T) {
+	dep := func(id string, typ model.DependencyType) *model.Dependency {
+		return &model.Dependency{DependsOnID: id, Type: typ}
+	}
+	issues := []model.Issue{
+		{ID: "C", Dependencies: []*model.Dependency{dep("B", model.DepBlocks), dep("A", model.DepRelated)}},
+		{ID: "B", Dependencies: []*model.Dependency{dep("A", "")}},
+		{ID: "A"},
+		// X and Y block each other
+		{ID: "Y", Dependencies: []*model.Dependency{dep("X", model.DepBlocks)}},
+		{ID: "X", Dependencies: []*model.Dependency{dep("Y", model.DepBlocks), dep("gone", model.DepBlocks)}},
+	}
+
+	d := BuildDSM(issues)
+	want := []string{"A", "B", "C", "X", "Y"}
+	if len(d.IDs) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, d.IDs)
+	}
+	for i := range want {
+		if d.IDs[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, d.IDs)
+		}
+	}
+	if d.Cell("B", "A") != model.DepBlocks {
+		t.Errorf("expected a legacy untyped link to show as blocks, got %q", d.Cell("B", "A"))
+	}
+	if d.Cell("C", "A") != model.DepRelated || d.Cell("A", "C") != "" {
+		t.Errorf("expected C related to A one way, got %q / %q", d.Cell("C", "A"), d.Cell("A", "C"))
+	}
+	if _, ok := d.Cells["X"]["gone"]; ok {
+		t.Errorf("expected links outside the set dropped")
+	}
+}
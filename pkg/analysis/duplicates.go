@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultDuplicateThreshold is the minimum similarity for a pair to be proposed
+const DefaultDuplicateThreshold = 0.6
+
+// DuplicatePair is a pair of open issues that look like the same work.
+// Keep is the older issue; Duplicate is the one that would be closed.
+type DuplicatePair struct {
+	Keep      string  `json:"keep"`
+	Duplicate string  `json:"duplicate"`
+	Score     float64 `json:"score"` // 0..1
+}
+
+// duplicateStopWords are dropped before comparing descriptions
+var duplicateStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "should": true, "that": true,
+	"the": true, "this": true, "to": true, "we": true, "when": true, "with": true,
+}
+
+// titleTrigrams returns the set of character trigrams of a normalized title.
+// Trigrams tolerate typos and plurals that would defeat word matching.
+func titleTrigrams(title string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	runes := []rune(" " + strings.Join(words, " ") + " ")
+	set := make(map[string]bool, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
+
+// descriptionTokens returns the set of significant words in a description
+func descriptionTokens(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) > 1 && !duplicateStopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// jaccard returns |a∩b| / |a∪b|, or 0 when both sets are empty
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	inter := 0
+	for k := range a {
+		if b[k] {
+			inter++
+		}
+	}
+	union := len(a) + len(b) - inter
+	if union == 0 {
+		return 0
+	}
+	return float64(inter) / float64(union)
+}
+
+// similarity blends the title trigram Jaccard 3:1 with the description word
+// Jaccard when both issues have a description
+func similarity(titleA, titleB, tokensA, tokensB map[string]bool) float64 {
+	score := jaccard(titleA, titleB)
+	if len(tokensA) > 0 && len(tokensB) > 0 {
+		score = 0.75*score + 0.25*jaccard(tokensA, tokensB)
+	}
+	return score
+}
+
+// Similarity scores how alike two issues are (0..1), as FindDuplicateCandidates does
+func Similarity(a, b model.Issue) float64 {
+	return similarity(titleTrigrams(a.Title), titleTrigrams(b.Title),
+		descriptionTokens(a.Description), descriptionTokens(b.Description))
+}
+
+// FindDuplicateCandidates proposes likely duplicate pairs among open issues.
+// Similarity is the trigram Jaccard of the titles, blended 3:1 with the word
+// Jaccard of the descriptions when both issues have one. Pairs already linked
+// by a dependency are skipped. Results are sorted by score, highest first.
+func FindDuplicateCandidates(issues []model.Issue, threshold float64) []DuplicatePair {
+	type candidate struct {
+		issue  *model.Issue
+		title  map[string]bool
+		tokens map[string]bool
+	}
+	var cands []candidate
+	linked := make(map[[2]string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				linked[[2]string{issue.ID, dep.DependsOnID}] = true
+				linked[[2]string{dep.DependsOnID, issue.ID}] = true
+			}
+		}
+		if issue.Status.IsClosed() || strings.TrimSpace(issue.Title) == "" {
+			continue
+		}
+		cands = append(cands, candidate{
+			issue:  issue,
+			title:  titleTrigrams(issue.Title),
+			tokens: descriptionTokens(issue.Description),
+		})
+	}
+
+	// The blended score is at most 0.75*title+0.25, and title Jaccard can't exceed
+	// the ratio of the set sizes, so pairs below this ratio can be skipped cheaply
+	minRatio := (threshold - 0.25) / 0.75
+
+	var pairs []DuplicatePair
+	for i := range cands {
+		for j := i + 1; j < len(cands); j++ {
+			a, b := cands[i], cands[j]
+			small, large := min(len(a.title), len(b.title)), max(len(a.title), len(b.title))
+			if large == 0 || float64(small)/float64(large) < minRatio {
+				continue
+			}
+			if linked[[2]string{a.issue.ID, b.issue.ID}] {
+				continue
+			}
+
+			score := similarity(a.title, b.title, a.tokens, b.tokens)
+			if score < threshold {
+				continue
+			}
+
+			keep, dup := a.issue, b.issue
+			if dup.CreatedAt.Before(keep.CreatedAt) || (dup.CreatedAt.Equal(keep.CreatedAt) && naturalLess(dup.ID, keep.ID)) {
+				keep, dup = dup, keep
+			}
+			pairs = append(pairs, DuplicatePair{Keep: keep.ID, Duplicate: dup.ID, Score: score})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].Keep != pairs[j].Keep {
+			return naturalLess(pairs[i].Keep, pairs[j].Keep)
+		}
+		return naturalLess(pairs[i].Duplicate, pairs[j].Duplicate)
+	})
+	return pairs
+}
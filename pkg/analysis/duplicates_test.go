@@ -0,0 +1,69 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFindDuplicateCandidates(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "B", Title: "Login fails on Safari", Status: model.StatusOpen, CreatedAt: base.Add(time.Hour)},
+		{ID: "A", Title: "Login failing on safari!", Status: model.StatusOpen, CreatedAt: base},
+		{ID: "C", Title: "Add dark mode toggle", Status: model.StatusOpen, CreatedAt: base},
+		// Same title as C but closed: not proposed
+		{ID: "D", Title: "Add dark mode toggle", Status: model.StatusClosed, CreatedAt: base},
+		// Same title as C but already linked to it
+		{ID: "E", Title: "Add dark mode toggles", Status: model.StatusOpen, CreatedAt: base,
+			Dependencies: []*model.Dependency{{IssueID: "E", DependsOnID: "C", Type: model.DepRelated}}},
+	}
+
+	pairs := FindDuplicateCandidates(issues, DefaultDuplicateThreshold)
+	if len(pairs) != 1 {
+		t.Fatalf("expected 1 pair, got %+v", pairs)
+	}
+	p := pairs[0]
+	if p.Keep != "A" || p.Duplicate != "B" {
+		t.Errorf("expected older A kept and B duplicate, got %+v", p)
+	}
+	if p.Score < DefaultDuplicateThreshold || p.Score > 1 {
+		t.Errorf("unexpected score %.2f", p.Score)
+	}
+}
+
+func TestFindDuplicateCandidatesDescriptions(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "X-1", Title: "Fix export crash", Description: "Exporting a board with emoji labels panics in the markdown writer", Status: model.StatusOpen},
+		{ID: "X-2", Title: "Fix export crash", Description: "Markdown writer panics when exporting a board with emoji labels", Status: model.StatusOpen},
+		{ID: "X-3", Title: "Fix export crash", Description: "Timeout talking to the remote sync server", Status: model.StatusOpen},
+	}
+
+	pairs := FindDuplicateCandidates(issues, 0.9)
+	if len(pairs) != 1 || pairs[0].Keep != "X-1" || pairs[0].Duplicate != "X-2" {
+		t.Fatalf("expected matching descriptions to rank above threshold, got %+v", pairs)
+	}
+
+	// Identical titles still pair at the default threshold regardless of description
+	if got := FindDuplicateCandidates(issues, DefaultDuplicateThreshold); len(got) != 3 {
+		t.Fatalf("expected all three pairs at default threshold, got %+v", got)
+	}
+	if got := FindDuplicateCandidates(nil, DefaultDuplicateThreshold); len(got) != 0 {
+		t.Fatalf("expected no pairs for no issues")
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	a := model.Issue{Title: "Fix export crash", Description: "Exporting a board with emoji labels panics"}
+	b := model.Issue{Title: "Fix export crash", Description: "Timeout talking to the sync server"}
+	if got := Similarity(a, a); got != 1 {
+		t.Errorf("expected an issue to be fully similar to itself, got %.2f", got)
+	}
+	if got := Similarity(a, b); got < 0.75 || got >= 1 {
+		t.Errorf("expected same titles with different descriptions in [0.75, 1), got %.2f", got)
+	}
+	if got := Similarity(a, model.Issue{Title: "Add dark mode"}); got > 0.2 {
+		t.Errorf("expected unrelated issues to score low, got %.2f", got)
+	}
+}
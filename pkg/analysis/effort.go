@@ -0,0 +1,135 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EffortRollup aggregates estimates around a single issue.
+// All durations are in minutes, as model.Issue.EstimateMinutes returns them.
+type EffortRollup struct {
+	IssueID    string `json:"issue_id"`
+	OwnMinutes int    `json:"own_minutes"` // This issue's estimate (0 if unestimated)
+	Estimated  bool   `json:"estimated"`   // Whether the issue itself has an estimate
+
+	// Epic rollup: the issue plus all descendants reachable via parent-child links
+	TreeMinutes          int `json:"tree_minutes"`
+	TreeRemainingMinutes int `json:"tree_remaining_minutes"` // Excludes closed issues
+	TreeUnestimated      int `json:"tree_unestimated"`       // Open issues in the tree without an estimate
+	TreeSize             int `json:"tree_size"`              // Issues in the tree, including this one
+
+	// Chain rollup: the issue plus every open issue that transitively blocks it
+	ChainMinutes     int `json:"chain_minutes"`
+	ChainUnestimated int `json:"chain_unestimated"`
+	ChainSize        int `json:"chain_size"` // Issues in the chain, including this one
+}
+
+// ComputeEffortRollups rolls estimates up parent-child trees (epics) and blocking
+// dependency chains for every issue. Cycles are tolerated: each issue is counted
+// at most once per rollup.
+func ComputeEffortRollups(issues []model.Issue) map[string]EffortRollup {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	children := make(map[string][]string)
+	blockers := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; !ok {
+				continue
+			}
+			switch {
+			case dep.Type == model.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			case isBlockingDep(dep.Type):
+				blockers[issue.ID] = append(blockers[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+
+	result := make(map[string]EffortRollup, len(issues))
+	for _, issue := range issues {
+		r := EffortRollup{IssueID: issue.ID}
+		r.OwnMinutes, r.Estimated = issue.EstimateMinutes()
+
+		walkUnique(issue.ID, children, func(id string) {
+			it := issueMap[id]
+			r.TreeSize++
+			if est, ok := it.EstimateMinutes(); ok {
+				r.TreeMinutes += est
+				if !it.Status.IsClosed() {
+					r.TreeRemainingMinutes += est
+				}
+			} else if !it.Status.IsClosed() {
+				r.TreeUnestimated++
+			}
+		})
+
+		walkUnique(issue.ID, blockers, func(id string) {
+			it := issueMap[id]
+			// Closed blockers no longer stand between us and done
+			if id != issue.ID && it.Status.IsClosed() {
+				return
+			}
+			r.ChainSize++
+			if est, ok := it.EstimateMinutes(); ok {
+				r.ChainMinutes += est
+			} else {
+				r.ChainUnestimated++
+			}
+		})
+
+		result[issue.ID] = r
+	}
+	return result
+}
+
+// walkUnique visits start and everything reachable from it through edges, once each
+func walkUnique(start string, edges map[string][]string, visit func(id string)) {
+	seen := map[string]bool{start: true}
+	stack := []string{start}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		visit(id)
+		for _, next := range edges[id] {
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+}
+
+// effortWeights returns a per-node weight for effort-weighted path computations.
+// Estimates are normalized by the median estimate so an unestimated issue counts
+// as a typical one; with no estimates at all every weight is 1 (pure hop count).
+func (a *Analyzer) effortWeights() map[int64]float64 {
+	var estimates []int
+	for _, issue := range a.issueMap {
+		if est, _ := issue.EstimateMinutes(); est > 0 {
+			estimates = append(estimates, est)
+		}
+	}
+	if len(estimates) == 0 {
+		return nil
+	}
+	sort.Ints(estimates)
+	median := float64(estimates[len(estimates)/2])
+
+	weights := make(map[int64]float64, len(a.issueMap))
+	for id, issue := range a.issueMap {
+		w := 1.0
+		if est, _ := issue.EstimateMinutes(); est > 0 {
+			w = float64(est) / median
+		}
+		weights[a.idToNode[id]] = w
+	}
+	return weights
+}
@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func minutes(n int) *int { return &n }
+
+func TestComputeEffortRollups_EpicTree(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "EPIC", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Status: model.StatusOpen, EstimatedMinutes: minutes(60),
+			Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "EPIC", Type: model.DepParentChild}}},
+		{ID: "B", Status: model.StatusClosed, EstimatedMinutes: minutes(30),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "EPIC", Type: model.DepParentChild}}},
+		{ID: "C", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepParentChild}}},
+	}
+
+	r := ComputeEffortRollups(issues)["EPIC"]
+	if r.Estimated || r.OwnMinutes != 0 {
+		t.Errorf("epic has no estimate of its own, got %+v", r)
+	}
+	if r.TreeMinutes != 90 || r.TreeRemainingMinutes != 60 || r.TreeSize != 4 {
+		t.Errorf("expected 90m total / 60m remaining, got %d / %d", r.TreeMinutes, r.TreeRemainingMinutes)
+	}
+	// EPIC and C are open and unestimated
+	if r.TreeUnestimated != 2 {
+		t.Errorf("expected 2 unestimated open issues, got %d", r.TreeUnestimated)
+	}
+}
+
+func TestComputeEffortRollups_BlockingChain(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, EstimatedMinutes: minutes(120)},
+		{ID: "B", Status: model.StatusOpen, EstimatedMinutes: minutes(60),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "DONE", Status: model.StatusClosed, EstimatedMinutes: minutes(500)},
+		{ID: "C", Status: model.StatusOpen, EstimatedMinutes: minutes(30),
+			Dependencies: []*model.Dependency{
+				{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks},
+				{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks},
+				{IssueID: "C", DependsOnID: "DONE", Type: model.DepBlocks},
+			}},
+	}
+
+	r := ComputeEffortRollups(issues)["C"]
+	// C + B + A, each counted once; closed DONE excluded
+	if r.ChainMinutes != 210 || r.ChainUnestimated != 0 || r.ChainSize != 3 {
+		t.Errorf("expected 210m chain, got %+v", r)
+	}
+}
+
+func TestComputeEffortRollups_Cycle(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, EstimatedMinutes: minutes(10),
+			Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, EstimatedMinutes: minutes(20),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	if r := ComputeEffortRollups(issues)["A"]; r.ChainMinutes != 30 {
+		t.Errorf("expected cycle members counted once (30m), got %d", r.ChainMinutes)
+	}
+}
+
+func TestCriticalPath_WeightedByEstimate(t *testing.T) {
+	// Two chains ending at ROOT: a long cheap chain and a short expensive one.
+	// X1 <- X2 <- X3 (each 30m) and Y (600m); with hop count X1 wins,
+	// weighted by effort Y's side dominates.
+	issues := []model.Issue{
+		{ID: "X1", Status: model.StatusOpen, EstimatedMinutes: minutes(30)},
+		{ID: "X2", Status: model.StatusOpen, EstimatedMinutes: minutes(30),
+			Dependencies: []*model.Dependency{{IssueID: "X2", DependsOnID: "X1", Type: model.DepBlocks}}},
+		{ID: "X3", Status: model.StatusOpen, EstimatedMinutes: minutes(30),
+			Dependencies: []*model.Dependency{{IssueID: "X3", DependsOnID: "X2", Type: model.DepBlocks}}},
+		{ID: "Y1", Status: model.StatusOpen, EstimatedMinutes: minutes(30)},
+		{ID: "Y2", Status: model.StatusOpen, EstimatedMinutes: minutes(600),
+			Dependencies: []*model.Dependency{{IssueID: "Y2", DependsOnID: "Y1", Type: model.DepBlocks}}},
+	}
+
+	stats := NewAnalyzer(issues).Analyze()
+	x1 := stats.GetCriticalPathScore("X1")
+	y1 := stats.GetCriticalPathScore("Y1")
+	if y1 <= x1 {
+		t.Fatalf("expected effort-weighted score of Y1 (%v) to exceed X1 (%v)", y1, x1)
+	}
+}
+
+func TestCriticalPath_NoEstimatesIsHopCount(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	stats := NewAnalyzer(issues).Analyze()
+	if got := stats.GetCriticalPathScore("A"); got != 2 {
+		t.Fatalf("expected hop-count score 2 for A, got %v", got)
+	}
+}
+
+func TestComputeEffortRollups_StoryPoints(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Extra: map[string]json.RawMessage{"story_points": json.RawMessage(`2`)}},
+		{ID: "B", Status: model.StatusOpen, EstimatedMinutes: minutes(60),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+
+	rollups := ComputeEffortRollups(issues)
+	if a := rollups["A"]; !a.Estimated || a.OwnMinutes != 2*model.MinutesPerPoint {
+		t.Errorf("expected 2 points counted as %d minutes, got %+v", 2*model.MinutesPerPoint, a)
+	}
+	if b := rollups["B"]; b.ChainMinutes != 60+2*model.MinutesPerPoint || b.ChainUnestimated != 0 {
+		t.Errorf("expected the pointed blocker in the chain, got %+v", b)
+	}
+}
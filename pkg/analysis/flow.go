@@ -0,0 +1,245 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FlowSample is the lead and cycle time of one closed issue
+type FlowSample struct {
+	IssueID   string          `json:"issue_id"`
+	IssueType model.IssueType `json:"issue_type"`
+	Priority  int             `json:"priority"`
+	Assignee  string          `json:"assignee,omitempty"`
+	Lead      time.Duration   `json:"lead"`            // created → closed
+	Cycle     time.Duration   `json:"cycle,omitempty"` // started → closed
+	HasCycle  bool            `json:"has_cycle"`       // False when started_at is unknown
+
+	// Time spent in each status before closing, from the issue's status timeline
+	InStatus map[model.Status]time.Duration `json:"in_status,omitempty"`
+}
+
+// DurationStats summarizes a set of durations
+type DurationStats struct {
+	Count int           `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P85   time.Duration `json:"p85"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// FlowBreakdown holds lead and cycle statistics for one slice of the closed issues
+type FlowBreakdown struct {
+	Key   string        `json:"key"`
+	Lead  DurationStats `json:"lead"`
+	Cycle DurationStats `json:"cycle"`
+}
+
+// FlowMetrics is the lead/cycle time report for a set of issues
+type FlowMetrics struct {
+	Samples    []FlowSample    `json:"samples"`
+	Lead       DurationStats   `json:"lead"`
+	Cycle      DurationStats   `json:"cycle"`
+	ByType     []FlowBreakdown `json:"by_type"`
+	ByPriority []FlowBreakdown `json:"by_priority"`
+	ByAssignee []FlowBreakdown `json:"by_assignee"`
+	InStatus   []StatusDwell   `json:"in_status"` // Workflow order, then by name
+}
+
+// StatusDwell is how long closed issues spent in one status
+type StatusDwell struct {
+	Status model.Status  `json:"status"`
+	Stats  DurationStats `json:"stats"`
+}
+
+// FlowBuckets are the upper bounds of the histogram bins used for lead and cycle
+// times. They grow roughly geometrically because flow times are long-tailed;
+// the final bin is open-ended.
+var FlowBuckets = []time.Duration{
+	24 * time.Hour,
+	2 * 24 * time.Hour,
+	4 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	60 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+}
+
+// ComputeFlowMetrics measures lead time (created → closed) and cycle time
+// (started → closed) for every closed issue, and how long they spent in each
+// status along the way. Issues closed without a closed_at timestamp use
+// updated_at. Cycle time starts at started_at or, failing that, the first
+// in_progress entry in the status history; issues with neither contribute
+// lead time only.
+func ComputeFlowMetrics(issues []model.Issue) FlowMetrics {
+	var fm FlowMetrics
+	for i := range issues {
+		issue := &issues[i]
+		if !issue.Status.IsClosed() || issue.CreatedAt.IsZero() {
+			continue
+		}
+		closed := closedTime(issue)
+		if closed.Before(issue.CreatedAt) {
+			continue
+		}
+		s := FlowSample{
+			IssueID:   issue.ID,
+			IssueType: issue.IssueType,
+			Priority:  issue.Priority,
+			Assignee:  issue.Assignee,
+			Lead:      closed.Sub(issue.CreatedAt),
+		}
+		if started := startedTime(issue); started != nil && !closed.Before(*started) {
+			s.Cycle = closed.Sub(*started)
+			s.HasCycle = true
+		}
+		spans, _ := StatusTimeline(*issue, closed)
+		s.InStatus = TimeInStatus(spans)
+		for status := range s.InStatus {
+			if status.IsClosed() {
+				delete(s.InStatus, status)
+			}
+		}
+		fm.Samples = append(fm.Samples, s)
+	}
+	sort.Slice(fm.Samples, func(i, j int) bool {
+		return fm.Samples[i].IssueID < fm.Samples[j].IssueID
+	})
+
+	fm.Lead, fm.Cycle = flowStats(fm.Samples)
+	fm.ByType = flowBreakdown(fm.Samples, func(s FlowSample) string { return string(s.IssueType) })
+	fm.ByPriority = flowBreakdown(fm.Samples, func(s FlowSample) string { return fmt.Sprintf("P%d", s.Priority) })
+	fm.ByAssignee = flowBreakdown(fm.Samples, func(s FlowSample) string {
+		if s.Assignee == "" {
+			return "unassigned"
+		}
+		return s.Assignee
+	})
+	fm.InStatus = statusDwell(fm.Samples)
+	return fm
+}
+
+// statusDwell summarizes the time samples spent in each status. Statuses
+// are in workflow order, then by name.
+func statusDwell(samples []FlowSample) []StatusDwell {
+	byStatus := make(map[model.Status][]time.Duration)
+	for _, s := range samples {
+		for status, d := range s.InStatus {
+			byStatus[status] = append(byStatus[status], d)
+		}
+	}
+	out := make([]StatusDwell, 0, len(byStatus))
+	for status, ds := range byStatus {
+		out = append(out, StatusDwell{Status: status, Stats: ComputeDurationStats(ds)})
+	}
+	rank := func(s model.Status) int {
+		for i, w := range WIPStatuses {
+			if w == s {
+				return i
+			}
+		}
+		return len(WIPStatuses)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if ri, rj := rank(out[i].Status), rank(out[j].Status); ri != rj {
+			return ri < rj
+		}
+		return out[i].Status < out[j].Status
+	})
+	return out
+}
+
+// LeadTimes returns the lead time of every sample
+func (fm FlowMetrics) LeadTimes() []time.Duration {
+	out := make([]time.Duration, 0, len(fm.Samples))
+	for _, s := range fm.Samples {
+		out = append(out, s.Lead)
+	}
+	return out
+}
+
+// CycleTimes returns the cycle time of every sample that has one
+func (fm FlowMetrics) CycleTimes() []time.Duration {
+	var out []time.Duration
+	for _, s := range fm.Samples {
+		if s.HasCycle {
+			out = append(out, s.Cycle)
+		}
+	}
+	return out
+}
+
+// flowStats computes lead and cycle statistics over samples
+func flowStats(samples []FlowSample) (lead, cycle DurationStats) {
+	fm := FlowMetrics{Samples: samples}
+	return ComputeDurationStats(fm.LeadTimes()), ComputeDurationStats(fm.CycleTimes())
+}
+
+// flowBreakdown groups samples by key, largest group first
+func flowBreakdown(samples []FlowSample, key func(FlowSample) string) []FlowBreakdown {
+	groups := make(map[string][]FlowSample)
+	for _, s := range samples {
+		k := key(s)
+		groups[k] = append(groups[k], s)
+	}
+	out := make([]FlowBreakdown, 0, len(groups))
+	for k, g := range groups {
+		lead, cycle := flowStats(g)
+		out = append(out, FlowBreakdown{Key: k, Lead: lead, Cycle: cycle})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Lead.Count != out[j].Lead.Count {
+			return out[i].Lead.Count > out[j].Lead.Count
+		}
+		return naturalLess(out[i].Key, out[j].Key)
+	})
+	return out
+}
+
+// ComputeDurationStats returns count, mean, max and nearest-rank percentiles
+func ComputeDurationStats(ds []time.Duration) DurationStats {
+	if len(ds) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return DurationStats{
+		Count: len(sorted),
+		Mean:  total / time.Duration(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P85:   percentile(sorted, 85),
+		P95:   percentile(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank p-th percentile of an ascending slice
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// DurationHistogram counts durations into bins bounded above by bounds
+// (inclusive); the extra final bin holds everything larger than the last bound.
+func DurationHistogram(ds []time.Duration, bounds []time.Duration) []int {
+	counts := make([]int, len(bounds)+1)
+	for _, d := range ds {
+		bin := sort.Search(len(bounds), func(i int) bool { return d <= bounds[i] })
+		counts[bin]++
+	}
+	return counts
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeDurationStats(t *testing.T) {
+	var ds []time.Duration
+	for i := 1; i <= 20; i++ {
+		ds = append(ds, time.Duration(i)*time.Hour)
+	}
+	s := ComputeDurationStats(ds)
+	if s.Count != 20 || s.P50 != 10*time.Hour || s.P85 != 17*time.Hour || s.P95 != 19*time.Hour || s.Max != 20*time.Hour {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+	if s.Mean != 630*time.Minute {
+		t.Fatalf("expected mean 10h30m, got %v", s.Mean)
+	}
+	if (ComputeDurationStats(nil) != DurationStats{}) {
+		t.Fatalf("expected zero stats for no samples")
+	}
+}
+
+func TestComputeFlowMetrics(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		ts := base.AddDate(0, 0, days)
+		return &ts
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, IssueType: model.TypeBug, Priority: 1, Assignee: "ann",
+			CreatedAt: base, StartedAt: at(2), ClosedAt: at(3)},
+		{ID: "B", Status: model.StatusClosed, IssueType: model.TypeFeature, Priority: 1,
+			CreatedAt: base, ClosedAt: at(10)},
+		// Closed without closed_at: updated_at stands in
+		{ID: "C", Status: model.StatusClosed, IssueType: model.TypeBug, Priority: 2, Assignee: "ann",
+			CreatedAt: base, StartedAt: at(1), UpdatedAt: *at(5)},
+		{ID: "D", Status: model.StatusOpen, CreatedAt: base, StartedAt: at(1)},
+	}
+
+	fm := ComputeFlowMetrics(issues)
+	if len(fm.Samples) != 3 {
+		t.Fatalf("expected 3 closed samples, got %d", len(fm.Samples))
+	}
+	if fm.Lead.Count != 3 || fm.Lead.P50 != 5*24*time.Hour || fm.Lead.Max != 10*24*time.Hour {
+		t.Fatalf("unexpected lead stats %+v", fm.Lead)
+	}
+	if fm.Cycle.Count != 2 || fm.Cycle.Max != 4*24*time.Hour {
+		t.Fatalf("unexpected cycle stats %+v", fm.Cycle)
+	}
+
+	if len(fm.ByType) != 2 || fm.ByType[0].Key != "bug" || fm.ByType[0].Lead.Count != 2 {
+		t.Fatalf("unexpected type breakdown %+v", fm.ByType)
+	}
+	if fm.ByPriority[0].Key != "P1" || fm.ByPriority[0].Cycle.Count != 1 {
+		t.Fatalf("unexpected priority breakdown %+v", fm.ByPriority)
+	}
+	if fm.ByAssignee[0].Key != "ann" || fm.ByAssignee[1].Key != "unassigned" {
+		t.Fatalf("unexpected assignee breakdown %+v", fm.ByAssignee)
+	}
+}
+
+func TestDurationHistogram(t *testing.T) {
+	day := 24 * time.Hour
+	counts := DurationHistogram([]time.Duration{day, day + 1, 3 * day, 200 * day}, []time.Duration{day, 2 * day})
+	want := []int{1, 1, 2}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Fatalf("bin %d: got %d, want %d (%v)", i, counts[i], want[i], counts)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -36,6 +37,8 @@ type StartupProfile struct {
 	Eigenvector   time.Duration `json:"eigenvector"`
 	HITS          time.Duration `json:"hits"`
 	HITSTO        bool          `json:"hits_timeout"`
+	Closeness     time.Duration `json:"closeness"`
+	ClosenessTO   bool          `json:"closeness_timeout"`
 	CriticalPath  time.Duration `json:"critical_path"`
 	Cycles        time.Duration `json:"cycles"`
 	CyclesTO      bool          `json:"cycles_timeout"`
@@ -75,8 +78,43 @@ type GraphStats struct {
 	eigenvector       map[string]float64
 	hubs              map[string]float64
 	authorities       map[string]float64
+	closeness         map[string]float64
 	criticalPathScore map[string]float64
 	cycles            [][]string
+	timedOut          bool // A metric hit its timeout, so its values are placeholders
+	progress          Phase2Progress
+}
+
+// Phase2Progress describes what the background Phase 2 is computing
+type Phase2Progress struct {
+	Stage string // Metric being computed, e.g. "betweenness"; "" before start and when done
+	Done  int    // Units finished within the stage (betweenness pivots); 0 when not tracked
+	Total int
+}
+
+// Progress returns the current Phase 2 stage and, for sampled betweenness, how
+// many pivots are done.
+func (s *GraphStats) Progress() Phase2Progress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.progress
+}
+
+// setStage records that Phase 2 moved on to a new metric
+func (s *GraphStats) setStage(stage string) {
+	s.mu.Lock()
+	s.progress = Phase2Progress{Stage: stage}
+	s.mu.Unlock()
+}
+
+// setStageProgress updates the unit count for stage, ignoring late updates
+// from a computation that already timed out
+func (s *GraphStats) setStageProgress(stage string, done, total int) {
+	s.mu.Lock()
+	if s.progress.Stage == stage {
+		s.progress.Done, s.progress.Total = done, total
+	}
+	s.mu.Unlock()
 }
 
 // IsPhase2Ready returns true if Phase 2 metrics have been computed.
@@ -144,6 +182,16 @@ func (s *GraphStats) GetAuthorityScore(id string) float64 {
 	return s.authorities[id]
 }
 
+// GetClosenessScore returns the closeness centrality for a single issue.
+func (s *GraphStats) GetClosenessScore(id string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closeness == nil {
+		return 0
+	}
+	return s.closeness[id]
+}
+
 // GetCriticalPathScore returns the critical path score for a single issue.
 func (s *GraphStats) GetCriticalPathScore(id string) float64 {
 	s.mu.RLock()
@@ -229,6 +277,21 @@ func (s *GraphStats) Authorities() map[string]float64 {
 	return cp
 }
 
+// Closeness returns a copy of the Closeness map. Safe for concurrent iteration.
+// Returns an empty map if Phase 2 is not yet complete.
+func (s *GraphStats) Closeness() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closeness == nil {
+		return nil
+	}
+	cp := make(map[string]float64, len(s.closeness))
+	for k, v := range s.closeness {
+		cp[k] = v
+	}
+	return cp
+}
+
 // CriticalPathScore returns a copy of the CriticalPathScore map. Safe for concurrent iteration.
 // Returns an empty map if Phase 2 is not yet complete.
 func (s *GraphStats) CriticalPathScore() map[string]float64 {
@@ -357,21 +420,35 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 
 // AnalyzeAsync performs graph analysis in two phases for fast startup.
 // Phase 1 (instant): Degree centrality, topological order, density
-// Phase 2 (background): PageRank, Betweenness, Eigenvector, HITS, Cycles
+// Phase 2 (background): PageRank, Betweenness, Eigenvector, HITS, Closeness, Cycles
 // Returns immediately with Phase 1 data. Use IsPhase2Ready() or WaitForPhase2() for Phase 2.
 //
 // If SetConfig was called, uses that config. Otherwise uses ConfigForSize() to
 // automatically select appropriate algorithms based on graph size.
 func (a *Analyzer) AnalyzeAsync() *GraphStats {
-	var config AnalysisConfig
+	return a.AnalyzeAsyncWithConfig(a.analysisConfig())
+}
+
+// analysisConfig returns the config AnalyzeAsync will use
+func (a *Analyzer) analysisConfig() AnalysisConfig {
 	if a.config != nil {
-		config = *a.config
-	} else {
-		nodeCount := len(a.issueMap)
-		edgeCount := a.g.Edges().Len()
-		config = ConfigForSize(nodeCount, edgeCount)
+		return *a.config
+	}
+	if int64(len(a.issueMap)) < fullAnalysisBelow.Load() {
+		return FullAnalysisConfig()
 	}
-	return a.AnalyzeAsyncWithConfig(config)
+	return ConfigForSize(len(a.issueMap), a.g.Edges().Len())
+}
+
+// fullAnalysisBelow is the graph size under which analyzers without an
+// explicit config run full analysis instead of the size tiers
+var fullAnalysisBelow atomic.Int64
+
+// SetFullAnalysisThreshold makes analyzers without an explicit config
+// compute every metric for graphs with fewer than nodes issues. 0 restores
+// the size tiers; math.MaxInt64 forces full analysis everywhere.
+func SetFullAnalysisThreshold(nodes int64) {
+	fullAnalysisBelow.Store(nodes)
 }
 
 // AnalyzeAsyncWithConfig performs graph analysis with a custom configuration.
@@ -392,6 +469,7 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(config AnalysisConfig) *GraphStats {
 		eigenvector:       make(map[string]float64),
 		hubs:              make(map[string]float64),
 		authorities:       make(map[string]float64),
+		closeness:         make(map[string]float64),
 		criticalPathScore: make(map[string]float64),
 	}
 
@@ -430,6 +508,7 @@ func (a *Analyzer) Analyze() GraphStats {
 		eigenvector:       stats.eigenvector,
 		hubs:              stats.hubs,
 		authorities:       stats.authorities,
+		closeness:         stats.closeness,
 		criticalPathScore: stats.criticalPathScore,
 		cycles:            stats.cycles,
 		phase2Ready:       true,
@@ -453,6 +532,7 @@ func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 		eigenvector:       stats.eigenvector,
 		hubs:              stats.hubs,
 		authorities:       stats.authorities,
+		closeness:         stats.closeness,
 		criticalPathScore: stats.criticalPathScore,
 		cycles:            stats.cycles,
 		phase2Ready:       true,
@@ -486,6 +566,7 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 		eigenvector:       make(map[string]float64),
 		hubs:              make(map[string]float64),
 		authorities:       make(map[string]float64),
+		closeness:         make(map[string]float64),
 		criticalPathScore: make(map[string]float64),
 	}
 
@@ -556,6 +637,7 @@ func (a *Analyzer) computePhase2WithProfile(stats *GraphStats, config AnalysisCo
 	localEigenvector := make(map[string]float64)
 	localHubs := make(map[string]float64)
 	localAuthorities := make(map[string]float64)
+	localCloseness := make(map[string]float64)
 	localCriticalPath := make(map[string]float64)
 	var localCycles [][]string
 
@@ -645,6 +727,25 @@ func (a *Analyzer) computePhase2WithProfile(stats *GraphStats, config AnalysisCo
 		profile.HITS = time.Since(hitsStart)
 	}
 
+	// Closeness
+	if config.ComputeCloseness {
+		clStart := time.Now()
+		clDone := make(chan map[int64]float64, 1)
+		go func() {
+			clDone <- computeCloseness(a.g)
+		}()
+
+		select {
+		case cl := <-clDone:
+			for id, score := range cl {
+				localCloseness[a.nodeToID[id]] = score
+			}
+		case <-time.After(config.ClosenessTimeout):
+			profile.ClosenessTO = true
+		}
+		profile.Closeness = time.Since(clStart)
+	}
+
 	// Critical Path
 	if config.ComputeCriticalPath {
 		cpStart := time.Now()
@@ -707,6 +808,7 @@ func (a *Analyzer) computePhase2WithProfile(stats *GraphStats, config AnalysisCo
 	stats.eigenvector = localEigenvector
 	stats.hubs = localHubs
 	stats.authorities = localAuthorities
+	stats.closeness = localCloseness
 	stats.criticalPathScore = localCriticalPath
 	stats.cycles = localCycles
 	stats.phase2Ready = true
@@ -757,11 +859,14 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 	localEigenvector := make(map[string]float64)
 	localHubs := make(map[string]float64)
 	localAuthorities := make(map[string]float64)
+	localCloseness := make(map[string]float64)
 	localCriticalPath := make(map[string]float64)
 	var localCycles [][]string
+	timedOut := false
 
 	// PageRank with timeout (if enabled)
 	if config.ComputePageRank {
+		stats.setStage("pagerank")
 		prDone := make(chan map[int64]float64, 1)
 		go func() {
 			prDone <- network.PageRank(a.g, 0.85, 1e-6)
@@ -774,6 +879,7 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 			}
 		case <-time.After(config.PageRankTimeout):
 			// Timeout - use uniform distribution
+			timedOut = true
 			uniform := 1.0 / float64(len(a.issueMap))
 			for id := range a.issueMap {
 				localPageRank[id] = uniform
@@ -783,11 +889,14 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 
 	// Betweenness with timeout (if enabled)
 	if config.ComputeBetweenness {
+		stats.setStage("betweenness")
 		bwDone := make(chan BetweennessResult, 1)
 		go func() {
 			// Choose algorithm based on mode
 			if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
-				bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize)
+				bwDone <- ApproxBetweennessWithProgress(a.g, config.BetweennessSampleSize, func(done, total int) {
+					stats.setStageProgress("betweenness", done, total)
+				})
 			} else {
 				// Exact mode or mode not set (default to exact)
 				exact := network.Betweenness(a.g)
@@ -810,11 +919,13 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 			}
 		case <-time.After(config.BetweennessTimeout):
 			// Timeout - skip (leave empty)
+			timedOut = true
 		}
 	}
 
 	// Eigenvector (if enabled - usually fast, no timeout needed)
 	if config.ComputeEigenvector {
+		stats.setStage("eigenvector")
 		for id, score := range computeEigenvector(a.g) {
 			localEigenvector[a.nodeToID[id]] = score
 		}
@@ -822,6 +933,7 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 
 	// HITS with timeout (if enabled and graph has edges)
 	if config.ComputeHITS && a.g.Edges().Len() > 0 {
+		stats.setStage("hits")
 		hitsDone := make(chan map[int64]network.HubAuthority, 1)
 		go func() {
 			hitsDone <- network.HITS(a.g, 1e-3)
@@ -835,11 +947,32 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 			}
 		case <-time.After(config.HITSTimeout):
 			// Timeout - skip
+			timedOut = true
+		}
+	}
+
+	// Closeness with timeout (if enabled)
+	if config.ComputeCloseness {
+		stats.setStage("closeness")
+		clDone := make(chan map[int64]float64, 1)
+		go func() {
+			clDone <- computeCloseness(a.g)
+		}()
+
+		select {
+		case cl := <-clDone:
+			for id, score := range cl {
+				localCloseness[a.nodeToID[id]] = score
+			}
+		case <-time.After(config.ClosenessTimeout):
+			// Timeout - skip (leave empty)
+			timedOut = true
 		}
 	}
 
 	// Critical Path (if enabled - requires topological sort)
 	if config.ComputeCriticalPath {
+		stats.setStage("critical path")
 		sorted, err := topo.Sort(a.g)
 		if err == nil {
 			localCriticalPath = a.computeHeights(sorted)
@@ -848,6 +981,7 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 
 	// Cycles with SCC pre-check and timeout (if enabled)
 	if config.ComputeCycles {
+		stats.setStage("cycles")
 		maxCycles := config.MaxCyclesToStore
 		if maxCycles == 0 {
 			maxCycles = 100 // Default
@@ -890,6 +1024,7 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 				}
 			case <-time.After(config.CyclesTimeout):
 				localCycles = [][]string{{"CYCLE_DETECTION_TIMEOUT"}}
+				timedOut = true
 			}
 		}
 	}
@@ -901,15 +1036,23 @@ func (a *Analyzer) computePhase2(stats *GraphStats, config AnalysisConfig) {
 	stats.eigenvector = localEigenvector
 	stats.hubs = localHubs
 	stats.authorities = localAuthorities
+	stats.closeness = localCloseness
 	stats.criticalPathScore = localCriticalPath
 	stats.cycles = localCycles
+	stats.timedOut = timedOut
+	stats.progress = Phase2Progress{}
 	stats.phase2Ready = true
 	stats.mu.Unlock()
 }
 
+// computeHeights returns each issue's critical path score: its own effort plus the
+// heaviest chain of issues that depend on it. Effort comes from estimates when any
+// are present (see effortWeights); otherwise every issue weighs 1 and the score is
+// the downstream hop count.
 func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 	heights := make(map[int64]float64)
 	impactScores := make(map[string]float64)
+	weights := a.effortWeights()
 
 	for _, n := range sorted {
 		nid := n.ID()
@@ -924,7 +1067,11 @@ func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 				}
 			}
 		}
-		heights[nid] = 1.0 + maxParentHeight
+		weight := 1.0
+		if w, ok := weights[nid]; ok {
+			weight = w
+		}
+		heights[nid] = weight + maxParentHeight
 		impactScores[a.nodeToID[nid]] = heights[nid]
 	}
 
@@ -934,10 +1081,7 @@ func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 // isBlockingDep returns true if the dependency type represents a blocking relationship.
 // Empty type defaults to blocks for legacy compatibility.
 func isBlockingDep(depType model.DependencyType) bool {
-	if depType == "" {
-		return true // Legacy deps default to blocking
-	}
-	return depType == model.DepBlocks
+	return depType.IsBlockingOrLegacy()
 }
 
 // GetActionableIssues returns issues that can be worked on immediately.
@@ -1024,6 +1168,60 @@ func (a *Analyzer) GetOpenBlockers(issueID string) []string {
 	return openBlockers
 }
 
+// computeCloseness returns harmonic closeness centrality: for each node, the
+// mean of 1/d over every other node, where d is the shortest-path distance
+// with edges treated as undirected. Unreachable nodes contribute 0, so the
+// score stays meaningful when the graph has several components.
+func computeCloseness(g graph.Directed) map[int64]float64 {
+	nodes := g.Nodes()
+	var nodeList []int64
+	for nodes.Next() {
+		nodeList = append(nodeList, nodes.Node().ID())
+	}
+	n := len(nodeList)
+	if n == 0 {
+		return nil
+	}
+
+	// Undirected adjacency, built once
+	adj := make(map[int64][]int64, n)
+	for _, id := range nodeList {
+		from := g.From(id)
+		for from.Next() {
+			to := from.Node().ID()
+			adj[id] = append(adj[id], to)
+			adj[to] = append(adj[to], id)
+		}
+	}
+
+	res := make(map[int64]float64, n)
+	if n == 1 {
+		res[nodeList[0]] = 0
+		return res
+	}
+	dist := make(map[int64]int, n)
+	for _, src := range nodeList {
+		clear(dist)
+		dist[src] = 0
+		queue := []int64{src}
+		sum := 0.0
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			for _, next := range adj[cur] {
+				if _, seen := dist[next]; seen {
+					continue
+				}
+				dist[next] = dist[cur] + 1
+				sum += 1 / float64(dist[next])
+				queue = append(queue, next)
+			}
+		}
+		res[src] = sum / float64(n-1)
+	}
+	return res
+}
+
 // computeEigenvector runs a simple power-iteration to estimate eigenvector centrality.
 func computeEigenvector(g graph.Directed) map[int64]float64 {
 	nodes := g.Nodes()
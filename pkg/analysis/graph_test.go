@@ -575,3 +575,40 @@ func TestImpactScore(t *testing.T) {
 		t.Errorf("Expected A to have score 1, got %f", stats.GetCriticalPathScore("A"))
 	}
 }
+
+func TestClosenessCentrality(t *testing.T) {
+	// Star around HUB (links in both directions count) plus a detached pair
+	issues := []model.Issue{
+		{ID: "HUB", Status: model.StatusOpen},
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "HUB", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "HUB", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "X", Status: model.StatusOpen},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "X", Type: model.DepBlocks}}},
+	}
+	issues[0].Dependencies = []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	closeness := stats.Closeness()
+	if len(closeness) != len(issues) {
+		t.Fatalf("expected closeness for every issue, got %v", closeness)
+	}
+
+	// HUB reaches A, B, C at distance 1: 3/5
+	if got := stats.GetClosenessScore("HUB"); got < 0.599 || got > 0.601 {
+		t.Errorf("HUB closeness: expected 0.6, got %.4f", got)
+	}
+	// A reaches HUB at 1, B and C at 2: (1 + 0.5 + 0.5)/5
+	if got := stats.GetClosenessScore("A"); got < 0.399 || got > 0.401 {
+		t.Errorf("A closeness: expected 0.4, got %.4f", got)
+	}
+	// Detached pair only reaches each other
+	if got := stats.GetClosenessScore("X"); got < 0.199 || got > 0.201 {
+		t.Errorf("X closeness: expected 0.2, got %.4f", got)
+	}
+	for id, score := range closeness {
+		if id != "HUB" && score >= closeness["HUB"] {
+			t.Errorf("expected HUB most central, %s has %.4f", id, score)
+		}
+	}
+}
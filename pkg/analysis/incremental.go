@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ComputeStructureHash hashes only what the graph metrics read: issue IDs,
+// blocking dependencies and effort estimates. Two issue sets with the same
+// structure hash produce identical GraphStats, so an edit to a title, status
+// or label can reuse the previous metrics.
+func ComputeStructureHash(issues []model.Issue) string {
+	if len(issues) == 0 {
+		return "empty"
+	}
+
+	keys := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		key := issue.ID + "\x00"
+		if est, _ := issue.EstimateMinutes(); est > 0 {
+			key += strconv.Itoa(est)
+		}
+		var deps []string
+		for _, dep := range issue.Dependencies {
+			if dep != nil && isBlockingDep(dep.Type) {
+				deps = append(deps, dep.DependsOnID)
+			}
+		}
+		sort.Strings(deps)
+		for _, d := range deps {
+			key += "\x00" + d
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, key := range keys {
+		h.Write([]byte(key))
+		h.Write([]byte{1})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// IssueHashes returns a content hash per issue ID, for cheap change detection
+// between two loads of the same file
+func IssueHashes(issues []model.Issue) map[string]string {
+	hashes := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		hashes[issue.ID] = IssueHash(issue)
+	}
+	return hashes
+}
+
+// IssueHash returns a content hash of one issue record
+func IssueHash(issue model.Issue) string {
+	h := sha256.New()
+	writeIssueHash(h, issue)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// IssueChanges lists the issue IDs that differ between two loads
+type IssueChanges struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// Count returns the total number of changed issues
+func (c IssueChanges) Count() int {
+	return len(c.Added) + len(c.Removed) + len(c.Modified)
+}
+
+// DiffIssueHashes compares per-issue hashes from IssueHashes. Each list is sorted.
+func DiffIssueHashes(before, after map[string]string) IssueChanges {
+	var c IssueChanges
+	for id, h := range after {
+		old, ok := before[id]
+		switch {
+		case !ok:
+			c.Added = append(c.Added, id)
+		case old != h:
+			c.Modified = append(c.Modified, id)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			c.Removed = append(c.Removed, id)
+		}
+	}
+	sort.Strings(c.Added)
+	sort.Strings(c.Removed)
+	sort.Strings(c.Modified)
+	return c
+}
@@ -0,0 +1,102 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func incrementalTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Core", Status: model.StatusOpen},
+		{ID: "B", Title: "Dep", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Other", Status: model.StatusOpen},
+	}
+}
+
+func TestComputeStructureHash(t *testing.T) {
+	base := analysis.ComputeStructureHash(incrementalTestIssues())
+	if analysis.ComputeStructureHash(nil) != "empty" {
+		t.Error("expected 'empty' for no issues")
+	}
+
+	// Content edits and non-blocking links keep the structure
+	edited := incrementalTestIssues()
+	edited[0].Title = "Renamed"
+	edited[1].Status = model.StatusClosed
+	edited[2].Dependencies = []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepRelated}}
+	edited[0], edited[2] = edited[2], edited[0] // Order doesn't matter either
+	if got := analysis.ComputeStructureHash(edited); got != base {
+		t.Errorf("expected content-only edits to keep the structure hash")
+	}
+
+	// A new blocking edge, an estimate or a new issue changes it
+	blocked := incrementalTestIssues()
+	blocked[2].Dependencies = []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}
+	estimated := incrementalTestIssues()
+	est := 30
+	estimated[0].EstimatedMinutes = &est
+	added := append(incrementalTestIssues(), model.Issue{ID: "D", Title: "New", Status: model.StatusOpen})
+	for name, issues := range map[string][]model.Issue{"blocking edge": blocked, "estimate": estimated, "new issue": added} {
+		if analysis.ComputeStructureHash(issues) == base {
+			t.Errorf("expected %s to change the structure hash", name)
+		}
+	}
+}
+
+func TestDiffIssueHashes(t *testing.T) {
+	before := analysis.IssueHashes(incrementalTestIssues())
+
+	after := incrementalTestIssues()
+	after[0].Title = "Renamed"
+	after = append(after[:2], model.Issue{ID: "D", Title: "New", Status: model.StatusOpen}) // Drops C
+
+	c := analysis.DiffIssueHashes(before, analysis.IssueHashes(after))
+	want := analysis.IssueChanges{Added: []string{"D"}, Removed: []string{"C"}, Modified: []string{"A"}}
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+	if c.Count() != 3 {
+		t.Errorf("expected 3 changes, got %d", c.Count())
+	}
+
+	if n := analysis.DiffIssueHashes(before, before).Count(); n != 0 {
+		t.Errorf("expected no changes for identical data, got %d", n)
+	}
+}
+
+func TestCachedAnalyzerAnalyzeReusing(t *testing.T) {
+	issues := incrementalTestIssues()
+	cache := analysis.NewCache(analysis.DefaultCacheTTL)
+	first := analysis.NewCachedAnalyzer(issues, cache)
+	prev := first.AnalyzeAsync()
+	prev.WaitForPhase2()
+
+	// Title edit: previous stats are reused
+	edited := incrementalTestIssues()
+	edited[0].Title = "Renamed"
+	ca := analysis.NewCachedAnalyzer(edited, cache)
+	if got := ca.AnalyzeReusing(prev, first.StructureHash()); got != prev || !ca.WasReused() {
+		t.Fatal("expected previous stats to be reused for a content-only edit")
+	}
+
+	// New blocking edge: fresh analysis
+	edited[2].Dependencies = []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}
+	ca = analysis.NewCachedAnalyzer(edited, cache)
+	got := ca.AnalyzeReusing(prev, first.StructureHash())
+	if got == prev || ca.WasReused() {
+		t.Fatal("expected a fresh analysis after a structural change")
+	}
+	got.WaitForPhase2()
+	if got.InDegree["B"] != 1 {
+		t.Errorf("expected new edge in fresh stats, got InDegree %v", got.InDegree)
+	}
+
+	// No previous structure: fresh analysis
+	ca = analysis.NewCachedAnalyzer(issues, analysis.NewCache(analysis.DefaultCacheTTL))
+	if ca.AnalyzeReusing(prev, ""); ca.WasReused() {
+		t.Error("expected no reuse without a previous structure hash")
+	}
+}
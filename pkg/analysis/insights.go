@@ -25,6 +25,47 @@ type Insights struct {
 	Stats *GraphStats
 }
 
+// Restrict returns the insights about the issues in ids only, dropping the
+// rest from each list and any cycle that leaves the set. Stats are shared.
+func (ins Insights) Restrict(ids map[string]bool) Insights {
+	items := func(list []InsightItem) []InsightItem {
+		var kept []InsightItem
+		for _, item := range list {
+			if ids[item.ID] {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+	var orphans []string
+	for _, id := range ins.Orphans {
+		if ids[id] {
+			orphans = append(orphans, id)
+		}
+	}
+	var cycles [][]string
+	for _, cycle := range ins.Cycles {
+		inside := true
+		for _, id := range cycle {
+			inside = inside && ids[id]
+		}
+		if inside {
+			cycles = append(cycles, cycle)
+		}
+	}
+	return Insights{
+		Bottlenecks:    items(ins.Bottlenecks),
+		Keystones:      items(ins.Keystones),
+		Influencers:    items(ins.Influencers),
+		Hubs:           items(ins.Hubs),
+		Authorities:    items(ins.Authorities),
+		Orphans:        orphans,
+		Cycles:         cycles,
+		ClusterDensity: ins.ClusterDensity,
+		Stats:          ins.Stats,
+	}
+}
+
 // GenerateInsights translates raw stats into actionable data
 func (s *GraphStats) GenerateInsights(limit int) Insights {
 	// Get thread-safe copies of all Phase 2 data
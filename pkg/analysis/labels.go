@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelStats summarizes the issues carrying a single label
+type LabelStats struct {
+	Label      string `json:"label"`
+	Total      int    `json:"total"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	Blocked    int    `json:"blocked"`
+	Closed     int    `json:"closed"`
+}
+
+// Active returns the number of issues with this label that are not closed
+func (s LabelStats) Active() int {
+	return s.Total - s.Closed
+}
+
+// ComputeLabelStats counts issues per label, ordered by total (desc) then name.
+// Issues without labels are not counted.
+func ComputeLabelStats(issues []model.Issue) []LabelStats {
+	byLabel := make(map[string]*LabelStats)
+	for _, issue := range issues {
+		seen := make(map[string]bool, len(issue.Labels))
+		for _, label := range issue.Labels {
+			if label == "" || seen[label] {
+				continue
+			}
+			seen[label] = true
+
+			s, ok := byLabel[label]
+			if !ok {
+				s = &LabelStats{Label: label}
+				byLabel[label] = s
+			}
+			s.Total++
+			switch issue.Status {
+			case model.StatusOpen:
+				s.Open++
+			case model.StatusInProgress:
+				s.InProgress++
+			case model.StatusBlocked:
+				s.Blocked++
+			case model.StatusClosed:
+				s.Closed++
+			}
+		}
+	}
+
+	result := make([]LabelStats, 0, len(byLabel))
+	for _, s := range byLabel {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total != result[j].Total {
+			return result[i].Total > result[j].Total
+		}
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
+
+// LabelWindow is how far back label throughput is measured
+const LabelWindow = 28 * 24 * time.Hour
+
+// LabelMetrics adds age, throughput and blockage to a label's counts
+type LabelMetrics struct {
+	LabelStats
+
+	// Stuck counts active issues that can't move: blocked by status or
+	// waiting on an open blocker
+	Stuck int `json:"stuck"`
+
+	AvgAge        time.Duration `json:"avg_age"`        // Mean age of the active issues
+	ClosedRecent  int           `json:"closed_recent"`  // Closed within LabelWindow
+	CreatedRecent int           `json:"created_recent"` // Created within LabelWindow
+}
+
+// StuckShare returns the fraction of active issues that are stuck
+func (m LabelMetrics) StuckShare() float64 {
+	if m.Active() == 0 {
+		return 0
+	}
+	return float64(m.Stuck) / float64(m.Active())
+}
+
+// IsStuck reports whether most of the label's active work can't move: at
+// least two issues and half of them stuck
+func (m LabelMetrics) IsStuck() bool {
+	return m.Stuck >= 2 && m.StuckShare() >= 0.5
+}
+
+// ClosedPerWeek returns the label's closes per week over LabelWindow
+func (m LabelMetrics) ClosedPerWeek() float64 {
+	return float64(m.ClosedRecent) / (float64(LabelWindow) / float64(7*24*time.Hour))
+}
+
+// ComputeLabelMetrics returns the metrics of every label, in the order of
+// ComputeLabelStats
+func ComputeLabelMetrics(issues []model.Issue, now time.Time) []LabelMetrics {
+	stats := ComputeLabelStats(issues)
+	index := make(map[string]int, len(stats))
+	result := make([]LabelMetrics, len(stats))
+	for i, s := range stats {
+		index[s.Label] = i
+		result[i].LabelStats = s
+	}
+
+	blockers := OpenBlockers(issues)
+	ages := make([]time.Duration, len(stats))
+	since := now.Add(-LabelWindow)
+	for i := range issues {
+		issue := &issues[i]
+		seen := make(map[string]bool, len(issue.Labels))
+		for _, label := range issue.Labels {
+			if label == "" || seen[label] {
+				continue
+			}
+			seen[label] = true
+			m := &result[index[label]]
+			if issue.CreatedAt.After(since) {
+				m.CreatedRecent++
+			}
+			if issue.Status.IsClosed() {
+				if closedTime(issue).After(since) {
+					m.ClosedRecent++
+				}
+				continue
+			}
+			if issue.Status == model.StatusBlocked || len(blockers[issue.ID]) > 0 {
+				m.Stuck++
+			}
+			if !issue.CreatedAt.IsZero() {
+				ages[index[label]] += max(now.Sub(issue.CreatedAt), 0)
+			}
+		}
+	}
+	for i := range result {
+		if n := result[i].Active(); n > 0 {
+			result[i].AvgAge = ages[i] / time.Duration(n)
+		}
+	}
+	return result
+}
+
+// LabelPair is two labels and how many issues carry both
+type LabelPair struct {
+	A     string `json:"a"` // A < B
+	B     string `json:"b"`
+	Count int    `json:"count"`
+}
+
+// ComputeLabelPairs counts the issues carrying each pair of labels, most
+// common pairs first
+func ComputeLabelPairs(issues []model.Issue) []LabelPair {
+	counts := make(map[[2]string]int)
+	for _, issue := range issues {
+		labels := make([]string, 0, len(issue.Labels))
+		seen := make(map[string]bool, len(issue.Labels))
+		for _, label := range issue.Labels {
+			if label != "" && !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+		sort.Strings(labels)
+		for i := range labels {
+			for j := i + 1; j < len(labels); j++ {
+				counts[[2]string{labels[i], labels[j]}]++
+			}
+		}
+	}
+	pairs := make([]LabelPair, 0, len(counts))
+	for k, n := range counts {
+		pairs = append(pairs, LabelPair{A: k[0], B: k[1], Count: n})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Count != pairs[j].Count {
+			return pairs[i].Count > pairs[j].Count
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A < pairs[j].A
+		}
+		return pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
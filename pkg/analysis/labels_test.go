@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeLabelStats(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"ui", "api"}},
+		{ID: "B", Status: model.StatusClosed, Labels: []string{"ui"}},
+		{ID: "C", Status: model.StatusBlocked, Labels: []string{"ui", "ui"}},
+		{ID: "D", Status: model.StatusInProgress},
+	}
+
+	stats := ComputeLabelStats(issues)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 labels, got %d", len(stats))
+	}
+
+	ui := stats[0]
+	if ui.Label != "ui" || ui.Total != 3 || ui.Open != 1 || ui.Blocked != 1 || ui.Closed != 1 {
+		t.Errorf("unexpected ui stats: %+v", ui)
+	}
+	if ui.Active() != 2 {
+		t.Errorf("expected 2 active ui issues, got %d", ui.Active())
+	}
+	if stats[1].Label != "api" || stats[1].Total != 1 {
+		t.Errorf("unexpected api stats: %+v", stats[1])
+	}
+}
+
+func TestComputeLabelStats_Empty(t *testing.T) {
+	if stats := ComputeLabelStats(nil); len(stats) != 0 {
+		t.Fatalf("expected no stats, got %v", stats)
+	}
+}
+
+func TestComputeLabelMetrics(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	recent := now.Add(-2 * day)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"infra"}, CreatedAt: now.Add(-10 * day),
+			Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusBlocked, Labels: []string{"infra", "ui"}, CreatedAt: now.Add(-30 * day)},
+		{ID: "C", Status: model.StatusOpen, Labels: []string{"ui"}, CreatedAt: recent},
+		{ID: "D", Status: model.StatusClosed, Labels: []string{"ui", "infra"}, CreatedAt: now.Add(-60 * day), ClosedAt: &recent},
+		{ID: "E", Status: model.StatusClosed, Labels: []string{"ui"}, CreatedAt: now.Add(-90 * day), UpdatedAt: now.Add(-40 * day)},
+	}
+
+	metrics := ComputeLabelMetrics(issues, now)
+	if len(metrics) != 2 || metrics[0].Label != "ui" || metrics[1].Label != "infra" {
+		t.Fatalf("expected ui then infra, got %+v", metrics)
+	}
+	infra, ui := metrics[1], metrics[0]
+	if infra.Stuck != 2 || !infra.IsStuck() || infra.AvgAge != 20*day {
+		t.Errorf("unexpected infra metrics %+v", infra)
+	}
+	if infra.ClosedRecent != 1 || infra.CreatedRecent != 1 {
+		t.Errorf("expected D closed and A created within the window: %+v", infra)
+	}
+	if ui.Stuck != 1 || ui.IsStuck() || ui.ClosedRecent != 1 {
+		t.Errorf("unexpected ui metrics %+v", ui)
+	}
+	if got := ui.ClosedPerWeek(); got != 0.25 {
+		t.Errorf("expected 0.25 closes a week, got %v", got)
+	}
+
+	pairs := ComputeLabelPairs(issues)
+	if len(pairs) != 1 || pairs[0] != (LabelPair{A: "infra", B: "ui", Count: 2}) {
+		t.Errorf("unexpected pairs %+v", pairs)
+	}
+}
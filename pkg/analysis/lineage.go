@@ -0,0 +1,141 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LineageNode is an issue in a discovered-from tree, with the issues found
+// while working on it
+type LineageNode struct {
+	ID          string
+	Title       string
+	Status      model.Status
+	Children    []*LineageNode // Discovered from this issue, oldest first
+	Descendants int            // Issues discovered from it, directly or not
+}
+
+// Lineage is the work discovered while doing other work: the discovered-from
+// trees, and how much of the work is emergent rather than planned
+type Lineage struct {
+	// Trees rooted at issues not discovered from any loaded issue, those
+	// leading to the most discoveries first. Issues that led to none are
+	// left out.
+	Roots []*LineageNode
+
+	Planned  int // Issues not discovered from another
+	Emergent int // Issues discovered from another
+
+	// The same split of the backlog, the issues still open
+	OpenPlanned  int
+	OpenEmergent int
+
+	MaxDepth int // Longest chain of discoveries, 0 when there are none
+}
+
+// EmergentShare returns the fraction of all issues that are emergent
+func (l Lineage) EmergentShare() float64 {
+	if total := l.Planned + l.Emergent; total > 0 {
+		return float64(l.Emergent) / float64(total)
+	}
+	return 0
+}
+
+// OpenEmergentShare returns the fraction of open issues that are emergent
+func (l Lineage) OpenEmergentShare() float64 {
+	if total := l.OpenPlanned + l.OpenEmergent; total > 0 {
+		return float64(l.OpenEmergent) / float64(total)
+	}
+	return 0
+}
+
+// ComputeLineage builds the discovered-from trees of issues. An issue
+// discovered from several is placed under the first; one discovered from an
+// issue that isn't loaded is emergent but roots its own tree.
+func ComputeLineage(issues []model.Issue) Lineage {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	var l Lineage
+	parent := make(map[string]string)
+	children := make(map[string][]*model.Issue)
+	for i := range issues {
+		issue := &issues[i]
+		emergent := false
+		for _, dep := range issue.Dependencies {
+			if dep == nil || dep.Type != model.DepDiscoveredFrom || dep.DependsOnID == issue.ID {
+				continue
+			}
+			if !emergent {
+				if _, ok := byID[dep.DependsOnID]; ok {
+					parent[issue.ID] = dep.DependsOnID
+					children[dep.DependsOnID] = append(children[dep.DependsOnID], issue)
+				}
+			}
+			emergent = true
+		}
+		open := !issue.Status.IsClosed()
+		switch {
+		case emergent && open:
+			l.Emergent++
+			l.OpenEmergent++
+		case emergent:
+			l.Emergent++
+		case open:
+			l.Planned++
+			l.OpenPlanned++
+		default:
+			l.Planned++
+		}
+	}
+
+	placed := make(map[string]bool)
+	var build func(issue *model.Issue, depth int) *LineageNode
+	build = func(issue *model.Issue, depth int) *LineageNode {
+		placed[issue.ID] = true
+		l.MaxDepth = max(l.MaxDepth, depth)
+		node := &LineageNode{ID: issue.ID, Title: issue.Title, Status: issue.Status}
+		kids := children[issue.ID]
+		sort.SliceStable(kids, func(i, j int) bool {
+			if !kids[i].CreatedAt.Equal(kids[j].CreatedAt) {
+				return kids[i].CreatedAt.Before(kids[j].CreatedAt)
+			}
+			return kids[i].ID < kids[j].ID
+		})
+		for _, kid := range kids {
+			if placed[kid.ID] {
+				continue // A cycle of discoveries
+			}
+			child := build(kid, depth+1)
+			node.Children = append(node.Children, child)
+			node.Descendants += 1 + child.Descendants
+		}
+		return node
+	}
+	for i := range issues {
+		issue := &issues[i]
+		if _, ok := parent[issue.ID]; ok || len(children[issue.ID]) == 0 {
+			continue
+		}
+		l.Roots = append(l.Roots, build(issue, 0))
+	}
+	// Issues in a cycle of discoveries have no root above them: start the
+	// tree at the first one met
+	for i := range issues {
+		issue := &issues[i]
+		if !placed[issue.ID] && len(children[issue.ID]) > 0 {
+			l.Roots = append(l.Roots, build(issue, 0))
+		}
+	}
+
+	sort.SliceStable(l.Roots, func(i, j int) bool {
+		if l.Roots[i].Descendants != l.Roots[j].Descendants {
+			return l.Roots[i].Descendants > l.Roots[j].Descendants
+		}
+		return l.Roots[i].ID < l.Roots[j].ID
+	})
+	return l
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeLineage(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	found := func(from string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: from, Type: model.DepDiscoveredFrom}}
+	}
+	issues := []model.Issue{
+		{ID: "plan-1", Status: model.StatusClosed},
+		{ID: "bug-2", Status: model.StatusOpen, CreatedAt: day.AddDate(0, 0, 2), Dependencies: found("plan-1")},
+		{ID: "bug-1", Status: model.StatusClosed, CreatedAt: day.AddDate(0, 0, 1), Dependencies: found("plan-1")},
+		{ID: "bug-3", Status: model.StatusOpen, Dependencies: found("bug-2")},
+		{ID: "plan-2", Status: model.StatusOpen},
+		{ID: "plan-3", Status: model.StatusOpen},
+		{ID: "task-1", Status: model.StatusOpen, Dependencies: found("plan-3")},
+		// Discovered from an issue that isn't loaded
+		{ID: "orphan", Status: model.StatusOpen, Dependencies: found("gone")},
+		// A cycle of discoveries
+		{ID: "loop-a", Status: model.StatusOpen, Dependencies: found("loop-b")},
+		{ID: "loop-b", Status: model.StatusOpen, Dependencies: found("loop-a")},
+	}
+
+	l := ComputeLineage(issues)
+	if l.Planned != 3 || l.Emergent != 7 || l.OpenPlanned != 2 || l.OpenEmergent != 6 {
+		t.Errorf("unexpected split: %+v", l)
+	}
+	if l.MaxDepth != 2 {
+		t.Errorf("expected plan-1 → bug-2 → bug-3 two deep, got %d", l.MaxDepth)
+	}
+	if len(l.Roots) != 3 {
+		t.Fatalf("expected trees under plan-1, plan-3 and the loop, got %d", len(l.Roots))
+	}
+	root := l.Roots[0]
+	if root.ID != "plan-1" || root.Descendants != 3 || len(root.Children) != 2 ||
+		root.Children[0].ID != "bug-1" || root.Children[1].Children[0].ID != "bug-3" {
+		t.Errorf("unexpected plan-1 tree: %+v", root)
+	}
+	if l.Roots[1].ID != "loop-a" || l.Roots[1].Descendants != 1 || l.Roots[2].ID != "plan-3" {
+		t.Errorf("expected the loop and plan-3 after plan-1, got %s and %s", l.Roots[1].ID, l.Roots[2].ID)
+	}
+	if got := l.OpenEmergentShare(); got != 0.75 {
+		t.Errorf("expected 6 of 8 open issues emergent, got %.2f", got)
+	}
+}
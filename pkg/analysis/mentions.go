@@ -0,0 +1,110 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DepMentions is the type of the implicit links Mentions finds: one issue
+// naming another in its text. It is never stored in the beads file.
+const DepMentions model.DependencyType = "mentions"
+
+// mentionTokenSplit separates text into candidate issue IDs
+var mentionTokenSplit = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// mentionURLPattern matches web addresses, whose IDs belong to the address
+// rather than mention an issue
+var mentionURLPattern = regexp.MustCompile(`https?://\S+`)
+
+// IssueTexts returns an issue's prose: its description, design, acceptance
+// criteria, notes and comments
+func IssueTexts(issue model.Issue) []string {
+	texts := []string{issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes}
+	for _, c := range issue.Comments {
+		if c != nil {
+			texts = append(texts, c.Text)
+		}
+	}
+	return texts
+}
+
+// MentionIndex maps each issue's ID, lower-cased, to the ID, for MentionedIDs
+func MentionIndex(issues []model.Issue) map[string]string {
+	index := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		index[strings.ToLower(issue.ID)] = issue.ID
+	}
+	return index
+}
+
+// MentionedIDs returns the IDs in index that text names, matched as whole
+// tokens ignoring case (so "bv-12" doesn't match "bv-123"), in the order
+// they first appear
+func MentionedIDs(text string, index map[string]string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	text = mentionURLPattern.ReplaceAllString(text, " ")
+	for _, token := range mentionTokenSplit.Split(text, -1) {
+		id, ok := index[strings.ToLower(strings.Trim(token, "._-"))]
+		if ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Mentions returns the other issues each issue names in its prose where no
+// dependency links the two in either direction: blockers and related work
+// referred to without filing a dependency. Issues mentioning none are left
+// out.
+func Mentions(issues []model.Issue) map[string][]string {
+	index := MentionIndex(issues)
+	linked := make(map[[2]string]bool)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				linked[[2]string{issue.ID, dep.DependsOnID}] = true
+				linked[[2]string{dep.DependsOnID, issue.ID}] = true
+			}
+		}
+	}
+
+	mentions := make(map[string][]string)
+	for _, issue := range issues {
+		seen := map[string]bool{issue.ID: true}
+		for _, text := range IssueTexts(issue) {
+			for _, id := range MentionedIDs(text, index) {
+				if !seen[id] && !linked[[2]string{issue.ID, id}] {
+					mentions[issue.ID] = append(mentions[issue.ID], id)
+				}
+				seen[id] = true
+			}
+		}
+	}
+	return mentions
+}
+
+// MentionsOf returns id's implicit links from mentions, as computed by
+// Mentions: the issues it mentions, then those mentioning it, each ordered
+// by ID
+func MentionsOf(mentions map[string][]string, id string) []Relation {
+	var out, in []Relation
+	for _, other := range mentions[id] {
+		out = append(out, Relation{ID: other, Type: DepMentions, Outgoing: true})
+	}
+	for from, ids := range mentions {
+		for _, other := range ids {
+			if other == id {
+				in = append(in, Relation{ID: from, Type: DepMentions})
+			}
+		}
+	}
+	for _, rels := range [][]Relation{out, in} {
+		sort.Slice(rels, func(i, j int) bool { return rels[i].ID < rels[j].ID })
+	}
+	return append(out, in...)
+}
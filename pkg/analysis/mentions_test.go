@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestMentions(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Description: "Waiting on BV-2 and bv-3; see https://example.com/bv-4. Not bv-12 or bv-1.",
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-3", Type: model.DepBlocks}}},
+		{ID: "bv-2", Comments: []*model.Comment{{Text: "bv-4 first, then bv-2 again."}}},
+		{ID: "bv-3", Notes: "Filed from bv-1."},
+		{ID: "bv-4"},
+	}
+
+	mentions := Mentions(issues)
+	want := map[string][]string{
+		// bv-3 is already a dependency, bv-4 only appears in a URL
+		"bv-1": {"bv-2"},
+		"bv-2": {"bv-4"},
+	}
+	if !reflect.DeepEqual(mentions, want) {
+		t.Fatalf("expected %v, got %v", want, mentions)
+	}
+
+	rels := MentionsOf(mentions, "bv-2")
+	wantRels := []Relation{
+		{ID: "bv-4", Type: DepMentions, Outgoing: true},
+		{ID: "bv-1", Type: DepMentions},
+	}
+	if !reflect.DeepEqual(rels, wantRels) {
+		t.Errorf("expected %+v, got %+v", wantRels, rels)
+	}
+}
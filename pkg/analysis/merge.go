@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// MergeFields are the issue fields MergeIssue reconciles: the ones bv
+// writes back, named as bd update's flags
+var MergeFields = []string{"title", "description", "status", "priority", "assignee"}
+
+// FieldConflict is a field both sides changed, to different values
+type FieldConflict struct {
+	Field  string
+	Base   string // The value both started from
+	Ours   string
+	Theirs string
+}
+
+// IssueField returns one of MergeFields of issue as text
+func IssueField(issue model.Issue, field string) string {
+	switch field {
+	case "title":
+		return issue.Title
+	case "description":
+		return issue.Description
+	case "status":
+		return string(issue.Status)
+	case "priority":
+		return strconv.Itoa(issue.Priority)
+	case "assignee":
+		return issue.Assignee
+	}
+	return ""
+}
+
+// SetIssueField sets one of MergeFields of issue from text, as IssueField
+// renders it
+func SetIssueField(issue *model.Issue, field, value string) {
+	switch field {
+	case "title":
+		issue.Title = value
+	case "description":
+		issue.Description = value
+	case "status":
+		issue.Status = model.Status(value)
+	case "priority":
+		if n, err := strconv.Atoi(value); err == nil {
+			issue.Priority = n
+		}
+	case "assignee":
+		issue.Assignee = value
+	}
+}
+
+// MergeIssue merges ours, an edit of base, into theirs, the same issue as
+// someone else has since saved it. A field only one side changed takes
+// that side's value. A description both changed is merged line by line
+// when ours only rewrote lines in place, as checklist toggles do, and
+// each of those lines is still in theirs exactly once. It returns theirs
+// with our changes applied, and the fields it couldn't merge, which keep
+// their value.
+func MergeIssue(base, ours, theirs model.Issue) (model.Issue, []FieldConflict) {
+	merged := theirs
+	var conflicts []FieldConflict
+	for _, field := range MergeFields {
+		b, o, t := IssueField(base, field), IssueField(ours, field), IssueField(theirs, field)
+		switch {
+		case o == b || o == t:
+			continue
+		case t == b:
+			SetIssueField(&merged, field, o)
+			continue
+		case field == "description":
+			if text, ok := mergeLines(b, o, t); ok {
+				merged.Description = text
+				continue
+			}
+		}
+		conflicts = append(conflicts, FieldConflict{Field: field, Base: b, Ours: o, Theirs: t})
+	}
+	return merged, conflicts
+}
+
+// mergeLines applies the lines ours rewrote in base to theirs, reporting
+// false when ours added or removed lines or a rewritten line isn't in
+// theirs exactly once
+func mergeLines(base, ours, theirs string) (string, bool) {
+	baseLines := strings.Split(base, "\n")
+	ourLines := strings.Split(ours, "\n")
+	if len(baseLines) != len(ourLines) {
+		return "", false
+	}
+	theirLines := strings.Split(theirs, "\n")
+	for i, line := range ourLines {
+		if line == baseLines[i] {
+			continue
+		}
+		at := -1
+		for j, their := range theirLines {
+			if their == baseLines[i] {
+				if at >= 0 {
+					return "", false
+				}
+				at = j
+			}
+		}
+		if at < 0 {
+			return "", false
+		}
+		theirLines[at] = line
+	}
+	return strings.Join(theirLines, "\n"), true
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestMergeIssue(t *testing.T) {
+	base := model.Issue{ID: "A", Title: "Cart", Description: "Steps:\n- [ ] one\n- [ ] two", Status: model.StatusOpen, Priority: 2}
+
+	// We toggled a checklist item; they retitled, reprioritized and added a line
+	ours := base
+	ours.Description = "Steps:\n- [x] one\n- [ ] two"
+	theirs := base
+	theirs.Title = "Cart totals"
+	theirs.Priority = 1
+	theirs.Description = "Steps:\n- [ ] zero\n- [ ] one\n- [ ] two"
+	merged, conflicts := MergeIssue(base, ours, theirs)
+	if len(conflicts) != 0 {
+		t.Fatalf("expected a clean merge, got %+v", conflicts)
+	}
+	want := theirs
+	want.Description = "Steps:\n- [ ] zero\n- [x] one\n- [ ] two"
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("expected %+v, got %+v", want, merged)
+	}
+
+	// Both sides changed the assignee and the same line
+	ours = base
+	ours.Assignee = "alice"
+	ours.Description = "Steps:\n- [x] one\n- [ ] two"
+	theirs = base
+	theirs.Assignee = "bob"
+	theirs.Description = "Steps:\n- [ ] one (carefully)\n- [ ] two"
+	theirs.Status = model.StatusInProgress
+	merged, conflicts = MergeIssue(base, ours, theirs)
+	wantConflicts := []FieldConflict{
+		{Field: "description", Base: base.Description, Ours: ours.Description, Theirs: theirs.Description},
+		{Field: "assignee", Base: "", Ours: "alice", Theirs: "bob"},
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("expected conflicts %+v, got %+v", wantConflicts, conflicts)
+	}
+	if !reflect.DeepEqual(merged, theirs) {
+		t.Errorf("expected conflicting fields to keep their value, got %+v", merged)
+	}
+
+	// The same change on both sides is no conflict
+	ours, theirs = base, base
+	ours.Status, theirs.Status = model.StatusClosed, model.StatusClosed
+	if _, conflicts := MergeIssue(base, ours, theirs); len(conflicts) != 0 {
+		t.Errorf("expected identical changes to merge, got %+v", conflicts)
+	}
+}
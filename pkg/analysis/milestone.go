@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultThroughputWindow is how far back RecentThroughput looks by default
+const DefaultThroughputWindow = 14 * 24 * time.Hour
+
+// MilestoneStats summarizes progress towards one milestone
+type MilestoneStats struct {
+	Name       string `json:"name"`
+	Total      int    `json:"total"`
+	Done       int    `json:"done"`
+	InProgress int    `json:"in_progress"`
+	Blocked    int    `json:"blocked"`
+
+	// RemainingBlockers lists open issues (inside or outside the milestone) that
+	// directly block one of the milestone's open issues
+	RemainingBlockers []string `json:"remaining_blockers,omitempty"`
+
+	// ProjectedCompletion extrapolates recent throughput over the remaining issues.
+	// Nil when the milestone is done or there has been no recent throughput.
+	ProjectedCompletion *time.Time `json:"projected_completion,omitempty"`
+}
+
+// Remaining returns the number of issues not yet closed
+func (s MilestoneStats) Remaining() int {
+	return s.Total - s.Done
+}
+
+// Progress returns completion in [0,1] by issue count
+func (s MilestoneStats) Progress() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Done) / float64(s.Total)
+}
+
+// RecentThroughput returns the number of issues closed per day over the window
+// ending at now. Issues without a closed_at timestamp fall back to updated_at.
+func RecentThroughput(issues []model.Issue, now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	since := now.Add(-window)
+	closed := 0
+	for i := range issues {
+		issue := &issues[i]
+		if !issue.Status.IsClosed() {
+			continue
+		}
+		at := closedTime(issue)
+		if at.After(since) && !at.After(now) {
+			closed++
+		}
+	}
+	return float64(closed) / (window.Hours() / 24)
+}
+
+// ComputeMilestoneStats groups issues by milestone (see model.Issue.MilestoneName),
+// ordered by name with embedded numbers compared numerically (v1.9 before v1.10).
+// Completion dates are projected from the project-wide throughput over
+// DefaultThroughputWindow, since a single milestone rarely has enough history.
+func ComputeMilestoneStats(issues []model.Issue, now time.Time) []MilestoneStats {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	byName := make(map[string]*MilestoneStats)
+	blockerSets := make(map[string]map[string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		name := issue.MilestoneName()
+		if name == "" {
+			continue
+		}
+		s, ok := byName[name]
+		if !ok {
+			s = &MilestoneStats{Name: name}
+			byName[name] = s
+			blockerSets[name] = make(map[string]bool)
+		}
+
+		s.Total++
+		switch issue.Status {
+		case model.StatusClosed:
+			s.Done++
+			continue
+		case model.StatusInProgress:
+			s.InProgress++
+		case model.StatusBlocked:
+			s.Blocked++
+		}
+
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !isBlockingDep(dep.Type) {
+				continue
+			}
+			if blocker, ok := issueMap[dep.DependsOnID]; ok && !blocker.Status.IsClosed() {
+				blockerSets[name][blocker.ID] = true
+			}
+		}
+	}
+
+	rate := RecentThroughput(issues, now, DefaultThroughputWindow)
+
+	result := make([]MilestoneStats, 0, len(byName))
+	for name, s := range byName {
+		for id := range blockerSets[name] {
+			s.RemainingBlockers = append(s.RemainingBlockers, id)
+		}
+		sort.Strings(s.RemainingBlockers)
+
+		if remaining := s.Remaining(); remaining > 0 && rate > 0 {
+			days := math.Ceil(float64(remaining) / rate)
+			eta := now.Add(time.Duration(days) * 24 * time.Hour)
+			s.ProjectedCompletion = &eta
+		}
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return naturalLess(result[i].Name, result[j].Name)
+	})
+	return result
+}
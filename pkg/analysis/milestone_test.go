@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRecentThroughput(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-2 * 24 * time.Hour)
+	old := now.Add(-30 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, ClosedAt: &recent},
+		{ID: "B", Status: model.StatusClosed, UpdatedAt: recent}, // falls back to updated_at
+		{ID: "C", Status: model.StatusClosed, ClosedAt: &old},
+		{ID: "D", Status: model.StatusOpen, UpdatedAt: recent},
+	}
+	if got := RecentThroughput(issues, now, 4*24*time.Hour); got != 0.5 {
+		t.Fatalf("expected 2 closures over 4 days = 0.5/day, got %v", got)
+	}
+}
+
+func TestComputeMilestoneStats(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, Milestone: "v1.10", ClosedAt: &closedAt},
+		{ID: "B", Status: model.StatusOpen, Milestone: "v1.10",
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "X", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusBlocked, Labels: []string{"milestone:v1.10"},
+			Dependencies: []*model.Dependency{
+				{IssueID: "C", DependsOnID: "X", Type: model.DepBlocks},
+				{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks}, // closed, not remaining
+			}},
+		{ID: "X", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusClosed, Milestone: "v1.9", ClosedAt: &closedAt},
+	}
+
+	stats := ComputeMilestoneStats(issues, now)
+	if len(stats) != 2 || stats[0].Name != "v1.9" || stats[1].Name != "v1.10" {
+		t.Fatalf("expected v1.9 then v1.10, got %+v", stats)
+	}
+
+	done := stats[0]
+	if done.Progress() != 1 || done.ProjectedCompletion != nil {
+		t.Errorf("completed milestone should be 100%% with no projection: %+v", done)
+	}
+
+	s := stats[1]
+	if s.Total != 3 || s.Done != 1 || s.Blocked != 1 || s.Remaining() != 2 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	if len(s.RemainingBlockers) != 1 || s.RemainingBlockers[0] != "X" {
+		t.Errorf("expected X as the only remaining blocker, got %v", s.RemainingBlockers)
+	}
+	// 2 closures in 14 days = 1/7 per day; 2 remaining -> 14 days
+	if s.ProjectedCompletion == nil {
+		t.Fatalf("expected a projected completion date")
+	}
+	if want := now.Add(14 * 24 * time.Hour); !s.ProjectedCompletion.Equal(want) {
+		t.Errorf("expected projection %v, got %v", want, *s.ProjectedCompletion)
+	}
+}
+
+func TestComputeMilestoneStats_NoThroughput(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Status: model.StatusOpen, Milestone: "m1"}}
+	stats := ComputeMilestoneStats(issues, time.Now())
+	if len(stats) != 1 || stats[0].ProjectedCompletion != nil {
+		t.Fatalf("expected no projection without throughput, got %+v", stats)
+	}
+}
@@ -3,6 +3,7 @@ package analysis
 import (
 	"fmt"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -19,12 +20,14 @@ type ImpactScore struct {
 }
 
 // ScoreBreakdown shows the weighted contribution of each component
+// (see ImpactWeights for the weights)
 type ScoreBreakdown struct {
-	PageRank      float64 `json:"pagerank"`       // 0.30 weight
-	Betweenness   float64 `json:"betweenness"`    // 0.30 weight
-	BlockerRatio  float64 `json:"blocker_ratio"`  // 0.20 weight
-	Staleness     float64 `json:"staleness"`      // 0.10 weight
-	PriorityBoost float64 `json:"priority_boost"` // 0.10 weight
+	PageRank      float64 `json:"pagerank"`
+	Betweenness   float64 `json:"betweenness"`
+	BlockerRatio  float64 `json:"blocker_ratio"`
+	Staleness     float64 `json:"staleness"`
+	PriorityBoost float64 `json:"priority_boost"`
+	DueDate       float64 `json:"due_date"`
 
 	// Raw normalized values (before weighting)
 	PageRankNorm      float64 `json:"pagerank_norm"`
@@ -32,17 +35,97 @@ type ScoreBreakdown struct {
 	BlockerRatioNorm  float64 `json:"blocker_ratio_norm"`
 	StalenessNorm     float64 `json:"staleness_norm"`
 	PriorityBoostNorm float64 `json:"priority_boost_norm"`
+	DueDateNorm       float64 `json:"due_date_norm"`
 }
 
-// Weights for composite score
+// Default weights for composite score
 const (
 	WeightPageRank      = 0.30
 	WeightBetweenness   = 0.30
 	WeightBlockerRatio  = 0.20
 	WeightStaleness     = 0.10
 	WeightPriorityBoost = 0.10
+	WeightDueDate       = 0.0
 )
 
+// ImpactWeights sets how much each component counts toward the impact
+// score. Scores use the weights scaled to sum to 1, so they stay in 0-1.
+type ImpactWeights struct {
+	PageRank      float64 `json:"pagerank"`
+	Betweenness   float64 `json:"betweenness"`
+	BlockerRatio  float64 `json:"blast_radius"` // Direct dependents
+	Staleness     float64 `json:"staleness"`    // Days since update
+	PriorityBoost float64 `json:"priority"`
+	DueDate       float64 `json:"due_date"` // Proximity of the due date
+}
+
+// DefaultImpactWeights returns the built-in weights
+func DefaultImpactWeights() ImpactWeights {
+	return ImpactWeights{
+		PageRank:      WeightPageRank,
+		Betweenness:   WeightBetweenness,
+		BlockerRatio:  WeightBlockerRatio,
+		Staleness:     WeightStaleness,
+		PriorityBoost: WeightPriorityBoost,
+		DueDate:       WeightDueDate,
+	}
+}
+
+// Validate checks that no weight is negative and at least one is positive
+func (w ImpactWeights) Validate() error {
+	for _, v := range []float64{w.PageRank, w.Betweenness, w.BlockerRatio, w.Staleness, w.PriorityBoost, w.DueDate} {
+		if v < 0 {
+			return fmt.Errorf("impact weights can't be negative")
+		}
+	}
+	if w.sum() == 0 {
+		return fmt.Errorf("at least one impact weight must be positive")
+	}
+	return nil
+}
+
+func (w ImpactWeights) sum() float64 {
+	return w.PageRank + w.Betweenness + w.BlockerRatio + w.Staleness + w.PriorityBoost + w.DueDate
+}
+
+// Normalized returns the weights scaled to sum to 1
+func (w ImpactWeights) Normalized() ImpactWeights {
+	total := w.sum()
+	if total <= 0 {
+		return DefaultImpactWeights()
+	}
+	return ImpactWeights{
+		PageRank:      w.PageRank / total,
+		Betweenness:   w.Betweenness / total,
+		BlockerRatio:  w.BlockerRatio / total,
+		Staleness:     w.Staleness / total,
+		PriorityBoost: w.PriorityBoost / total,
+		DueDate:       w.DueDate / total,
+	}
+}
+
+// impactWeights holds the weights set with SetImpactWeights, nil for the defaults
+var impactWeights atomic.Pointer[ImpactWeights]
+
+// SetImpactWeights changes the weights every impact score uses from now
+// on. Invalid weights are an error and leave the current ones in place.
+func SetImpactWeights(w ImpactWeights) error {
+	if err := w.Validate(); err != nil {
+		return err
+	}
+	n := w.Normalized()
+	impactWeights.Store(&n)
+	return nil
+}
+
+// CurrentImpactWeights returns the weights in use, scaled to sum to 1
+func CurrentImpactWeights() ImpactWeights {
+	if w := impactWeights.Load(); w != nil {
+		return *w
+	}
+	return DefaultImpactWeights().Normalized()
+}
+
 // ComputeImpactScores calculates impact scores for all open issues
 func (a *Analyzer) ComputeImpactScores() []ImpactScore {
 	return a.ComputeImpactScoresAt(time.Now())
@@ -56,6 +139,15 @@ func (a *Analyzer) ComputeImpactScoresAt(now time.Time) []ImpactScore {
 	}
 
 	stats := a.Analyze()
+	return a.ComputeImpactScoresFromStats(&stats, now)
+}
+
+// ComputeImpactScoresFromStats calculates impact scores from stats that have
+// already been computed, so callers holding a finished Phase 2 don't rerun it
+func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time) []ImpactScore {
+	if len(a.issueMap) == 0 || stats == nil {
+		return nil
+	}
 
 	// Get thread-safe copies of Phase 2 data
 	pageRank := stats.PageRank()
@@ -65,6 +157,7 @@ func (a *Analyzer) ComputeImpactScoresAt(now time.Time) []ImpactScore {
 	maxPR := findMax(pageRank)
 	maxBW := findMax(betweenness)
 	maxBlockers := findMaxInt(stats.InDegree)
+	w := CurrentImpactWeights()
 
 	var scores []ImpactScore
 
@@ -80,27 +173,31 @@ func (a *Analyzer) ComputeImpactScoresAt(now time.Time) []ImpactScore {
 		blockerNorm := normalizeInt(stats.InDegree[id], maxBlockers)
 		stalenessNorm := computeStaleness(issue.UpdatedAt, now)
 		priorityNorm := computePriorityBoost(issue.Priority)
+		dueNorm := computeDueProximity(issue.DueDate, now)
 
 		// Compute weighted score
 		breakdown := ScoreBreakdown{
-			PageRank:      prNorm * WeightPageRank,
-			Betweenness:   bwNorm * WeightBetweenness,
-			BlockerRatio:  blockerNorm * WeightBlockerRatio,
-			Staleness:     stalenessNorm * WeightStaleness,
-			PriorityBoost: priorityNorm * WeightPriorityBoost,
+			PageRank:      prNorm * w.PageRank,
+			Betweenness:   bwNorm * w.Betweenness,
+			BlockerRatio:  blockerNorm * w.BlockerRatio,
+			Staleness:     stalenessNorm * w.Staleness,
+			PriorityBoost: priorityNorm * w.PriorityBoost,
+			DueDate:       dueNorm * w.DueDate,
 
 			PageRankNorm:      prNorm,
 			BetweennessNorm:   bwNorm,
 			BlockerRatioNorm:  blockerNorm,
 			StalenessNorm:     stalenessNorm,
 			PriorityBoostNorm: priorityNorm,
+			DueDateNorm:       dueNorm,
 		}
 
 		score := breakdown.PageRank +
 			breakdown.Betweenness +
 			breakdown.BlockerRatio +
 			breakdown.Staleness +
-			breakdown.PriorityBoost
+			breakdown.PriorityBoost +
+			breakdown.DueDate
 
 		scores = append(scores, ImpactScore{
 			IssueID:   id,
@@ -182,6 +279,23 @@ func computePriorityBoost(priority int) float64 {
 	}
 }
 
+// computeDueProximity returns a 0-1 score for how close the due date is:
+// 1 when due today or overdue, falling to 0 at two weeks out or with no
+// due date
+func computeDueProximity(due *time.Time, now time.Time) float64 {
+	if due == nil {
+		return 0
+	}
+	days := due.Sub(now).Hours() / 24
+	switch {
+	case days <= 0:
+		return 1
+	case days >= 14:
+		return 0
+	}
+	return 1 - days/14
+}
+
 // normalize returns v/max, handling zero max
 func normalize(v, max float64) float64 {
 	if max == 0 {
@@ -259,7 +373,17 @@ func (a *Analyzer) GenerateRecommendations() []PriorityRecommendation {
 
 // GenerateRecommendationsWithThresholds generates recommendations with custom thresholds
 func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds RecommendationThresholds) []PriorityRecommendation {
-	scores := a.ComputeImpactScores()
+	return a.recommendationsFromScores(a.ComputeImpactScores(), thresholds)
+}
+
+// GenerateRecommendationsFromStats generates recommendations from stats whose
+// Phase 2 has already completed, without running the analysis again
+func (a *Analyzer) GenerateRecommendationsFromStats(stats *GraphStats) []PriorityRecommendation {
+	return a.recommendationsFromScores(a.ComputeImpactScoresFromStats(stats, time.Now()), DefaultThresholds())
+}
+
+// recommendationsFromScores turns impact scores into filtered, sorted recommendations
+func (a *Analyzer) recommendationsFromScores(scores []ImpactScore, thresholds RecommendationThresholds) []PriorityRecommendation {
 	if len(scores) == 0 {
 		return nil
 	}
@@ -333,6 +457,17 @@ func generateRecommendation(score ImpactScore, unblocksCount int, thresholds Rec
 		signalStrength += 0.2
 	}
 
+	// Check due date, only when it counts toward the score
+	if score.Breakdown.DueDate > 0 && score.Breakdown.DueDateNorm >= 0.5 {
+		if score.Breakdown.DueDateNorm >= 1 {
+			reasoning = append(reasoning, "Due now or overdue")
+		} else {
+			reasoning = append(reasoning, "Due within a week")
+		}
+		signals++
+		signalStrength += score.Breakdown.DueDateNorm * 0.3
+	}
+
 	// No signals = no recommendation needed
 	if signals == 0 {
 		return nil
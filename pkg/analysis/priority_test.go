@@ -251,6 +251,48 @@ func TestScoreBreakdownWeights(t *testing.T) {
 	}
 }
 
+func TestCustomImpactWeights(t *testing.T) {
+	defer analysis.SetImpactWeights(analysis.DefaultImpactWeights())
+
+	now := time.Now()
+	soon := now.Add(3 * 24 * time.Hour)
+	overdue := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "late", Title: "Overdue", Status: model.StatusOpen, Priority: 4, UpdatedAt: now, DueDate: &overdue},
+		{ID: "soon", Title: "Due soon", Status: model.StatusOpen, Priority: 4, UpdatedAt: now, DueDate: &soon},
+		{ID: "urgent", Title: "No due date", Status: model.StatusOpen, Priority: 0, UpdatedAt: now},
+	}
+
+	if err := analysis.SetImpactWeights(analysis.ImpactWeights{PriorityBoost: -1, DueDate: 2}); err == nil {
+		t.Error("negative weight should be rejected")
+	}
+	if err := analysis.SetImpactWeights(analysis.ImpactWeights{}); err == nil {
+		t.Error("all-zero weights should be rejected")
+	}
+
+	// Weights are scaled to sum to 1
+	if err := analysis.SetImpactWeights(analysis.ImpactWeights{PriorityBoost: 1, DueDate: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if w := analysis.CurrentImpactWeights(); w.DueDate != 0.75 || w.PriorityBoost != 0.25 {
+		t.Errorf("weights not normalized: %+v", w)
+	}
+
+	scores := analysis.NewAnalyzer(issues).ComputeImpactScoresAt(now)
+	if len(scores) != 3 || scores[0].IssueID != "late" || scores[1].IssueID != "soon" {
+		t.Fatalf("expected due dates to rank first, got %+v", scores)
+	}
+	if scores[0].Score != 0.75 || scores[0].Breakdown.DueDateNorm != 1 {
+		t.Errorf("overdue issue: score %v, due norm %v", scores[0].Score, scores[0].Breakdown.DueDateNorm)
+	}
+	if got := scores[1].Breakdown.DueDateNorm; got <= 0.7 || got >= 0.8 {
+		t.Errorf("due in 3 days should be about 11/14, got %v", got)
+	}
+	if scores[2].Score != 0.25 || scores[2].Breakdown.PageRank != 0 {
+		t.Errorf("P0 issue: score %v, breakdown %+v", scores[2].Score, scores[2].Breakdown)
+	}
+}
+
 func TestComputeImpactScoreDetails(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "test", Title: "Test Issue", Status: model.StatusInProgress, Priority: 2},
@@ -446,3 +488,29 @@ func TestRecommendationDirection(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateRecommendationsFromStatsMatchesAnalyze(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Core", Status: model.StatusOpen, Priority: 4},
+		{ID: "B", Title: "Dep", Status: model.StatusOpen, Priority: 1, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Dep", Status: model.StatusOpen, Priority: 1, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	an := analysis.NewAnalyzer(issues)
+	stats := an.AnalyzeAsync()
+	stats.WaitForPhase2()
+
+	got := an.GenerateRecommendationsFromStats(stats)
+	want := an.GenerateRecommendations()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d recommendations, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].IssueID != want[i].IssueID || got[i].SuggestedPriority != want[i].SuggestedPriority {
+			t.Errorf("recommendation %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if recs := an.GenerateRecommendationsFromStats(nil); recs != nil {
+		t.Errorf("expected nil recommendations for nil stats, got %v", recs)
+	}
+}
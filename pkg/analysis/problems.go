@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ProblemKind classifies a data problem
+type ProblemKind string
+
+const (
+	ProblemDuplicateID    ProblemKind = "duplicate_id"
+	ProblemDanglingDep    ProblemKind = "dangling_dependency"
+	ProblemSelfDep        ProblemKind = "self_dependency"
+	ProblemUnknownDepType ProblemKind = "unknown_dependency_type"
+	ProblemUnreadableLine ProblemKind = "unreadable_line" // Reported by the loader, not FindDataProblems
+)
+
+// Label returns a short human-readable name for the kind
+func (k ProblemKind) Label() string {
+	switch k {
+	case ProblemDuplicateID:
+		return "Duplicate ID"
+	case ProblemDanglingDep:
+		return "Missing dependency"
+	case ProblemSelfDep:
+		return "Self-dependency"
+	case ProblemUnknownDepType:
+		return "Unknown dep type"
+	case ProblemUnreadableLine:
+		return "Unreadable line"
+	}
+	return string(k)
+}
+
+// DataProblem is an inconsistency in the loaded issues that the views would
+// otherwise hide, such as a dependency on an issue that doesn't exist
+type DataProblem struct {
+	Kind      ProblemKind `json:"kind"`
+	IssueID   string      `json:"issue_id,omitempty"`   // The record to fix; "" for unreadable lines
+	Line      int         `json:"line,omitempty"`       // 1-based line in the beads file, when known
+	DependsOn string      `json:"depends_on,omitempty"` // The missing ID, for dangling dependencies
+	Detail    string      `json:"detail"`
+}
+
+// FindDataProblems checks loaded issues for duplicate IDs, dependencies on
+// unknown issues, self-dependencies and unrecognized dependency types.
+// Problems are sorted by issue ID, then kind.
+func FindDataProblems(issues []model.Issue) []DataProblem {
+	var problems []DataProblem
+
+	count := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		count[issue.ID]++
+	}
+	for id, n := range count {
+		if n > 1 {
+			problems = append(problems, DataProblem{
+				Kind:    ProblemDuplicateID,
+				IssueID: id,
+				Detail:  fmt.Sprintf("%d records share this ID; bv uses the one updated last", n),
+			})
+		}
+	}
+
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
+			}
+			switch {
+			case dep.DependsOnID == issue.ID:
+				problems = append(problems, DataProblem{
+					Kind:    ProblemSelfDep,
+					IssueID: issue.ID,
+					Detail:  "depends on itself",
+				})
+			case count[dep.DependsOnID] == 0:
+				problems = append(problems, DataProblem{
+					Kind:      ProblemDanglingDep,
+					IssueID:   issue.ID,
+					DependsOn: dep.DependsOnID,
+					Detail:    fmt.Sprintf("depends on %s, which is not in the file", dep.DependsOnID),
+				})
+			}
+			if dep.Type != "" && !dep.Type.IsValid() {
+				problems = append(problems, DataProblem{
+					Kind:    ProblemUnknownDepType,
+					IssueID: issue.ID,
+					Detail:  fmt.Sprintf("dependency on %s has unknown type %q", dep.DependsOnID, dep.Type),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].IssueID != problems[j].IssueID {
+			return naturalLess(problems[i].IssueID, problems[j].IssueID)
+		}
+		return problems[i].Kind < problems[j].Kind
+	})
+	return problems
+}
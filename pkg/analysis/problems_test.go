@@ -0,0 +1,55 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFindDataProblems(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "A", Status: model.StatusClosed},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+			{IssueID: "B", DependsOnID: "GONE", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "C", DependsOnID: "C", Type: model.DepBlocks},
+			{IssueID: "C", DependsOnID: "A", Type: "mentions"},
+		}},
+	}
+
+	got := analysis.FindDataProblems(issues)
+	want := []struct {
+		kind analysis.ProblemKind
+		id   string
+	}{
+		{analysis.ProblemDuplicateID, "A"},
+		{analysis.ProblemDanglingDep, "B"},
+		{analysis.ProblemSelfDep, "C"},
+		{analysis.ProblemUnknownDepType, "C"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d problems, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Kind != w.kind || got[i].IssueID != w.id {
+			t.Errorf("problem %d: got %s on %s, want %s on %s", i, got[i].Kind, got[i].IssueID, w.kind, w.id)
+		}
+	}
+	if got[1].Detail != "depends on GONE, which is not in the file" {
+		t.Errorf("unexpected detail %q", got[1].Detail)
+	}
+}
+
+func TestFindDataProblemsClean(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	if got := analysis.FindDataProblems(issues); len(got) != 0 {
+		t.Errorf("expected no problems, got %+v", got)
+	}
+}
@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// RedundantDependency is a blocking dependency already implied by a longer
+// chain of them, so dropping it changes no ordering
+type RedundantDependency struct {
+	IssueID     string   `json:"issue_id"`
+	DependsOnID string   `json:"depends_on_id"`
+	Via         []string `json:"via"` // The chain between the two, in order
+}
+
+// String explains the redundancy, e.g. "A→C is implied by A→B→C"
+func (r RedundantDependency) String() string {
+	chain := append(append([]string{r.IssueID}, r.Via...), r.DependsOnID)
+	return r.IssueID + "→" + r.DependsOnID + " is implied by " + strings.Join(chain, "→")
+}
+
+// RedundantDependencies returns the blocking dependencies that the
+// transitive reduction of the graph drops: those whose blocker is also
+// reached through the issue's other blockers. Dependencies on a cycle are
+// left alone, since a cycle has no unique reduction. Results are ordered by
+// issue, then blocker.
+func RedundantDependencies(issues []model.Issue) []RedundantDependency {
+	inSet := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		inSet[issue.ID] = true
+	}
+	edges := make(map[string][]string, len(issues))
+	for _, issue := range issues {
+		seen := make(map[string]bool)
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !isBlockingDep(dep.Type) || !inSet[dep.DependsOnID] ||
+				dep.DependsOnID == issue.ID || seen[dep.DependsOnID] {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			edges[issue.ID] = append(edges[issue.ID], dep.DependsOnID)
+		}
+		sort.Strings(edges[issue.ID])
+	}
+
+	var redundant []RedundantDependency
+	ids := make([]string, 0, len(edges))
+	for id := range edges {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, from := range ids {
+		if len(edges[from]) < 2 {
+			continue
+		}
+		for _, to := range edges[from] {
+			via := chainAvoiding(edges, from, to)
+			if via == nil || reaches(edges, to, from) {
+				continue
+			}
+			redundant = append(redundant, RedundantDependency{IssueID: from, DependsOnID: to, Via: via})
+		}
+	}
+	return redundant
+}
+
+// chainAvoiding finds the shortest path from one issue to another that
+// doesn't take the direct edge between them, and returns the issues along
+// it, excluding both ends. It returns nil when there is no such path.
+func chainAvoiding(edges map[string][]string, from, to string) []string {
+	parent := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[id] {
+			if id == from && next == to {
+				continue
+			}
+			if _, seen := parent[next]; seen {
+				continue
+			}
+			parent[next] = id
+			if next == to {
+				var via []string
+				for p := id; p != from; p = parent[p] {
+					via = append([]string{p}, via...)
+				}
+				return via
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// reaches reports whether to can be reached from from
+func reaches(edges map[string][]string, from, to string) bool {
+	seen := map[string]bool{from: true}
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range edges[id] {
+			if next == to {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRedundantDependencies(t *testing.T) {
+	on := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		// A→C is implied by A→B→C; A→D by A→C→D
+		{ID: "A", Dependencies: on("B", "C", "D")},
+		{ID: "B", Dependencies: on("C")},
+		{ID: "C", Dependencies: on("D")},
+		{ID: "D"},
+		// Related links don't make a chain
+		{ID: "E", Dependencies: append(on("D"), &model.Dependency{DependsOnID: "C", Type: model.DepRelated})},
+		// X→Z and Y→Z are on the cycle X→Y→Z→X, so neither is dropped
+		{ID: "X", Dependencies: on("Y", "Z")},
+		{ID: "Y", Dependencies: on("Z")},
+		{ID: "Z", Dependencies: on("X")},
+	}
+
+	got := RedundantDependencies(issues)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 redundant dependencies, got %+v", got)
+	}
+	if got[0].String() != "A→C is implied by A→B→C" {
+		t.Errorf("unexpected first finding %q", got[0])
+	}
+	if got[1].String() != "A→D is implied by A→C→D" {
+		t.Errorf("expected the shortest chain for A→D, got %q", got[1])
+	}
+}
@@ -0,0 +1,50 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Relation is a non-blocking link between two issues, such as related,
+// discovered-from or a custom type declared non-blocking, seen from one end
+type Relation struct {
+	ID       string               `json:"id"`       // The issue at the other end
+	Type     model.DependencyType `json:"type"`     // The link's dependency type
+	Outgoing bool                 `json:"outgoing"` // Declared on this issue rather than the other
+}
+
+// IsRelation reports whether a dependency of type t is a non-blocking link
+// rather than part of the blocking graph or the parent-child hierarchy.
+// Untyped dependencies are legacy blockers.
+func IsRelation(t model.DependencyType) bool {
+	return t != "" && t != model.DepParentChild && !t.IsBlocking()
+}
+
+// RelationsOf returns id's non-blocking links in both directions: those it
+// declares, then those declared on it, each ordered by type and ID
+func RelationsOf(issues []model.Issue, id string) []Relation {
+	var out, in []Relation
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !IsRelation(dep.Type) {
+				continue
+			}
+			switch {
+			case issue.ID == id && dep.DependsOnID != id:
+				out = append(out, Relation{ID: dep.DependsOnID, Type: dep.Type, Outgoing: true})
+			case dep.DependsOnID == id && issue.ID != id:
+				in = append(in, Relation{ID: issue.ID, Type: dep.Type})
+			}
+		}
+	}
+	for _, rels := range [][]Relation{out, in} {
+		sort.Slice(rels, func(i, j int) bool {
+			if rels[i].Type != rels[j].Type {
+				return rels[i].Type < rels[j].Type
+			}
+			return rels[i].ID < rels[j].ID
+		})
+	}
+	return append(out, in...)
+}
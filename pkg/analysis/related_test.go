@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRelationsOf(t *testing.T) {
+	model.SetCustomDependencyTypes(map[model.DependencyType]bool{"supersedes": true, "duplicates": false})
+	defer model.SetCustomDependencyTypes(nil)
+
+	dep := func(on string, typ model.DependencyType) *model.Dependency {
+		return &model.Dependency{DependsOnID: on, Type: typ}
+	}
+	issues := []model.Issue{
+		{ID: "A", Dependencies: []*model.Dependency{
+			dep("B", model.DepBlocks),
+			dep("C", model.DepRelated),
+			dep("D", "duplicates"),
+			dep("E", "supersedes"),
+			dep("F", model.DepParentChild),
+		}},
+		{ID: "B"},
+		{ID: "C"},
+		{ID: "G", Dependencies: []*model.Dependency{dep("A", model.DepDiscoveredFrom)}},
+	}
+
+	rels := RelationsOf(issues, "A")
+	want := []Relation{
+		{ID: "D", Type: "duplicates", Outgoing: true},
+		{ID: "C", Type: model.DepRelated, Outgoing: true},
+		{ID: "G", Type: model.DepDiscoveredFrom},
+	}
+	if len(rels) != len(want) {
+		t.Fatalf("expected %v, got %v", want, rels)
+	}
+	for i := range want {
+		if rels[i] != want[i] {
+			t.Errorf("relation %d: expected %+v, got %+v", i, want[i], rels[i])
+		}
+	}
+
+	if rels := RelationsOf(issues, "C"); len(rels) != 1 || rels[0].ID != "A" || rels[0].Outgoing {
+		t.Errorf("expected C to see A's related link, got %v", rels)
+	}
+}
@@ -0,0 +1,328 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Risk factor names, as reported in RiskFactor.Name
+const (
+	RiskImpact    = "impact"
+	RiskProgress  = "progress"
+	RiskOwnership = "single_owner"
+	RiskDue       = "due"
+)
+
+// riskFloor is what a factor at zero still contributes to the product, so
+// one quiet factor damps a score instead of erasing it: work with no due
+// date can still be risky
+const riskFloor = 0.2
+
+// dominantRiskFactor is the value at which a factor counts as driving a score
+const dominantRiskFactor = 0.5
+
+// RiskFactor is one ingredient of a risk score, from 0 (no risk) to 1
+type RiskFactor struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Reason string  `json:"reason"` // e.g. "due in 3 days", "all 4 open on sam"
+}
+
+// RiskScore is the composite risk of an open issue or epic: high impact ×
+// low progress × work concentrated on one person × an approaching due date
+type RiskScore struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Epic     bool   `json:"epic"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee,omitempty"`
+
+	// Score is 0-100; 100 means every factor is at its worst
+	Score float64 `json:"score"`
+
+	// Factors holds all four factors, strongest first
+	Factors []RiskFactor `json:"factors"`
+}
+
+// Dominant returns the factors driving the score: those at or above one
+// half, or the strongest alone when none is
+func (r RiskScore) Dominant() []RiskFactor {
+	var dominant []RiskFactor
+	for _, f := range r.Factors {
+		if f.Value >= dominantRiskFactor {
+			dominant = append(dominant, f)
+		}
+	}
+	if len(dominant) == 0 && len(r.Factors) > 0 && r.Factors[0].Value > 0 {
+		dominant = r.Factors[:1]
+	}
+	return dominant
+}
+
+// Explain describes the dominant factors, e.g. "due in 2 days; P1, blocks 5
+// open"
+func (r RiskScore) Explain() string {
+	var reasons []string
+	for _, f := range r.Dominant() {
+		reasons = append(reasons, f.Reason)
+	}
+	if len(reasons) == 0 {
+		return "no factor stands out"
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// ComputeRiskScores scores every open issue and epic, riskiest first (then
+// by priority and ID). An epic is judged by its open subtree: the share of
+// its descendants still open, who holds them, the earliest due date among
+// them and the work outside the epic waiting on them.
+func ComputeRiskScores(issues []model.Issue, now time.Time) []RiskScore {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	blockers := OpenBlockers(issues)
+	waiters := make(map[string][]string)
+	for id, bs := range blockers {
+		for _, b := range bs {
+			waiters[b] = append(waiters[b], id)
+		}
+	}
+
+	type unit struct {
+		issue      *model.Issue
+		members    []*model.Issue // The issue, or an epic's descendants
+		downstream int
+	}
+	var units []unit
+	maxDownstream := 0
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() {
+			continue
+		}
+		u := unit{issue: issue}
+		if issue.IssueType == model.TypeEpic {
+			subtree := Subtree(issues, issue.ID)
+			for id := range subtree {
+				if member, ok := issueMap[id]; ok && id != issue.ID {
+					u.members = append(u.members, member)
+				}
+			}
+			sort.Slice(u.members, func(a, b int) bool { return u.members[a].ID < u.members[b].ID })
+			u.downstream = countOutside(subtree, waiters)
+		} else {
+			u.members = []*model.Issue{issue}
+			u.downstream = countDownstream(issue.ID, waiters)
+		}
+		maxDownstream = max(maxDownstream, u.downstream)
+		units = append(units, u)
+	}
+
+	scores := make([]RiskScore, 0, len(units))
+	for _, u := range units {
+		var path []*model.Issue
+		if u.issue.IssueType == model.TypeEpic {
+			path = u.members
+		} else {
+			// Whoever must deliver the issue and what it waits on
+			path = append([]*model.Issue{u.issue}, openChain(u.issue.ID, blockers, issueMap)...)
+		}
+		r := RiskScore{
+			ID:       u.issue.ID,
+			Title:    u.issue.Title,
+			Epic:     u.issue.IssueType == model.TypeEpic,
+			Priority: u.issue.Priority,
+			Assignee: u.issue.Assignee,
+			Factors: []RiskFactor{
+				impactFactor(u.issue, u.downstream, maxDownstream),
+				progressFactor(u.issue, u.members),
+				ownershipFactor(path),
+				dueFactor(u.issue, u.members, now),
+			},
+		}
+		product := 1.0
+		for _, f := range r.Factors {
+			product *= riskFloor + (1-riskFloor)*f.Value
+		}
+		r.Score = math.Round(product*1000) / 10
+		sort.SliceStable(r.Factors, func(a, b int) bool { return r.Factors[a].Value > r.Factors[b].Value })
+		scores = append(scores, r)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		a, b := scores[i], scores[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	return scores
+}
+
+// countOutside counts the open issues outside set that wait, directly or
+// through a chain, on an issue inside it
+func countOutside(set map[string]bool, waiters map[string][]string) int {
+	seen := make(map[string]bool)
+	var stack []string
+	for id := range set {
+		stack = append(stack, id)
+	}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, w := range waiters[next] {
+			if !seen[w] && !set[w] {
+				seen[w] = true
+				stack = append(stack, w)
+			}
+		}
+	}
+	return len(seen)
+}
+
+// openChain returns the open issues id waits on, directly or through a chain
+func openChain(id string, blockers map[string][]string, issueMap map[string]*model.Issue) []*model.Issue {
+	var chain []*model.Issue
+	seen := map[string]bool{id: true}
+	stack := []string{id}
+	for len(stack) > 0 {
+		next := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, b := range blockers[next] {
+			if seen[b] {
+				continue
+			}
+			seen[b] = true
+			stack = append(stack, b)
+			if issue, ok := issueMap[b]; ok {
+				chain = append(chain, issue)
+			}
+		}
+	}
+	return chain
+}
+
+// impactFactor weighs priority and the open work waiting on the issue equally
+func impactFactor(issue *model.Issue, downstream, maxDownstream int) RiskFactor {
+	value := (computePriorityBoost(issue.Priority) + normalizeInt(downstream, maxDownstream)) / 2
+	reason := fmt.Sprintf("P%d", issue.Priority)
+	if downstream > 0 {
+		reason += fmt.Sprintf(", blocks %d open", downstream)
+	}
+	return RiskFactor{Name: RiskImpact, Value: value, Reason: reason}
+}
+
+// statusProgress is how far along work in status is, from 0 to 1
+func statusProgress(status model.Status) float64 {
+	switch {
+	case status.IsClosed():
+		return 1
+	case status == model.StatusInProgress:
+		return 0.5
+	}
+	return 0
+}
+
+// progressFactor is how little of the work is done: the issue's own status,
+// or for an epic the average over its descendants
+func progressFactor(issue *model.Issue, members []*model.Issue) RiskFactor {
+	if issue.IssueType != model.TypeEpic || len(members) == 0 {
+		reason := "not started"
+		if issue.Status == model.StatusInProgress {
+			reason = "in progress"
+		} else if issue.Status == model.StatusBlocked {
+			reason = "blocked"
+		}
+		return RiskFactor{Name: RiskProgress, Value: 1 - statusProgress(issue.Status), Reason: reason}
+	}
+	done, closed := 0.0, 0
+	for _, m := range members {
+		done += statusProgress(m.Status)
+		if m.Status.IsClosed() {
+			closed++
+		}
+	}
+	return RiskFactor{
+		Name:   RiskProgress,
+		Value:  1 - done/float64(len(members)),
+		Reason: fmt.Sprintf("%d of %d done", closed, len(members)),
+	}
+}
+
+// ownershipFactor is how much of the open work rests on one person: their
+// share of it, discounted for small amounts of work, since a lone issue
+// with one owner is the norm rather than a risk. Unassigned work is nobody's
+// share; lint's unassigned_priority rule covers it.
+func ownershipFactor(work []*model.Issue) RiskFactor {
+	counts := make(map[string]int)
+	open := 0
+	for _, w := range work {
+		if w.Status.IsClosed() {
+			continue
+		}
+		open++
+		if w.Assignee != "" {
+			counts[w.Assignee]++
+		}
+	}
+	top, topCount := "", 0
+	for who, n := range counts {
+		if n > topCount || (n == topCount && who < top) {
+			top, topCount = who, n
+		}
+	}
+	if topCount == 0 {
+		return RiskFactor{Name: RiskOwnership, Value: 0, Reason: "unassigned"}
+	}
+
+	value := normalizeInt(topCount, open) * float64(open) / float64(open+1)
+	reason := fmt.Sprintf("%d of %d open on %s", topCount, open, top)
+	if topCount == open {
+		reason = fmt.Sprintf("all %d open on %s", open, top)
+		if open == 1 {
+			reason = "only " + top
+		}
+	}
+	return RiskFactor{Name: RiskOwnership, Value: value, Reason: reason}
+}
+
+// dueFactor is how close the earliest due date is, among the issue and the
+// open work under it
+func dueFactor(issue *model.Issue, members []*model.Issue, now time.Time) RiskFactor {
+	due := issue.DueDate
+	for _, m := range members {
+		if m.DueDate != nil && !m.Status.IsClosed() && (due == nil || m.DueDate.Before(*due)) {
+			due = m.DueDate
+		}
+	}
+	if due == nil {
+		return RiskFactor{Name: RiskDue, Value: 0, Reason: "no due date"}
+	}
+
+	days := int(due.Sub(now).Hours() / 24)
+	var reason string
+	switch {
+	case due.Before(now) && days == 0:
+		reason = "overdue"
+	case days == -1:
+		reason = "overdue by a day"
+	case days < 0:
+		reason = fmt.Sprintf("overdue by %d days", -days)
+	case days == 0:
+		reason = "due today"
+	case days == 1:
+		reason = "due tomorrow"
+	default:
+		reason = fmt.Sprintf("due in %d days", days)
+	}
+	return RiskFactor{Name: RiskDue, Value: computeDueProximity(due, now), Reason: reason}
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeRiskScores(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(2 * 24 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+	child := func(parent string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: parent, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		// Urgent, not started, due in two days, and sam also holds its blocker
+		{ID: "A", Title: "Launch", Status: model.StatusOpen, Priority: 0, Assignee: "sam", DueDate: &soon,
+			Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusInProgress, Priority: 2, Assignee: "sam"},
+		// Low priority, in progress, due in a month
+		{ID: "C", Status: model.StatusInProgress, Priority: 4, Assignee: "kim", DueDate: &later},
+		// An epic with one of its two children done, both children on kim
+		{ID: "E", Title: "Billing", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 1},
+		{ID: "E1", Status: model.StatusClosed, Assignee: "kim", Dependencies: child("E")},
+		{ID: "E2", Status: model.StatusOpen, Assignee: "kim", DueDate: &soon, Dependencies: child("E")},
+		{ID: "X", Status: model.StatusClosed},
+	}
+
+	scores := ComputeRiskScores(issues, now)
+	byID := make(map[string]RiskScore)
+	for _, s := range scores {
+		byID[s.ID] = s
+	}
+	if _, ok := byID["X"]; ok {
+		t.Error("closed issues should not be scored")
+	}
+	if len(scores) != 5 {
+		t.Fatalf("expected A, B, C, E and E2 scored, got %+v", scores)
+	}
+	if scores[0].ID != "A" {
+		t.Errorf("expected A riskiest, got %s (%v)", scores[0].ID, scores[0].Score)
+	}
+	if c := byID["C"]; c.Score >= byID["A"].Score || c.Score >= byID["B"].Score {
+		t.Errorf("expected C, low priority and far off, below A and B: %+v", scores)
+	}
+	for i := 1; i < len(scores); i++ {
+		if scores[i].Score > scores[i-1].Score {
+			t.Errorf("scores out of order: %+v", scores)
+		}
+	}
+
+	a := byID["A"]
+	if a.Score <= 0 || a.Score > 100 {
+		t.Errorf("expected a score in (0, 100], got %v", a.Score)
+	}
+	factor := func(r RiskScore, name string) RiskFactor {
+		for _, f := range r.Factors {
+			if f.Name == name {
+				return f
+			}
+		}
+		t.Fatalf("%s has no %s factor", r.ID, name)
+		return RiskFactor{}
+	}
+	if f := factor(a, RiskOwnership); f.Reason != "all 2 open on sam" {
+		t.Errorf("expected A's chain all on sam, got %+v", f)
+	}
+	if f := factor(a, RiskDue); f.Reason != "due in 2 days" {
+		t.Errorf("expected A due in 2 days, got %+v", f)
+	}
+	if f := factor(byID["B"], RiskImpact); f.Reason != "P2, blocks 1 open" {
+		t.Errorf("expected B to block A, got %+v", f)
+	}
+	if explain := a.Explain(); !strings.Contains(explain, "due in 2 days") || !strings.Contains(explain, "not started") {
+		t.Errorf("expected A's explanation to name the due date and progress, got %q", explain)
+	}
+	for i := 1; i < len(a.Factors); i++ {
+		if a.Factors[i].Value > a.Factors[i-1].Value {
+			t.Errorf("factors should be strongest first: %+v", a.Factors)
+		}
+	}
+
+	// The epic is judged by its subtree
+	e := byID["E"]
+	if !e.Epic {
+		t.Error("expected E marked as an epic")
+	}
+	if f := factor(e, RiskProgress); f.Reason != "1 of 2 done" || f.Value != 0.5 {
+		t.Errorf("expected E half done, got %+v", f)
+	}
+	if f := factor(e, RiskDue); f.Reason != "due in 2 days" {
+		t.Errorf("expected E due with its child E2, got %+v", f)
+	}
+	if f := factor(e, RiskOwnership); f.Reason != "only kim" {
+		t.Errorf("expected E's open work on kim, got %+v", f)
+	}
+}
+
+func TestRiskScoreExplainWithoutDominantFactor(t *testing.T) {
+	quiet := RiskScore{Factors: []RiskFactor{
+		{Name: RiskImpact, Value: 0.3, Reason: "P3"},
+		{Name: RiskDue, Value: 0, Reason: "no due date"},
+	}}
+	if got := quiet.Explain(); got != "P3" {
+		t.Errorf("expected the strongest factor alone, got %q", got)
+	}
+	if got := (RiskScore{}).Explain(); got != "no factor stands out" {
+		t.Errorf("got %q", got)
+	}
+}
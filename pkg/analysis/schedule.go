@@ -0,0 +1,286 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultDayMinutes is how many minutes of estimated work one person gets
+// through in a working day
+const DefaultDayMinutes = 6 * 60
+
+// DefaultUnestimatedMinutes is what the scheduler assumes for an issue with
+// no estimate: one working day
+const DefaultUnestimatedMinutes = DefaultDayMinutes
+
+// ScheduleOptions tunes BuildSchedule
+type ScheduleOptions struct {
+	Now        time.Time
+	DayMinutes int       // Capacity per person per working day; 0 means DefaultDayMinutes
+	Limits     WIPLimits // The in_progress column limit caps how many people work at once
+}
+
+// ScheduledIssue is an open issue placed on the schedule
+type ScheduledIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+	Assignee string `json:"assignee"`
+
+	// Suggested is set when the issue had no assignee and the scheduler
+	// picked whoever could start it first
+	Suggested bool `json:"suggested,omitempty"`
+
+	Minutes   int  `json:"minutes"`   // The estimate the schedule used
+	Estimated bool `json:"estimated"` // False when Minutes is DefaultUnestimatedMinutes
+
+	// Start and Finish are the first and last working day of the issue;
+	// StartDay and FinishDay count them in working days from today (0)
+	Start     time.Time `json:"start"`
+	Finish    time.Time `json:"finish"`
+	StartDay  int       `json:"start_day"`
+	FinishDay int       `json:"finish_day"`
+
+	start, finish int // Working minutes from Now
+}
+
+// Schedule is a projected plan for the open work
+type Schedule struct {
+	Items  []ScheduledIssue `json:"items"`  // By start, then assignee and ID
+	Finish time.Time        `json:"finish"` // When the last item finishes; zero with no items
+
+	// Unscheduled lists open issues that wait, directly or through a chain,
+	// on a dependency cycle, so can never start
+	Unscheduled []string `json:"unscheduled,omitempty"`
+}
+
+// Item returns the scheduled issue with id, or nil
+func (s *Schedule) Item(id string) *ScheduledIssue {
+	for i := range s.Items {
+		if s.Items[i].ID == id {
+			return &s.Items[i]
+		}
+	}
+	return nil
+}
+
+// BuildSchedule places every open issue except epics on a schedule: each
+// person works through their issues one at a time at DayMinutes a working
+// day (weekends off), in-progress work first and then by priority, never
+// starting an issue before its open blockers finish. Less urgent work fills
+// the gaps left while more urgent work waits on its blockers. Unassigned issues go to
+// whoever can start them first. With an in_progress WIP limit set, no more
+// than that many issues are worked at once.
+func BuildSchedule(issues []model.Issue, opts ScheduleOptions) Schedule {
+	if opts.DayMinutes <= 0 {
+		opts.DayMinutes = DefaultDayMinutes
+	}
+	blockers := OpenBlockers(issues)
+
+	var pending []*model.Issue
+	people := make(map[string][]span) // Assignee -> the work booked for them, by start
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.IssueType == model.TypeEpic {
+			continue
+		}
+		pending = append(pending, issue)
+		people[issue.Assignee] = nil
+	}
+	delete(people, "")
+	if len(people) == 0 {
+		people[""] = nil // Nobody named: one anonymous lane
+	}
+	names := make([]string, 0, len(people))
+	for name := range people {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		a, b := pending[i], pending[j]
+		if (a.Status == model.StatusInProgress) != (b.Status == model.StatusInProgress) {
+			return a.Status == model.StatusInProgress
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+
+	wip := opts.Limits.StatusLimit(model.StatusInProgress)
+	finish := make(map[string]int)
+	var placed []ScheduledIssue
+	for len(pending) > 0 {
+		// The most urgent issue whose blockers are all on the schedule
+		pick := -1
+		for i, issue := range pending {
+			ready := true
+			for _, b := range blockers[issue.ID] {
+				if _, ok := finish[b]; !ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				pick = i
+				break
+			}
+		}
+		if pick < 0 {
+			break // Everything left waits on a cycle
+		}
+		issue := pending[pick]
+		pending = append(pending[:pick], pending[pick+1:]...)
+
+		item := ScheduledIssue{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Assignee: issue.Assignee,
+		}
+		item.Minutes, item.Estimated = plannedMinutes(issue)
+		earliest := 0
+		for _, b := range blockers[issue.ID] {
+			earliest = max(earliest, finish[b])
+		}
+
+		slot := func(name string) int {
+			start := earliest
+			for {
+				start = freeFrom(people[name], start, item.Minutes)
+				if wip <= 0 {
+					return start
+				}
+				next := wipStart(placed, start, item.Minutes, wip)
+				if next == start {
+					return start
+				}
+				start = next
+			}
+		}
+		if item.Assignee == "" {
+			if _, ok := people[""]; !ok {
+				item.Suggested = true
+			}
+			best := -1
+			for _, name := range names {
+				if start := slot(name); best < 0 || start < best {
+					best, item.Assignee = start, name
+				}
+			}
+		}
+		item.start = slot(item.Assignee)
+		item.finish = item.start + item.Minutes
+		people[item.Assignee] = book(people[item.Assignee], span{item.start, item.finish})
+		finish[issue.ID] = item.finish
+		placed = append(placed, item)
+	}
+
+	s := Schedule{Items: placed}
+	for _, issue := range pending {
+		s.Unscheduled = append(s.Unscheduled, issue.ID)
+	}
+	sort.Strings(s.Unscheduled)
+
+	today := time.Date(opts.Now.Year(), opts.Now.Month(), opts.Now.Day(), 0, 0, 0, 0, opts.Now.Location())
+	for i := range s.Items {
+		item := &s.Items[i]
+		item.StartDay = item.start / opts.DayMinutes
+		// The last minute of work falls on the finish day
+		item.FinishDay = max(item.finish-1, item.start) / opts.DayMinutes
+		item.Start = addWorkdays(today, item.StartDay)
+		item.Finish = addWorkdays(today, item.FinishDay)
+		if item.Finish.After(s.Finish) {
+			s.Finish = item.Finish
+		}
+	}
+	sort.SliceStable(s.Items, func(i, j int) bool {
+		a, b := s.Items[i], s.Items[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if a.Assignee != b.Assignee {
+			return a.Assignee < b.Assignee
+		}
+		return a.ID < b.ID
+	})
+	return s
+}
+
+// plannedMinutes returns the work an issue is planned at: its estimate, or
+// DefaultUnestimatedMinutes and false without one
+func plannedMinutes(issue *model.Issue) (int, bool) {
+	if est, _ := issue.EstimateMinutes(); est > 0 {
+		return est, true
+	}
+	return DefaultUnestimatedMinutes, false
+}
+
+// span is a stretch of working minutes, from start up to finish
+type span struct{ start, finish int }
+
+// freeFrom returns the first minute from start at which booked, sorted by
+// start, leaves minutes free, so later work can fill gaps left by earlier
+// work waiting on blockers
+func freeFrom(booked []span, start, minutes int) int {
+	for _, b := range booked {
+		if start+minutes <= b.start {
+			break
+		}
+		start = max(start, b.finish)
+	}
+	return start
+}
+
+// book adds s to booked, keeping it sorted by start
+func book(booked []span, s span) []span {
+	i := sort.Search(len(booked), func(i int) bool { return booked[i].start > s.start })
+	booked = append(booked, span{})
+	copy(booked[i+1:], booked[i:])
+	booked[i] = s
+	return booked
+}
+
+// wipStart returns the first working minute from start at which an issue
+// taking minutes overlaps fewer than limit placed issues. Counting every
+// overlap, rather than the peak at any moment, errs on the side of waiting.
+func wipStart(placed []ScheduledIssue, start, minutes, limit int) int {
+	for {
+		overlapping := 0
+		next := -1
+		for _, p := range placed {
+			if p.start < start+minutes && p.finish > start {
+				overlapping++
+				if next < 0 || p.finish < next {
+					next = p.finish
+				}
+			}
+		}
+		if overlapping < limit || next < 0 {
+			return start
+		}
+		start = next
+	}
+}
+
+// addWorkdays returns the working day n working days after day, skipping
+// weekends; n = 0 is day itself, or the Monday after when it falls on a
+// weekend
+func addWorkdays(day time.Time, n int) time.Time {
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, 1)
+	}
+	for n > 0 {
+		day = day.AddDate(0, 0, 1)
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return day
+}
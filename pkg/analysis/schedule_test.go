@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildSchedule(t *testing.T) {
+	// A Monday
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	est := func(minutes int) *int { return &minutes }
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 1, Assignee: "sam", EstimatedMinutes: est(2 * DefaultDayMinutes)},
+		{ID: "B", Status: model.StatusOpen, Priority: 0, Assignee: "kim", Dependencies: blocks("A")},
+		{ID: "C", Status: model.StatusInProgress, Priority: 3, Assignee: "sam", EstimatedMinutes: est(DefaultDayMinutes / 2)},
+		{ID: "D", Status: model.StatusOpen, Priority: 2},
+		{ID: "E", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "X", Status: model.StatusClosed, Assignee: "sam"},
+		// A cycle, and work waiting on it
+		{ID: "Y1", Status: model.StatusOpen, Dependencies: blocks("Y2")},
+		{ID: "Y2", Status: model.StatusOpen, Dependencies: blocks("Y1")},
+		{ID: "Y3", Status: model.StatusOpen, Dependencies: blocks("Y1")},
+	}
+
+	s := BuildSchedule(issues, ScheduleOptions{Now: now})
+	if !reflect.DeepEqual(s.Unscheduled, []string{"Y1", "Y2", "Y3"}) {
+		t.Errorf("expected the cycle left unscheduled, got %v", s.Unscheduled)
+	}
+	if s.Item("E") != nil || s.Item("X") != nil {
+		t.Error("epics and closed issues should not be scheduled")
+	}
+
+	// sam finishes the in-progress C first, then A over the next two days
+	c, a := s.Item("C"), s.Item("A")
+	if c.StartDay != 0 || c.FinishDay != 0 || !c.Estimated {
+		t.Errorf("expected C on day 0, got %+v", c)
+	}
+	if a.StartDay != 0 || a.FinishDay != 2 {
+		t.Errorf("expected A from day 0 to day 2, got %+v", a)
+	}
+	// kim can't start B until A is done, however urgent it is
+	b := s.Item("B")
+	if b.StartDay != 2 || b.Estimated || b.Minutes != DefaultUnestimatedMinutes {
+		t.Errorf("expected B to wait for A, got %+v", b)
+	}
+	// The unassigned D goes to whoever is free first: kim, idle until B
+	d := s.Item("D")
+	if d.Assignee != "kim" || !d.Suggested || d.StartDay != 0 {
+		t.Errorf("expected D suggested for kim on day 0, got %+v", d)
+	}
+	if b.StartDay < d.FinishDay {
+		t.Errorf("kim works one issue at a time: D %+v, B %+v", d, b)
+	}
+	if !s.Finish.Equal(b.Finish) || !b.Finish.Equal(time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected everything done Thursday with B, got %v", s.Finish)
+	}
+	for i := 1; i < len(s.Items); i++ {
+		if s.Items[i].StartDay < s.Items[i-1].StartDay {
+			t.Errorf("items should be ordered by start: %+v", s.Items)
+		}
+	}
+}
+
+func TestBuildScheduleRespectsWIPAndWeekends(t *testing.T) {
+	// A Friday
+	now := time.Date(2025, 6, 6, 9, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Assignee: "sam"},
+		{ID: "B", Status: model.StatusOpen, Assignee: "kim"},
+	}
+	limits := WIPLimits{Status: map[model.Status]int{model.StatusInProgress: 1}}
+	s := BuildSchedule(issues, ScheduleOptions{Now: now, Limits: limits})
+
+	a, b := s.Item("A"), s.Item("B")
+	if a.StartDay != 0 || b.StartDay != 1 {
+		t.Fatalf("expected one issue at a time under the WIP limit, got %+v and %+v", a, b)
+	}
+	if b.Start.Weekday() != time.Monday {
+		t.Errorf("expected B to start after the weekend, got %v", b.Start)
+	}
+}
@@ -0,0 +1,120 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Slack is how far an open issue can slip before it delays anything, from a
+// critical path pass over the open work: every issue starts as soon as its
+// blockers finish and takes its estimate, or DefaultUnestimatedMinutes.
+// Times are working minutes from now.
+type Slack struct {
+	ID          string `json:"id"`
+	EarlyStart  int    `json:"early_start"`
+	EarlyFinish int    `json:"early_finish"`
+
+	// LateFinish is the latest the issue can finish without delaying the
+	// issues waiting on it or the finish of its epic
+	LateFinish int `json:"late_finish"`
+
+	// Minutes is LateFinish - EarlyFinish: zero on the critical path
+	Minutes int `json:"minutes"`
+
+	// Epic is the nearest epic above the issue, whose finish bounds it;
+	// "" when it has none and the finish of all open work bounds it instead
+	Epic string `json:"epic,omitempty"`
+}
+
+// Critical reports whether any slip delays something
+func (s Slack) Critical() bool {
+	return s.Minutes == 0
+}
+
+// ComputeSlack returns the slack of every open issue except epics, by ID.
+// An epic finishes when the last open issue under it does; issues outside
+// any epic are measured against the finish of all open work. Issues in or
+// waiting on a dependency cycle have no entry.
+func ComputeSlack(issues []model.Issue) map[string]Slack {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	inPlan := func(id string) bool {
+		issue, ok := issueMap[id]
+		return ok && !issue.Status.IsClosed() && issue.IssueType != model.TypeEpic
+	}
+
+	blockers := make(map[string][]string)
+	waiters := make(map[string][]string)
+	waiting := make(map[string]int)
+	var ids []string
+	for id, open := range OpenBlockers(issues) {
+		if !inPlan(id) {
+			continue
+		}
+		ids = append(ids, id)
+		for _, b := range open {
+			if inPlan(b) {
+				blockers[id] = append(blockers[id], b)
+				waiters[b] = append(waiters[b], id)
+				waiting[id]++
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	// Blockers before the issues waiting on them; a cycle never empties
+	var order, queue []string
+	for _, id := range ids {
+		if waiting[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, w := range waiters[id] {
+			if waiting[w]--; waiting[w] == 0 {
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	// Forward pass: earliest start and finish
+	slack := make(map[string]Slack, len(order))
+	epics := epicMembership(issues, issueMap)
+	epicFinish := make(map[string]int)
+	for _, id := range order {
+		s := Slack{ID: id, Epic: epics[id]}
+		for _, b := range blockers[id] {
+			s.EarlyStart = max(s.EarlyStart, slack[b].EarlyFinish)
+		}
+		minutes, _ := plannedMinutes(issueMap[id])
+		s.EarlyFinish = s.EarlyStart + minutes
+		epicFinish[s.Epic] = max(epicFinish[s.Epic], s.EarlyFinish)
+		slack[id] = s
+	}
+	// Outside any epic, the bound is the finish of everything
+	for _, finish := range epicFinish {
+		epicFinish[""] = max(epicFinish[""], finish)
+	}
+
+	// Backward pass: latest finish that delays neither waiters nor the epic
+	for i := len(order) - 1; i >= 0; i-- {
+		s := slack[order[i]]
+		s.LateFinish = epicFinish[s.Epic]
+		for _, w := range waiters[s.ID] {
+			waiter, ok := slack[w]
+			if !ok {
+				continue // Stuck behind a cycle
+			}
+			s.LateFinish = min(s.LateFinish, waiter.LateFinish-(waiter.EarlyFinish-waiter.EarlyStart))
+		}
+		s.Minutes = s.LateFinish - s.EarlyFinish
+		slack[s.ID] = s
+	}
+	return slack
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeSlack(t *testing.T) {
+	est := func(minutes int) *int { return &minutes }
+	day := DefaultDayMinutes
+	dep := func(id string, typ model.DependencyType) *model.Dependency {
+		return &model.Dependency{DependsOnID: id, Type: typ}
+	}
+	issues := []model.Issue{
+		{ID: "E", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		// Under E: A then B, with C alongside
+		{ID: "A", Status: model.StatusOpen, EstimatedMinutes: est(2 * day), Dependencies: []*model.Dependency{dep("E", model.DepParentChild)}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("E", model.DepParentChild), dep("A", model.DepBlocks), dep("X", model.DepBlocks)}},
+		{ID: "C", Status: model.StatusInProgress, Dependencies: []*model.Dependency{dep("E", model.DepParentChild)}},
+		{ID: "X", Status: model.StatusClosed},
+		// Outside any epic: D then H, and Z, which only a cycle waits on
+		{ID: "D", Status: model.StatusOpen},
+		{ID: "H", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("D", model.DepBlocks)}},
+		{ID: "Z", Status: model.StatusOpen},
+		{ID: "Y1", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("Y2", model.DepBlocks)}},
+		{ID: "Y2", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("Y1", model.DepBlocks), dep("Z", model.DepBlocks)}},
+	}
+
+	slack := ComputeSlack(issues)
+	for _, id := range []string{"E", "X", "Y1", "Y2"} {
+		if _, ok := slack[id]; ok {
+			t.Errorf("expected no slack for %s", id)
+		}
+	}
+	tests := []struct {
+		id      string
+		minutes int
+		epic    string
+	}{
+		{"A", 0, "E"},
+		{"B", 0, "E"},
+		{"C", 2 * day, "E"},
+		// Bounded by the finish of all open work, day 3
+		{"D", day, ""},
+		{"H", day, ""},
+		{"Z", 2 * day, ""},
+	}
+	for _, tt := range tests {
+		s, ok := slack[tt.id]
+		if !ok {
+			t.Errorf("%s: missing", tt.id)
+			continue
+		}
+		if s.Minutes != tt.minutes || s.Epic != tt.epic {
+			t.Errorf("%s: expected %d minutes of slack against %q, got %+v", tt.id, tt.minutes, tt.epic, s)
+		}
+		if s.Critical() != (tt.minutes == 0) {
+			t.Errorf("%s: Critical() = %v", tt.id, s.Critical())
+		}
+	}
+	if b := slack["B"]; b.EarlyStart != 2*day || b.LateFinish != 3*day {
+		t.Errorf("expected B from day 2 to day 3, got %+v", b)
+	}
+
+	// An hour of work waiting on C outside the epic, due by the finish of
+	// everything on day 3, leaves C an hour less slack
+	issues = append(issues, model.Issue{ID: "F", Status: model.StatusOpen, EstimatedMinutes: est(60),
+		Dependencies: []*model.Dependency{dep("C", model.DepBlocks)}})
+	if c := ComputeSlack(issues)["C"]; c.Minutes != 2*day-60 {
+		t.Errorf("expected C to lose an hour of slack, got %+v", c)
+	}
+}
@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SprintStats tracks committed versus completed work in one sprint.
+// Points are estimates in minutes (model.Issue.EstimateMinutes); issue counts
+// are tracked alongside so sprints without estimates still show progress.
+type SprintStats struct {
+	Name            string `json:"name"`
+	Committed       int    `json:"committed"` // Issues in the sprint
+	Done            int    `json:"done"`      // Closed issues in the sprint
+	InProgress      int    `json:"in_progress"`
+	Blocked         int    `json:"blocked"`
+	CommittedPoints int    `json:"committed_points"`
+	DonePoints      int    `json:"done_points"`
+	Unestimated     int    `json:"unestimated"` // Issues without an estimate
+}
+
+// HasPoints reports whether any issue in the sprint is estimated
+func (s SprintStats) HasPoints() bool {
+	return s.CommittedPoints > 0
+}
+
+// Progress returns completion in [0,1], by points when estimated, otherwise by issue count
+func (s SprintStats) Progress() float64 {
+	if s.HasPoints() {
+		return float64(s.DonePoints) / float64(s.CommittedPoints)
+	}
+	if s.Committed == 0 {
+		return 0
+	}
+	return float64(s.Done) / float64(s.Committed)
+}
+
+// ComputeSprintStats groups issues by sprint (see model.Issue.SprintName) and
+// returns one entry per sprint, latest sprint first.
+func ComputeSprintStats(issues []model.Issue) []SprintStats {
+	bySprint := make(map[string]*SprintStats)
+	for i := range issues {
+		issue := &issues[i]
+		name := issue.SprintName()
+		if name == "" {
+			continue
+		}
+		s, ok := bySprint[name]
+		if !ok {
+			s = &SprintStats{Name: name}
+			bySprint[name] = s
+		}
+
+		s.Committed++
+		closed := issue.Status.IsClosed()
+		switch {
+		case closed:
+			s.Done++
+		case issue.Status == model.StatusInProgress:
+			s.InProgress++
+		case issue.Status == model.StatusBlocked:
+			s.Blocked++
+		}
+		if est, ok := issue.EstimateMinutes(); ok {
+			s.CommittedPoints += est
+			if closed {
+				s.DonePoints += est
+			}
+		} else {
+			s.Unestimated++
+		}
+	}
+
+	result := make([]SprintStats, 0, len(bySprint))
+	for _, s := range bySprint {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return naturalLess(result[j].Name, result[i].Name)
+	})
+	return result
+}
+
+// naturalLess compares names with embedded numbers numerically, so "9" < "10"
+// and "2025-W9" < "2025-W10".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		ad, bd := isDigit(a[0]), isDigit(b[0])
+		if ad && bd {
+			an, arest := splitDigits(a)
+			bn, brest := splitDigits(b)
+			ai, _ := strconv.Atoi(an)
+			bi, _ := strconv.Atoi(bn)
+			if ai != bi {
+				return ai < bi
+			}
+			a, b = arest, brest
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// splitDigits splits s into its leading run of digits and the remainder
+func splitDigits(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i], s[i:]
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeSprintStats(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, Sprint: "9", EstimatedMinutes: minutes(60)},
+		{ID: "B", Status: model.StatusInProgress, Labels: []string{"sprint:10"}, EstimatedMinutes: minutes(120)},
+		{ID: "C", Status: model.StatusClosed, Labels: []string{"sprint:10"}, EstimatedMinutes: minutes(60)},
+		{ID: "D", Status: model.StatusOpen, Labels: []string{"sprint:10"}},
+		{ID: "E", Status: model.StatusOpen},
+	}
+
+	stats := ComputeSprintStats(issues)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 sprints, got %d", len(stats))
+	}
+	if stats[0].Name != "10" || stats[1].Name != "9" {
+		t.Fatalf("expected latest sprint first, got %s, %s", stats[0].Name, stats[1].Name)
+	}
+
+	s := stats[0]
+	if s.Committed != 3 || s.Done != 1 || s.InProgress != 1 || s.Unestimated != 1 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	if s.CommittedPoints != 180 || s.DonePoints != 60 {
+		t.Errorf("unexpected points: %+v", s)
+	}
+	if p := s.Progress(); p < 0.33 || p > 0.34 {
+		t.Errorf("expected progress by points ~0.33, got %v", p)
+	}
+}
+
+func TestSprintStats_ProgressWithoutPoints(t *testing.T) {
+	s := SprintStats{Committed: 4, Done: 1}
+	if s.Progress() != 0.25 {
+		t.Fatalf("expected count-based progress 0.25, got %v", s.Progress())
+	}
+	if (SprintStats{}).Progress() != 0 {
+		t.Fatalf("expected empty sprint progress 0")
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"9", "10", true},
+		{"2025-W9", "2025-W10", true},
+		{"Sprint 10", "Sprint 2", false},
+		{"alpha", "beta", true},
+		{"s1", "s1a", true},
+	}
+	for _, tt := range tests {
+		if got := naturalLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("naturalLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package analysis
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+// Subgraph returns id and the issues within hops links of it along blocking
+// and parent-child dependencies: its blockers, their blockers and so on
+// upstream, and its dependents likewise downstream. Siblings reached only by
+// going up and back down are not included. hops <= 0 means no limit.
+func Subgraph(issues []model.Issue, id string, hops int) map[string]bool {
+	up := make(map[string][]string)
+	down := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !(isBlockingDep(dep.Type) || dep.Type == model.DepParentChild) {
+				continue
+			}
+			up[issue.ID] = append(up[issue.ID], dep.DependsOnID)
+			down[dep.DependsOnID] = append(down[dep.DependsOnID], issue.ID)
+		}
+	}
+
+	set := map[string]bool{id: true}
+	for _, edges := range []map[string][]string{up, down} {
+		seen := map[string]bool{id: true}
+		frontier := []string{id}
+		for depth := 0; len(frontier) > 0 && (hops <= 0 || depth < hops); depth++ {
+			var next []string
+			for _, n := range frontier {
+				for _, m := range edges[n] {
+					if !seen[m] {
+						seen[m] = true
+						set[m] = true
+						next = append(next, m)
+					}
+				}
+			}
+			frontier = next
+		}
+	}
+	return set
+}
+
+// Subtree returns id and every issue below it through parent-child
+// dependencies: an epic's children, their children and so on
+func Subtree(issues []model.Issue, id string) map[string]bool {
+	children := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	set := make(map[string]bool)
+	walkUnique(id, children, func(n string) { set[n] = true })
+	return set
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSubgraph(t *testing.T) {
+	dep := func(on string, typ model.DependencyType) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: typ}}
+	}
+	// A <- B <- C <- D, S is B's sibling under A, R is only related to C
+	issues := []model.Issue{
+		{ID: "A"},
+		{ID: "B", Dependencies: dep("A", model.DepBlocks)},
+		{ID: "C", Dependencies: dep("B", model.DepParentChild)},
+		{ID: "D", Dependencies: dep("C", "")},
+		{ID: "S", Dependencies: dep("A", model.DepBlocks)},
+		{ID: "R", Dependencies: dep("C", model.DepRelated)},
+	}
+
+	check := func(hops int, want ...string) {
+		t.Helper()
+		got := Subgraph(issues, "B", hops)
+		if len(got) != len(want) {
+			t.Fatalf("hops %d: expected %v, got %v", hops, want, got)
+		}
+		for _, id := range want {
+			if !got[id] {
+				t.Fatalf("hops %d: expected %s in %v", hops, id, got)
+			}
+		}
+	}
+	check(1, "A", "B", "C")
+	check(2, "A", "B", "C", "D")
+	check(0, "A", "B", "C", "D")
+}
+
+func TestSubtree(t *testing.T) {
+	child := func(of string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: of, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		{ID: "EP"},
+		{ID: "C1", Dependencies: child("EP")},
+		{ID: "C2", Dependencies: child("C1")},
+		{ID: "B", Dependencies: []*model.Dependency{{DependsOnID: "C1", Type: model.DepBlocks}}},
+	}
+	got := Subtree(issues, "EP")
+	if len(got) != 3 || !got["EP"] || !got["C1"] || !got["C2"] {
+		t.Fatalf("expected EP, C1 and C2 but not the blocked B, got %v", got)
+	}
+}
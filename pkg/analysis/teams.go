@@ -0,0 +1,203 @@
+package analysis
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Teams groups assignees: team name -> member assignee names
+type Teams map[string][]string
+
+// Names returns the team names, sorted
+func (t Teams) Names() []string {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TeamOf returns assignee's team, "" for none. Someone in several teams
+// counts toward the first by name.
+func (t Teams) TeamOf(assignee string) string {
+	if assignee == "" {
+		return ""
+	}
+	for _, name := range t.Names() {
+		for _, member := range t[name] {
+			if member == assignee {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// teams holds the teams set with SetTeams, nil for none
+var teams atomic.Pointer[Teams]
+
+// SetTeams changes the teams the workload view and team filters use
+func SetTeams(t Teams) {
+	teams.Store(&t)
+}
+
+// CurrentTeams returns the teams in use, empty when none are defined
+func CurrentTeams() Teams {
+	if t := teams.Load(); t != nil {
+		return *t
+	}
+	return Teams{}
+}
+
+// CrossTeamBlocker is an open issue of one team waiting on open work owned
+// by another
+type CrossTeamBlocker struct {
+	IssueID     string `json:"issue_id"`
+	Team        string `json:"team"`
+	BlockerID   string `json:"blocker_id"`
+	BlockerTeam string `json:"blocker_team"`
+}
+
+// ComputeCrossTeamBlockers finds every open issue blocked by an open issue
+// assigned to a different team, ordered by team, blocking team and IDs.
+// Issues outside any team are never counted on either side.
+func ComputeCrossTeamBlockers(issues []model.Issue, t Teams) []CrossTeamBlocker {
+	if len(t) == 0 {
+		return nil
+	}
+	teamOf := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		teamOf[issue.ID] = t.TeamOf(issue.Assignee)
+	}
+	var result []CrossTeamBlocker
+	for id, blockers := range OpenBlockers(issues) {
+		team := teamOf[id]
+		if team == "" {
+			continue
+		}
+		for _, b := range blockers {
+			if other := teamOf[b]; other != "" && other != team {
+				result = append(result, CrossTeamBlocker{IssueID: id, Team: team, BlockerID: b, BlockerTeam: other})
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Team != b.Team {
+			return a.Team < b.Team
+		}
+		if a.BlockerTeam != b.BlockerTeam {
+			return a.BlockerTeam < b.BlockerTeam
+		}
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.BlockerID < b.BlockerID
+	})
+	return result
+}
+
+// TeamWorkload rolls up the workload of a team's members
+type TeamWorkload struct {
+	Team       string   `json:"team"`    // "" for assignees outside any team and unassigned work
+	Members    []string `json:"members"` // As configured, sorted
+	Open       int      `json:"open"`
+	InProgress int      `json:"in_progress"`
+	Blocked    int      `json:"blocked"`
+	Closed     int      `json:"closed"`
+	Overloaded int      `json:"overloaded"` // Members flagged overloaded
+	Idle       int      `json:"idle"`       // Members with no open work, including those with no issues
+
+	// WaitingOnOthers counts the team's open issues blocked by another
+	// team's open work; BlockingOthers counts other teams' open issues
+	// waiting on this team's
+	WaitingOnOthers int `json:"waiting_on_others"`
+	BlockingOthers  int `json:"blocking_others"`
+
+	AvgAge time.Duration `json:"avg_age"` // Mean age of the open issues
+}
+
+// ComputeTeamWorkload returns one entry per team, busiest first, then one
+// for work outside any team when some of it is open
+func ComputeTeamWorkload(issues []model.Issue, now time.Time, t Teams) []TeamWorkload {
+	byTeam := make(map[string]*TeamWorkload)
+	for _, name := range t.Names() {
+		members := append([]string(nil), t[name]...)
+		sort.Strings(members)
+		byTeam[name] = &TeamWorkload{Team: name, Members: members}
+	}
+
+	ages := make(map[string]time.Duration)
+	seen := make(map[string]bool)
+	for _, w := range ComputeWorkload(issues, now) {
+		team := t.TeamOf(w.Assignee)
+		tw, ok := byTeam[team]
+		if !ok {
+			tw = &TeamWorkload{}
+			byTeam[team] = tw
+		}
+		tw.Open += w.Open
+		tw.InProgress += w.InProgress
+		tw.Blocked += w.Blocked
+		tw.Closed += w.Closed
+		ages[team] += w.AvgAge * time.Duration(w.Open)
+		switch {
+		case team == "":
+		case w.Level == LoadOverloaded:
+			tw.Overloaded++
+		case w.Level == LoadIdle:
+			tw.Idle++
+		}
+		seen[w.Assignee] = true
+	}
+	// Members without any issues are idle too
+	for _, tw := range byTeam {
+		for _, member := range tw.Members {
+			if !seen[member] && t.TeamOf(member) == tw.Team {
+				tw.Idle++
+			}
+		}
+	}
+
+	waiting := make(map[string]map[string]bool)
+	blocking := make(map[string]map[string]bool)
+	for _, c := range ComputeCrossTeamBlockers(issues, t) {
+		if waiting[c.Team] == nil {
+			waiting[c.Team] = make(map[string]bool)
+		}
+		if blocking[c.BlockerTeam] == nil {
+			blocking[c.BlockerTeam] = make(map[string]bool)
+		}
+		waiting[c.Team][c.IssueID] = true
+		blocking[c.BlockerTeam][c.IssueID] = true
+	}
+
+	result := make([]TeamWorkload, 0, len(byTeam))
+	var outside *TeamWorkload
+	for name, tw := range byTeam {
+		if tw.Open > 0 {
+			tw.AvgAge = ages[name] / time.Duration(tw.Open)
+		}
+		tw.WaitingOnOthers = len(waiting[name])
+		tw.BlockingOthers = len(blocking[name])
+		if name == "" {
+			outside = tw
+			continue
+		}
+		result = append(result, *tw)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Open != result[j].Open {
+			return result[i].Open > result[j].Open
+		}
+		return result[i].Team < result[j].Team
+	})
+	if outside != nil && outside.Open > 0 {
+		result = append(result, *outside)
+	}
+	return result
+}
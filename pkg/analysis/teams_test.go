@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestTeams(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Assignee: "ann", Dependencies: blocks("C")},
+		{ID: "B", Status: model.StatusInProgress, Assignee: "ann", Dependencies: blocks("D")},
+		{ID: "C", Status: model.StatusOpen, Assignee: "bob"},
+		{ID: "D", Status: model.StatusClosed, Assignee: "bob"},
+		{ID: "E", Status: model.StatusOpen, Assignee: "eve", Dependencies: blocks("A")},
+		{ID: "F", Status: model.StatusOpen, Dependencies: blocks("C")},
+	}
+	teams := Teams{"web": {"ann", "zed"}, "infra": {"bob"}}
+	if got := teams.TeamOf("bob"); got != "infra" {
+		t.Errorf("TeamOf(bob) = %q", got)
+	}
+	if got := teams.TeamOf("eve"); got != "" {
+		t.Errorf("eve is in no team, got %q", got)
+	}
+
+	// B's blocker is closed; E and F are outside any team
+	cross := ComputeCrossTeamBlockers(issues, teams)
+	if len(cross) != 1 || cross[0] != (CrossTeamBlocker{IssueID: "A", Team: "web", BlockerID: "C", BlockerTeam: "infra"}) {
+		t.Fatalf("unexpected cross-team blockers %+v", cross)
+	}
+
+	rollup := ComputeTeamWorkload(issues, time.Now(), teams)
+	if len(rollup) != 3 {
+		t.Fatalf("expected web, infra and outside work, got %+v", rollup)
+	}
+	web, infra, outside := rollup[0], rollup[1], rollup[2]
+	if web.Team != "web" || web.Open != 2 || web.InProgress != 1 || web.WaitingOnOthers != 1 || web.Idle != 1 {
+		t.Errorf("unexpected web rollup %+v", web)
+	}
+	if infra.Team != "infra" || infra.Open != 1 || infra.Closed != 1 || infra.BlockingOthers != 1 {
+		t.Errorf("unexpected infra rollup %+v", infra)
+	}
+	if outside.Team != "" || outside.Open != 2 {
+		t.Errorf("unexpected rollup outside teams %+v", outside)
+	}
+
+	if ComputeCrossTeamBlockers(issues, nil) != nil {
+		t.Errorf("expected no cross-team blockers without teams")
+	}
+}
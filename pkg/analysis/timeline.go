@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// StatusSpan is a stretch of time an issue spent in one status
+type StatusSpan struct {
+	Status   model.Status  `json:"status"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"` // now for the current status
+	Duration time.Duration `json:"duration"`
+	Current  bool          `json:"current,omitempty"`
+	By       string        `json:"by,omitempty"` // Who made the change, when known
+}
+
+// TimelineSource says where a status timeline came from
+type TimelineSource string
+
+const (
+	TimelineHistory    TimelineSource = "history"    // The issue's status_history
+	TimelineTimestamps TimelineSource = "timestamps" // created_at, started_at and closed_at
+)
+
+// StatusTimeline returns the statuses an issue has been in, oldest first.
+// It follows the issue's StatusHistory when there is one; otherwise it
+// reconstructs open → in_progress → closed from the issue's timestamps, with
+// any other current status starting at the last update. The closed span of
+// a closed issue ends when it was closed rather than at now.
+func StatusTimeline(issue model.Issue, now time.Time) ([]StatusSpan, TimelineSource) {
+	changes, source := issue.StatusHistory, TimelineHistory
+	if len(changes) == 0 {
+		changes, source = reconstructStatusChanges(issue), TimelineTimestamps
+	} else {
+		changes = append([]model.StatusChange(nil), changes...)
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].At.Before(changes[j].At) })
+		// History may start after creation (e.g. snapshots taken later)
+		if !issue.CreatedAt.IsZero() && changes[0].At.After(issue.CreatedAt) && changes[0].Status != model.StatusOpen {
+			changes = append([]model.StatusChange{{Status: model.StatusOpen, At: issue.CreatedAt}}, changes...)
+		}
+	}
+
+	var spans []StatusSpan
+	for _, c := range changes {
+		if c.At.IsZero() {
+			continue
+		}
+		// Repeated statuses extend the current span
+		if n := len(spans); n > 0 && spans[n-1].Status == c.Status {
+			continue
+		}
+		spans = append(spans, StatusSpan{Status: c.Status, Start: c.At, By: c.By})
+	}
+	for i := range spans {
+		switch {
+		case i+1 < len(spans):
+			spans[i].End = spans[i+1].Start
+		case spans[i].Status.IsClosed():
+			spans[i].End = spans[i].Start
+			spans[i].Current = true
+		default:
+			spans[i].End = now
+			spans[i].Current = true
+		}
+		spans[i].Duration = spans[i].End.Sub(spans[i].Start)
+	}
+	return spans, source
+}
+
+// reconstructStatusChanges infers status changes from an issue's timestamps
+func reconstructStatusChanges(issue model.Issue) []model.StatusChange {
+	var changes []model.StatusChange
+	if !issue.CreatedAt.IsZero() {
+		changes = append(changes, model.StatusChange{Status: model.StatusOpen, At: issue.CreatedAt})
+	}
+	if issue.StartedAt != nil {
+		changes = append(changes, model.StatusChange{Status: model.StatusInProgress, At: *issue.StartedAt, By: issue.Assignee})
+	}
+	switch {
+	case issue.Status.IsClosed():
+		changes = append(changes, model.StatusChange{Status: issue.Status, At: closedTime(&issue), By: issue.Assignee})
+	case issue.Status != model.StatusOpen && issue.Status != model.StatusInProgress:
+		changes = append(changes, model.StatusChange{Status: issue.Status, At: issue.UpdatedAt})
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].At.Before(changes[j].At) })
+	return changes
+}
+
+// startedTime returns when an issue first went in progress: started_at, or
+// the first in_progress entry in its status history
+func startedTime(issue *model.Issue) *time.Time {
+	if issue.StartedAt != nil {
+		return issue.StartedAt
+	}
+	var first *time.Time
+	for i := range issue.StatusHistory {
+		c := &issue.StatusHistory[i]
+		if c.Status == model.StatusInProgress && (first == nil || c.At.Before(*first)) {
+			first = &c.At
+		}
+	}
+	return first
+}
+
+// TimeInStatus totals how long the spans spent in each status
+func TimeInStatus(spans []StatusSpan) map[model.Status]time.Duration {
+	out := make(map[model.Status]time.Duration)
+	for _, s := range spans {
+		out[s.Status] += s.Duration
+	}
+	return out
+}
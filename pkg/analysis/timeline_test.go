@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestStatusTimeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	now := day(20)
+
+	// From timestamps: open → in_progress → closed
+	issue := model.Issue{ID: "A", Status: model.StatusClosed, CreatedAt: day(1), StartedAt: ptr(day(3)), ClosedAt: ptr(day(10))}
+	spans, source := StatusTimeline(issue, now)
+	if source != TimelineTimestamps || len(spans) != 3 {
+		t.Fatalf("expected 3 spans from timestamps, got %v %+v", source, spans)
+	}
+	if spans[0].Duration != 48*time.Hour || spans[1].Duration != 7*24*time.Hour || spans[2].Duration != 0 || !spans[2].Current {
+		t.Fatalf("unexpected spans %+v", spans)
+	}
+
+	// From history, starting after creation and repeating a status
+	issue = model.Issue{ID: "B", Status: model.StatusBlocked, CreatedAt: day(1), StatusHistory: []model.StatusChange{
+		{Status: model.StatusBlocked, At: day(12), By: "ann"},
+		{Status: model.StatusInProgress, At: day(4), By: "bob"},
+		{Status: model.StatusBlocked, At: day(15)},
+	}}
+	spans, source = StatusTimeline(issue, now)
+	if source != TimelineHistory || len(spans) != 3 {
+		t.Fatalf("expected 3 spans from history, got %v %+v", source, spans)
+	}
+	got := TimeInStatus(spans)
+	if got[model.StatusOpen] != 3*24*time.Hour || got[model.StatusInProgress] != 8*24*time.Hour || got[model.StatusBlocked] != 8*24*time.Hour {
+		t.Fatalf("unexpected time in status %v", got)
+	}
+	if spans[2].By != "ann" || !spans[2].Current || !spans[2].End.Equal(now) {
+		t.Fatalf("unexpected current span %+v", spans[2])
+	}
+}
+
+func TestFlowMetricsUseStatusHistory(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	issues := []model.Issue{
+		// No started_at, but history says it went in progress on day 5
+		{ID: "A", Status: model.StatusClosed, CreatedAt: day(1), ClosedAt: ptr(day(9)), StatusHistory: []model.StatusChange{
+			{Status: model.StatusOpen, At: day(1)},
+			{Status: model.StatusInProgress, At: day(5)},
+			{Status: model.StatusClosed, At: day(9)},
+		}},
+		{ID: "B", Status: model.StatusClosed, CreatedAt: day(1), StartedAt: ptr(day(2)), ClosedAt: ptr(day(4))},
+	}
+	fm := ComputeFlowMetrics(issues)
+	if fm.Cycle.Count != 2 || fm.Samples[0].Cycle != 4*24*time.Hour {
+		t.Fatalf("expected cycle time from history, got %+v", fm.Samples)
+	}
+	if len(fm.InStatus) != 2 || fm.InStatus[0].Status != model.StatusOpen || fm.InStatus[1].Status != model.StatusInProgress {
+		t.Fatalf("expected open then in_progress dwell, got %+v", fm.InStatus)
+	}
+	if fm.InStatus[0].Stats.Max != 4*24*time.Hour || fm.InStatus[1].Stats.P50 != 2*24*time.Hour {
+		t.Fatalf("unexpected dwell stats %+v", fm.InStatus)
+	}
+}
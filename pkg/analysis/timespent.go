@@ -0,0 +1,88 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+)
+
+// TimeTotal is the time logged on an issue, by a person or under an epic
+type TimeTotal struct {
+	Key     string // Issue ID, assignee or epic ID; "" for none
+	Title   string // The issue's or epic's title
+	Spent   time.Duration
+	Running bool // A timer still counts towards it
+}
+
+// TimeSpent is the worklog totalled three ways, largest first
+type TimeSpent struct {
+	Total      time.Duration
+	ByIssue    []TimeTotal
+	ByAssignee []TimeTotal // By who logged the time, or the issue's assignee
+	ByEpic     []TimeTotal // Under each issue's nearest epic; "" for none
+}
+
+// ComputeTimeSpent totals the worklog entries for issues, counting running
+// timers up to now. Entries for issues not among them are left out.
+func ComputeTimeSpent(issues []model.Issue, entries []worklog.Entry, now time.Time) TimeSpent {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	epics := epicMembership(issues, issueMap)
+
+	byIssue := make(map[string]*TimeTotal)
+	byAssignee := make(map[string]*TimeTotal)
+	byEpic := make(map[string]*TimeTotal)
+	add := func(totals map[string]*TimeTotal, key, title string, e worklog.Entry) {
+		t, ok := totals[key]
+		if !ok {
+			t = &TimeTotal{Key: key, Title: title}
+			totals[key] = t
+		}
+		t.Spent += e.Duration(now)
+		t.Running = t.Running || e.Running()
+	}
+
+	var ts TimeSpent
+	for _, e := range entries {
+		issue, ok := issueMap[e.IssueID]
+		if !ok {
+			continue
+		}
+		ts.Total += e.Duration(now)
+		add(byIssue, issue.ID, issue.Title, e)
+		who := e.Author
+		if who == "" {
+			who = issue.Assignee
+		}
+		add(byAssignee, who, "", e)
+		epic := epics[issue.ID]
+		title := ""
+		if epic != "" {
+			title = issueMap[epic].Title
+		}
+		add(byEpic, epic, title, e)
+	}
+	ts.ByIssue = sortedTotals(byIssue)
+	ts.ByAssignee = sortedTotals(byAssignee)
+	ts.ByEpic = sortedTotals(byEpic)
+	return ts
+}
+
+// sortedTotals lists totals by time spent, largest first
+func sortedTotals(totals map[string]*TimeTotal) []TimeTotal {
+	out := make([]TimeTotal, 0, len(totals))
+	for _, t := range totals {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Spent != out[j].Spent {
+			return out[i].Spent > out[j].Spent
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+)
+
+func TestComputeTimeSpent(t *testing.T) {
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	child := func(parent string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: parent, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		{ID: "E", Title: "Checkout", IssueType: model.TypeEpic},
+		{ID: "A", Title: "Cart", Assignee: "carol", Dependencies: child("E")},
+		{ID: "B", Title: "Payment", Dependencies: child("A")},
+		{ID: "C", Title: "Docs", Assignee: "dave"},
+	}
+	entry := func(id, author string, from, to time.Duration) worklog.Entry {
+		e := worklog.Entry{IssueID: id, Author: author, Start: start.Add(from)}
+		if to > 0 {
+			end := start.Add(to)
+			e.End = &end
+		}
+		return e
+	}
+	entries := []worklog.Entry{
+		entry("A", "alice", 0, time.Hour),
+		entry("B", "alice", time.Hour, 3*time.Hour),
+		entry("A", "", 0, 30*time.Minute), // carol's, by assignment
+		entry("C", "bob", 3*time.Hour, 0), // Running until now
+		entry("gone", "alice", 0, time.Hour),
+	}
+
+	ts := ComputeTimeSpent(issues, entries, start.Add(4*time.Hour))
+	if ts.Total != 4*time.Hour+30*time.Minute {
+		t.Errorf("expected 4h 30m in all, got %v", ts.Total)
+	}
+	want := []TimeTotal{
+		{Key: "B", Title: "Payment", Spent: 2 * time.Hour},
+		{Key: "A", Title: "Cart", Spent: 90 * time.Minute},
+		{Key: "C", Title: "Docs", Spent: time.Hour, Running: true},
+	}
+	if len(ts.ByIssue) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), ts.ByIssue)
+	}
+	for i := range want {
+		if ts.ByIssue[i] != want[i] {
+			t.Errorf("issue %d: expected %+v, got %+v", i, want[i], ts.ByIssue[i])
+		}
+	}
+	if len(ts.ByAssignee) != 3 || ts.ByAssignee[0].Key != "alice" || ts.ByAssignee[0].Spent != 3*time.Hour ||
+		ts.ByAssignee[2].Key != "carol" {
+		t.Errorf("unexpected totals by assignee: %+v", ts.ByAssignee)
+	}
+	// B is under E through A
+	if len(ts.ByEpic) != 2 || ts.ByEpic[0].Key != "E" || ts.ByEpic[0].Title != "Checkout" ||
+		ts.ByEpic[0].Spent != 3*time.Hour+30*time.Minute || ts.ByEpic[1].Key != "" {
+		t.Errorf("unexpected totals by epic: %+v", ts.ByEpic)
+	}
+}
@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// healthStaleDays is how long open work can sit without an update before
+// it counts against the health score
+const healthStaleDays = 14
+
+// TrendPoint is the state of the issues at one commit of the beads file
+type TrendPoint struct {
+	Date     time.Time `json:"date"`
+	Revision string    `json:"revision"`
+	Total    int       `json:"total"`
+	Open     int       `json:"open"`    // Including blocked and in progress
+	Ready    int       `json:"ready"`   // Open with no open blockers
+	Blocked  int       `json:"blocked"` // Open and waiting on an open blocker
+	Closed   int       `json:"closed"`
+	Cycles   int       `json:"cycles"`
+	Health   int       `json:"health"`
+}
+
+// MeasureTrendPoint computes the metrics for the issues as they were at a
+// commit made at date
+func MeasureTrendPoint(issues []model.Issue, date time.Time, revision string) TrendPoint {
+	p := TrendPoint{Date: date, Revision: revision, Total: len(issues)}
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			p.Closed++
+			continue
+		}
+		p.Open++
+	}
+	blockers := OpenBlockers(issues)
+	p.Ready = countReady(blockers)
+	p.Blocked = p.Open - p.Ready
+
+	// Only cycle detection is needed, so skip the expensive metrics
+	stats := NewAnalyzer(issues).AnalyzeWithConfig(AnalysisConfig{
+		ComputeCycles:    true,
+		CyclesTimeout:    2 * time.Second,
+		MaxCyclesToStore: 100,
+	})
+	p.Cycles = len(stats.Cycles())
+	p.Health = HealthScore(issues, p.Cycles, date)
+	return p
+}
+
+// HealthScore rates the state of the issues from 0 to 100:
+//
+//   - 40 points for the share of open work that is ready to start
+//   - 20 for having no dependency cycles, halved by each cycle
+//   - 20 for the share of open work updated in the last two weeks
+//   - 20 for the share of open P0 and P1 work with an owner
+//
+// With nothing open, every share counts as full.
+func HealthScore(issues []model.Issue, cycles int, now time.Time) int {
+	open, fresh, urgent, owned := 0, 0, 0, 0
+	for _, issue := range issues {
+		if issue.Status.IsClosed() {
+			continue
+		}
+		open++
+		if issue.UpdatedAt.IsZero() || now.Sub(issue.UpdatedAt) < healthStaleDays*24*time.Hour {
+			fresh++
+		}
+		if issue.Priority <= 1 {
+			urgent++
+			if issue.Assignee != "" {
+				owned++
+			}
+		}
+	}
+	share := func(n, of int) float64 {
+		if of == 0 {
+			return 1
+		}
+		return float64(n) / float64(of)
+	}
+
+	ready := countReady(OpenBlockers(issues))
+	score := 40*share(ready, open) +
+		20/math.Pow(2, float64(cycles)) +
+		20*share(fresh, open) +
+		20*share(owned, urgent)
+	return int(math.Round(score))
+}
+
+// DailyTrend keeps the last point of each calendar day, in the points' own
+// time zone
+func DailyTrend(points []TrendPoint) []TrendPoint {
+	var daily []TrendPoint
+	for _, p := range points {
+		if n := len(daily); n > 0 && sameDay(daily[n-1].Date, p.Date) {
+			daily[n-1] = p
+			continue
+		}
+		daily = append(daily, p)
+	}
+	return daily
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
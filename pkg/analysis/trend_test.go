@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestMeasureTrendPoint(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Priority: 0, Assignee: "sam", UpdatedAt: now},
+		{ID: "B", Status: model.StatusOpen, Priority: 1, UpdatedAt: now, Dependencies: blocks("A")},
+		{ID: "C", Status: model.StatusInProgress, Priority: 2, UpdatedAt: now.AddDate(0, 0, -30)},
+		{ID: "D", Status: model.StatusClosed, Dependencies: blocks("A")},
+	}
+
+	p := MeasureTrendPoint(issues, now, "abc123")
+	if p.Total != 4 || p.Open != 3 || p.Ready != 2 || p.Blocked != 1 || p.Closed != 1 || p.Cycles != 0 {
+		t.Fatalf("unexpected counts: %+v", p)
+	}
+	// 40×2/3 ready + 20 no cycles + 20×2/3 fresh + 20×1/2 urgent owned
+	if p.Health != 70 {
+		t.Errorf("expected health 70, got %d", p.Health)
+	}
+	if p.Revision != "abc123" || !p.Date.Equal(now) {
+		t.Errorf("expected the commit recorded, got %+v", p)
+	}
+
+	// A cycle halves the cycle points and leaves nothing ready
+	issues[0].Dependencies = blocks("B")
+	p = MeasureTrendPoint(issues, now, "def456")
+	if p.Cycles != 1 || p.Ready != 1 {
+		t.Fatalf("expected one cycle and only C ready, got %+v", p)
+	}
+	if p.Health != 47 {
+		t.Errorf("expected health 47, got %d", p.Health)
+	}
+}
+
+func TestHealthScoreWithNothingOpen(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Status: model.StatusClosed}}
+	if got := HealthScore(issues, 0, time.Now()); got != 100 {
+		t.Errorf("expected 100, got %d", got)
+	}
+	if got := HealthScore(nil, 1, time.Now()); got != 90 {
+		t.Errorf("expected a cycle to cost 10, got %d", got)
+	}
+}
+
+func TestDailyTrend(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 6, d, h, 0, 0, 0, time.UTC) }
+	points := []TrendPoint{
+		{Date: day(1, 9), Revision: "a"},
+		{Date: day(1, 17), Revision: "b"},
+		{Date: day(3, 10), Revision: "c"},
+	}
+	daily := DailyTrend(points)
+	if len(daily) != 2 || daily[0].Revision != "b" || daily[1].Revision != "c" {
+		t.Errorf("expected the last commit of each day, got %+v", daily)
+	}
+}
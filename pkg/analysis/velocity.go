@@ -0,0 +1,96 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultVelocityWindow is the number of weeks averaged into the rolling velocity
+const DefaultVelocityWindow = 4
+
+// WeeklyThroughput is the work closed in one week
+type WeeklyThroughput struct {
+	End          time.Time `json:"end"` // Exclusive end of the week
+	Closed       int       `json:"closed"`
+	ClosedPoints int       `json:"closed_points"` // Estimated minutes of the closed issues
+	// Velocity is the mean weekly throughput over the window ending with this week
+	Velocity float64 `json:"velocity"`
+}
+
+// VelocityReport summarizes recent throughput and forecasts when the current
+// open set clears at that pace
+type VelocityReport struct {
+	Weeks          []WeeklyThroughput `json:"weeks"`  // Oldest first; the last week ends tonight
+	Window         int                `json:"window"` // Weeks averaged into Velocity
+	Velocity       float64            `json:"velocity"`
+	PointsVelocity float64            `json:"points_velocity"`
+	Open           int                `json:"open"`
+	OpenPoints     int                `json:"open_points"`
+
+	// WeeksToClear is Open / Velocity; zero when nothing is open or there is no velocity
+	WeeksToClear float64    `json:"weeks_to_clear"`
+	Forecast     *time.Time `json:"forecast,omitempty"`
+}
+
+// ComputeVelocity reports closed issues per week over the last `weeks` weeks and
+// a rolling average over `window` weeks. Weeks are the same calendar-aligned
+// buckets as ComputeBurndown with GranularityWeek.
+func ComputeVelocity(issues []model.Issue, now time.Time, weeks, window int) VelocityReport {
+	r := VelocityReport{Window: window}
+	if weeks <= 0 {
+		return r
+	}
+	if window <= 0 || window > weeks {
+		window = weeks
+		r.Window = weeks
+	}
+
+	// One extra leading bucket absorbs all earlier history so each week is a difference
+	points := ComputeBurndown(issues, now, GranularityWeek, weeks+1)
+	r.Weeks = make([]WeeklyThroughput, weeks)
+	for i := range r.Weeks {
+		prev, cur := points[i], points[i+1]
+		r.Weeks[i] = WeeklyThroughput{
+			End:          cur.End,
+			Closed:       cur.Done - prev.Done,
+			ClosedPoints: cur.DonePoints - prev.DonePoints,
+		}
+	}
+	for i := range r.Weeks {
+		from := max(0, i-window+1)
+		sum := 0
+		for _, w := range r.Weeks[from : i+1] {
+			sum += w.Closed
+		}
+		r.Weeks[i].Velocity = float64(sum) / float64(i+1-from)
+	}
+
+	recent := r.Weeks[weeks-window:]
+	closed, closedPoints := 0, 0
+	for _, w := range recent {
+		closed += w.Closed
+		closedPoints += w.ClosedPoints
+	}
+	r.Velocity = float64(closed) / float64(window)
+	r.PointsVelocity = float64(closedPoints) / float64(window)
+
+	for i := range issues {
+		if issues[i].Status.IsClosed() {
+			continue
+		}
+		r.Open++
+		if est, ok := issues[i].EstimateMinutes(); ok {
+			r.OpenPoints += est
+		}
+	}
+
+	if r.Open > 0 && r.Velocity > 0 {
+		r.WeeksToClear = float64(r.Open) / r.Velocity
+		days := math.Ceil(r.WeeksToClear * 7)
+		eta := now.AddDate(0, 0, int(days))
+		r.Forecast = &eta
+	}
+	return r
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeVelocity(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	closedDaysAgo := func(d int) *time.Time {
+		ts := now.AddDate(0, 0, -d)
+		return &ts
+	}
+	var issues []model.Issue
+	// Week ending tonight: 3 closed; previous week: 1 closed; a month ago: 1 closed
+	for i, d := range []int{0, 1, 2, 8, 30} {
+		issues = append(issues, model.Issue{
+			ID: string(rune('A' + i)), Status: model.StatusClosed,
+			CreatedAt: now.AddDate(0, 0, -60), ClosedAt: closedDaysAgo(d), EstimatedMinutes: minutes(60),
+		})
+	}
+	issues = append(issues,
+		model.Issue{ID: "O1", Status: model.StatusOpen, CreatedAt: now, EstimatedMinutes: minutes(30)},
+		model.Issue{ID: "O2", Status: model.StatusInProgress, CreatedAt: now},
+	)
+
+	r := ComputeVelocity(issues, now, 6, 2)
+	if len(r.Weeks) != 6 {
+		t.Fatalf("expected 6 weeks, got %d", len(r.Weeks))
+	}
+	last := r.Weeks[5]
+	if last.Closed != 3 || last.ClosedPoints != 180 || r.Weeks[4].Closed != 1 {
+		t.Fatalf("unexpected weekly throughput %+v", r.Weeks)
+	}
+	if r.Velocity != 2 || last.Velocity != 2 || r.PointsVelocity != 120 {
+		t.Fatalf("expected velocity 2/week (120 points), got %.2f (%.2f)", r.Velocity, r.PointsVelocity)
+	}
+	if r.Open != 2 || r.OpenPoints != 30 {
+		t.Fatalf("expected 2 open (30 points), got %d (%d)", r.Open, r.OpenPoints)
+	}
+	if r.WeeksToClear != 1 || r.Forecast == nil || !r.Forecast.Equal(now.AddDate(0, 0, 7)) {
+		t.Fatalf("expected forecast one week out, got %.2f %v", r.WeeksToClear, r.Forecast)
+	}
+}
+
+func TestComputeVelocityNoThroughput(t *testing.T) {
+	now := time.Now()
+	r := ComputeVelocity([]model.Issue{{ID: "A", Status: model.StatusOpen, CreatedAt: now}}, now, 4, 8)
+	if r.Window != 4 || r.Velocity != 0 || r.Forecast != nil || r.WeeksToClear != 0 {
+		t.Fatalf("expected no forecast without throughput, got %+v", r)
+	}
+}
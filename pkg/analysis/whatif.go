@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Hypothetical is an issue not filed yet, with the links it would have
+type Hypothetical struct {
+	ID        string
+	Title     string
+	Priority  int
+	Parent    string   // Epic or parent issue it would go under, if any
+	BlockedBy []string // Issues it would wait on
+	Blocks    []string // Issues that would wait on it
+}
+
+// EpicForecast projects when an open epic finishes
+type EpicForecast struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+
+	// Remaining counts the open issues under the epic and the open work
+	// outside it that they wait on, directly or through a chain
+	Remaining int `json:"remaining"`
+
+	// Projected extrapolates recent throughput over Remaining. Nil when
+	// nothing remains or nothing closed recently.
+	Projected *time.Time `json:"projected,omitempty"`
+}
+
+// WhatIfState is the part of the plan a new issue can move
+type WhatIfState struct {
+	CriticalPath []string       `json:"critical_path"`
+	Ready        int            `json:"ready"`
+	Epics        []EpicForecast `json:"epics"`
+}
+
+// WhatIfResult compares the plan with and without a hypothetical issue
+type WhatIfResult struct {
+	Issue      model.Issue `json:"issue"`      // The hypothetical as added
+	Throughput float64     `json:"throughput"` // Issues closed per day, which both forecasts assume
+	Before     WhatIfState `json:"before"`
+	After      WhatIfState `json:"after"`
+}
+
+// SimulateNewIssue adds h to a copy of issues and reports how the critical
+// path, ready count and epic forecasts change. It fails when h's ID is taken
+// or it links to an issue that doesn't exist.
+func SimulateNewIssue(issues []model.Issue, h Hypothetical, now time.Time) (*WhatIfResult, error) {
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+	if h.ID == "" || known[h.ID] {
+		return nil, fmt.Errorf("the new issue needs an unused ID, not %q", h.ID)
+	}
+	links := append(append([]string{h.Parent}, h.BlockedBy...), h.Blocks...)
+	for _, id := range links {
+		if id != "" && !known[id] {
+			return nil, fmt.Errorf("no issue %s to link the new issue to", id)
+		}
+	}
+
+	added := model.Issue{
+		ID:        h.ID,
+		Title:     h.Title,
+		Status:    model.StatusOpen,
+		Priority:  h.Priority,
+		IssueType: model.TypeTask,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if h.Parent != "" {
+		added.Dependencies = append(added.Dependencies, &model.Dependency{IssueID: h.ID, DependsOnID: h.Parent, Type: model.DepParentChild})
+	}
+	for _, id := range h.BlockedBy {
+		added.Dependencies = append(added.Dependencies, &model.Dependency{IssueID: h.ID, DependsOnID: id, Type: model.DepBlocks})
+	}
+
+	// Copy the issues that gain a blocker, so the caller's stay untouched
+	blocks := make(map[string]bool, len(h.Blocks))
+	for _, id := range h.Blocks {
+		blocks[id] = true
+	}
+	after := make([]model.Issue, 0, len(issues)+1)
+	for _, issue := range issues {
+		if blocks[issue.ID] {
+			deps := make([]*model.Dependency, len(issue.Dependencies), len(issue.Dependencies)+1)
+			copy(deps, issue.Dependencies)
+			issue.Dependencies = append(deps, &model.Dependency{IssueID: issue.ID, DependsOnID: h.ID, Type: model.DepBlocks})
+		}
+		after = append(after, issue)
+	}
+	after = append(after, added)
+
+	// The new issue is open, so it can't change throughput
+	rate := RecentThroughput(issues, now, DefaultThroughputWindow)
+	return &WhatIfResult{
+		Issue:      added,
+		Throughput: rate,
+		Before:     whatIfState(issues, rate, now),
+		After:      whatIfState(after, rate, now),
+	}, nil
+}
+
+func whatIfState(issues []model.Issue, rate float64, now time.Time) WhatIfState {
+	blockers := OpenBlockers(issues)
+	return WhatIfState{
+		CriticalPath: longestOpenChain(blockers),
+		Ready:        countReady(blockers),
+		Epics:        forecastEpics(issues, blockers, rate, now),
+	}
+}
+
+// forecastEpics projects when each open epic finishes at rate issues closed
+// per day, ordered by ID
+func forecastEpics(issues []model.Issue, blockers map[string][]string, rate float64, now time.Time) []EpicForecast {
+	var forecasts []EpicForecast
+	for _, epic := range issues {
+		if epic.IssueType != model.TypeEpic || epic.Status.IsClosed() {
+			continue
+		}
+		// Open issues under the epic, then everything open they wait on
+		remaining := make(map[string]bool)
+		for id := range Subtree(issues, epic.ID) {
+			if _, open := blockers[id]; !open || id == epic.ID {
+				continue
+			}
+			walkUnique(id, blockers, func(n string) {
+				if n != epic.ID {
+					remaining[n] = true
+				}
+			})
+		}
+
+		f := EpicForecast{ID: epic.ID, Title: epic.Title, Remaining: len(remaining)}
+		if f.Remaining > 0 && rate > 0 {
+			eta := now.Add(time.Duration(math.Ceil(float64(f.Remaining)/rate)) * 24 * time.Hour)
+			f.Projected = &eta
+		}
+		forecasts = append(forecasts, f)
+	}
+	sort.Slice(forecasts, func(i, j int) bool { return forecasts[i].ID < forecasts[j].ID })
+	return forecasts
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSimulateNewIssue(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	closedAt := now.AddDate(0, 0, -3)
+	child := func(id, epic string, deps ...*model.Dependency) model.Issue {
+		deps = append(deps, &model.Dependency{DependsOnID: epic, Type: model.DepParentChild})
+		return model.Issue{ID: id, Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: deps}
+	}
+	issues := []model.Issue{
+		{ID: "E", Title: "Billing", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("E1", "E"),
+		child("E2", "E", &model.Dependency{DependsOnID: "E1", Type: model.DepBlocks}),
+		{ID: "X", Status: model.StatusOpen, IssueType: model.TypeTask},
+		// 14 closed in the last two weeks: one a day
+		{ID: "C1", Status: model.StatusClosed, ClosedAt: &closedAt},
+	}
+	for i := 2; i <= 14; i++ {
+		issues = append(issues, model.Issue{ID: "C" + string(rune('a'+i)), Status: model.StatusClosed, ClosedAt: &closedAt})
+	}
+
+	// A schema change everything in the epic would wait on, itself waiting on X
+	r, err := SimulateNewIssue(issues, Hypothetical{ID: "NEW", Title: "Schema", BlockedBy: []string{"X"}, Blocks: []string{"E1"}}, now)
+	if err != nil {
+		t.Fatalf("SimulateNewIssue: %v", err)
+	}
+	if r.Throughput != 1 {
+		t.Errorf("expected one closed a day, got %v", r.Throughput)
+	}
+	if !reflect.DeepEqual(r.Before.CriticalPath, []string{"E1", "E2"}) {
+		t.Errorf("unexpected path before: %v", r.Before.CriticalPath)
+	}
+	if !reflect.DeepEqual(r.After.CriticalPath, []string{"X", "NEW", "E1", "E2"}) {
+		t.Errorf("expected the path to run through NEW, got %v", r.After.CriticalPath)
+	}
+	// Before: E, E1 and X ready; after: E and X
+	if r.Before.Ready != 3 || r.After.Ready != 2 {
+		t.Errorf("expected ready 3 → 2, got %d → %d", r.Before.Ready, r.After.Ready)
+	}
+
+	if len(r.Before.Epics) != 1 || len(r.After.Epics) != 1 {
+		t.Fatalf("expected one epic forecast, got %+v / %+v", r.Before.Epics, r.After.Epics)
+	}
+	before, after := r.Before.Epics[0], r.After.Epics[0]
+	if before.Remaining != 2 || after.Remaining != 4 {
+		t.Errorf("expected the epic to wait on NEW and X too: %d → %d", before.Remaining, after.Remaining)
+	}
+	if before.Projected == nil || after.Projected == nil || after.Projected.Sub(*before.Projected) != 48*time.Hour {
+		t.Errorf("expected the forecast two days later, got %v → %v", before.Projected, after.Projected)
+	}
+
+	// The caller's issues are untouched
+	for _, dep := range issues[1].Dependencies {
+		if dep.DependsOnID == "NEW" {
+			t.Error("SimulateNewIssue changed the caller's issues")
+		}
+	}
+}
+
+func TestSimulateNewIssueRejectsBadLinks(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Status: model.StatusOpen}}
+	if _, err := SimulateNewIssue(issues, Hypothetical{ID: "A"}, time.Now()); err == nil {
+		t.Error("expected a taken ID to fail")
+	}
+	if _, err := SimulateNewIssue(issues, Hypothetical{ID: "NEW", Blocks: []string{"missing"}}, time.Now()); err == nil {
+		t.Error("expected a link to a missing issue to fail")
+	}
+}
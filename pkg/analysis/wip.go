@@ -0,0 +1,101 @@
+package analysis
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// WIPLimits caps work in progress. A limit of 0 means no limit.
+type WIPLimits struct {
+	Status      map[model.Status]int // Issues per status column (open, in_progress, blocked)
+	PerAssignee int                  // In-progress issues per assignee
+	Assignees   map[string]int       // Per-assignee overrides of PerAssignee
+}
+
+// WIPStatuses are the statuses a limit can be set on
+var WIPStatuses = []model.Status{model.StatusOpen, model.StatusInProgress, model.StatusBlocked}
+
+// DefaultWIPLimits limits each assignee to DefaultWIPLimit issues in
+// progress and leaves the status columns unlimited
+func DefaultWIPLimits() WIPLimits {
+	return WIPLimits{PerAssignee: DefaultWIPLimit}
+}
+
+// StatusLimit returns the limit for a status column, 0 for none
+func (l WIPLimits) StatusLimit(status model.Status) int {
+	return l.Status[status]
+}
+
+// AssigneeLimit returns how many issues assignee may have in progress, 0
+// for no limit. Unassigned work is never limited.
+func (l WIPLimits) AssigneeLimit(assignee string) int {
+	if assignee == "" {
+		return 0
+	}
+	if n, ok := l.Assignees[assignee]; ok {
+		return n
+	}
+	return l.PerAssignee
+}
+
+// wipLimits holds the limits set with SetWIPLimits, nil for the defaults
+var wipLimits atomic.Pointer[WIPLimits]
+
+// SetWIPLimits changes the limits the workload view, board and lint use
+func SetWIPLimits(l WIPLimits) {
+	wipLimits.Store(&l)
+}
+
+// CurrentWIPLimits returns the limits in use
+func CurrentWIPLimits() WIPLimits {
+	if l := wipLimits.Load(); l != nil {
+		return *l
+	}
+	return DefaultWIPLimits()
+}
+
+// WIPViolation is a status column or assignee over its limit
+type WIPViolation struct {
+	Scope    string   `json:"scope"` // "status" or "assignee"
+	Name     string   `json:"name"`  // The status or assignee
+	Count    int      `json:"count"`
+	Limit    int      `json:"limit"`
+	IssueIDs []string `json:"issue_ids"` // The issues counted, sorted
+}
+
+// CheckWIP returns every status column and assignee over its limit:
+// statuses first in workflow order, then assignees by name
+func CheckWIP(issues []model.Issue, limits WIPLimits) []WIPViolation {
+	byStatus := make(map[model.Status][]string)
+	byAssignee := make(map[string][]string)
+	for _, issue := range issues {
+		byStatus[issue.Status] = append(byStatus[issue.Status], issue.ID)
+		if issue.Status == model.StatusInProgress && issue.Assignee != "" {
+			byAssignee[issue.Assignee] = append(byAssignee[issue.Assignee], issue.ID)
+		}
+	}
+
+	var violations []WIPViolation
+	add := func(scope, name string, ids []string, limit int) {
+		if limit <= 0 || len(ids) <= limit {
+			return
+		}
+		sorted := append([]string(nil), ids...)
+		sort.Strings(sorted)
+		violations = append(violations, WIPViolation{Scope: scope, Name: name, Count: len(ids), Limit: limit, IssueIDs: sorted})
+	}
+	for _, status := range WIPStatuses {
+		add("status", string(status), byStatus[status], limits.StatusLimit(status))
+	}
+	names := make([]string, 0, len(byAssignee))
+	for name := range byAssignee {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add("assignee", name, byAssignee[name], limits.AssigneeLimit(name))
+	}
+	return violations
+}
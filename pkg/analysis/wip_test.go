@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCheckWIP(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusInProgress, Assignee: "ann"},
+		{ID: "B", Status: model.StatusInProgress, Assignee: "ann"},
+		{ID: "C", Status: model.StatusInProgress, Assignee: "bob"},
+		{ID: "D", Status: model.StatusInProgress},
+		{ID: "E", Status: model.StatusOpen, Assignee: "bob"},
+	}
+	limits := WIPLimits{
+		Status:      map[model.Status]int{model.StatusInProgress: 3, model.StatusOpen: 1},
+		PerAssignee: 1,
+		Assignees:   map[string]int{"bob": 0},
+	}
+	got := CheckWIP(issues, limits)
+	if len(got) != 2 {
+		t.Fatalf("expected the in_progress column and ann over their limits, got %+v", got)
+	}
+	if v := got[0]; v.Scope != "status" || v.Name != "in_progress" || v.Count != 4 || v.Limit != 3 {
+		t.Errorf("unexpected status violation %+v", v)
+	}
+	if v := got[1]; v.Scope != "assignee" || v.Name != "ann" || v.Count != 2 || len(v.IssueIDs) != 2 {
+		t.Errorf("unexpected assignee violation %+v", v)
+	}
+
+	defer SetWIPLimits(DefaultWIPLimits())
+	SetWIPLimits(limits)
+	for _, w := range ComputeWorkload(issues, time.Now()) {
+		if w.Assignee == "ann" && (w.Level != LoadOverloaded || w.WIPLimit != 1) {
+			t.Errorf("ann should be over her WIP limit: %+v", w)
+		}
+		if w.Assignee == "bob" && (w.Level == LoadOverloaded || w.WIPLimit != 0) {
+			t.Errorf("bob has no limit: %+v", w)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultWIPLimit is the number of in-progress issues above which an assignee
+// is flagged as overloaded regardless of their open count, unless WIPLimits
+// says otherwise
+const DefaultWIPLimit = 3
+
+// LoadLevel classifies an assignee's workload relative to the team
+type LoadLevel string
+
+const (
+	LoadIdle       LoadLevel = "idle"       // No open work
+	LoadNormal     LoadLevel = "normal"     // Within range of the team
+	LoadOverloaded LoadLevel = "overloaded" // Far above the team median or over the WIP limit
+)
+
+// AssigneeWorkload summarizes the open work owned by one assignee
+type AssigneeWorkload struct {
+	Assignee   string `json:"assignee"` // Empty for unassigned work
+	Open       int    `json:"open"`     // All non-closed issues, including in progress and blocked
+	InProgress int    `json:"in_progress"`
+	Blocked    int    `json:"blocked"`
+	Closed     int    `json:"closed"`
+	WIPLimit   int    `json:"wip_limit"` // In-progress limit, 0 for none
+
+	// Downstream counts distinct open issues owned by anyone that transitively
+	// wait on this assignee's open work
+	Downstream int `json:"downstream"`
+
+	AvgAge time.Duration `json:"avg_age"` // Mean age of the open issues
+	Level  LoadLevel     `json:"level"`
+}
+
+// ComputeWorkload returns one entry per assignee (plus one for unassigned work
+// when there is any), busiest first. An assignee is overloaded when they hold
+// more than twice the median open count (and at least 4 issues), or more
+// issues in progress than their WIP limit (see SetWIPLimits). Assignees whose
+// issues are all closed are idle.
+func ComputeWorkload(issues []model.Issue, now time.Time) []AssigneeWorkload {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	// Reverse blocking edges: blocker -> issues waiting on it
+	dependents := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !isBlockingDep(dep.Type) {
+				continue
+			}
+			if _, ok := issueMap[dep.DependsOnID]; ok {
+				dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	byAssignee := make(map[string]*AssigneeWorkload)
+	ages := make(map[string]time.Duration)
+	roots := make(map[string][]string)
+	for i := range issues {
+		issue := &issues[i]
+		w, ok := byAssignee[issue.Assignee]
+		if !ok {
+			w = &AssigneeWorkload{Assignee: issue.Assignee}
+			byAssignee[issue.Assignee] = w
+		}
+		if issue.Status.IsClosed() {
+			w.Closed++
+			continue
+		}
+		w.Open++
+		switch issue.Status {
+		case model.StatusInProgress:
+			w.InProgress++
+		case model.StatusBlocked:
+			w.Blocked++
+		}
+		if !issue.CreatedAt.IsZero() {
+			ages[issue.Assignee] += now.Sub(issue.CreatedAt)
+		}
+		roots[issue.Assignee] = append(roots[issue.Assignee], issue.ID)
+	}
+
+	// Unassigned work only matters while some of it is open
+	if w, ok := byAssignee[""]; ok && w.Open == 0 {
+		delete(byAssignee, "")
+	}
+
+	var openCounts []int
+	for name, w := range byAssignee {
+		if w.Open > 0 {
+			w.AvgAge = ages[name] / time.Duration(w.Open)
+			if name != "" {
+				openCounts = append(openCounts, w.Open)
+			}
+		}
+		downstream := make(map[string]bool)
+		for _, root := range roots[name] {
+			walkUnique(root, dependents, func(id string) {
+				if id != root && !issueMap[id].Status.IsClosed() {
+					downstream[id] = true
+				}
+			})
+		}
+		w.Downstream = len(downstream)
+	}
+
+	median := 0
+	if len(openCounts) > 0 {
+		sort.Ints(openCounts)
+		median = openCounts[(len(openCounts)-1)/2] // Lower median so one heavy assignee stands out in small teams
+	}
+
+	limits := CurrentWIPLimits()
+	result := make([]AssigneeWorkload, 0, len(byAssignee))
+	for _, w := range byAssignee {
+		w.WIPLimit = limits.AssigneeLimit(w.Assignee)
+		switch {
+		case w.Assignee == "":
+			w.Level = LoadNormal
+		case w.Open == 0:
+			w.Level = LoadIdle
+		case w.WIPLimit > 0 && w.InProgress > w.WIPLimit, w.Open >= 4 && w.Open > 2*median:
+			w.Level = LoadOverloaded
+		default:
+			w.Level = LoadNormal
+		}
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Open != result[j].Open {
+			return result[i].Open > result[j].Open
+		}
+		return result[i].Assignee < result[j].Assignee
+	})
+	return result
+}
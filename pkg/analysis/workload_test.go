@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeWorkload(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+
+	issues := []model.Issue{
+		{ID: "A1", Assignee: "ann", Status: model.StatusInProgress, CreatedAt: daysAgo(4)},
+		{ID: "A2", Assignee: "ann", Status: model.StatusOpen, CreatedAt: daysAgo(2)},
+		{ID: "A3", Assignee: "ann", Status: model.StatusBlocked, CreatedAt: daysAgo(6)},
+		{ID: "A4", Assignee: "ann", Status: model.StatusOpen, CreatedAt: daysAgo(4)},
+		{ID: "B1", Assignee: "bob", Status: model.StatusOpen, CreatedAt: daysAgo(1), Dependencies: blocks("B1", "A1")},
+		{ID: "U1", Status: model.StatusOpen, CreatedAt: daysAgo(1), Dependencies: blocks("U1", "B1")},
+		{ID: "U2", Status: model.StatusClosed, CreatedAt: daysAgo(1), Dependencies: blocks("U2", "A1")},
+		{ID: "C1", Assignee: "cat", Status: model.StatusClosed, CreatedAt: daysAgo(9)},
+	}
+
+	got := ComputeWorkload(issues, now)
+	byName := make(map[string]AssigneeWorkload)
+	for _, w := range got {
+		byName[w.Assignee] = w
+	}
+	if len(got) != 4 || got[0].Assignee != "ann" {
+		t.Fatalf("expected ann first of 4 entries, got %+v", got)
+	}
+
+	ann := byName["ann"]
+	if ann.Open != 4 || ann.InProgress != 1 || ann.Blocked != 1 || ann.AvgAge != 4*24*time.Hour {
+		t.Fatalf("unexpected ann workload %+v", ann)
+	}
+	// B1 and U1 transitively wait on A1; closed U2 doesn't count
+	if ann.Downstream != 2 || ann.Level != LoadOverloaded {
+		t.Fatalf("expected ann overloaded with 2 downstream, got %+v", ann)
+	}
+	if bob := byName["bob"]; bob.Downstream != 1 || bob.Level != LoadNormal {
+		t.Fatalf("unexpected bob workload %+v", bob)
+	}
+	if cat := byName["cat"]; cat.Level != LoadIdle || cat.Closed != 1 {
+		t.Fatalf("expected cat idle, got %+v", cat)
+	}
+	if u := byName[""]; u.Open != 1 || u.Level != LoadNormal {
+		t.Fatalf("expected one unassigned open issue, got %+v", u)
+	}
+}
+
+func TestComputeWorkloadWIPLimit(t *testing.T) {
+	var issues []model.Issue
+	for i := 0; i <= DefaultWIPLimit; i++ {
+		issues = append(issues, model.Issue{ID: string(rune('A' + i)), Assignee: "ann", Status: model.StatusInProgress})
+	}
+	got := ComputeWorkload(issues, time.Now())
+	if len(got) != 1 || got[0].Level != LoadOverloaded {
+		t.Fatalf("expected WIP over limit to be overloaded, got %+v", got)
+	}
+}
@@ -0,0 +1,1123 @@
+// Package config loads bv's settings from layered sources: built-in
+// defaults, the user config file, the project's .beads_viewer.toml, BV_*
+// environment variables and --set flags, each overriding the last.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFilename is the per-repo config file, at the project root
+const ProjectFilename = ".beads_viewer.toml"
+
+// Views are the screens bv can open on (view.default)
+var Views = []string{"list", "board", "graph", "insights"}
+
+// Columns are the optional list columns (view.columns), in display order
+var Columns = []string{"due", "age", "comments", "checklist", "assignee", "labels"}
+
+// Densities are how tightly the list packs its rows (view.density)
+var Densities = []string{"compact", "comfortable", "spacious"}
+
+// AmbiguousWidths are how wide box drawing and other East Asian ambiguous
+// characters draw (view.ambiguous_width)
+var AmbiguousWidths = []string{"auto", "narrow", "wide"}
+
+// WatchModes are the ways live reload notices changes to the beads file
+// (watch.mode): auto uses fsnotify except on network filesystems, notify
+// always uses it and poll always polls
+var WatchModes = []string{"auto", "notify", "poll"}
+
+// ImagePreviews are the graphics protocols the detail view can preview
+// image attachments with (view.image_preview); "auto" detects the terminal
+var ImagePreviews = []string{"auto", "kitty", "iterm", "sixel", "off"}
+
+// ThemeColors are the palette entries theme.colors can override
+var ThemeColors = []string{
+	"primary", "secondary", "subtext",
+	"open", "in_progress", "blocked", "closed",
+	"bug", "feature", "task", "epic", "chore",
+	"border", "highlight",
+}
+
+// builtinModifierKeys are the ctrl+ and alt+ shortcuts the TUI binds itself
+var builtinModifierKeys = map[string]bool{
+	"ctrl+b": true, "ctrl+c": true, "ctrl+d": true, "ctrl+e": true, "ctrl+f": true,
+	"ctrl+k": true, "ctrl+n": true, "ctrl+p": true, "ctrl+s": true, "ctrl+u": true,
+	"ctrl+w": true, "ctrl+y": true,
+	"alt+1": true, "alt+2": true, "alt+3": true, "alt+4": true, "alt+5": true,
+	"alt+6": true, "alt+7": true, "alt+8": true, "alt+9": true,
+	"alt+a": true, "alt+d": true, "alt+e": true, "alt+f": true, "alt+l": true,
+	"alt+m": true, "alt+s": true, "alt+t": true, "alt+w": true,
+}
+
+// IsBuiltinKey reports whether the TUI binds key itself: every key without a
+// ctrl+ or alt+ modifier (letters, digits, arrows, enter and the like are all
+// taken or typed into prompts) and the shortcuts in builtinModifierKeys
+func IsBuiltinKey(key string) bool {
+	if !strings.HasPrefix(key, "ctrl+") && !strings.HasPrefix(key, "alt+") {
+		return true
+	}
+	return builtinModifierKeys[key]
+}
+
+// KeyActions maps each rebindable action (keys.<action>) to its default key
+var KeyActions = map[string]string{
+	"quit":           "q",
+	"help":           "?",
+	"board":          "b",
+	"graph":          "g",
+	"insights":       "i",
+	"actionable":     "a",
+	"priority_hints": "p",
+	"recipes":        "R",
+	"export":         "E",
+	"sort":           "s",
+	"reverse_sort":   "S",
+	"milestones":     "M",
+	"burndown":       "B",
+	"flow":           "F",
+	"velocity":       "V",
+	"workload":       "W",
+	"duplicates":     "X",
+	"problems":       "P",
+	"activity":       "U",
+	"coupling":       "K",
+	"dsm":            "J",
+	"risk":           "Q",
+	"schedule":       "alt+s",
+	"aging":          "alt+a",
+	"labels":         "alt+l",
+	"lineage":        "alt+d",
+	"worklog":        "alt+w",
+	"timer":          "alt+t",
+	"focus_mode":     "alt+f",
+	"mine":           "@",
+	"archive":        "A",
+	"sprints":        "I",
+	"new_tab":        "ctrl+n",
+	"close_tab":      "ctrl+w",
+	"focus_subgraph": "ctrl+f",
+	"epic_scope":     "alt+e",
+	"new_issue":      "n",
+	"comment":        "m",
+}
+
+// Config holds every setting. The zero value of a field means "not set";
+// Default fills in the built-in values.
+type Config struct {
+	Theme    ThemeConfig
+	Keys     map[string]string // Action -> key, only for rebound actions
+	View     ViewConfig
+	Links    LinksConfig
+	Analysis AnalysisConfig
+	User     UserConfig
+	Impact   analysis.ImpactWeights   // Relative weight of each impact score component
+	WIP      analysis.WIPLimits       // Work-in-progress limits per status and assignee
+	Teams    analysis.Teams           // Team name -> member assignees, from [teams]
+	Time     timefmt.Settings         // How ages, dates and times show
+	Watch    WatchConfig              // How live reload notices file changes
+	Fields   FieldsConfig             // Fields other tools add to issues
+	Plugins  map[string]*PluginConfig // Name -> plugin, from [plugins.<name>] tables
+	Scripts  ScriptsConfig
+
+	// Templates prefill the new-issue form, from [templates.<name>] tables
+	Templates map[string]*TemplateConfig
+
+	// DependencyTypes declares dependency types beyond beads' own, each
+	// mapped to whether it blocks like "blocks" does
+	DependencyTypes map[model.DependencyType]bool
+
+	// sources records where each key's value came from
+	sources map[string]string
+}
+
+// ThemeConfig controls colors
+type ThemeConfig struct {
+	Mode   string            // auto, dark or light
+	Colors map[string]string // Palette entry -> "#RRGGBB"
+}
+
+// ViewConfig controls the TUI's layout
+type ViewConfig struct {
+	Default   string   // The view bv opens on
+	Columns   []string // Optional list columns to show; nil shows all that fit
+	Density   string   // Row spacing and decorations, one of Densities
+	CardLines int      // Lines per list row: 1, or 2 or 3 for cards
+	// Columns per ambiguous-width character, one of AmbiguousWidths
+	AmbiguousWidth string
+	// Graphics protocol for image attachments, one of ImagePreviews
+	ImagePreview string
+}
+
+// WatchConfig controls how live reload notices the beads file changing.
+// Polling is for NFS, SMB and SSHFS mounts, where fsnotify misses changes
+// made on other machines.
+type WatchConfig struct {
+	Mode         string        // One of WatchModes
+	PollInterval time.Duration // How often to check the file when polling
+	Hash         bool          // Compare contents too when polling, not just mtime and size
+}
+
+// FieldsConfig picks which of the fields other tools add to issues, ones bv
+// doesn't know, show in the TUI. Each is named by its JSON key.
+type FieldsConfig struct {
+	Columns []string // Shown as list columns, in order
+	Details []string // Shown in the issue details, in order
+}
+
+// LinksConfig controls links out to other tools
+type LinksConfig struct {
+	IssueURL string // Go template for an issue's URL, see .bv/links.yaml
+}
+
+// UserConfig says who "me" is: whose issues the mine toggle shows and whose
+// unblock alerts fire
+type UserConfig struct {
+	Name     string            // Assignee name; "" works it out (see CurrentUser)
+	GitNames map[string]string // git config user.name -> assignee name
+}
+
+// AnalysisConfig controls how much graph analysis runs
+type AnalysisConfig struct {
+	ForceFull      bool // Compute every metric regardless of graph size
+	FullBelowNodes int  // Graphs with fewer issues get full analysis; 0 keeps the size tiers
+}
+
+// ScriptsConfig holds expressions evaluated per issue (see package script)
+type ScriptsConfig struct {
+	Impact  string         // Replaces the list's impact score
+	Sort    string         // Key for the "Custom" list sort
+	Columns []ScriptColumn // Extra list columns, in file order
+}
+
+// ScriptColumn is a computed list column
+type ScriptColumn struct {
+	Name string
+	Expr string
+}
+
+// PluginOutputs are the ways a plugin's output can be shown (plugins.<name>.output)
+var PluginOutputs = []string{"toast", "pager", "none"}
+
+// DefaultPluginTimeout is how long a plugin may run unless it sets a timeout
+const DefaultPluginTimeout = 30 * time.Second
+
+// PluginConfig is an external command bound to a key. It receives the
+// selected issue as JSON on stdin.
+type PluginConfig struct {
+	Key     string        // Key that runs it
+	Command string        // Shell command, run from the project root
+	Output  string        // toast (first line in the status bar), pager or none
+	Timeout time.Duration // Killed after this long
+}
+
+// TemplateFields are the fields the new-issue form can ask for besides the
+// title (templates.<name>.fields)
+var TemplateFields = []string{"type", "priority", "assignee", "labels"}
+
+// TemplateConfig is a starting point for issues created from the TUI, so
+// they follow the team's conventions
+type TemplateConfig struct {
+	Title       string   // Text the title starts with, e.g. "Bug: "
+	Type        string   // Issue type; "" leaves it to bd
+	Priority    int      // 0 (critical) to 4
+	Labels      []string // Labels new issues get
+	Assignee    string
+	Description string
+	Checklist   []string // Appended to the description as "- [ ]" items
+	Fields      []string // Fields the form asks for, of TemplateFields; nil asks for all
+}
+
+// Default returns the built-in settings
+func Default() *Config {
+	return &Config{
+		Theme:   ThemeConfig{Mode: "auto", Colors: map[string]string{}},
+		Keys:    map[string]string{},
+		View:    ViewConfig{Default: "list", Density: "comfortable", CardLines: 1, AmbiguousWidth: "auto", ImagePreview: "auto"},
+		User:    UserConfig{GitNames: map[string]string{}},
+		Impact:  analysis.DefaultImpactWeights(),
+		WIP:     analysis.DefaultWIPLimits(),
+		Teams:   analysis.Teams{},
+		Time:    timefmt.DefaultSettings(),
+		Watch:   WatchConfig{Mode: "auto", PollInterval: 2 * time.Second},
+		Plugins: map[string]*PluginConfig{},
+
+		Templates:       map[string]*TemplateConfig{},
+		DependencyTypes: map[model.DependencyType]bool{},
+		sources:         map[string]string{},
+	}
+}
+
+// UserPath returns the user-level config file: $XDG_CONFIG_HOME/bv/config.toml,
+// falling back to ~/.config/bv/config.toml
+func UserPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "bv", "config.toml")
+}
+
+// ProjectPath returns the project config file for projectDir
+func ProjectPath(projectDir string) string {
+	return filepath.Join(projectDir, ProjectFilename)
+}
+
+// Load builds the configuration for projectDir from every layer except
+// flags, which the caller applies afterwards with Set. Missing files are
+// skipped; a malformed file or variable is an error naming its source.
+func Load(projectDir string) (*Config, error) {
+	cfg := Default()
+	if path := UserPath(); path != "" {
+		if err := cfg.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadLinksYAML(projectDir); err != nil {
+		return nil, err
+	}
+	if err := cfg.loadProjectFile(ProjectPath(projectDir)); err != nil {
+		return nil, err
+	}
+	if err := cfg.LoadEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadFile applies the settings in a TOML file; a missing file is not an error
+func (c *Config) LoadFile(path string) error {
+	return c.loadFile(path, false)
+}
+
+// loadProjectFile applies the project's .beads_viewer.toml. It is checked in
+// with the repository, so plugins, which run shell commands, are refused
+// there: they only come from the user's config, the environment or flags.
+func (c *Config) loadProjectFile(path string) error {
+	return c.loadFile(path, true)
+}
+
+// loadFile applies the settings in a TOML file, refusing plugins if project
+func (c *Config) loadFile(path string, project bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading config: %w", err)
+	}
+	entries, err := parseTOML(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, e := range entries {
+		if project && strings.HasPrefix(e.Key, "plugins.") {
+			return fmt.Errorf("%s: %s: plugins can't be set in a project file, which anyone with commit access can change; define them in %s", path, e.Key, UserPath())
+		}
+		if err := c.set(e.Key, e.Value, path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// loadLinksYAML reads issue_url from .bv/links.yaml, which predates this
+// file, as part of the project layer
+func (c *Config) loadLinksYAML(projectDir string) error {
+	path := filepath.Join(projectDir, ".bv", "links.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading links config: %w", err)
+	}
+	var links struct {
+		IssueURL string `yaml:"issue_url"`
+	}
+	if err := yaml.Unmarshal(data, &links); err != nil {
+		return fmt.Errorf("parsing links config: %w", err)
+	}
+	if strings.TrimSpace(links.IssueURL) != "" {
+		return c.set("links.issue_url", links.IssueURL, path)
+	}
+	return nil
+}
+
+// LoadEnv applies BV_<SECTION>_<KEY> variables from environ (as returned
+// by os.Environ), e.g. BV_VIEW_DEFAULT=board or BV_KEYS_BOARD=v. Lists
+// are comma-separated. Unrelated BV_ variables are ignored.
+func (c *Config) LoadEnv(environ []string) error {
+	sort.Strings(environ) // Deterministic order for error messages
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, "BV_") {
+			continue
+		}
+		key := envKey(name)
+		if key == "" {
+			continue
+		}
+		if err := c.Set(key, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		c.sources[key] = "$" + name
+	}
+	return nil
+}
+
+// envKey maps a BV_ variable name to its config key, or "" if it isn't one
+func envKey(name string) string {
+	rest := strings.ToLower(strings.TrimPrefix(name, "BV_"))
+	for _, prefix := range []string{"theme_colors_", "keys_", "teams_"} {
+		if strings.HasPrefix(rest, prefix) {
+			return strings.ReplaceAll(strings.TrimSuffix(prefix, "_"), "_", ".") + "." + strings.TrimPrefix(rest, prefix)
+		}
+	}
+	for _, key := range scalarKeys {
+		if strings.ReplaceAll(key, ".", "_") == rest {
+			return key
+		}
+	}
+	return ""
+}
+
+// scalarKeys are the fixed (non-map) settings, in display order
+var scalarKeys = []string{
+	"theme.mode",
+	"view.default",
+	"view.columns",
+	"view.density",
+	"view.card_lines",
+	"view.ambiguous_width",
+	"view.image_preview",
+	"links.issue_url",
+	"user.name",
+	"analysis.force_full",
+	"analysis.full_below_nodes",
+	"impact.pagerank",
+	"impact.betweenness",
+	"impact.blast_radius",
+	"impact.staleness",
+	"impact.priority",
+	"impact.due_date",
+	"wip.open",
+	"wip.in_progress",
+	"wip.blocked",
+	"wip.per_assignee",
+	"time.style",
+	"time.date_format",
+	"time.clock",
+	"time.zone",
+	"watch.mode",
+	"watch.poll_interval",
+	"watch.hash",
+	"fields.columns",
+	"fields.details",
+	"scripts.impact",
+	"scripts.sort",
+}
+
+// Set applies a setting given as text, as from a flag or environment
+// variable. Lists are comma-separated; booleans accept true/false/1/0.
+func (c *Config) Set(key, value string) error {
+	var v any = value
+	switch key {
+	case "view.columns", "fields.columns", "fields.details":
+		v = splitList(value)
+	case "analysis.force_full", "watch.hash":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: expected true or false, got %q", key, value)
+		}
+		v = b
+	case "view.card_lines", "analysis.full_below_nodes", "wip.open", "wip.in_progress", "wip.blocked", "wip.per_assignee":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: expected a number, got %q", key, value)
+		}
+		v = n
+	}
+	if strings.HasPrefix(key, "teams.") {
+		v = splitList(value)
+	}
+	if strings.HasPrefix(key, "templates.") {
+		switch key[strings.LastIndex(key, ".")+1:] {
+		case "labels", "checklist", "fields":
+			v = splitList(value)
+		case "priority":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: expected a number, got %q", key, value)
+			}
+			v = n
+		}
+	}
+	if strings.HasPrefix(key, "wip.assignees.") {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: expected a number, got %q", key, value)
+		}
+		v = n
+	}
+	if c.impactWeight(key) != nil {
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s: expected a number, got %q", key, value)
+		}
+		v = f
+	}
+	return c.set(key, v, "--set")
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(value string) []any {
+	var items []any
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// set validates and stores one value, recording its source
+func (c *Config) set(key string, value any, source string) error {
+	str := func() (string, error) {
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%s: expected a string", key)
+		}
+		return s, nil
+	}
+
+	switch {
+	case key == "theme.mode":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		s = strings.ToLower(s)
+		if s != "auto" && s != "dark" && s != "light" {
+			return fmt.Errorf("theme.mode must be auto, dark or light, not %q", s)
+		}
+		c.Theme.Mode = s
+
+	case strings.HasPrefix(key, "theme.colors."):
+		name := strings.TrimPrefix(key, "theme.colors.")
+		if !contains(ThemeColors, name) {
+			return fmt.Errorf("unknown theme color %q (known: %s)", name, strings.Join(ThemeColors, ", "))
+		}
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !hexColor.MatchString(s) {
+			return fmt.Errorf("%s: expected a color like #BD93F9, got %q", key, s)
+		}
+		c.Theme.Colors[name] = s
+
+	case strings.HasPrefix(key, "keys."):
+		action := strings.TrimPrefix(key, "keys.")
+		if _, ok := KeyActions[action]; !ok {
+			return fmt.Errorf("unknown key action %q", action)
+		}
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if s == "" || strings.ContainsAny(s, " \t") {
+			return fmt.Errorf("%s: invalid key %q", key, s)
+		}
+		c.Keys[action] = s
+
+	case key == "view.default":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !contains(Views, s) {
+			return fmt.Errorf("view.default must be one of %s, not %q", strings.Join(Views, ", "), s)
+		}
+		c.View.Default = s
+
+	case key == "view.columns":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("view.columns: expected a list")
+		}
+		cols := []string{}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || !contains(Columns, s) {
+				return fmt.Errorf("view.columns: unknown column %v (known: %s)", item, strings.Join(Columns, ", "))
+			}
+			cols = append(cols, s)
+		}
+		c.View.Columns = cols
+
+	case key == "fields.columns" || key == "fields.details":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected a list", key)
+		}
+		names := []string{}
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok || strings.TrimSpace(s) == "" {
+				return fmt.Errorf("%s: expected field names, got %v", key, item)
+			}
+			names = append(names, s)
+		}
+		if key == "fields.columns" {
+			c.Fields.Columns = names
+		} else {
+			c.Fields.Details = names
+		}
+
+	case key == "view.density":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !contains(Densities, s) {
+			return fmt.Errorf("view.density must be one of %s, not %q", strings.Join(Densities, ", "), s)
+		}
+		c.View.Density = s
+
+	case key == "view.card_lines":
+		n, ok := value.(int64)
+		if !ok || n < 1 || n > 3 {
+			return fmt.Errorf("view.card_lines: expected 1, 2 or 3")
+		}
+		c.View.CardLines = int(n)
+
+	case key == "view.ambiguous_width":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !contains(AmbiguousWidths, s) {
+			return fmt.Errorf("view.ambiguous_width must be one of %s, not %q", strings.Join(AmbiguousWidths, ", "), s)
+		}
+		c.View.AmbiguousWidth = s
+
+	case key == "view.image_preview":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !contains(ImagePreviews, s) {
+			return fmt.Errorf("view.image_preview must be one of %s, not %q", strings.Join(ImagePreviews, ", "), s)
+		}
+		c.View.ImagePreview = s
+
+	case key == "links.issue_url":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		c.Links.IssueURL = s
+
+	case key == "user.name":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		c.User.Name = strings.TrimPrefix(strings.TrimSpace(s), "@")
+
+	case strings.HasPrefix(key, "user.git_names."):
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(key, "user.git_names.")
+		if name == "" || s == "" {
+			return fmt.Errorf("%s: expected a git user name mapped to an assignee", key)
+		}
+		c.User.GitNames[name] = strings.TrimPrefix(strings.TrimSpace(s), "@")
+
+	case key == "analysis.force_full":
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("analysis.force_full: expected true or false")
+		}
+		c.Analysis.ForceFull = b
+
+	case key == "watch.mode":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if !contains(WatchModes, s) {
+			return fmt.Errorf("watch.mode must be one of %s, not %q", strings.Join(WatchModes, ", "), s)
+		}
+		c.Watch.Mode = s
+
+	case key == "watch.poll_interval":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 100*time.Millisecond {
+			return fmt.Errorf("watch.poll_interval: expected a duration of at least 100ms like \"2s\", got %q", s)
+		}
+		c.Watch.PollInterval = d
+
+	case key == "watch.hash":
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("watch.hash: expected true or false")
+		}
+		c.Watch.Hash = b
+
+	case key == "analysis.full_below_nodes":
+		n, ok := value.(int64)
+		if !ok || n < 0 {
+			return fmt.Errorf("analysis.full_below_nodes: expected a non-negative number")
+		}
+		c.Analysis.FullBelowNodes = int(n)
+
+	case key == "time.style" || key == "time.clock":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if key == "time.style" {
+			if !contains(timefmt.Styles, s) {
+				return fmt.Errorf("time.style must be one of %s, not %q", strings.Join(timefmt.Styles, ", "), s)
+			}
+			c.Time.Style = s
+		} else {
+			if !contains(timefmt.Clocks, s) {
+				return fmt.Errorf("time.clock must be one of %s, not %q", strings.Join(timefmt.Clocks, ", "), s)
+			}
+			c.Time.Clock = s
+		}
+
+	case key == "time.date_format":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if _, err := timefmt.DateLayout(s); err != nil {
+			return fmt.Errorf("time.date_format: %w", err)
+		}
+		c.Time.Date = s
+
+	case key == "time.zone":
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if _, err := timefmt.Location(s); err != nil {
+			return fmt.Errorf("time.zone: %w", err)
+		}
+		c.Time.Zone = s
+
+	case strings.HasPrefix(key, "teams."):
+		name := strings.TrimPrefix(key, "teams.")
+		items, ok := value.([]any)
+		if name == "" || !ok {
+			return fmt.Errorf("%s: expected a list of assignees", key)
+		}
+		members := []string{}
+		for _, item := range items {
+			s, ok := item.(string)
+			if s = strings.TrimPrefix(strings.TrimSpace(s), "@"); !ok || s == "" {
+				return fmt.Errorf("%s: expected a list of assignees, got %v", key, item)
+			}
+			members = append(members, s)
+		}
+		c.Teams[name] = members
+
+	case strings.HasPrefix(key, "wip."):
+		n, ok := value.(int64)
+		if !ok || n < 0 {
+			return fmt.Errorf("%s: expected a non-negative number (0 for no limit)", key)
+		}
+		switch name := strings.TrimPrefix(key, "wip."); {
+		case name == "per_assignee":
+			c.WIP.PerAssignee = int(n)
+		case strings.HasPrefix(name, "assignees."):
+			if c.WIP.Assignees == nil {
+				c.WIP.Assignees = map[string]int{}
+			}
+			c.WIP.Assignees[strings.TrimPrefix(name, "assignees.")] = int(n)
+		case contains(wipStatusNames(), name):
+			if n == 0 {
+				delete(c.WIP.Status, model.Status(name)) // 0 is no limit
+				break
+			}
+			if c.WIP.Status == nil {
+				c.WIP.Status = map[model.Status]int{}
+			}
+			c.WIP.Status[model.Status(name)] = int(n)
+		default:
+			return fmt.Errorf("unknown setting %q", key)
+		}
+
+	case c.impactWeight(key) != nil:
+		var f float64
+		switch n := value.(type) {
+		case int64:
+			f = float64(n)
+		case float64:
+			f = n
+		default:
+			return fmt.Errorf("%s: expected a number", key)
+		}
+		if f < 0 {
+			return fmt.Errorf("%s: weights can't be negative", key)
+		}
+		*c.impactWeight(key) = f
+
+	case key == "scripts.impact" || key == "scripts.sort" || strings.HasPrefix(key, "scripts.columns."):
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		if _, err := script.Compile(s); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		switch key {
+		case "scripts.impact":
+			c.Scripts.Impact = s
+		case "scripts.sort":
+			c.Scripts.Sort = s
+		default:
+			c.setScriptColumn(strings.TrimPrefix(key, "scripts.columns."), s)
+		}
+
+	case strings.HasPrefix(key, "plugins."):
+		if err := c.setPlugin(key, value); err != nil {
+			return err
+		}
+
+	case strings.HasPrefix(key, "templates."):
+		if err := c.setTemplate(key, value); err != nil {
+			return err
+		}
+
+	case strings.HasPrefix(key, "dependency_types."):
+		name := model.DependencyType(strings.TrimPrefix(key, "dependency_types."))
+		if name == "" || name.IsBuiltin() {
+			return fmt.Errorf("%s: %q is not a custom dependency type", key, name)
+		}
+		s, err := str()
+		if err != nil {
+			return err
+		}
+		switch s {
+		case "blocking":
+			c.DependencyTypes[name] = true
+		case "non-blocking":
+			c.DependencyTypes[name] = false
+		default:
+			return fmt.Errorf("%s: expected blocking or non-blocking, got %q", key, s)
+		}
+
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	c.sources[key] = source
+	return nil
+}
+
+// setScriptColumn adds a computed column, or replaces the expression of one
+// already defined so it keeps its place
+func (c *Config) setScriptColumn(name, expr string) {
+	for i := range c.Scripts.Columns {
+		if c.Scripts.Columns[i].Name == name {
+			c.Scripts.Columns[i].Expr = expr
+			return
+		}
+	}
+	c.Scripts.Columns = append(c.Scripts.Columns, ScriptColumn{Name: name, Expr: expr})
+}
+
+// ScriptEngine compiles the configured scripts, or returns nil when there
+// are none
+func (c *Config) ScriptEngine() *script.Engine {
+	compile := func(src string) *script.Program {
+		if src == "" {
+			return nil
+		}
+		p, _ := script.Compile(src) // Checked when the setting was stored
+		return p
+	}
+	e := &script.Engine{Impact: compile(c.Scripts.Impact), Sort: compile(c.Scripts.Sort)}
+	for _, col := range c.Scripts.Columns {
+		e.Columns = append(e.Columns, script.Column{Name: col.Name, Program: compile(col.Expr)})
+	}
+	if e.Empty() {
+		return nil
+	}
+	return e
+}
+
+// setPlugin stores one field of a [plugins.<name>] table
+func (c *Config) setPlugin(key string, value any) error {
+	rest := strings.TrimPrefix(key, "plugins.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return fmt.Errorf("unknown setting %q (plugins are [plugins.<name>] tables)", key)
+	}
+	name, field := rest[:dot], rest[dot+1:]
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s: expected a string", key)
+	}
+	p := c.Plugins[name]
+	if p == nil {
+		p = &PluginConfig{Output: "toast", Timeout: DefaultPluginTimeout}
+	}
+	switch field {
+	case "key":
+		if s == "" || strings.ContainsAny(s, " \t") {
+			return fmt.Errorf("%s: invalid key %q", key, s)
+		}
+		p.Key = s
+	case "command":
+		p.Command = s
+	case "output":
+		if !contains(PluginOutputs, s) {
+			return fmt.Errorf("%s must be one of %s, not %q", key, strings.Join(PluginOutputs, ", "), s)
+		}
+		p.Output = s
+	case "timeout":
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s: expected a duration like \"30s\", got %q", key, s)
+		}
+		p.Timeout = d
+	default:
+		return fmt.Errorf("unknown plugin setting %q (known: key, command, output, timeout)", field)
+	}
+	c.Plugins[name] = p
+	return nil
+}
+
+// setTemplate stores one field of a [templates.<name>] table
+func (c *Config) setTemplate(key string, value any) error {
+	rest := strings.TrimPrefix(key, "templates.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return fmt.Errorf("unknown setting %q (templates are [templates.<name>] tables)", key)
+	}
+	name, field := rest[:dot], rest[dot+1:]
+	list := func() ([]string, error) {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a list", key)
+		}
+		out := []string{}
+		for _, item := range items {
+			s, ok := item.(string)
+			if s = strings.TrimSpace(s); !ok || s == "" {
+				return nil, fmt.Errorf("%s: expected a list of strings, got %v", key, item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	}
+	tmpl := c.Templates[name]
+	if tmpl == nil {
+		tmpl = &TemplateConfig{Priority: 2}
+	}
+	switch field {
+	case "title", "type", "assignee", "description":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", key)
+		}
+		switch field {
+		case "title":
+			tmpl.Title = s
+		case "type":
+			if s != "" && !model.IssueType(s).IsValid() {
+				return fmt.Errorf("%s must be one of bug, feature, task, epic, chore, not %q", key, s)
+			}
+			tmpl.Type = s
+		case "assignee":
+			tmpl.Assignee = strings.TrimPrefix(strings.TrimSpace(s), "@")
+		default:
+			tmpl.Description = s
+		}
+	case "priority":
+		n, ok := value.(int64)
+		if !ok || n < 0 || n > 4 {
+			return fmt.Errorf("%s: expected a priority from 0 to 4", key)
+		}
+		tmpl.Priority = int(n)
+	case "labels", "checklist":
+		items, err := list()
+		if err != nil {
+			return err
+		}
+		if field == "labels" {
+			tmpl.Labels = items
+		} else {
+			tmpl.Checklist = items
+		}
+	case "fields":
+		items, err := list()
+		if err != nil {
+			return err
+		}
+		for _, f := range items {
+			if !contains(TemplateFields, f) {
+				return fmt.Errorf("unknown field %q in %s (known: %s)", f, key, strings.Join(TemplateFields, ", "))
+			}
+		}
+		tmpl.Fields = items
+	default:
+		return fmt.Errorf("unknown template setting %q (known: title, type, priority, labels, assignee, description, checklist, fields)", field)
+	}
+	c.Templates[name] = tmpl
+	return nil
+}
+
+// wipStatusNames returns the statuses a WIP limit can be set on
+func wipStatusNames() []string {
+	names := make([]string, len(analysis.WIPStatuses))
+	for i, s := range analysis.WIPStatuses {
+		names[i] = string(s)
+	}
+	return names
+}
+
+// impactWeight returns the field behind an impact.* key, or nil
+func (c *Config) impactWeight(key string) *float64 {
+	switch key {
+	case "impact.pagerank":
+		return &c.Impact.PageRank
+	case "impact.betweenness":
+		return &c.Impact.Betweenness
+	case "impact.blast_radius":
+		return &c.Impact.BlockerRatio
+	case "impact.staleness":
+		return &c.Impact.Staleness
+	case "impact.priority":
+		return &c.Impact.PriorityBoost
+	case "impact.due_date":
+		return &c.Impact.DueDate
+	}
+	return nil
+}
+
+// Validate checks the settings that span several keys: the impact weights
+// can't all be zero, nobody may be in two teams, every plugin needs a
+// command and a key, and no plugin may share a key with another plugin or a
+// built-in shortcut
+func (c *Config) Validate() error {
+	if err := c.Impact.Validate(); err != nil {
+		return fmt.Errorf("[impact]: %w", err)
+	}
+	teamOf := make(map[string]string)
+	for _, team := range c.Teams.Names() {
+		for _, member := range c.Teams[team] {
+			if other, ok := teamOf[member]; ok && other != team {
+				return fmt.Errorf("teams %s and %s both include %s", other, team, member)
+			}
+			teamOf[member] = team
+		}
+	}
+	byKey := make(map[string]string, len(c.Plugins))
+	for _, name := range c.PluginNames() {
+		p := c.Plugins[name]
+		if strings.TrimSpace(p.Command) == "" {
+			return fmt.Errorf("plugin %s: command is required", name)
+		}
+		if p.Key == "" {
+			return fmt.Errorf("plugin %s: key is required", name)
+		}
+		if other, ok := byKey[p.Key]; ok {
+			return fmt.Errorf("plugins %s and %s are both bound to %q", other, name, p.Key)
+		}
+		if IsBuiltinKey(p.Key) || c.KeyTranslation()[p.Key] != "" {
+			return fmt.Errorf("plugin %s: %q is a built-in key; bind plugins to a free ctrl+ or alt+ key", name, p.Key)
+		}
+		byKey[p.Key] = name
+	}
+	return nil
+}
+
+// PluginNames returns the configured plugins' names, sorted
+func (c *Config) PluginNames() []string {
+	names := make([]string, 0, len(c.Plugins))
+	for name := range c.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TemplateNames returns the configured templates' names, sorted
+func (c *Config) TemplateNames() []string {
+	names := make([]string, 0, len(c.Templates))
+	for name := range c.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// KeyTranslation maps each rebound key to the default key of its action,
+// which is what the TUI handles. An action's default key keeps working
+// unless another action was bound to it.
+func (c *Config) KeyTranslation() map[string]string {
+	if len(c.Keys) == 0 {
+		return nil
+	}
+	t := make(map[string]string, len(c.Keys))
+	for action, key := range c.Keys {
+		if def := KeyActions[action]; key != def {
+			t[key] = def
+		}
+	}
+	return t
+}
+
+// Source returns where key's value came from: a file path, "$BV_..."
+// for the environment, "--set" for flags, or "default"
+func (c *Config) Source(key string) string {
+	if s, ok := c.sources[key]; ok {
+		return s
+	}
+	return "default"
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
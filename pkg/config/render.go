@@ -0,0 +1,404 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Write prints the effective configuration as TOML, noting where each
+// value that isn't a default came from. The output is a valid config file.
+func (c *Config) Write(w io.Writer) error {
+	var sb strings.Builder
+	line := func(key, name, value string) {
+		entry := fmt.Sprintf("%s = %s", name, value)
+		if src := c.Source(key); src != "default" {
+			entry = fmt.Sprintf("%-40s # %s", entry, src)
+		}
+		sb.WriteString(entry + "\n")
+	}
+
+	sb.WriteString("[theme]\n")
+	line("theme.mode", "mode", strconv.Quote(c.Theme.Mode))
+	sb.WriteString("\n[theme.colors]\n")
+	for _, name := range ThemeColors {
+		if color, ok := c.Theme.Colors[name]; ok {
+			line("theme.colors."+name, name, strconv.Quote(color))
+		}
+	}
+
+	sb.WriteString("\n[keys]\n")
+	actions := make([]string, 0, len(KeyActions))
+	for action := range KeyActions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		key, ok := c.Keys[action]
+		if !ok {
+			key = KeyActions[action]
+		}
+		line("keys."+action, action, strconv.Quote(key))
+	}
+
+	sb.WriteString("\n[view]\n")
+	line("view.default", "default", strconv.Quote(c.View.Default))
+	if c.View.Columns == nil {
+		sb.WriteString("# columns = all that fit\n")
+	} else {
+		quoted := make([]string, len(c.View.Columns))
+		for i, col := range c.View.Columns {
+			quoted[i] = strconv.Quote(col)
+		}
+		line("view.columns", "columns", "["+strings.Join(quoted, ", ")+"]")
+	}
+	line("view.density", "density", strconv.Quote(c.View.Density))
+	line("view.card_lines", "card_lines", strconv.Itoa(c.View.CardLines))
+	line("view.ambiguous_width", "ambiguous_width", strconv.Quote(c.View.AmbiguousWidth))
+	line("view.image_preview", "image_preview", strconv.Quote(c.View.ImagePreview))
+
+	sb.WriteString("\n[links]\n")
+	if c.Links.IssueURL == "" {
+		sb.WriteString("# issue_url not set\n")
+	} else {
+		line("links.issue_url", "issue_url", strconv.Quote(c.Links.IssueURL))
+	}
+
+	sb.WriteString("\n[user]\n")
+	if c.User.Name == "" {
+		sb.WriteString("# name = from --me, $BD_ACTOR, git_names or $USER\n")
+	} else {
+		line("user.name", "name", strconv.Quote(c.User.Name))
+	}
+	if len(c.User.GitNames) > 0 {
+		sb.WriteString("\n[user.git_names]\n")
+		names := make([]string, 0, len(c.User.GitNames))
+		for name := range c.User.GitNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			line("user.git_names."+name, tomlKey(name), strconv.Quote(c.User.GitNames[name]))
+		}
+	}
+
+	sb.WriteString("\n[analysis]\n")
+	line("analysis.force_full", "force_full", strconv.FormatBool(c.Analysis.ForceFull))
+	line("analysis.full_below_nodes", "full_below_nodes", strconv.Itoa(c.Analysis.FullBelowNodes))
+
+	sb.WriteString("\n[impact]\n")
+	for _, name := range []string{"pagerank", "betweenness", "blast_radius", "staleness", "priority", "due_date"} {
+		key := "impact." + name
+		line(key, name, strconv.FormatFloat(*c.impactWeight(key), 'f', -1, 64))
+	}
+
+	sb.WriteString("\n[wip]\n")
+	for _, name := range wipStatusNames() {
+		line("wip."+name, name, strconv.Itoa(c.WIP.Status[model.Status(name)]))
+	}
+	line("wip.per_assignee", "per_assignee", strconv.Itoa(c.WIP.PerAssignee))
+	if len(c.WIP.Assignees) > 0 {
+		sb.WriteString("\n[wip.assignees]\n")
+		names := make([]string, 0, len(c.WIP.Assignees))
+		for name := range c.WIP.Assignees {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			line("wip.assignees."+name, tomlKey(name), strconv.Itoa(c.WIP.Assignees[name]))
+		}
+	}
+
+	sb.WriteString("\n[time]\n")
+	line("time.style", "style", strconv.Quote(c.Time.Style))
+	line("time.date_format", "date_format", strconv.Quote(c.Time.Date))
+	line("time.clock", "clock", strconv.Quote(c.Time.Clock))
+	line("time.zone", "zone", strconv.Quote(c.Time.Zone))
+
+	sb.WriteString("\n[watch]\n")
+	line("watch.mode", "mode", strconv.Quote(c.Watch.Mode))
+	line("watch.poll_interval", "poll_interval", strconv.Quote(c.Watch.PollInterval.String()))
+	line("watch.hash", "hash", strconv.FormatBool(c.Watch.Hash))
+
+	sb.WriteString("\n[fields]\n")
+	for _, f := range []struct {
+		name  string
+		names []string
+	}{{"columns", c.Fields.Columns}, {"details", c.Fields.Details}} {
+		if len(f.names) == 0 {
+			sb.WriteString("# " + f.name + " = none\n")
+			continue
+		}
+		quoted := make([]string, len(f.names))
+		for i, name := range f.names {
+			quoted[i] = strconv.Quote(name)
+		}
+		line("fields."+f.name, f.name, "["+strings.Join(quoted, ", ")+"]")
+	}
+
+	if len(c.Teams) > 0 {
+		sb.WriteString("\n[teams]\n")
+		for _, name := range c.Teams.Names() {
+			quoted := make([]string, len(c.Teams[name]))
+			for i, member := range c.Teams[name] {
+				quoted[i] = strconv.Quote(member)
+			}
+			line("teams."+name, tomlKey(name), "["+strings.Join(quoted, ", ")+"]")
+		}
+	}
+
+	if c.Scripts.Impact != "" || c.Scripts.Sort != "" {
+		sb.WriteString("\n[scripts]\n")
+		if c.Scripts.Impact != "" {
+			line("scripts.impact", "impact", strconv.Quote(c.Scripts.Impact))
+		}
+		if c.Scripts.Sort != "" {
+			line("scripts.sort", "sort", strconv.Quote(c.Scripts.Sort))
+		}
+	}
+	if len(c.Scripts.Columns) > 0 {
+		sb.WriteString("\n[scripts.columns]\n")
+		for _, col := range c.Scripts.Columns {
+			line("scripts.columns."+col.Name, col.Name, strconv.Quote(col.Expr))
+		}
+	}
+
+	if len(c.DependencyTypes) > 0 {
+		sb.WriteString("\n[dependency_types]\n")
+		names := make([]string, 0, len(c.DependencyTypes))
+		for name := range c.DependencyTypes {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			kind := "non-blocking"
+			if c.DependencyTypes[model.DependencyType(name)] {
+				kind = "blocking"
+			}
+			line("dependency_types."+name, tomlKey(name), strconv.Quote(kind))
+		}
+	}
+
+	for _, name := range c.PluginNames() {
+		p := c.Plugins[name]
+		prefix := "plugins." + name + "."
+		sb.WriteString("\n[plugins." + name + "]\n")
+		line(prefix+"key", "key", strconv.Quote(p.Key))
+		line(prefix+"command", "command", strconv.Quote(p.Command))
+		line(prefix+"output", "output", strconv.Quote(p.Output))
+		line(prefix+"timeout", "timeout", strconv.Quote(p.Timeout.String()))
+	}
+
+	quoteAll := func(items []string) string {
+		quoted := make([]string, len(items))
+		for i, item := range items {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	}
+	for _, name := range c.TemplateNames() {
+		tmpl := c.Templates[name]
+		prefix := "templates." + name + "."
+		sb.WriteString("\n[templates." + name + "]\n")
+		line(prefix+"title", "title", strconv.Quote(tmpl.Title))
+		line(prefix+"type", "type", strconv.Quote(tmpl.Type))
+		line(prefix+"priority", "priority", strconv.Itoa(tmpl.Priority))
+		if len(tmpl.Labels) > 0 {
+			line(prefix+"labels", "labels", quoteAll(tmpl.Labels))
+		}
+		line(prefix+"assignee", "assignee", strconv.Quote(tmpl.Assignee))
+		line(prefix+"description", "description", strconv.Quote(tmpl.Description))
+		if len(tmpl.Checklist) > 0 {
+			line(prefix+"checklist", "checklist", quoteAll(tmpl.Checklist))
+		}
+		if tmpl.Fields != nil {
+			line(prefix+"fields", "fields", quoteAll(tmpl.Fields))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Template is the commented starter file written by "bv config init"
+const Template = `# beads_viewer configuration
+#
+# Settings are layered; later layers win:
+#   1. ~/.config/bv/config.toml (or $XDG_CONFIG_HOME/bv/config.toml)
+#   2. .beads_viewer.toml at the project root
+#   3. BV_<SECTION>_<KEY> environment variables, e.g. BV_VIEW_DEFAULT=board
+#   4. bv --set section.key=value
+# Run "bv config show" to see the result and where each value came from.
+
+[theme]
+# auto follows the terminal background; dark or light forces a palette
+mode = "auto"
+
+[theme.colors]
+# Override palette entries with hex colors:
+# primary, secondary, subtext, open, in_progress, blocked, closed,
+# bug, feature, task, epic, chore, border, highlight
+# primary = "#BD93F9"
+
+[keys]
+# Rebind top-level actions. The old key keeps working unless another
+# action takes it. Actions: quit, help, board, graph, insights,
+# actionable, priority_hints, recipes, export, sort, reverse_sort,
+# milestones, burndown, flow, velocity, workload, duplicates, problems,
+# activity, coupling, dsm, risk, schedule, aging, labels, lineage,
+# worklog, timer, focus_mode, mine, archive, sprints, new_tab, close_tab,
+# focus_subgraph, epic_scope, new_issue, comment
+# board = "v"
+
+[view]
+# The view bv opens on: list, board, graph or insights
+default = "list"
+# Optional list columns, shown when the terminal is wide enough:
+# due, age, comments, checklist, assignee, labels
+# columns = ["due", "assignee", "labels"]
+# How tightly the list packs its rows: compact (narrow gutter, no type
+# icons, priority hints or comment counts), comfortable or spacious (wider
+# gutter, a blank line between rows). "d" in the list cycles them.
+density = "comfortable"
+# Lines per list row: 1, or 2 or 3 for cards adding labels, blockers and
+# recent activity (and on 3, the description's first line). "x" cycles.
+card_lines = 1
+# How many columns box drawing, block elements and other East Asian
+# ambiguous characters take: narrow (1), wide (2, as many CJK terminals
+# draw them) or auto, which follows RUNEWIDTH_EASTASIAN and then the locale
+ambiguous_width = "auto"
+# How "v" in the details previews image attachments: kitty, iterm or sixel
+# graphics, off for a list of names, or auto, which detects kitty, iTerm2,
+# WezTerm, Ghostty, foot and mlterm (and stays off inside tmux)
+image_preview = "auto"
+
+[links]
+# Go template for opening an issue in your tracker with "o"
+# issue_url = "https://github.com/owner/repo/issues/{{.ID}}"
+
+[user]
+# Your assignee name: whose issues @ narrows every view to, and whose
+# unblock alerts fire. Unset, bv uses --me, $BD_ACTOR, then git config
+# user.name through git_names, then $USER.
+# name = "alice"
+
+[user.git_names]
+# git config user.name -> assignee name
+# "Alice Liddell" = "alice"
+
+[analysis]
+# Compute every metric regardless of graph size (like --force-full-analysis)
+force_full = false
+# Graphs with fewer issues than this always get full analysis; 0 keeps
+# the built-in size tiers
+full_below_nodes = 0
+
+[impact]
+# Relative weight of each part of the impact score (scaled to sum to 1)
+pagerank = 0.3
+betweenness = 0.3
+# Issues directly depending on this one
+blast_radius = 0.2
+# Days since the last update, maxing out at 30
+staleness = 0.1
+priority = 0.1
+# Nearness of the due date: overdue counts fully, two weeks out not at all
+due_date = 0
+
+[wip]
+# Work-in-progress limits, 0 for none. Columns and people over their limit
+# are flagged on the board and in the workload view, and by "bv lint".
+# Issues per status column (open, in_progress, blocked)
+in_progress = 0
+# Issues in progress per assignee
+per_assignee = 3
+
+[wip.assignees]
+# Per-person overrides of per_assignee
+# alice = 5
+
+[time]
+# How ages show: relative ("3d ago") or absolute (the date)
+style = "relative"
+# iso (2006-01-02), us (01/02/2006), eu (02.01.2006), short (Jan 02),
+# long (Mon Jan 2, 2006) or any Go layout, e.g. "02 Jan 2006"
+date_format = "iso"
+# 24h or 12h
+clock = "24h"
+# The zone times show in: local, utc or an IANA name such as Europe/Berlin
+zone = "local"
+
+[watch]
+# How live reload notices the beads file changing: auto (fsnotify, but
+# polling on NFS, SMB and SSHFS mounts), notify or poll
+mode = "auto"
+# How often to check the file when polling
+poll_interval = "2s"
+# Also compare the file's contents when polling, for mounts whose mtimes
+# are too coarse or cached to notice every write
+hash = false
+
+[fields]
+# Fields other tools add to issues, which bv keeps but doesn't know,
+# named by their JSON key: shown as list columns, or in the issue details
+# columns = ["customer", "story_points"]
+# details = ["customer", "links"]
+
+[teams]
+# Groups of assignees, each in at most one team. The workload view rolls
+# them up (t) and flags issues blocked by another team's work.
+# web = ["alice", "bob"]
+# infra = ["carol"]
+
+[scripts]
+# Expressions in Python syntax, evaluated per issue over its fields and
+# graph metrics (pagerank, betweenness, blockers, dependents, due_days, ...)
+# impact = "0.5 * pagerank_norm + 0.3 * betweenness_norm + 0.2 * (4 - priority) / 4"
+# sort = "due_days if due_days != None else 999"
+
+[scripts.columns]
+# Extra list columns, named by their key
+# hours = "round(estimate / 60, 1) if estimate else None"
+
+[dependency_types]
+# Dependency types beyond blocks, parent-child, related and
+# discovered-from. blocking ones hold up their issue like blocks; the
+# rest show under Related in the details.
+# supersedes = "blocking"
+# duplicates = "non-blocking"
+
+# Plugins run an external command on the selected issue, which they get as
+# JSON on stdin (and as $BV_ISSUE_ID). output is toast (first line in the
+# status bar), pager (all of it, scrollable) or none. The key must be a
+# ctrl+ or alt+ one bv doesn't use, and plugins only load from the user
+# config, never from a project's .beads_viewer.toml.
+# [plugins.branch]
+# key = "ctrl+g"
+# command = "git switch -c \"$BV_ISSUE_ID\""
+# output = "toast"
+# timeout = "30s"
+
+# Templates prefill the new-issue form (n), so new issues follow the team's
+# conventions. With any defined, n first asks which to start from. fields
+# are the ones the form asks for besides the title (type, priority,
+# assignee, labels); the rest take the template's values. The checklist is
+# appended to the description as "- [ ]" items.
+# [templates.bug]
+# title = "Bug: "
+# type = "bug"
+# priority = 1
+# labels = ["triage"]
+# description = "Steps to reproduce:\n\nExpected:\n\nActual:"
+# checklist = ["Regression test", "Changelog entry"]
+# fields = ["priority", "labels"]
+`
+
+// tomlKey returns name as a TOML key, quoted unless it is a bare key
+func tomlKey(name string) string {
+	return toml.Key{name}.String()
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlEntry is one key/value pair, with its table prefix folded into Key
+type tomlEntry struct {
+	Key   string // Dotted, e.g. "theme.colors.primary"
+	Value any    // string, int64, float64, bool, time.Time or []any
+}
+
+// parseTOML reads a TOML document into its settings, in the order they
+// appear. Tables, whether [headers], dotted keys or inline tables, are
+// flattened into dotted keys; arrays of tables are not settings bv has.
+func parseTOML(data string) ([]tomlEntry, error) {
+	var doc map[string]any
+	md, err := toml.Decode(data, &doc)
+	if err != nil {
+		var perr toml.ParseError
+		if errors.As(err, &perr) {
+			return nil, fmt.Errorf("line %d: %s", perr.Position.Line, perr.Message)
+		}
+		return nil, err
+	}
+
+	var entries []tomlEntry
+	for _, key := range md.Keys() {
+		value, ok := lookupTOML(doc, key)
+		if !ok {
+			continue // Inside an array of tables, reported below
+		}
+		switch value.(type) {
+		case map[string]any:
+			continue // Its keys follow
+		case []map[string]any:
+			return nil, fmt.Errorf("%s: arrays of tables are not supported", key)
+		}
+		entries = append(entries, tomlEntry{Key: strings.Join(key, "."), Value: value})
+	}
+	return entries, nil
+}
+
+// lookupTOML returns the value at key in doc, or false when the key passes
+// through something other than a table
+func lookupTOML(doc map[string]any, key toml.Key) (any, bool) {
+	var value any = doc
+	for _, part := range key {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value = table[part]
+	}
+	return value, true
+}
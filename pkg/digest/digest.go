@@ -0,0 +1,247 @@
+// Package digest summarizes what changed in the issues over a period (work
+// that became ready or blocked, new cycles, stale items) for posting to chat
+// or a webhook.
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultStaleDays is how long an open issue can go without updates before
+// the digest calls it stale
+const DefaultStaleDays = 14
+
+// maxListed caps each section of the rendered text; the JSON has everything
+const maxListed = 10
+
+// maxTopBlockers is how many of the open issues holding up the most work the
+// digest names: few enough to act on this week
+const maxTopBlockers = 3
+
+// Item is an issue as it appears in a digest section
+type Item struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Priority  int      `json:"priority"`
+	Assignee  string   `json:"assignee,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // Newly blocked: the open blockers
+	IdleDays  int      `json:"idle_days,omitempty"`  // Stale: days since the last update
+}
+
+// Counts is the state of the issues at the end of the period
+type Counts struct {
+	Open    int `json:"open"`
+	Ready   int `json:"ready"`
+	Blocked int `json:"blocked"`
+}
+
+// Digest is what changed between two states of the issues
+type Digest struct {
+	Project      string     `json:"project"`
+	From         time.Time  `json:"from"`
+	To           time.Time  `json:"to"`
+	FromRevision string     `json:"from_revision,omitempty"`
+	NewlyReady   []Item     `json:"newly_ready"`
+	NewlyBlocked []Item     `json:"newly_blocked"`
+	NewCycles    [][]string `json:"new_cycles"`
+	Opened       []Item     `json:"opened"`
+	Closed       []Item     `json:"closed"`
+	Stale        []Item     `json:"stale"`
+	Counts       Counts     `json:"counts"`
+
+	// TopBlockers are the open issues holding up the most open work at the
+	// end of the period, whatever changed
+	TopBlockers []analysis.TopBlocker `json:"top_blockers"`
+}
+
+// Build compares the issues at the start of the period (from) with the
+// current ones (to). Open issues not updated for staleDays are listed as
+// stale; staleDays <= 0 means DefaultStaleDays.
+func Build(project string, from, to *analysis.Snapshot, staleDays int) *Digest {
+	if staleDays <= 0 {
+		staleDays = DefaultStaleDays
+	}
+	diff := analysis.CompareSnapshots(from, to)
+	d := &Digest{
+		Project:      project,
+		From:         from.Timestamp,
+		To:           to.Timestamp,
+		FromRevision: from.Revision,
+		NewCycles:    diff.NewCycles,
+		TopBlockers:  analysis.BlockerLeaderboard(to.Issues, maxTopBlockers),
+	}
+
+	before := analysis.OpenBlockers(from.Issues)
+	after := analysis.OpenBlockers(to.Issues)
+	beforeStatus := make(map[string]model.Status, len(from.Issues))
+	for _, issue := range from.Issues {
+		beforeStatus[issue.ID] = issue.Status
+	}
+	wasBlocked := func(id string) bool {
+		return beforeStatus[id] == model.StatusBlocked || len(before[id]) > 0
+	}
+
+	for _, issue := range to.Issues {
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		blockers := after[issue.ID]
+		_, existed := beforeStatus[issue.ID]
+		d.Counts.Open++
+		if issue.Status == model.StatusBlocked || len(blockers) > 0 {
+			d.Counts.Blocked++
+			if existed && !wasBlocked(issue.ID) {
+				item := itemFor(issue)
+				item.BlockedBy = blockers
+				d.NewlyBlocked = append(d.NewlyBlocked, item)
+			}
+			continue
+		}
+
+		d.Counts.Ready++
+		// Ready now, and either blocked or closed before (reopened work counts)
+		if existed && (wasBlocked(issue.ID) || beforeStatus[issue.ID] == model.StatusClosed) {
+			d.NewlyReady = append(d.NewlyReady, itemFor(issue))
+		}
+		// Only ready work can be stale: blocked work has a reason to sit still
+		if idle := int(to.Timestamp.Sub(issue.UpdatedAt).Hours() / 24); !issue.UpdatedAt.IsZero() && idle >= staleDays {
+			item := itemFor(issue)
+			item.IdleDays = idle
+			d.Stale = append(d.Stale, item)
+		}
+	}
+
+	for _, issue := range diff.NewIssues {
+		d.Opened = append(d.Opened, itemFor(issue))
+	}
+	for _, issue := range diff.ClosedIssues {
+		d.Closed = append(d.Closed, itemFor(issue))
+	}
+
+	byPriority(d.NewlyReady)
+	byPriority(d.NewlyBlocked)
+	byPriority(d.Opened)
+	byPriority(d.Closed)
+	sort.SliceStable(d.Stale, func(i, j int) bool { return d.Stale[i].IdleDays > d.Stale[j].IdleDays })
+	return d
+}
+
+func itemFor(issue model.Issue) Item {
+	return Item{ID: issue.ID, Title: issue.Title, Priority: issue.Priority, Assignee: issue.Assignee}
+}
+
+func byPriority(items []Item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Priority != items[j].Priority {
+			return items[i].Priority < items[j].Priority
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+// IsEmpty reports whether nothing worth mentioning happened
+func (d *Digest) IsEmpty() bool {
+	return len(d.NewlyReady) == 0 && len(d.NewlyBlocked) == 0 && len(d.NewCycles) == 0 &&
+		len(d.Opened) == 0 && len(d.Closed) == 0 && len(d.Stale) == 0
+}
+
+// style is the markup a rendering uses
+type style struct {
+	bold   func(string) string
+	code   func(string) string
+	bullet string
+}
+
+var (
+	markdownStyle = style{
+		bold:   func(s string) string { return "**" + s + "**" },
+		code:   func(s string) string { return "`" + s + "`" },
+		bullet: "- ",
+	}
+	// Slack's mrkdwn: single asterisks for bold, and a bullet character
+	// because it has no list syntax
+	slackStyle = style{
+		bold:   func(s string) string { return "*" + s + "*" },
+		code:   func(s string) string { return "`" + s + "`" },
+		bullet: "• ",
+	}
+)
+
+// Markdown renders the digest as Markdown
+func (d *Digest) Markdown() string { return d.render(markdownStyle) }
+
+// SlackText renders the digest in Slack's mrkdwn
+func (d *Digest) SlackText() string { return d.render(slackStyle) }
+
+func (d *Digest) render(st style) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s → %s\n", st.bold(d.Project+" digest"), d.From.Format("Jan 2"), d.To.Format("Jan 2"))
+	fmt.Fprintf(&sb, "%d open · %d ready · %d blocked · %d opened · %d closed\n",
+		d.Counts.Open, d.Counts.Ready, d.Counts.Blocked, len(d.Opened), len(d.Closed))
+	if len(d.TopBlockers) > 0 {
+		fmt.Fprintf(&sb, "\n%s\n", st.bold("Unstick first"))
+		for _, b := range d.TopBlockers {
+			line := fmt.Sprintf("%s%s P%d %s", st.bullet, st.code(b.ID), b.Priority, b.Title)
+			if b.Assignee != "" {
+				line += " (@" + b.Assignee + ")"
+			} else {
+				line += " (unassigned)"
+			}
+			fmt.Fprintf(&sb, "%s — holds up %d (%d directly)\n", line, b.Downstream, b.Direct)
+		}
+	}
+	if d.IsEmpty() {
+		sb.WriteString("\nNo changes worth mentioning.\n")
+		return sb.String()
+	}
+
+	section := func(title string, items []Item, extra func(Item) string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s\n", st.bold(fmt.Sprintf("%s (%d)", title, len(items))))
+		for i, item := range items {
+			if i == maxListed {
+				fmt.Fprintf(&sb, "%s…and %d more\n", st.bullet, len(items)-maxListed)
+				break
+			}
+			line := fmt.Sprintf("%s%s P%d %s", st.bullet, st.code(item.ID), item.Priority, item.Title)
+			if item.Assignee != "" {
+				line += " (@" + item.Assignee + ")"
+			}
+			if extra != nil {
+				line += extra(item)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	section("Newly ready", d.NewlyReady, nil)
+	section("Newly blocked", d.NewlyBlocked, func(item Item) string {
+		if len(item.BlockedBy) == 0 {
+			return ""
+		}
+		return " — waiting on " + strings.Join(item.BlockedBy, ", ")
+	})
+	if len(d.NewCycles) > 0 {
+		fmt.Fprintf(&sb, "\n%s\n", st.bold(fmt.Sprintf("New dependency cycles (%d)", len(d.NewCycles))))
+		for _, cycle := range d.NewCycles {
+			if len(cycle) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "%s%s\n", st.bullet, strings.Join(cycle, " → ")+" → "+cycle[0])
+		}
+	}
+	section("Stale", d.Stale, func(item Item) string {
+		return fmt.Sprintf(" — idle %d days", item.IdleDays)
+	})
+	section("Closed", d.Closed, nil)
+	section("Opened", d.Opened, nil)
+	return sb.String()
+}
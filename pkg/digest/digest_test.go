@@ -0,0 +1,154 @@
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func blockedBy(ids ...string) []*model.Dependency {
+	var deps []*model.Dependency
+	for _, id := range ids {
+		deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+	}
+	return deps
+}
+
+func sampleDigest(t *testing.T) *Digest {
+	t.Helper()
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 7)
+	recent := now.Add(-time.Hour)
+
+	from := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "B", Title: "API", Status: model.StatusOpen, Dependencies: blockedBy("A"), UpdatedAt: recent},
+		{ID: "C", Title: "Docs", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "D", Title: "Ops", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "E", Title: "Loop 1", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "F", Title: "Loop 2", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "S", Title: "Forgotten", Status: model.StatusOpen, UpdatedAt: now.AddDate(0, 0, -30)},
+	}
+	to := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusClosed, UpdatedAt: recent},
+		{ID: "B", Title: "API", Priority: 1, Assignee: "alice", Status: model.StatusOpen, Dependencies: blockedBy("A"), UpdatedAt: recent},
+		{ID: "C", Title: "Docs", Status: model.StatusOpen, Dependencies: blockedBy("D"), UpdatedAt: recent},
+		{ID: "D", Title: "Ops", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "E", Title: "Loop 1", Status: model.StatusOpen, Dependencies: blockedBy("F"), UpdatedAt: recent},
+		{ID: "F", Title: "Loop 2", Status: model.StatusOpen, Dependencies: blockedBy("E"), UpdatedAt: recent},
+		{ID: "G", Title: "New idea", Status: model.StatusOpen, UpdatedAt: recent},
+		{ID: "S", Title: "Forgotten", Status: model.StatusOpen, UpdatedAt: now.AddDate(0, 0, -30)},
+	}
+	return Build("proj", analysis.NewSnapshotAt(from, start, "abc123"), analysis.NewSnapshotAt(to, now, ""), 0)
+}
+
+func ids(items []Item) string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.ID)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestBuild(t *testing.T) {
+	d := sampleDigest(t)
+
+	if got := ids(d.NewlyReady); got != "B" {
+		t.Errorf("newly ready: got %q, want B", got)
+	}
+	if got := ids(d.NewlyBlocked); got != "C,E,F" {
+		t.Errorf("newly blocked: got %q, want C,E,F", got)
+	}
+	if len(d.NewlyBlocked) > 0 && strings.Join(d.NewlyBlocked[0].BlockedBy, ",") != "D" {
+		t.Errorf("C should be waiting on D, got %v", d.NewlyBlocked[0].BlockedBy)
+	}
+	if len(d.NewCycles) != 1 {
+		t.Errorf("expected the E/F cycle, got %v", d.NewCycles)
+	}
+	if got := ids(d.Opened); got != "G" {
+		t.Errorf("opened: got %q", got)
+	}
+	if got := ids(d.Closed); got != "A" {
+		t.Errorf("closed: got %q", got)
+	}
+	if len(d.Stale) != 1 || d.Stale[0].ID != "S" || d.Stale[0].IdleDays != 30 {
+		t.Errorf("stale: got %+v", d.Stale)
+	}
+	if d.Counts != (Counts{Open: 7, Ready: 4, Blocked: 3}) {
+		t.Errorf("counts: got %+v", d.Counts)
+	}
+	// D holds up C; E and F hold each other up
+	if len(d.TopBlockers) != 3 || d.TopBlockers[0].ID != "D" || d.TopBlockers[0].Downstream != 1 {
+		t.Errorf("top blockers: got %+v", d.TopBlockers)
+	}
+}
+
+func TestRender(t *testing.T) {
+	d := sampleDigest(t)
+
+	md := d.Markdown()
+	for _, want := range []string{"**proj digest** Mar 1 → Mar 8", "**Newly ready (1)**", "- `B` P1 API (@alice)",
+		"- `C` P0 Docs — waiting on D", "New dependency cycles (1)", "idle 30 days",
+		"**Unstick first**", "- `D` P0 Ops (unassigned) — holds up 1 (1 directly)"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	slack := d.SlackText()
+	if !strings.Contains(slack, "*Newly ready (1)*") || !strings.Contains(slack, "• `B`") || strings.Contains(slack, "**") {
+		t.Errorf("slack text should use mrkdwn:\n%s", slack)
+	}
+
+	empty := Build("proj", analysis.NewSnapshot(nil), analysis.NewSnapshot(nil), 0)
+	if !empty.IsEmpty() || !strings.Contains(empty.Markdown(), "No changes worth mentioning") {
+		t.Errorf("empty digest: %s", empty.Markdown())
+	}
+}
+
+func TestPost(t *testing.T) {
+	d := sampleDigest(t)
+	var got map[string]any
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = nil
+		json.Unmarshal(body, &got)
+		w.WriteHeader(status)
+		io.WriteString(w, "no_team")
+	}))
+	defer srv.Close()
+
+	if err := d.Post(context.Background(), srv.URL, ""); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if got["project"] != "proj" || !strings.Contains(got["text"].(string), "Newly ready") {
+		t.Errorf("generic webhooks should get the JSON digest with text, got %v", got)
+	}
+
+	if err := d.Post(context.Background(), srv.URL, FormatSlack); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if _, ok := got["project"]; ok || !strings.Contains(got["text"].(string), "*Newly ready (1)*") {
+		t.Errorf("slack payload should be just mrkdwn text, got %v", got)
+	}
+
+	status = http.StatusForbidden
+	if err := d.Post(context.Background(), srv.URL, ""); err == nil || !strings.Contains(err.Error(), "no_team") {
+		t.Errorf("expected the webhook's error, got %v", err)
+	}
+
+	if DetectFormat("https://hooks.slack.com/services/T/B/X") != FormatSlack || DetectFormat(srv.URL) != FormatJSON {
+		t.Error("format detection")
+	}
+	if _, err := d.Payload("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,82 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Payload formats for Post
+const (
+	FormatSlack = "slack" // {"text": mrkdwn}, for Slack and compatible incoming webhooks
+	FormatJSON  = "json"  // The Digest itself, plus a Markdown "text" rendering
+)
+
+// postTimeout bounds a webhook call so a hung endpoint can't stall cron jobs
+const postTimeout = 15 * time.Second
+
+// DetectFormat picks the payload format for a webhook URL: Slack for Slack's
+// webhook host, JSON for anything else
+func DetectFormat(webhookURL string) string {
+	if u, err := url.Parse(webhookURL); err == nil && strings.EqualFold(u.Hostname(), "hooks.slack.com") {
+		return FormatSlack
+	}
+	return FormatJSON
+}
+
+// Payload encodes the digest for posting in the given format
+func (d *Digest) Payload(format string) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(struct {
+			Text string `json:"text"`
+		}{d.SlackText()})
+	case FormatJSON:
+		return json.Marshal(struct {
+			*Digest
+			Text string `json:"text"`
+		}{d, d.Markdown()})
+	default:
+		return nil, fmt.Errorf("unknown post format %q (want %s or %s)", format, FormatSlack, FormatJSON)
+	}
+}
+
+// Post sends the digest to webhookURL. An empty format is detected from the URL.
+func (d *Digest) Post(ctx context.Context, webhookURL, format string) error {
+	if format == "" {
+		format = DetectFormat(webhookURL)
+	}
+	body, err := d.Payload(format)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, postTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The URL usually embeds a secret token, so keep it out of the error
+		if uerr, ok := err.(*url.Error); ok {
+			err = uerr.Err
+		}
+		return fmt.Errorf("posting digest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
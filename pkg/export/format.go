@@ -0,0 +1,190 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+)
+
+// FormatRow is what a --format template sees for each issue. The flattened
+// fields cover the common cases; Issue holds everything else.
+type FormatRow struct {
+	ID, Title, Status, Type, Assignee string
+	Priority                          int
+	Labels                            []string
+	Impact                            float64 // Critical path depth
+	PageRank                          float64
+	Betweenness                       float64
+	Blockers                          []string // Open issues blocking this one
+	Dependents                        int      // Issues that depend on this one
+	Ready                             bool     // Open with no open blockers
+	Created, Updated                  time.Time
+	Due                               *time.Time
+	Issue                             model.Issue
+}
+
+// BuildFormatRows pairs each issue with its graph metrics, keeping order
+func BuildFormatRows(issues []model.Issue, config *analysis.AnalysisConfig) []FormatRow {
+	analyzer := analysis.NewAnalyzer(issues)
+	if config != nil {
+		analyzer.SetConfig(config)
+	}
+	stats := analyzer.Analyze()
+
+	ready := make(map[string]bool)
+	for _, issue := range analyzer.GetActionableIssues() {
+		ready[issue.ID] = true
+	}
+	dependents := make(map[string]int)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID != "" {
+				dependents[dep.DependsOnID]++
+			}
+		}
+	}
+
+	rows := make([]FormatRow, 0, len(issues))
+	for _, issue := range issues {
+		row := FormatRow{
+			ID:          issue.ID,
+			Title:       issue.Title,
+			Status:      string(issue.Status),
+			Type:        string(issue.IssueType),
+			Assignee:    issue.Assignee,
+			Priority:    issue.Priority,
+			Labels:      issue.Labels,
+			Impact:      stats.GetCriticalPathScore(issue.ID),
+			PageRank:    stats.GetPageRankScore(issue.ID),
+			Betweenness: stats.GetBetweennessScore(issue.ID),
+			Dependents:  dependents[issue.ID],
+			Ready:       ready[issue.ID],
+			Created:     issue.CreatedAt,
+			Updated:     issue.UpdatedAt,
+			Due:         issue.DueDate,
+			Issue:       issue,
+		}
+		if issue.Status != model.StatusClosed {
+			row.Blockers = analyzer.GetOpenBlockers(issue.ID)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// formatFuncs are the helpers available to --format templates, on top of
+// text/template's built-ins (printf, len, index, eq, ...)
+var formatFuncs = template.FuncMap{
+	"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// truncate shortens s to n runes, ending in "…" when it cuts
+	"truncate": func(n int, s string) string {
+		if n <= 0 || utf8.RuneCountInString(s) <= n {
+			return s
+		}
+		r := []rune(s)
+		if n == 1 {
+			return "…"
+		}
+		return string(r[:n-1]) + "…"
+	},
+	// pad left-aligns s in a column of n runes; a negative n right-aligns
+	"pad": func(n int, s string) string {
+		width := n
+		if width < 0 {
+			width = -width
+		}
+		gap := width - utf8.RuneCountInString(s)
+		if gap <= 0 {
+			return s
+		}
+		if n < 0 {
+			return strings.Repeat(" ", gap) + s
+		}
+		return s + strings.Repeat(" ", gap)
+	},
+	// date formats a time or *time.Time in the configured date format
+	// (time.date_format), or with an optional Go layout; zero and nil times
+	// give ""
+	"date": func(t any, layout ...string) (string, error) {
+		var tm time.Time
+		switch v := t.(type) {
+		case time.Time:
+			tm = v
+		case *time.Time:
+			if v != nil {
+				tm = *v
+			}
+		case nil:
+		default:
+			return "", fmt.Errorf("date: unsupported type %T", t)
+		}
+		if tm.IsZero() {
+			return "", nil
+		}
+		if len(layout) > 0 {
+			return tm.Format(layout[0]), nil
+		}
+		return timefmt.Day(tm), nil
+	},
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// default returns def when v is empty (so it pipes: {{.Assignee | default "-"}})
+	"default": func(def string, v any) any {
+		switch x := v.(type) {
+		case nil:
+			return def
+		case string:
+			if x == "" {
+				return def
+			}
+		case []string:
+			if len(x) == 0 {
+				return def
+			}
+		}
+		return v
+	},
+}
+
+// ParseFormat compiles a --format template. Shell-typed escapes \t, \n and
+// \\ are interpreted so '{{.ID}}\t{{.Title}}' works without $'...' quoting.
+func ParseFormat(text string) (*template.Template, error) {
+	text = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n").Replace(text)
+	return template.New("format").Funcs(formatFuncs).Option("missingkey=error").Parse(text)
+}
+
+// WriteFormatted executes tmpl once per item, ending each output with a
+// newline unless the template already supplies one. Items the template
+// renders as nothing are skipped, so {{if}} can act as a filter.
+func WriteFormatted[T any](w io.Writer, tmpl *template.Template, items []T) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return err
+		}
+		if buf.Len() == 0 {
+			continue
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
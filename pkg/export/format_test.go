@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFormatRowsAndTemplate(t *testing.T) {
+	due := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Title: "Root blocker", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, DueDate: &due},
+		{ID: "B", Title: "Waits on A", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeBug,
+			Assignee: "sam", Labels: []string{"api", "urgent"},
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	rows := BuildFormatRows(issues, nil)
+	if len(rows) != 2 || rows[0].ID != "A" || rows[1].ID != "B" {
+		t.Fatalf("rows should keep issue order, got %+v", rows)
+	}
+	if !rows[0].Ready || rows[1].Ready {
+		t.Errorf("only A should be ready: %v %v", rows[0].Ready, rows[1].Ready)
+	}
+	if rows[0].Dependents != 1 || len(rows[1].Blockers) != 1 || rows[1].Blockers[0] != "A" {
+		t.Errorf("unexpected dependents/blockers: %d %v", rows[0].Dependents, rows[1].Blockers)
+	}
+	if rows[0].Impact <= rows[1].Impact {
+		t.Errorf("root blocker should have more impact: %v vs %v", rows[0].Impact, rows[1].Impact)
+	}
+
+	tmpl, err := ParseFormat(`{{.ID}}\t{{upper .Type}}\t{{.Assignee | default "-"}}\t{{join "," .Labels}}\t{{date .Due}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteFormatted(&buf, tmpl, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := "A\tTASK\t-\t\t2025-03-01\nB\tBUG\tsam\tapi,urgent\t\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestFormatHelpers(t *testing.T) {
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{truncate 5 "abcdefgh"}}`, "abcd…\n"},
+		{`{{truncate 10 "short"}}`, "short\n"},
+		{`[{{pad 5 "ab"}}]`, "[ab   ]\n"},
+		{`[{{pad -5 "ab"}}]`, "[   ab]\n"},
+		{`{{json .}}`, "[\"x\",\"y\"]\n"},
+		{`{{len .}}\n`, "2\n"},
+	}
+	for _, tt := range tests {
+		tmpl, err := ParseFormat(tt.tmpl)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tmpl, err)
+		}
+		var buf bytes.Buffer
+		if err := WriteFormatted(&buf, tmpl, [][]string{{"x", "y"}}); err != nil {
+			t.Fatalf("%s: %v", tt.tmpl, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.tmpl, buf.String(), tt.want)
+		}
+	}
+}
+
+func TestWriteFormattedSkipsEmptyAndReportsErrors(t *testing.T) {
+	rows := []FormatRow{{ID: "A", Ready: true}, {ID: "B"}}
+	tmpl, err := ParseFormat(`{{if .Ready}}{{.ID}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteFormatted(&buf, tmpl, rows); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "A\n" {
+		t.Errorf("got %q, want only the ready issue", buf.String())
+	}
+
+	bad, err := ParseFormat(`{{.Missing}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFormatted(&buf, bad, rows); err == nil || !strings.Contains(err.Error(), "Missing") {
+		t.Errorf("expected an error naming the unknown field, got %v", err)
+	}
+	if _, err := ParseFormat(`{{`); err == nil {
+		t.Error("expected a parse error")
+	}
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// graphmlKey declares a node or edge attribute
+type graphmlKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	Name     string `xml:"attr.name,attr"`
+	Type     string `xml:"attr.type,attr"`
+	Default  string `xml:"default,omitempty"`
+	metricFn func(*analysis.GraphStats, string) float64
+}
+
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphmlNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphmlData `xml:"data"`
+}
+
+type graphmlEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphmlData `xml:"data"`
+}
+
+type graphmlGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphmlNode `xml:"node"`
+	Edges       []graphmlEdge `xml:"edge"`
+}
+
+type graphmlDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphmlKey `xml:"key"`
+	Graph   graphmlGraph `xml:"graph"`
+}
+
+// graphmlNodeKeys are the issue attributes every node carries
+var graphmlNodeKeys = []graphmlKey{
+	{ID: "label", For: "node", Name: "label", Type: "string"},
+	{ID: "status", For: "node", Name: "status", Type: "string"},
+	{ID: "priority", For: "node", Name: "priority", Type: "int"},
+	{ID: "type", For: "node", Name: "type", Type: "string"},
+	{ID: "assignee", For: "node", Name: "assignee", Type: "string"},
+	{ID: "labels", For: "node", Name: "labels", Type: "string"},
+}
+
+// graphmlMetricKeys are the graph metrics, present when stats are given
+var graphmlMetricKeys = []graphmlKey{
+	{ID: "pagerank", For: "node", Name: "pagerank", Type: "double", metricFn: (*analysis.GraphStats).GetPageRankScore},
+	{ID: "betweenness", For: "node", Name: "betweenness", Type: "double", metricFn: (*analysis.GraphStats).GetBetweennessScore},
+	{ID: "eigenvector", For: "node", Name: "eigenvector", Type: "double", metricFn: (*analysis.GraphStats).GetEigenvectorScore},
+	{ID: "hub", For: "node", Name: "hub", Type: "double", metricFn: (*analysis.GraphStats).GetHubScore},
+	{ID: "authority", For: "node", Name: "authority", Type: "double", metricFn: (*analysis.GraphStats).GetAuthorityScore},
+	{ID: "closeness", For: "node", Name: "closeness", Type: "double", metricFn: (*analysis.GraphStats).GetClosenessScore},
+	{ID: "critical_path", For: "node", Name: "critical_path", Type: "double", metricFn: (*analysis.GraphStats).GetCriticalPathScore},
+	{ID: "in_degree", For: "node", Name: "in_degree", Type: "int", metricFn: func(s *analysis.GraphStats, id string) float64 { return float64(s.InDegree[id]) }},
+	{ID: "out_degree", For: "node", Name: "out_degree", Type: "int", metricFn: func(s *analysis.GraphStats, id string) float64 { return float64(s.OutDegree[id]) }},
+}
+
+// graphmlEdgeKeys describe each dependency
+var graphmlEdgeKeys = []graphmlKey{
+	{ID: "dep_type", For: "edge", Name: "type", Type: "string"},
+	{ID: "blocking", For: "edge", Name: "blocking", Type: "boolean", Default: "false"},
+}
+
+// WriteGraphML writes the dependency graph as GraphML for Gephi, yEd and
+// other graph tools. Nodes are issues with their fields and, when stats is
+// non-nil, their graph metrics; edges run from an issue to the issue it
+// depends on and carry the dependency type. Dependencies on issues that
+// aren't in the list are left out.
+func WriteGraphML(w io.Writer, issues []model.Issue, stats *analysis.GraphStats) error {
+	doc := graphmlDoc{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphmlGraph{ID: "beads", EdgeDefault: "directed"},
+	}
+	doc.Keys = append(doc.Keys, graphmlNodeKeys...)
+	if stats != nil {
+		doc.Keys = append(doc.Keys, graphmlMetricKeys...)
+	}
+	doc.Keys = append(doc.Keys, graphmlEdgeKeys...)
+
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+
+	for _, issue := range issues {
+		node := graphmlNode{ID: issue.ID, Data: []graphmlData{
+			{"label", issue.Title},
+			{"status", string(issue.Status)},
+			{"priority", strconv.Itoa(issue.Priority)},
+			{"type", string(issue.IssueType)},
+		}}
+		if issue.Assignee != "" {
+			node.Data = append(node.Data, graphmlData{"assignee", issue.Assignee})
+		}
+		if len(issue.Labels) > 0 {
+			node.Data = append(node.Data, graphmlData{"labels", strings.Join(issue.Labels, ",")})
+		}
+		if stats != nil {
+			for _, k := range graphmlMetricKeys {
+				node.Data = append(node.Data, graphmlData{k.ID, strconv.FormatFloat(k.metricFn(stats, issue.ID), 'g', -1, 64)})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !known[dep.DependsOnID] || dep.DependsOnID == issue.ID {
+				continue
+			}
+			depType := dep.Type
+			if depType == "" {
+				depType = model.DepBlocks
+			}
+			doc.Graph.Edges = append(doc.Graph.Edges, graphmlEdge{
+				ID:     fmt.Sprintf("e%d", len(doc.Graph.Edges)),
+				Source: issue.ID,
+				Target: dep.DependsOnID,
+				Data: []graphmlData{
+					{"dep_type", string(depType)},
+					{"blocking", strconv.FormatBool(depType.IsBlocking())},
+				},
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// SaveGraphMLToFile writes the GraphML export to path
+func SaveGraphMLToFile(issues []model.Issue, stats *analysis.GraphStats, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteGraphML(f, issues, stats); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
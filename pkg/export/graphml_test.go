@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWriteGraphML(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema & <types>", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Labels: []string{"db", "core"}},
+		{ID: "B", Title: "API", Status: model.StatusBlocked, IssueType: model.TypeFeature, Assignee: "alice", Dependencies: []*model.Dependency{
+			{DependsOnID: "A", Type: model.DepBlocks},
+			{DependsOnID: "E", Type: model.DepParentChild},
+			{DependsOnID: "missing", Type: model.DepBlocks},
+		}},
+		{ID: "E", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	var sb strings.Builder
+	if err := WriteGraphML(&sb, issues, &stats); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+
+	// Parse it back to check it's well-formed and complete
+	var doc struct {
+		Keys []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"key"`
+		Graph struct {
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Nodes       []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+				Data   []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if doc.Graph.EdgeDefault != "directed" || len(doc.Graph.Nodes) != 3 {
+		t.Fatalf("expected 3 nodes in a directed graph, got %+v", doc.Graph)
+	}
+	if len(doc.Keys) != len(graphmlNodeKeys)+len(graphmlMetricKeys)+len(graphmlEdgeKeys) {
+		t.Errorf("expected metric keys with stats, got %d keys", len(doc.Keys))
+	}
+
+	attrs := map[string]string{}
+	for _, d := range doc.Graph.Nodes[0].Data {
+		attrs[d.Key] = d.Value
+	}
+	if attrs["label"] != "Schema & <types>" || attrs["priority"] != "1" || attrs["labels"] != "db,core" || attrs["in_degree"] != "1" {
+		t.Errorf("unexpected node attributes: %v", attrs)
+	}
+	if _, ok := attrs["pagerank"]; !ok {
+		t.Error("expected pagerank on nodes")
+	}
+
+	if len(doc.Graph.Edges) != 2 {
+		t.Fatalf("expected 2 edges (dangling dependency dropped), got %d", len(doc.Graph.Edges))
+	}
+	e := doc.Graph.Edges[1]
+	if e.Source != "B" || e.Target != "E" || e.Data[0].Value != "parent-child" || e.Data[1].Value != "false" {
+		t.Errorf("unexpected parent-child edge: %+v", e)
+	}
+
+	sb.Reset()
+	if err := WriteGraphML(&sb, issues, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sb.String(), "pagerank") {
+		t.Error("no metric attributes without stats")
+	}
+}
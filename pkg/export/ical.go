@@ -0,0 +1,126 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// icsDate is the iCalendar DATE format used for all-day events
+const icsDate = "20060102"
+
+// WriteICS writes an iCalendar feed of deadlines: an all-day event on the
+// due date of every open issue that has one, and one per open milestone. A
+// milestone's target is the latest due date among its issues or, if none
+// has one, its projected completion, marked tentative. UIDs are stable, so
+// re-importing or subscribing updates events instead of duplicating them.
+func WriteICS(w io.Writer, issues []model.Issue, project string, now time.Time) error {
+	var sb strings.Builder
+	line := func(format string, args ...any) {
+		sb.WriteString(foldICSLine(fmt.Sprintf(format, args...)))
+	}
+	stamp := now.UTC().Format("20060102T150405Z")
+	event := func(uid string, day time.Time, summary, description string, tentative bool) {
+		line("BEGIN:VEVENT")
+		line("UID:%s@%s.beads", uid, icsText(project))
+		line("DTSTAMP:%s", stamp)
+		line("DTSTART;VALUE=DATE:%s", day.Format(icsDate))
+		line("DTEND;VALUE=DATE:%s", day.AddDate(0, 0, 1).Format(icsDate))
+		line("SUMMARY:%s", icsText(summary))
+		if description != "" {
+			line("DESCRIPTION:%s", icsText(description))
+		}
+		if tentative {
+			line("STATUS:TENTATIVE")
+		}
+		line("TRANSP:TRANSPARENT") // Deadlines shouldn't block out the day
+		line("END:VEVENT")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//beads_viewer//bv//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:%s", icsText(project+" deadlines"))
+
+	milestoneDue := make(map[string]time.Time)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.DueDate == nil {
+			continue
+		}
+		if name := issue.MilestoneName(); name != "" && issue.DueDate.After(milestoneDue[name]) {
+			milestoneDue[name] = *issue.DueDate
+		}
+		if issue.Status.IsClosed() {
+			continue
+		}
+		desc := fmt.Sprintf("P%d %s · %s", issue.Priority, issue.IssueType, issue.Status)
+		if issue.Assignee != "" {
+			desc += " · @" + issue.Assignee
+		}
+		if name := issue.MilestoneName(); name != "" {
+			desc += " · milestone " + name
+		}
+		event("issue-"+issue.ID, *issue.DueDate, fmt.Sprintf("Due: %s %s", issue.ID, issue.Title), desc, false)
+	}
+
+	for _, ms := range analysis.ComputeMilestoneStats(issues, now) {
+		if ms.Remaining() == 0 {
+			continue
+		}
+		desc := fmt.Sprintf("%d of %d issues done", ms.Done, ms.Total)
+		if due, ok := milestoneDue[ms.Name]; ok {
+			event("milestone-"+ms.Name, due, "Milestone: "+ms.Name, desc, false)
+		} else if ms.ProjectedCompletion != nil {
+			event("milestone-"+ms.Name, *ms.ProjectedCompletion, "Milestone: "+ms.Name+" (projected)",
+				desc+"; date projected from recent throughput", true)
+		}
+	}
+	line("END:VCALENDAR")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// SaveICSToFile writes the deadline calendar to path
+func SaveICSToFile(issues []model.Issue, project, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteICS(f, issues, project, time.Now()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// icsText escapes a TEXT value (RFC 5545 §3.3.11)
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// foldICSLine terminates a content line with CRLF, folding it so no line
+// exceeds 75 octets without splitting a UTF-8 sequence
+func foldICSLine(s string) string {
+	const limit = 75
+	var sb strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > limit {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += n
+	}
+	sb.WriteString("\r\n")
+	return sb.String()
+}
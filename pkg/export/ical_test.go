@@ -0,0 +1,65 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWriteICS(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		t := time.Date(2025, 6, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	recent := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Ship it; then, celebrate", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Assignee: "alice", Milestone: "v1.0", DueDate: day(10)},
+		{ID: "bv-2", Title: "Docs", Status: model.StatusClosed, IssueType: model.TypeTask, Milestone: "v1.0", DueDate: day(20), ClosedAt: &recent},
+		{ID: "bv-3", Title: "Later", Status: model.StatusOpen, IssueType: model.TypeTask, Milestone: "v2.0"},
+		{ID: "bv-4", Title: "No date", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-5", Title: strings.Repeat("Long title ", 10), Status: model.StatusOpen, IssueType: model.TypeBug, DueDate: day(3)},
+	}
+
+	var sb strings.Builder
+	if err := WriteICS(&sb, issues, "proj", now); err != nil {
+		t.Fatal(err)
+	}
+	ics := sb.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n", "X-WR-CALNAME:proj deadlines\r\n",
+		"UID:issue-bv-1@proj.beads\r\n", "DTSTART;VALUE=DATE:20250610\r\n", "DTEND;VALUE=DATE:20250611\r\n",
+		`SUMMARY:Due: bv-1 Ship it\; then\, celebrate`, "@alice · milestone v1.0",
+		// v1.0's target is its latest due date, even though that issue is closed
+		"UID:milestone-v1.0@proj.beads\r\nDTSTAMP:20250601T120000Z\r\nDTSTART;VALUE=DATE:20250620\r\n",
+		"SUMMARY:Milestone: v2.0 (projected)\r\n", "STATUS:TENTATIVE\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("missing %q in:\n%s", want, ics)
+		}
+	}
+	if strings.Contains(ics, "issue-bv-2@") || strings.Contains(ics, "bv-4") {
+		t.Error("closed issues and issues without due dates get no event")
+	}
+	for _, l := range strings.Split(ics, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line longer than 75 octets: %q", l)
+		}
+	}
+	if !strings.Contains(ics, "\r\n ") {
+		t.Error("expected the long summary to be folded")
+	}
+}
+
+func TestFoldICSLineKeepsRunesWhole(t *testing.T) {
+	folded := foldICSLine("SUMMARY:" + strings.Repeat("é", 80))
+	for _, l := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(l) > 75 || !strings.HasSuffix(l, "é") {
+			t.Errorf("bad fold %q", l)
+		}
+	}
+}
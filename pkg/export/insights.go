@@ -0,0 +1,168 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// InsightsSchemaVersion is the version of the InsightsDocument layout. Fields
+// may be added within a version; renaming or removing one bumps it.
+const InsightsSchemaVersion = 1
+
+// InsightsDocument is the stable JSON form of the graph analysis, for tools
+// that build on bv's metrics. Unlike --robot-insights, which mirrors internal
+// structs, every field here has a fixed snake_case name.
+type InsightsDocument struct {
+	SchemaVersion int       `json:"schema_version"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	IssueCount    int       `json:"issue_count"`
+
+	Graph InsightsGraph `json:"graph"`
+
+	// Metrics maps each metric name to its score for every issue ID.
+	// Skipped metrics are absent; see Skipped for why.
+	Metrics map[string]map[string]float64 `json:"metrics"`
+	Skipped map[string]string             `json:"skipped,omitempty"`
+
+	// Top lists, highest score first
+	Bottlenecks []InsightsItem `json:"bottlenecks"` // Betweenness
+	Keystones   []InsightsItem `json:"keystones"`   // Critical path score
+	Influencers []InsightsItem `json:"influencers"` // Eigenvector
+	Hubs        []InsightsItem `json:"hubs"`
+	Authorities []InsightsItem `json:"authorities"`
+
+	Cycles       [][]string `json:"cycles"`
+	ReadyQueue   []string   `json:"ready_queue"`   // Open, unblocked issues by priority
+	CriticalPath []string   `json:"critical_path"` // Longest chain of open blocking work, first to last
+}
+
+// InsightsGraph describes the dependency graph as a whole
+type InsightsGraph struct {
+	Nodes            int      `json:"nodes"`
+	Edges            int      `json:"edges"`
+	Density          float64  `json:"density"`
+	TopologicalOrder []string `json:"topological_order,omitempty"` // Empty when the graph has cycles
+}
+
+// InsightsItem is one entry in a top list
+type InsightsItem struct {
+	ID    string  `json:"id"`
+	Title string  `json:"title"`
+	Value float64 `json:"value"`
+}
+
+// BuildInsightsDocument analyzes issues and collects the results. Top lists
+// hold at most limit entries; limit <= 0 keeps every issue.
+func BuildInsightsDocument(issues []model.Issue, config *analysis.AnalysisConfig, limit int, now time.Time) InsightsDocument {
+	analyzer := analysis.NewAnalyzer(issues)
+	if config != nil {
+		analyzer.SetConfig(config)
+	}
+	stats := analyzer.Analyze()
+	insights := stats.GenerateInsights(limit)
+
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+	}
+	items := func(in []analysis.InsightItem) []InsightsItem {
+		out := make([]InsightsItem, 0, len(in))
+		for _, item := range in {
+			out = append(out, InsightsItem{ID: item.ID, Title: titles[item.ID], Value: item.Value})
+		}
+		return out
+	}
+
+	doc := InsightsDocument{
+		SchemaVersion: InsightsSchemaVersion,
+		GeneratedAt:   now.UTC(),
+		IssueCount:    len(issues),
+		Graph: InsightsGraph{
+			Nodes:            stats.NodeCount,
+			Edges:            stats.EdgeCount,
+			Density:          stats.Density,
+			TopologicalOrder: stats.TopologicalOrder,
+		},
+		Metrics:      make(map[string]map[string]float64),
+		Skipped:      make(map[string]string),
+		Bottlenecks:  items(insights.Bottlenecks),
+		Keystones:    items(insights.Keystones),
+		Influencers:  items(insights.Influencers),
+		Hubs:         items(insights.Hubs),
+		Authorities:  items(insights.Authorities),
+		Cycles:       insights.Cycles,
+		CriticalPath: analysis.OpenCriticalPath(issues),
+	}
+	if doc.Cycles == nil {
+		doc.Cycles = [][]string{}
+	}
+	if doc.CriticalPath == nil {
+		doc.CriticalPath = []string{}
+	}
+
+	cfg := stats.Config
+	metric := func(name string, computed bool, skipReason string, values map[string]float64) {
+		if !computed {
+			if skipReason == "" {
+				skipReason = "disabled"
+			}
+			doc.Skipped[name] = skipReason
+			return
+		}
+		// Some metrics leave out zero scores; list every issue so maps line up
+		full := make(map[string]float64, len(issues))
+		for _, issue := range issues {
+			full[issue.ID] = values[issue.ID]
+		}
+		doc.Metrics[name] = full
+	}
+	metric("pagerank", cfg.ComputePageRank, cfg.PageRankSkipReason, stats.PageRank())
+	metric("betweenness", cfg.ComputeBetweenness, cfg.BetweennessSkipReason, stats.Betweenness())
+	metric("eigenvector", cfg.ComputeEigenvector, "", stats.Eigenvector())
+	metric("hubs", cfg.ComputeHITS, cfg.HITSSkipReason, stats.Hubs())
+	metric("authorities", cfg.ComputeHITS, cfg.HITSSkipReason, stats.Authorities())
+	metric("closeness", cfg.ComputeCloseness, cfg.ClosenessSkipReason, stats.Closeness())
+	metric("critical_path", cfg.ComputeCriticalPath, "", stats.CriticalPathScore())
+	doc.Metrics["in_degree"] = intMetric(stats.InDegree, issues)
+	doc.Metrics["out_degree"] = intMetric(stats.OutDegree, issues)
+	if !cfg.ComputeCycles {
+		doc.Skipped["cycles"] = cfg.CyclesSkipReason
+		if doc.Skipped["cycles"] == "" {
+			doc.Skipped["cycles"] = "disabled"
+		}
+	}
+
+	ready := analyzer.GetActionableIssues()
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		return ready[i].ID < ready[j].ID
+	})
+	doc.ReadyQueue = make([]string, 0, len(ready))
+	for _, issue := range ready {
+		doc.ReadyQueue = append(doc.ReadyQueue, issue.ID)
+	}
+	return doc
+}
+
+// intMetric converts a degree map, filling in zero for issues without edges
+func intMetric(m map[string]int, issues []model.Issue) map[string]float64 {
+	out := make(map[string]float64, len(issues))
+	for _, issue := range issues {
+		out[issue.ID] = float64(m[issue.ID])
+	}
+	return out
+}
+
+// WriteInsightsJSON writes doc as indented JSON
+func WriteInsightsJSON(w io.Writer, doc InsightsDocument) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,72 @@
+package export
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestInsightsDocument(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Priority: 2},
+		{ID: "B", Title: "API", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("A")},
+		{ID: "C", Title: "UI", Status: model.StatusOpen, Dependencies: blocks("B")},
+		{ID: "D", Title: "Docs", Status: model.StatusOpen, Priority: 1},
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc := BuildInsightsDocument(issues, nil, 2, now)
+
+	if doc.SchemaVersion != InsightsSchemaVersion || doc.IssueCount != 4 || doc.Graph.Edges != 2 {
+		t.Errorf("unexpected header: %+v", doc)
+	}
+	if got := strings.Join(doc.ReadyQueue, ","); got != "D,A" {
+		t.Errorf("ready queue should be unblocked issues by priority, got %s", got)
+	}
+	if got := strings.Join(doc.CriticalPath, ","); got != "A,B,C" {
+		t.Errorf("critical path: got %s", got)
+	}
+	if len(doc.Keystones) != 2 || doc.Keystones[0].ID != "A" || doc.Keystones[0].Title != "Schema" {
+		t.Errorf("keystones should be limited and titled: %+v", doc.Keystones)
+	}
+	for name, values := range doc.Metrics {
+		if len(values) != len(issues) {
+			t.Errorf("metric %s should cover every issue, got %v", name, values)
+		}
+	}
+	if doc.Metrics["in_degree"]["A"] != 1 || doc.Metrics["out_degree"]["C"] != 1 {
+		t.Errorf("degrees: %v %v", doc.Metrics["in_degree"], doc.Metrics["out_degree"])
+	}
+
+	var sb strings.Builder
+	if err := WriteInsightsJSON(&sb, doc); err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(sb.String()), &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"schema_version", "generated_at", "graph", "metrics", "bottlenecks", "keystones",
+		"influencers", "hubs", "authorities", "cycles", "ready_queue", "critical_path"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("JSON missing %q", key)
+		}
+	}
+	if raw["generated_at"] != "2025-01-02T03:04:05Z" {
+		t.Errorf("generated_at: %v", raw["generated_at"])
+	}
+
+	cfg := analysis.DefaultConfig()
+	cfg.ComputeBetweenness = false
+	cfg.BetweennessSkipReason = "too big"
+	doc = BuildInsightsDocument(issues, &cfg, 0, now)
+	if _, ok := doc.Metrics["betweenness"]; ok || doc.Skipped["betweenness"] != "too big" {
+		t.Errorf("skipped metrics should be reported, got %v", doc.Skipped)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"unicode"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 )
 
 // sanitizeMermaidID ensures an ID is valid for Mermaid diagrams.
@@ -188,7 +189,7 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 
 			safeDepID := getSafeID(dep.DependsOnID)
 			linkStyle := "-.->" // Dashed for related
-			if dep.Type == model.DepBlocks {
+			if dep.Type.IsBlocking() {
 				linkStyle = "==>" // Bold for blockers
 			}
 			sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeID, linkStyle, safeDepID))
@@ -215,10 +216,13 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 		if i.Assignee != "" {
 			sb.WriteString(fmt.Sprintf("| **Assignee** | @%s |\n", i.Assignee))
 		}
-		sb.WriteString(fmt.Sprintf("| **Created** | %s |\n", i.CreatedAt.Format("2006-01-02 15:04")))
-		sb.WriteString(fmt.Sprintf("| **Updated** | %s |\n", i.UpdatedAt.Format("2006-01-02 15:04")))
+		sb.WriteString(fmt.Sprintf("| **Created** | %s |\n", timefmt.DateTime(i.CreatedAt)))
+		sb.WriteString(fmt.Sprintf("| **Updated** | %s |\n", timefmt.DateTime(i.UpdatedAt)))
 		if i.ClosedAt != nil {
-			sb.WriteString(fmt.Sprintf("| **Closed** | %s |\n", i.ClosedAt.Format("2006-01-02 15:04")))
+			sb.WriteString(fmt.Sprintf("| **Closed** | %s |\n", timefmt.DateTime(*i.ClosedAt)))
+		}
+		if i.DueDate != nil {
+			sb.WriteString(fmt.Sprintf("| **Due** | %s |\n", timefmt.Day(*i.DueDate)))
 		}
 		if len(i.Labels) > 0 {
 			// Escape pipe characters in labels to avoid breaking markdown table
@@ -257,7 +261,7 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 					continue
 				}
 				icon := "🔗"
-				if dep.Type == model.DepBlocks {
+				if dep.Type.IsBlocking() {
 					icon = "⛔"
 				}
 				sb.WriteString(fmt.Sprintf("- %s **%s**: `%s`\n", icon, dep.Type, dep.DependsOnID))
@@ -273,7 +277,7 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 				}
 				escapedText := strings.ReplaceAll(c.Text, "\n", "\n> ")
 				sb.WriteString(fmt.Sprintf("> **%s** (%s)\n>\n> %s\n\n",
-					c.Author, c.CreatedAt.Format("2006-01-02"), escapedText))
+					c.Author, timefmt.Date(c.CreatedAt), escapedText))
 			}
 		}
 
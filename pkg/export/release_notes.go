@@ -0,0 +1,183 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ReleaseNotesTemplateFile is the per-project template in .bv that replaces
+// DefaultReleaseNotesTemplate
+const ReleaseNotesTemplateFile = "release-notes.tmpl"
+
+// DefaultReleaseNotesTemplate renders the notes as Markdown: one section per
+// issue type, with issues under a sub-heading for their epic
+const DefaultReleaseNotesTemplate = `# {{.Title}}
+
+_{{.Total}} issues closed from {{.From}} to {{.To}}_
+{{range .Groups}}
+## {{.Heading}}
+{{range .Epics}}{{if .ID}}
+### {{.Title}} ({{.ID}})
+{{end}}
+{{range .Issues}}- {{.Title}} ({{.ID}})
+{{end}}{{end}}{{end}}`
+
+// ReleaseNotes is the data a release notes template is executed with
+type ReleaseNotes struct {
+	Title    string
+	From, To string    // The bounds as given: tags, revisions or dates
+	Since    time.Time // Issues closed at or after Since...
+	Until    time.Time // ...and before Until
+	Total    int
+	Groups   []ReleaseGroup
+}
+
+// ReleaseGroup holds the closed issues of one type
+type ReleaseGroup struct {
+	Type    model.IssueType
+	Heading string // e.g. "Features", "Bug Fixes"
+	Epics   []ReleaseEpic
+}
+
+// ReleaseEpic holds the closed issues under one epic. ID and Title are empty
+// for issues that belong to no epic, which come last.
+type ReleaseEpic struct {
+	ID, Title string
+	Issues    []model.Issue
+}
+
+// releaseTypes fixes the section order and headings; other types follow
+var releaseTypes = []struct {
+	typ     model.IssueType
+	heading string
+}{
+	{model.TypeFeature, "Features"},
+	{model.TypeBug, "Bug Fixes"},
+	{model.TypeTask, "Tasks"},
+	{model.TypeChore, "Chores"},
+	{model.TypeEpic, "Epics"},
+}
+
+// BuildReleaseNotes collects the issues closed in [since, until), grouped by
+// type and then by the epic they belong to (via a parent-child dependency)
+func BuildReleaseNotes(issues []model.Issue, since, until time.Time) ReleaseNotes {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	byType := make(map[model.IssueType][]model.Issue)
+	total := 0
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed {
+			continue
+		}
+		closed := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			closed = *issue.ClosedAt
+		}
+		if closed.Before(since) || !closed.Before(until) {
+			continue
+		}
+		byType[issue.IssueType] = append(byType[issue.IssueType], issue)
+		total++
+	}
+
+	notes := ReleaseNotes{Since: since, Until: until, Total: total}
+	addGroup := func(typ model.IssueType, heading string) {
+		if closed := byType[typ]; len(closed) > 0 {
+			notes.Groups = append(notes.Groups, ReleaseGroup{Type: typ, Heading: heading, Epics: groupByEpic(closed, issueMap)})
+		}
+		delete(byType, typ)
+	}
+	for _, rt := range releaseTypes {
+		addGroup(rt.typ, rt.heading)
+	}
+	var others []model.IssueType
+	for typ := range byType {
+		others = append(others, typ)
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	for _, typ := range others {
+		heading := "Other"
+		if typ != "" {
+			heading = strings.ToUpper(string(typ[:1])) + string(typ[1:])
+		}
+		addGroup(typ, heading)
+	}
+	return notes
+}
+
+// groupByEpic splits issues by parent epic, epics in ID order and issues
+// without one last, each list sorted by priority then ID
+func groupByEpic(issues []model.Issue, issueMap map[string]*model.Issue) []ReleaseEpic {
+	index := make(map[string]int)
+	var epics []ReleaseEpic
+	var loose []model.Issue
+	for _, issue := range issues {
+		epicID := ""
+		if issue.IssueType != model.TypeEpic {
+			epicID = releaseEpicOf(issue, issueMap)
+		}
+		if epicID == "" {
+			loose = append(loose, issue)
+			continue
+		}
+		i, ok := index[epicID]
+		if !ok {
+			i = len(epics)
+			index[epicID] = i
+			epics = append(epics, ReleaseEpic{ID: epicID, Title: issueMap[epicID].Title})
+		}
+		epics[i].Issues = append(epics[i].Issues, issue)
+	}
+
+	sort.Slice(epics, func(i, j int) bool { return epics[i].ID < epics[j].ID })
+	if len(loose) > 0 {
+		epics = append(epics, ReleaseEpic{Issues: loose})
+	}
+	for _, e := range epics {
+		sort.SliceStable(e.Issues, func(i, j int) bool {
+			if e.Issues[i].Priority != e.Issues[j].Priority {
+				return e.Issues[i].Priority < e.Issues[j].Priority
+			}
+			return e.Issues[i].ID < e.Issues[j].ID
+		})
+	}
+	return epics
+}
+
+// releaseEpicOf returns the epic an issue is a child of, or ""
+func releaseEpicOf(issue model.Issue, issueMap map[string]*model.Issue) string {
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.Type != model.DepParentChild {
+			continue
+		}
+		if parent, ok := issueMap[dep.DependsOnID]; ok && parent.IssueType == model.TypeEpic {
+			return parent.ID
+		}
+	}
+	return ""
+}
+
+// RenderReleaseNotes executes tmplText (DefaultReleaseNotesTemplate when
+// empty) with notes
+func RenderReleaseNotes(notes ReleaseNotes, tmplText string) (string, error) {
+	if tmplText == "" {
+		tmplText = DefaultReleaseNotesTemplate
+	}
+	tmpl, err := template.New("release-notes").Option("missingkey=error").Parse(tmplText)
+	if err != nil {
+		return "", fmt.Errorf("parsing release notes template: %w", err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, notes); err != nil {
+		return "", fmt.Errorf("rendering release notes: %w", err)
+	}
+	return sb.String(), nil
+}
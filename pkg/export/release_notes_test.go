@@ -0,0 +1,81 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func releaseIssues(start time.Time) []model.Issue {
+	at := func(days int) *time.Time {
+		t := start.AddDate(0, 0, days)
+		return &t
+	}
+	child := []*model.Dependency{{DependsOnID: "E1", Type: model.DepParentChild}}
+	return []model.Issue{
+		{ID: "E1", Title: "Sync", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "F1", Title: "Two-way sync", IssueType: model.TypeFeature, Priority: 2, Status: model.StatusClosed, ClosedAt: at(1), Dependencies: child},
+		{ID: "F2", Title: "Dark mode", IssueType: model.TypeFeature, Status: model.StatusClosed, ClosedAt: at(2)},
+		{ID: "F3", Title: "Conflict view", IssueType: model.TypeFeature, Priority: 1, Status: model.StatusClosed, ClosedAt: at(3), Dependencies: child},
+		{ID: "B1", Title: "Crash on start", IssueType: model.TypeBug, Status: model.StatusClosed, UpdatedAt: *at(4)},
+		{ID: "C1", Title: "Bump deps", IssueType: model.TypeChore, Status: model.StatusClosed, ClosedAt: at(5)},
+		{ID: "Q1", Title: "Question", IssueType: "question", Status: model.StatusClosed, ClosedAt: at(5)},
+		{ID: "OLD", Title: "Before", IssueType: model.TypeBug, Status: model.StatusClosed, ClosedAt: at(-1)},
+		{ID: "LATE", Title: "After", IssueType: model.TypeBug, Status: model.StatusClosed, ClosedAt: at(10)},
+		{ID: "OPEN", Title: "Still open", IssueType: model.TypeBug, Status: model.StatusOpen},
+	}
+}
+
+func TestBuildReleaseNotes(t *testing.T) {
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	notes := BuildReleaseNotes(releaseIssues(start), start, start.AddDate(0, 0, 7))
+
+	if notes.Total != 6 {
+		t.Errorf("expected 6 issues in range, got %d", notes.Total)
+	}
+	var headings []string
+	for _, g := range notes.Groups {
+		headings = append(headings, g.Heading)
+	}
+	if got := strings.Join(headings, ","); got != "Features,Bug Fixes,Chores,Question" {
+		t.Errorf("unexpected groups: %s", got)
+	}
+
+	features := notes.Groups[0].Epics
+	if len(features) != 2 || features[0].ID != "E1" || features[0].Title != "Sync" || features[1].ID != "" {
+		t.Fatalf("features should be under E1 then loose, got %+v", features)
+	}
+	if ids := features[0].Issues; ids[0].ID != "F3" || ids[1].ID != "F1" {
+		t.Errorf("epic issues should be sorted by priority, got %s, %s", ids[0].ID, ids[1].ID)
+	}
+	if notes.Groups[1].Epics[0].Issues[0].ID != "B1" {
+		t.Error("an issue without ClosedAt should fall back to UpdatedAt")
+	}
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	notes := BuildReleaseNotes(releaseIssues(start), start, start.AddDate(0, 0, 7))
+	notes.Title, notes.From, notes.To = "Release notes v1.2.0", "v1.1.0", "v1.2.0"
+
+	text, err := RenderReleaseNotes(notes, "")
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{"# Release notes v1.2.0", "_6 issues closed from v1.1.0 to v1.2.0_",
+		"## Features\n\n### Sync (E1)\n\n- Conflict view (F3)\n- Two-way sync (F1)\n\n- Dark mode (F2)\n", "## Bug Fixes", "- Bump deps (C1)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+
+	custom, err := RenderReleaseNotes(notes, "{{range .Groups}}{{.Type}}:{{range .Epics}}{{len .Issues}}{{end}} {{end}}")
+	if err != nil || custom != "feature:21 bug:1 chore:1 question:1 " {
+		t.Errorf("custom template: %q, %v", custom, err)
+	}
+	if _, err := RenderReleaseNotes(notes, "{{.Nope}}"); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,71 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTableWriters(t *testing.T) {
+	table := Table{
+		Header:  []string{"ID", "Title", "Priority"},
+		Numeric: []bool{false, false, true},
+		Rows: [][]string{
+			{"007", `Say "hi" <now>, & go`, "1"},
+			{"bv-2", "", "n/a"},
+		},
+	}
+
+	var csvOut bytes.Buffer
+	if err := table.WriteCSV(&csvOut); err != nil {
+		t.Fatal(err)
+	}
+	if want := "ID,Title,Priority\n007,\"Say \"\"hi\"\" <now>, & go\",1\nbv-2,,n/a\n"; csvOut.String() != want {
+		t.Errorf("csv:\n%s", csvOut.String())
+	}
+
+	var xlsx bytes.Buffer
+	if err := table.WriteXLSX(&xlsx); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(xlsx.Bytes()), int64(xlsx.Len()))
+	if err != nil {
+		t.Fatalf("xlsx should be a zip: %v", err)
+	}
+	var sheet string
+	names := map[string]bool{}
+	for _, f := range zr.File {
+		names[f.Name] = true
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			data, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(data)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml"} {
+		if !names[name] {
+			t.Errorf("xlsx missing %s", name)
+		}
+	}
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr" s="1"><is><t>ID</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`, // IDs stay text
+		`Say &#34;hi&#34; &lt;now&gt;, &amp; go`,
+		`<c r="C2"><v>1</v></c>`,
+		`<c r="C3" t="inlineStr"><is><t xml:space="preserve">n/a</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s:\n%s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Error("empty cells should be left out")
+	}
+
+	if xlsxColumn(0) != "A" || xlsxColumn(25) != "Z" || xlsxColumn(26) != "AA" || xlsxColumn(701) != "ZZ" {
+		t.Error("column letters")
+	}
+}
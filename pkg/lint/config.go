@@ -0,0 +1,150 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how a rule's findings affect the exit code
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // Fails the run
+	SeverityWarning Severity = "warning" // Reported; fails only with --strict
+	SeverityOff     Severity = "off"     // Not checked
+)
+
+// Rule names, as used in .bv/lint.yaml and the output
+const (
+	RuleCycles           = "cycles"
+	RuleDanglingDep      = "dangling_dependency"
+	RuleSelfDep          = "self_dependency"
+	RuleDuplicateID      = "duplicate_id"
+	RuleUnknownDepType   = "unknown_dependency_type"
+	RuleUnassignedUrgent = "unassigned_priority"
+	RuleMissingEstimate  = "missing_estimate"
+	RuleWIPLimit         = "wip_limit"
+	RuleRedundantDep     = "redundant_dependency"
+)
+
+// Config selects which rules run and how strictly
+type Config struct {
+	// Rules maps a rule name to its severity; rules not listed keep their default
+	Rules map[string]Severity `yaml:"rules" json:"rules"`
+
+	// UnassignedMaxPriority is the lowest priority (highest number) that
+	// unassigned_priority requires an owner for; 1 means P0 and P1
+	UnassignedMaxPriority int `yaml:"unassigned_max_priority" json:"unassigned_max_priority"`
+
+	// EstimateTypes limits missing_estimate to these issue types; empty
+	// means every type except epics
+	EstimateTypes []string `yaml:"estimate_types" json:"estimate_types"`
+
+	// WIP holds the limits wip_limit checks. They come from the [wip]
+	// settings (.beads_viewer.toml), not this file.
+	WIP analysis.WIPLimits `yaml:"-" json:"-"`
+}
+
+// DefaultConfig fails on structural breakage, warns about unowned urgent
+// work, exceeded WIP limits and redundant dependencies, and leaves estimates
+// unchecked, since many projects don't use them
+func DefaultConfig() *Config {
+	return &Config{
+		Rules: map[string]Severity{
+			RuleCycles:           SeverityError,
+			RuleDanglingDep:      SeverityError,
+			RuleSelfDep:          SeverityError,
+			RuleDuplicateID:      SeverityError,
+			RuleUnknownDepType:   SeverityWarning,
+			RuleUnassignedUrgent: SeverityWarning,
+			RuleMissingEstimate:  SeverityOff,
+			RuleWIPLimit:         SeverityWarning,
+			RuleRedundantDep:     SeverityWarning,
+		},
+		UnassignedMaxPriority: 1,
+		WIP:                   analysis.DefaultWIPLimits(),
+	}
+}
+
+// ConfigFilename is the default config filename
+const ConfigFilename = "lint.yaml"
+
+// ConfigPath returns the default config path for a project
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig loads lint configuration from path, layered over the defaults.
+// A missing file yields the defaults.
+func LoadConfig(path string) (*Config, error) {
+	config := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, fmt.Errorf("reading lint config: %w", err)
+	}
+
+	var file struct {
+		Rules                 map[string]Severity `yaml:"rules"`
+		UnassignedMaxPriority *int                `yaml:"unassigned_max_priority"`
+		EstimateTypes         []string            `yaml:"estimate_types"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing lint config: %w", err)
+	}
+	for rule, sev := range file.Rules {
+		config.Rules[rule] = Severity(strings.ToLower(string(sev)))
+	}
+	if file.UnassignedMaxPriority != nil {
+		config.UnassignedMaxPriority = *file.UnassignedMaxPriority
+	}
+	if file.EstimateTypes != nil {
+		config.EstimateTypes = file.EstimateTypes
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lint config: %w", err)
+	}
+	return config, nil
+}
+
+// Validate checks that every rule is known and has a valid severity
+func (c *Config) Validate() error {
+	known := DefaultConfig().Rules
+	names := make([]string, 0, len(c.Rules))
+	for rule := range c.Rules {
+		names = append(names, rule)
+	}
+	sort.Strings(names)
+	for _, rule := range names {
+		if _, ok := known[rule]; !ok {
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+		switch c.Rules[rule] {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return fmt.Errorf("rule %s: severity must be error, warning or off, not %q", rule, c.Rules[rule])
+		}
+	}
+	if c.UnassignedMaxPriority < 0 || c.UnassignedMaxPriority > 4 {
+		return fmt.Errorf("unassigned_max_priority must be between 0 and 4")
+	}
+	return nil
+}
+
+// Severity returns the configured severity of rule
+func (c *Config) Severity(rule string) Severity {
+	if sev, ok := c.Rules[rule]; ok {
+		return sev
+	}
+	return SeverityOff
+}
@@ -0,0 +1,159 @@
+// Package lint checks the issue graph for hygiene problems such as cycles
+// and dangling dependencies, so CI can fail a build when they appear.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Finding is one rule violation
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	IssueID  string   `json:"issue_id,omitempty"` // "" for graph-wide findings such as cycles
+	Message  string   `json:"message"`
+	Details  []string `json:"details,omitempty"`
+}
+
+// Result is the outcome of a lint run
+type Result struct {
+	Findings []Finding `json:"findings"`
+	Errors   int       `json:"errors"`
+	Warnings int       `json:"warnings"`
+}
+
+// ExitCode returns 1 when the run should fail: on any error, or on any
+// warning when strict is set. Otherwise it returns 0.
+func (r *Result) ExitCode(strict bool) int {
+	if r.Errors > 0 || (strict && r.Warnings > 0) {
+		return 1
+	}
+	return 0
+}
+
+// problemRules maps the data problems the loader-side checks find to rules
+var problemRules = map[analysis.ProblemKind]string{
+	analysis.ProblemDanglingDep:    RuleDanglingDep,
+	analysis.ProblemSelfDep:        RuleSelfDep,
+	analysis.ProblemDuplicateID:    RuleDuplicateID,
+	analysis.ProblemUnknownDepType: RuleUnknownDepType,
+}
+
+// Run checks issues against every rule that isn't off. Findings are
+// ordered errors first, then by rule and issue ID.
+func Run(issues []model.Issue, config *Config) Result {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	var result Result
+	add := func(rule, issueID, message string, details []string) {
+		sev := config.Severity(rule)
+		if sev == SeverityOff {
+			return
+		}
+		result.Findings = append(result.Findings, Finding{
+			Rule: rule, Severity: sev, IssueID: issueID, Message: message, Details: details,
+		})
+		if sev == SeverityError {
+			result.Errors++
+		} else {
+			result.Warnings++
+		}
+	}
+
+	for _, p := range analysis.FindDataProblems(issues) {
+		if rule, ok := problemRules[p.Kind]; ok {
+			add(rule, p.IssueID, p.Detail, nil)
+		}
+	}
+
+	if config.Severity(RuleCycles) != SeverityOff {
+		// Only cycle detection is needed, and it must run whatever the graph size
+		stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(analysis.AnalysisConfig{
+			ComputeCycles:    true,
+			CyclesTimeout:    2 * time.Second,
+			MaxCyclesToStore: 100,
+		})
+		for _, cycle := range stats.Cycles() {
+			if len(cycle) == 0 {
+				continue
+			}
+			add(RuleCycles, "", "dependency cycle: "+strings.Join(cycle, " → "), cycle)
+		}
+	}
+
+	estimateTypes := make(map[string]bool, len(config.EstimateTypes))
+	for _, t := range config.EstimateTypes {
+		estimateTypes[strings.ToLower(t)] = true
+	}
+	for _, issue := range issues {
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		if issue.Assignee == "" && issue.Priority <= config.UnassignedMaxPriority {
+			add(RuleUnassignedUrgent, issue.ID, fmt.Sprintf("P%d issue has no assignee", issue.Priority), nil)
+		}
+		needsEstimate := issue.IssueType != model.TypeEpic
+		if len(estimateTypes) > 0 {
+			needsEstimate = estimateTypes[strings.ToLower(string(issue.IssueType))]
+		}
+		if _, ok := issue.EstimateMinutes(); needsEstimate && !ok {
+			add(RuleMissingEstimate, issue.ID, fmt.Sprintf("open %s has no estimate", issue.IssueType), nil)
+		}
+	}
+
+	if config.Severity(RuleRedundantDep) != SeverityOff {
+		for _, r := range analysis.RedundantDependencies(issues) {
+			add(RuleRedundantDep, r.IssueID, r.String(), r.Via)
+		}
+	}
+
+	if config.Severity(RuleWIPLimit) != SeverityOff {
+		for _, v := range analysis.CheckWIP(issues, config.WIP) {
+			msg := fmt.Sprintf("%d issues %s, limit %d", v.Count, strings.ReplaceAll(v.Name, "_", " "), v.Limit)
+			if v.Scope == "assignee" {
+				msg = fmt.Sprintf("@%s has %d issues in progress, limit %d", v.Name, v.Count, v.Limit)
+			}
+			add(RuleWIPLimit, "", msg, v.IssueIDs)
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity == SeverityError
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.IssueID < b.IssueID
+	})
+	if result.Findings == nil {
+		result.Findings = []Finding{}
+	}
+	return result
+}
+
+// Summary renders the result for a terminal or CI log
+func (r *Result) Summary() string {
+	var sb strings.Builder
+	for _, f := range r.Findings {
+		sb.WriteString(fmt.Sprintf("%-7s %-24s ", f.Severity, f.Rule))
+		if f.IssueID != "" {
+			sb.WriteString(f.IssueID + ": ")
+		}
+		sb.WriteString(f.Message + "\n")
+	}
+	if len(r.Findings) == 0 {
+		sb.WriteString("No lint findings.\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("\n%d error(s), %d warning(s)\n", r.Errors, r.Warnings))
+	return sb.String()
+}
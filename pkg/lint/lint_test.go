@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func blocks(from, to string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+}
+
+func TestRunDefaults(t *testing.T) {
+	estimate := 30
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Dependencies: blocks("A", "B")},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Dependencies: blocks("B", "A")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Dependencies: blocks("C", "GONE")},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeTask, Assignee: "sam", EstimatedMinutes: &estimate},
+		{ID: "E", Title: "E", Status: model.StatusClosed, Priority: 0, IssueType: model.TypeTask},
+	}
+	r := Run(issues, nil)
+
+	if r.Errors != 2 || r.Warnings != 1 {
+		t.Fatalf("want 2 errors and 1 warning, got %d/%d: %+v", r.Errors, r.Warnings, r.Findings)
+	}
+	// Errors sort first, then by rule name
+	if r.Findings[0].Rule != RuleCycles || r.Findings[1].Rule != RuleDanglingDep || r.Findings[1].IssueID != "C" {
+		t.Errorf("unexpected error findings: %+v", r.Findings[:2])
+	}
+	if f := r.Findings[2]; f.Rule != RuleUnassignedUrgent || f.IssueID != "C" {
+		t.Errorf("only open, unassigned C should need an owner: %+v", f)
+	}
+	if r.ExitCode(false) != 1 {
+		t.Error("errors should fail the run")
+	}
+	if !strings.Contains(r.Summary(), "2 error(s), 1 warning(s)") {
+		t.Errorf("summary missing counts:\n%s", r.Summary())
+	}
+}
+
+func TestRunConfiguredRules(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeEpic},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeBug},
+	}
+	config := DefaultConfig()
+	config.Rules[RuleMissingEstimate] = SeverityWarning
+	r := Run(issues, config)
+	if r.Warnings != 2 || r.Errors != 0 {
+		t.Fatalf("epics shouldn't need estimates by default: %+v", r.Findings)
+	}
+	if r.ExitCode(false) != 0 || r.ExitCode(true) != 1 {
+		t.Error("warnings should only fail with strict")
+	}
+
+	config.EstimateTypes = []string{"bug"}
+	if r := Run(issues, config); r.Warnings != 1 || r.Findings[0].IssueID != "C" {
+		t.Errorf("estimate_types should limit the check: %+v", r.Findings)
+	}
+
+	if r := Run(nil, config); r.ExitCode(true) != 0 || r.Summary() != "No lint findings.\n" {
+		t.Errorf("empty project should be clean: %+v", r)
+	}
+}
+
+func TestRunWIPLimits(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusInProgress, Priority: 2, Assignee: "sam"},
+		{ID: "B", Title: "B", Status: model.StatusInProgress, Priority: 2, Assignee: "sam"},
+		{ID: "C", Title: "C", Status: model.StatusInProgress, Priority: 2, Assignee: "kim"},
+	}
+	config := DefaultConfig()
+	if r := Run(issues, config); len(r.Findings) != 0 {
+		t.Fatalf("default limits shouldn't flag two issues in progress: %+v", r.Findings)
+	}
+
+	config.WIP.Status = map[model.Status]int{model.StatusInProgress: 2}
+	config.WIP.PerAssignee = 1
+	r := Run(issues, config)
+	if r.Warnings != 2 || r.Errors != 0 {
+		t.Fatalf("want the column and sam flagged: %+v", r.Findings)
+	}
+	if f := r.Findings[0]; f.Message != "3 issues in progress, limit 2" {
+		t.Errorf("unexpected column finding %+v", f)
+	}
+	if f := r.Findings[1]; f.Message != "@sam has 2 issues in progress, limit 1" || len(f.Details) != 2 {
+		t.Errorf("unexpected assignee finding %+v", f)
+	}
+
+	config.Rules[RuleWIPLimit] = SeverityOff
+	if r := Run(issues, config); len(r.Findings) != 0 {
+		t.Errorf("wip_limit off should skip the check: %+v", r.Findings)
+	}
+}
+
+func TestRunRedundantDependencies(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 2, Assignee: "sam",
+			Dependencies: append(blocks("A", "B"), blocks("A", "C")...)},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 2, Assignee: "sam", Dependencies: blocks("B", "C")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 2, Assignee: "sam"},
+	}
+	r := Run(issues, nil)
+	if r.Warnings != 1 || r.Errors != 0 {
+		t.Fatalf("want one warning for A→C: %+v", r.Findings)
+	}
+	if f := r.Findings[0]; f.Rule != RuleRedundantDep || f.IssueID != "A" || f.Message != "A→C is implied by A→B→C" {
+		t.Errorf("unexpected finding %+v", f)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lint.yaml")
+
+	config, err := LoadConfig(path)
+	if err != nil || config.Severity(RuleCycles) != SeverityError {
+		t.Fatalf("missing file should give defaults: %v %+v", err, config)
+	}
+
+	os.WriteFile(path, []byte("rules:\n  cycles: warning\n  missing_estimate: Error\n  unassigned_priority: off\nunassigned_max_priority: 0\n"), 0644)
+	config, err = LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Severity(RuleCycles) != SeverityWarning || config.Severity(RuleMissingEstimate) != SeverityError ||
+		config.Severity(RuleUnassignedUrgent) != SeverityOff || config.Severity(RuleDanglingDep) != SeverityError {
+		t.Errorf("rules not layered over defaults: %+v", config.Rules)
+	}
+	if config.UnassignedMaxPriority != 0 {
+		t.Errorf("explicit 0 should be kept, got %d", config.UnassignedMaxPriority)
+	}
+
+	os.WriteFile(path, []byte("rules:\n  no_such_rule: error\n"), 0644)
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "no_such_rule") {
+		t.Errorf("expected unknown rule error, got %v", err)
+	}
+	os.WriteFile(path, []byte("rules:\n  cycles: fatal\n"), 0644)
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("expected invalid severity error")
+	}
+}
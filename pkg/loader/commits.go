@@ -0,0 +1,130 @@
+package loader
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// maxScannedCommits bounds how much history CommitsMentioning reads
+const maxScannedCommits = 5000
+
+// CommitLink is a commit whose message mentions an issue ID
+type CommitLink struct {
+	SHA     string    `json:"sha"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+}
+
+// idTokenSplit separates commit message text into candidate issue IDs
+var idTokenSplit = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// CommitsMentioning scans the most recent commits on HEAD for messages that
+// mention any of ids, matched as whole tokens and ignoring case, so "bv-12"
+// doesn't match "bv-123". Each ID's commits are newest first.
+func (g *GitLoader) CommitsMentioning(ids []string) (map[string][]CommitLink, error) {
+	want := make(map[string]string, len(ids))
+	for _, id := range ids {
+		want[strings.ToLower(id)] = id
+	}
+	links := make(map[string][]CommitLink)
+	if len(want) == 0 {
+		return links, nil
+	}
+
+	// Fields are separated by \x1f and commits by \x1e, neither of which
+	// appears in commit messages
+	cmd := exec.Command("git", "log", fmt.Sprintf("-n%d", maxScannedCommits),
+		"--format=%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1e", "HEAD")
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	for _, record := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		link := CommitLink{SHA: fields[0], Author: fields[1], Date: date, Subject: fields[3]}
+
+		seen := make(map[string]bool)
+		for _, token := range idTokenSplit.Split(fields[3]+"\n"+fields[4], -1) {
+			id, ok := want[strings.ToLower(strings.Trim(token, "._-"))]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			links[id] = append(links[id], link)
+		}
+	}
+	return links, nil
+}
+
+// RemoteURL returns the fetch URL of the origin remote, or "" if there is none
+func (g *GitLoader) RemoteURL() string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ForgeCommitURL turns a git remote URL and commit SHA into the commit's web
+// page on GitHub, GitLab, Bitbucket or a similar forge. It returns "" for
+// remotes it can't map to a web host, such as local paths.
+func ForgeCommitURL(remote, sha string) string {
+	base := strings.TrimSuffix(strings.TrimSpace(remote), "/")
+	base = strings.TrimSuffix(base, ".git")
+
+	switch {
+	case strings.HasPrefix(base, "https://"), strings.HasPrefix(base, "http://"):
+		// Drop credentials embedded in the URL
+		scheme, rest, _ := strings.Cut(base, "://")
+		if at := strings.Index(rest, "@"); at >= 0 && at < strings.Index(rest+"/", "/") {
+			rest = rest[at+1:]
+		}
+		base = scheme + "://" + rest
+	case strings.HasPrefix(base, "ssh://"), strings.HasPrefix(base, "git://"):
+		_, rest, _ := strings.Cut(base, "://")
+		if at := strings.Index(rest, "@"); at >= 0 {
+			rest = rest[at+1:]
+		}
+		host, path, ok := strings.Cut(rest, "/")
+		if !ok {
+			return ""
+		}
+		host, _, _ = strings.Cut(host, ":") // Drop the SSH port
+		base = "https://" + host + "/" + path
+	case strings.Contains(base, ":") && !strings.Contains(base, "://"):
+		// scp-style: git@github.com:owner/repo
+		hostPart, path, _ := strings.Cut(base, ":")
+		if at := strings.Index(hostPart, "@"); at >= 0 {
+			hostPart = hostPart[at+1:]
+		}
+		if hostPart == "" || path == "" || strings.Contains(hostPart, "/") || strings.HasPrefix(path, "/") {
+			return ""
+		}
+		base = "https://" + hostPart + "/" + path
+	default:
+		return ""
+	}
+
+	switch {
+	case strings.Contains(base, "gitlab"):
+		return base + "/-/commit/" + sha
+	case strings.Contains(base, "bitbucket.org"):
+		return base + "/commits/" + sha
+	default:
+		return base + "/commit/" + sha
+	}
+}
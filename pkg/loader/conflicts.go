@@ -0,0 +1,219 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ErrConflictMarker is the error of a skipped line that is a git conflict
+// marker, left in the file by a merge that needs resolving
+var ErrConflictMarker = errors.New("git conflict marker")
+
+// conflictMarker returns the marker a line starts with ("<<<<<<<",
+// "|||||||", "=======" or ">>>>>>>") and the label after it, or "" if the
+// line is not a conflict marker
+func conflictMarker(line []byte) (marker, label string) {
+	for _, m := range []string{"<<<<<<<", "|||||||", "=======", ">>>>>>>"} {
+		rest, ok := bytes.CutPrefix(line, []byte(m))
+		if !ok {
+			continue
+		}
+		if len(rest) > 0 && rest[0] != ' ' && rest[0] != '\t' && rest[0] != '\r' {
+			return "", ""
+		}
+		return m, strings.TrimSpace(string(rest))
+	}
+	return "", ""
+}
+
+// isMarker reports whether line is a git conflict marker
+func isMarker(line []byte) bool {
+	marker, _ := conflictMarker(line)
+	return marker != ""
+}
+
+// HasConflictMarkers reports whether any of the skipped lines is a git
+// conflict marker
+func HasConflictMarkers(skipped []LineError) bool {
+	for _, s := range skipped {
+		if errors.Is(s.Err, ErrConflictMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictVersion is one side's copy of a record in a conflicted beads file
+type ConflictVersion struct {
+	Line  string      // The JSONL line as written
+	Issue model.Issue // Decoded from Line; zero if the line isn't an issue
+}
+
+// ConflictRecord pairs the two sides' copies of a record inside conflict
+// hunks by issue ID. A side that doesn't have the record is nil.
+type ConflictRecord struct {
+	ID     string // "" for a line that isn't an issue
+	Ours   *ConflictVersion
+	Theirs *ConflictVersion
+}
+
+// Same reports whether both sides have the record, written identically
+func (r ConflictRecord) Same() bool {
+	return r.Ours != nil && r.Theirs != nil && r.Ours.Line == r.Theirs.Line
+}
+
+// conflictPart is a line outside the conflict hunks, or a hunk: the records
+// that first appear in it
+type conflictPart struct {
+	line    string
+	hunk    bool
+	records []int
+}
+
+// MergeConflict is a beads file left with git conflict markers, its hunks
+// split into records that can be resolved one by one
+type MergeConflict struct {
+	OursLabel   string // From the markers, e.g. HEAD
+	TheirsLabel string // e.g. the merged branch
+	Hunks       int
+	Records     []ConflictRecord // Records inside the hunks, in file order
+	parts       []conflictPart
+}
+
+// ParseMergeConflict splits data at its git conflict markers. Records inside
+// the hunks are paired across the two sides by issue ID; lines outside them
+// are kept as they are. The base section of diff3-style hunks is ignored.
+// Data without markers parses to a MergeConflict with no hunks.
+func ParseMergeConflict(data []byte) (MergeConflict, error) {
+	var c MergeConflict
+	byID := make(map[string]int)
+	const (
+		outside = iota
+		ours
+		base
+		theirs
+	)
+	state := outside
+	var hunk *conflictPart
+
+	// add puts line, from one side of the current hunk, into its record
+	add := func(line []byte, theirSide bool) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			return
+		}
+		v := &ConflictVersion{Line: string(line)}
+		if err := json.Unmarshal(line, &v.Issue); err != nil {
+			v.Issue = model.Issue{}
+		}
+		id := v.Issue.ID
+		i, ok := byID[id]
+		if ok && id != "" {
+			slot := &c.Records[i].Ours
+			if theirSide {
+				slot = &c.Records[i].Theirs
+			}
+			if *slot == nil {
+				*slot = v
+				return
+			}
+		}
+		// A new record, or a second copy of an ID on the same side
+		r := ConflictRecord{ID: id, Ours: v}
+		if theirSide {
+			r = ConflictRecord{ID: id, Theirs: v}
+		}
+		c.Records = append(c.Records, r)
+		if !ok && id != "" {
+			byID[id] = len(c.Records) - 1
+		}
+		hunk.records = append(hunk.records, len(c.Records)-1)
+	}
+
+	lines := strings.Split(string(stripBOM(data)), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for n, text := range lines {
+		line := []byte(strings.TrimSuffix(text, "\r"))
+		marker, label := conflictMarker(line)
+		switch {
+		case marker == "<<<<<<<" && state == outside:
+			state = ours
+			c.Hunks++
+			c.parts = append(c.parts, conflictPart{hunk: true})
+			hunk = &c.parts[len(c.parts)-1]
+			if c.OursLabel == "" {
+				c.OursLabel = label
+			}
+		case marker == "|||||||" && state == ours:
+			state = base
+		case marker == "=======" && (state == ours || state == base):
+			state = theirs
+		case marker == ">>>>>>>" && state == theirs:
+			state = outside
+			hunk = nil
+			if c.TheirsLabel == "" {
+				c.TheirsLabel = label
+			}
+		case marker != "":
+			return MergeConflict{}, fmt.Errorf("line %d: unexpected %s conflict marker", n+1, marker)
+		case state == outside:
+			c.parts = append(c.parts, conflictPart{line: string(line)})
+		case state == ours:
+			add(line, false)
+		case state == theirs:
+			add(line, true)
+		}
+	}
+	if state != outside {
+		return MergeConflict{}, errors.New("conflict hunk is not closed")
+	}
+	return c, nil
+}
+
+// DefaultChoices returns, per record, whether to take their copy: theirs
+// when only they have the record or when theirs was updated later, ours
+// otherwise. Records only one side has are kept.
+func (c MergeConflict) DefaultChoices() []bool {
+	theirs := make([]bool, len(c.Records))
+	for i, r := range c.Records {
+		switch {
+		case r.Ours == nil:
+			theirs[i] = true
+		case r.Theirs != nil:
+			theirs[i] = r.Theirs.Issue.UpdatedAt.After(r.Ours.Issue.UpdatedAt)
+		}
+	}
+	return theirs
+}
+
+// Resolve returns the file without conflict markers: lines outside the
+// hunks as they were, and in place of each hunk the chosen copy of its
+// records, theirs where useTheirs is set. A record missing from the chosen
+// side is dropped.
+func (c MergeConflict) Resolve(useTheirs []bool) []byte {
+	var b bytes.Buffer
+	for _, part := range c.parts {
+		if !part.hunk {
+			b.WriteString(part.line)
+			b.WriteByte('\n')
+			continue
+		}
+		for _, i := range part.records {
+			v := c.Records[i].Ours
+			if i < len(useTheirs) && useTheirs[i] {
+				v = c.Records[i].Theirs
+			}
+			if v != nil {
+				b.WriteString(v.Line)
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.Bytes()
+}
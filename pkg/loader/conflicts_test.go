@@ -0,0 +1,71 @@
+package loader_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestParseMergeConflict(t *testing.T) {
+	a := `{"id":"A","title":"Kept","status":"open","issue_type":"task"}`
+	oursB := `{"id":"B","title":"Ours","status":"closed","issue_type":"task","updated_at":"2025-06-03T00:00:00Z"}`
+	theirsB := `{"id":"B","title":"Theirs","status":"open","issue_type":"task","updated_at":"2025-06-02T00:00:00Z"}`
+	oursC := `{"id":"C","title":"Old","status":"open","issue_type":"task","updated_at":"2025-06-01T00:00:00Z"}`
+	theirsC := `{"id":"C","title":"New","status":"open","issue_type":"task","updated_at":"2025-06-04T00:00:00Z"}`
+	d := `{"id":"D","title":"Added by them","status":"open","issue_type":"task"}`
+	data := strings.Join([]string{
+		a,
+		"<<<<<<< HEAD",
+		oursB,
+		oursC,
+		"||||||| base",
+		`{"id":"B","title":"Base","status":"open","issue_type":"task"}`,
+		"=======",
+		theirsC,
+		theirsB,
+		d,
+		">>>>>>> feature",
+	}, "\n") + "\n"
+
+	// The loader skips the markers but reports them
+	res, err := loader.StreamIssues(strings.NewReader(data), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skipped) != 4 || !loader.HasConflictMarkers(res.Skipped) {
+		t.Fatalf("expected the 4 markers skipped, got %v", res.Skipped)
+	}
+
+	c, err := loader.ParseMergeConflict([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Hunks != 1 || c.OursLabel != "HEAD" || c.TheirsLabel != "feature" {
+		t.Errorf("unexpected hunks %d, labels %q / %q", c.Hunks, c.OursLabel, c.TheirsLabel)
+	}
+	var ids []string
+	for _, r := range c.Records {
+		ids = append(ids, r.ID)
+	}
+	if strings.Join(ids, ",") != "B,C,D" || c.Records[2].Ours != nil || c.Records[0].Theirs.Line != theirsB {
+		t.Fatalf("expected B, C and D paired by ID, got %+v", c.Records)
+	}
+
+	// Newest wins, and a record only they added is kept
+	choices := c.DefaultChoices()
+	if want := strings.Join([]string{a, oursB, theirsC, d}, "\n") + "\n"; string(c.Resolve(choices)) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, c.Resolve(choices))
+	}
+	// Taking our side of D drops it
+	if got := string(c.Resolve([]bool{true, false, false})); got != strings.Join([]string{a, theirsB, oursC}, "\n")+"\n" {
+		t.Errorf("unexpected resolution\n%s", got)
+	}
+
+	if _, err := loader.ParseMergeConflict([]byte("<<<<<<< HEAD\n" + a + "\n")); err == nil {
+		t.Error("expected an unclosed hunk to fail")
+	}
+	if c, err := loader.ParseMergeConflict([]byte(a + "\n")); err != nil || c.Hunks != 0 {
+		t.Errorf("expected no hunks, got %d, %v", c.Hunks, err)
+	}
+}
@@ -3,7 +3,6 @@ package loader
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -84,8 +83,50 @@ func (g *GitLoader) LoadAt(revision string) ([]model.Issue, error) {
 // LoadAtDate loads issues from the state at a specific date/time
 // Uses git rev-list to find the commit at or before the given time
 func (g *GitLoader) LoadAtDate(t time.Time) ([]model.Issue, error) {
-	revision := fmt.Sprintf("HEAD@{%s}", t.Format("2006-01-02 15:04:05"))
-	return g.LoadAt(revision)
+	sha, err := g.commitBefore(t)
+	if err != nil {
+		return nil, err
+	}
+	return g.LoadAt(sha)
+}
+
+// commitBefore returns the last commit on HEAD made at or before t. Unlike
+// HEAD@{date}, which reads the local reflog, this walks history, so it works
+// in fresh clones and for dates older than the reflog.
+func (g *GitLoader) commitBefore(t time.Time) (string, error) {
+	cmd := exec.Command("git", "rev-list", "-1", "--before="+t.Format(time.RFC3339), "HEAD")
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-list failed: %w", err)
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("no commits before %s", t.Format("2006-01-02 15:04"))
+	}
+	return sha, nil
+}
+
+// DescribeRevision returns the commit a revision resolves to, with its date and subject
+func (g *GitLoader) DescribeRevision(revision string) (RevisionInfo, error) {
+	sha, err := g.resolveRevision(revision)
+	if err != nil {
+		return RevisionInfo{}, err
+	}
+	cmd := exec.Command("git", "log", "-1", "--format=%H|%aI|%s", sha)
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return RevisionInfo{}, fmt.Errorf("describing %s: %w", revision, err)
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(out)), "|", 3)
+	if len(parts) != 3 {
+		return RevisionInfo{}, fmt.Errorf("unexpected git log output for %s", revision)
+	}
+	timestamp, _ := time.Parse(time.RFC3339, parts[1])
+	return RevisionInfo{SHA: parts[0], Timestamp: timestamp, Message: parts[2]}, nil
 }
 
 // ResolveRevision resolves any git revision to its commit SHA
@@ -140,6 +181,18 @@ func (g *GitLoader) ListRevisions(limit int) ([]RevisionInfo, error) {
 	return revisions, nil
 }
 
+// GitUserName returns git config user.name as seen from dir, or "" when it
+// isn't set or git isn't available
+func GitUserName(dir string) string {
+	cmd := exec.Command("git", "config", "user.name")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
 // RevisionInfo describes a git commit
 type RevisionInfo struct {
 	SHA       string    `json:"sha"`
@@ -157,13 +210,14 @@ func (g *GitLoader) resolveRevision(revision string) (string, error) {
 		return strings.TrimSpace(string(out)), nil
 	}
 
-	// If rev-parse failed, try to interpret the revision as a date.
+	// If rev-parse failed, try to interpret the revision as a date. A bare
+	// date means the state at the end of that day.
 	if t, ok := parseDateString(revision); ok {
-		dateSpec := fmt.Sprintf("HEAD@{%s}", t.Format("2006-01-02 15:04:05"))
-		cmd = exec.Command("git", "rev-parse", dateSpec)
-		cmd.Dir = g.repoPath
-		if out, dateErr := cmd.Output(); dateErr == nil {
-			return strings.TrimSpace(string(out)), nil
+		if _, err := time.Parse("2006-01-02", revision); err == nil {
+			t = t.Add(24*time.Hour - time.Second)
+		}
+		if sha, dateErr := g.commitBefore(t); dateErr == nil {
+			return sha, nil
 		}
 	}
 
@@ -232,44 +286,13 @@ func (g *GitLoader) loadFileFromGit(sha, path string) ([]model.Issue, error) {
 	return parseJSONL(out)
 }
 
-// parseJSONL parses JSONL content into issues
+// parseJSONL parses JSONL content into issues, silently skipping bad lines
 func parseJSONL(data []byte) ([]model.Issue, error) {
-	// Strip BOM from the entire file content if present at start
-	data = stripBOM(data)
-
-	var issues []model.Issue
-	scanner := bufio.NewScanner(bytes.NewReader(data))
-	// Increase buffer size for large lines
-	const maxCapacity = 1024 * 1024 * 10 // 10MB
-	buf := make([]byte, maxCapacity)
-	scanner.Buffer(buf, maxCapacity)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-
-		var issue model.Issue
-		if err := json.Unmarshal(line, &issue); err != nil {
-			// Skip malformed lines
-			continue
-		}
-
-		// Validate issue
-		if err := issue.Validate(); err != nil {
-			// Skip invalid issues
-			continue
-		}
-
-		issues = append(issues, issue)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scanning JSONL: %w", err)
+	res, err := StreamIssues(bytes.NewReader(data), int64(len(data)), nil)
+	if err != nil {
+		return nil, err
 	}
-
-	return issues, nil
+	return res.Issues, nil
 }
 
 // Cache methods
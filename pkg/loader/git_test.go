@@ -339,6 +339,53 @@ func TestGitLoader_HasBeadsAtRevision(t *testing.T) {
 	}
 }
 
+func TestGitLoader_DateOlderThanReflog(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	beadsFile := filepath.Join(dir, ".beads", "beads.jsonl")
+	os.MkdirAll(filepath.Dir(beadsFile), 0755)
+
+	commitAt := func(date, content, msg string) {
+		t.Helper()
+		if err := os.WriteFile(beadsFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, dir, "add", ".")
+		cmd := exec.Command("git", "commit", "-m", msg)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("commit failed: %v\n%s", err, out)
+		}
+	}
+	commitAt("2020-05-01T12:00:00Z", `{"id":"A","title":"A","status":"open","issue_type":"task"}`+"\n", "May")
+	commitAt("2020-06-01T12:00:00Z", `{"id":"A","title":"A","status":"closed","issue_type":"task"}`+"\n", "June")
+
+	loader := NewGitLoader(dir)
+	// The reflog only knows today, so this must come from history
+	issues, err := loader.LoadAt("2020-05-20")
+	if err != nil {
+		t.Fatalf("LoadAt(date) failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Status != "open" {
+		t.Fatalf("expected the May state, got %+v", issues)
+	}
+
+	info, err := loader.DescribeRevision("2020-06-02")
+	if err != nil {
+		t.Fatalf("DescribeRevision failed: %v", err)
+	}
+	if info.Message != "June" || info.Timestamp.UTC().Format("2006-01-02") != "2020-06-01" {
+		t.Errorf("expected the June commit, got %+v", info)
+	}
+
+	if _, err := loader.LoadAt("2019-01-01"); err == nil {
+		t.Error("expected an error before the first commit")
+	}
+}
+
 func TestGitLoader_InvalidRevision(t *testing.T) {
 	repoDir, cleanup := setupTestGitRepo(t)
 	defer cleanup()
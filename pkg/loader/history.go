@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// maxHistoryRevisions bounds how many snapshots StatusHistory reads
+const maxHistoryRevisions = 500
+
+// StatusHistory reconstructs each issue's status changes from the committed
+// snapshots of the beads file, oldest first. A change is dated by the
+// issue's own updated_at (or closed_at) when that falls between the two
+// snapshots, otherwise by the commit that first shows it, and is attributed
+// to that commit's author. Only the most recent maxHistoryRevisions commits
+// are read.
+func (g *GitLoader) StatusHistory() (map[string][]model.StatusChange, error) {
+	cmd := exec.Command("git", "log", fmt.Sprintf("-n%d", maxHistoryRevisions),
+		"--format=%H%x1f%an%x1f%aI", "--",
+		".beads/beads.base.jsonl",
+		".beads/beads.jsonl",
+		".beads/issues.jsonl",
+	)
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing git history: %w", err)
+	}
+
+	type commit struct {
+		sha, author string
+		at          time.Time
+	}
+	var commits []commit
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		at, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, commit{sha: fields[0], author: fields[1], at: at})
+	}
+
+	history := make(map[string][]model.StatusChange)
+	var prev time.Time
+	// git log is newest first
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		issues, err := g.loadFromGit(c.sha)
+		if err != nil {
+			continue
+		}
+		for _, issue := range issues {
+			changes := history[issue.ID]
+			if n := len(changes); n > 0 && changes[n-1].Status == issue.Status {
+				continue
+			}
+			at := changeTime(issue, prev, c.at)
+			if len(changes) == 0 && issue.Status == model.StatusOpen && !issue.CreatedAt.IsZero() && issue.CreatedAt.Before(at) {
+				at = issue.CreatedAt
+			}
+			history[issue.ID] = append(changes, model.StatusChange{Status: issue.Status, At: at, By: c.author})
+		}
+		prev = c.at
+	}
+	return history, nil
+}
+
+// changeTime dates a status change first seen in a snapshot committed at
+// at, the previous snapshot having been committed at prev
+func changeTime(issue model.Issue, prev, at time.Time) time.Time {
+	candidate := issue.UpdatedAt
+	if issue.Status.IsClosed() && issue.ClosedAt != nil {
+		candidate = *issue.ClosedAt
+	}
+	if !candidate.IsZero() && candidate.After(prev) && !candidate.After(at) {
+		return candidate
+	}
+	return at
+}
+
+// ApplyStatusHistory fills in the status history of issues that have none
+// from history (see StatusHistory). When an issue's current status differs
+// from the last committed one, the uncommitted change is added at its
+// updated_at. It
+// returns how many issues were given a history.
+func ApplyStatusHistory(issues []model.Issue, history map[string][]model.StatusChange) int {
+	applied := 0
+	for i := range issues {
+		issue := &issues[i]
+		changes := history[issue.ID]
+		if len(issue.StatusHistory) > 0 || len(changes) == 0 {
+			continue
+		}
+		changes = append([]model.StatusChange(nil), changes...)
+		if last := changes[len(changes)-1]; last.Status != issue.Status {
+			at := issue.UpdatedAt
+			if at.Before(last.At) {
+				at = last.At
+			}
+			changes = append(changes, model.StatusChange{Status: issue.Status, At: at})
+		}
+		issue.StatusHistory = changes
+		applied++
+	}
+	return applied
+}
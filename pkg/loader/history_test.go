@@ -0,0 +1,72 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestStatusHistory(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.email", "test@test.com")
+	runGit(t, dir, "config", "user.name", "Test User")
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(date, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ".beads", "beads.jsonl"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-m", "update", "--date", date)
+	}
+	commit("2025-03-01T12:00:00Z", `{"id":"A","title":"a","status":"open","issue_type":"task","created_at":"2025-03-01T09:00:00Z"}`+"\n")
+	// updated_at falls between the snapshots, so it dates the change
+	commit("2025-03-05T12:00:00Z", `{"id":"A","title":"a","status":"in_progress","issue_type":"task","created_at":"2025-03-01T09:00:00Z","updated_at":"2025-03-04T08:00:00Z"}`+"\n")
+	commit("2025-03-06T12:00:00Z", `{"id":"A","title":"a","status":"in_progress","issue_type":"task","created_at":"2025-03-01T09:00:00Z","updated_at":"2025-03-06T08:00:00Z"}`+"\n")
+	commit("2025-03-09T12:00:00Z", `{"id":"A","title":"a","status":"blocked","issue_type":"task","created_at":"2025-03-01T09:00:00Z"}`+"\n")
+
+	history, err := NewGitLoader(dir).StatusHistory()
+	if err != nil {
+		t.Fatalf("StatusHistory: %v", err)
+	}
+	changes := history["A"]
+	want := []model.StatusChange{
+		{Status: model.StatusOpen, At: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), By: "Test User"},
+		{Status: model.StatusInProgress, At: time.Date(2025, 3, 4, 8, 0, 0, 0, time.UTC), By: "Test User"},
+		{Status: model.StatusBlocked, At: time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC), By: "Test User"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i].Status != want[i].Status || !changes[i].At.Equal(want[i].At) || changes[i].By != want[i].By {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	// The working copy has moved on to closed since the last commit
+	closedAt := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusClosed, UpdatedAt: closedAt},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", StatusHistory: []model.StatusChange{{Status: model.StatusOpen}}},
+	}
+	history["C"] = changes
+	if n := ApplyStatusHistory(issues, history); n != 1 {
+		t.Fatalf("expected history applied to 1 issue, got %d", n)
+	}
+	got := issues[0].StatusHistory
+	if len(got) != 4 || got[3].Status != model.StatusClosed || !got[3].At.Equal(closedAt) {
+		t.Fatalf("expected the uncommitted close appended, got %+v", got)
+	}
+	if len(history["A"]) != 3 {
+		t.Fatalf("ApplyStatusHistory must not change the shared history")
+	}
+}
@@ -1,12 +1,11 @@
 package loader
 
 import (
-	"bufio"
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -14,7 +13,7 @@ import (
 
 // FindJSONLPath locates the beads JSONL file in the given directory.
 // Prefers beads.jsonl (canonical) over issues.jsonl (legacy fallback).
-// Skips backup files and merge artifacts.
+// Skips backup files, merge artifacts and archives.
 func FindJSONLPath(beadsDir string) (string, error) {
 	entries, err := os.ReadDir(beadsDir)
 	if err != nil {
@@ -33,11 +32,12 @@ func FindJSONLPath(beadsDir string) (string, error) {
 			continue
 		}
 
-		// Skip backups, merge artifacts, and deletion manifests
+		// Skip backups, merge artifacts, deletion manifests and archives
 		if strings.Contains(name, ".backup") ||
 			strings.Contains(name, ".orig") ||
 			strings.Contains(name, ".merge") ||
-			name == "deletions.jsonl" {
+			name == "deletions.jsonl" ||
+			isArchiveFile(name) {
 			continue
 		}
 
@@ -79,6 +79,31 @@ func FindJSONLPath(beadsDir string) (string, error) {
 	return filepath.Join(beadsDir, candidates[0]), nil
 }
 
+// archiveNames are the files beads moves archived (closed) issues into
+var archiveNames = []string{"archive.jsonl", "closed.jsonl"}
+
+// isArchiveFile reports whether name follows the archive naming convention
+func isArchiveFile(name string) bool {
+	return slices.Contains(archiveNames, name) || strings.HasSuffix(name, ".archive.jsonl")
+}
+
+// FindArchivePath locates the archive JSONL in the given beads directory,
+// returning "" if there is none. archive.jsonl is preferred over closed.jsonl,
+// then over the first *.archive.jsonl.
+func FindArchivePath(beadsDir string) string {
+	for _, name := range archiveNames {
+		path := filepath.Join(beadsDir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(beadsDir, "*.archive.jsonl"))
+	if len(matches) > 0 {
+		return matches[0]
+	}
+	return ""
+}
+
 // LoadIssues reads issues from the .beads directory in the given repository path.
 // Automatically finds the correct JSONL file (beads.jsonl preferred, issues.jsonl fallback).
 func LoadIssues(repoPath string) ([]model.Issue, error) {
@@ -100,59 +125,18 @@ func LoadIssues(repoPath string) ([]model.Issue, error) {
 }
 
 // LoadIssuesFromFile reads issues directly from a specific JSONL file path.
+// Malformed lines are skipped; invalid issues are skipped with a warning.
 func LoadIssuesFromFile(path string) ([]model.Issue, error) {
-	// Check if file exists
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no beads issues found at %s", path)
-	}
-
-	file, err := os.Open(path)
+	res, err := LoadIssuesFromFileWithProgress(path, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open issues file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
-
-	var issues []model.Issue
-	scanner := bufio.NewScanner(file)
-	// Increase buffer size for large lines (issues can be large)
-	const maxCapacity = 1024 * 1024 * 10 // 10MB
-	buf := make([]byte, maxCapacity)
-	scanner.Buffer(buf, maxCapacity)
-
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+	for _, skipped := range res.Skipped {
+		if !skipped.Malformed {
+			fmt.Fprintf(os.Stderr, "Warning: skipping invalid issue on line %d: %v\n", skipped.Line, skipped.Err)
 		}
-
-		// Strip UTF-8 BOM if present on the first line
-		if lineNum == 1 {
-			line = stripBOM(line)
-		}
-
-		var issue model.Issue
-		if err := json.Unmarshal(line, &issue); err != nil {
-			// Skip malformed lines but continue loading the rest
-			continue
-		}
-
-		// Validate issue
-		if err := issue.Validate(); err != nil {
-			// Skip invalid issues
-			fmt.Fprintf(os.Stderr, "Warning: skipping invalid issue on line %d: %v\n", lineNum, err)
-			continue
-		}
-
-		issues = append(issues, issue)
 	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading issues file: %w", err)
-	}
-
-	return issues, nil
+	return res.Issues, nil
 }
 
 // stripBOM removes the UTF-8 Byte Order Mark if present
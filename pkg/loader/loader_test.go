@@ -140,6 +140,39 @@ func TestFindJSONLPath_SkipsDeletionsJSONL(t *testing.T) {
 	}
 }
 
+func TestFindJSONLPath_SkipsArchives(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "archive.jsonl"), []byte(`{"id":"1"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "old.archive.jsonl"), []byte(`{"id":"2"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "other.jsonl"), []byte(`{"id":"3"}`), 0644)
+
+	path, err := loader.FindJSONLPath(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filepath.Base(path) != "other.jsonl" {
+		t.Errorf("Should not select an archive, got: %s", path)
+	}
+}
+
+func TestFindArchivePath(t *testing.T) {
+	dir := t.TempDir()
+	if got := loader.FindArchivePath(dir); got != "" {
+		t.Errorf("Expected no archive, got: %s", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "2024.archive.jsonl"), []byte(`{"id":"1"}`), 0644)
+	if got := loader.FindArchivePath(dir); filepath.Base(got) != "2024.archive.jsonl" {
+		t.Errorf("Expected 2024.archive.jsonl, got: %s", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "closed.jsonl"), []byte(`{"id":"2"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "archive.jsonl"), []byte(`{"id":"3"}`), 0644)
+	if got := loader.FindArchivePath(dir); filepath.Base(got) != "archive.jsonl" {
+		t.Errorf("Expected archive.jsonl to be preferred, got: %s", got)
+	}
+}
+
 func TestFindJSONLPath_SkipsEmptyPreferredFiles(t *testing.T) {
 	dir := t.TempDir()
 	// Create empty beads.jsonl and non-empty other.jsonl
@@ -0,0 +1,119 @@
+package loader
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxSiblingRepos bounds how many neighbouring projects LocateIssueIDs opens
+const maxSiblingRepos = 50
+
+// IDLocation is a beads file other than the loaded one that contains an issue ID
+type IDLocation struct {
+	Path    string
+	Deleted bool // Listed in a deletions manifest rather than as an issue
+}
+
+// LocateIssueIDs looks for ids outside beadsPath: in the other JSONL files
+// next to it (backups, archives, deletions.jsonl) and in the beads files of
+// sibling projects, which is where a dependency on another repo usually
+// points. Unreadable files are ignored. Each ID's locations are sorted by path.
+func LocateIssueIDs(beadsPath string, ids []string) map[string][]IDLocation {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	found := make(map[string][]IDLocation)
+	if len(want) == 0 || beadsPath == "" {
+		return found
+	}
+
+	for _, path := range candidateFiles(beadsPath) {
+		deleted := filepath.Base(path) == "deletions.jsonl"
+		for id := range scanIDs(path, want) {
+			found[id] = append(found[id], IDLocation{Path: path, Deleted: deleted})
+		}
+	}
+	for id := range found {
+		sort.Slice(found[id], func(i, j int) bool { return found[id][i].Path < found[id][j].Path })
+	}
+	return found
+}
+
+// candidateFiles lists the JSONL files worth searching besides beadsPath
+func candidateFiles(beadsPath string) []string {
+	beadsDir := filepath.Dir(beadsPath)
+	self, _ := filepath.Abs(beadsPath)
+
+	var files []string
+	add := func(path string) {
+		if abs, err := filepath.Abs(path); err == nil && abs != self {
+			files = append(files, path)
+		}
+	}
+
+	// Everything in the same .beads directory, including backups
+	if matches, err := filepath.Glob(filepath.Join(beadsDir, "*.jsonl")); err == nil {
+		for _, m := range matches {
+			add(m)
+		}
+	}
+
+	// The main beads file of each sibling project: <parent>/<repo>/.beads
+	project := filepath.Dir(beadsDir)
+	entries, err := os.ReadDir(filepath.Dir(project))
+	if err != nil {
+		return files
+	}
+	repos := 0
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") || e.Name() == filepath.Base(project) {
+			continue
+		}
+		if repos >= maxSiblingRepos {
+			break
+		}
+		siblingBeads := filepath.Join(filepath.Dir(project), e.Name(), ".beads")
+		if path, err := FindJSONLPath(siblingBeads); err == nil {
+			add(path)
+			repos++
+		}
+	}
+	return files
+}
+
+// scanIDs returns which of want appear as "id" fields in the JSONL file at path
+func scanIDs(path string, want map[string]bool) map[string]bool {
+	hits := make(map[string]bool)
+	f, err := os.Open(path)
+	if err != nil {
+		return hits
+	}
+	defer f.Close()
+
+	br := bufio.NewReaderSize(f, 64*1024)
+	var buf []byte
+	for {
+		line, n, tooLong, err := readLine(br, buf[:0])
+		buf = line
+		if n == 0 && err != nil {
+			break
+		}
+		if !tooLong {
+			var rec struct {
+				ID string `json:"id"`
+			}
+			if json.Unmarshal(stripBOM(trimNewline(line)), &rec) == nil && want[rec.ID] {
+				hits[rec.ID] = true
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return hits
+}
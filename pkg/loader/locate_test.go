@@ -0,0 +1,46 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func writeBeadsFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLocateIssueIDs(t *testing.T) {
+	root := t.TempDir()
+	main := filepath.Join(root, "app", ".beads", "beads.jsonl")
+	writeBeadsFile(t, main, `{"id":"app-1","depends_on":"app-9"}`+"\n")
+	writeBeadsFile(t, filepath.Join(root, "app", ".beads", "beads.backup.jsonl"), `{"id":"app-9"}`+"\n{broken\n")
+	writeBeadsFile(t, filepath.Join(root, "app", ".beads", "deletions.jsonl"), `{"id":"app-8","reason":"stale"}`+"\n")
+	writeBeadsFile(t, filepath.Join(root, "api", ".beads", "beads.jsonl"), `{"id":"api-3"}`+"\n")
+
+	found := loader.LocateIssueIDs(main, []string{"app-9", "app-8", "api-3", "app-1", "nowhere-1"})
+
+	if locs := found["app-9"]; len(locs) != 1 || filepath.Base(locs[0].Path) != "beads.backup.jsonl" || locs[0].Deleted {
+		t.Errorf("app-9: unexpected locations %+v", locs)
+	}
+	if locs := found["app-8"]; len(locs) != 1 || !locs[0].Deleted {
+		t.Errorf("app-8: expected a deletions entry, got %+v", locs)
+	}
+	if locs := found["api-3"]; len(locs) != 1 || locs[0].Path != filepath.Join(root, "api", ".beads", "beads.jsonl") {
+		t.Errorf("api-3: expected the sibling repo, got %+v", locs)
+	}
+	if _, ok := found["app-1"]; ok {
+		t.Error("the loaded file itself should not be searched")
+	}
+	if _, ok := found["nowhere-1"]; ok {
+		t.Error("expected no location for an unknown ID")
+	}
+}
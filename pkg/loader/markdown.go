@@ -0,0 +1,324 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileError describes a Markdown issue file the loader skipped
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// MarkdownResult holds the issues read from a directory of Markdown files
+// and the files that were skipped
+type MarkdownResult struct {
+	Issues  []model.Issue
+	Skipped []FileError
+}
+
+// markdownStatuses maps the statuses teams write in front matter to beads'
+var markdownStatuses = map[string]model.Status{
+	"open":        model.StatusOpen,
+	"todo":        model.StatusOpen,
+	"in_progress": model.StatusInProgress,
+	"in progress": model.StatusInProgress,
+	"in-progress": model.StatusInProgress,
+	"doing":       model.StatusInProgress,
+	"blocked":     model.StatusBlocked,
+	"closed":      model.StatusClosed,
+	"done":        model.StatusClosed,
+}
+
+// LoadIssuesFromMarkdownDir reads one issue per Markdown file (.md or
+// .markdown) under dir, from its YAML front matter, with the body as the
+// description:
+//
+//	---
+//	id: web-12            # default: the file name without its extension
+//	title: Fix login      # default: the body's first "# " heading, else the file name
+//	status: in_progress   # open, in_progress, blocked or closed (todo, doing, done too)
+//	priority: 1           # 0-4 or P0-P4, default 2
+//	type: bug             # default task
+//	assignee: alice
+//	labels: [auth, web]
+//	blocked_by: [web-3]   # IDs of the issues it waits on
+//	parent: web-1
+//	due: 2025-07-01
+//	---
+//
+// Files without front matter are not issues and are left out; hidden
+// directories are not searched. Front matter keys bv doesn't know are kept
+// in the issue's Extra fields. Files that fail to parse, or whose issue is
+// invalid, are collected in Skipped.
+func LoadIssuesFromMarkdownDir(dir string) (MarkdownResult, error) {
+	var res MarkdownResult
+	if info, err := os.Stat(dir); err != nil {
+		return res, fmt.Errorf("reading issues directory: %w", err)
+	} else if !info.IsDir() {
+		return res, fmt.Errorf("%s is not a directory", dir)
+	}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ext := strings.ToLower(filepath.Ext(path)); ext != ".md" && ext != ".markdown" {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			res.Skipped = append(res.Skipped, FileError{Path: rel, Err: err})
+			return nil
+		}
+		var modified time.Time
+		if info, err := d.Info(); err == nil {
+			modified = info.ModTime()
+		}
+		issue, ok, err := parseMarkdownIssue(data, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), modified)
+		switch {
+		case err != nil:
+			res.Skipped = append(res.Skipped, FileError{Path: rel, Err: err})
+		case ok:
+			res.Issues = append(res.Issues, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return res, fmt.Errorf("reading issues directory: %w", err)
+	}
+	return res, nil
+}
+
+// splitFrontMatter returns the YAML between the leading "---" lines of data
+// and the body after it; ok is false when data has no front matter
+func splitFrontMatter(data []byte) (front, body []byte, ok bool) {
+	data = bytes.ReplaceAll(stripBOM(data), []byte("\r\n"), []byte("\n"))
+	rest, found := bytes.CutPrefix(data, []byte("---\n"))
+	if !found {
+		return nil, data, false
+	}
+	// The front matter ends at a "---" or "..." line
+	for start := 0; start < len(rest); {
+		line, next := rest[start:], len(rest)
+		if end := bytes.IndexByte(line, '\n'); end >= 0 {
+			line, next = line[:end], start+end+1
+		}
+		if s := string(line); s == "---" || s == "..." {
+			return rest[:start], rest[next:], true
+		}
+		start = next
+	}
+	return nil, data, false
+}
+
+// parseMarkdownIssue builds an issue from a Markdown file's front matter and
+// body. name is the file name without its extension and modified its mtime,
+// the defaults for the ID and updated time. ok is false for a file without
+// front matter.
+func parseMarkdownIssue(data []byte, name string, modified time.Time) (model.Issue, bool, error) {
+	front, body, ok := splitFrontMatter(data)
+	if !ok {
+		return model.Issue{}, false, nil
+	}
+	fields := map[string]any{}
+	if err := yaml.Unmarshal(front, &fields); err != nil {
+		return model.Issue{}, true, fmt.Errorf("front matter: %w", err)
+	}
+
+	issue := model.Issue{
+		ID:        name,
+		Status:    model.StatusOpen,
+		Priority:  2,
+		IssueType: model.TypeTask,
+		UpdatedAt: modified,
+	}
+	description := strings.TrimSpace(string(body))
+	var errs []error
+	fail := func(key string, value any, want string) {
+		errs = append(errs, fmt.Errorf("%s: expected %s, got %v", key, want, value))
+	}
+	for _, key := range slices.Sorted(maps.Keys(fields)) { // Errors in a stable order
+		value := fields[key]
+		if value == nil {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "id":
+			issue.ID = fmt.Sprint(value)
+		case "title":
+			issue.Title = fmt.Sprint(value)
+		case "status":
+			s, found := markdownStatuses[strings.ToLower(fmt.Sprint(value))]
+			if !found {
+				fail(key, value, "open, in_progress, blocked or closed")
+			}
+			issue.Status = s
+		case "priority":
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(fmt.Sprint(value)), "P"))
+			if err != nil || p < 0 || p > 4 {
+				fail(key, value, "0-4 or P0-P4")
+			}
+			issue.Priority = p
+		case "type", "issue_type":
+			issue.IssueType = model.IssueType(strings.ToLower(fmt.Sprint(value)))
+		case "assignee", "owner":
+			issue.Assignee = strings.TrimPrefix(fmt.Sprint(value), "@")
+		case "labels", "tags":
+			issue.Labels = stringList(value)
+		case "sprint":
+			issue.Sprint = fmt.Sprint(value)
+		case "milestone":
+			issue.Milestone = fmt.Sprint(value)
+		case "estimate", "estimated_minutes":
+			n, err := strconv.Atoi(fmt.Sprint(value))
+			if err != nil || n < 0 {
+				fail(key, value, "minutes")
+			}
+			issue.EstimatedMinutes = &n
+		case "blocked_by", "depends_on":
+			for _, id := range stringList(value) {
+				issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+			}
+		case "parent":
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{DependsOnID: fmt.Sprint(value), Type: model.DepParentChild})
+		case "created", "created_at":
+			issue.CreatedAt = frontMatterTime(key, value, fail)
+		case "updated", "updated_at":
+			issue.UpdatedAt = frontMatterTime(key, value, fail)
+		case "closed", "closed_at":
+			t := frontMatterTime(key, value, fail)
+			issue.ClosedAt = &t
+		case "due", "due_date":
+			t := frontMatterTime(key, value, fail)
+			issue.DueDate = &t
+		case "description":
+			description = strings.TrimSpace(fmt.Sprint(value) + "\n\n" + description)
+		default:
+			raw, err := json.Marshal(jsonValue(value))
+			if err != nil {
+				fail(key, value, "a plain value")
+				continue
+			}
+			if issue.Extra == nil {
+				issue.Extra = make(map[string]json.RawMessage)
+			}
+			issue.Extra[key] = raw
+		}
+	}
+
+	// Without a title, the first heading is the title, else the file name
+	if issue.Title == "" {
+		heading, rest, _ := strings.Cut(description, "\n")
+		if title, ok := strings.CutPrefix(heading, "# "); ok {
+			issue.Title = strings.TrimSpace(title)
+			description = strings.TrimSpace(rest)
+		} else {
+			issue.Title = name
+		}
+	}
+	issue.Description = description
+	for _, dep := range issue.Dependencies {
+		dep.IssueID = issue.ID
+	}
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = issue.UpdatedAt
+	}
+	if issue.UpdatedAt.Before(issue.CreatedAt) {
+		issue.UpdatedAt = issue.CreatedAt // The file may have been copied since
+	}
+	if issue.ClosedAt == nil && issue.Status == model.StatusClosed {
+		closed := issue.UpdatedAt
+		issue.ClosedAt = &closed
+	}
+	if len(errs) > 0 {
+		return issue, true, errors.Join(errs...)
+	}
+	return issue, true, issue.Validate()
+}
+
+// stringList reads a front matter list, or a comma-separated string
+func stringList(value any) []string {
+	var items []string
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+				items = append(items, s)
+			}
+		}
+	default:
+		for _, item := range strings.Split(fmt.Sprint(v), ",") {
+			if s := strings.TrimSpace(item); s != "" {
+				items = append(items, s)
+			}
+		}
+	}
+	return items
+}
+
+// frontMatterTime reads a date or time, which YAML may already have parsed
+func frontMatterTime(key string, value any, fail func(key string, value any, want string)) time.Time {
+	if t, ok := value.(time.Time); ok {
+		return t
+	}
+	s := fmt.Sprint(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t
+		}
+	}
+	fail(key, value, "a date like 2025-07-01")
+	return time.Time{}
+}
+
+// jsonValue converts a YAML value for encoding as JSON, whose objects need
+// string keys
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = jsonValue(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[fmt.Sprint(k)] = jsonValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = jsonValue(item)
+		}
+		return out
+	}
+	return value
+}
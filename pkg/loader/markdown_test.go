@@ -0,0 +1,90 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadIssuesFromMarkdownDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("web-12.md", `---
+title: Fix login redirect
+status: doing
+priority: P1
+type: bug
+assignee: "@alice"
+labels: [auth, web]
+blocked_by: [web-3]
+parent: web-1
+created: 2025-06-01
+due: 2025-07-01
+customer: Acme
+---
+After login the user lands on /404.
+`)
+	write("epics/web-1.markdown", "---\r\nid: web-1\r\ntype: epic\r\nstatus: done\r\ntags: web, q3\r\n---\r\n# Web revamp\r\n\r\nEverything web.\r\n")
+	write("web-3.md", "---\n---\nNo heading here.\n")
+	write("README.md", "# How we track issues\n")                  // No front matter: not an issue
+	write("notes.txt", "---\ntitle: not markdown\n---\n")          // Not Markdown
+	write(".drafts/web-9.md", "---\ntitle: Hidden\n---\n")         // Hidden directory
+	write("broken.md", "---\nstatus: someday\npriority: 7\n---\n") // Invalid values
+
+	res, err := loader.LoadIssuesFromMarkdownDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0].Path != "broken.md" ||
+		res.Skipped[0].Error() != "broken.md: priority: expected 0-4 or P0-P4, got 7\nstatus: expected open, in_progress, blocked or closed, got someday" {
+		t.Errorf("expected broken.md skipped, got %v", res.Skipped)
+	}
+	byID := map[string]model.Issue{}
+	for _, issue := range res.Issues {
+		byID[issue.ID] = issue
+	}
+	if len(res.Issues) != 3 {
+		t.Fatalf("expected 3 issues, got %+v", res.Issues)
+	}
+
+	bug := byID["web-12"]
+	if bug.Title != "Fix login redirect" || bug.Status != model.StatusInProgress || bug.Priority != 1 ||
+		bug.IssueType != model.TypeBug || bug.Assignee != "alice" || strings.Join(bug.Labels, ",") != "auth,web" ||
+		bug.Description != "After login the user lands on /404." || bug.ExtraField("customer") != "Acme" {
+		t.Errorf("unexpected issue %+v", bug)
+	}
+	if len(bug.Dependencies) != 2 || *bug.Dependencies[0] != (model.Dependency{IssueID: "web-12", DependsOnID: "web-3", Type: model.DepBlocks}) ||
+		bug.Dependencies[1].DependsOnID != "web-1" || bug.Dependencies[1].Type != model.DepParentChild {
+		t.Errorf("unexpected dependencies %+v %+v", bug.Dependencies[0], bug.Dependencies[1])
+	}
+	if bug.CreatedAt.Format(time.DateOnly) != "2025-06-01" || bug.DueDate == nil || bug.DueDate.Format(time.DateOnly) != "2025-07-01" {
+		t.Errorf("unexpected dates: created %v, due %v", bug.CreatedAt, bug.DueDate)
+	}
+
+	epic := byID["web-1"]
+	if epic.Title != "Web revamp" || epic.Description != "Everything web." || epic.Status != model.StatusClosed ||
+		epic.ClosedAt == nil || strings.Join(epic.Labels, ",") != "web,q3" {
+		t.Errorf("unexpected epic %+v", epic)
+	}
+	if plain := byID["web-3"]; plain.Title != "web-3" || plain.Status != model.StatusOpen || plain.Priority != 2 || plain.IssueType != model.TypeTask {
+		t.Errorf("expected defaults, got %+v", plain)
+	}
+
+	if _, err := loader.LoadIssuesFromMarkdownDir(filepath.Join(dir, "web-3.md")); err == nil {
+		t.Error("expected a file to be refused")
+	}
+}
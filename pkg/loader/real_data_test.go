@@ -1,7 +1,10 @@
 package loader_test
 
 import (
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
@@ -49,3 +52,31 @@ func BenchmarkLoadLargeFile(b *testing.B) {
 		_, _ = loader.LoadIssuesFromFile(f)
 	}
 }
+
+// BenchmarkLoadSyntheticFile loads 2,000 issues, with and without fields bv
+// doesn't know, to keep an eye on what decoding Issue.Extra costs
+func BenchmarkLoadSyntheticFile(b *testing.B) {
+	for _, extra := range []string{"", `,"external_tracker":{"system":"jira","key":"OPS-1"}`} {
+		name := "known_fields"
+		if extra != "" {
+			name = "extra_fields"
+		}
+		b.Run(name, func(b *testing.B) {
+			var sb strings.Builder
+			for i := range 2000 {
+				fmt.Fprintf(&sb, `{"id":"bv-%d","title":"Issue %d","description":"Something to do","status":"open","priority":%d,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z","labels":["backend","ui"],"dependencies":[{"issue_id":"bv-%d","depends_on_id":"bv-%d","type":"blocks"}]%s}`+"\n",
+					i, i, i%5, i, i/2, extra)
+			}
+			path := filepath.Join(b.TempDir(), "beads.jsonl")
+			if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := loader.LoadIssuesFromFile(path); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
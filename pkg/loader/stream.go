@@ -0,0 +1,162 @@
+package loader
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// maxLineBytes caps a single JSONL line; longer lines are skipped, not fatal
+const maxLineBytes = 1024 * 1024 * 10 // 10MB
+
+// progressEvery is how many lines pass between progress callbacks
+const progressEvery = 500
+
+// LineError describes a line the loader skipped
+type LineError struct {
+	Line      int
+	Err       error
+	Malformed bool // Undecodable JSON or an overlong line; false for an issue that failed validation
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Progress is reported periodically while streaming issues
+type Progress struct {
+	BytesRead  int64
+	TotalBytes int64 // 0 when the size is unknown
+	Issues     int
+	Skipped    int
+}
+
+// Fraction returns how much of the input has been read (0..1), or 0 when the size is unknown
+func (p Progress) Fraction() float64 {
+	if p.TotalBytes <= 0 {
+		return 0
+	}
+	return min(float64(p.BytesRead)/float64(p.TotalBytes), 1)
+}
+
+// StreamResult holds the issues decoded by StreamIssues and the lines it skipped
+type StreamResult struct {
+	Issues  []model.Issue
+	Skipped []LineError
+}
+
+// StreamIssues decodes JSONL from r one line at a time, so only the current
+// line is held in memory alongside the decoded issues. Malformed, overlong and
+// invalid lines are collected in Skipped instead of aborting the load.
+// onProgress, if non-nil, is called every few hundred lines and once at the end.
+func StreamIssues(r io.Reader, totalBytes int64, onProgress func(Progress)) (StreamResult, error) {
+	var res StreamResult
+	br := bufio.NewReaderSize(r, 64*1024)
+	var buf []byte
+	var read int64
+	report := func() {
+		if onProgress != nil {
+			onProgress(Progress{BytesRead: read, TotalBytes: totalBytes, Issues: len(res.Issues), Skipped: len(res.Skipped)})
+		}
+	}
+
+	for lineNum := 1; ; lineNum++ {
+		line, n, tooLong, err := readLine(br, buf[:0])
+		buf = line
+		read += int64(n)
+		if err != nil && err != io.EOF {
+			return res, fmt.Errorf("error reading issues file: %w", err)
+		}
+		if n == 0 && err == io.EOF {
+			break
+		}
+
+		line = trimNewline(line)
+		if lineNum == 1 {
+			line = stripBOM(line)
+		}
+		switch {
+		case tooLong:
+			res.Skipped = append(res.Skipped, LineError{Line: lineNum, Err: errors.New("line exceeds 10MB"), Malformed: true})
+		case len(line) == 0:
+			// Blank line
+		case isMarker(line):
+			res.Skipped = append(res.Skipped, LineError{Line: lineNum, Err: ErrConflictMarker, Malformed: true})
+		default:
+			var issue model.Issue
+			if err := json.Unmarshal(line, &issue); err != nil {
+				res.Skipped = append(res.Skipped, LineError{Line: lineNum, Err: err, Malformed: true})
+			} else if err := issue.Validate(); err != nil {
+				res.Skipped = append(res.Skipped, LineError{Line: lineNum, Err: err})
+			} else {
+				res.Issues = append(res.Issues, issue)
+			}
+		}
+
+		if lineNum%progressEvery == 0 {
+			report()
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	report()
+	return res, nil
+}
+
+// readLine reads through the next newline, appending to buf. Lines longer than
+// maxLineBytes are consumed but not kept. n counts every byte consumed.
+func readLine(br *bufio.Reader, buf []byte) (line []byte, n int, tooLong bool, err error) {
+	for {
+		chunk, err := br.ReadSlice('\n')
+		n += len(chunk)
+		if !tooLong {
+			if len(buf)+len(chunk) > maxLineBytes+1 { // +1 for the newline
+				tooLong = true
+				buf = buf[:0]
+			} else {
+				buf = append(buf, chunk...)
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return buf, n, tooLong, err
+	}
+}
+
+// trimNewline drops a trailing \n or \r\n
+func trimNewline(b []byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == '\n' {
+		b = b[:len(b)-1]
+	}
+	if len(b) > 0 && b[len(b)-1] == '\r' {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// LoadIssuesFromFileWithProgress streams issues from path, reporting progress
+// and returning the lines that were skipped
+func LoadIssuesFromFileWithProgress(path string, onProgress func(Progress)) (StreamResult, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return StreamResult{}, fmt.Errorf("no beads issues found at %s", path)
+	}
+	if err != nil {
+		return StreamResult{}, fmt.Errorf("failed to open issues file: %w", err)
+	}
+	defer file.Close()
+
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+	return StreamIssues(file, size, onProgress)
+}
@@ -0,0 +1,95 @@
+package loader_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestStreamIssuesCollectsSkippedLines(t *testing.T) {
+	content := "\xEF\xBB\xBF" + `{"id":"1","title":"A","status":"open","issue_type":"task"}` + "\r\n" +
+		`{not json}` + "\n" +
+		"\n" +
+		`{"id":"","title":"No ID","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"2","title":"B","status":"open","issue_type":"task"}` // No trailing newline
+
+	res, err := loader.StreamIssues(strings.NewReader(content), int64(len(content)), nil)
+	if err != nil {
+		t.Fatalf("StreamIssues: %v", err)
+	}
+	if len(res.Issues) != 2 || res.Issues[0].ID != "1" || res.Issues[1].ID != "2" {
+		t.Fatalf("expected issues 1 and 2, got %+v", res.Issues)
+	}
+	if len(res.Skipped) != 2 {
+		t.Fatalf("expected 2 skipped lines, got %v", res.Skipped)
+	}
+	if res.Skipped[0].Line != 2 || !res.Skipped[0].Malformed {
+		t.Errorf("expected malformed JSON on line 2, got %+v", res.Skipped[0])
+	}
+	if res.Skipped[1].Line != 4 || res.Skipped[1].Malformed {
+		t.Errorf("expected invalid issue on line 4, got %+v", res.Skipped[1])
+	}
+	if !strings.HasPrefix(res.Skipped[1].Error(), "line 4: ") {
+		t.Errorf("unexpected error text %q", res.Skipped[1].Error())
+	}
+}
+
+func TestStreamIssuesSkipsOverlongLine(t *testing.T) {
+	huge := `{"id":"big","title":"Big","status":"open","issue_type":"task","description":"` +
+		strings.Repeat("x", 11*1024*1024) + `"}`
+	content := huge + "\n" + `{"id":"ok","title":"OK","status":"open","issue_type":"task"}` + "\n"
+
+	res, err := loader.StreamIssues(strings.NewReader(content), int64(len(content)), nil)
+	if err != nil {
+		t.Fatalf("expected overlong line to be skipped, got error: %v", err)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].ID != "ok" {
+		t.Fatalf("expected only the short issue, got %d issues", len(res.Issues))
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0].Line != 1 || !res.Skipped[0].Malformed {
+		t.Errorf("expected line 1 skipped as malformed, got %v", res.Skipped)
+	}
+}
+
+func TestLoadIssuesFromFileWithProgress(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 1200; i++ {
+		fmt.Fprintf(&b, `{"id":"i-%d","title":"Issue %d","status":"open","issue_type":"task"}`+"\n", i, i)
+	}
+	path := filepath.Join(t.TempDir(), "beads.jsonl")
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var updates []loader.Progress
+	res, err := loader.LoadIssuesFromFileWithProgress(path, func(p loader.Progress) {
+		updates = append(updates, p)
+	})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(res.Issues) != 1200 {
+		t.Fatalf("expected 1200 issues, got %d", len(res.Issues))
+	}
+	// Every 500 lines plus the final report
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 progress updates, got %d", len(updates))
+	}
+	for i := 1; i < len(updates); i++ {
+		if updates[i].BytesRead < updates[i-1].BytesRead {
+			t.Errorf("progress went backwards: %+v", updates)
+		}
+	}
+	last := updates[len(updates)-1]
+	if last.Issues != 1200 || last.BytesRead != int64(b.Len()) || last.Fraction() != 1 {
+		t.Errorf("unexpected final progress %+v", last)
+	}
+
+	if _, err := loader.LoadIssuesFromFileWithProgress(filepath.Join(t.TempDir(), "missing.jsonl"), nil); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
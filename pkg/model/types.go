@@ -1,36 +1,216 @@
 package model
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 // Issue represents a trackable work item
 type Issue struct {
-	ID                 string        `json:"id"`
-	ContentHash        string        `json:"-"`
-	Title              string        `json:"title"`
-	Description        string        `json:"description"`
-	Design             string        `json:"design,omitempty"`
-	AcceptanceCriteria string        `json:"acceptance_criteria,omitempty"`
-	Notes              string        `json:"notes,omitempty"`
-	Status             Status        `json:"status"`
-	Priority           int           `json:"priority"`
-	IssueType          IssueType     `json:"issue_type"`
-	Assignee           string        `json:"assignee,omitempty"`
-	EstimatedMinutes   *int          `json:"estimated_minutes,omitempty"`
-	CreatedAt          time.Time     `json:"created_at"`
-	UpdatedAt          time.Time     `json:"updated_at"`
-	ClosedAt           *time.Time    `json:"closed_at,omitempty"`
-	ExternalRef        *string       `json:"external_ref,omitempty"`
-	CompactionLevel    int           `json:"compaction_level,omitempty"`
-	CompactedAt        *time.Time    `json:"compacted_at,omitempty"`
-	CompactedAtCommit  *string       `json:"compacted_at_commit,omitempty"`
-	OriginalSize       int           `json:"original_size,omitempty"`
-	Labels             []string      `json:"labels,omitempty"`
-	Dependencies       []*Dependency `json:"dependencies,omitempty"`
-	Comments           []*Comment    `json:"comments,omitempty"`
-	SourceRepo         string        `json:"source_repo,omitempty"`
+	ID                 string         `json:"id"`
+	ContentHash        string         `json:"-"`
+	Title              string         `json:"title"`
+	Description        string         `json:"description"`
+	Design             string         `json:"design,omitempty"`
+	AcceptanceCriteria string         `json:"acceptance_criteria,omitempty"`
+	Notes              string         `json:"notes,omitempty"`
+	Status             Status         `json:"status"`
+	Priority           int            `json:"priority"`
+	IssueType          IssueType      `json:"issue_type"`
+	Assignee           string         `json:"assignee,omitempty"`
+	EstimatedMinutes   *int           `json:"estimated_minutes,omitempty"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	StartedAt          *time.Time     `json:"started_at,omitempty"` // First moved to in_progress
+	ClosedAt           *time.Time     `json:"closed_at,omitempty"`
+	DueDate            *time.Time     `json:"due_date,omitempty"`
+	ExternalRef        *string        `json:"external_ref,omitempty"`
+	CompactionLevel    int            `json:"compaction_level,omitempty"`
+	CompactedAt        *time.Time     `json:"compacted_at,omitempty"`
+	CompactedAtCommit  *string        `json:"compacted_at_commit,omitempty"`
+	OriginalSize       int            `json:"original_size,omitempty"`
+	Labels             []string       `json:"labels,omitempty"`
+	Sprint             string         `json:"sprint,omitempty"`
+	Milestone          string         `json:"milestone,omitempty"`
+	Dependencies       []*Dependency  `json:"dependencies,omitempty"`
+	Comments           []*Comment     `json:"comments,omitempty"`
+	StatusHistory      []StatusChange `json:"status_history,omitempty"` // Oldest first, when the tracker records it
+	SourceRepo         string         `json:"source_repo,omitempty"`
+
+	// Extra holds the fields bv doesn't know, added by other tools, as
+	// written. They are encoded back with the issue, so nothing is lost.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// knownFields are the JSON names of Issue's fields, lowercased as
+// encoding/json matches them case-insensitively
+var knownFields = func() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(Issue{})
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			known[strings.ToLower(name)] = true
+		}
+	}
+	return known
+}()
+
+// UnmarshalJSON decodes an issue, keeping the fields it doesn't know in Extra
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	type plain Issue // Without the methods, so this doesn't recurse
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
+	}
+	i.Extra = unknownFields(data)
+	return nil
+}
+
+// unknownFields returns the top-level fields of the JSON object data that
+// aren't Issue's, as written, or nil when there are none (most issues).
+// data has already decoded as an issue, so this only has to track strings
+// and nesting to tell keys from values.
+func unknownFields(data []byte) map[string]json.RawMessage {
+	var extra map[string]json.RawMessage
+	depth := 0
+	expectKey := false
+	name, valueStart := "", -1 // The unknown field whose value is being skipped
+	endValue := func(end int) {
+		if valueStart < 0 {
+			return
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = json.RawMessage(bytes.Clone(bytes.TrimSpace(data[valueStart:end])))
+		valueStart = -1
+	}
+	for n := 0; n < len(data); n++ {
+		switch data[n] {
+		case '{':
+			depth++
+			expectKey = depth == 1
+		case '[':
+			depth++
+		case '}', ']':
+			if depth == 1 {
+				endValue(n)
+			}
+			depth--
+		case ',':
+			if depth == 1 {
+				endValue(n)
+				expectKey = true
+			}
+		case '"':
+			start := n
+			escaped := false
+			for n++; n < len(data) && data[n] != '"'; n++ {
+				if data[n] == '\\' {
+					escaped = true
+					n++
+				}
+			}
+			if !expectKey {
+				continue
+			}
+			expectKey = false
+			key := data[start+1 : n]
+			if knownFields[string(key)] {
+				continue
+			}
+			name = string(key)
+			if escaped {
+				_ = json.Unmarshal(data[start:n+1], &name) // Valid, as data decoded
+			}
+			if knownFields[strings.ToLower(name)] {
+				continue
+			}
+			// The value starts after the colon
+			for n++; n < len(data) && data[n] != ':'; n++ {
+			}
+			valueStart = n + 1
+		}
+	}
+	return extra
+}
+
+// MarshalJSON encodes an issue with its Extra fields, sorted by name
+func (i Issue) MarshalJSON() ([]byte, error) {
+	type plain Issue
+	data, err := json.Marshal(plain(i))
+	if err != nil || len(i.Extra) == 0 {
+		return data, err
+	}
+	names := make([]string, 0, len(i.Extra))
+	for name := range i.Extra {
+		if !knownFields[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1]) // Up to the closing brace
+	for _, name := range names {
+		key, _ := json.Marshal(name)
+		b.WriteByte(',')
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(i.Extra[name])
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MinutesPerPoint converts a story-point estimate to minutes: a point counts
+// as half a working day
+const MinutesPerPoint = 240
+
+// PointFields are the custom fields read as a story-point estimate, in order,
+// when an issue has no estimated_minutes
+var PointFields = []string{"estimate_points", "story_points", "points"}
+
+// EstimateMinutes returns the issue's estimate in minutes: estimated_minutes
+// when set, otherwise the first of PointFields holding a number, times
+// MinutesPerPoint. It reports false for an unestimated issue.
+func (i *Issue) EstimateMinutes() (int, bool) {
+	if i.EstimatedMinutes != nil {
+		return *i.EstimatedMinutes, true
+	}
+	for _, name := range PointFields {
+		if points, err := strconv.ParseFloat(i.ExtraField(name), 64); err == nil && points >= 0 {
+			return int(points*MinutesPerPoint + 0.5), true
+		}
+	}
+	return 0, false
+}
+
+// ExtraField returns the extra field name as text: strings as they are,
+// other values as JSON, and "" when the issue doesn't have it
+func (i *Issue) ExtraField(name string) string {
+	raw, ok := i.Extra[name]
+	if !ok {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return ""
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	return compact.String()
 }
 
 // Validate checks if the issue data is logically valid
@@ -53,6 +233,65 @@ func (i *Issue) Validate() error {
 	return nil
 }
 
+// IsOverdue returns true if the issue is still open and the calendar day of its
+// due date has passed. Due dates are usually date-only, so an issue due today is
+// not overdue until the day is over.
+func (i *Issue) IsOverdue(now time.Time) bool {
+	if i.DueDate == nil || i.Status.IsClosed() {
+		return false
+	}
+	return !now.Before(endOfDay(*i.DueDate))
+}
+
+// IsDueWithin returns true if the issue is still open, not yet overdue, and falls
+// due no later than now+d.
+func (i *Issue) IsDueWithin(now time.Time, d time.Duration) bool {
+	if i.DueDate == nil || i.Status.IsClosed() || i.IsOverdue(now) {
+		return false
+	}
+	return !i.DueDate.After(now.Add(d))
+}
+
+// endOfDay returns midnight at the end of t's calendar day (in t's location)
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+}
+
+// SprintName returns the sprint (iteration) the issue belongs to. The sprint field
+// wins; otherwise a label such as "sprint:24" or "sprint-24" names the sprint.
+// Returns "" if the issue is not in a sprint.
+func (i *Issue) SprintName() string {
+	if i.Sprint != "" {
+		return i.Sprint
+	}
+	return i.labelValue("sprint")
+}
+
+// MilestoneName returns the milestone the issue belongs to. The milestone field
+// wins; otherwise a label such as "milestone:v1.0" or "milestone-v1.0" names it.
+// Returns "" if the issue has no milestone.
+func (i *Issue) MilestoneName() string {
+	if i.Milestone != "" {
+		return i.Milestone
+	}
+	return i.labelValue("milestone")
+}
+
+// labelValue returns the value of the first "<key>:<value>" or "<key>-<value>"
+// label (key matched case-insensitively), or "" if there is none
+func (i *Issue) labelValue(key string) string {
+	for _, label := range i.Labels {
+		if len(label) <= len(key)+1 || !strings.EqualFold(label[:len(key)], key) {
+			continue
+		}
+		if sep := label[len(key)]; sep == ':' || sep == '-' {
+			return label[len(key)+1:]
+		}
+	}
+	return ""
+}
+
 // Status represents the current state of an issue
 type Status string
 
@@ -121,8 +360,33 @@ const (
 	DepDiscoveredFrom DependencyType = "discovered-from"
 )
 
-// IsValid returns true if the dependency type is a recognized value
-func (d DependencyType) IsValid() bool {
+// customDepTypes holds the types declared with SetCustomDependencyTypes,
+// each mapped to whether it blocks
+var customDepTypes atomic.Pointer[map[DependencyType]bool]
+
+// SetCustomDependencyTypes declares dependency types beyond the built-in
+// ones, each mapped to whether it blocks like "blocks" does. Built-in types
+// keep their meaning.
+func SetCustomDependencyTypes(types map[DependencyType]bool) {
+	custom := make(map[DependencyType]bool, len(types))
+	for t, blocking := range types {
+		if !t.IsBuiltin() {
+			custom[t] = blocking
+		}
+	}
+	customDepTypes.Store(&custom)
+}
+
+// CustomDependencyTypes returns the declared custom types and whether each blocks
+func CustomDependencyTypes() map[DependencyType]bool {
+	if custom := customDepTypes.Load(); custom != nil {
+		return *custom
+	}
+	return nil
+}
+
+// IsBuiltin returns true if the dependency type is one beads defines
+func (d DependencyType) IsBuiltin() bool {
 	switch d {
 	case DepBlocks, DepRelated, DepParentChild, DepDiscoveredFrom:
 		return true
@@ -130,9 +394,35 @@ func (d DependencyType) IsValid() bool {
 	return false
 }
 
+// IsValid returns true if the dependency type is a recognized value: built
+// in or declared with SetCustomDependencyTypes
+func (d DependencyType) IsValid() bool {
+	if d.IsBuiltin() {
+		return true
+	}
+	_, ok := CustomDependencyTypes()[d]
+	return ok
+}
+
 // IsBlocking returns true if this dependency type represents a blocking relationship
 func (d DependencyType) IsBlocking() bool {
-	return d == DepBlocks
+	if d == DepBlocks {
+		return true
+	}
+	return !d.IsBuiltin() && CustomDependencyTypes()[d]
+}
+
+// IsBlockingOrLegacy is IsBlocking, also counting the empty type that older
+// beads files use for blocks
+func (d DependencyType) IsBlockingOrLegacy() bool {
+	return d == "" || d.IsBlocking()
+}
+
+// StatusChange records an issue entering a status
+type StatusChange struct {
+	Status Status    `json:"status"`
+	At     time.Time `json:"at"`
+	By     string    `json:"by,omitempty"`
 }
 
 // Comment represents a comment on an issue
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -131,6 +134,28 @@ func TestDependencyType_IsBlocking(t *testing.T) {
 	}
 }
 
+func TestCustomDependencyTypes(t *testing.T) {
+	SetCustomDependencyTypes(map[DependencyType]bool{
+		"supersedes": true,
+		"duplicates": false,
+		DepRelated:   true, // Built-in types keep their meaning
+	})
+	defer SetCustomDependencyTypes(nil)
+
+	if !DependencyType("supersedes").IsValid() || !DependencyType("supersedes").IsBlocking() {
+		t.Error("expected supersedes to be a valid blocking type")
+	}
+	if !DependencyType("duplicates").IsValid() || DependencyType("duplicates").IsBlocking() {
+		t.Error("expected duplicates to be a valid non-blocking type")
+	}
+	if DepRelated.IsBlocking() {
+		t.Error("expected related to stay non-blocking")
+	}
+	if DependencyType("causes").IsValid() || DependencyType("causes").IsBuiltin() {
+		t.Error("expected undeclared types to stay invalid")
+	}
+}
+
 func TestIssue_Struct(t *testing.T) {
 	// This test verifies that we can construct an Issue with valid data
 	now := time.Now()
@@ -284,3 +309,155 @@ func TestIssue_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestIssue_DueDate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	inThreeDays := now.Add(3 * 24 * time.Hour)
+	nextMonth := now.Add(30 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		issue       Issue
+		wantOverdue bool
+		wantDueWeek bool
+	}{
+		{"NoDueDate", Issue{Status: StatusOpen}, false, false},
+		{"Overdue", Issue{Status: StatusOpen, DueDate: &yesterday}, true, false},
+		{"OverdueButClosed", Issue{Status: StatusClosed, DueDate: &yesterday}, false, false},
+		{"DueSoon", Issue{Status: StatusInProgress, DueDate: &inThreeDays}, false, true},
+		{"DueLater", Issue{Status: StatusOpen, DueDate: &nextMonth}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.IsOverdue(now); got != tt.wantOverdue {
+				t.Errorf("IsOverdue() = %v, want %v", got, tt.wantOverdue)
+			}
+			if got := tt.issue.IsDueWithin(now, 7*24*time.Hour); got != tt.wantDueWeek {
+				t.Errorf("IsDueWithin(7d) = %v, want %v", got, tt.wantDueWeek)
+			}
+		})
+	}
+}
+
+func TestIssue_DueTodayNotOverdue(t *testing.T) {
+	due := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	issue := Issue{Status: StatusOpen, DueDate: &due}
+
+	if issue.IsOverdue(due.Add(23 * time.Hour)) {
+		t.Error("issue due today should not be overdue before the day ends")
+	}
+	if !issue.IsDueWithin(due.Add(23*time.Hour), 0) {
+		t.Error("issue due today should count as due")
+	}
+	if !issue.IsOverdue(due.Add(24 * time.Hour)) {
+		t.Error("issue should be overdue once its due day has passed")
+	}
+}
+
+func TestIssue_SprintName(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue Issue
+		want  string
+	}{
+		{"None", Issue{Labels: []string{"ui"}}, ""},
+		{"Field", Issue{Sprint: "2025-W10", Labels: []string{"sprint:9"}}, "2025-W10"},
+		{"ColonLabel", Issue{Labels: []string{"ui", "sprint:12"}}, "12"},
+		{"DashLabel", Issue{Labels: []string{"Sprint-Alpha"}}, "Alpha"},
+		{"BarePrefix", Issue{Labels: []string{"sprint-"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.issue.SprintName(); got != tt.want {
+				t.Errorf("SprintName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssue_MilestoneName(t *testing.T) {
+	if got := (&Issue{Milestone: "v2", Labels: []string{"milestone:v1"}}).MilestoneName(); got != "v2" {
+		t.Errorf("expected field to win, got %q", got)
+	}
+	if got := (&Issue{Labels: []string{"ui", "Milestone:v1.0"}}).MilestoneName(); got != "v1.0" {
+		t.Errorf("expected label convention, got %q", got)
+	}
+	if got := (&Issue{Labels: []string{"milestones"}}).MilestoneName(); got != "" {
+		t.Errorf("expected no milestone, got %q", got)
+	}
+}
+
+func TestIssueKeepsExtraFields(t *testing.T) {
+	line := `{"id":"A","title":"T","status":"open","issue_type":"task","customer":"Acme","story_points":5,"links":{"pr":"#12"}}`
+	var issue Issue
+	if err := json.Unmarshal([]byte(line), &issue); err != nil {
+		t.Fatal(err)
+	}
+	if len(issue.Extra) != 3 || issue.ExtraField("customer") != "Acme" || issue.ExtraField("story_points") != "5" ||
+		issue.ExtraField("links") != `{"pr":"#12"}` || issue.ExtraField("missing") != "" {
+		t.Fatalf("unexpected extra fields %v", issue.Extra)
+	}
+
+	// Encoding keeps them, after the known fields
+	data, err := json.Marshal(issue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), `,"customer":"Acme","links":{"pr":"#12"},"story_points":5}`) {
+		t.Errorf("expected the extra fields written back, got %s", data)
+	}
+	var again Issue
+	if err := json.Unmarshal(data, &again); err != nil || !reflect.DeepEqual(again, issue) {
+		t.Errorf("expected a round trip, got %+v, %v", again, err)
+	}
+
+	// Without unknown fields there is no Extra
+	var plain Issue
+	if err := json.Unmarshal([]byte(`{"id":"B","Title":"T"}`), &plain); err != nil || plain.Extra != nil || plain.Title != "T" {
+		t.Errorf("expected no extra fields, got %+v, %v", plain, err)
+	}
+}
+
+func TestIssueExtraFieldsScanOnlyTopLevelKeys(t *testing.T) {
+	line := `{ "id" : "A", "title":"a \"quoted\", {braced} [title]",` +
+		`"links" : { "customer": 1, "list": [ {"x": "}"} ] } ,` +
+		`"description":"customer: \\", "cust\u006fmer" : [1, "a,b"] ,` +
+		`"LABELS":["x"], "last":null }`
+	var issue Issue
+	if err := json.Unmarshal([]byte(line), &issue); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]json.RawMessage{
+		"links":    json.RawMessage(`{ "customer": 1, "list": [ {"x": "}"} ] }`),
+		"customer": json.RawMessage(`[1, "a,b"]`),
+		"last":     json.RawMessage(`null`),
+	}
+	if !reflect.DeepEqual(issue.Extra, want) {
+		t.Errorf("unexpected extra fields %q", issue.Extra)
+	}
+	if issue.Title != `a "quoted", {braced} [title]` || len(issue.Labels) != 1 {
+		t.Errorf("expected the known fields decoded, got %+v", issue)
+	}
+}
+
+func TestIssueEstimateMinutes(t *testing.T) {
+	est := 90
+	tests := []struct {
+		issue Issue
+		want  int
+		ok    bool
+	}{
+		{Issue{}, 0, false},
+		{Issue{EstimatedMinutes: &est, Extra: map[string]json.RawMessage{"points": json.RawMessage(`3`)}}, 90, true},
+		{Issue{Extra: map[string]json.RawMessage{"story_points": json.RawMessage(`1.5`)}}, 360, true},
+		{Issue{Extra: map[string]json.RawMessage{"points": json.RawMessage(`"2"`)}}, 480, true},
+		{Issue{Extra: map[string]json.RawMessage{"points": json.RawMessage(`"large"`)}}, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := tt.issue.EstimateMinutes(); got != tt.want || ok != tt.ok {
+			t.Errorf("EstimateMinutes(%v) = %d, %v; want %d, %v", tt.issue.Extra, got, ok, tt.want, tt.ok)
+		}
+	}
+}
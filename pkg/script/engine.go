@@ -0,0 +1,267 @@
+package script
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Variables describes every name a script can read. Metrics are 0 until
+// background analysis finishes; values that don't apply are None.
+var Variables = map[string]string{
+	"id":               "Issue ID",
+	"title":            "Title",
+	"status":           "open, in_progress, blocked or closed",
+	"type":             "Issue type (bug, feature, task, epic, chore)",
+	"priority":         "Priority number, 0 is most urgent",
+	"assignee":         "Assignee, \"\" when unassigned",
+	"labels":           "List of labels",
+	"sprint":           "Sprint name, \"\" when none",
+	"milestone":        "Milestone name, \"\" when none",
+	"comments":         "Number of comments",
+	"estimate":         "Estimated minutes (story points converted), None when not estimated",
+	"age_days":         "Days since the issue was created",
+	"updated_days":     "Days since the last update",
+	"due_days":         "Days until due (negative when overdue), None without a due date",
+	"blockers":         "Open issues blocking this one",
+	"dependents":       "Issues that depend on this one",
+	"dependencies":     "Issues this one depends on",
+	"pagerank":         "PageRank",
+	"betweenness":      "Betweenness centrality",
+	"critical_path":    "Critical path depth (the list's built-in impact)",
+	"eigenvector":      "Eigenvector centrality",
+	"hub":              "HITS hub score",
+	"authority":        "HITS authority score",
+	"pagerank_norm":    "PageRank scaled so the highest issue is 1",
+	"betweenness_norm": "Betweenness scaled so the highest issue is 1",
+	"critical_norm":    "Critical path depth scaled so the deepest issue is 1",
+}
+
+// VariableNames lists the script variables, sorted
+func VariableNames() []string {
+	names := make([]string, 0, len(Variables))
+	for name := range Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Column is a computed list column
+type Column struct {
+	Name    string
+	Program *Program
+}
+
+// Result holds one issue's script values; nil where a script isn't set or
+// failed
+type Result struct {
+	Impact  any
+	Sort    any
+	Columns []any // In Engine.Columns order
+}
+
+// Engine evaluates the configured scripts per issue and caches the results
+// until the issues or their metrics change
+type Engine struct {
+	Impact  *Program // Replaces the list's impact score
+	Sort    *Program // Key for the "Custom" list sort
+	Columns []Column // Extra list columns
+
+	stats    *analysis.GraphStats
+	phase2   bool
+	issueMap map[string]*model.Issue
+	now      time.Time
+	maxPR    float64
+	maxBW    float64
+	maxCP    float64
+
+	cache    map[string]*Result
+	errCount int
+	firstErr error
+}
+
+// Empty reports whether no scripts are configured
+func (e *Engine) Empty() bool {
+	return e == nil || (e.Impact == nil && e.Sort == nil && len(e.Columns) == 0)
+}
+
+// Bind points the engine at a set of issues and their analysis. The cache
+// is kept while stats is the same and its phase 2 state hasn't changed, so
+// calling Bind before every use is cheap; see Invalidate.
+func (e *Engine) Bind(issues []model.Issue, stats *analysis.GraphStats) {
+	if e == nil {
+		return
+	}
+	ready := stats != nil && stats.IsPhase2Ready()
+	if e.cache != nil && stats == e.stats && ready == e.phase2 {
+		return
+	}
+	e.stats, e.phase2 = stats, ready
+	e.now = time.Now()
+	e.cache = make(map[string]*Result, len(issues))
+	e.errCount, e.firstErr = 0, nil
+	e.issueMap = make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		e.issueMap[issues[i].ID] = &issues[i]
+	}
+	e.maxPR, e.maxBW, e.maxCP = 0, 0, 0
+	if stats != nil {
+		e.maxPR = maxValue(stats.PageRank())
+		e.maxBW = maxValue(stats.Betweenness())
+		e.maxCP = maxValue(stats.CriticalPathScore())
+	}
+}
+
+// Invalidate drops the cached results, so the next Bind recomputes them.
+// Call it when the issues change but their analysis may not, as when a
+// reload only edits fields the graph doesn't depend on.
+func (e *Engine) Invalidate() {
+	if e != nil {
+		e.cache = nil
+	}
+}
+
+func maxValue(m map[string]float64) float64 {
+	max := 0.0
+	for _, v := range m {
+		max = math.Max(max, v)
+	}
+	return max
+}
+
+// Eval returns issue's script values, computing them on first use. A
+// script that fails for an issue yields nil; see Errors.
+func (e *Engine) Eval(issue model.Issue) *Result {
+	if e.Empty() {
+		return &Result{}
+	}
+	if e.cache == nil {
+		e.Bind(nil, nil)
+	}
+	if r, ok := e.cache[issue.ID]; ok {
+		return r
+	}
+	args := arguments(e.vars(issue))
+	run := func(name string, p *Program) any {
+		if p == nil {
+			return nil
+		}
+		v, err := p.call(args)
+		if err != nil {
+			e.errCount++
+			if e.firstErr == nil {
+				e.firstErr = fmt.Errorf("%s script on %s: %w", name, issue.ID, err)
+			}
+			return nil
+		}
+		return v
+	}
+	r := &Result{Impact: run("impact", e.Impact), Sort: run("sort", e.Sort)}
+	for _, c := range e.Columns {
+		r.Columns = append(r.Columns, run(c.Name+" column", c.Program))
+	}
+	e.cache[issue.ID] = r
+	return r
+}
+
+// ImpactScore returns the custom impact for issue, or fallback when no
+// impact script is set or it didn't produce a number
+func (e *Engine) ImpactScore(issue model.Issue, fallback float64) float64 {
+	if e == nil || e.Impact == nil {
+		return fallback
+	}
+	if f, ok := e.Eval(issue).Impact.(float64); ok {
+		return f
+	}
+	return fallback
+}
+
+// Errors returns how many evaluations failed since the last rebind, and
+// the first failure
+func (e *Engine) Errors() (int, error) {
+	if e == nil {
+		return 0, nil
+	}
+	return e.errCount, e.firstErr
+}
+
+// vars builds the variables for one issue
+func (e *Engine) vars(issue model.Issue) map[string]any {
+	labels := make([]any, len(issue.Labels))
+	for i, l := range issue.Labels {
+		labels[i] = l
+	}
+	days := func(d time.Duration) int { return int(math.Floor(d.Hours() / 24)) }
+
+	v := map[string]any{
+		"id":           issue.ID,
+		"title":        issue.Title,
+		"status":       string(issue.Status),
+		"type":         string(issue.IssueType),
+		"priority":     issue.Priority,
+		"assignee":     issue.Assignee,
+		"labels":       labels,
+		"sprint":       issue.SprintName(),
+		"milestone":    issue.MilestoneName(),
+		"comments":     len(issue.Comments),
+		"estimate":     nil,
+		"age_days":     days(e.now.Sub(issue.CreatedAt)),
+		"updated_days": days(e.now.Sub(issue.UpdatedAt)),
+		"due_days":     nil,
+	}
+	if est, ok := issue.EstimateMinutes(); ok {
+		v["estimate"] = est
+	}
+	if issue.DueDate != nil {
+		// Whole days, counting from today's date rather than this instant
+		today := time.Date(e.now.Year(), e.now.Month(), e.now.Day(), 0, 0, 0, 0, e.now.Location())
+		due := issue.DueDate.In(e.now.Location())
+		due = time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, e.now.Location())
+		v["due_days"] = int(math.Round(due.Sub(today).Hours() / 24))
+	}
+
+	blockers, deps := 0, 0
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		deps++
+		if !dep.Type.IsBlocking() {
+			continue
+		}
+		if b, ok := e.issueMap[dep.DependsOnID]; ok && !b.Status.IsClosed() {
+			blockers++
+		}
+	}
+	v["blockers"] = blockers
+	v["dependencies"] = deps
+
+	s := e.stats
+	if s == nil {
+		s = &analysis.GraphStats{}
+	}
+	pr, bw, cp := s.GetPageRankScore(issue.ID), s.GetBetweennessScore(issue.ID), s.GetCriticalPathScore(issue.ID)
+	v["dependents"] = s.InDegree[issue.ID]
+	v["pagerank"] = pr
+	v["betweenness"] = bw
+	v["critical_path"] = cp
+	v["eigenvector"] = s.GetEigenvectorScore(issue.ID)
+	v["hub"] = s.GetHubScore(issue.ID)
+	v["authority"] = s.GetAuthorityScore(issue.ID)
+	v["pagerank_norm"] = norm(pr, e.maxPR)
+	v["betweenness_norm"] = norm(bw, e.maxBW)
+	v["critical_norm"] = norm(cp, e.maxCP)
+	return v
+}
+
+func norm(v, max float64) float64 {
+	if max <= 0 {
+		return 0
+	}
+	return v / max
+}
@@ -0,0 +1,343 @@
+// Package script evaluates small user-written Starlark expressions over an
+// issue and its graph metrics, for computed list columns, sort keys and a
+// custom impact formula. Expressions are plain Starlark (go.starlark.net):
+// the issue's fields are predeclared both as an issue struct (issue.priority)
+// and as bare names (priority), alongside the math module and a few helpers.
+package script
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	starlarkmath "go.starlark.net/lib/math"
+	"go.starlark.net/resolve"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+// maxSteps bounds the work one evaluation may do, so a runaway
+// comprehension can't hang the list
+const maxSteps = 1_000_000
+
+// fileOptions are the Starlark dialect scripts are written in
+var fileOptions = &syntax.FileOptions{}
+
+// Program is a compiled expression
+type Program struct {
+	src string
+	fn  *starlark.Function // lambda issue, <Variables...>: <src>
+}
+
+// Compile parses src and checks that every name it uses exists, so mistakes
+// surface when the config loads rather than per issue
+func Compile(src string) (*Program, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, fmt.Errorf("empty expression")
+	}
+	expr, err := fileOptions.ParseExpr("script", src, 0)
+	if err != nil {
+		return nil, positioned(err)
+	}
+	if _, err := resolve.ExprOptions(fileOptions, expr, isPredeclared, starlark.Universe.Has); err != nil {
+		return nil, positioned(err)
+	}
+
+	// Wrap the expression in a lambda taking the variables, so it compiles
+	// once and each issue is a call
+	params := append([]string{"issue"}, VariableNames()...)
+	lambda := "lambda " + strings.Join(params, ", ") + ": (\n" + src + "\n)"
+	outer, err := starlark.ExprFuncOptions(fileOptions, "script", lambda, helpers)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := starlark.Call(newThread(), outer, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{src: src, fn: fn.(*starlark.Function)}, nil
+}
+
+// String returns the source of the expression
+func (p *Program) String() string {
+	return p.src
+}
+
+// Eval evaluates the expression with the given variables (see Variables);
+// missing ones are None. The result is nil (None), a bool, float64, string
+// or []any.
+func (p *Program) Eval(vars map[string]any) (any, error) {
+	return p.call(arguments(vars))
+}
+
+// call evaluates the expression with arguments built by arguments
+func (p *Program) call(args starlark.Tuple) (any, error) {
+	v, err := starlark.Call(newThread(), p.fn, args, nil)
+	if err != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(err, &evalErr) {
+			return nil, errors.New(evalErr.Msg)
+		}
+		return nil, err
+	}
+	return fromStarlark(v)
+}
+
+func newThread() *starlark.Thread {
+	thread := &starlark.Thread{Name: "script", Print: func(*starlark.Thread, string) {}}
+	thread.SetMaxExecutionSteps(maxSteps)
+	return thread
+}
+
+// arguments turns vars into the lambda's arguments: the issue struct, then
+// each variable in VariableNames order
+func arguments(vars map[string]any) starlark.Tuple {
+	names := VariableNames()
+	fields := make(starlark.StringDict, len(names))
+	args := make(starlark.Tuple, 1, len(names)+1)
+	for _, name := range names {
+		v := toStarlark(vars[name])
+		fields[name] = v
+		args = append(args, v)
+	}
+	args[0] = starlarkstruct.FromStringDict(starlarkstruct.Default, fields)
+	return args
+}
+
+// positioned rewrites a Starlark syntax or resolve error as "col N: msg",
+// naming the line too when the expression spans several
+func positioned(err error) error {
+	var pos syntax.Position
+	var msg string
+	var syntaxErr syntax.Error
+	var resolveErrs resolve.ErrorList
+	switch {
+	case errors.As(err, &syntaxErr):
+		pos, msg = syntaxErr.Pos, syntaxErr.Msg
+	case errors.As(err, &resolveErrs):
+		pos, msg = resolveErrs[0].Pos, resolveErrs[0].Msg
+		if name, ok := strings.CutPrefix(msg, "undefined: "); ok {
+			msg = fmt.Sprintf("unknown name %q%s", name, suggest(name))
+		}
+	default:
+		return err
+	}
+	if pos.Line > 1 {
+		return fmt.Errorf("line %d col %d: %s", pos.Line, pos.Col, msg)
+	}
+	return fmt.Errorf("col %d: %s", pos.Col, msg)
+}
+
+// suggest names a known variable that contains name, to catch near misses
+// like "page_rank"
+func suggest(name string) string {
+	clean := strings.ReplaceAll(name, "_", "")
+	for _, v := range VariableNames() {
+		if strings.ReplaceAll(v, "_", "") == clean || strings.HasPrefix(v, name) {
+			return fmt.Sprintf(" (did you mean %s?)", v)
+		}
+	}
+	return ""
+}
+
+func isPredeclared(name string) bool {
+	if _, ok := Variables[name]; ok {
+		return true
+	}
+	return name == "issue" || helpers.Has(name)
+}
+
+// toStarlark converts a variable's Go value: nil, bool, int, float64,
+// string or []any. Lists are frozen so one script can't change what the
+// next one sees.
+func toStarlark(v any) starlark.Value {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None
+	case bool:
+		return starlark.Bool(v)
+	case int:
+		return starlark.MakeInt(v)
+	case float64:
+		return starlark.Float(v)
+	case string:
+		return starlark.String(v)
+	case []any:
+		items := make([]starlark.Value, len(v))
+		for i, item := range v {
+			items[i] = toStarlark(item)
+		}
+		list := starlark.NewList(items)
+		list.Freeze()
+		return list
+	}
+	return starlark.String(fmt.Sprint(v))
+}
+
+// fromStarlark converts a script's result, with every number as a float64
+func fromStarlark(v starlark.Value) (any, error) {
+	switch v := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		return float64(v.Float()), nil
+	case starlark.Float:
+		return float64(v), nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Indexable: // list or tuple
+		items := make([]any, v.Len())
+		for i := range items {
+			item, err := fromStarlark(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("result must be a number, string, bool, list or None, not %s", v.Type())
+}
+
+// Compare orders two numbers, two strings or two booleans, returning -1, 0
+// or 1. Other combinations are an error.
+func Compare(a, b any) (int, error) {
+	switch av := a.(type) {
+	case float64:
+		if bv, ok := b.(float64); ok {
+			switch {
+			case av < bv:
+				return -1, nil
+			case av > bv:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), nil
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0, nil
+			case !av:
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("can't compare %s with %s", typeName(a), typeName(b))
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "list"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// Format renders a value for display: whole numbers without decimals,
+// others to two places, None as "–"
+func Format(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "–"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatFloat(v, 'f', 0, 64)
+		}
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case string:
+		return v
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = Format(item)
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v)
+}
+
+// helpers are predeclared next to Starlark's built-ins: the math module,
+// and shorthands for what scripts reach for most
+var helpers = starlark.StringDict{
+	"math":  starlarkmath.Module,
+	"floor": starlarkmath.Module.Members["floor"],
+	"ceil":  starlarkmath.Module.Members["ceil"],
+	"sqrt":  starlarkmath.Module.Members["sqrt"],
+	"log":   starlarkmath.Module.Members["log"],
+	"pow":   starlarkmath.Module.Members["pow"],
+	"round": starlark.NewBuiltin("round", round),
+	"clamp": starlark.NewBuiltin("clamp", clamp),
+	"lower": starlark.NewBuiltin("lower", stringMethod("lower")),
+	"upper": starlark.NewBuiltin("upper", stringMethod("upper")),
+}
+
+// round(x, places=0) rounds half away from zero to places decimals
+func round(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x starlark.Value
+	places := 0
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "x", &x, "places?", &places); err != nil {
+		return nil, err
+	}
+	f, ok := starlark.AsFloat(x)
+	if !ok {
+		return nil, fmt.Errorf("%s: got %s, want number", b.Name(), x.Type())
+	}
+	scale := math.Pow(10, float64(places))
+	return starlark.Float(math.Round(f*scale) / scale), nil
+}
+
+// clamp(x, lo, hi) limits x to the range lo..hi
+func clamp(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var x, lo, hi starlark.Value
+	if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 3, &x, &lo, &hi); err != nil {
+		return nil, err
+	}
+	var f [3]float64
+	for i, v := range []starlark.Value{x, lo, hi} {
+		var ok bool
+		if f[i], ok = starlark.AsFloat(v); !ok {
+			return nil, fmt.Errorf("%s: got %s, want number", b.Name(), v.Type())
+		}
+	}
+	return starlark.Float(math.Min(math.Max(f[0], f[1]), f[2])), nil
+}
+
+// stringMethod calls the string method name on the one argument, so
+// lower(title) works like title.lower()
+func stringMethod(name string) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var s starlark.String
+		if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &s); err != nil {
+			return nil, err
+		}
+		method, err := s.Attr(name)
+		if err != nil {
+			return nil, err
+		}
+		return starlark.Call(thread, method, nil, nil)
+	}
+}
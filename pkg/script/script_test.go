@@ -0,0 +1,203 @@
+package script
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]any{
+		"priority": 1,
+		"labels":   []any{"backend", "urgent"},
+		"title":    "Fix login",
+		"due_days": nil,
+		"estimate": 90,
+		"blockers": 0,
+		"pagerank": 0.25,
+	}
+	tests := []struct {
+		src  string
+		want any
+	}{
+		{"1 + 2 * 3", 7.0},
+		{"(1 + 2) * 3", 9.0},
+		{"7 // 2", 3.0},
+		{"-7 % 3", 2.0},
+		{"10 / 4", 2.5},
+		{"-priority", -1.0},
+		{"(4 - priority) / 4", 0.75},
+		{"issue.priority + issue.pagerank", 1.25},
+		{"priority <= 1 and blockers == 0", true},
+		{"'urgent' in labels", true},
+		{"'ops' not in issue.labels", true},
+		{"'login' in lower(title)", true},
+		{"'login' in title.lower()", true},
+		{"due_days if due_days != None else 999", 999.0},
+		{"due_days or 999", 999.0},
+		{"not due_days", true},
+		{"labels[0]", "backend"},
+		{"labels[-1]", "urgent"},
+		{"len(labels) + len(title)", 11.0},
+		{"max(1, 5, 3)", 5.0},
+		{"min([x for x in [4, None, 2] if x != None])", 2.0},
+		{"round(estimate / 60, 1)", 1.5},
+		{"round(estimate / 60, 1) if estimate else None", 1.5},
+		{"clamp(estimate, 0, 60)", 60.0},
+		{"log(100, 10)", 2.0},
+		{"math.floor(pagerank * 10)", 2.0},
+		{"str(priority) + 'h'", "1h"},
+		{"[1, 2] + [3]", []any{1.0, 2.0, 3.0}},
+		{"'P' + str(priority) if priority < 2 else ''", "P1"},
+		{"True and 'x'", "x"},
+		{"(1 + # a comment inside the parentheses\n0.5)", 1.5},
+	}
+	for _, tt := range tests {
+		p, err := Compile(tt.src)
+		if err != nil {
+			t.Errorf("%q: compile error %v", tt.src, err)
+			continue
+		}
+		got, err := p.Eval(vars)
+		if err != nil {
+			t.Errorf("%q: eval error %v", tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q = %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := map[string]string{
+		"":                    "empty",
+		"1 +":                 "want primary expression",
+		"page_rank * 2":       "did you mean pagerank",
+		"frobnicate(1)":       `unknown name "frobnicate"`,
+		"'open":               "unexpected EOF in string",
+		"priority $ 2":        "unexpected input character '$'",
+		"(1 + 2":              "got end of file",
+		"priority priority":   "got identifier",
+		"max(1,, 2)":          "got ','",
+		"None = 1":            "got '='",
+		"(1\n+ frobnicate)":   "line 2 col",
+		"priority not labels": "got identifier",
+	}
+	for src, want := range tests {
+		_, err := Compile(src)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", src, want, err)
+		}
+	}
+}
+
+func TestRuntimeErrors(t *testing.T) {
+	vars := map[string]any{"due_days": nil, "labels": []any{}, "title": "x"}
+	for src, want := range map[string]string{
+		"due_days + 1":                   "unknown binary op: NoneType + int",
+		"1 / 0":                          "floating-point division by zero",
+		"labels[0]":                      "out of range",
+		"title < 1":                      "string < int not implemented",
+		"round()":                        "round: missing argument for x",
+		"lower('A', 'B')":                "lower: got 2 arguments, want 1",
+		"clamp(1, 2)":                    "clamp: got 2 arguments, want 3",
+		"1 in title":                     "'in <string>' requires string as left operand",
+		"float('abc')":                   "invalid float literal",
+		"{}":                             "result must be a number, string, bool, list or None, not dict",
+		"[x for x in range(1000000000)]": "too many steps",
+	} {
+		p, err := Compile(src)
+		if err != nil {
+			t.Fatalf("%q: %v", src, err)
+		}
+		if _, err := p.Eval(vars); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: expected error containing %q, got %v", src, want, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for v, want := range map[any]string{nil: "–", 3.0: "3", 1.234: "1.23", true: "True", "x": "x"} {
+		if got := Format(v); got != want {
+			t.Errorf("Format(%#v) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestEngineCachesPerIssue(t *testing.T) {
+	now := time.Now()
+	due := now.Add(72 * time.Hour)
+	est := 120
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, Priority: 0, CreatedAt: now.Add(-48 * time.Hour), UpdatedAt: now, DueDate: &due, EstimatedMinutes: &est},
+		{ID: "B", Title: "Leaf", Status: model.StatusOpen, Priority: 2, CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	e := &Engine{
+		Impact: mustCompile(t, "dependents * 10 + (4 - priority)"),
+		Sort:   mustCompile(t, "due_days if due_days != None else 999"),
+		Columns: []Column{
+			{Name: "age", Program: mustCompile(t, "age_days")},
+			{Name: "hours", Program: mustCompile(t, "estimate / 60")},
+		},
+	}
+	e.Bind(issues, &stats)
+
+	a := e.Eval(issues[0])
+	if a.Impact != 14.0 || a.Sort != 3.0 || !reflect.DeepEqual(a.Columns, []any{2.0, 2.0}) {
+		t.Errorf("unexpected values for A: %+v", a)
+	}
+	b := e.Eval(issues[1])
+	if b.Impact != 2.0 || b.Sort != 999.0 || b.Columns[1] != nil {
+		t.Errorf("unexpected values for B: %+v", b)
+	}
+	if n, err := e.Errors(); n != 1 || !strings.Contains(err.Error(), "hours column script on B") {
+		t.Errorf("expected one failure on B's estimate, got %d %v", n, err)
+	}
+	if e.ImpactScore(issues[1], -1) != 2.0 {
+		t.Errorf("expected the scripted impact")
+	}
+
+	// Same data: the cached result is reused
+	e.Bind(issues, &stats)
+	if e.Eval(issues[0]) != a {
+		t.Errorf("expected a cached result")
+	}
+	// Changed issues under the same analysis: recomputed once invalidated
+	issues[0].Priority = 1
+	e.Invalidate()
+	e.Bind(issues, &stats)
+	if r := e.Eval(issues[0]); r == a || r.Impact != 13.0 {
+		t.Errorf("expected a fresh result after Invalidate, got %+v", r)
+	}
+	// New analysis: recomputed
+	stats2 := analysis.NewAnalyzer(issues).Analyze()
+	e.Bind(issues, &stats2)
+	if e.Eval(issues[0]) == a {
+		t.Errorf("expected the cache to reset for new stats")
+	}
+	if n, _ := e.Errors(); n != 0 {
+		t.Errorf("expected error count to reset, got %d", n)
+	}
+
+	var none *Engine
+	if !none.Empty() || none.ImpactScore(issues[0], 0.5) != 0.5 {
+		t.Errorf("nil engine should fall back")
+	}
+}
+
+func mustCompile(t *testing.T, src string) *Program {
+	t.Helper()
+	p, err := Compile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
@@ -0,0 +1,221 @@
+// Package timefmt formats the times bv shows, in the TUI and in reports:
+// ages such as "3d ago" or absolute dates, in the user's date layout,
+// clock and time zone. Set installs the settings for the whole process.
+package timefmt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Styles are how ages show (time.style): "relative" as "3d ago",
+// "absolute" as the date
+var Styles = []string{"relative", "absolute"}
+
+// Clocks are the clock formats (time.clock)
+var Clocks = []string{"24h", "12h"}
+
+// DatePresets are the named date formats (time.date_format); any other
+// value is a Go layout such as "02 Jan 2006"
+var DatePresets = map[string]string{
+	"iso":   "2006-01-02",
+	"us":    "01/02/2006",
+	"eu":    "02.01.2006",
+	"short": "Jan 02",
+	"long":  "Mon Jan 2, 2006",
+}
+
+// Settings choose how times show
+type Settings struct {
+	Style string // One of Styles
+	Date  string // A DatePresets name or a Go layout
+	Clock string // One of Clocks
+	Zone  string // "local", "utc" or an IANA zone such as "Europe/Berlin"
+}
+
+// DefaultSettings returns the built-in settings: relative ages, ISO dates,
+// a 24-hour clock in the local time zone
+func DefaultSettings() Settings {
+	return Settings{Style: "relative", Date: "iso", Clock: "24h", Zone: "local"}
+}
+
+// PresetNames returns the DatePresets names, sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(DatePresets))
+	for name := range DatePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DateLayout returns the Go layout for a date_format value: a preset's
+// layout, or the value itself when it formats a date
+func DateLayout(date string) (string, error) {
+	if layout, ok := DatePresets[date]; ok {
+		return layout, nil
+	}
+	// A layout must change when formatted; plain text would print as is
+	if date == "" || time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC).Format(date) == date {
+		return "", fmt.Errorf("date format %q is neither a preset (%s) nor a Go layout such as \"02 Jan 2006\"",
+			date, strings.Join(PresetNames(), ", "))
+	}
+	return date, nil
+}
+
+// Location returns the time zone named zone
+func Location(zone string) (*time.Location, error) {
+	switch strings.ToLower(zone) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: expected local, utc or an IANA name such as Europe/Berlin", zone)
+	}
+	return loc, nil
+}
+
+// format is the installed settings, resolved
+type format struct {
+	absolute bool
+	date     string
+	clock    string
+	loc      *time.Location
+}
+
+var current atomic.Pointer[format]
+
+// defaultFormat applies until Set is called
+var defaultFormat = sync.OnceValue(func() *format {
+	f, _ := resolve(DefaultSettings())
+	return f
+})
+
+// Set installs s for every later call, after checking each setting
+func Set(s Settings) error {
+	f, err := resolve(s)
+	if err != nil {
+		return err
+	}
+	current.Store(f)
+	return nil
+}
+
+func resolve(s Settings) (*format, error) {
+	f := &format{}
+	switch s.Style {
+	case "relative", "":
+	case "absolute":
+		f.absolute = true
+	default:
+		return nil, fmt.Errorf("time style %q must be relative or absolute", s.Style)
+	}
+	var err error
+	if s.Date == "" {
+		s.Date = "iso"
+	}
+	if f.date, err = DateLayout(s.Date); err != nil {
+		return nil, err
+	}
+	switch s.Clock {
+	case "24h", "":
+		f.clock = "15:04"
+	case "12h":
+		f.clock = "3:04pm"
+	default:
+		return nil, fmt.Errorf("clock %q must be 24h or 12h", s.Clock)
+	}
+	if f.loc, err = Location(s.Zone); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func get() *format {
+	if f := current.Load(); f != nil {
+		return f
+	}
+	return defaultFormat()
+}
+
+// Absolute reports whether ages show as dates
+func Absolute() bool {
+	return get().absolute
+}
+
+// In returns t in the configured time zone
+func In(t time.Time) time.Time {
+	return t.In(get().loc)
+}
+
+// Date formats t's date in the configured layout and zone
+func Date(t time.Time) string {
+	f := get()
+	return t.In(f.loc).Format(f.date)
+}
+
+// Day formats a calendar date such as a due date in the configured layout,
+// leaving it in its own zone so it can't shift to the day before or after
+func Day(t time.Time) string {
+	return t.Format(get().date)
+}
+
+// Clock formats t's time of day
+func Clock(t time.Time) string {
+	f := get()
+	return t.In(f.loc).Format(f.clock)
+}
+
+// ClockWidth is the widest Clock can be, for aligning columns of times
+func ClockWidth() int {
+	return len(time.Date(2000, 1, 1, 22, 10, 0, 0, time.UTC).Format(get().clock))
+}
+
+// DateTime formats t's date and time of day
+func DateTime(t time.Time) string {
+	f := get()
+	return t.In(f.loc).Format(f.date + " " + f.clock)
+}
+
+// Age describes t as how long before now it was ("3d ago"), or as its
+// date when ages show as dates. Zero times are "unknown" and future ones
+// "now".
+func Age(t, now time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	if get().absolute {
+		return Date(t)
+	}
+	return Relative(t, now)
+}
+
+// Relative describes t as how long before now it was, whatever the style
+func Relative(t, now time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		// Future timestamps treated as now
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dw ago", int(d.Hours()/(24*7)))
+	default:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
+	}
+}
@@ -0,0 +1,70 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormats(t *testing.T) {
+	t.Cleanup(func() { _ = Set(DefaultSettings()) })
+	at := time.Date(2026, 3, 7, 22, 5, 0, 0, time.UTC)
+	now := at.Add(3 * 24 * time.Hour)
+
+	if err := Set(Settings{Style: "relative", Date: "iso", Clock: "24h", Zone: "utc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := Age(at, now); got != "3d ago" {
+		t.Errorf("relative age: got %q", got)
+	}
+	if got := DateTime(at); got != "2026-03-07 22:05" {
+		t.Errorf("ISO date and time: got %q", got)
+	}
+
+	if err := Set(Settings{Style: "absolute", Date: "us", Clock: "12h", Zone: "Asia/Tokyo"}); err != nil {
+		t.Fatal(err)
+	}
+	// 22:05 UTC is the next morning in Tokyo
+	if got := Age(at, now); got != "03/08/2026" {
+		t.Errorf("absolute age: got %q", got)
+	}
+	if got := Clock(at); got != "7:05am" {
+		t.Errorf("12-hour clock: got %q", got)
+	}
+	if ClockWidth() != len("10:10pm") {
+		t.Errorf("unexpected clock width %d", ClockWidth())
+	}
+	// Calendar dates stay on their day whatever the zone
+	due := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	if got := Day(due); got != "03/07/2026" {
+		t.Errorf("due date: got %q", got)
+	}
+	if got := Relative(at, now); got != "3d ago" {
+		t.Errorf("relative whatever the style: got %q", got)
+	}
+
+	if err := Set(Settings{Date: "02 Jan 2006", Zone: "utc"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := Date(at); got != "07 Mar 2026" {
+		t.Errorf("custom layout: got %q", got)
+	}
+	if got := Age(time.Time{}, now); got != "unknown" {
+		t.Errorf("zero time: got %q", got)
+	}
+	if got := Age(now.Add(time.Hour), now); got != "now" {
+		t.Errorf("future time: got %q", got)
+	}
+}
+
+func TestInvalidSettings(t *testing.T) {
+	for _, s := range []Settings{
+		{Style: "fuzzy"},
+		{Date: "tomorrow"},
+		{Clock: "36h"},
+		{Zone: "Mars/Olympus_Mons"},
+	} {
+		if err := Set(s); err == nil {
+			t.Errorf("expected %+v to fail", s)
+		}
+	}
+}
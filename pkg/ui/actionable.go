@@ -249,7 +249,7 @@ func (m *ActionableModel) Render() string {
 			if maxTitleLen < 10 {
 				maxTitleLen = 10
 			}
-			title := truncateRunesHelper(item.Title, maxTitleLen, "…")
+			title := truncateToWidth(item.Title, maxTitleLen, "…")
 
 			titleStyle := t.Renderer.NewStyle()
 			if isSelected {
@@ -283,7 +283,7 @@ func (m *ActionableModel) Render() string {
 					Italic(true).
 					PaddingLeft(8)
 				unblocksText := "↳ Unblocks: " + strings.Join(item.UnblocksIDs, ", ")
-				unblocksText = truncateRunesHelper(unblocksText, m.width-12, "...")
+				unblocksText = truncateToWidth(unblocksText, m.width-12, "...")
 				lines = append(lines, unblocksStyle.Render(unblocksText))
 			}
 		}
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ActivityModel is the chronological feed of recent project events
+type ActivityModel struct {
+	all          []analysis.ActivityEvent
+	events       []analysis.ActivityEvent // all, filtered by actor
+	actors       []string                 // Most active first
+	actor        string                   // "" shows everyone
+	selected     int
+	scrollOffset int
+	now          time.Time
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewActivityModel builds the feed from every issue
+func NewActivityModel(issues []model.Issue, now time.Time, theme Theme) ActivityModel {
+	all := analysis.BuildActivityFeed(issues, time.Time{})
+	return ActivityModel{
+		all:    all,
+		events: all,
+		actors: analysis.ActivityActors(all),
+		now:    now,
+		theme:  theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *ActivityModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *ActivityModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *ActivityModel) MoveDown() {
+	if m.selected < len(m.events)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// CycleActor shows only the next actor's events, most active first, then
+// everyone again
+func (m *ActivityModel) CycleActor() {
+	next := ""
+	if m.actor == "" {
+		if len(m.actors) > 0 {
+			next = m.actors[0]
+		}
+	} else {
+		for i, a := range m.actors {
+			if a == m.actor && i+1 < len(m.actors) {
+				next = m.actors[i+1]
+			}
+		}
+	}
+	m.SetActor(next)
+}
+
+// SetActor shows only actor's events ("" for everyone)
+func (m *ActivityModel) SetActor(actor string) {
+	m.actor = actor
+	m.events = m.all
+	if actor != "" {
+		m.events = nil
+		for _, e := range m.all {
+			if e.Actor == actor {
+				m.events = append(m.events, e)
+			}
+		}
+	}
+	m.selected, m.scrollOffset = 0, 0
+}
+
+// Actor returns the actor being shown, "" for everyone
+func (m *ActivityModel) Actor() string {
+	return m.actor
+}
+
+// SelectedIssueID returns the issue of the highlighted event, "" when the
+// feed is empty
+func (m *ActivityModel) SelectedIssueID() string {
+	if m.selected < 0 || m.selected >= len(m.events) {
+		return ""
+	}
+	return m.events[m.selected].IssueID
+}
+
+// visibleRows returns how many event rows fit below the header. Day
+// headings take rows too, so this errs on the small side.
+func (m *ActivityModel) visibleRows() int {
+	return max((m.height-3)*3/4, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *ActivityModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// activityIcons marks each kind of event
+var activityIcons = map[analysis.ActivityKind]string{
+	analysis.ActivityCreated:   "✚",
+	analysis.ActivityStarted:   "▶",
+	analysis.ActivityCommented: "💬",
+	analysis.ActivityClosed:    "✔",
+	analysis.ActivityUpdated:   "✎",
+}
+
+// dayHeading names the day of t relative to now
+func dayHeading(t, now time.Time) string {
+	t, now = timefmt.In(t), timefmt.In(now)
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := now.Date()
+	day := time.Date(y1, m1, d1, 0, 0, 0, 0, t.Location())
+	today := time.Date(y2, m2, d2, 0, 0, 0, 0, t.Location())
+	switch days := int(today.Sub(day).Hours() / 24); {
+	case days == 0:
+		return "Today"
+	case days == 1:
+		return "Yesterday"
+	case days > 1 && days < 7:
+		return t.Format("Monday")
+	case y1 == y2:
+		return t.Format("Mon Jan 2")
+	default:
+		return t.Format("Mon") + " " + timefmt.Day(t)
+	}
+}
+
+// Render renders the feed, grouped by day
+func (m *ActivityModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	who := "everyone"
+	if m.actor != "" {
+		who = "@" + m.actor
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("📰 ACTIVITY  │  %d events  │  %s", len(m.events), who)))
+	lines = append(lines, "")
+
+	if len(m.events) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No activity recorded."))
+		return strings.Join(lines, "\n")
+	}
+
+	kindStyles := map[analysis.ActivityKind]lipgloss.Style{
+		analysis.ActivityCreated:   t.Renderer.NewStyle().Foreground(t.Open),
+		analysis.ActivityStarted:   t.Renderer.NewStyle().Foreground(t.InProgress),
+		analysis.ActivityCommented: t.Renderer.NewStyle().Foreground(t.Primary),
+		analysis.ActivityClosed:    t.Renderer.NewStyle().Foreground(t.Closed),
+		analysis.ActivityUpdated:   subtle,
+	}
+	dayStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	actorWidth := 14
+	titleWidth := max(m.width-actorWidth-42, 12)
+
+	lastDay := ""
+	for i := m.scrollOffset; i < len(m.events) && len(lines) < m.height-1; i++ {
+		e := m.events[i]
+		if day := dayHeading(e.Time, m.now); day != lastDay {
+			lines = append(lines, dayStyle.Render(day))
+			lastDay = day
+		}
+
+		actor := ""
+		if e.Actor != "" {
+			actor = "@" + e.Actor
+		}
+		what := string(e.Kind)
+		if e.Kind == analysis.ActivityUpdated && e.Detail != "" {
+			what += " → " + e.Detail
+		}
+		text := e.Title
+		if e.Kind == analysis.ActivityCommented && e.Detail != "" {
+			text = "“" + e.Detail + "”"
+		}
+
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		kind := kindStyles[e.Kind].Render(activityIcons[e.Kind] + " " + fitWidth(what, 22))
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("%s%s ", prefix, fitWidth(timefmt.Clock(e.Time), timefmt.ClockWidth())))+kind+
+			rowStyle.Render(fmt.Sprintf(" %s %s %s",
+				fitWidth(actor, actorWidth),
+				fitWidth(e.IssueID, 12),
+				truncateToWidth(text, titleWidth, "…"))))
+	}
+	if older := len(m.events) - m.scrollOffset - m.visibleRows(); older > 0 {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d older", older)))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func activityTestIssues() []model.Issue {
+	now := time.Now()
+	return []model.Issue{
+		{ID: "A", Title: "ann work", Assignee: "ann", Status: model.StatusOpen, CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now.Add(-3 * time.Hour)},
+		{ID: "B", Title: "bob work", Assignee: "bob", Status: model.StatusOpen, CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-time.Hour),
+			Comments: []*model.Comment{{Author: "ann", Text: "looks good", CreatedAt: now.Add(-time.Hour)}}},
+		{ID: "C", Title: "closed", Status: model.StatusClosed, CreatedAt: now.Add(-48 * time.Hour)},
+	}
+}
+
+func TestActivityRender(t *testing.T) {
+	a := NewActivityModel(activityTestIssues(), time.Now(), DefaultTheme(lipgloss.NewRenderer(nil)))
+	a.SetSize(120, 20)
+	out := a.Render()
+	for _, want := range []string{"4 events", "everyone", "Today", "@ann", "“looks good”", "bob work"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in activity view:\n%s", want, out)
+		}
+	}
+
+	// ann is most active (created A, commented on B)
+	a.CycleActor()
+	if a.Actor() != "ann" || !strings.Contains(a.Render(), "2 events") {
+		t.Fatalf("expected ann's 2 events, got actor %q:\n%s", a.Actor(), a.Render())
+	}
+	a.CycleActor()
+	a.CycleActor()
+	if a.Actor() != "" {
+		t.Fatalf("expected to cycle back to everyone, got %q", a.Actor())
+	}
+}
+
+func TestActivityEnterJumpsToIssue(t *testing.T) {
+	m := NewModel(activityTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+
+	// Hide the target behind the closed filter
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	m = updated.(Model)
+	if !m.isActivityView || m.focused != focusActivity {
+		t.Fatalf("expected activity view focused")
+	}
+	// Newest first: ann's comment on B
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isActivityView {
+		t.Fatalf("expected activity view closed after enter")
+	}
+	if sel, ok := m.list.SelectedItem().(IssueItem); !ok || sel.Issue.ID != "B" {
+		t.Fatalf("expected B selected, got %v", m.list.SelectedItem())
+	}
+}
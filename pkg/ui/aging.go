@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// agingPriorities are the chart's columns, P0 to P4; other priorities are
+// clamped into the nearest one
+const agingPriorities = 5
+
+// AgingModel is the aging-WIP chart: each in-progress issue plotted by how
+// long it has been in progress against its priority, with the 50th and 85th
+// percentile cycle times of finished work as reference lines
+type AgingModel struct {
+	aging        analysis.AgingWIP
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewAgingModel ages the in-progress issues in scope
+func NewAgingModel(issues []model.Issue, now time.Time, theme Theme) AgingModel {
+	return AgingModel{
+		aging: analysis.ComputeAgingWIP(issues, now),
+		theme: theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *AgingModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *AgingModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *AgingModel) MoveDown() {
+	if m.selected < len(m.aging.Items)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the highlighted issue, or nil when nothing is in progress
+func (m *AgingModel) Selected() *analysis.AgingItem {
+	if m.selected < 0 || m.selected >= len(m.aging.Items) {
+		return nil
+	}
+	return &m.aging.Items[m.selected]
+}
+
+// chartHeight returns the rows of the plot, leaving the rest to the list
+func (m *AgingModel) chartHeight() int {
+	return min(max(m.height/2-2, 5), 16)
+}
+
+// visibleRows returns how many issues the list below the chart shows
+func (m *AgingModel) visibleRows() int {
+	// header, blank, chart, axis, labels, blank, list header, blank, legend
+	return max(m.height-m.chartHeight()-8, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *AgingModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// ageStyle colors an age by where it falls against finished work's cycle
+// times: within the median, within the 85th percentile, or beyond
+func (m *AgingModel) ageStyle(age time.Duration) lipgloss.Style {
+	t := m.theme
+	c := m.aging.Cycle
+	switch {
+	case c.Count == 0:
+		return t.Renderer.NewStyle().Foreground(t.Primary)
+	case age > c.P85:
+		return t.Renderer.NewStyle().Foreground(t.Blocked)
+	case age > c.P50:
+		return t.Renderer.NewStyle().Foreground(t.InProgress)
+	default:
+		return t.Renderer.NewStyle().Foreground(t.Open)
+	}
+}
+
+// Render renders the chart and the list of in-progress issues
+func (m *AgingModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	items := m.aging.Items
+	cycle := m.aging.Cycle
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	overdue := 0
+	for _, item := range items {
+		if m.aging.Overdue(item) {
+			overdue++
+		}
+	}
+	header := fmt.Sprintf("⏳ AGING WIP  │  %d in progress", len(items))
+	if cycle.Count > 0 {
+		header += fmt.Sprintf("  │  %d older than 85%% of finished work (%s)", overdue, FormatSpan(cycle.P85))
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	if len(items) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width-4).
+			Align(lipgloss.Center).
+			Render("Nothing is in progress."))
+		return strings.Join(lines, "\n")
+	}
+
+	// The top of the chart is the oldest issue, or the 85th percentile if
+	// every issue is younger, so the reference lines always show
+	top := items[0].Age
+	if cycle.Count > 0 {
+		top = max(top, cycle.P85)
+	}
+	top = max(top, time.Hour)
+	height := m.chartHeight()
+	rowOf := func(age time.Duration) int {
+		return height - 1 - int(math.Round(float64(age)/float64(top)*float64(height-1)))
+	}
+
+	const axisWidth = 6
+	refWidth := 0
+	if cycle.Count > 0 {
+		refWidth = 10
+	}
+	colWidth := max((m.width-axisWidth-refWidth-4)/agingPriorities, 4)
+
+	// Issues in each cell of the plot, oldest first
+	cells := make(map[[2]int][]int)
+	for i, item := range items {
+		col := min(max(item.Priority, 0), agingPriorities-1)
+		key := [2]int{rowOf(item.Age), col}
+		cells[key] = append(cells[key], i)
+	}
+	refs := make(map[int]string)
+	if cycle.Count > 0 {
+		refs[rowOf(cycle.P50)] = "50% " + FormatSpan(cycle.P50)
+		refs[rowOf(cycle.P85)] = "85% " + FormatSpan(cycle.P85)
+	}
+
+	for row := 0; row < height; row++ {
+		label := ""
+		switch row {
+		case 0:
+			label = FormatSpan(top)
+		case height - 1:
+			label = "0"
+		}
+		ref, isRef := refs[row]
+		fill := " "
+		if isRef {
+			fill = "┄"
+		}
+		var sb strings.Builder
+		sb.WriteString(subtle.Render(fmt.Sprintf("%*s┤", axisWidth-1, label)))
+		for col := 0; col < agingPriorities; col++ {
+			in := cells[[2]int{row, col}]
+			// Markers start one cell in; overflow shows as +n
+			room := colWidth - 2
+			shown := in
+			if len(in) > room {
+				shown = in[:max(room-2, 0)]
+			}
+			sb.WriteString(subtle.Render(fill))
+			used := 1
+			for _, i := range shown {
+				marker := "●"
+				style := m.ageStyle(items[i].Age)
+				if i == m.selected {
+					marker = "◉"
+					style = style.Bold(true).Reverse(true)
+				}
+				sb.WriteString(style.Render(marker))
+				used++
+			}
+			if extra := len(in) - len(shown); extra > 0 {
+				more := fmt.Sprintf("+%d", extra)
+				sb.WriteString(subtle.Render(more))
+				used += len(more)
+			}
+			if used < colWidth {
+				sb.WriteString(subtle.Render(strings.Repeat(fill, colWidth-used)))
+			}
+		}
+		if isRef {
+			sb.WriteString(subtle.Render(" " + ref))
+		}
+		lines = append(lines, sb.String())
+	}
+
+	plotWidth := colWidth * agingPriorities
+	lines = append(lines, subtle.Render(strings.Repeat(" ", axisWidth-1)+"└"+repeatToWidth("─", plotWidth)))
+	var xLabels strings.Builder
+	xLabels.WriteString(strings.Repeat(" ", axisWidth))
+	for col := 0; col < agingPriorities; col++ {
+		xLabels.WriteString(fmt.Sprintf("%-*s", colWidth, fmt.Sprintf(" P%d", col)))
+	}
+	lines = append(lines, subtle.Render(strings.TrimRight(xLabels.String(), " ")))
+	lines = append(lines, "")
+
+	// Oldest first, the order j/k walks the markers in
+	titleWidth := max(m.width-44, 10)
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-12s %-4s %-12s %7s  %s", "ISSUE", "PRI", "ASSIGNEE", "AGE", "TITLE")))
+	end := min(m.scrollOffset+m.visibleRows(), len(items))
+	for i := m.scrollOffset; i < end; i++ {
+		item := items[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		who := "-"
+		if item.Assignee != "" {
+			who = "@" + item.Assignee
+		}
+		age := FormatSpan(item.Age)
+		if item.Approximate {
+			age = "~" + age
+		}
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("%s%s P%-3d %s ", prefix,
+			fitWidth(item.ID, 12), item.Priority, fitWidth(who, 12)))+
+			m.ageStyle(item.Age).Render(fmt.Sprintf("%7s", age))+
+			rowStyle.Render("  "+truncateToWidth(item.Title, titleWidth, "…")))
+	}
+	if len(items) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(items)-end)))
+	}
+
+	lines = append(lines, "")
+	if cycle.Count == 0 {
+		lines = append(lines, subtle.Render(" Age since first going in progress  ·  ~ start unknown, aged from the last update  ·  no finished work to compare with"))
+	} else {
+		lines = append(lines, " "+m.ageStyle(cycle.P50).Render("●")+subtle.Render(" within the median cycle time  ")+
+			m.ageStyle(cycle.P85).Render("●")+subtle.Render(" within the 85th percentile  ")+
+			m.ageStyle(cycle.P85+1).Render("●")+subtle.Render(fmt.Sprintf(" beyond  ·  from %d finished issues  ·  ~ start unknown", cycle.Count)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAgingViewPlotsAndJumps(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	at := func(d time.Duration) *time.Time { t := now.Add(-d); return &t }
+	issues := []model.Issue{
+		{ID: "W1", Title: "Stuck refactor", Status: model.StatusInProgress, Priority: 1, Assignee: "sam", StartedAt: at(12 * day)},
+		{ID: "W2", Title: "Fresh fix", Status: model.StatusInProgress, Priority: 0, StartedAt: at(day)},
+		{ID: "W3", Title: "Not started", Status: model.StatusOpen, Priority: 0},
+		{ID: "C1", Title: "Done", Status: model.StatusClosed, CreatedAt: now.Add(-30 * day), StartedAt: at(25 * day), ClosedAt: at(22 * day)},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}, Alt: true})
+	m = updated.(Model)
+	if !m.isAgingView || m.focused != focusAging {
+		t.Fatalf("expected alt+a to open the aging chart")
+	}
+	out := m.View()
+	for _, want := range []string{"AGING WIP", "2 in progress", "1 older than 85% of finished work (3d)", "85% 3d", "P4", "Stuck refactor", "@sam"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the chart:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Not started") {
+		t.Errorf("open issues should not be plotted")
+	}
+
+	// Oldest first, so W2 is second
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isAgingView {
+		t.Fatalf("expected enter to leave the chart")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "W2" {
+		t.Errorf("expected W2 selected, got %v", m.list.SelectedItem())
+	}
+}
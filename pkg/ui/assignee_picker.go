@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+// AssigneeSetMsg reports the outcome of assigning an issue
+type AssigneeSetMsg struct {
+	ID, Assignee string
+	Err          error
+}
+
+// AssignCmd sets the assignee of base, the issue as loaded, using the bd
+// CLI, clearing it when assignee is "". It won't overwrite an assignee set
+// since (see writeIssueCmd). The file watcher picks up the change and
+// reloads the list.
+func AssignCmd(beadsPath string, base model.Issue, assignee string) tea.Cmd {
+	ours := base
+	ours.Assignee = assignee
+	return writeIssueCmd(beadsPath, issueEdit{base: base, ours: ours, done: func(err error) tea.Msg {
+		return AssigneeSetMsg{ID: base.ID, Assignee: assignee, Err: err}
+	}})
+}
+
+// assigneeCount is one assignee seen in the issues, with how many they hold
+type assigneeCount struct {
+	Name  string
+	Open  int
+	Total int
+}
+
+// assigneeRow is one line of the picker: an assignee, a team, or one of the
+// special entries that clear the filter, pick unassigned issues or name
+// someone new
+type assigneeRow struct {
+	Name    string
+	Open    int
+	Total   int
+	Matched []int // Byte offsets of Name matching the query
+	All     bool  // "All assignees": clears the filter
+	New     bool  // A name typed in that no issue has yet
+	Team    bool  // Name is a team from the config
+}
+
+// filter returns the list filter choosing the row sets
+func (r assigneeRow) filter() string {
+	switch {
+	case r.All:
+		return "all"
+	case r.Team:
+		return teamFilterPrefix + r.Name
+	default:
+		return assigneeFilterPrefix + r.Name
+	}
+}
+
+// AssigneePickerModel is the overlay for choosing an assignee, either to
+// filter the list by or to assign the selected issue to. Typing narrows the
+// assignees seen in the issues by fuzzy match.
+type AssigneePickerModel struct {
+	counts   []assigneeCount // Most open issues first, unassigned last
+	teams    []assigneeCount // Configured teams, by name
+	rows     []assigneeRow
+	query    string
+	selected int
+	scroll   int
+	assign   bool   // Assigning issueID rather than filtering
+	issueID  string // The issue being assigned
+	current  string // The list's filter, or issueID's assignee
+	width    int
+	height   int
+	theme    Theme
+}
+
+// NewAssigneePickerModel creates an assignee picker over the issues' assignees
+func NewAssigneePickerModel(issues []model.Issue, theme Theme) AssigneePickerModel {
+	m := AssigneePickerModel{theme: theme}
+	m.SetIssues(issues)
+	return m
+}
+
+// SetIssues refreshes the assignees and their counts (e.g. after a reload)
+func (m *AssigneePickerModel) SetIssues(issues []model.Issue) {
+	byName := make(map[string]*assigneeCount)
+	for _, issue := range issues {
+		c, ok := byName[issue.Assignee]
+		if !ok {
+			c = &assigneeCount{Name: issue.Assignee}
+			byName[issue.Assignee] = c
+		}
+		c.Total++
+		if !issue.Status.IsClosed() {
+			c.Open++
+		}
+	}
+	m.counts = m.counts[:0]
+	for _, c := range byName {
+		m.counts = append(m.counts, *c)
+	}
+	sort.Slice(m.counts, func(i, j int) bool {
+		a, b := m.counts[i], m.counts[j]
+		if (a.Name == "") != (b.Name == "") {
+			return b.Name == "" // Unassigned last
+		}
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+
+	teams := analysis.CurrentTeams()
+	m.teams = m.teams[:0]
+	for _, name := range teams.Names() {
+		c := assigneeCount{Name: name}
+		for _, issue := range issues {
+			if teams.TeamOf(issue.Assignee) == name {
+				c.Total++
+				if !issue.Status.IsClosed() {
+					c.Open++
+				}
+			}
+		}
+		m.teams = append(m.teams, c)
+	}
+	m.refresh()
+}
+
+// SetSize updates the picker dimensions
+func (m *AssigneePickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// OpenFilter readies the picker to filter the list, with the cursor on the
+// row of filter, the list's current filter, if there is one
+func (m *AssigneePickerModel) OpenFilter(filter string) {
+	m.assign, m.issueID, m.current = false, "", filter
+	m.query = ""
+	m.refresh()
+	m.selected = 0
+	for i, r := range m.rows {
+		if !r.All && r.filter() == filter {
+			m.selected = i
+			break
+		}
+	}
+}
+
+// OpenAssign readies the picker to assign issue, with the cursor on its
+// current assignee
+func (m *AssigneePickerModel) OpenAssign(issue model.Issue) {
+	m.assign, m.issueID, m.current = true, issue.ID, issue.Assignee
+	m.query = ""
+	m.refresh()
+	m.selected = 0
+	m.selectName(issue.Assignee)
+}
+
+// Assigning reports whether the picker assigns an issue rather than filters
+func (m *AssigneePickerModel) Assigning() bool {
+	return m.assign
+}
+
+// IssueID returns the issue being assigned
+func (m *AssigneePickerModel) IssueID() string {
+	return m.issueID
+}
+
+// Query returns what has been typed
+func (m *AssigneePickerModel) Query() string {
+	return m.query
+}
+
+// selectName moves the cursor onto name's row, if shown
+func (m *AssigneePickerModel) selectName(name string) {
+	for i, r := range m.rows {
+		if !r.All && !r.New && !r.Team && r.Name == name {
+			m.selected = i
+			return
+		}
+	}
+}
+
+// refresh rebuilds the rows for the query
+func (m *AssigneePickerModel) refresh() {
+	m.rows = m.rows[:0]
+	query := strings.TrimPrefix(strings.TrimSpace(m.query), "@")
+	if query == "" {
+		if !m.assign {
+			m.rows = append(m.rows, assigneeRow{All: true})
+			for _, c := range m.teams {
+				m.rows = append(m.rows, assigneeRow{Name: c.Name, Open: c.Open, Total: c.Total, Team: true})
+			}
+		}
+		for _, c := range m.counts {
+			// Assigning to nobody is offered only to clear an assignee
+			if c.Name == "" && m.assign && m.current == "" {
+				continue
+			}
+			m.rows = append(m.rows, assigneeRow{Name: c.Name, Open: c.Open, Total: c.Total})
+		}
+		if m.assign && m.current != "" && !m.hasName("") {
+			m.rows = append(m.rows, assigneeRow{})
+		}
+	} else {
+		names := make([]string, 0, len(m.counts))
+		var named []assigneeCount
+		exact := false
+		for _, c := range m.counts {
+			if c.Name == "" {
+				continue
+			}
+			names = append(names, c.Name)
+			named = append(named, c)
+			exact = exact || c.Name == query
+		}
+		// Ties keep the most-open-first order of counts
+		for _, match := range fuzzy.Find(query, names) {
+			c := named[match.Index]
+			m.rows = append(m.rows, assigneeRow{Name: c.Name, Open: c.Open, Total: c.Total, Matched: match.MatchedIndexes})
+		}
+		if m.assign && !exact {
+			m.rows = append(m.rows, assigneeRow{Name: query, New: true})
+		}
+		if !m.assign {
+			teamNames := make([]string, len(m.teams))
+			for i, c := range m.teams {
+				teamNames[i] = c.Name
+			}
+			for _, match := range fuzzy.Find(query, teamNames) {
+				c := m.teams[match.Index]
+				m.rows = append(m.rows, assigneeRow{Name: c.Name, Open: c.Open, Total: c.Total, Matched: match.MatchedIndexes, Team: true})
+			}
+		}
+	}
+	m.selected = min(m.selected, max(len(m.rows)-1, 0))
+}
+
+// hasName reports whether name is among the counted assignees
+func (m *AssigneePickerModel) hasName(name string) bool {
+	for _, c := range m.counts {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Update handles a key: typing narrows the list, up/down move, tab completes
+// the highlighted name. Enter and esc are left to the caller.
+func (m *AssigneePickerModel) Update(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "ctrl+n":
+		if m.selected < len(m.rows)-1 {
+			m.selected++
+		}
+	case "tab":
+		if r, ok := m.Selected(); ok && !r.All && r.Name != "" {
+			m.query = r.Name
+			m.selected = 0
+			m.refresh()
+		}
+	case "backspace":
+		if runes := []rune(m.query); len(runes) > 0 {
+			m.query = string(runes[:len(runes)-1])
+			m.selected = 0
+			m.refresh()
+		}
+	case "ctrl+u":
+		m.query = ""
+		m.selected = 0
+		m.refresh()
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.query += string(msg.Runes)
+			m.selected = 0
+			m.refresh()
+		}
+	}
+}
+
+// Selected returns the highlighted row; false when nothing matches
+func (m *AssigneePickerModel) Selected() (assigneeRow, bool) {
+	if m.selected < 0 || m.selected >= len(m.rows) {
+		return assigneeRow{}, false
+	}
+	return m.rows[m.selected], true
+}
+
+// View renders the picker overlay
+func (m *AssigneePickerModel) View() string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	matchStyle := t.Renderer.NewStyle().Foreground(t.Primary).Underline(true)
+
+	title := "Filter by Assignee"
+	if len(m.teams) > 0 {
+		title = "Filter by Assignee or Team"
+	}
+	if m.assign {
+		title = "Assign " + m.issueID
+	}
+	var lines []string
+	lines = append(lines, titleStyle.Render(title))
+	lines = append(lines, "")
+	lines = append(lines, "@ "+m.query+t.Renderer.NewStyle().Reverse(true).Render(" "))
+	lines = append(lines, "")
+
+	if len(m.rows) == 0 {
+		lines = append(lines, subtle.Italic(true).Render("No assignee matches"))
+	}
+
+	visible := max(m.height-12, 5)
+	if m.selected < m.scroll {
+		m.scroll = m.selected
+	}
+	if m.selected >= m.scroll+visible {
+		m.scroll = m.selected - visible + 1
+	}
+	m.scroll = min(m.scroll, max(len(m.rows)-visible, 0))
+	end := min(m.scroll+visible, len(m.rows))
+
+	for i := m.scroll; i < end; i++ {
+		r := m.rows[i]
+		prefix := "  "
+		nameStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+		if i == m.selected {
+			prefix = "▸ "
+			nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+		}
+		var line string
+		switch {
+		case r.All:
+			line = nameStyle.Render(prefix + "All assignees")
+		case r.Team:
+			line = nameStyle.Render(prefix+"👥 ") + highlightMatches(r.Name, r.Matched, nameStyle, matchStyle) +
+				subtle.Render(fmt.Sprintf(" team, %d open / %d total", r.Open, r.Total))
+		case r.New:
+			line = nameStyle.Render(prefix+"+ @"+r.Name) + subtle.Render(" (new)")
+		case r.Name == "" && m.assign:
+			line = nameStyle.Render(prefix + "Unassign")
+		case r.Name == "":
+			line = nameStyle.Render(prefix+"Unassigned") + subtle.Render(fmt.Sprintf(" %d open / %d total", r.Open, r.Total))
+		default:
+			line = nameStyle.Render(prefix+"@") + highlightMatches(r.Name, r.Matched, nameStyle, matchStyle) +
+				subtle.Render(fmt.Sprintf(" %d open / %d total", r.Open, r.Total))
+		}
+		switch {
+		case m.assign && !r.New && r.Name == m.current:
+			line += subtle.Render(" (current)")
+		case !m.assign && !r.All && r.filter() == m.current:
+			line += " (active)"
+		}
+		lines = append(lines, line)
+	}
+	if len(m.rows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.rows)-end)))
+	}
+
+	lines = append(lines, "")
+	action := "filter"
+	if m.assign {
+		action = "assign"
+	}
+	lines = append(lines, subtle.Italic(true).Render("type to search • ↑/↓: navigate • tab: complete • enter: "+action+" • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// highlightMatches renders s in base, with the characters starting at the
+// byte offsets in matched in hit
+func highlightMatches(s string, matched []int, base, hit lipgloss.Style) string {
+	if len(matched) == 0 {
+		return base.Render(s)
+	}
+	isHit := make(map[int]bool, len(matched))
+	for _, i := range matched {
+		isHit[i] = true
+	}
+	var sb strings.Builder
+	for i, r := range s {
+		if isHit[i] {
+			sb.WriteString(hit.Render(string(r)))
+		} else {
+			sb.WriteString(base.Render(string(r)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func assigneeTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Assignee: "alice"},
+		{ID: "B", Title: "B", Status: model.StatusClosed, Assignee: "alice"},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Assignee: "bob"},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Assignee: "bob"},
+		{ID: "E", Title: "E", Status: model.StatusOpen, Assignee: "alan"},
+		{ID: "F", Title: "F", Status: model.StatusOpen},
+	}
+}
+
+func typeKeys(t *testing.T, m Model, keys string) Model {
+	t.Helper()
+	for _, r := range keys {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestAssigneePickerSearch(t *testing.T) {
+	p := NewAssigneePickerModel(assigneeTestIssues(), DefaultTheme(lipgloss.NewRenderer(nil)))
+	p.OpenFilter("all")
+
+	var names []string
+	for _, r := range p.rows {
+		switch {
+		case r.All:
+			names = append(names, "*")
+		default:
+			names = append(names, r.Name)
+		}
+	}
+	// Most open issues first, unassigned last
+	if got := strings.Join(names, ","); got != "*,bob,alice,alan," {
+		t.Fatalf("unexpected rows %q", got)
+	}
+
+	for _, r := range "al" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	// The closer match first
+	if len(p.rows) != 2 || p.rows[0].Name != "alan" || p.rows[1].Name != "alice" {
+		t.Fatalf("expected alan and alice to match 'al', got %+v", p.rows)
+	}
+	if p.rows[1].Open != 1 || p.rows[1].Total != 2 {
+		t.Fatalf("expected alice's counts 1/2, got %d/%d", p.rows[1].Open, p.rows[1].Total)
+	}
+	p.Update(tea.KeyMsg{Type: tea.KeyDown})
+	p.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if p.Query() != "alice" || len(p.rows) != 1 {
+		t.Fatalf("expected tab to complete alice, got %q with %d rows", p.Query(), len(p.rows))
+	}
+
+	// Assigning offers a name nobody has yet, and clearing the assignee
+	p.OpenAssign(assigneeTestIssues()[0])
+	if r, _ := p.Selected(); r.Name != "alice" {
+		t.Fatalf("expected cursor on the current assignee, got %q", r.Name)
+	}
+	for _, r := range "carol" {
+		p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if r, ok := p.Selected(); !ok || !r.New || r.Name != "carol" {
+		t.Fatalf("expected a new-name row, got %+v", r)
+	}
+	if !strings.Contains(p.View(), "carol") {
+		t.Fatalf("expected the query rendered")
+	}
+}
+
+func TestAssigneePickerKeys(t *testing.T) {
+	var calls []string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, strings.Join(args, " "))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	m := NewModel(assigneeTestIssues(), nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	// Letters search rather than trigger shortcuts
+	m = typeKeys(t, m, "ubo")
+	if !m.showAssigneePicker || m.focused != focusAssigneePicker {
+		t.Fatalf("expected assignee picker open")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showAssigneePicker {
+		t.Fatalf("expected picker closed after enter")
+	}
+	if got := strings.Join(visibleIDs(m), ","); got != "C,D" {
+		t.Fatalf("expected bob's issues, got %s", got)
+	}
+
+	// Assign the selected issue to someone new
+	m = typeKeys(t, m, "wcarol")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected assign command")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if got := strings.Join(calls, "\n"); got != "update C --assignee carol" {
+		t.Fatalf("unexpected bd calls: %s", got)
+	}
+	if m.statusMsg != "Assigned C to @carol" || m.statusIsError {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+
+	// No match leaves the picker open
+	m = typeKeys(t, m, "uzzz")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.showAssigneePicker || !m.statusIsError {
+		t.Fatalf("expected picker to stay open without a match")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showAssigneePicker || m.focused != focusList {
+		t.Fatalf("expected esc to close the picker")
+	}
+}
@@ -0,0 +1,374 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// attachment is a file an issue's text embeds or links to
+type attachment struct {
+	Name   string // Alt text or link text, else the file name
+	Target string // As written in the issue
+	Path   string // Resolved local path, "" for remote files
+	Image  bool
+}
+
+// markdownRefPattern matches Markdown images and links: ![alt](target) and
+// [text](target "title")
+var markdownRefPattern = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"\n]*")?\s*\)`)
+
+// imageExts are the extensions treated as images; only previewExts decode
+var imageExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true, ".bmp": true}
+
+var previewExts = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true}
+
+// remoteAttachmentExts are the extensions that make a link to a URL an
+// attachment rather than a web page
+var remoteAttachmentExts = map[string]bool{
+	".pdf": true, ".zip": true, ".gz": true, ".tgz": true, ".tar": true, ".log": true, ".txt": true,
+	".csv": true, ".json": true, ".mp4": true, ".mov": true, ".webm": true,
+}
+
+// issueAttachments returns the files referenced from an issue's description,
+// design, acceptance criteria, notes and comments, in order and without
+// repeats. Relative paths resolve against dir, the project root.
+func issueAttachments(issue model.Issue, dir string) []attachment {
+	var out []attachment
+	seen := make(map[string]bool)
+	for _, text := range analysis.IssueTexts(issue) {
+		for _, ref := range markdownRefPattern.FindAllStringSubmatch(text, -1) {
+			embed, name, target := ref[1] == "!", strings.TrimSpace(ref[2]), ref[3]
+			if seen[target] {
+				continue
+			}
+			a, ok := resolveAttachment(embed, name, target, dir)
+			if !ok {
+				continue
+			}
+			seen[target] = true
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// resolveAttachment turns a reference into an attachment, reporting false
+// for links that aren't to files
+func resolveAttachment(embed bool, name, target, dir string) (attachment, bool) {
+	a := attachment{Name: name, Target: target}
+	var ext, base string
+	if u, err := url.Parse(target); err == nil && u.Scheme != "" && u.Scheme != "file" {
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return a, false
+		}
+		ext, base = strings.ToLower(path.Ext(u.Path)), path.Base(u.Path)
+		if !embed && !imageExts[ext] && !remoteAttachmentExts[ext] {
+			return a, false
+		}
+	} else {
+		if strings.HasPrefix(target, "#") {
+			return a, false
+		}
+		p := strings.TrimPrefix(target, "file://")
+		if unescaped, err := url.PathUnescape(p); err == nil {
+			p = unescaped
+		}
+		ext, base = strings.ToLower(filepath.Ext(p)), filepath.Base(p)
+		if ext == "" && !embed {
+			return a, false
+		}
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		a.Path = p
+	}
+	a.Image = embed || imageExts[ext]
+	if a.Name == "" {
+		a.Name = base
+	}
+	return a, true
+}
+
+// formatBytes renders a file size such as 24.1 KB
+func formatBytes(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
+
+// describe says where an attachment is: its path and size, or why it can't
+// be read
+func (a attachment) describe() string {
+	if a.Path == "" {
+		return a.Target
+	}
+	info, err := os.Stat(a.Path)
+	if err != nil {
+		return a.Target + ", missing"
+	}
+	return fmt.Sprintf("%s, %s", a.Target, formatBytes(info.Size()))
+}
+
+// icon marks images apart from other files
+func (a attachment) icon() string {
+	if a.Image {
+		return "🖼"
+	}
+	return "📎"
+}
+
+// attachmentsMarkdown lists an issue's attachments for the detail view
+func (m *Model) attachmentsMarkdown(issue model.Issue) string {
+	atts := issueAttachments(issue, beadsProjectDir(m.beadsPath))
+	if len(atts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Attachments (%d)\n", len(atts)))
+	for _, a := range atts {
+		sb.WriteString(fmt.Sprintf("- %s `%s` — %s\n", a.icon(), a.Name, a.describe()))
+	}
+	sb.WriteString("\n*v preview*\n\n")
+	return sb.String()
+}
+
+// selectedAttachments returns the attachments of the issue highlighted in the list
+func (m *Model) selectedAttachments() []attachment {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return nil
+	}
+	return issueAttachments(item.Issue, beadsProjectDir(m.beadsPath))
+}
+
+// SetImagePreview sets how image attachments preview: a graphics protocol
+// (kitty, iterm or sixel), "off" for the text fallback, or "auto"
+func (m *Model) SetImagePreview(mode string) error {
+	if !slices.Contains(imagePreviews, mode) {
+		return fmt.Errorf("unknown image preview %q (known: %s)", mode, strings.Join(imagePreviews, ", "))
+	}
+	m.imagePreview = mode
+	return nil
+}
+
+// graphicsProtocol returns the protocol images preview with, or "" for the
+// text fallback. Remote sessions always fall back: the environment that
+// would say what the terminal supports is this machine's, not theirs.
+func (m *Model) graphicsProtocol() string {
+	if m.remoteTerm != nil {
+		return ""
+	}
+	switch m.imagePreview {
+	case "off":
+		return ""
+	case "auto", "":
+		return detectGraphics(os.Getenv)
+	}
+	return m.imagePreview
+}
+
+// attachmentPreviewMsg carries an image encoded for the terminal
+type attachmentPreviewMsg struct {
+	seq    int // Which load it answers; older ones are dropped
+	escape string
+	err    error
+}
+
+// previewDelay gives the frame with the blank preview area time to reach
+// the terminal before the frame that draws the image into it. Lines the
+// renderer has already drawn and that don't change aren't redrawn, so the
+// image isn't painted over.
+const previewDelay = 60 * time.Millisecond
+
+// openAttachmentPreview shows the preview of the selected issue's first
+// attachment
+func (m *Model) openAttachmentPreview() tea.Cmd {
+	if len(m.selectedAttachments()) == 0 {
+		m.statusMsg = "❌ No attachments on this issue"
+		m.statusIsError = true
+		return nil
+	}
+	m.showAttachmentPreview = true
+	m.attachmentIndex = 0
+	return m.loadAttachmentPreview()
+}
+
+// handleAttachmentPreviewKeys handles keys while the preview is open: n/p
+// step through the attachments, esc closes
+func (m Model) handleAttachmentPreviewKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "v":
+		m.showAttachmentPreview = false
+		m.previewSeq++ // Drop an image still loading
+		m.previewEscape = ""
+	case "n", "l", "right", "j", "down", "tab":
+		if n := len(m.selectedAttachments()); n > 1 {
+			m.attachmentIndex = (m.attachmentIndex + 1) % n
+			return m, m.loadAttachmentPreview()
+		}
+	case "p", "h", "left", "k", "up", "shift+tab":
+		if n := len(m.selectedAttachments()); n > 1 {
+			m.attachmentIndex = (m.attachmentIndex + n - 1) % n
+			return m, m.loadAttachmentPreview()
+		}
+	}
+	return m, nil
+}
+
+// previewArea returns the cells the preview box keeps for the image
+func (m *Model) previewArea() (cols, rows int) {
+	listed := min(len(m.selectedAttachments()), attachmentsListed)
+	// Border and padding, title, the list, blanks around the image, keys
+	chrome := 4 + 1 + listed + 2 + 1
+	return min(m.width-8, 100) - 4, min(max(m.height-1-chrome, 3), 40)
+}
+
+// attachmentsListed is how many attachments the preview lists at once
+const attachmentsListed = 5
+
+// loadAttachmentPreview clears the preview area and, when the attachment is
+// an image the terminal can draw, encodes it in the background
+func (m *Model) loadAttachmentPreview() tea.Cmd {
+	m.previewSeq++
+	m.previewEscape, m.previewErr = "", nil
+	atts := m.selectedAttachments()
+	protocol := m.graphicsProtocol()
+	if m.attachmentIndex >= len(atts) || protocol == "" {
+		return nil
+	}
+	a := atts[m.attachmentIndex]
+	if !a.Image || a.Path == "" || !previewExts[strings.ToLower(filepath.Ext(a.Path))] || !fileExists(a.Path) {
+		return nil
+	}
+	cols, rows := m.previewArea()
+	seq := m.previewSeq
+	return func() tea.Msg {
+		start := time.Now()
+		msg := attachmentPreviewMsg{seq: seq}
+		img, err := decodeImageFile(a.Path)
+		if err == nil {
+			msg.escape, err = encodeGraphics(protocol, img, cols, rows)
+		}
+		msg.err = err
+		time.Sleep(time.Until(start.Add(previewDelay)))
+		return msg
+	}
+}
+
+// previewFallback says why an attachment shows as text rather than an
+// image, or "" while the image loads
+func (m *Model) previewFallback(a attachment) string {
+	ext := strings.ToLower(filepath.Ext(a.Target))
+	switch {
+	case m.previewErr != nil:
+		return m.previewErr.Error()
+	case a.Path == "":
+		return "Remote file, not downloaded for preview"
+	case !a.Image:
+		return "Not an image"
+	case !fileExists(a.Path):
+		return "File not found"
+	case !previewExts[ext]:
+		return fmt.Sprintf("No preview for %s images", ext)
+	case m.remoteTerm != nil:
+		return "Images aren't drawn in remote sessions"
+	case m.graphicsProtocol() == "":
+		return "Inline images need a kitty, iTerm2 or Sixel terminal (view.image_preview)"
+	}
+	return ""
+}
+
+// renderAttachmentPreview draws the selected issue's attachments in a box
+// centered over the body: the list, then the current image, or a note on why
+// it can't be shown
+func (m Model) renderAttachmentPreview() string {
+	t := m.theme
+	atts := m.selectedAttachments()
+	if len(atts) == 0 {
+		return ""
+	}
+	idx := min(m.attachmentIndex, len(atts)-1)
+	current := atts[idx]
+	cols, rows := m.previewArea()
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(truncateToWidth(
+		fmt.Sprintf("Attachments · %s (%d/%d)", m.selectedIssueID(), idx+1, len(atts)), cols, "…")))
+	first := max(min(idx-attachmentsListed/2, len(atts)-attachmentsListed), 0)
+	for i := first; i < min(first+attachmentsListed, len(atts)); i++ {
+		a := atts[i]
+		marker := "  "
+		style := mutedStyle
+		if i == idx {
+			marker, style = "▸ ", textStyle
+		}
+		lines = append(lines, style.Render(truncateToWidth(
+			fmt.Sprintf("%s%s %s — %s", marker, a.icon(), a.Name, a.describe()), cols, "…")))
+	}
+	lines = append(lines, "")
+
+	// The image area. The renderer only redraws lines that change, so its
+	// blank lines alternate an empty reset with each load: lines left over
+	// from the previous image are redrawn, clearing it.
+	blank := strings.Repeat(" ", cols)
+	if m.previewSeq%2 == 1 {
+		blank = "\x1b[m" + blank
+	}
+	area := make([]string, rows)
+	for i := range area {
+		area[i] = blank
+	}
+	if m.previewEscape != "" {
+		area[0] = m.previewEscape + area[0]
+	} else if note := m.previewFallback(current); note != "" {
+		area[rows/2] = mutedStyle.Italic(true).Render(truncateToWidth(note, cols, "…"))
+	}
+	lines = append(lines, area...)
+
+	lines = append(lines, "", mutedStyle.Italic(true).Render("n/p: next attachment • esc: close"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(cols + 4).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
+
+// fileExists reports whether path names something that can be stat'ed
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIssueAttachments(t *testing.T) {
+	issue := model.Issue{
+		ID: "A",
+		Description: "Looks like ![the bug](docs/shot%201.png) after [the trace](logs/trace.log).\n" +
+			"See [the docs](https://example.com/guide) and [the spec](https://example.com/spec.pdf), " +
+			"or [below](#notes).",
+		Notes:    "Again: ![](docs/shot%201.png)",
+		Comments: []*model.Comment{{Text: "Now ![fixed](https://example.com/after.gif \"after\")"}},
+	}
+	atts := issueAttachments(issue, "/project")
+	want := []attachment{
+		{Name: "the bug", Target: "docs/shot%201.png", Path: "/project/docs/shot 1.png", Image: true},
+		{Name: "the trace", Target: "logs/trace.log", Path: "/project/logs/trace.log"},
+		{Name: "the spec", Target: "https://example.com/spec.pdf"},
+		{Name: "fixed", Target: "https://example.com/after.gif", Image: true},
+	}
+	if len(atts) != len(want) {
+		t.Fatalf("expected %d attachments, got %+v", len(want), atts)
+	}
+	for i := range want {
+		if atts[i] != want[i] {
+			t.Errorf("attachment %d: got %+v, want %+v", i, atts[i], want[i])
+		}
+	}
+}
+
+func TestAttachmentPreview(t *testing.T) {
+	dir := t.TempDir()
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	f, err := os.Create(filepath.Join(dir, "shot.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, img)
+	f.Close()
+
+	issues := []model.Issue{{ID: "A", Title: "broken layout", Status: model.StatusOpen, IssueType: model.TypeBug,
+		Description: "![screenshot](shot.png) and ![before](gone.png)"}}
+	m := NewModel(issues, nil, "")
+	m.beadsPath = filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := m.SetImagePreview("kitty"); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 40})
+	m = updated.(Model)
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.viewport.View(), "Attachments (2)") {
+		t.Fatalf("expected the details to list the attachments:\n%s", m.viewport.View())
+	}
+
+	cmd := press(key("v"))
+	if !m.showAttachmentPreview || cmd == nil {
+		t.Fatalf("expected v to open the preview and load the image")
+	}
+	// Until the image arrives the area is blank
+	if strings.Contains(m.View(), "\x1b_Gf=") {
+		t.Fatalf("expected no image before it loads")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	view := m.View()
+	if !strings.Contains(view, "\x1b_Gf=100,a=T") || !strings.Contains(view, m.previewEscape) ||
+		!strings.Contains(view, "Attachments · A (1/2)") {
+		t.Fatalf("expected the image drawn in the preview:\n%q", view)
+	}
+
+	// The next attachment is missing, which says so instead
+	if cmd := press(key("n")); cmd != nil {
+		t.Fatalf("expected nothing to load for a missing file")
+	}
+	view = m.View()
+	if strings.Contains(view, "\x1b_Gf=100") || !strings.Contains(view, "File not found") {
+		t.Fatalf("expected the missing file noted:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showAttachmentPreview || !m.showDetails {
+		t.Fatalf("expected esc to return to the details")
+	}
+	if !strings.HasPrefix(m.View(), kittyDeleteImages) {
+		t.Fatalf("expected the kitty image cleared once the preview closes")
+	}
+
+	// Without graphics the preview says why
+	m.SetImagePreview("off")
+	if cmd := press(key("v")); cmd != nil {
+		t.Fatalf("expected nothing to load without graphics")
+	}
+	if !strings.Contains(m.View(), "Inline images need a kitty") {
+		t.Fatalf("expected the text fallback:\n%s", m.View())
+	}
+}
+
+func TestSixelImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	got := sixelImage(img)
+	// Red in the color cube, then one band of two columns with the top two
+	// pixels set
+	want := "\x1bP0;1;0q\"1;1;2;2#180;2;100;0;0#180BB-\x1b\\"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDetectGraphics(t *testing.T) {
+	for _, tc := range []struct {
+		env  map[string]string
+		want string
+	}{
+		{map[string]string{"TERM": "xterm-kitty"}, graphicsKitty},
+		{map[string]string{"TERM_PROGRAM": "iTerm.app"}, graphicsITerm},
+		{map[string]string{"TERM": "foot"}, graphicsSixel},
+		{map[string]string{"TERM": "xterm-kitty", "TMUX": "/tmp/tmux"}, ""},
+		{map[string]string{"TERM": "xterm-256color"}, ""},
+	} {
+		if got := detectGraphics(func(k string) string { return tc.env[k] }); got != tc.want {
+			t.Errorf("%v: got %q, want %q", tc.env, got, tc.want)
+		}
+	}
+}
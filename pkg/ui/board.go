@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/lipgloss"
@@ -20,6 +21,14 @@ type BoardModel struct {
 	width        int
 	height       int
 	theme        Theme
+
+	// Swimlanes (see board_swimlanes.go)
+	issues      []model.Issue           // Unsplit, for regrouping
+	issueMap    map[string]*model.Issue // All issues, for epic lookups
+	laneBy      GroupBy                 // GroupNone, GroupAssignee or GroupEpic
+	lanes       []boardLane             // Rows, empty without swimlanes
+	focusedLane int                     // Index into lanes
+	laneRow     int                     // Selection within the focused cell
 }
 
 // Column indices for the Kanban board
@@ -30,6 +39,22 @@ const (
 	ColClosed     = 3
 )
 
+// columnStatuses maps column indices to the status they show
+var columnStatuses = [4]model.Status{model.StatusOpen, model.StatusInProgress, model.StatusBlocked, model.StatusClosed}
+
+// columnCount formats a column's issue count, with its WIP limit when it has
+// one, and reports whether the column is over the limit
+func columnCount(colIdx, count int) (string, bool) {
+	limit := analysis.CurrentWIPLimits().StatusLimit(columnStatuses[colIdx])
+	if limit <= 0 {
+		return fmt.Sprintf("(%d)", count), false
+	}
+	if count > limit {
+		return fmt.Sprintf("⚠ (%d/%d)", count, limit), true
+	}
+	return fmt.Sprintf("(%d/%d)", count, limit), false
+}
+
 // sortIssuesByPriorityAndDate sorts issues by priority (ascending) then by creation date (descending)
 func sortIssuesByPriorityAndDate(issues []model.Issue) {
 	sort.Slice(issues, func(i, j int) bool {
@@ -63,38 +88,16 @@ func (b *BoardModel) updateActiveColumns() {
 
 // NewBoardModel creates a new Kanban board from the given issues
 func NewBoardModel(issues []model.Issue, theme Theme) BoardModel {
-	var cols [4][]model.Issue
-
-	// Distribute issues into columns by status
-	for _, issue := range issues {
-		switch issue.Status {
-		case model.StatusOpen:
-			cols[ColOpen] = append(cols[ColOpen], issue)
-		case model.StatusInProgress:
-			cols[ColInProgress] = append(cols[ColInProgress], issue)
-		case model.StatusBlocked:
-			cols[ColBlocked] = append(cols[ColBlocked], issue)
-		case model.StatusClosed:
-			cols[ColClosed] = append(cols[ColClosed], issue)
-		}
-	}
-
-	// Sort each column
-	for i := 0; i < 4; i++ {
-		sortIssuesByPriorityAndDate(cols[i])
-	}
-
 	b := BoardModel{
-		columns:    cols,
 		focusedCol: 0,
 		theme:      theme,
 	}
-	b.updateActiveColumns()
+	b.SetIssues(issues)
 	return b
 }
 
-// SetIssues updates the board data, typically after filtering
-func (b *BoardModel) SetIssues(issues []model.Issue) {
+// boardColumns distributes issues into status columns, each sorted
+func boardColumns(issues []model.Issue) [4][]model.Issue {
 	var cols [4][]model.Issue
 
 	for _, issue := range issues {
@@ -114,8 +117,13 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	for i := 0; i < 4; i++ {
 		sortIssuesByPriorityAndDate(cols[i])
 	}
+	return cols
+}
 
-	b.columns = cols
+// SetIssues updates the board data, typically after filtering
+func (b *BoardModel) SetIssues(issues []model.Issue) {
+	b.issues = issues
+	b.columns = boardColumns(issues)
 
 	// Sanitize selection to prevent out-of-bounds
 	for i := 0; i < 4; i++ {
@@ -129,6 +137,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	}
 
 	b.updateActiveColumns()
+	b.buildLanes()
 }
 
 // actualFocusedCol returns the actual column index (0-3) being focused
@@ -141,6 +150,10 @@ func (b *BoardModel) actualFocusedCol() int {
 
 // Navigation methods
 func (b *BoardModel) MoveDown() {
+	if b.hasLanes() {
+		b.laneMoveDown()
+		return
+	}
 	col := b.actualFocusedCol()
 	count := len(b.columns[col])
 	if count == 0 {
@@ -152,6 +165,10 @@ func (b *BoardModel) MoveDown() {
 }
 
 func (b *BoardModel) MoveUp() {
+	if b.hasLanes() {
+		b.laneMoveUp()
+		return
+	}
 	col := b.actualFocusedCol()
 	if b.selectedRow[col] > 0 {
 		b.selectedRow[col]--
@@ -161,21 +178,31 @@ func (b *BoardModel) MoveUp() {
 func (b *BoardModel) MoveRight() {
 	if b.focusedCol < len(b.activeColIdx)-1 {
 		b.focusedCol++
+		b.clampLaneRow()
 	}
 }
 
 func (b *BoardModel) MoveLeft() {
 	if b.focusedCol > 0 {
 		b.focusedCol--
+		b.clampLaneRow()
 	}
 }
 
 func (b *BoardModel) MoveToTop() {
+	if b.hasLanes() {
+		b.laneMoveToTop()
+		return
+	}
 	col := b.actualFocusedCol()
 	b.selectedRow[col] = 0
 }
 
 func (b *BoardModel) MoveToBottom() {
+	if b.hasLanes() {
+		b.laneMoveToBottom()
+		return
+	}
 	col := b.actualFocusedCol()
 	count := len(b.columns[col])
 	if count > 0 {
@@ -184,6 +211,10 @@ func (b *BoardModel) MoveToBottom() {
 }
 
 func (b *BoardModel) PageDown(visibleRows int) {
+	if b.hasLanes() {
+		b.moveLane(1)
+		return
+	}
 	col := b.actualFocusedCol()
 	count := len(b.columns[col])
 	if count == 0 {
@@ -197,6 +228,10 @@ func (b *BoardModel) PageDown(visibleRows int) {
 }
 
 func (b *BoardModel) PageUp(visibleRows int) {
+	if b.hasLanes() {
+		b.moveLane(-1)
+		return
+	}
 	col := b.actualFocusedCol()
 	newRow := b.selectedRow[col] - visibleRows/2
 	if newRow < 0 {
@@ -207,6 +242,9 @@ func (b *BoardModel) PageUp(visibleRows int) {
 
 // SelectedIssue returns the currently selected issue, or nil if none
 func (b *BoardModel) SelectedIssue() *model.Issue {
+	if b.hasLanes() {
+		return b.laneSelectedIssue()
+	}
 	col := b.actualFocusedCol()
 	cols := b.columns[col]
 	row := b.selectedRow[col]
@@ -247,6 +285,9 @@ func (b BoardModel) View(width, height int) string {
 			Foreground(t.Secondary).
 			Render("No issues to display")
 	}
+	if b.hasLanes() {
+		return b.swimlaneView(width, height)
+	}
 
 	// Calculate column widths - distribute space proportionally
 	// Minimum column width for readability
@@ -283,7 +324,12 @@ func (b BoardModel) View(width, height int) string {
 		issueCount := len(issues)
 
 		// Header with emoji, title, and count
-		headerText := fmt.Sprintf("%s %s (%d)", columnEmoji[colIdx], columnTitles[colIdx], issueCount)
+		countText, overLimit := columnCount(colIdx, issueCount)
+		headerText := fmt.Sprintf("%s %s %s", columnEmoji[colIdx], columnTitles[colIdx], countText)
+		headerColor := columnColors[colIdx]
+		if overLimit {
+			headerColor = t.Blocked
+		}
 		headerStyle := t.Renderer.NewStyle().
 			Width(baseWidth).
 			Align(lipgloss.Center).
@@ -292,12 +338,12 @@ func (b BoardModel) View(width, height int) string {
 
 		if isFocused {
 			headerStyle = headerStyle.
-				Background(columnColors[colIdx]).
+				Background(headerColor).
 				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
 		} else {
 			headerStyle = headerStyle.
 				Background(lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#2a2a2a"}).
-				Foreground(columnColors[colIdx])
+				Foreground(headerColor)
 		}
 
 		header := headerStyle.Render(headerText)
@@ -371,8 +417,8 @@ func (b BoardModel) View(width, height int) string {
 			Padding(0, 1).
 			Border(lipgloss.RoundedBorder())
 
-		if isFocused {
-			colStyle = colStyle.BorderForeground(columnColors[colIdx])
+		if isFocused || overLimit {
+			colStyle = colStyle.BorderForeground(headerColor)
 		} else {
 			colStyle = colStyle.BorderForeground(t.Secondary)
 		}
@@ -421,7 +467,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 	if maxIDLen < 6 {
 		maxIDLen = 6
 	}
-	displayID := truncateRunesHelper(issue.ID, maxIDLen, "…")
+	displayID := truncateToWidth(issue.ID, maxIDLen, "…")
 
 	line1 := fmt.Sprintf("%s %s %s",
 		t.Renderer.NewStyle().Foreground(iconColor).Render(icon),
@@ -436,7 +482,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 	if titleWidth < 10 {
 		titleWidth = 10
 	}
-	truncatedTitle := truncateRunesHelper(issue.Title, titleWidth, "…")
+	truncatedTitle := truncateToWidth(issue.Title, titleWidth, "…")
 
 	titleStyle := t.Renderer.NewStyle()
 	if selected {
@@ -453,7 +499,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 
 	// Assignee chip
 	if issue.Assignee != "" {
-		assignee := truncateRunesHelper(issue.Assignee, 8, "…")
+		assignee := truncateToWidth(issue.Assignee, 8, "…")
 		meta = append(meta, t.Renderer.NewStyle().
 			Foreground(t.Secondary).
 			Render("@"+assignee))
@@ -468,13 +514,13 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 
 	// Labels chip (first label + count)
 	if len(issue.Labels) > 0 {
-		labelPreview := truncateRunesHelper(issue.Labels[0], 6, "")
+		labelPreview := truncateToWidth(issue.Labels[0], 6, "")
 		labelText := labelPreview
 		if len(issue.Labels) > 1 {
 			labelText += fmt.Sprintf("+%d", len(issue.Labels)-1)
 		}
 		labelStyle := t.Renderer.NewStyle().
-			Foreground(t.InProgress).
+			Foreground(GetLabelColor(issue.Labels[0])).
 			Padding(0, 0)
 		meta = append(meta, labelStyle.Render(labelText))
 	}
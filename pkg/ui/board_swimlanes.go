@@ -0,0 +1,420 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// boardLane is one swimlane: the issues of one assignee or epic, by status
+type boardLane struct {
+	key     string
+	label   string
+	columns [4][]model.Issue
+}
+
+// count returns the number of issues in the lane
+func (l boardLane) count() int {
+	n := 0
+	for _, col := range l.columns {
+		n += len(col)
+	}
+	return n
+}
+
+// maxLaneRows caps a lane's height when the lanes don't all fit
+const maxLaneRows = 6
+
+// NextSwimlanes returns the swimlane mode after by: none → assignee → epic
+func NextSwimlanes(by GroupBy) GroupBy {
+	switch by {
+	case GroupNone:
+		return GroupAssignee
+	case GroupAssignee:
+		return GroupEpic
+	default:
+		return GroupNone
+	}
+}
+
+// Swimlanes returns how the board is split into lanes (GroupNone for none)
+func (b *BoardModel) Swimlanes() GroupBy {
+	return b.laneBy
+}
+
+// SetSwimlanes splits the board into one row per assignee or epic, keeping
+// the status columns. Any other grouping turns swimlanes off. issueMap holds
+// every issue, so an epic still labels its lane when it is filtered out.
+func (b *BoardModel) SetSwimlanes(by GroupBy, issueMap map[string]*model.Issue) {
+	if by != GroupAssignee && by != GroupEpic {
+		by = GroupNone
+	}
+	b.laneBy = by
+	b.issueMap = issueMap
+	b.buildLanes()
+}
+
+// LaneLabels returns the lane titles, top to bottom
+func (b *BoardModel) LaneLabels() []string {
+	labels := make([]string, len(b.lanes))
+	for i, l := range b.lanes {
+		labels[i] = l.label
+	}
+	return labels
+}
+
+// hasLanes reports whether the board is showing swimlanes
+func (b *BoardModel) hasLanes() bool {
+	return b.laneBy != GroupNone && len(b.lanes) > 0
+}
+
+// buildLanes regroups the board's issues into lanes, keeping the selected
+// issue selected where it still exists
+func (b *BoardModel) buildLanes() {
+	var selectedID string
+	if sel := b.laneSelectedIssue(); sel != nil {
+		selectedID = sel.ID
+	}
+	b.lanes = nil
+	if b.laneBy == GroupNone {
+		return
+	}
+
+	index := make(map[string]int)
+	var keys []groupKey
+	var laneIssues [][]model.Issue
+	for _, issue := range b.issues {
+		gk := groupKeysFor(issue, b.laneBy, b.issueMap, nil)[0]
+		i, ok := index[gk.key]
+		if !ok {
+			i = len(keys)
+			index[gk.key] = i
+			keys = append(keys, gk)
+			laneIssues = append(laneIssues, nil)
+		}
+		laneIssues[i] = append(laneIssues[i], issue)
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	// Same order as the list's sections: named lanes alphabetically, then
+	// Unassigned / No epic
+	sort.SliceStable(order, func(i, j int) bool {
+		a, c := keys[order[i]], keys[order[j]]
+		if a.order != c.order {
+			return a.order < c.order
+		}
+		return strings.ToLower(a.label) < strings.ToLower(c.label)
+	})
+	for _, i := range order {
+		b.lanes = append(b.lanes, boardLane{key: keys[i].key, label: keys[i].label, columns: boardColumns(laneIssues[i])})
+	}
+
+	if b.focusedLane >= len(b.lanes) {
+		b.focusedLane = len(b.lanes) - 1
+	}
+	if b.focusedLane < 0 {
+		b.focusedLane = 0
+	}
+	if selectedID != "" {
+		b.selectLaneIssue(selectedID)
+	}
+	b.clampLaneRow()
+}
+
+// selectLaneIssue moves the selection to the issue with the given ID
+func (b *BoardModel) selectLaneIssue(id string) bool {
+	for li, lane := range b.lanes {
+		for colIdx, col := range lane.columns {
+			for row, issue := range col {
+				if issue.ID != id {
+					continue
+				}
+				for i, active := range b.activeColIdx {
+					if active == colIdx {
+						b.focusedLane, b.focusedCol, b.laneRow = li, i, row
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// laneCell returns the issues in the focused lane and column
+func (b *BoardModel) laneCell() []model.Issue {
+	if b.focusedLane < 0 || b.focusedLane >= len(b.lanes) {
+		return nil
+	}
+	return b.lanes[b.focusedLane].columns[b.actualFocusedCol()]
+}
+
+// clampLaneRow keeps the selection inside the focused cell
+func (b *BoardModel) clampLaneRow() {
+	if n := len(b.laneCell()); b.laneRow >= n {
+		b.laneRow = n - 1
+	}
+	if b.laneRow < 0 {
+		b.laneRow = 0
+	}
+}
+
+// laneSelectedIssue returns the selected issue in swimlane mode
+func (b *BoardModel) laneSelectedIssue() *model.Issue {
+	cell := b.laneCell()
+	if b.laneRow >= 0 && b.laneRow < len(cell) {
+		return &cell[b.laneRow]
+	}
+	return nil
+}
+
+// laneMoveDown moves down the cell, continuing into the next lane with
+// issues in this column
+func (b *BoardModel) laneMoveDown() {
+	if b.laneRow < len(b.laneCell())-1 {
+		b.laneRow++
+		return
+	}
+	col := b.actualFocusedCol()
+	for i := b.focusedLane + 1; i < len(b.lanes); i++ {
+		if len(b.lanes[i].columns[col]) > 0 {
+			b.focusedLane, b.laneRow = i, 0
+			return
+		}
+	}
+}
+
+// laneMoveUp moves up the cell, continuing into the previous lane with
+// issues in this column
+func (b *BoardModel) laneMoveUp() {
+	if b.laneRow > 0 {
+		b.laneRow--
+		return
+	}
+	col := b.actualFocusedCol()
+	for i := b.focusedLane - 1; i >= 0; i-- {
+		if n := len(b.lanes[i].columns[col]); n > 0 {
+			b.focusedLane, b.laneRow = i, n-1
+			return
+		}
+	}
+}
+
+// laneMoveToTop selects the first issue in this column
+func (b *BoardModel) laneMoveToTop() {
+	col := b.actualFocusedCol()
+	for i := range b.lanes {
+		if len(b.lanes[i].columns[col]) > 0 {
+			b.focusedLane, b.laneRow = i, 0
+			return
+		}
+	}
+}
+
+// laneMoveToBottom selects the last issue in this column
+func (b *BoardModel) laneMoveToBottom() {
+	col := b.actualFocusedCol()
+	for i := len(b.lanes) - 1; i >= 0; i-- {
+		if n := len(b.lanes[i].columns[col]); n > 0 {
+			b.focusedLane, b.laneRow = i, n-1
+			return
+		}
+	}
+}
+
+// moveLane jumps delta lanes, to the top of the cell
+func (b *BoardModel) moveLane(delta int) {
+	b.focusedLane += delta
+	if b.focusedLane >= len(b.lanes) {
+		b.focusedLane = len(b.lanes) - 1
+	}
+	if b.focusedLane < 0 {
+		b.focusedLane = 0
+	}
+	b.laneRow = 0
+}
+
+// swimlaneView renders one row per lane under shared status headers. Cards
+// shrink to one line each so a team's whole board fits on a screen.
+func (b BoardModel) swimlaneView(width, height int) string {
+	t := b.theme
+	numCols := len(b.activeColIdx)
+	colWidth := (width - (numCols-1)*2) / numCols
+	if colWidth < 16 {
+		colWidth = 16
+	}
+	fullWidth := colWidth*numCols + (numCols-1)*2
+
+	columnTitles := []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"}
+	columnColors := []lipgloss.AdaptiveColor{t.Open, t.InProgress, t.Blocked, t.Closed}
+	columnEmoji := []string{"📋", "🔄", "🚫", "✅"}
+	gap := strings.Repeat(" ", 2)
+
+	// Status headers, counting every lane
+	var headers []string
+	for i, colIdx := range b.activeColIdx {
+		countText, overLimit := columnCount(colIdx, len(b.columns[colIdx]))
+		color := columnColors[colIdx]
+		if overLimit {
+			color = t.Blocked
+		}
+		style := t.Renderer.NewStyle().Width(colWidth).Align(lipgloss.Center).Bold(true)
+		if b.focusedCol == i {
+			style = style.Background(color).
+				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
+		} else {
+			style = style.Background(lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#2a2a2a"}).
+				Foreground(color)
+		}
+		text := fmt.Sprintf("%s %s %s", columnEmoji[colIdx], columnTitles[colIdx], countText)
+		headers = append(headers, style.Render(truncateToWidth(text, colWidth, "…")))
+	}
+	lines := []string{strings.Join(headers, gap)}
+
+	// Lane heights: a title line plus the tallest cell, capped when the
+	// lanes don't all fit
+	avail := height - 2 // Headers and the lane position line
+	rows := make([]int, len(b.lanes))
+	total := 0
+	for i, lane := range b.lanes {
+		for _, colIdx := range b.activeColIdx {
+			rows[i] = max(rows[i], len(lane.columns[colIdx]))
+		}
+		rows[i] = max(rows[i], 1)
+		total += rows[i] + 1
+	}
+	if total > avail {
+		for i := range rows {
+			rows[i] = min(rows[i], maxLaneRows)
+		}
+	}
+
+	// Scroll so the focused lane is visible
+	start := 0
+	for {
+		used := 0
+		for i := start; i <= b.focusedLane && i < len(b.lanes); i++ {
+			used += rows[i] + 1
+		}
+		if used <= avail || start >= b.focusedLane {
+			break
+		}
+		start++
+	}
+
+	used, end := 0, start
+	for end < len(b.lanes) && (end == start || used+rows[end]+1 <= avail) {
+		lines = append(lines, b.renderLane(end, rows[end], colWidth, fullWidth)...)
+		used += rows[end] + 1
+		end++
+	}
+	if start > 0 || end < len(b.lanes) {
+		pos := fmt.Sprintf("↕ lane %d/%d", b.focusedLane+1, len(b.lanes))
+		lines = append(lines, t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render(pos))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderLane renders a lane's title and its cells, rows lines tall
+func (b BoardModel) renderLane(li, rows, colWidth, fullWidth int) []string {
+	t := b.theme
+	lane := b.lanes[li]
+	focused := li == b.focusedLane
+
+	title := fmt.Sprintf("── %s (%d) ", lane.label, lane.count())
+	overLimit := false
+	if b.laneBy == GroupAssignee {
+		// Assignee lanes show the person's WIP limit
+		if limit := analysis.CurrentWIPLimits().AssigneeLimit(lane.key); limit > 0 {
+			wip := len(lane.columns[ColInProgress])
+			overLimit = wip > limit
+			warn := ""
+			if overLimit {
+				warn = "⚠ "
+			}
+			title = fmt.Sprintf("── %s (%d) · %sWIP %d/%d ", lane.label, lane.count(), warn, wip, limit)
+		}
+	}
+	title = truncateToWidth(title, fullWidth, "…")
+	if pad := fullWidth - lipgloss.Width(title); pad > 0 {
+		title += repeatToWidth("─", pad)
+	}
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	if focused {
+		titleStyle = titleStyle.Foreground(t.Primary).Bold(true)
+	}
+	if overLimit {
+		titleStyle = titleStyle.Foreground(t.Blocked).Bold(true)
+	}
+	out := []string{titleStyle.Render(title)}
+
+	var cells []string
+	for i, colIdx := range b.activeColIdx {
+		selRow := -1
+		if focused && i == b.focusedCol {
+			selRow = b.laneRow
+		}
+		cells = append(cells, b.renderLaneCell(lane.columns[colIdx], selRow, rows, colWidth))
+	}
+	return append(out, lipgloss.JoinHorizontal(lipgloss.Top, joinWithGap(cells, 2)...))
+}
+
+// renderLaneCell renders up to rows one-line cards, scrolled so the
+// selected row (-1 for none) is visible
+func (b BoardModel) renderLaneCell(issues []model.Issue, selRow, rows, width int) string {
+	t := b.theme
+	style := t.Renderer.NewStyle().Width(width).MaxWidth(width)
+	muted := style.Foreground(t.Secondary).Italic(true)
+
+	shown, start := len(issues), 0
+	if len(issues) > rows {
+		shown = rows - 1 // Leave a line for the overflow note
+		if selRow >= shown {
+			start = selRow - shown + 1
+		}
+	}
+
+	var lines []string
+	for row := start; row < start+shown && row < len(issues); row++ {
+		issue := issues[row]
+		text := GetPriorityIcon(issue.Priority) + " " +
+			truncateToWidth(issue.ID+" "+issue.Title, width-3, "…")
+		if row == selRow {
+			lines = append(lines, style.Background(t.Highlight).Foreground(t.Primary).Bold(true).Render(text))
+		} else {
+			lines = append(lines, style.Render(text))
+		}
+	}
+	switch {
+	case len(issues) > rows && selRow >= 0:
+		lines = append(lines, muted.Render(fmt.Sprintf("↕ %d/%d", selRow+1, len(issues))))
+	case len(issues) > rows:
+		lines = append(lines, muted.Render(fmt.Sprintf("+%d more", len(issues)-shown)))
+	case len(issues) == 0:
+		lines = append(lines, muted.Foreground(ColorMuted).Render("·"))
+	}
+	for len(lines) < rows {
+		lines = append(lines, style.Render(""))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// joinWithGap interleaves blocks with gap-wide spacers for JoinHorizontal
+func joinWithGap(blocks []string, gap int) []string {
+	spacer := strings.Repeat(" ", gap)
+	out := make([]string, 0, len(blocks)*2)
+	for i, block := range blocks {
+		if i > 0 {
+			out = append(out, spacer)
+		}
+		out = append(out, block)
+	}
+	return out
+}
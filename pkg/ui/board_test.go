@@ -3,9 +3,11 @@ package ui_test
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 
@@ -368,3 +370,102 @@ func TestViewRendering(t *testing.T) {
 		})
 	}
 }
+
+// TestSwimlanes verifies lane grouping, navigation across lanes and rendering
+func TestSwimlanes(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "E1", Title: "Epic", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 0, Assignee: "bob", CreatedAt: createTime(5)},
+		{ID: "A1", Status: model.StatusOpen, Priority: 1, Assignee: "bob", CreatedAt: createTime(4),
+			Dependencies: []*model.Dependency{{IssueID: "A1", DependsOnID: "E1", Type: model.DepParentChild}}},
+		{ID: "A2", Status: model.StatusInProgress, Priority: 1, Assignee: "alice", CreatedAt: createTime(3)},
+		{ID: "A3", Status: model.StatusOpen, Priority: 2, CreatedAt: createTime(2)},
+		{ID: "A4", Status: model.StatusOpen, Priority: 2, Assignee: "alice", CreatedAt: createTime(1)},
+	}
+	issueMap := make(map[string]*model.Issue)
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	b := ui.NewBoardModel(issues, theme)
+	b.SetSwimlanes(ui.NextSwimlanes(ui.GroupNone), issueMap)
+	if b.Swimlanes() != ui.GroupAssignee {
+		t.Fatalf("expected assignee lanes, got %v", b.Swimlanes())
+	}
+	if got := fmt.Sprint(b.LaneLabels()); got != "[@alice @bob Unassigned]" {
+		t.Errorf("unexpected lanes %s", got)
+	}
+
+	// Open column: alice has A4, bob has E1 then A1, unassigned has A3
+	want := []string{"A4", "E1", "A1", "A3"}
+	for i, id := range want {
+		if sel := b.SelectedIssue(); sel == nil || sel.ID != id {
+			t.Fatalf("step %d: expected %s, got %v", i, id, sel)
+		}
+		b.MoveDown()
+	}
+	if sel := b.SelectedIssue(); sel.ID != "A3" {
+		t.Errorf("moving past the last lane should stay put, got %s", sel.ID)
+	}
+	b.MoveToTop()
+	if sel := b.SelectedIssue(); sel.ID != "A4" {
+		t.Errorf("expected home to select A4, got %s", sel.ID)
+	}
+	b.PageDown(10)
+	if sel := b.SelectedIssue(); sel.ID != "E1" {
+		t.Errorf("expected ctrl+d to jump to bob's lane, got %s", sel.ID)
+	}
+
+	// Refiltering keeps the selected issue
+	b.SetIssues(issues[:3])
+	if sel := b.SelectedIssue(); sel == nil || sel.ID != "E1" {
+		t.Errorf("expected E1 to stay selected, got %v", sel)
+	}
+
+	b.SetIssues(issues)
+	b.SetSwimlanes(ui.NextSwimlanes(b.Swimlanes()), issueMap)
+	if got := fmt.Sprint(b.LaneLabels()); got != "[E1 Epic No epic]" {
+		t.Errorf("unexpected epic lanes %s", got)
+	}
+	out := b.View(100, 20)
+	for _, s := range []string{"E1 Epic (2)", "No epic (3)", "IN PROGRESS (1)"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in swimlane view:\n%s", s, out)
+		}
+	}
+	_ = b.View(40, 6) // Too short for every lane; should not panic
+
+	b.SetSwimlanes(ui.NextSwimlanes(b.Swimlanes()), issueMap)
+	if b.Swimlanes() != ui.GroupNone || len(b.LaneLabels()) != 0 {
+		t.Errorf("expected swimlanes off")
+	}
+}
+
+// TestBoardWIPLimits verifies over-limit columns and lanes are flagged
+func TestBoardWIPLimits(t *testing.T) {
+	defer analysis.SetWIPLimits(analysis.DefaultWIPLimits())
+	analysis.SetWIPLimits(analysis.WIPLimits{
+		Status:      map[model.Status]int{model.StatusInProgress: 1, model.StatusOpen: 5},
+		PerAssignee: 1,
+	})
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusInProgress, Assignee: "ann"},
+		{ID: "B", Status: model.StatusInProgress, Assignee: "ann"},
+		{ID: "C", Status: model.StatusOpen, Assignee: "bob"},
+	}
+	b := ui.NewBoardModel(issues, createTheme())
+	out := b.View(120, 30)
+	for _, s := range []string{"IN PROGRESS ⚠ (2/1)", "OPEN (1/5)"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in board:\n%s", s, out)
+		}
+	}
+
+	b.SetSwimlanes(ui.GroupAssignee, nil)
+	out = b.View(120, 30)
+	for _, s := range []string{"@ann (2) · ⚠ WIP 2/1", "@bob (1) · WIP 0/1"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in swimlanes:\n%s", s, out)
+		}
+	}
+}
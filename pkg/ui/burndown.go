@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// burndownBarWidth is the width of one bucket's bar; one column of gap follows it
+const burndownBarWidth = 2
+
+// eighthBlocks are partial bar tops indexed by eighths filled (0 = empty)
+var eighthBlocks = []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇"}
+
+// BurndownModel charts open scope (burndown) or completed vs total scope (burnup) over time
+type BurndownModel struct {
+	issues      []model.Issue
+	now         time.Time
+	granularity analysis.Granularity
+	usePoints   bool // Chart estimated minutes instead of issue counts
+	burnup      bool
+	width       int
+	height      int
+	theme       Theme
+}
+
+// NewBurndownModel creates a daily burndown chart of issue counts
+func NewBurndownModel(issues []model.Issue, now time.Time, theme Theme) BurndownModel {
+	return BurndownModel{
+		issues: issues,
+		now:    now,
+		theme:  theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *BurndownModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ToggleGranularity switches between day and week buckets
+func (m *BurndownModel) ToggleGranularity() {
+	if m.granularity == analysis.GranularityDay {
+		m.granularity = analysis.GranularityWeek
+	} else {
+		m.granularity = analysis.GranularityDay
+	}
+}
+
+// TogglePoints switches between issue counts and estimated points
+func (m *BurndownModel) TogglePoints() {
+	m.usePoints = !m.usePoints
+}
+
+// ToggleBurnup switches between burndown and burnup charts
+func (m *BurndownModel) ToggleBurnup() {
+	m.burnup = !m.burnup
+}
+
+// Granularity returns the current bucket size
+func (m *BurndownModel) Granularity() analysis.Granularity {
+	return m.granularity
+}
+
+// bucketCount returns how many bars fit next to a y-axis of the given width
+func (m *BurndownModel) bucketCount(axisWidth int) int {
+	n := (m.width - axisWidth - 2) / (burndownBarWidth + 1)
+	limit := 90
+	if m.granularity == analysis.GranularityWeek {
+		limit = 52
+	}
+	if n > limit {
+		n = limit
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// values extracts the plotted series: the bar value and, for burnup, the scope behind it
+func (m *BurndownModel) values(points []analysis.BurnPoint) (bars, scope []int) {
+	bars = make([]int, len(points))
+	scope = make([]int, len(points))
+	for i, p := range points {
+		switch {
+		case m.burnup && m.usePoints:
+			bars[i], scope[i] = p.DonePoints, p.ScopePoints
+		case m.burnup:
+			bars[i], scope[i] = p.Done, p.Scope
+		case m.usePoints:
+			bars[i] = p.RemainingPoints()
+		default:
+			bars[i] = p.Open()
+		}
+	}
+	return bars, scope
+}
+
+// formatValue renders a chart value as a count or a duration
+func (m *BurndownModel) formatValue(v int) string {
+	if m.usePoints {
+		return FormatMinutes(v)
+	}
+	return fmt.Sprintf("%d", v)
+}
+
+// Render renders the chart
+func (m *BurndownModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	title, unit := "📉 BURNDOWN", "open issues"
+	if m.burnup {
+		title, unit = "📈 BURNUP", "closed vs total issues"
+	}
+	if m.usePoints {
+		unit = strings.Replace(unit, "issues", "points", 1)
+	}
+
+	// Nothing plotted can exceed today's total scope, so its label sizes the y axis
+	total := analysis.ComputeBurndown(m.issues, m.now, m.granularity, 1)[0]
+	largest := total.Scope
+	if m.usePoints {
+		largest = total.ScopePoints
+	}
+	axisWidth := max(lipgloss.Width(m.formatValue(largest)), 2)
+	buckets := m.bucketCount(axisWidth)
+	points := analysis.ComputeBurndown(m.issues, m.now, m.granularity, buckets)
+	bars, scope := m.values(points)
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	header := fmt.Sprintf("%s  │  %s  │  per %s  │  last %d %ss",
+		title, unit, m.granularity, buckets, m.granularity)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	maxVal := 0
+	for i := range bars {
+		maxVal = max(maxVal, bars[i], scope[i])
+	}
+
+	// header, blank, x axis, x labels, blank, summary
+	chartHeight := m.height - 6
+	if chartHeight < 3 {
+		chartHeight = 3
+	}
+
+	barStyle := t.Renderer.NewStyle().Foreground(t.Primary)
+	if m.burnup {
+		barStyle = barStyle.Foreground(t.Open)
+	}
+	ghostStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	axisStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	scaled := func(v int) float64 {
+		if maxVal == 0 {
+			return 0
+		}
+		return float64(v) / float64(maxVal) * float64(chartHeight)
+	}
+
+	for row := 0; row < chartHeight; row++ {
+		level := float64(chartHeight - row) // 1-based height of this row
+
+		label := ""
+		switch row {
+		case 0:
+			label = m.formatValue(maxVal)
+		case chartHeight / 2:
+			if chartHeight >= 6 {
+				label = m.formatValue(int(float64(maxVal) * level / float64(chartHeight)))
+			}
+		}
+		var sb strings.Builder
+		sb.WriteString(axisStyle.Render(fmt.Sprintf("%*s┤", axisWidth, label)))
+
+		for i := range bars {
+			bar := scaled(bars[i])
+			cell := " "
+			ghost := false
+			switch {
+			case bar >= level:
+				cell = "█"
+			case bar > level-1:
+				cell = eighthBlocks[int((bar-(level-1))*8)]
+			case m.burnup && scaled(scope[i]) > level-1:
+				cell, ghost = "░", true
+			}
+			segment := strings.Repeat(cell, burndownBarWidth)
+			if ghost {
+				sb.WriteString(ghostStyle.Render(segment))
+			} else {
+				sb.WriteString(barStyle.Render(segment))
+			}
+			sb.WriteString(" ")
+		}
+		lines = append(lines, sb.String())
+	}
+
+	// X axis with the first, middle and last bucket dates
+	plotWidth := buckets * (burndownBarWidth + 1)
+	lines = append(lines, axisStyle.Render(strings.Repeat(" ", axisWidth)+"└"+repeatToWidth("─", plotWidth)))
+	lines = append(lines, axisStyle.Render(strings.Repeat(" ", axisWidth+1)+m.renderXLabels(points, plotWidth)))
+	lines = append(lines, "")
+
+	last := points[len(points)-1]
+	first := points[0]
+	var summary string
+	if m.burnup {
+		pct := 0.0
+		if scope[len(scope)-1] > 0 {
+			pct = float64(bars[len(bars)-1]) / float64(scope[len(scope)-1]) * 100
+		}
+		summary = fmt.Sprintf("Done %s of %s (%.0f%%)", m.formatValue(bars[len(bars)-1]), m.formatValue(scope[len(scope)-1]), pct)
+	} else {
+		delta := bars[len(bars)-1] - bars[0]
+		sign := "+"
+		if delta < 0 {
+			sign = "-"
+			delta = -delta
+		}
+		summary = fmt.Sprintf("Open now %s  ·  %s%s since %s  ·  %d closed, %d opened in range",
+			m.formatValue(bars[len(bars)-1]), sign, m.formatValue(delta), first.End.AddDate(0, 0, -1).Format("Jan 02"),
+			last.Done-first.Done, last.Scope-first.Scope)
+	}
+	lines = append(lines, " "+summary)
+
+	return strings.Join(lines, "\n")
+}
+
+// renderXLabels spreads the first, middle and last bucket dates across the plot width.
+// Each bucket is labeled with its last day.
+func (m *BurndownModel) renderXLabels(points []analysis.BurnPoint, width int) string {
+	label := func(p analysis.BurnPoint) string {
+		return p.End.AddDate(0, 0, -1).Format("Jan 02")
+	}
+	row := []rune(strings.Repeat(" ", width))
+	place := func(col int, s string) {
+		r := []rune(s)
+		if col+len(r) > len(row) {
+			col = len(row) - len(r)
+		}
+		if col < 0 {
+			return
+		}
+		copy(row[col:], r)
+	}
+
+	place(0, label(points[0]))
+	if n := len(points); n > 1 {
+		step := burndownBarWidth + 1
+		if mid := n / 2; mid*step > 8 && (n-1-mid)*step > 8 {
+			place(mid*step, label(points[mid]))
+		}
+		if (n-1)*step > 8 {
+			place((n-1)*step, label(points[n-1]))
+		}
+	}
+	return strings.TrimRight(string(row), " ")
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func burndownTestIssues(now time.Time) []model.Issue {
+	closed := now.AddDate(0, 0, -2)
+	return []model.Issue{
+		{ID: "A", Status: model.StatusClosed, CreatedAt: now.AddDate(0, 0, -20), ClosedAt: &closed, EstimatedMinutes: intPtr(90)},
+		{ID: "B", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -10), EstimatedMinutes: intPtr(30)},
+		{ID: "C", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -5)},
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestBurndownRenderFitsAndToggles(t *testing.T) {
+	now := time.Now()
+	b := NewBurndownModel(burndownTestIssues(now), now, DefaultTheme(lipgloss.NewRenderer(nil)))
+	b.SetSize(80, 20)
+
+	out := b.Render()
+	if !strings.Contains(out, "BURNDOWN") || !strings.Contains(out, "Open now 2") {
+		t.Fatalf("expected burndown header and summary, got:\n%s", out)
+	}
+	if !strings.Contains(out, "█") {
+		t.Fatalf("expected bars in chart")
+	}
+	for i, line := range strings.Split(out, "\n") {
+		if w := lipgloss.Width(line); w > 80 {
+			t.Errorf("line %d overflows: %d cells", i, w)
+		}
+	}
+
+	b.ToggleGranularity()
+	if b.Granularity() != analysis.GranularityWeek || !strings.Contains(b.Render(), "per week") {
+		t.Fatalf("expected weekly buckets")
+	}
+
+	b.TogglePoints()
+	if out := b.Render(); !strings.Contains(out, "open points") || !strings.Contains(out, "Open now 30m") {
+		t.Fatalf("expected remaining points, got:\n%s", out)
+	}
+
+	b.ToggleBurnup()
+	if out := b.Render(); !strings.Contains(out, "BURNUP") || !strings.Contains(out, "Done 1h30m of 2h (75%)") {
+		t.Fatalf("expected burnup summary, got:\n%s", out)
+	}
+}
+
+func TestBurndownViewKeys(t *testing.T) {
+	m := NewModel(burndownTestIssues(time.Now()), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")})
+	m = updated.(Model)
+	if !m.isBurndownView || m.focused != focusBurndown {
+		t.Fatalf("expected burndown view focused")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(Model)
+	if m.burndownView.Granularity() != analysis.GranularityWeek {
+		t.Fatalf("expected w to switch to weekly buckets")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.isBurndownView || m.focused != focusList {
+		t.Fatalf("expected esc to close burndown view")
+	}
+}
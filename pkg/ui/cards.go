@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cardLineNames describe the rows for 1, 2 and 3 lines per issue
+var cardLineNames = []string{"", "single lines", "two-line cards", "three-line cards"}
+
+// activityWeeks is how many weeks a card's activity sparkline covers
+const activityWeeks = 8
+
+// sparkLevels draw a sparkline's bars, lowest first
+var sparkLevels = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// openDependents counts, for each issue, the open issues it blocks
+func openDependents(issueMap map[string]*model.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issueMap {
+		if issue.Status.IsClosed() {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || dep.DependsOnID == issue.ID {
+				continue
+			}
+			counts[dep.DependsOnID]++
+		}
+	}
+	return counts
+}
+
+// weeklyActivity counts the issue's events in each of the last weeks weeks,
+// oldest first: creation, status changes (or start and close when the
+// tracker keeps no history) and comments
+func weeklyActivity(issue *model.Issue, now time.Time, weeks int) []int {
+	counts := make([]int, weeks)
+	add := func(at time.Time) {
+		if at.IsZero() || at.After(now) {
+			return
+		}
+		if w := int(now.Sub(at) / (7 * 24 * time.Hour)); w < weeks {
+			counts[weeks-1-w]++
+		}
+	}
+	add(issue.CreatedAt)
+	if len(issue.StatusHistory) > 0 {
+		for _, change := range issue.StatusHistory {
+			add(change.At)
+		}
+	} else {
+		for _, at := range []*time.Time{issue.StartedAt, issue.ClosedAt} {
+			if at != nil {
+				add(*at)
+			}
+		}
+	}
+	for _, c := range issue.Comments {
+		if c != nil {
+			add(c.CreatedAt)
+		}
+	}
+	return counts
+}
+
+// renderActivitySparkline draws counts as bars scaled to the busiest week,
+// quiet weeks as a dim floor
+func renderActivitySparkline(counts []int, t Theme) string {
+	peak := 0
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	quiet := t.Renderer.NewStyle().Foreground(ColorBgHighlight)
+	busy := t.Renderer.NewStyle().Foreground(ColorInfo)
+	var sb strings.Builder
+	for _, c := range counts {
+		if c == 0 {
+			sb.WriteString(quiet.Render(sparkLevels[0]))
+			continue
+		}
+		level := min(1+(c*(len(sparkLevels)-1)-1)/peak, len(sparkLevels)-1)
+		sb.WriteString(busy.Render(sparkLevels[level]))
+	}
+	return sb.String()
+}
+
+// blockerSummary describes what the issue waits on or holds up, "" when
+// neither applies
+func (d IssueDelegate) blockerSummary(issue *model.Issue) string {
+	t := d.Theme
+	if issue.Status.IsClosed() {
+		return ""
+	}
+	var blockers []string
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if b, ok := d.IssueMap[dep.DependsOnID]; ok && !b.Status.IsClosed() {
+			blockers = append(blockers, b.ID)
+		}
+	}
+	if len(blockers) > 0 {
+		text := "⛔ waiting on " + strings.Join(blockers[:min(len(blockers), 2)], ", ")
+		if len(blockers) > 2 {
+			text += fmt.Sprintf(" +%d", len(blockers)-2)
+		}
+		return t.Renderer.NewStyle().Foreground(t.Blocked).Render(text)
+	}
+	if n := d.Unblocks[issue.ID]; n > 0 {
+		return t.Renderer.NewStyle().Foreground(ColorWarning).Render(fmt.Sprintf("⚡ unblocks %d", n))
+	}
+	if issue.Status == model.StatusOpen {
+		return t.Renderer.NewStyle().Foreground(t.Open).Render("✓ ready")
+	}
+	return ""
+}
+
+// renderCardLines renders the lines a card adds under the issue's row,
+// starting at the title column (indent) of a row width wide: labels and
+// blockers, then on three-line cards the description's first line, with
+// the activity sparkline at the end of the last line
+func (d IssueDelegate) renderCardLines(i IssueItem, width, indent int, isSelected bool) []string {
+	t := d.Theme
+	subtle := t.Renderer.NewStyle().Foreground(ColorMuted)
+	avail := max(width-indent, 10)
+	spark := renderActivitySparkline(weeklyActivity(&i.Issue, time.Now(), activityWeeks), t)
+	if avail >= 60 {
+		spark = subtle.Render("activity ") + spark
+	}
+
+	var parts []string
+	if len(i.Issue.Labels) > 0 {
+		parts = append(parts, RenderLabelChips(t.Renderer, i.Issue.Labels, avail/2))
+	}
+	if s := d.blockerSummary(&i.Issue); s != "" {
+		parts = append(parts, s)
+	}
+	detail := strings.Join(parts, subtle.Render(" · "))
+
+	lines := []string{detail}
+	if d.Lines >= 3 {
+		desc := strings.TrimSpace(i.Issue.Description)
+		if n := strings.IndexByte(desc, '\n'); n >= 0 {
+			desc = desc[:n]
+		}
+		if desc == "" {
+			desc = "no description"
+		}
+		lines = append(lines, subtle.Render(truncateToWidth(desc, max(avail-lipgloss.Width(spark)-2, 5), "…")))
+	}
+
+	// Right-align the sparkline on the last line
+	last := &lines[len(lines)-1]
+	if gap := avail - lipgloss.Width(*last) - lipgloss.Width(spark); gap >= 1 {
+		*last += strings.Repeat(" ", gap) + spark
+	}
+
+	rowStyle := t.Renderer.NewStyle().Width(width).MaxWidth(width)
+	if isSelected {
+		rowStyle = rowStyle.Background(t.Highlight)
+	}
+	for n, line := range lines {
+		lines[n] = rowStyle.Render(strings.Repeat(" ", indent) + line)
+	}
+	return lines
+}
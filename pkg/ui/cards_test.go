@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestWeeklyActivity(t *testing.T) {
+	now := time.Now()
+	ago := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+	closed := ago(1)
+	issue := model.Issue{
+		CreatedAt: ago(20),
+		ClosedAt:  &closed,
+		Comments:  []*model.Comment{{CreatedAt: ago(2)}, {CreatedAt: ago(100)}, nil},
+	}
+	if got, want := weeklyActivity(&issue, now, 4), []int{0, 1, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A status history replaces the start and close times
+	issue.StatusHistory = []model.StatusChange{{Status: model.StatusInProgress, At: ago(9)}}
+	if got, want := weeklyActivity(&issue, now, 4), []int{0, 1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with history got %v, want %v", got, want)
+	}
+}
+
+func TestIssueDelegate_RenderCard(t *testing.T) {
+	item := newTestIssueItem("A")
+	item.Issue.Description = "Why this matters\nMore detail"
+	item.Issue.Dependencies = []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}
+	blocker := model.Issue{ID: "B", Status: model.StatusOpen}
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+
+	delegate := IssueDelegate{
+		Theme:    theme,
+		Lines:    3,
+		IssueMap: map[string]*model.Issue{"A": &item.Issue, "B": &blocker},
+	}
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+	l.SetWidth(160)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a three-line card, got %d lines:\n%s", len(lines), buf.String())
+	}
+	for n, want := range []string{"Short title for testing", "waiting on B", "Why this matters"} {
+		if !strings.Contains(lines[n], want) {
+			t.Errorf("expected %q on line %d, got %q", want, n+1, lines[n])
+		}
+	}
+	if !strings.Contains(lines[1], "one") || strings.Contains(lines[0], "one") {
+		t.Errorf("expected labels under the row, not in it:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[2], "activity") {
+		t.Errorf("expected the activity sparkline on the last line, got %q", lines[2])
+	}
+	if !strings.Contains(delegate.RenderHeader(160, fitColumns(l.Items()), SortMode{}, ""), "ASSIGNEE") ||
+		strings.Contains(delegate.RenderHeader(160, fitColumns(l.Items()), SortMode{}, ""), "LABELS") {
+		t.Errorf("expected the header to drop only the labels column")
+	}
+}
+
+func TestCardLinesKey(t *testing.T) {
+	var issues []model.Issue
+	for _, id := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"} {
+		issues = append(issues, model.Issue{ID: id, Title: id, Status: model.StatusOpen})
+	}
+	issues[1].Dependencies = []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	perPage := m.list.Paginator.PerPage
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(Model)
+	if m.statusMsg != "Rows: two-line cards" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if got := m.list.Paginator.PerPage; got != perPage/2 {
+		t.Errorf("expected two-line cards to halve the page from %d, got %d", perPage, got)
+	}
+	if m.list.Index() != 0 {
+		t.Errorf("expected the selection to stay put, got %d", m.list.Index())
+	}
+	if m.listUnblocks["A"] != 1 {
+		t.Errorf("expected A to unblock B, got %v", m.listUnblocks)
+	}
+	if out := m.View(); !strings.Contains(out, "unblocks 1") {
+		t.Errorf("expected cards in the list:\n%s", out)
+	}
+
+	m = typeKeys(t, m, "xx")
+	if m.cardLines != 1 || m.list.Paginator.PerPage != perPage {
+		t.Errorf("expected x to cycle back to single lines")
+	}
+}
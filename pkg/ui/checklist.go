@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ChecklistToggledMsg reports the outcome of writing back a checklist item
+// toggled in the details
+type ChecklistToggledMsg struct {
+	ID       string
+	Item     string
+	Done     bool   // The item's new state
+	Previous string // The description before the toggle, restored if bd fails
+	Err      error
+}
+
+// ToggleChecklistCmd saves the description of base, the issue as loaded,
+// with a task list item toggled, using the bd CLI. The toggle is merged into
+// any changes saved since (see writeIssueCmd). The file watcher picks up the
+// change and reloads the list.
+func ToggleChecklistCmd(beadsPath string, base model.Issue, description string, toggled ChecklistToggledMsg) tea.Cmd {
+	ours := base
+	ours.Description = description
+	return writeIssueCmd(beadsPath, issueEdit{base: base, ours: ours, done: func(err error) tea.Msg {
+		toggled.Err = err
+		return toggled
+	}})
+}
+
+// renderChecklistProgress renders how much of a checklist is done as a bar
+// barWidth cells wide and a count
+func renderChecklistProgress(t Theme, c analysis.Checklist, barWidth int) string {
+	done := c.Done()
+	filled := done * barWidth / len(c)
+	color := t.InProgress
+	if done == len(c) {
+		color = t.Open
+	}
+	return t.Renderer.NewStyle().Foreground(color).Render(repeatToWidth("█", filled)) +
+		t.Renderer.NewStyle().Foreground(t.Secondary).Render(repeatToWidth("░", barWidth-filled)) +
+		t.Renderer.NewStyle().Foreground(ColorMuted).Render(fmt.Sprintf(" %d/%d", done, len(c)))
+}
+
+// checklistCursorIndex returns the highlighted item's index among the n
+// checklist items of the issue id
+func (m *Model) checklistCursorIndex(id string, n int) int {
+	if m.checklistCursorIssue != id || m.checklistCursor >= n {
+		return 0
+	}
+	return m.checklistCursor
+}
+
+// checklistDescription returns the description for the details, the
+// highlighted task list item marked when there is more than one
+func (m *Model) checklistDescription(id, description string, items analysis.Checklist) string {
+	if len(items) < 2 {
+		return description
+	}
+	lines := strings.Split(description, "\n")
+	line := items[m.checklistCursorIndex(id, len(items))].Line
+	lines[line] = strings.TrimRight(lines[line], " \r") + " ◂"
+	return strings.Join(lines, "\n")
+}
+
+// handleChecklistKey handles the detail view's checklist keys: ( and )
+// choose an item of the description's task list, x checks it off or
+// unchecks it and returns the command saving the description
+func (m *Model) handleChecklistKey(key string) tea.Cmd {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return nil
+	}
+	items := analysis.ParseChecklist(issue.Description)
+	if len(items) == 0 {
+		if key == "x" {
+			m.statusMsg = "❌ No checklist in this issue"
+			m.statusIsError = true
+		}
+		return nil
+	}
+	cursor := m.checklistCursorIndex(issue.ID, len(items))
+	switch key {
+	case ")":
+		m.checklistCursorIssue, m.checklistCursor = issue.ID, (cursor+1)%len(items)
+		m.updateViewportContent()
+		return nil
+	case "(":
+		m.checklistCursorIssue, m.checklistCursor = issue.ID, (cursor+len(items)-1)%len(items)
+		m.updateViewportContent()
+		return nil
+	}
+	if m.refuseEdit() {
+		return nil
+	}
+	description, _ := analysis.ToggleChecklistItem(issue.Description, cursor)
+	item := items[cursor]
+	m.setIssueDescription(issue.ID, description)
+	m.checklistCursorIssue, m.checklistCursor = issue.ID, cursor
+	if item.Done {
+		m.statusMsg = fmt.Sprintf("Unchecking %q…", item.Text)
+	} else {
+		m.statusMsg = fmt.Sprintf("Checking off %q…", item.Text)
+	}
+	m.statusIsError = false
+	return ToggleChecklistCmd(m.beadsPath, issue, description, ChecklistToggledMsg{
+		ID: issue.ID, Item: item.Text, Done: !item.Done, Previous: issue.Description,
+	})
+}
+
+// setIssueDescription shows a new description for id right away, ahead of
+// the reload that picks up the saved one
+func (m *Model) setIssueDescription(id, description string) {
+	m.patchIssue(id, func(issue *model.Issue) { issue.Description = description })
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestChecklistToggleWritesBack(t *testing.T) {
+	var calls [][]string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	description := "Steps:\n- [x] Reproduce\n- [ ] Fix\n- [ ] Add a test"
+	issues := []model.Issue{{ID: "A", Title: "Crash on save", Status: model.StatusOpen, IssueType: model.TypeBug,
+		Description: description, CreatedAt: time.Now()}}
+	m := NewModel(issues, nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !strings.Contains(m.viewport.View(), "1/3") {
+		t.Fatalf("expected checklist progress in the details:\n%s", m.viewport.View())
+	}
+
+	m = typeKeys(t, m, ")")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected a command saving the description")
+	}
+	want := "Steps:\n- [x] Reproduce\n- [x] Fix\n- [ ] Add a test"
+	if m.issueMap["A"].Description != want {
+		t.Fatalf("expected the item checked off right away, got %q", m.issueMap["A"].Description)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(calls) != 1 || strings.Join(calls[0], "|") != "update|A|--description|"+want {
+		t.Fatalf("unexpected bd calls: %q", calls)
+	}
+	if m.statusMsg != `Checked off "Fix"` || m.statusIsError {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+
+	// A failed save restores the description
+	updated, _ = m.Update(ChecklistToggledMsg{ID: "A", Item: "Fix", Previous: description, Err: errors.New("bd update: locked")})
+	m = updated.(Model)
+	if m.issueMap["A"].Description != description || !m.statusIsError || !strings.Contains(m.statusMsg, "locked") {
+		t.Fatalf("expected the description restored and an error status, got %q / %q", m.issueMap["A"].Description, m.statusMsg)
+	}
+}
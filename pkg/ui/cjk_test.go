@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TestCJKDatasetLayout renders a dataset of Chinese, Japanese and Korean IDs,
+// titles, labels and assignees with ambiguous characters both narrow and
+// wide, checking that nothing wraps and the columns line up
+func TestCJKDatasetLayout(t *testing.T) {
+	issues, err := loader.LoadIssuesFromFile("../../tests/testdata/cjk.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetAmbiguousWidth("narrow") })
+
+	for _, mode := range []string{"narrow", "wide"} {
+		if err := SetAmbiguousWidth(mode); err != nil {
+			t.Fatal(err)
+		}
+		for _, width := range []int{80, 140} {
+			theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+
+			// List rows keep the assignee column under one another
+			var items []list.Item
+			for _, issue := range issues {
+				items = append(items, IssueItem{Issue: issue})
+			}
+			fit := fitColumns(items)
+			delegate := IssueDelegate{Theme: theme, Fit: &fit}
+			l := list.New(items, delegate, 0, 0)
+			l.SetWidth(width)
+			column := -1
+			for i, item := range items {
+				var buf bytes.Buffer
+				delegate.Render(&buf, l, i, item)
+				row := buf.String()
+				if strings.Contains(row, "\n") || lipgloss.Width(row) != width-1 {
+					t.Errorf("%s/%d: row %d is %d columns or wraps: %q", mode, width, i, lipgloss.Width(row), row)
+				}
+				if at := strings.Index(row, "@"); at >= 0 {
+					if column < 0 {
+						column = lipgloss.Width(row[:at])
+					} else if got := lipgloss.Width(row[:at]); got != column {
+						t.Errorf("%s/%d: row %d has the assignee at %d, not %d", mode, width, i, got, column)
+					}
+				}
+			}
+
+			// Graph boxes keep one line per field and straight borders
+			g := NewGraphModel(issues, nil, theme)
+			var ego *model.Issue
+			for i := range issues {
+				if issues[i].ID == "前端_组件_按钮样式重构" {
+					ego = &issues[i]
+				}
+			}
+			for name, box := range map[string]string{
+				"ego":  g.renderEgoNode(ego.ID, ego, width, theme),
+				"node": g.renderNodeBox("api-1", 22, theme, false, model.DepBlocks),
+				"self": g.renderNodeBox(ego.ID, 22, theme, true, ""),
+			} {
+				lines := strings.Split(strings.Trim(box, "\n"), "\n")
+				if len(lines) != 5 && !(name == "self" && len(lines) == 4) {
+					t.Errorf("%s/%d: %s box wrapped to %d lines:\n%s", mode, width, name, len(lines), box)
+				}
+				for _, line := range lines[1:] {
+					if lipgloss.Width(strings.TrimSpace(line)) != lipgloss.Width(strings.TrimSpace(lines[0])) {
+						t.Errorf("%s/%d: %s box has ragged borders:\n%s", mode, width, name, box)
+						break
+					}
+				}
+			}
+			for _, line := range strings.Split(g.renderNodeList(30, 20, theme), "\n") {
+				if lipgloss.Width(line) > 30 {
+					t.Errorf("%s/%d: node list line overflows: %q", mode, width, line)
+				}
+			}
+
+			// The footer stays on one line in every view
+			m := NewModel(issues, nil, "")
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 30})
+			m = updated.(Model)
+			for _, keys := range []string{"", "b", "g", "i"} {
+				m := typeKeys(t, m, keys)
+				if footer := m.renderFooter(); strings.Contains(footer, "\n") || lipgloss.Width(footer) > width {
+					t.Errorf("%s/%d: footer after %q does not fit: %q", mode, width, keys, footer)
+				}
+			}
+		}
+	}
+}
+
+func TestAmbiguousWidthFromEnv(t *testing.T) {
+	for _, tt := range []struct {
+		env  map[string]string
+		want int
+	}{
+		{map[string]string{}, 1},
+		{map[string]string{"LANG": "en_US.UTF-8"}, 1},
+		{map[string]string{"LANG": "ja_JP.UTF-8"}, 2},
+		{map[string]string{"LC_ALL": "C", "LANG": "zh_CN.UTF-8"}, 1},
+		{map[string]string{"LC_CTYPE": "ko_KR.UTF-8"}, 2},
+		{map[string]string{"RUNEWIDTH_EASTASIAN": "0", "LANG": "zh_TW.UTF-8"}, 1},
+		{map[string]string{"RUNEWIDTH_EASTASIAN": "1"}, 2},
+	} {
+		if got := ambiguousWidthFromEnv(func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("%v: got %d, want %d", tt.env, got, tt.want)
+		}
+	}
+	if err := SetAmbiguousWidth("double"); err == nil {
+		t.Error("expected an unknown mode to fail")
+	}
+}
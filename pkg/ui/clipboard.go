@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/atotto/clipboard"
+)
+
+// systemClipboard writes to the local clipboard. It is a variable so tests can stub it.
+var systemClipboard = clipboard.WriteAll
+
+// osc52Output receives OSC 52 sequences. Bubble Tea owns stdout, but stderr
+// is the same terminal in an interactive session.
+var osc52Output io.Writer = os.Stderr
+
+// inSSHSession reports whether bv runs on a remote host, where the local
+// clipboard belongs to the wrong machine
+func inSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_CLIENT") != ""
+}
+
+// osc52Sequence asks the terminal to put text on its clipboard, wrapped in a
+// passthrough sequence when tmux sits in between
+func osc52Sequence(text string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if tmux {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// writeClipboard copies text to the clipboard. Over SSH, or when there is no
+// usable system clipboard, it falls back to OSC 52 so the terminal on the
+// user's machine does the copying. via is "OSC 52" in that case, else "".
+func writeClipboard(text string) (via string, err error) {
+	if !inSSHSession() {
+		if err := systemClipboard(text); err == nil {
+			return "", nil
+		}
+	}
+	if _, err := io.WriteString(osc52Output, osc52Sequence(text, os.Getenv("TMUX") != "")); err != nil {
+		return "", fmt.Errorf("clipboard unavailable: %w", err)
+	}
+	return "OSC 52", nil
+}
+
+// copyText copies text and reports what was copied in the status bar. In a
+// remote session the copy always goes to the session's terminal.
+func (m *Model) copyText(text, what string) {
+	var via string
+	var err error
+	if m.remoteTerm != nil {
+		via = "OSC 52"
+		_, err = io.WriteString(m.remoteTerm, osc52Sequence(text, false))
+	} else {
+		via, err = writeClipboard(text)
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", what)
+	if via != "" {
+		m.statusMsg += " (via " + via + ")"
+	}
+	m.statusIsError = false
+}
+
+// selectedIssue returns the issue highlighted in the list, setting an error
+// status when there is none
+func (m *Model) selectedIssue() (model.Issue, bool) {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return model.Issue{}, false
+	}
+	return item.Issue, true
+}
+
+// copyIssueID copies the selected issue's ID
+func (m *Model) copyIssueID() {
+	if issue, ok := m.selectedIssue(); ok {
+		m.copyText(issue.ID, issue.ID)
+	}
+}
+
+// copyIssueLink copies a Markdown link to the selected issue, using the
+// project's issue URL template when there is one
+func (m *Model) copyIssueLink() {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return
+	}
+	if m.issueURL != nil {
+		if url, err := IssueURL(m.issueURL, issue); err == nil && url != "" {
+			m.copyText(fmt.Sprintf("[%s: %s](%s)", issue.ID, issue.Title, url), "link to "+issue.ID)
+			return
+		}
+	}
+	m.copyText(fmt.Sprintf("`%s` %s", issue.ID, issue.Title), "reference to "+issue.ID+" (no issue_url configured)")
+}
+
+// openBlockers returns the issues that still block issue, in dependency order
+func (m *Model) openBlockers(issue model.Issue) []*model.Issue {
+	var blockers []*model.Issue
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlockingOrLegacy() {
+			continue
+		}
+		if blocker, ok := m.issueMap[dep.DependsOnID]; ok && blocker.Status != model.StatusClosed {
+			blockers = append(blockers, blocker)
+		}
+	}
+	return blockers
+}
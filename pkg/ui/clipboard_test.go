@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stubClipboard captures clipboard writes, making the system clipboard fail when systemErr is set
+func stubClipboard(t *testing.T, systemErr error) (system *string, osc *bytes.Buffer) {
+	t.Helper()
+	var copied string
+	var buf bytes.Buffer
+	origSystem, origOSC := systemClipboard, osc52Output
+	systemClipboard = func(text string) error {
+		if systemErr != nil {
+			return systemErr
+		}
+		copied = text
+		return nil
+	}
+	osc52Output = &buf
+	t.Cleanup(func() { systemClipboard, osc52Output = origSystem, origOSC })
+	for _, env := range []string{"SSH_TTY", "SSH_CONNECTION", "SSH_CLIENT", "TMUX"} {
+		t.Setenv(env, "")
+	}
+	return &copied, &buf
+}
+
+func TestWriteClipboardFallsBackToOSC52(t *testing.T) {
+	system, osc := stubClipboard(t, nil)
+	if via, err := writeClipboard("local"); err != nil || via != "" || *system != "local" || osc.Len() != 0 {
+		t.Fatalf("expected the system clipboard locally, got via %q, err %v, osc %q", via, err, osc.String())
+	}
+
+	t.Setenv("SSH_CONNECTION", "10.0.0.1 22 10.0.0.2 22")
+	via, err := writeClipboard("remote")
+	if err != nil || via != "OSC 52" {
+		t.Fatalf("expected OSC 52 over SSH, got via %q, err %v", via, err)
+	}
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("remote")) + "\x07"
+	if osc.String() != want {
+		t.Errorf("unexpected OSC 52 sequence %q", osc.String())
+	}
+
+	osc.Reset()
+	t.Setenv("TMUX", "/tmp/tmux-0/default,1,0")
+	writeClipboard("x")
+	if !strings.HasPrefix(osc.String(), "\x1bPtmux;\x1b\x1b]52;c;") || !strings.HasSuffix(osc.String(), "\x1b\\") {
+		t.Errorf("expected a tmux passthrough sequence, got %q", osc.String())
+	}
+}
+
+func TestWriteClipboardWithoutSystemClipboard(t *testing.T) {
+	_, osc := stubClipboard(t, errors.New("no xclip"))
+	if via, err := writeClipboard("text"); err != nil || via != "OSC 52" || osc.Len() == 0 {
+		t.Fatalf("expected OSC 52 when the system clipboard fails, got via %q, err %v", via, err)
+	}
+}
+
+func TestClipboardKeys(t *testing.T) {
+	system, _ := stubClipboard(t, nil)
+	issues := []model.Issue{
+		{ID: "bv-2", Title: "Blocked work", Status: model.StatusOpen, Priority: 1,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-1", Title: "Foundation", Status: model.StatusInProgress, Priority: 0},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = updated.(Model)
+	m.list.Select(1) // bv-2, after bv-1 in priority order
+	if m.selectedIssueID() != "bv-2" {
+		t.Fatalf("expected bv-2 selected, got %q", m.selectedIssueID())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlY})
+	m = updated.(Model)
+	if *system != "bv-2" {
+		t.Errorf("ctrl+y should copy the ID, got %q", *system)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlK})
+	m = updated.(Model)
+	if *system != "`bv-2` Blocked work" {
+		t.Errorf("ctrl+k without issue_url should copy a plain reference, got %q", *system)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	m = updated.(Model)
+	if !strings.Contains(*system, "## Blocked By\n\n- bv-1: Foundation (in_progress)") {
+		t.Errorf("summary should list open blockers, got:\n%s", *system)
+	}
+}
+
+func TestRemoteSessionTargetsViewerTerminal(t *testing.T) {
+	system, osc := stubClipboard(t, nil)
+	opened := ""
+	origOpen := openURL
+	openURL = func(url string) error { opened = url; return nil }
+	defer func() { openURL = origOpen }()
+
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "Remote", Status: model.StatusOpen}}, nil, "")
+	var term bytes.Buffer
+	m.SetRemoteTerminal(&term)
+
+	m.copyIssueID()
+	if *system != "" || osc.Len() != 0 {
+		t.Errorf("remote copy must not touch the server's clipboard (system %q, osc %q)", *system, osc.String())
+	}
+	if want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("bv-1")) + "\x07"; term.String() != want {
+		t.Errorf("expected OSC 52 on the session terminal, got %q", term.String())
+	}
+
+	m.openInBrowser("https://example.com/bv-1")
+	if opened != "" || !strings.Contains(m.statusMsg, "https://example.com/bv-1") {
+		t.Errorf("remote sessions should show the URL, not open it (opened %q, status %q)", opened, m.statusMsg)
+	}
+
+	if !m.refuseRemoteEdit() || !m.statusIsError {
+		t.Error("remote sessions should refuse edits")
+	}
+}
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// collisionPinsPath returns where the records chosen for colliding IDs are
+// kept: .bv/collisions.json in projectDir
+func collisionPinsPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "collisions.json")
+}
+
+// LoadCollisionPins reads the records chosen for colliding IDs, ID -> the
+// record's analysis.IssueHash. A missing file has none.
+func LoadCollisionPins(path string) (map[string]string, error) {
+	pins := map[string]string{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pins, nil
+	}
+	if err != nil {
+		return pins, err
+	}
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return map[string]string{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return pins, nil
+}
+
+// SaveCollisionPins writes pins to path, creating its directory
+func SaveCollisionPins(path string, pins map[string]string) error {
+	data, err := json.MarshalIndent(pins, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// collision returns the collision of id, if its records collide
+func (m *ProblemsModel) collision(id string) (analysis.IDCollision, bool) {
+	for _, c := range m.collisions {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return analysis.IDCollision{}, false
+}
+
+// SetCollisions gives the panel the records of each colliding ID, listed
+// under a selected duplicate ID problem
+func (m *ProblemsModel) SetCollisions(collisions []analysis.IDCollision) {
+	m.collisions = collisions
+}
+
+// SelectedCollision returns the collision of the highlighted duplicate ID
+// problem
+func (m *ProblemsModel) SelectedCollision() (analysis.IDCollision, bool) {
+	p, ok := m.SelectedProblem()
+	if !ok || p.Kind != analysis.ProblemDuplicateID {
+		return analysis.IDCollision{}, false
+	}
+	return m.collision(p.IssueID)
+}
+
+// collisionLines lists c's records, marking the one in use, for the panel
+func (m *ProblemsModel) collisionLines(c analysis.IDCollision) []string {
+	t := m.theme
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	keptStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	lines := []string{"", t.Renderer.NewStyle().Bold(true).Render(fmt.Sprintf("  Records sharing %s, in file order:", c.ID))}
+	for i, record := range c.Records {
+		mark := "  "
+		if i == c.Kept {
+			mark = keptStyle.Render("✓ ")
+		}
+		assignee := "unassigned"
+		if record.Assignee != "" {
+			assignee = "@" + record.Assignee
+		}
+		meta := fmt.Sprintf("updated %s  %s  %s  ", timefmt.Date(record.UpdatedAt), record.Status, assignee)
+		line := fmt.Sprintf("  %s%d  %s", mark, i+1, meta)
+		lines = append(lines, line+truncateToWidth(record.Title, max(m.width-displayWidth(line)-4, 10), "…"))
+	}
+	in := "the one updated last is in use"
+	if c.Pinned {
+		in = "chosen by hand"
+	}
+	hint := fmt.Sprintf("  ✓ %s  │  u use the next record", in)
+	if c.Pinned {
+		hint += "  │  n back to the one updated last"
+	}
+	return append(lines, subtle.Render(hint))
+}
+
+// pinCollisionRecord makes the issue id use its index'th colliding record,
+// or the one updated last when index is -1, remembering the choice in
+// .bv/collisions.json and reloading
+func (m *Model) pinCollisionRecord(id string, index int) tea.Cmd {
+	// No beads file is fine: the choice is saved for the next start
+	if m.refuseRemoteEdit() || m.refuseReadOnly() {
+		return nil
+	}
+	c, ok := m.problemsView.collision(id)
+	if !ok || index >= len(c.Records) {
+		return nil
+	}
+	pins := maps.Clone(m.collisionPins) // The reload worker reads the old map
+	if pins == nil {
+		pins = map[string]string{}
+	}
+	if index < 0 {
+		delete(pins, id)
+		m.statusMsg = fmt.Sprintf("%s uses the record updated last", id)
+	} else {
+		pins[id] = analysis.IssueHash(c.Records[index])
+		m.statusMsg = fmt.Sprintf("%s uses record %s of %d", id, strconv.Itoa(index+1), len(c.Records))
+	}
+	m.statusIsError = false
+	if m.collisionPinsPath != "" {
+		if err := SaveCollisionPins(m.collisionPinsPath, pins); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Saving the choice failed: %v", err)
+			m.statusIsError = true
+			return nil
+		}
+	}
+	m.collisionPins = pins
+	if m.beadsPath == "" {
+		m.statusMsg += "; takes effect when bv is restarted"
+		return nil
+	}
+	m.reloading = true
+	return ReloadIssuesCmd(m.beadsPath, m.currentLoad())
+}
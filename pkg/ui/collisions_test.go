@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// collisionTestModel loads a beads file in a temp project where two
+// records share the ID A, returning the model and the project directory
+func collisionTestModel(t *testing.T) (Model, string) {
+	t.Helper()
+	dir := t.TempDir()
+	beads := filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beads), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A merge left two copies of A; the first in the file is the newer
+	data := `{"id":"A","title":"Ours","status":"in_progress","priority":1,"issue_type":"task","created_at":"2025-06-01T00:00:00Z","updated_at":"2025-06-03T00:00:00Z"}
+{"id":"B","title":"Other","status":"open","priority":2,"issue_type":"task","created_at":"2025-06-01T00:00:00Z","updated_at":"2025-06-01T00:00:00Z"}
+{"id":"A","title":"Theirs","status":"open","priority":1,"issue_type":"task","created_at":"2025-06-01T00:00:00Z","updated_at":"2025-06-02T00:00:00Z"}
+`
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beads)
+	t.Cleanup(m.Stop)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	return updated.(Model), dir
+}
+
+func TestDuplicateIDsResolveAndPin(t *testing.T) {
+	m, dir := collisionTestModel(t)
+	beads := filepath.Join(dir, ".beads", "beads.jsonl")
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.issues) != 2 || m.issueMap["A"].Title != "Ours" {
+		t.Fatalf("expected the newer A kept, got %d issues, A %q", len(m.issues), m.issueMap["A"].Title)
+	}
+	if !strings.Contains(m.statusMsg, "1 IDs are shared by several records") {
+		t.Errorf("expected a collision warning, got %q", m.statusMsg)
+	}
+
+	key := func(k string) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+		return cmd
+	}
+	reload := func(cmd tea.Cmd) {
+		t.Helper()
+		if cmd == nil {
+			t.Fatalf("expected a reload")
+		}
+		updated, _ := m.Update(cmd())
+		m = updated.(Model)
+	}
+
+	key("P")
+	out := m.View()
+	for _, want := range []string{"Duplicate ID", "Records sharing A", "✓ 1", "Ours", "2  updated", "Theirs", "the one updated last is in use"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the problems panel:\n%s", want, out)
+		}
+	}
+
+	// Use the other record, remembered across loads
+	reload(key("u"))
+	if m.issueMap["A"].Title != "Theirs" || !m.isProblemsView {
+		t.Fatalf("expected the second A in use, got %q", m.issueMap["A"].Title)
+	}
+	if !strings.Contains(m.View(), "chosen by hand") {
+		t.Errorf("expected the pinned record marked:\n%s", m.View())
+	}
+	pins, err := LoadCollisionPins(filepath.Join(dir, ".bv", "collisions.json"))
+	if err != nil || pins["A"] == "" {
+		t.Fatalf("expected the choice saved, got %v, %v", pins, err)
+	}
+	again := NewModel(issues, nil, beads)
+	defer again.Stop()
+	if again.issueMap["A"].Title != "Theirs" {
+		t.Errorf("expected the choice to hold on the next start, got %q", again.issueMap["A"].Title)
+	}
+
+	reload(key("n"))
+	if m.issueMap["A"].Title != "Ours" {
+		t.Errorf("expected n to go back to the newer record, got %q", m.issueMap["A"].Title)
+	}
+}
+
+func TestCollisionChoiceRefusedWhenIssuesCantChange(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		setup  func(*Model)
+		status string
+	}{
+		{"remote", func(m *Model) { m.SetRemoteTerminal(&strings.Builder{}) }, "read-only in a remote session"},
+		{"read-only", func(m *Model) { m.SetReadOnly("loaded from Markdown files") }, "read-only when loaded from Markdown files"},
+	} {
+		m, dir := collisionTestModel(t)
+		tt.setup(&m)
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+		m = updated.(Model)
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+		m = updated.(Model)
+
+		if cmd != nil || !m.statusIsError || !strings.Contains(m.statusMsg, tt.status) {
+			t.Errorf("%s: expected the choice refused, got cmd=%v status %q", tt.name, cmd != nil, m.statusMsg)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".bv", "collisions.json")); !os.IsNotExist(err) {
+			t.Errorf("%s: expected no pins file, got %v", tt.name, err)
+		}
+		if m.issueMap["A"].Title != "Ours" {
+			t.Errorf("%s: expected the newer A still in use, got %q", tt.name, m.issueMap["A"].Title)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CommentAddedMsg reports the outcome of saving a comment
+type CommentAddedMsg struct {
+	ID      string
+	Comment *model.Comment // Shown ahead of the reload, taken back if bd fails
+	Err     error
+}
+
+// AddCommentCmd adds a comment to the issue using the bd CLI, which stamps
+// it with the time. The text goes last, after "--", so a comment starting
+// with a dash isn't taken for a flag.
+func AddCommentCmd(beadsPath string, comment *model.Comment) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	args := []string{"comments", "add", comment.IssueID}
+	if comment.Author != "" {
+		args = append(args, "--author", comment.Author)
+	}
+	args = append(args, "--", comment.Text)
+	return func() tea.Msg {
+		return CommentAddedMsg{ID: comment.IssueID, Comment: comment, Err: runBeadsCLI(dir, args...)}
+	}
+}
+
+// CommentEditedMsg carries the comment back from $EDITOR
+type CommentEditedMsg struct {
+	Text string
+	Err  error
+}
+
+// commentEditor returns the command line of the user's editor: $VISUAL,
+// then $EDITOR, then vi (notepad on Windows)
+func commentEditor() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
+
+// editCommentCmd suspends the TUI and opens text in the user's editor,
+// returning what was saved
+func editCommentCmd(text string) tea.Cmd {
+	f, err := os.CreateTemp("", "bv-comment-*.md")
+	if err != nil {
+		return func() tea.Msg { return CommentEditedMsg{Err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return CommentEditedMsg{Err: err} }
+	}
+	editor := commentEditor()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return CommentEditedMsg{Err: err}
+		}
+		data, err := os.ReadFile(path)
+		return CommentEditedMsg{Text: strings.TrimRight(string(data), "\n"), Err: err}
+	})
+}
+
+// CommentComposerModel is the overlay for writing a comment on an issue
+type CommentComposerModel struct {
+	issueID string
+	title   string
+	author  string
+	input   textarea.Model
+	err     string
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewCommentComposerModel creates the comment composer
+func NewCommentComposerModel(theme Theme) CommentComposerModel {
+	input := textarea.New()
+	input.Placeholder = "Write a comment…"
+	input.ShowLineNumbers = false
+	input.CharLimit = 0
+	return CommentComposerModel{input: input, theme: theme}
+}
+
+// SetSize updates the overlay dimensions
+func (m *CommentComposerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.SetWidth(min(max(width-12, 20), 80))
+	m.input.SetHeight(min(max(height-14, 3), 12))
+}
+
+// Open starts an empty comment on issue, signed by author
+func (m *CommentComposerModel) Open(issue model.Issue, author string) tea.Cmd {
+	m.issueID = issue.ID
+	m.title = issue.Title
+	m.author = author
+	m.err = ""
+	m.input.Reset()
+	return m.input.Focus()
+}
+
+// Update passes a key to the text
+func (m *CommentComposerModel) Update(msg tea.KeyMsg) tea.Cmd {
+	m.err = ""
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return cmd
+}
+
+// Text returns the comment as written so far
+func (m *CommentComposerModel) Text() string {
+	return m.input.Value()
+}
+
+// SetText replaces the comment, as after editing it in $EDITOR
+func (m *CommentComposerModel) SetText(text string) {
+	m.input.SetValue(text)
+}
+
+// SetError shows why the comment can't be saved
+func (m *CommentComposerModel) SetError(err error) {
+	m.err = err.Error()
+}
+
+// Comment returns the comment to save, stamped now
+func (m *CommentComposerModel) Comment() (*model.Comment, error) {
+	text := strings.TrimSpace(m.input.Value())
+	if text == "" {
+		return nil, errors.New("the comment is empty")
+	}
+	return &model.Comment{IssueID: m.issueID, Author: m.author, Text: text, CreatedAt: time.Now()}, nil
+}
+
+// View renders the composer
+func (m *CommentComposerModel) View() string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	heading := titleStyle.Render("Comment on "+m.issueID) + " " +
+		subtle.Render(truncateToWidth(m.title, max(m.input.Width()-displayWidth(m.issueID)-12, 10), "…"))
+	lines := []string{heading}
+	if m.author != "" {
+		lines = append(lines, subtle.Render("as @"+m.author))
+	}
+	lines = append(lines, "", m.input.View())
+	if m.err != "" {
+		lines = append(lines, "", t.Renderer.NewStyle().Foreground(t.Blocked).Render("❌ "+m.err))
+	}
+	lines = append(lines, "", subtle.Italic(true).Render(
+		fmt.Sprintf("ctrl+s: save with bd • ctrl+e: edit in %s • esc: cancel", commentEditor()[0])))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCommentComposerAddsComment(t *testing.T) {
+	var calls [][]string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	issues := []model.Issue{{ID: "A", Title: "Crash on save", Status: model.StatusOpen, IssueType: model.TypeBug, CreatedAt: time.Now()}}
+	m := NewModel(issues, nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	m.SetNotifyAssignee("alice", false)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	m = typeKeys(t, m, "m")
+	if !m.showCommentComposer || !strings.Contains(m.View(), "Comment on A") {
+		t.Fatalf("expected the comment composer open:\n%s", m.View())
+	}
+	// Saving nothing is refused
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if cmd != nil || !m.showCommentComposer || !strings.Contains(m.View(), "the comment is empty") {
+		t.Fatalf("expected an empty comment refused")
+	}
+
+	// Global keys go to the text while composing
+	m = typeKeys(t, m, "-q fixed")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	m = typeKeys(t, m, "in v2")
+	if m.commentComposer.Text() != "-q fixed\nin v2" {
+		t.Fatalf("unexpected comment text %q", m.commentComposer.Text())
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if cmd == nil || m.showCommentComposer {
+		t.Fatalf("expected the composer closed with a save command")
+	}
+	if got := m.issueMap["A"].Comments; len(got) != 1 || got[0].Author != "alice" || got[0].CreatedAt.IsZero() {
+		t.Fatalf("expected the comment shown right away, got %+v", got)
+	}
+	msg := cmd()
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(calls) != 1 || strings.Join(calls[0], "|") != "comments|add|A|--author|alice|--|-q fixed\nin v2" {
+		t.Fatalf("unexpected bd calls: %q", calls)
+	}
+	if m.statusMsg != "Commented on A" || m.statusIsError {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+
+	// A failed save takes the comment back
+	failed := msg.(CommentAddedMsg)
+	failed.Err = errors.New("bd: database locked")
+	updated, _ = m.Update(failed)
+	m = updated.(Model)
+	if len(m.issueMap["A"].Comments) != 0 || !m.statusIsError || !strings.Contains(m.statusMsg, "locked") {
+		t.Fatalf("expected the comment removed and an error status, got %d / %q", len(m.issueMap["A"].Comments), m.statusMsg)
+	}
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CommitLinksMsg carries the commits whose messages mention loaded issue IDs
+type CommitLinksMsg struct {
+	Links     map[string][]loader.CommitLink
+	RemoteURL string // Origin remote, used to build forge links
+	Err       error
+}
+
+// LinkCommitsCmd scans the git log of the project that owns beadsPath for
+// commits mentioning the IDs of issues
+func LinkCommitsCmd(beadsPath string, issues []model.Issue) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return func() tea.Msg {
+		g := loader.NewGitLoader(dir)
+		links, err := g.CommitsMentioning(ids)
+		return CommitLinksMsg{Links: links, RemoteURL: g.RemoteURL(), Err: err}
+	}
+}
+
+// openURL opens url in the default browser. It is a variable so tests can stub it.
+var openURL = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// openInBrowser opens url and reports it in the status bar. A remote session
+// has no browser on this machine to open, so the URL is only shown.
+func (m *Model) openInBrowser(url string) {
+	if m.remoteTerm != nil {
+		m.statusMsg = fmt.Sprintf("🔗 %s", url)
+		m.statusIsError = false
+		return
+	}
+	if err := openURL(url); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to open browser: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("🌐 Opened %s", url)
+	m.statusIsError = false
+}
+
+// selectedIssueID returns the ID of the issue highlighted in the list, or ""
+func (m *Model) selectedIssueID() string {
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		return item.Issue.ID
+	}
+	return ""
+}
+
+// selectedCommit returns the linked commit highlighted in the detail view
+func (m *Model) selectedCommit() (loader.CommitLink, bool) {
+	id := m.selectedIssueID()
+	commits := m.commitLinks[id]
+	if len(commits) == 0 {
+		return loader.CommitLink{}, false
+	}
+	if m.commitCursorIssue != id || m.commitCursor >= len(commits) {
+		return commits[0], true
+	}
+	return commits[m.commitCursor], true
+}
+
+// handleCommitKey handles the linked-commit keys shared by the list and the
+// detail pane. It reports whether key was one of them.
+func (m *Model) handleCommitKey(key string) bool {
+	switch key {
+	case "[", "]":
+		id := m.selectedIssueID()
+		commits := m.commitLinks[id]
+		if len(commits) == 0 {
+			return true
+		}
+		if m.commitCursorIssue != id {
+			m.commitCursorIssue, m.commitCursor = id, 0
+		}
+		if key == "]" {
+			m.commitCursor = (m.commitCursor + 1) % len(commits)
+		} else {
+			m.commitCursor = (m.commitCursor + len(commits) - 1) % len(commits)
+		}
+		m.updateViewportContent()
+	case "y":
+		commit, ok := m.selectedCommit()
+		if !ok {
+			m.statusMsg = "❌ No linked commits for this issue"
+			m.statusIsError = true
+			return true
+		}
+		m.copyText(commit.SHA, shortSHA(commit.SHA))
+	case "Y":
+		commit, ok := m.selectedCommit()
+		if !ok {
+			m.statusMsg = "❌ No linked commits for this issue"
+			m.statusIsError = true
+			return true
+		}
+		url := loader.ForgeCommitURL(m.gitRemote, commit.SHA)
+		if url == "" {
+			m.statusMsg = "❌ No web forge for this repo's origin remote"
+			m.statusIsError = true
+			return true
+		}
+		m.openInBrowser(url)
+	default:
+		return false
+	}
+	return true
+}
+
+// commitsMarkdown lists the commits linked to an issue for the detail view
+func (m *Model) commitsMarkdown(id string) string {
+	commits := m.commitLinks[id]
+	if len(commits) == 0 {
+		return ""
+	}
+	cursor := 0
+	if m.commitCursorIssue == id && m.commitCursor < len(commits) {
+		cursor = m.commitCursor
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Commits (%d)\n", len(commits)))
+	for i, c := range commits {
+		marker := "  "
+		if i == cursor {
+			marker = "▸ "
+		}
+		sb.WriteString(fmt.Sprintf("- %s`%s` %s — %s, %s\n", marker, shortSHA(c.SHA), c.Subject, c.Author, timefmt.Date(c.Date)))
+	}
+	hint := "*y copy hash"
+	if m.gitRemote != "" {
+		hint += " • Y open on forge"
+	}
+	if len(commits) > 1 {
+		hint += " • [ ] choose commit"
+	}
+	sb.WriteString("\n" + hint + "*\n\n")
+	return sb.String()
+}
+
+// shortSHA abbreviates a commit hash for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLinkedCommitsInDetailView(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "Parser", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	updated, _ = m.Update(CommitLinksMsg{
+		Links: map[string][]loader.CommitLink{"bv-1": {
+			{SHA: "1111111aaaaaaa", Subject: "Fix parser", Author: "Alice", Date: date},
+			{SHA: "2222222bbbbbbb", Subject: "Start parser", Author: "Bob", Date: date},
+		}},
+		RemoteURL: "git@github.com:owner/repo.git",
+	})
+	m = updated.(Model)
+
+	content := m.viewport.View()
+	for _, want := range []string{"Commits (2)", "1111111", "Fix parser", "Alice"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail view missing %q:\n%s", want, content)
+		}
+	}
+
+	var opened string
+	orig := openURL
+	openURL = func(url string) error { opened = url; return nil }
+	defer func() { openURL = orig }()
+
+	for _, key := range []string{"]", "Y"} {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+	if opened != "https://github.com/owner/repo/commit/2222222bbbbbbb" {
+		t.Errorf("expected the second commit opened on GitHub, got %q", opened)
+	}
+
+	// Wraps back to the newest commit
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	m = updated.(Model)
+	if c, ok := m.selectedCommit(); !ok || c.SHA != "1111111aaaaaaa" {
+		t.Errorf("expected cursor to wrap to the newest commit, got %+v", c)
+	}
+}
@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// compareSide is one issue of a comparison with its graph context
+type compareSide struct {
+	issue       model.Issue
+	blockers    []string // Open issues blocking it
+	dependents  []string // Open issues it blocks, highest impact first
+	impact      float64
+	pageRank    float64
+	betweenness float64
+}
+
+// compareRow is one field of a comparison. better is -1 when the left value
+// ranks higher, 1 when the right one does, 0 when neither is preferred.
+type compareRow struct {
+	label       string
+	left, right string
+	better      int
+}
+
+// differs reports whether the two sides disagree on the field
+func (r compareRow) differs() bool {
+	return r.left != r.right
+}
+
+// ComparisonModel shows two issues side by side with their differences highlighted
+type ComparisonModel struct {
+	left, right compareSide
+	similarity  float64
+	width       int
+	height      int
+	theme       Theme
+}
+
+// NewComparisonModel compares left with right
+func NewComparisonModel(left, right compareSide, theme Theme) ComparisonModel {
+	return ComparisonModel{
+		left:       left,
+		right:      right,
+		similarity: analysis.Similarity(left.issue, right.issue),
+		theme:      theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *ComparisonModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Swap exchanges the two panes
+func (m *ComparisonModel) Swap() {
+	m.left, m.right = m.right, m.left
+}
+
+// IDs returns the issues shown on the left and the right
+func (m *ComparisonModel) IDs() (string, string) {
+	return m.left.issue.ID, m.right.issue.ID
+}
+
+// rows lists the compared fields, top to bottom
+func (m *ComparisonModel) rows() []compareRow {
+	l, r := m.left.issue, m.right.issue
+	date := func(issue model.Issue) string {
+		if issue.CreatedAt.IsZero() {
+			return "—"
+		}
+		return timefmt.Date(issue.CreatedAt)
+	}
+	due := func(issue model.Issue) string {
+		if issue.DueDate == nil {
+			return "—"
+		}
+		return timefmt.Day(*issue.DueDate)
+	}
+	estimate := func(issue model.Issue) string {
+		est, ok := issue.EstimateMinutes()
+		if !ok {
+			return "—"
+		}
+		return FormatMinutes(est)
+	}
+	orNone := func(s string) string {
+		if s == "" {
+			return "—"
+		}
+		return s
+	}
+	ids := func(list []string) string {
+		if len(list) == 0 {
+			return "none"
+		}
+		return fmt.Sprintf("%d: %s", len(list), strings.Join(list, ", "))
+	}
+	// higher prefers the side with the larger value
+	higher := func(a, b float64) int {
+		switch {
+		case a > b:
+			return -1
+		case b > a:
+			return 1
+		}
+		return 0
+	}
+
+	return []compareRow{
+		{label: "Type", left: string(l.IssueType), right: string(r.IssueType)},
+		{label: "Status", left: string(l.Status), right: string(r.Status)},
+		// Lower numbers are more urgent
+		{label: "Priority", left: "P" + strconv.Itoa(l.Priority), right: "P" + strconv.Itoa(r.Priority), better: higher(float64(r.Priority), float64(l.Priority))},
+		{label: "Assignee", left: orNone(l.Assignee), right: orNone(r.Assignee)},
+		{label: "Labels", left: orNone(strings.Join(l.Labels, ", ")), right: orNone(strings.Join(r.Labels, ", "))},
+		{label: "Created", left: date(l), right: date(r)},
+		{label: "Due", left: due(l), right: due(r)},
+		{label: "Estimate", left: estimate(l), right: estimate(r)},
+		{label: "Comments", left: strconv.Itoa(len(l.Comments)), right: strconv.Itoa(len(r.Comments))},
+		{label: "Blocked by", left: ids(m.left.blockers), right: ids(m.right.blockers)},
+		{label: "Unblocks", left: ids(m.left.dependents), right: ids(m.right.dependents),
+			better: higher(float64(len(m.left.dependents)), float64(len(m.right.dependents)))},
+		{label: "Impact", left: fmt.Sprintf("%.1f", m.left.impact), right: fmt.Sprintf("%.1f", m.right.impact),
+			better: higher(m.left.impact, m.right.impact)},
+		{label: "PageRank", left: fmt.Sprintf("%.4f", m.left.pageRank), right: fmt.Sprintf("%.4f", m.right.pageRank),
+			better: higher(m.left.pageRank, m.right.pageRank)},
+		{label: "Betweenness", left: fmt.Sprintf("%.4f", m.left.betweenness), right: fmt.Sprintf("%.4f", m.right.betweenness),
+			better: higher(m.left.betweenness, m.right.betweenness)},
+	}
+}
+
+// Render renders the two issues in adjacent panes
+func (m *ComparisonModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	sameStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+	diffStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground()).Bold(true)
+	diffLabelStyle := t.Renderer.NewStyle().Foreground(t.Highlight).Bold(true)
+	betterStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	const labelWidth = 13
+	colWidth := max((m.width-labelWidth-6)/2, 10)
+	cell := func(text string, style lipgloss.Style, best bool) string {
+		marker := "  "
+		if best {
+			marker = betterStyle.Render("▲ ")
+		}
+		text = truncateToWidth(text, colWidth-3, "…")
+		return marker + style.Render(text) + strings.Repeat(" ", max(colWidth-2-lipgloss.Width(text), 0))
+	}
+
+	rows := m.rows()
+	differences := 0
+	for _, r := range rows {
+		if r.differs() {
+			differences++
+		}
+	}
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("⚖ COMPARE  │  %s vs %s  │  %.0f%% similar  │  %d differences",
+		m.left.issue.ID, m.right.issue.ID, m.similarity*100, differences)))
+	lines = append(lines, "")
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	lines = append(lines, strings.Repeat(" ", labelWidth)+
+		cell(m.left.issue.Title, titleStyle, false)+cell(m.right.issue.Title, titleStyle, false))
+	lines = append(lines, strings.Repeat(" ", labelWidth)+subtle.Render(repeatToWidth("─", colWidth*2)))
+
+	for _, r := range rows {
+		label, style := labelStyle.Render(fmt.Sprintf("  %-*s", labelWidth-2, r.label)), sameStyle
+		if r.differs() {
+			label, style = diffLabelStyle.Render(fmt.Sprintf("≠ %-*s", labelWidth-2, r.label)), diffStyle
+		}
+		lines = append(lines, label+cell(r.left, style, r.better < 0)+cell(r.right, style, r.better > 0))
+	}
+
+	// Descriptions fill what's left above the hint line
+	lines = append(lines, "", labelStyle.Render("  Description"))
+	room := m.height - len(lines) - 2
+	left := descriptionLines(m.left.issue.Description, colWidth-3)
+	right := descriptionLines(m.right.issue.Description, colWidth-3)
+	style := sameStyle
+	if m.left.issue.Description != m.right.issue.Description {
+		style = diffStyle
+	}
+	for i := 0; i < min(max(len(left), len(right)), room); i++ {
+		var l, r string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		lines = append(lines, strings.Repeat(" ", labelWidth)+cell(l, style, false)+cell(r, style, false))
+	}
+
+	lines = append(lines, "", subtle.Render("  ▲ ranks higher for prioritizing • ≠ differs • s swap sides • esc back"))
+	return strings.Join(lines, "\n")
+}
+
+// descriptionLines wraps a description to width, "—" when there is none
+func descriptionLines(desc string, width int) []string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return []string{"—"}
+	}
+	wrapped := lipgloss.NewStyle().Width(width).Render(desc)
+	return strings.Split(wrapped, "\n")
+}
+
+// comparisonSide gathers an issue's blockers, dependents and metrics
+func (m *Model) comparisonSide(issue model.Issue) compareSide {
+	side := compareSide{
+		issue:       issue,
+		impact:      m.analysis.GetCriticalPathScore(issue.ID),
+		pageRank:    m.analysis.GetPageRankScore(issue.ID),
+		betweenness: m.analysis.GetBetweennessScore(issue.ID),
+	}
+	for _, b := range m.openBlockers(issue) {
+		side.blockers = append(side.blockers, b.ID)
+	}
+	for _, d := range m.openDependents(issue.ID) {
+		side.dependents = append(side.dependents, d.ID)
+	}
+	return side
+}
+
+// openComparison shows left and right side by side, returning to the
+// current view when closed
+func (m *Model) openComparison(left, right model.Issue) {
+	m.compareView = NewComparisonModel(m.comparisonSide(left), m.comparisonSide(right), m.theme)
+	m.compareView.SetSize(m.width, m.height-2)
+	m.compareReturn = m.focused
+	m.isCompareView = true
+	m.focused = focusCompare
+}
+
+// markForComparison marks the selected issue for comparison, or compares it
+// with the issue already marked
+func (m *Model) markForComparison() {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return
+	}
+	id := item.Issue.ID
+	marked, ok := m.issueMap[m.compareMark]
+	switch {
+	case m.compareMark == id:
+		m.compareMark = ""
+		m.statusMsg = "Comparison mark cleared"
+	case !ok:
+		m.compareMark = id
+		m.statusMsg = fmt.Sprintf("Marked %s: select another issue and press = to compare", id)
+	default:
+		m.compareMark = ""
+		m.openComparison(*marked, item.Issue)
+		return
+	}
+	m.statusIsError = false
+}
+
+// handleCompareKeys handles keys while two issues are compared
+func (m Model) handleCompareKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "=":
+		m.isCompareView = false
+		m.focused = m.compareReturn
+	case "s":
+		m.compareView.Swap()
+	}
+	return m, nil
+}
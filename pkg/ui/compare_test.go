@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func compareTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Login fails on Safari", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeBug},
+		{ID: "B", Title: "Login failing on safari", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeBug},
+		{ID: "C", Title: "Session cleanup", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+}
+
+func TestComparisonRows(t *testing.T) {
+	m := NewModel(compareTestIssues(), nil, "")
+	c := NewComparisonModel(m.comparisonSide(*m.issueMap["A"]), m.comparisonSide(*m.issueMap["B"]), m.theme)
+
+	rows := map[string]compareRow{}
+	for _, r := range c.rows() {
+		rows[r.label] = r
+	}
+	if rows["Type"].differs() || !rows["Priority"].differs() {
+		t.Fatalf("expected type shared and priority different: %+v %+v", rows["Type"], rows["Priority"])
+	}
+	if rows["Priority"].better != -1 {
+		t.Errorf("expected the P1 issue preferred, got %d", rows["Priority"].better)
+	}
+	if rows["Unblocks"].right != "1: C" || rows["Unblocks"].better != 1 {
+		t.Errorf("expected B to unblock C, got %+v", rows["Unblocks"])
+	}
+
+	c.Swap()
+	if l, r := c.IDs(); l != "B" || r != "A" {
+		t.Fatalf("expected swapped panes, got %s and %s", l, r)
+	}
+}
+
+func TestCompareMarkAndOpen(t *testing.T) {
+	m := NewModel(compareTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			if k == "esc" {
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}
+
+	press("=")
+	if m.compareMark == "" || m.isCompareView {
+		t.Fatalf("expected the first = to mark the issue")
+	}
+	marked := m.compareMark
+	press("=")
+	if m.compareMark != "" {
+		t.Fatalf("expected = on the marked issue to clear the mark")
+	}
+
+	press("=", "j", "=")
+	if !m.isCompareView || m.focused != focusCompare {
+		t.Fatalf("expected = on a second issue to open the comparison")
+	}
+	left, right := m.compareView.IDs()
+	if left != marked || right == marked {
+		t.Fatalf("expected %s on the left, got %s vs %s", marked, left, right)
+	}
+	out := m.View()
+	for _, want := range []string{"COMPARE", "similar", "≠ Priority"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in comparison:\n%s", want, out)
+		}
+	}
+
+	// Views can't open underneath the comparison
+	press("b")
+	if m.isBoardView {
+		t.Fatalf("expected b to be ignored while comparing")
+	}
+	press("esc")
+	if m.isCompareView || m.focused != focusList {
+		t.Fatalf("expected esc to return to the list")
+	}
+}
+
+func TestCompareDuplicatePair(t *testing.T) {
+	m := NewModel(compareTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	for _, k := range []string{"X", "="} {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+	}
+	if !m.isCompareView {
+		t.Fatalf("expected = to compare the duplicate pair")
+	}
+	if l, r := m.compareView.IDs(); l != "A" || r != "B" {
+		t.Fatalf("expected keep A against dup B, got %s vs %s", l, r)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if !m.isDuplicatesView || m.focused != focusDuplicates {
+		t.Fatalf("expected to return to the duplicates panel")
+	}
+}
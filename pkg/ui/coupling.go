@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxCouplingEpics caps the matrix so its columns fit; the crossings list
+// below it still covers every epic
+const maxCouplingEpics = 9
+
+// CouplingModel is the report of blocking dependencies that cross epic
+// boundaries: an epic-to-epic matrix above the list of crossings
+type CouplingModel struct {
+	coupling     analysis.EpicCoupling
+	issueMap     map[string]*model.Issue
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewCouplingModel builds the report from the issues in scope
+func NewCouplingModel(issues []model.Issue, theme Theme) CouplingModel {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	return CouplingModel{
+		coupling: analysis.ComputeEpicCoupling(issues),
+		issueMap: issueMap,
+		theme:    theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *CouplingModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *CouplingModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *CouplingModel) MoveDown() {
+	if m.selected < len(m.coupling.Crossings)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the highlighted crossing, or nil when there are none
+func (m *CouplingModel) Selected() *analysis.CrossEpicDependency {
+	if m.selected < 0 || m.selected >= len(m.coupling.Crossings) {
+		return nil
+	}
+	return &m.coupling.Crossings[m.selected]
+}
+
+// matrixEpics returns the epics shown in the matrix, most coupled first
+func (m *CouplingModel) matrixEpics() []string {
+	epics := m.coupling.Epics
+	if len(epics) > maxCouplingEpics {
+		epics = epics[:maxCouplingEpics]
+	}
+	return epics
+}
+
+// visibleRows returns how many crossings fit below the matrix. Epic pair
+// headings take rows too, so this errs on the small side.
+func (m *CouplingModel) visibleRows() int {
+	used := 6 + len(m.matrixEpics())
+	return max((m.height-used)*2/3, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *CouplingModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// title returns the title of issue id, or "" when it isn't loaded
+func (m *CouplingModel) title(id string) string {
+	if issue, ok := m.issueMap[id]; ok {
+		return issue.Title
+	}
+	return ""
+}
+
+// Render renders the matrix and the crossings for the selected epic pair
+func (m *CouplingModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	crossings := m.coupling.Crossings
+	open := 0
+	for _, c := range crossings {
+		if c.Open {
+			open++
+		}
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🔀 CROSS-EPIC DEPENDENCIES  │  %d across %d epics  │  %d still open",
+		len(crossings), len(m.coupling.Epics), open)))
+	lines = append(lines, "")
+
+	if len(crossings) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No blocking dependencies cross epic boundaries."))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, m.renderMatrix()...)
+	lines = append(lines, "")
+
+	blockedStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
+	pairStyle := t.Renderer.NewStyle().Foreground(t.Epic).Bold(true)
+	titleWidth := max((m.width-40)/2, 10)
+
+	lastPair := ""
+	for i := m.scrollOffset; i < len(crossings) && len(lines) < m.height-1; i++ {
+		c := crossings[i]
+		if pair := c.FromEpic + " → " + c.ToEpic; pair != lastPair {
+			lines = append(lines, pairStyle.Render(pair+" ")+subtle.Render(fmt.Sprintf("(%d)", m.coupling.Count(c.FromEpic, c.ToEpic))))
+			lastPair = pair
+		}
+
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		state := subtle.Render("done")
+		if c.Open {
+			state = blockedStyle.Render("open")
+		}
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("%s%s %s waits on %s %s ",
+			prefix,
+			fitWidth(c.IssueID, 12),
+			fitWidth(m.title(c.IssueID), titleWidth),
+			fitWidth(c.DependsOnID, 12),
+			fitWidth(m.title(c.DependsOnID), titleWidth)))+state)
+	}
+	if more := len(crossings) - m.scrollOffset - m.visibleRows(); more > 0 {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", more)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderMatrix renders the epic-to-epic counts: each row waits on the
+// columns, numbered to match the rows. The selected crossing's cell is
+// highlighted.
+func (m *CouplingModel) renderMatrix() []string {
+	t := m.theme
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	cellStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+	selStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground()).Background(t.Primary).Bold(true)
+
+	epics := m.matrixEpics()
+	labelWidth := max(min(m.width-6-5*len(epics), 40), 16)
+	sel := m.Selected()
+
+	header := fmt.Sprintf("%-*s", labelWidth+3, "waits on →")
+	for i := range epics {
+		header += fmt.Sprintf("%5d", i+1)
+	}
+	lines := []string{subtle.Render(header)}
+
+	for i, from := range epics {
+		label := from
+		if title := m.title(from); title != "" {
+			label += " " + title
+		}
+		row := subtle.Render(fmt.Sprintf("%d  ", i+1)) + fitWidth(label, labelWidth)
+		for _, to := range epics {
+			n := m.coupling.Count(from, to)
+			cell := fmt.Sprintf("%5s", "·")
+			if from == to {
+				cell = fmt.Sprintf("%5s", " ")
+			} else if n > 0 {
+				cell = fmt.Sprintf("%5d", n)
+			}
+			switch {
+			case sel != nil && sel.FromEpic == from && sel.ToEpic == to:
+				row += selStyle.Render(cell)
+			case n > 0:
+				row += cellStyle.Render(cell)
+			default:
+				row += subtle.Render(cell)
+			}
+		}
+		lines = append(lines, row)
+	}
+	if hidden := len(m.coupling.Epics) - len(epics); hidden > 0 {
+		lines = append(lines, subtle.Render(fmt.Sprintf("   … %d less coupled epics listed below only", hidden)))
+	}
+	return lines
+}
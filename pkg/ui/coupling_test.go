@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCouplingViewJumpsToCrossing(t *testing.T) {
+	child := func(id, epic string, blockers ...string) model.Issue {
+		deps := []*model.Dependency{{DependsOnID: epic, Type: model.DepParentChild}}
+		for _, b := range blockers {
+			deps = append(deps, &model.Dependency{DependsOnID: b, Type: model.DepBlocks})
+		}
+		return model.Issue{ID: id, Title: id + " task", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: deps}
+	}
+	issues := []model.Issue{
+		{ID: "EA", Title: "Auth", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "EB", Title: "Billing", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		child("A1", "EA", "B1"),
+		child("B1", "EB"),
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	m = updated.(Model)
+	if !m.isCouplingView || m.focused != focusCoupling {
+		t.Fatalf("expected K to open the coupling view")
+	}
+	out := m.View()
+	for _, want := range []string{"CROSS-EPIC", "EA → EB", "A1", "B1", "waits on"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the report:\n%s", want, out)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	m = updated.(Model)
+	if m.isCouplingView {
+		t.Fatalf("expected o to leave the report")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "B1" {
+		t.Errorf("expected the blocker B1 selected, got %v", m.list.SelectedItem())
+	}
+}
@@ -88,45 +88,45 @@ func TestHandleListKeysFiltersAndTimeTravelPrompt(t *testing.T) {
 	m.focused = focusList
 	m.isSplitView = false
 
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
 	if m.currentFilter != "open" {
 		t.Fatalf("expected filter 'open', got %s", m.currentFilter)
 	}
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
 	if m.currentFilter != "closed" {
 		t.Fatalf("expected filter 'closed', got %s", m.currentFilter)
 	}
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
 	if m.currentFilter != "ready" {
 		t.Fatalf("expected filter 'ready', got %s", m.currentFilter)
 	}
 
 	// Paging up/down
 	m.list.Select(0)
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyCtrlD})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyCtrlD})
 	if m.list.Index() == 0 {
 		t.Fatalf("ctrl+d should move selection down")
 	}
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyCtrlU})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyCtrlU})
 	if m.list.Index() != 0 {
 		t.Fatalf("ctrl+u should move selection up")
 	}
 
 	// Enter should flip showDetails in mobile view
 	m.showDetails = false
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyEnter})
 	if !m.showDetails {
 		t.Fatalf("enter should show details when not split view")
 	}
 
 	// Time-travel prompt toggling
 	m.timeTravelMode = false
-	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m, _ = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
 	if !m.showTimeTravelPrompt || m.focused != focusTimeTravelInput {
 		t.Fatalf("time-travel prompt not activated")
 	}
 	// Cancel via Esc to avoid git dependency
-	m = m.handleTimeTravelInputKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	m, _ = m.handleTimeTravelInputKeys(tea.KeyMsg{Type: tea.KeyEsc})
 	if m.showTimeTravelPrompt {
 		t.Fatalf("prompt should close on esc")
 	}
@@ -187,6 +187,36 @@ func TestViewTogglesGraphBoardInsightsActionable(t *testing.T) {
 	}
 }
 
+func TestGraphEnterRecentersOnPickedNeighbor(t *testing.T) {
+	// A blocks B
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 120, 30
+	m.isGraphView = true
+	m.focused = focusGraph
+	for i := 0; i < len(issues) && m.graphView.SelectedIssue().ID != "B"; i++ {
+		m.graphView.MoveDown()
+	}
+
+	m = m.handleGraphKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")})
+	m = m.handleGraphKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.isGraphView || m.graphView.SelectedIssue().ID != "A" {
+		t.Fatalf("expected enter to re-center the graph on the picked blocker A, got %s", m.graphView.SelectedIssue().ID)
+	}
+	if trail := m.graphView.Trail(); len(trail) != 1 || trail[0] != "B" {
+		t.Fatalf("expected trail [B], got %v", trail)
+	}
+
+	// With nothing picked, enter opens the selected issue as before
+	m = m.handleGraphKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.isGraphView {
+		t.Fatalf("expected enter without a picked neighbor to leave the graph view")
+	}
+}
+
 func TestHandleGraphBoardActionableKeys(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "X", Title: "Cross", Status: model.StatusOpen},
@@ -290,7 +320,7 @@ func TestWaitForPhase2CmdCompletes(t *testing.T) {
 		0,
 		nil,
 	)
-	cmd := WaitForPhase2Cmd(stats)
+	cmd := WaitForPhase2Cmd(nil, stats)
 	if msg := cmd(); msg == nil {
 		t.Fatalf("expected Phase2ReadyMsg")
 	}
@@ -529,7 +559,7 @@ func TestBoardAndInsightsExtraKeys(t *testing.T) {
 	m.showTimeTravelPrompt = true
 	m.focused = focusTimeTravelInput
 	m.timeTravelInput.SetValue("HEAD~1")
-	m = m.handleTimeTravelInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.handleTimeTravelInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
 	if !m.statusIsError && m.statusMsg == "" {
 		t.Fatalf("expected status message after attempting time-travel without git")
 	}
@@ -694,11 +724,11 @@ func TestGraphRenderBlocksAndDependents(t *testing.T) {
 
 	blockers := []string{"B1", "B2", "B3", "B4", "B5", "B6"}
 	dependents := []string{"D1", "D2", "D3"}
-	blockOut := g.renderBlockersVisual(blockers, 80, g.theme)
-	if !strings.Contains(blockOut, "+1 more") {
-		t.Fatalf("blockers visual should include + more badge")
+	blockOut := g.renderBlockersVisual("EGO", blockers, 80, g.theme)
+	if !strings.Contains(blockOut, "B6") || strings.Contains(blockOut, "more") {
+		t.Fatalf("blockers visual should box every blocker for panning: %s", blockOut)
 	}
-	depOut := g.renderDependentsVisual(dependents, 80, g.theme)
+	depOut := g.renderDependentsVisual("EGO", dependents, 80, g.theme)
 	if !strings.Contains(depOut, "D1") || !strings.Contains(depOut, "D3") {
 		t.Fatalf("dependents visual missing entries: %s", depOut)
 	}
@@ -0,0 +1,420 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// IssueTemplate prefills the new-issue form so new issues follow the team's
+// conventions (the config's [templates.<name>] tables)
+type IssueTemplate struct {
+	Name        string
+	Title       string // Text the title starts with, e.g. "Bug: "
+	Type        string // "" leaves it to bd
+	Priority    int
+	Labels      []string
+	Assignee    string
+	Description string
+	Checklist   []string // Appended to the description as "- [ ]" items
+	Fields      []string // Fields the form asks for besides the title; nil asks for all
+}
+
+// createFields are the fields the form can ask for besides the title
+var createFields = []string{"type", "priority", "assignee", "labels"}
+
+// blankTemplate starts an issue from nothing
+var blankTemplate = IssueTemplate{Name: "Blank issue", Priority: 2}
+
+// description returns the template's description with its checklist
+func (t IssueTemplate) description() string {
+	desc := strings.TrimRight(t.Description, "\n")
+	if len(t.Checklist) == 0 {
+		return desc
+	}
+	var sb strings.Builder
+	if desc != "" {
+		sb.WriteString(desc + "\n\n")
+	}
+	for i, item := range t.Checklist {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("- [ ] " + item)
+	}
+	return sb.String()
+}
+
+// summary describes what the template fills in
+func (t IssueTemplate) summary() string {
+	var parts []string
+	if t.Type != "" {
+		parts = append(parts, t.Type)
+	}
+	parts = append(parts, fmt.Sprintf("P%d", t.Priority))
+	if t.Assignee != "" {
+		parts = append(parts, "@"+t.Assignee)
+	}
+	if len(t.Labels) > 0 {
+		parts = append(parts, strings.Join(t.Labels, ", "))
+	}
+	if len(t.Checklist) > 0 {
+		parts = append(parts, fmt.Sprintf("%d-item checklist", len(t.Checklist)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// asks reports whether the form asks for field rather than taking the
+// template's value
+func (t IssueTemplate) asks(field string) bool {
+	if t.Fields == nil {
+		return true
+	}
+	for _, f := range t.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueDraft is a new issue as filled in on the form
+type IssueDraft struct {
+	Title       string
+	Type        string
+	Priority    int
+	Assignee    string
+	Labels      []string
+	Description string
+}
+
+// args returns the bd arguments creating the issue. The title goes last,
+// after "--", so one starting with a dash isn't taken for a flag.
+func (d IssueDraft) args() []string {
+	args := []string{"create", "--priority", strconv.Itoa(d.Priority)}
+	if d.Type != "" {
+		args = append(args, "--type", d.Type)
+	}
+	if d.Assignee != "" {
+		args = append(args, "--assignee", d.Assignee)
+	}
+	if len(d.Labels) > 0 {
+		args = append(args, "--labels", strings.Join(d.Labels, ","))
+	}
+	if d.Description != "" {
+		args = append(args, "--description", d.Description)
+	}
+	return append(args, "--", d.Title)
+}
+
+// IssueCreatedMsg reports the outcome of creating an issue
+type IssueCreatedMsg struct {
+	Title string
+	Err   error
+}
+
+// CreateIssueCmd creates the drafted issue using the bd CLI. The file
+// watcher picks up the change and reloads the list.
+func CreateIssueCmd(beadsPath string, draft IssueDraft) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		err := runBeadsCLI(dir, draft.args()...)
+		return IssueCreatedMsg{Title: draft.Title, Err: err}
+	}
+}
+
+// createField is one line of the form
+type createField struct {
+	name  string // title, or one of createFields
+	label string
+	input textinput.Model
+}
+
+// CreateFormModel is the overlay for creating an issue: first a choice of
+// template when any are configured, then a form prefilled from it
+type CreateFormModel struct {
+	templates []IssueTemplate
+	choosing  bool // Picking a template rather than filling in the form
+	selected  int  // Highlighted template; len(templates) is a blank issue
+	template  IssueTemplate
+	fields    []createField
+	focus     int
+	err       string
+	width     int
+	height    int
+	theme     Theme
+}
+
+// NewCreateFormModel creates the new-issue form
+func NewCreateFormModel(templates []IssueTemplate, theme Theme) CreateFormModel {
+	return CreateFormModel{templates: templates, theme: theme}
+}
+
+// SetSize updates the overlay dimensions
+func (m *CreateFormModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	for i := range m.fields {
+		m.fields[i].input.Width = m.inputWidth()
+	}
+}
+
+// inputWidth returns how wide the text fields are
+func (m *CreateFormModel) inputWidth() int {
+	return min(max(m.width-30, 20), 70)
+}
+
+// Open resets the overlay, asking for a template first if there are any
+func (m *CreateFormModel) Open() {
+	m.selected = 0
+	m.choosing = len(m.templates) > 0
+	if !m.choosing {
+		m.start(blankTemplate)
+	}
+}
+
+// Choosing reports whether the overlay is asking for a template
+func (m *CreateFormModel) Choosing() bool {
+	return m.choosing
+}
+
+// MoveUp moves the template selection up
+func (m *CreateFormModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+}
+
+// MoveDown moves the template selection down
+func (m *CreateFormModel) MoveDown() {
+	if m.selected < len(m.templates) {
+		m.selected++
+	}
+}
+
+// Choose fills in the form from the highlighted template
+func (m *CreateFormModel) Choose() {
+	t := blankTemplate
+	if m.selected < len(m.templates) {
+		t = m.templates[m.selected]
+	}
+	m.choosing = false
+	m.start(t)
+}
+
+// Back returns from the form to the templates, reporting false when there
+// is nothing to go back to
+func (m *CreateFormModel) Back() bool {
+	if m.choosing || len(m.templates) == 0 {
+		return false
+	}
+	m.choosing = true
+	return true
+}
+
+// start fills in the form from t
+func (m *CreateFormModel) start(t IssueTemplate) {
+	m.template = t
+	m.err = ""
+	m.focus = 0
+	newInput := func(value, placeholder string) textinput.Model {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = placeholder
+		ti.CharLimit = 200
+		ti.Width = m.inputWidth()
+		ti.TextStyle = m.theme.Renderer.NewStyle().Foreground(m.theme.Base.GetForeground())
+		ti.SetValue(value)
+		return ti
+	}
+	m.fields = []createField{{name: "title", label: "Title", input: newInput(t.Title, "What needs doing")}}
+	for _, name := range createFields {
+		if !t.asks(name) {
+			continue
+		}
+		f := createField{name: name}
+		switch name {
+		case "type":
+			f.label, f.input = "Type", newInput(t.Type, "bug, feature, task, epic or chore")
+		case "priority":
+			f.label, f.input = "Priority", newInput(strconv.Itoa(t.Priority), "0 (critical) to 4")
+		case "assignee":
+			f.label, f.input = "Assignee", newInput(t.Assignee, "nobody")
+		case "labels":
+			f.label, f.input = "Labels", newInput(strings.Join(t.Labels, ", "), "comma-separated")
+		}
+		m.fields = append(m.fields, f)
+	}
+	m.fields[0].input.Focus()
+}
+
+// NextField moves to the next field, wrapping around
+func (m *CreateFormModel) NextField() {
+	m.setFocus((m.focus + 1) % len(m.fields))
+}
+
+// PrevField moves to the previous field, wrapping around
+func (m *CreateFormModel) PrevField() {
+	m.setFocus((m.focus + len(m.fields) - 1) % len(m.fields))
+}
+
+func (m *CreateFormModel) setFocus(i int) {
+	m.fields[m.focus].input.Blur()
+	m.focus = i
+	m.fields[m.focus].input.Focus()
+}
+
+// Update passes a key to the focused field
+func (m *CreateFormModel) Update(msg tea.KeyMsg) tea.Cmd {
+	if m.choosing || len(m.fields) == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	m.fields[m.focus].input, cmd = m.fields[m.focus].input.Update(msg)
+	m.err = ""
+	return cmd
+}
+
+// SetError shows a problem with the form
+func (m *CreateFormModel) SetError(err error) {
+	m.err = err.Error()
+}
+
+// Draft returns the issue the form describes, with the template's values
+// for the fields it doesn't ask for
+func (m *CreateFormModel) Draft() (IssueDraft, error) {
+	t := m.template
+	d := IssueDraft{Type: t.Type, Priority: t.Priority, Assignee: t.Assignee, Labels: t.Labels, Description: t.description()}
+	for _, f := range m.fields {
+		value := strings.TrimSpace(f.input.Value())
+		switch f.name {
+		case "title":
+			if value == "" || value == strings.TrimSpace(t.Title) {
+				return IssueDraft{}, fmt.Errorf("a title is required")
+			}
+			d.Title = value
+		case "type":
+			value = strings.ToLower(value)
+			if value != "" && !model.IssueType(value).IsValid() {
+				return IssueDraft{}, fmt.Errorf("type must be bug, feature, task, epic or chore, not %q", value)
+			}
+			d.Type = value
+		case "priority":
+			n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(value), "P"))
+			if err != nil || n < 0 || n > 4 {
+				return IssueDraft{}, fmt.Errorf("priority must be 0 to 4, not %q", value)
+			}
+			d.Priority = n
+		case "assignee":
+			d.Assignee = strings.TrimPrefix(value, "@")
+		case "labels":
+			d.Labels = nil
+			for _, label := range strings.Split(value, ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					d.Labels = append(d.Labels, label)
+				}
+			}
+		}
+	}
+	return d, nil
+}
+
+// View renders the overlay
+func (m *CreateFormModel) View() string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	if m.choosing {
+		lines = append(lines, titleStyle.Render("New Issue"), "", subtle.Render("Start from a template:"), "")
+		choices := append(append([]IssueTemplate{}, m.templates...), blankTemplate)
+		for i, tmpl := range choices {
+			prefix := "  "
+			nameStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+			if i == m.selected {
+				prefix = "▸ "
+				nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+			}
+			line := nameStyle.Render(fmt.Sprintf("%s%-14s", prefix, tmpl.Name))
+			if i < len(m.templates) {
+				line += subtle.Render(" " + tmpl.summary())
+			}
+			lines = append(lines, line)
+		}
+		lines = append(lines, "", subtle.Italic(true).Render("j/k: navigate • enter: choose • esc: cancel"))
+	} else {
+		title := "New Issue"
+		if m.template.Name != blankTemplate.Name {
+			title += " from " + m.template.Name
+		}
+		lines = append(lines, titleStyle.Render(title), "")
+		labelStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+		for i, f := range m.fields {
+			label := labelStyle
+			if i == m.focus {
+				label = titleStyle
+			}
+			lines = append(lines, label.Render(fmt.Sprintf("%-9s ", f.label))+f.input.View())
+		}
+		// The fields the template fills in without asking
+		var fixed []string
+		for _, name := range createFields {
+			if m.template.asks(name) {
+				continue
+			}
+			switch name {
+			case "type":
+				if m.template.Type != "" {
+					fixed = append(fixed, m.template.Type)
+				}
+			case "priority":
+				fixed = append(fixed, fmt.Sprintf("P%d", m.template.Priority))
+			case "assignee":
+				if m.template.Assignee != "" {
+					fixed = append(fixed, "@"+m.template.Assignee)
+				}
+			case "labels":
+				if len(m.template.Labels) > 0 {
+					fixed = append(fixed, strings.Join(m.template.Labels, ", "))
+				}
+			}
+		}
+		if len(fixed) > 0 {
+			lines = append(lines, "", subtle.Render("Also: "+strings.Join(fixed, " · ")))
+		}
+		if desc := m.template.description(); desc != "" {
+			lines = append(lines, "", labelStyle.Render("Description"))
+			descLines := strings.Split(desc, "\n")
+			shown := min(len(descLines), max(m.height-len(lines)-12, 3))
+			for _, l := range descLines[:shown] {
+				lines = append(lines, subtle.Render("  "+truncateToWidth(l, m.inputWidth()+8, "…")))
+			}
+			if shown < len(descLines) {
+				lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more lines", len(descLines)-shown)))
+			}
+		}
+		if m.err != "" {
+			lines = append(lines, "", t.Renderer.NewStyle().Foreground(t.Blocked).Render("❌ "+m.err))
+		}
+		back := "esc: cancel"
+		if len(m.templates) > 0 {
+			back = "esc: templates"
+		}
+		lines = append(lines, "", subtle.Italic(true).Render("tab/↑/↓: field • enter: create with bd • "+back))
+	}
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
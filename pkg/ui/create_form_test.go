@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCreateIssueFromTemplate(t *testing.T) {
+	var calls [][]string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	m := NewModel([]model.Issue{{ID: "A", Title: "Existing", Status: model.StatusOpen}}, nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	m.SetIssueTemplates([]IssueTemplate{{
+		Name: "bug", Title: "Bug: ", Type: "bug", Priority: 1, Labels: []string{"triage"},
+		Description: "Steps to reproduce:", Checklist: []string{"Regression test"},
+		Fields: []string{"priority"},
+	}})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	m = typeKeys(t, m, "n")
+	if !m.showCreateForm || !m.createForm.Choosing() {
+		t.Fatalf("expected n to offer the templates")
+	}
+	out := m.View()
+	for _, want := range []string{"bug", "P1 · triage · 1-item checklist", "Blank issue"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the template choice:\n%s", want, out)
+		}
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	// The title starts from the template, which alone isn't enough
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.showCreateForm || !strings.Contains(m.View(), "a title is required") {
+		t.Fatalf("expected the form to insist on a title:\n%s", m.View())
+	}
+	// Letters go to the form, not the shortcuts
+	m = typeKeys(t, m, "crash on load")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = updated.(Model)
+	m = typeKeys(t, m, "0")
+	out = m.View()
+	for _, want := range []string{"New Issue from bug", "Also: bug · triage", "- [ ] Regression test"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the form:\n%s", want, out)
+		}
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showCreateForm || cmd == nil {
+		t.Fatalf("expected enter to create the issue")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	want := "create --priority 0 --type bug --labels triage --description Steps to reproduce:\n\n- [ ] Regression test -- Bug: crash on load"
+	if len(calls) != 1 || strings.Join(calls[0], " ") != want {
+		t.Fatalf("unexpected bd calls %q", calls)
+	}
+	if m.statusMsg != `Created "Bug: crash on load"` {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+
+	// Without templates the form opens straight away, and esc closes it
+	m.SetIssueTemplates(nil)
+	m = typeKeys(t, m, "n")
+	if !m.showCreateForm || m.createForm.Choosing() {
+		t.Fatalf("expected a blank form")
+	}
+	m = typeKeys(t, m, "x")
+	if _, err := m.createForm.Draft(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showCreateForm {
+		t.Fatalf("expected esc to close the form")
+	}
+}
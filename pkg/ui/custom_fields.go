@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SetCustomFields picks which fields other tools add to issues show: as
+// list columns, after the computed ones, and as sections of the details.
+// Each is named by its JSON key (see model.Issue.Extra).
+func (m *Model) SetCustomFields(columns, details []string) {
+	m.fieldColumns = columns
+	m.fieldDetails = details
+	m.list.SetDelegate(m.issueDelegate())
+	m.updateViewportContent()
+}
+
+// customFieldsMarkdown renders the detail fields the issue has, each as a
+// section: text as it is, objects and lists as indented JSON
+func (m *Model) customFieldsMarkdown(issue model.Issue) string {
+	var sb strings.Builder
+	for _, name := range m.fieldDetails {
+		raw, ok := issue.Extra[name]
+		if !ok || issue.ExtraField(name) == "" {
+			continue
+		}
+		sb.WriteString("### " + name + "\n")
+		trimmed := bytes.TrimSpace(raw)
+		var indented bytes.Buffer
+		if (trimmed[0] == '{' || trimmed[0] == '[') && json.Indent(&indented, trimmed, "", "  ") == nil {
+			sb.WriteString("```json\n" + indented.String() + "\n```\n\n")
+		} else {
+			sb.WriteString(issue.ExtraField(name) + "\n\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCustomFieldColumnsAndDetails(t *testing.T) {
+	var issue model.Issue
+	line := `{"id":"A","title":"Invoice export","status":"open","priority":1,"issue_type":"feature",` +
+		`"customer":"Acme Corp","links":{"pr":"#12","design":"figma"}}`
+	if err := json.Unmarshal([]byte(line), &issue); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel([]model.Issue{issue, {ID: "B", Title: "Other", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask}}, nil, "")
+	m.SetCustomFields([]string{"customer"}, []string{"links", "customer"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+
+	out := m.View()
+	for _, want := range []string{"CUSTOMER", "Acme Corp"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the list:\n%s", want, out)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	details := m.viewport.View()
+	for _, want := range []string{"links", `"design": "figma"`, "customer"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("expected %q in the details:\n%s", want, details)
+		}
+	}
+}
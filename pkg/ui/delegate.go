@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,14 +20,46 @@ type IssueDelegate struct {
 	Theme             Theme
 	ShowPriorityHints bool
 	PriorityHints     map[string]*analysis.PriorityRecommendation
-	WorkspaceMode     bool // When true, shows repo prefix badges
+	WorkspaceMode     bool     // When true, shows repo prefix badges
+	Columns           []string // Optional columns to show (due, age, comments, checklist, assignee, labels); nil shows all
+	ScriptColumns     []string // Names of the computed columns, matching IssueItem.Script.Columns
+	FieldColumns      []string // Fields other tools add to issues, shown as columns
+	// Column widths fitted to every listed issue, so rows line up under the
+	// header; nil fits each row to itself
+	Fit *columnFit
+	// Lines per issue: 1 (or 0) for single-line rows, 2 or 3 for cards that
+	// add labels, blockers and recent activity under the row
+	Lines    int
+	IssueMap map[string]*model.Issue // Resolves card blockers
+	Unblocks map[string]int          // Open issues each issue blocks, for cards
+	Density  Density                 // Gutter, row gaps and decorations
+}
+
+// showColumn reports whether the optional column name is enabled
+func (d IssueDelegate) showColumn(name string) bool {
+	// Cards show labels under the row; compact rows drop comment counts
+	if (name == "labels" && d.Lines > 1) || (name == "comments" && d.Density == DensityCompact) {
+		return false
+	}
+	if d.Columns == nil {
+		return true
+	}
+	for _, c := range d.Columns {
+		if c == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (d IssueDelegate) Height() int {
-	return 1
+	return max(d.Lines, 1)
 }
 
 func (d IssueDelegate) Spacing() int {
+	if d.Density == DensitySpacious {
+		return 1
+	}
 	return 0
 }
 
@@ -33,6 +68,11 @@ func (d IssueDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 }
 
 func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	if header, ok := listItem.(GroupHeaderItem); ok {
+		d.renderGroupHeader(w, m, index, header)
+		fmt.Fprint(w, strings.Repeat("\n", d.Height()-1))
+		return
+	}
 	i, ok := listItem.(IssueItem)
 	if !ok {
 		return
@@ -50,171 +90,77 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	// ══════════════════════════════════════════════════════════════════════════
 	// POLISHED ROW LAYOUT - Stripe-level visual hierarchy
-	// Layout: [sel] [type] [prio-badge] [status-badge] [ID] [title...] [meta]
+	// Layout: [sel] [repo] [type] [prio-badge] [hint] [status-badge] [ID] [diff] [title...] [meta]
+	// Every column has a fixed width (see listLayout) so rows line up under
+	// the header row
 	// ══════════════════════════════════════════════════════════════════════════
+	fit := fitColumns([]list.Item{i})
+	if d.Fit != nil {
+		fit = *d.Fit
+	}
+	l := d.layout(width, fit)
 
 	// Get all the data
 	icon, iconColor := t.GetTypeIcon(string(i.Issue.IssueType))
-	idStr := i.Issue.ID
-	title := i.Issue.Title
-	ageStr := FormatTimeRel(i.Issue.CreatedAt)
-	commentCount := len(i.Issue.Comments)
-
-	// Calculate widths for right-side columns (fixed)
-	rightWidth := 0
-	var rightParts []string
-
-	// Show Age and Comments only if we have reasonable width
-	if width > 60 {
-		// Age - with subtle styling
-		ageStyle := t.Renderer.NewStyle().Foreground(ColorMuted)
-		rightParts = append(rightParts, ageStyle.Render(fmt.Sprintf("%8s", ageStr)))
-		rightWidth += 9
-
-		// Comments with icon
-		if commentCount > 0 {
-			commentStyle := t.Renderer.NewStyle().Foreground(ColorInfo)
-			rightParts = append(rightParts, commentStyle.Render(fmt.Sprintf("💬%d", commentCount)))
-			rightWidth += 4 + len(fmt.Sprintf("%d", commentCount))
-		} else {
-			rightParts = append(rightParts, "   ")
-			rightWidth += 3
-		}
-	}
-
-	// Assignee (if present and we have room)
-	if width > 100 && i.Issue.Assignee != "" {
-		assignee := truncateRunesHelper(i.Issue.Assignee, 12, "…")
-		assigneeStyle := t.Renderer.NewStyle().Foreground(ColorSecondary)
-		rightParts = append(rightParts, assigneeStyle.Render(fmt.Sprintf("@%-12s", assignee)))
-		rightWidth += 14
-	}
-
-	// Labels (if present and we have room) - render as mini tags
-	if width > 140 && len(i.Issue.Labels) > 0 {
-		labelStr := truncateRunesHelper(strings.Join(i.Issue.Labels, ","), 20, "…")
-		labelStyle := t.Renderer.NewStyle().
-			Foreground(ColorPrimary).
-			Background(ColorBgSubtle).
-			Padding(0, 1)
-		rightParts = append(rightParts, labelStyle.Render(labelStr))
-		rightWidth += lipgloss.Width(labelStyle.Render(labelStr)) + 1
-	}
-
-	// Left side fixed columns with polished badges
-	// [selector 2] [repo-badge 0-6] [icon 2] [prio-badge 3] [hint 1-2] [status-badge 6] [id dynamic] [space]
-	leftFixedWidth := 2 + 3 // selector + icon
-
-	// Repo badge width (workspace mode)
-	var repoBadge string
-	if d.WorkspaceMode && i.RepoPrefix != "" {
-		// Create a compact repo badge like [API] or [WEB]
-		repoBadge = RenderRepoBadge(i.RepoPrefix)
-		leftFixedWidth += lipgloss.Width(repoBadge) + 1
-	}
-
-	// Priority badge (polished)
-	prioBadge := RenderPriorityBadge(i.Issue.Priority)
-	prioBadgeWidth := lipgloss.Width(prioBadge)
-	leftFixedWidth += prioBadgeWidth + 1
-
-	// Priority hint indicator
-	if d.ShowPriorityHints {
-		leftFixedWidth += 2
-	}
-
-	// Status badge (polished)
-	statusBadge := RenderStatusBadge(string(i.Issue.Status))
-	statusBadgeWidth := lipgloss.Width(statusBadge)
-	leftFixedWidth += statusBadgeWidth + 1
-
-	// ID width - use actual rune length, but cap reasonably
-	idRunes := []rune(idStr)
-	idWidth := len(idRunes)
-	if idWidth > 35 {
-		idWidth = 35
-		idStr = truncateRunesHelper(idStr, 35, "…")
-	}
-	leftFixedWidth += idWidth + 1
-
-	// Diff badge width adjustment
-	if badge := i.DiffStatus.Badge(); badge != "" {
-		leftFixedWidth += lipgloss.Width(badge) + 1
-	}
-
-	// Title gets everything in between
-	titleWidth := width - leftFixedWidth - rightWidth - 2
-	if titleWidth < 5 {
-		titleWidth = 5
-	}
-
-	// Truncate title if needed
-	titleRunes := []rune(title)
-	if len(titleRunes) > titleWidth {
-		title = string(titleRunes[:titleWidth-1]) + "…"
-	} else {
-		// Pad title to fill space
-		title = title + strings.Repeat(" ", titleWidth-len(titleRunes))
-	}
+	idStr := truncateToWidth(i.Issue.ID, l.id, "…")
+	title := truncateToWidth(i.Issue.Title, l.title, "…")
 
 	// ══════════════════════════════════════════════════════════════════════════
 	// BUILD THE ROW
 	// ══════════════════════════════════════════════════════════════════════════
 	var leftSide strings.Builder
 
-	// Selection indicator with accent color
+	// Selection indicator with accent color, right-aligned in the gutter
+	gutter := strings.Repeat(" ", l.gutter)
 	if isSelected {
-		leftSide.WriteString(t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ "))
-	} else {
-		leftSide.WriteString("  ")
+		gutter = strings.Repeat(" ", max(l.gutter-2, 0)) + t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render(truncateToWidth("▸ ", l.gutter, ""))
 	}
+	leftSide.WriteString(gutter)
 
 	// Repo badge (workspace mode)
-	if repoBadge != "" {
-		leftSide.WriteString(repoBadge)
-		leftSide.WriteString(" ")
+	if l.repo > 0 {
+		leftSide.WriteString(padCell(RenderRepoBadge(i.RepoPrefix), l.repo, false) + " ")
 	}
 
 	// Type icon with color
-	leftSide.WriteString(t.Renderer.NewStyle().Foreground(iconColor).Render(icon))
-	leftSide.WriteString(" ")
+	if l.typ {
+		leftSide.WriteString(padCell(t.Renderer.NewStyle().Foreground(iconColor).Render(icon), typeColumnWidth, false) + " ")
+	}
 
 	// Priority badge (polished)
-	leftSide.WriteString(prioBadge)
-	leftSide.WriteString(" ")
+	leftSide.WriteString(padCell(RenderPriorityBadge(i.Issue.Priority), priorityColumnWidth, false) + " ")
 
 	// Priority hint indicator (↑/↓)
-	if d.ShowPriorityHints && d.PriorityHints != nil {
+	if l.hint {
+		hintStr := " "
 		if hint, ok := d.PriorityHints[i.Issue.ID]; ok {
 			if hint.Direction == "increase" {
-				leftSide.WriteString(t.Renderer.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Bold(true).Render("↑"))
+				hintStr = t.Renderer.NewStyle().Foreground(lipgloss.Color("#FF6B6B")).Bold(true).Render("↑")
 			} else if hint.Direction == "decrease" {
-				leftSide.WriteString(t.Renderer.NewStyle().Foreground(lipgloss.Color("#4ECDC4")).Bold(true).Render("↓"))
+				hintStr = t.Renderer.NewStyle().Foreground(lipgloss.Color("#4ECDC4")).Bold(true).Render("↓")
 			}
-		} else {
-			leftSide.WriteString(" ")
 		}
-		leftSide.WriteString(" ")
+		leftSide.WriteString(padCell(hintStr, hintColumnWidth, false) + " ")
 	}
 
 	// Status badge (polished)
-	leftSide.WriteString(statusBadge)
-	leftSide.WriteString(" ")
+	leftSide.WriteString(padCell(RenderStatusBadge(string(i.Issue.Status)), statusColumnWidth, false) + " ")
 
 	// ID with secondary styling
 	idStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
 	if isSelected {
 		idStyle = idStyle.Bold(true)
 	}
-	leftSide.WriteString(idStyle.Render(idStr))
-	leftSide.WriteString(" ")
+	leftSide.WriteString(padCell(idStyle.Render(idStr), l.id, false) + " ")
 
 	// Diff badge (time-travel mode)
-	if badge := i.DiffStatus.Badge(); badge != "" {
-		leftSide.WriteString(badge)
-		leftSide.WriteString(" ")
+	if l.diff {
+		leftSide.WriteString(padCell(i.DiffStatus.Badge(), diffColumnWidth, false) + " ")
 	}
 
+	// Cards line their details up under the title
+	indent := lipgloss.Width(leftSide.String())
+
 	// Title with emphasis when selected
 	titleStyle := t.Renderer.NewStyle()
 	if isSelected {
@@ -222,21 +168,64 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	} else {
 		titleStyle = titleStyle.Foreground(lipgloss.AdaptiveColor{Light: "#333333", Dark: "#E8E8E8"})
 	}
-	leftSide.WriteString(titleStyle.Render(title))
-
-	// Right side
-	rightSide := strings.Join(rightParts, " ")
+	leftSide.WriteString(padCell(titleStyle.Render(title), l.title, false))
 
-	// Combine: left + padding + right
-	leftLen := lipgloss.Width(leftSide.String())
-	rightLen := lipgloss.Width(rightSide)
-	padding := width - leftLen - rightLen
-	if padding < 0 {
-		padding = 0
+	// Right side: each column padded to its width, blank when the issue has no value
+	var rightSide strings.Builder
+	if l.due > 0 {
+		rightSide.WriteString(" " + padCell(RenderDueBadge(t.Renderer, &i.Issue, time.Now()), l.due, false))
+	}
+	if l.age > 0 {
+		// Age - with subtle styling
+		ageStyle := t.Renderer.NewStyle().Foreground(ColorMuted)
+		rightSide.WriteString(" " + padCell(ageStyle.Render(FormatTimeRel(i.Issue.CreatedAt)), l.age, true))
+	}
+	if l.comments > 0 {
+		// Comments with icon
+		cell := ""
+		if n := len(i.Issue.Comments); n > 0 {
+			cell = t.Renderer.NewStyle().Foreground(ColorInfo).Render(fmt.Sprintf("💬%d", n))
+		}
+		rightSide.WriteString(" " + padCell(cell, l.comments, false))
+	}
+	if l.checklist > 0 {
+		cell := ""
+		if checklist := analysis.ParseChecklist(i.Issue.Description); len(checklist) > 0 {
+			cell = renderChecklistProgress(t, checklist, 4)
+		}
+		rightSide.WriteString(" " + padCell(cell, l.checklist, false))
+	}
+	// Computed columns from the user's scripts
+	for n := range l.scripts {
+		cell := ""
+		if i.Script != nil && n < len(i.Script.Columns) {
+			cell = t.Renderer.NewStyle().Foreground(ColorInfo).Render(
+				truncateToWidth(script.Format(i.Script.Columns[n]), scriptColumnWidth, "…"))
+		}
+		rightSide.WriteString(" " + padCell(cell, scriptColumnWidth, false))
+	}
+	// Fields other tools add, blank when the issue doesn't have one
+	for n := range l.fields {
+		cell := ""
+		if value := i.Issue.ExtraField(d.FieldColumns[n]); value != "" {
+			cell = t.Renderer.NewStyle().Foreground(ColorSecondary).Render(truncateToWidth(value, fieldColumnWidth, "…"))
+		}
+		rightSide.WriteString(" " + padCell(cell, fieldColumnWidth, false))
+	}
+	if l.assignee > 0 {
+		cell := ""
+		if i.Issue.Assignee != "" {
+			cell = t.Renderer.NewStyle().Foreground(ColorSecondary).Render("@" + truncateToWidth(i.Issue.Assignee, 12, "…"))
+		}
+		rightSide.WriteString(" " + padCell(cell, l.assignee, false))
+	}
+	if l.labels > 0 {
+		// Labels as colored chips
+		rightSide.WriteString(" " + padCell(RenderLabelChips(t.Renderer, i.Issue.Labels, l.labels), l.labels, false))
 	}
 
 	// Construct the row string
-	row := leftSide.String() + strings.Repeat(" ", padding) + rightSide
+	row := leftSide.String() + rightSide.String()
 
 	// Apply row background for selection and clamp width
 	rowStyle := t.Renderer.NewStyle().Width(width).MaxWidth(width)
@@ -245,6 +234,9 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	} else {
 		row = rowStyle.Render(row)
 	}
+	if d.Lines > 1 {
+		row = strings.Join(append([]string{row}, d.renderCardLines(i, width, indent, isSelected)...), "\n")
+	}
 
 	fmt.Fprint(w, row)
 }
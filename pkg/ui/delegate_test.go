@@ -9,6 +9,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
@@ -97,7 +98,7 @@ func TestIssueDelegate_RenderFallsBackWidthAndNoPanic(t *testing.T) {
 
 func TestIssueDelegate_RenderUltraWide(t *testing.T) {
 	item := newTestIssueItem("WIDE-1")
-	// Assignee and Labels require width thresholds >100 and >140
+	// Assignee and label chips require width thresholds >100 and >120
 	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
 	delegate := IssueDelegate{Theme: theme}
 
@@ -111,8 +112,8 @@ func TestIssueDelegate_RenderUltraWide(t *testing.T) {
 	if !strings.Contains(out, "@alice") {
 		t.Fatalf("ultra-wide output missing assignee @alice: %q", out)
 	}
-	if !strings.Contains(out, "one,two") { // joined labels
-		t.Fatalf("ultra-wide output missing labels 'one,two': %q", out)
+	if !strings.Contains(out, "one") || !strings.Contains(out, "two") { // one chip per label
+		t.Fatalf("ultra-wide output missing label chips 'one' and 'two': %q", out)
 	}
 }
 
@@ -139,3 +140,80 @@ func TestIssueDelegate_RenderNarrow(t *testing.T) {
 		t.Fatalf("narrow output should hide comments count: %q", out)
 	}
 }
+
+func TestIssueDelegate_RowsLineUpUnderHeader(t *testing.T) {
+	short, long := newTestIssueItem("A-1"), newTestIssueItem("LONGER-ID-1234")
+	long.Issue.Title = "Another title"
+	long.Issue.Assignee = ""
+	items := []list.Item{short, long}
+	fit := fitColumns(items)
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{Theme: theme, Fit: &fit}
+
+	l := list.New(items, delegate, 0, 0)
+	l.SetWidth(130)
+	header := delegate.RenderHeader(130, fit, SortMode{Field: SortPriority}, "Priority ↑")
+
+	column := func(line, s string) int {
+		t.Helper()
+		i := strings.Index(line, s)
+		if i < 0 {
+			t.Fatalf("%q not found in %q", s, line)
+		}
+		return lipgloss.Width(line[:i])
+	}
+	titleCol := column(header, "TITLE")
+	assigneeCol := column(header, "ASSIGNEE")
+	if !strings.Contains(header, "PRI↑") {
+		t.Errorf("expected the priority column to carry the sort direction: %q", header)
+	}
+	if !strings.Contains(header, "Priority ↑") {
+		t.Errorf("expected the indicator in the header: %q", header)
+	}
+
+	var rows []string
+	for i, item := range items {
+		var buf bytes.Buffer
+		delegate.Render(&buf, l, i, item)
+		rows = append(rows, buf.String())
+	}
+	if got := column(rows[0], "Short title"); got != titleCol {
+		t.Errorf("expected the title at column %d, got %d", titleCol, got)
+	}
+	if got := column(rows[1], "Another title"); got != titleCol {
+		t.Errorf("expected the title at column %d with a longer ID, got %d", titleCol, got)
+	}
+	if got := column(rows[0], "@alice"); got != assigneeCol {
+		t.Errorf("expected the assignee at column %d, got %d", assigneeCol, got)
+	}
+	if w := lipgloss.Width(header); w != 129 {
+		t.Errorf("expected the header as wide as the rows, got %d", w)
+	}
+}
+
+func TestIssueDelegate_AbsoluteAges(t *testing.T) {
+	t.Cleanup(func() { _ = timefmt.Set(timefmt.DefaultSettings()) })
+	if err := timefmt.Set(timefmt.Settings{Style: "absolute", Date: "eu", Zone: "utc"}); err != nil {
+		t.Fatal(err)
+	}
+	item := newTestIssueItem("A-1")
+	item.Issue.CreatedAt = time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	items := []list.Item{item}
+	fit := fitColumns(items)
+	delegate := IssueDelegate{Theme: DefaultTheme(lipgloss.NewRenderer(os.Stdout)), Fit: &fit}
+	l := list.New(items, delegate, 0, 0)
+	l.SetWidth(130)
+
+	var buf bytes.Buffer
+	delegate.Render(&buf, l, 0, item)
+	row := buf.String()
+	header := delegate.RenderHeader(130, fit, SortMode{}, "")
+	at, col := strings.Index(row, "07.03.2026"), strings.Index(header, "CREATED")
+	if at < 0 || col < 0 {
+		t.Fatalf("expected the creation date under CREATED:\n%s\n%s", header, row)
+	}
+	// Right-aligned: the date and its header end in the same column
+	if end, hend := lipgloss.Width(row[:at])+10, lipgloss.Width(header[:col])+len("CREATED"); end != hend {
+		t.Errorf("expected the date to end at column %d, got %d", hend, end)
+	}
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Density is how tightly the list packs its rows: the gutter before each
+// row, the gap between rows and which decorations render
+type Density int
+
+const (
+	DensityComfortable Density = iota // The default: every decoration, no gaps
+	DensityCompact                    // Narrow gutter; no type icons, priority hints or comment counts
+	DensitySpacious                   // Wide gutter and a blank line between rows
+)
+
+// densityOrder is the order d cycles through
+var densityOrder = []Density{DensityCompact, DensityComfortable, DensitySpacious}
+
+func (d Density) String() string {
+	switch d {
+	case DensityCompact:
+		return "compact"
+	case DensitySpacious:
+		return "spacious"
+	default:
+		return "comfortable"
+	}
+}
+
+// Next returns the density after d in densityOrder, wrapping around
+func (d Density) Next() Density {
+	for i, o := range densityOrder {
+		if o == d {
+			return densityOrder[(i+1)%len(densityOrder)]
+		}
+	}
+	return DensityComfortable
+}
+
+// gutter is the width before a row's first column, the selection marker
+// included
+func (d Density) gutter() int {
+	switch d {
+	case DensityCompact:
+		return 1
+	case DensitySpacious:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// ParseDensity returns the density named name
+func ParseDensity(name string) (Density, error) {
+	var names []string
+	for _, d := range densityOrder {
+		if d.String() == name {
+			return d, nil
+		}
+		names = append(names, d.String())
+	}
+	return DensityComfortable, fmt.Errorf("unknown density %q (known: %s)", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDensity(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, IssueType: model.TypeBug,
+			Comments: []*model.Comment{{Text: "hi"}}},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	perPage := m.list.Paginator.PerPage
+
+	// comfortable → spacious → compact
+	m = typeKeys(t, m, "d")
+	if m.density != DensitySpacious || m.statusMsg != "Density: spacious" {
+		t.Fatalf("expected spacious, got %v (%q)", m.density, m.statusMsg)
+	}
+	if got := m.list.Paginator.PerPage; got != perPage/2 {
+		t.Errorf("expected a blank line between rows to halve the page from %d, got %d", perPage, got)
+	}
+	if !strings.Contains(ansi.Strip(m.View()), "    TYPE") {
+		t.Errorf("expected a wide gutter before the header:\n%s", m.View())
+	}
+
+	m = typeKeys(t, m, "d")
+	if m.density != DensityCompact || m.list.Paginator.PerPage != perPage {
+		t.Fatalf("expected compact with a full page, got %v", m.density)
+	}
+	out := ansi.Strip(m.View())
+	if strings.Contains(out, "TYPE") || strings.Contains(out, "💬") {
+		t.Errorf("expected compact rows without type icons or comment counts:\n%s", out)
+	}
+
+	if err := m.SetDensity("comfortable"); err != nil || m.density != DensityComfortable {
+		t.Errorf("expected comfortable, got %v (%v)", m.density, err)
+	}
+	if err := m.SetDensity("roomy"); err == nil {
+		t.Errorf("expected an unknown density to fail")
+	}
+}
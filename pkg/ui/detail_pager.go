@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// detailPager holds the search state of the detail view's pager
+type detailPager struct {
+	content   string // Rendered details, before highlighting
+	width     int    // Widest line of content, for panning wide tables and code
+	searching bool   // The / prompt is open
+	input     textinput.Model
+	query     string
+	matches   []int // Lines holding the query, top to bottom
+	current   int   // Index into matches
+}
+
+// newDetailSearchInput builds the / prompt
+func newDetailSearchInput(theme Theme) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.CharLimit = 100
+	ti.Width = 40
+	ti.PromptStyle = theme.Renderer.NewStyle().Foreground(theme.Primary).Bold(true)
+	ti.TextStyle = theme.Renderer.NewStyle().Foreground(theme.Base.GetForeground())
+	return ti
+}
+
+// detailPagerActive reports whether keys should go to the detail pager: the
+// full-screen details, or the detail pane of the split view when focused
+func (m *Model) detailPagerActive() bool {
+	if m.isSplitView {
+		return m.focused == focusDetail
+	}
+	return m.showDetails && m.focused == focusList
+}
+
+// setDetailContent shows rendered details in the viewport, highlighting the
+// current search
+func (m *Model) setDetailContent(content string) {
+	m.pager.content = content
+	m.pager.width = 0
+	for _, line := range strings.Split(content, "\n") {
+		m.pager.width = max(m.pager.width, ansi.StringWidth(line))
+	}
+	m.viewport.SetXOffset(0)
+	m.refreshDetailSearch()
+}
+
+// detailPanStep is how many columns h and l pan details wider than the pane
+const detailPanStep = 8
+
+// refreshDetailSearch finds the query in the details and redraws them with
+// every match highlighted. Lines with a match lose their markdown styling so
+// the highlight can be placed in plain text.
+func (m *Model) refreshDetailSearch() {
+	p := &m.pager
+	p.matches = nil
+	if p.query == "" {
+		m.viewport.SetContent(p.content)
+		return
+	}
+
+	t := m.theme
+	matchStyle := t.Renderer.NewStyle().Reverse(true)
+	currentStyle := t.Renderer.NewStyle().Background(t.Highlight).Foreground(t.Base.GetForeground()).Bold(true)
+	currentLine := -1
+	if p.current >= 0 {
+		currentLine = p.currentLine()
+	}
+
+	needle := strings.ToLower(p.query)
+	lines := strings.Split(p.content, "\n")
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		lower := strings.ToLower(plain)
+		if !strings.Contains(lower, needle) {
+			continue
+		}
+		p.matches = append(p.matches, i)
+		style := matchStyle
+		if i == currentLine {
+			style = currentStyle
+		}
+		var sb strings.Builder
+		for {
+			// Lowercasing can change byte lengths outside ASCII; fall back to
+			// leaving the line unhighlighted rather than cutting a rune
+			idx := strings.Index(lower, needle)
+			if idx < 0 || len(lower) != len(plain) {
+				sb.WriteString(plain)
+				break
+			}
+			sb.WriteString(plain[:idx])
+			sb.WriteString(style.Render(plain[idx : idx+len(needle)]))
+			plain, lower = plain[idx+len(needle):], lower[idx+len(needle):]
+		}
+		lines[i] = sb.String()
+	}
+	if p.current >= len(p.matches) {
+		p.current = 0
+	}
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// currentLine returns the line of the current match, -1 when there is none
+func (p *detailPager) currentLine() int {
+	if p.current < 0 || p.current >= len(p.matches) {
+		return -1
+	}
+	return p.matches[p.current]
+}
+
+// jumpToMatch moves to the match delta steps from the current one (0 for
+// the first match at or below the top of the view) and scrolls it into view
+func (m *Model) jumpToMatch(delta int) {
+	p := &m.pager
+	if len(p.matches) == 0 {
+		m.statusMsg = fmt.Sprintf("Pattern not found: %s", p.query)
+		m.statusIsError = true
+		return
+	}
+	if delta == 0 {
+		p.current = 0
+		for i, line := range p.matches {
+			if line >= m.viewport.YOffset {
+				p.current = i
+				break
+			}
+		}
+	} else {
+		p.current = (p.current + delta + len(p.matches)) % len(p.matches)
+	}
+	m.refreshDetailSearch()
+	m.viewport.SetYOffset(p.currentLine() - m.viewport.Height/3)
+	m.statusMsg = ""
+}
+
+// handleDetailSearchKeys handles typing in the / prompt
+func (m Model) handleDetailSearchKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.pager.searching = false
+		m.pager.input.Blur()
+		m.pager.query = strings.TrimSpace(m.pager.input.Value())
+		m.pager.current = 0
+		m.refreshDetailSearch()
+		if m.pager.query != "" {
+			m.jumpToMatch(0)
+		}
+	case "esc":
+		m.pager.searching = false
+		m.pager.input.Blur()
+	default:
+		var cmd tea.Cmd
+		m.pager.input, cmd = m.pager.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// handleDetailPagerKeys handles scrolling and searching the details,
+// following the links in them and checking off their checklists. It
+// reports whether the key was a pager key, with any command it started;
+// others fall through to the usual handling.
+func (m Model) handleDetailPagerKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "/":
+		m.pager.searching = true
+		m.pager.input.SetValue(m.pager.query)
+		m.pager.input.CursorEnd()
+		m.pager.input.Focus()
+	case "n", "N":
+		if m.pager.query == "" {
+			return m, nil, false
+		}
+		if msg.String() == "n" {
+			m.jumpToMatch(1)
+		} else {
+			m.jumpToMatch(-1)
+		}
+	case "esc":
+		// Clear the search before leaving the details
+		if m.pager.query == "" {
+			return m, nil, false
+		}
+		m.pager.query = ""
+		m.refreshDetailSearch()
+	case "j", "down":
+		m.viewport.ScrollDown(1)
+	case "k", "up":
+		m.viewport.ScrollUp(1)
+	case "pgdown", " ", "ctrl+f":
+		m.viewport.PageDown()
+	case "pgup", "ctrl+b":
+		m.viewport.PageUp()
+	case "ctrl+d":
+		m.viewport.HalfPageDown()
+	case "ctrl+u":
+		m.viewport.HalfPageUp()
+	case "h", "left":
+		m.viewport.ScrollLeft(detailPanStep)
+	case "l", "right":
+		m.viewport.ScrollRight(detailPanStep)
+	case "home":
+		m.viewport.GotoTop()
+	case "G", "end":
+		m.viewport.GotoBottom()
+	case "f", "{", "}":
+		m.handleLinkKey(msg.String())
+	case "x", "(", ")":
+		return m, m.handleChecklistKey(msg.String()), true
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// detailPagerHints returns the footer hints for the detail pager: the
+// search prompt while typing, otherwise the match count and scroll position
+func (m *Model) detailPagerHints(key func(string) string) []string {
+	if m.pager.searching {
+		return []string{m.pager.input.View(), key("⏎") + " find", key("esc") + " cancel"}
+	}
+	var hints []string
+	if m.pager.query != "" {
+		if n := len(m.pager.matches); n > 0 {
+			hints = append(hints, key("n/N")+fmt.Sprintf(" match %d/%d", m.pager.current+1, n))
+		} else {
+			hints = append(hints, "no match")
+		}
+	} else {
+		hints = append(hints, key("/")+" search")
+	}
+	if m.pager.width > m.viewport.Width {
+		hints = append(hints, key("h/l")+" pan")
+	}
+	return append(hints, m.detailPosition())
+}
+
+// detailPosition describes how far through the details the view is
+func (m *Model) detailPosition() string {
+	switch {
+	case m.viewport.AtTop() && m.viewport.AtBottom():
+		return "All"
+	case m.viewport.AtTop():
+		return "Top"
+	case m.viewport.AtBottom():
+		return "Bot"
+	default:
+		return fmt.Sprintf("%d%%", int(m.viewport.ScrollPercent()*100))
+	}
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDetailPagerSearch(t *testing.T) {
+	var desc []string
+	for i := 0; i < 80; i++ {
+		line := "filler line"
+		if i == 10 || i == 60 {
+			line = "the NEEDLE is here"
+		}
+		desc = append(desc, line+"\n")
+	}
+	issues := []model.Issue{{ID: "A", Title: "long", Status: model.StatusOpen, IssueType: model.TypeTask,
+		Description: strings.Join(desc, "\n"), CreatedAt: time.Now()}}
+
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = updated.(Model)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}
+
+	press("enter")
+	if !m.showDetails || !m.detailPagerActive() {
+		t.Fatalf("expected full-screen details with the pager active")
+	}
+	if !strings.Contains(m.renderFooter(), "Top") {
+		t.Errorf("expected the position at the top in the footer:\n%s", m.renderFooter())
+	}
+
+	press("j", "j")
+	if m.viewport.YOffset != 2 {
+		t.Fatalf("expected j to scroll the details, offset %d", m.viewport.YOffset)
+	}
+
+	press("/")
+	if !m.pager.searching {
+		t.Fatalf("expected the search prompt open")
+	}
+	press("n", "e", "e", "d", "l", "e", "enter")
+	if m.pager.searching || m.pager.query != "needle" || len(m.pager.matches) != 2 {
+		t.Fatalf("expected 2 matches for needle, got %+v", m.pager.matches)
+	}
+	first := m.pager.currentLine()
+	if first < m.viewport.YOffset || first >= m.viewport.YOffset+m.viewport.Height {
+		t.Fatalf("expected match line %d in view at offset %d", first, m.viewport.YOffset)
+	}
+	if footer := m.renderFooter(); !strings.Contains(footer, "match 1/2") {
+		t.Errorf("expected match count in the footer:\n%s", footer)
+	}
+
+	press("n")
+	if m.pager.current != 1 || m.pager.currentLine() <= first {
+		t.Fatalf("expected n to move to the second match, got %d", m.pager.current)
+	}
+	press("N")
+	if m.pager.current != 0 {
+		t.Fatalf("expected N to move back to the first match, got %d", m.pager.current)
+	}
+
+	// Esc clears the search first, then leaves the details
+	press("esc")
+	if m.pager.query != "" || !m.showDetails {
+		t.Fatalf("expected esc to clear the search and stay in the details")
+	}
+	press("esc")
+	if m.showDetails {
+		t.Fatalf("expected the second esc to close the details")
+	}
+}
+
+func TestDetailPagerPansWideContent(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Title: "wide", Status: model.StatusOpen, IssueType: model.TypeTask,
+		Description: "```\n" + strings.Repeat("x", 150) + "END\n```", CreatedAt: time.Now()}}
+
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = updated.(Model)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			if k == "enter" {
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}
+
+	press("enter")
+	if m.pager.width <= m.viewport.Width {
+		t.Fatalf("expected details wider than the pane, %d <= %d", m.pager.width, m.viewport.Width)
+	}
+	if !strings.Contains(m.renderFooter(), "pan") {
+		t.Errorf("expected a pan hint in the footer:\n%s", m.renderFooter())
+	}
+	if strings.Contains(m.viewport.View(), "END") {
+		t.Fatalf("expected the end of the line cut off before panning")
+	}
+
+	for i := 0; i < 20; i++ {
+		press("l")
+	}
+	if !strings.Contains(m.viewport.View(), "END") {
+		t.Fatalf("expected l to pan to the end of the line:\n%s", m.viewport.View())
+	}
+	for i := 0; i < 20; i++ {
+		press("h")
+	}
+	if m.viewport.HorizontalScrollPercent() != 0 {
+		t.Fatalf("expected h to pan back to the left edge")
+	}
+}
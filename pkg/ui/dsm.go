@@ -0,0 +1,318 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dsmCellWidth is the width of one matrix column, enough for its number
+const dsmCellWidth = 3
+
+// DSMModel is the dependency structure matrix: open issues, or epics, on
+// both axes with a mark wherever the row depends on the column
+type DSMModel struct {
+	issues    analysis.DSM
+	coupling  analysis.EpicCoupling
+	epics     bool // Epic to epic counts instead of issues
+	issueMap  map[string]*model.Issue
+	row, col  int
+	rowOffset int
+	colOffset int
+	width     int
+	height    int
+	theme     Theme
+}
+
+// NewDSMModel builds the matrix from the issues in scope; closed issues no
+// longer hold anything up and are left out
+func NewDSMModel(issues []model.Issue, theme Theme) DSMModel {
+	issueMap := make(map[string]*model.Issue, len(issues))
+	var open []model.Issue
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+		if !issues[i].Status.IsClosed() {
+			open = append(open, issues[i])
+		}
+	}
+	return DSMModel{
+		issues:   analysis.BuildDSM(open),
+		coupling: analysis.ComputeEpicCoupling(issues),
+		issueMap: issueMap,
+		theme:    theme,
+	}
+}
+
+// axis returns the row and column order at the current level
+func (m *DSMModel) axis() []string {
+	if m.epics {
+		return m.coupling.Epics
+	}
+	return m.issues.IDs
+}
+
+// marked reports whether row depends on col at the current level
+func (m *DSMModel) marked(row, col string) bool {
+	if m.epics {
+		return m.coupling.Count(row, col) > 0
+	}
+	return m.issues.Cell(row, col) != ""
+}
+
+// SetSize updates the view dimensions
+func (m *DSMModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves the cursor up a row
+func (m *DSMModel) MoveUp() { m.moveTo(m.row-1, m.col) }
+
+// MoveDown moves the cursor down a row
+func (m *DSMModel) MoveDown() { m.moveTo(m.row+1, m.col) }
+
+// MoveLeft moves the cursor left a column
+func (m *DSMModel) MoveLeft() { m.moveTo(m.row, m.col-1) }
+
+// MoveRight moves the cursor right a column
+func (m *DSMModel) MoveRight() { m.moveTo(m.row, m.col+1) }
+
+func (m *DSMModel) moveTo(row, col int) {
+	n := len(m.axis())
+	m.row = max(min(row, n-1), 0)
+	m.col = max(min(col, n-1), 0)
+	m.ensureVisible()
+}
+
+// ToggleEpics switches between issues and epics on the axes and reports
+// whether epics are now shown
+func (m *DSMModel) ToggleEpics() bool {
+	m.epics = !m.epics
+	m.row, m.col, m.rowOffset, m.colOffset = 0, 0, 0, 0
+	return m.epics
+}
+
+// JumpToMark moves the cursor to the next marked cell, reading row by row,
+// or the previous one when delta is negative. It reports false when the
+// matrix has no marks.
+func (m *DSMModel) JumpToMark(delta int) bool {
+	ids := m.axis()
+	n := len(ids)
+	if n == 0 {
+		return false
+	}
+	pos := m.row*n + m.col
+	for step := 1; step <= n*n; step++ {
+		p := ((pos+step*delta)%(n*n) + n*n) % (n * n)
+		if r, c := p/n, p%n; r != c && m.marked(ids[r], ids[c]) {
+			m.moveTo(r, c)
+			return true
+		}
+	}
+	return false
+}
+
+// Selected returns the row and column under the cursor, "" when empty
+func (m *DSMModel) Selected() (row, col string) {
+	ids := m.axis()
+	if m.row >= len(ids) || m.col >= len(ids) {
+		return "", ""
+	}
+	return ids[m.row], ids[m.col]
+}
+
+// labelWidth is the width of the row labels left of the matrix
+func (m *DSMModel) labelWidth() int {
+	return max(min(m.width/3, 32), 12)
+}
+
+// visibleRows and visibleCols return how much of the matrix fits around
+// the header, column numbers and footer lines
+func (m *DSMModel) visibleRows() int {
+	return max(m.height-7, 1)
+}
+
+func (m *DSMModel) visibleCols() int {
+	return max((m.width-m.labelWidth()-8)/dsmCellWidth, 1)
+}
+
+// ensureVisible scrolls both ways to keep the cursor on screen
+func (m *DSMModel) ensureVisible() {
+	rows, cols := m.visibleRows(), m.visibleCols()
+	if m.row < m.rowOffset {
+		m.rowOffset = m.row
+	}
+	if m.row >= m.rowOffset+rows {
+		m.rowOffset = m.row - rows + 1
+	}
+	if m.col < m.colOffset {
+		m.colOffset = m.col
+	}
+	if m.col >= m.colOffset+cols {
+		m.colOffset = m.col - cols + 1
+	}
+}
+
+// title returns the title of issue id, or "" when it isn't loaded
+func (m *DSMModel) title(id string) string {
+	if issue, ok := m.issueMap[id]; ok {
+		return issue.Title
+	}
+	return ""
+}
+
+// cell renders the mark for row depending on col, unstyled, and the color
+// it takes
+func (m *DSMModel) cell(row, col string) (string, lipgloss.AdaptiveColor) {
+	t := m.theme
+	if m.epics {
+		switch n := m.coupling.Count(row, col); {
+		case n > 9:
+			return "+", t.Blocked
+		case n > 0:
+			return fmt.Sprint(n), t.Blocked
+		}
+		return "·", t.Subtext
+	}
+	switch dt := m.issues.Cell(row, col); {
+	case dt == "":
+		return "·", t.Subtext
+	case dt == model.DepParentChild:
+		return "◆", edgeColor(dt, t)
+	case dt.IsBlocking():
+		return "■", edgeColor(dt, t)
+	case dt == model.DepRelated:
+		return "○", edgeColor(dt, t)
+	default:
+		return "•", edgeColor(dt, t)
+	}
+}
+
+// Render renders the visible part of the matrix, what the cursor cell
+// means, and a legend
+func (m *DSMModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	cursorStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+
+	ids := m.axis()
+	level := fmt.Sprintf("%d open issues", len(ids))
+	if m.epics {
+		level = fmt.Sprintf("%d coupled epics", len(ids))
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("▦ DEPENDENCY MATRIX  │  %s  │  rows depend on columns", level)))
+	lines = append(lines, "")
+
+	if len(ids) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		empty := "No open issues."
+		if m.epics {
+			empty = "No blocking dependencies cross epic boundaries."
+		}
+		lines = append(lines, emptyStyle.Render(empty))
+		return strings.Join(lines, "\n")
+	}
+
+	labelWidth := m.labelWidth()
+	lastCol := min(m.colOffset+m.visibleCols(), len(ids))
+	lastRow := min(m.rowOffset+m.visibleRows(), len(ids))
+
+	header := strings.Repeat(" ", labelWidth+6)
+	for c := m.colOffset; c < lastCol; c++ {
+		num := fmt.Sprintf("%*d", dsmCellWidth, (c+1)%1000)
+		if c == m.col {
+			header += cursorStyle.Render(num)
+		} else {
+			header += subtle.Render(num)
+		}
+	}
+	lines = append(lines, header)
+
+	for r := m.rowOffset; r < lastRow; r++ {
+		label := ids[r]
+		if title := m.title(ids[r]); title != "" {
+			label += " " + title
+		}
+		num := fmt.Sprintf("%4d  ", r+1)
+		label = fitWidth(label, labelWidth)
+		if r == m.row {
+			label = cursorStyle.Render(num + label)
+		} else {
+			label = subtle.Render(num) + label
+		}
+
+		var cells strings.Builder
+		for c := m.colOffset; c < lastCol; c++ {
+			mark, color := m.cell(ids[r], ids[c])
+			style := t.Renderer.NewStyle().Foreground(color)
+			if r == c {
+				mark, style = "╲", subtle
+			}
+			if r == m.row && c == m.col {
+				style = style.Background(t.Highlight).Bold(true)
+			}
+			cells.WriteString(style.Render(fmt.Sprintf("%*s", dsmCellWidth, mark)))
+		}
+		lines = append(lines, label+cells.String())
+	}
+
+	lines = append(lines, "", m.describeCursor())
+	legend := "■ blocks  ◆ parent  ○ related  • other"
+	if m.epics {
+		legend = "n issues in the row epic wait on the column epic"
+	}
+	lines = append(lines, subtle.Render(legend+"  │  marks above the diagonal are cycles"))
+	return strings.Join(lines, "\n")
+}
+
+// describeCursor spells out the dependency under the cursor
+func (m *DSMModel) describeCursor() string {
+	row, col := m.Selected()
+	t := m.theme
+	idStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	name := func(id string) string {
+		s := idStyle.Render(id)
+		if title := m.title(id); title != "" {
+			s += " " + truncateToWidth(title, max(m.width/4, 10), "…")
+		}
+		return s
+	}
+
+	switch {
+	case row == col:
+		return name(row)
+	case m.epics:
+		n := m.coupling.Count(row, col)
+		if n == 0 {
+			return name(row) + subtle.Render(" doesn't wait on ") + name(col)
+		}
+		return name(row) + subtle.Render(" waits on ") + name(col) + subtle.Render(fmt.Sprintf(" (%d dependencies)", n))
+	}
+	dt := m.issues.Cell(row, col)
+	if dt == "" {
+		return name(row) + subtle.Render(" doesn't depend on ") + name(col)
+	}
+	return name(row) + t.Renderer.NewStyle().Foreground(edgeColor(dt, t)).Render(" ─"+edgeLabel(dt)+"→ ") + name(col)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDSMViewJumpsBetweenMarks(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "B", Title: "API", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "UI", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "D", Title: "Done", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+
+	press("J")
+	if !m.isDSMView || m.focused != focusDSM {
+		t.Fatalf("expected J to open the matrix")
+	}
+	if out := m.View(); !strings.Contains(out, "3 open issues") || !strings.Contains(out, "■") {
+		t.Errorf("expected the three open issues with blocking marks:\n%s", out)
+	}
+
+	press("n")
+	if row, col := m.dsmView.Selected(); row != "B" || col != "A" {
+		t.Fatalf("expected the first mark at B→A, got %s→%s", row, col)
+	}
+	press("n")
+	if row, col := m.dsmView.Selected(); row != "C" || col != "B" {
+		t.Fatalf("expected the next mark at C→B, got %s→%s", row, col)
+	}
+	if out := m.View(); !strings.Contains(out, "─blocks→") {
+		t.Errorf("expected the cursor cell described:\n%s", out)
+	}
+
+	press("o")
+	if m.isDSMView {
+		t.Fatalf("expected o to leave the matrix")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "B" {
+		t.Errorf("expected the column issue B selected, got %v", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DedupResultMsg reports the outcome of closing an issue as a duplicate
+type DedupResultMsg struct {
+	Pair analysis.DuplicatePair
+	Err  error
+}
+
+// runBeadsCLI runs a bd subcommand in dir. It is a variable so tests can stub it.
+var runBeadsCLI = func(dir string, args ...string) error {
+	cmd := exec.Command("bd", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("bd %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("bd %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// DedupCmd links the duplicate to the kept issue with a related dependency and
+// closes it, using the bd CLI from the project that owns beadsPath. The file
+// watcher picks up the change and reloads the list.
+func DedupCmd(beadsPath string, pair analysis.DuplicatePair) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		if err := runBeadsCLI(dir, "dep", "add", pair.Duplicate, pair.Keep, "--type", string(model.DepRelated)); err != nil {
+			return DedupResultMsg{Pair: pair, Err: err}
+		}
+		err := runBeadsCLI(dir, "close", pair.Duplicate, "--reason", "Duplicate of "+pair.Keep)
+		return DedupResultMsg{Pair: pair, Err: err}
+	}
+}
+
+// beadsProjectDir returns the project that owns beadsPath, where bd must run
+func beadsProjectDir(beadsPath string) string {
+	if beadsPath == "" {
+		return ""
+	}
+	// beadsPath is <project>/.beads/<file>.jsonl
+	return filepath.Dir(filepath.Dir(beadsPath))
+}
+
+// duplicatePairKey identifies a pair regardless of which side is kept
+func duplicatePairKey(a, b string) string {
+	if b < a {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+// DuplicatesModel is the review panel for likely duplicate issues
+type DuplicatesModel struct {
+	pairs        []analysis.DuplicatePair
+	issueMap     map[string]*model.Issue
+	dismissed    map[string]bool // Shared with the parent so dismissals survive reopening
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewDuplicatesModel finds duplicate candidates, hiding pairs in dismissed
+func NewDuplicatesModel(issues []model.Issue, issueMap map[string]*model.Issue, dismissed map[string]bool, theme Theme) DuplicatesModel {
+	var pairs []analysis.DuplicatePair
+	for _, p := range analysis.FindDuplicateCandidates(issues, analysis.DefaultDuplicateThreshold) {
+		if !dismissed[duplicatePairKey(p.Keep, p.Duplicate)] {
+			pairs = append(pairs, p)
+		}
+	}
+	return DuplicatesModel{
+		pairs:     pairs,
+		issueMap:  issueMap,
+		dismissed: dismissed,
+		theme:     theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *DuplicatesModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *DuplicatesModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *DuplicatesModel) MoveDown() {
+	if m.selected < len(m.pairs)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// SelectedPair returns the highlighted pair and whether there is one
+func (m *DuplicatesModel) SelectedPair() (analysis.DuplicatePair, bool) {
+	if m.selected < 0 || m.selected >= len(m.pairs) {
+		return analysis.DuplicatePair{}, false
+	}
+	return m.pairs[m.selected], true
+}
+
+// Swap flips which issue of the selected pair is kept
+func (m *DuplicatesModel) Swap() {
+	if p, ok := m.SelectedPair(); ok {
+		m.pairs[m.selected].Keep, m.pairs[m.selected].Duplicate = p.Duplicate, p.Keep
+	}
+}
+
+// Dismiss marks the selected pair as not a duplicate and hides it
+func (m *DuplicatesModel) Dismiss() {
+	if p, ok := m.SelectedPair(); ok {
+		if m.dismissed != nil {
+			m.dismissed[duplicatePairKey(p.Keep, p.Duplicate)] = true
+		}
+		m.Remove(p)
+	}
+}
+
+// Remove drops a pair (in either orientation) from the panel
+func (m *DuplicatesModel) Remove(pair analysis.DuplicatePair) {
+	key := duplicatePairKey(pair.Keep, pair.Duplicate)
+	for i, p := range m.pairs {
+		if duplicatePairKey(p.Keep, p.Duplicate) == key {
+			m.pairs = append(m.pairs[:i], m.pairs[i+1:]...)
+			break
+		}
+	}
+	if m.selected >= len(m.pairs) {
+		m.selected = max(len(m.pairs)-1, 0)
+	}
+	m.ensureVisible()
+}
+
+// visibleRows returns how many pairs fit below the header and above the hint line
+func (m *DuplicatesModel) visibleRows() int {
+	return max((m.height-4)/3, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected pair on screen
+func (m *DuplicatesModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// issueTitle returns the title of id, or "" if it isn't loaded
+func (m *DuplicatesModel) issueTitle(id string) string {
+	if issue, ok := m.issueMap[id]; ok {
+		return issue.Title
+	}
+	return ""
+}
+
+// Render renders the duplicate review panel
+func (m *DuplicatesModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🪞 DUPLICATE CANDIDATES  │  %d pairs  │  ≥%.0f%% similar",
+		len(m.pairs), analysis.DefaultDuplicateThreshold*100)))
+	lines = append(lines, "")
+
+	if len(m.pairs) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No likely duplicates among open issues."))
+		return strings.Join(lines, "\n")
+	}
+
+	keepStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	dupStyle := t.Renderer.NewStyle().Foreground(t.Closed).Bold(true)
+	titleWidth := max(m.width-30, 10)
+
+	end := min(m.scrollOffset+m.visibleRows(), len(m.pairs))
+	for i := m.scrollOffset; i < end; i++ {
+		p := m.pairs[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		lines = append(lines,
+			rowStyle.Render(fmt.Sprintf("%s%3.0f%%  ", prefix, p.Score*100))+keepStyle.Render("keep ")+
+				rowStyle.Render(fmt.Sprintf("%-10s %s", p.Keep, truncateToWidth(m.issueTitle(p.Keep), titleWidth, "…"))),
+			"        "+dupStyle.Render("dup  ")+
+				rowStyle.Render(fmt.Sprintf("%-10s %s", p.Duplicate, truncateToWidth(m.issueTitle(p.Duplicate), titleWidth, "…"))),
+			"")
+	}
+	if len(m.pairs) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.pairs)-end)))
+	}
+	lines = append(lines, subtle.Render("  x close dup (bd) • r swap keep/dup • n not a duplicate • = compare"))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func duplicateTestIssues() []model.Issue {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{ID: "A", Title: "Login fails on Safari", Status: model.StatusOpen, CreatedAt: base},
+		{ID: "B", Title: "Login failing on Safari", Status: model.StatusOpen, CreatedAt: base.Add(time.Hour)},
+		{ID: "C", Title: "Add dark mode toggle", Status: model.StatusOpen, CreatedAt: base},
+	}
+}
+
+func TestDuplicatesRenderSwapDismiss(t *testing.T) {
+	issues := duplicateTestIssues()
+	issueMap := map[string]*model.Issue{}
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	dismissed := map[string]bool{}
+	d := NewDuplicatesModel(issues, issueMap, dismissed, DefaultTheme(lipgloss.NewRenderer(nil)))
+	d.SetSize(100, 20)
+	out := d.Render()
+	for _, want := range []string{"1 pairs", "Login fails on Safari", "Login failing on Safari"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in duplicates view:\n%s", want, out)
+		}
+	}
+
+	d.Swap()
+	if p, _ := d.SelectedPair(); p.Keep != "B" || p.Duplicate != "A" {
+		t.Fatalf("expected swap to keep B, got %+v", p)
+	}
+
+	d.Dismiss()
+	if _, ok := d.SelectedPair(); ok {
+		t.Fatalf("expected no pairs after dismiss")
+	}
+	// Dismissed pairs stay hidden when the panel is rebuilt
+	d = NewDuplicatesModel(issues, issueMap, dismissed, DefaultTheme(lipgloss.NewRenderer(nil)))
+	d.SetSize(100, 20)
+	if !strings.Contains(d.Render(), "No likely duplicates") {
+		t.Fatalf("expected dismissed pair to stay hidden")
+	}
+}
+
+func TestDuplicatesDedupRunsBeadsCLI(t *testing.T) {
+	var calls []string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, dir+" "+strings.Join(args, " "))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	m := NewModel(duplicateTestIssues(), nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	m = updated.(Model)
+	if !m.isDuplicatesView || m.focused != focusDuplicates {
+		t.Fatalf("expected duplicates view focused")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected dedup command")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	want := []string{"proj dep add B A --type related", "proj close B --reason Duplicate of A"}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected bd calls:\n%s", strings.Join(calls, "\n"))
+	}
+	if m.statusMsg != "Closed B as duplicate of A" || m.statusIsError {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if _, ok := m.duplicatesView.SelectedPair(); ok {
+		t.Fatalf("expected resolved pair removed from panel")
+	}
+
+	// Failures surface as an error status
+	updated, _ = m.Update(DedupResultMsg{Err: errors.New("bd close: not found")})
+	m = updated.(Model)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "not found") {
+		t.Fatalf("expected error status, got %q", m.statusMsg)
+	}
+}
+
+func TestBeadsWritesRefusedWithoutBeadsFile(t *testing.T) {
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		t.Fatalf("bd %s should not run without a beads file", strings.Join(args, " "))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	m := NewModel(duplicateTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	if cmd != nil || !m.statusIsError || !strings.Contains(m.statusMsg, "without a beads file") {
+		t.Fatalf("expected the dedup to be refused, got cmd=%v status %q", cmd != nil, m.statusMsg)
+	}
+}
+
+func TestWriteKeysDoNothingWhenReadOnly(t *testing.T) {
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		t.Fatalf("bd %s should not run on Markdown issues", strings.Join(args, " "))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	for _, tt := range []struct {
+		keys  string
+		focus focus // Where the keys leave focus, with no form opened
+	}{
+		{"n", focusList}, {"w", focusList}, {"m", focusList}, {"Xx", focusDuplicates},
+	} {
+		m := NewModel(duplicateTestIssues(), nil, "")
+		m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // Refused by the flag alone
+		m.SetReadOnly("loaded from Markdown files")
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+		m = updated.(Model)
+		var cmd tea.Cmd
+		for _, r := range tt.keys {
+			updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			m = updated.(Model)
+		}
+		if cmd != nil || !m.statusIsError || !strings.Contains(m.statusMsg, "read-only when loaded from Markdown files") {
+			t.Errorf("%s: expected the edit refused, got cmd=%v status %q", tt.keys, cmd != nil, m.statusMsg)
+		}
+		if m.focused != tt.focus {
+			t.Errorf("%s: expected focus on %v, got %v", tt.keys, tt.focus, m.focused)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSetFileWatchPolls(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "beads.jsonl")
+	if err := os.WriteFile(beads, []byte(`{"id":"A","title":"One","status":"open"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel([]model.Issue{{ID: "A", Title: "One", Status: model.StatusOpen}}, nil, beads)
+	defer m.Stop()
+
+	if err := m.SetFileWatch("inotify", 0, false); err == nil {
+		t.Error("expected an unknown watch mode to be refused")
+	}
+	if err := m.SetFileWatch("poll", 100*time.Millisecond, true); err != nil {
+		t.Fatal(err)
+	}
+	if !m.watcher.IsPolling() || m.watcher.PollInterval() != 100*time.Millisecond {
+		t.Fatalf("expected polling every 100ms, got polling=%v every %s", m.watcher.IsPolling(), m.watcher.PollInterval())
+	}
+	if m.statusMsg != "" {
+		t.Errorf("expected no status for configured polling, got %q", m.statusMsg)
+	}
+
+	if err := os.WriteFile(beads, []byte(`{"id":"A","title":"Two","status":"open"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-m.watcher.Changed():
+	case <-time.After(2 * time.Second):
+		t.Error("expected the polling watcher to notice the change")
+	}
+}
@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FlowDimension selects the breakdown shown under the flow histogram
+type FlowDimension int
+
+const (
+	FlowByType FlowDimension = iota
+	FlowByPriority
+	FlowByAssignee
+	flowDimensionCount // Sentinel for cycling
+)
+
+// String returns the display name of the dimension
+func (d FlowDimension) String() string {
+	switch d {
+	case FlowByPriority:
+		return "priority"
+	case FlowByAssignee:
+		return "assignee"
+	default:
+		return "type"
+	}
+}
+
+// FlowModel shows lead and cycle time percentiles, a distribution histogram and
+// a breakdown by type, priority or assignee
+type FlowModel struct {
+	metrics   analysis.FlowMetrics
+	showCycle bool // Histogram and breakdown use cycle time instead of lead time
+	dimension FlowDimension
+	width     int
+	height    int
+	theme     Theme
+}
+
+// NewFlowModel computes flow metrics for the given issues
+func NewFlowModel(issues []model.Issue, theme Theme) FlowModel {
+	return FlowModel{
+		metrics: analysis.ComputeFlowMetrics(issues),
+		theme:   theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *FlowModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ToggleMetric switches the histogram and breakdown between lead and cycle time
+func (m *FlowModel) ToggleMetric() {
+	m.showCycle = !m.showCycle
+}
+
+// CycleDimension advances the breakdown to the next dimension
+func (m *FlowModel) CycleDimension() {
+	m.dimension = (m.dimension + 1) % flowDimensionCount
+}
+
+// Dimension returns the active breakdown dimension
+func (m *FlowModel) Dimension() FlowDimension {
+	return m.dimension
+}
+
+// metricName returns the label of the selected metric
+func (m *FlowModel) metricName() string {
+	if m.showCycle {
+		return "cycle time"
+	}
+	return "lead time"
+}
+
+// Render renders the flow metrics view
+func (m *FlowModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	fm := m.metrics
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	sectionStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	header := fmt.Sprintf("⏱  LEAD & CYCLE TIME  │  %d closed  │  %d with started_at", fm.Lead.Count, fm.Cycle.Count)
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	if fm.Lead.Count == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No closed issues yet. Lead time needs created_at and closed_at; cycle time also needs started_at."))
+		return strings.Join(lines, "\n")
+	}
+
+	// Percentile table
+	lines = append(lines, sectionStyle.Render("Percentiles"))
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-11s %5s %7s %7s %7s %7s %7s", "", "n", "mean", "p50", "p85", "p95", "max")))
+	lines = append(lines, m.statsRow("Lead", fm.Lead, !m.showCycle))
+	lines = append(lines, m.statsRow("Cycle", fm.Cycle, m.showCycle))
+	lines = append(lines, "")
+
+	// Time spent in each status before closing
+	if len(fm.InStatus) > 1 {
+		lines = append(lines, sectionStyle.Render("Time in Status"))
+		for _, d := range fm.InStatus {
+			lines = append(lines, m.statsRow(string(d.Status), d.Stats, false))
+		}
+		lines = append(lines, "")
+	}
+
+	// Histogram of the selected metric
+	durations := fm.LeadTimes()
+	if m.showCycle {
+		durations = fm.CycleTimes()
+	}
+	counts := analysis.DurationHistogram(durations, analysis.FlowBuckets)
+	lines = append(lines, sectionStyle.Render("Distribution ("+m.metricName()+")"))
+	lines = append(lines, m.renderHistogram(counts)...)
+	lines = append(lines, "")
+
+	// Breakdown, trimmed to the remaining height
+	lines = append(lines, sectionStyle.Render(fmt.Sprintf("By %s (%s)", m.dimension, m.metricName())))
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-20s %5s %7s %7s %7s", "", "n", "p50", "p85", "max")))
+	rows := m.breakdown()
+	room := m.height - len(lines)
+	if room < 1 {
+		room = 1
+	}
+	for i, b := range rows {
+		if i == room-1 && len(rows) > room {
+			lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(rows)-i)))
+			break
+		}
+		s := b.Lead
+		if m.showCycle {
+			s = b.Cycle
+		}
+		if s.Count == 0 {
+			lines = append(lines, fmt.Sprintf("  %s %5d %7s", fitWidth(b.Key, 20), 0, "—"))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s %5d %7s %7s %7s",
+			fitWidth(b.Key, 20), s.Count, FormatSpan(s.P50), FormatSpan(s.P85), FormatSpan(s.Max)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// statsRow renders one percentile table row, highlighted when it is the selected metric
+func (m *FlowModel) statsRow(name string, s analysis.DurationStats, selected bool) string {
+	t := m.theme
+	prefix := "  "
+	style := t.Renderer.NewStyle()
+	if selected {
+		prefix = "▸ "
+		style = style.Foreground(t.Primary).Bold(true)
+	}
+	if s.Count == 0 {
+		return style.Render(fmt.Sprintf("%s%-11s %5d %7s", prefix, name, 0, "—"))
+	}
+	return style.Render(fmt.Sprintf("%s%-11s %5d %7s %7s %7s %7s %7s", prefix, name, s.Count,
+		FormatSpan(s.Mean), FormatSpan(s.P50), FormatSpan(s.P85), FormatSpan(s.P95), FormatSpan(s.Max)))
+}
+
+// renderHistogram draws one horizontal bar per FlowBuckets bin
+func (m *FlowModel) renderHistogram(counts []int) []string {
+	t := m.theme
+	maxCount := 0
+	for _, c := range counts {
+		maxCount = max(maxCount, c)
+	}
+	barWidth := m.width - 24
+	if barWidth > 50 {
+		barWidth = 50
+	}
+	if barWidth < 5 {
+		barWidth = 5
+	}
+
+	bounds := analysis.FlowBuckets
+	barStyle := t.Renderer.NewStyle().Foreground(t.Primary)
+	lines := make([]string, 0, len(counts))
+	for i, c := range counts {
+		var label string
+		if i < len(bounds) {
+			label = "≤" + FormatSpan(bounds[i])
+		} else {
+			label = ">" + FormatSpan(bounds[len(bounds)-1])
+		}
+		n := 0
+		if maxCount > 0 {
+			n = c * barWidth / maxCount
+		}
+		if c > 0 && n == 0 {
+			n = 1
+		}
+		lines = append(lines, fmt.Sprintf("  %6s │%s %d", label, barStyle.Render(repeatToWidth("█", n)), c))
+	}
+	return lines
+}
+
+// breakdown returns the rows for the active dimension
+func (m *FlowModel) breakdown() []analysis.FlowBreakdown {
+	switch m.dimension {
+	case FlowByPriority:
+		return m.metrics.ByPriority
+	case FlowByAssignee:
+		return m.metrics.ByAssignee
+	default:
+		return m.metrics.ByType
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func flowTestIssues() []model.Issue {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		ts := base.AddDate(0, 0, days)
+		return &ts
+	}
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusClosed, IssueType: model.TypeBug, Priority: 1, Assignee: "ann",
+			CreatedAt: base, StartedAt: at(2), ClosedAt: at(3)},
+		{ID: "B", Title: "B", Status: model.StatusClosed, IssueType: model.TypeFeature, Priority: 2,
+			CreatedAt: base, ClosedAt: at(40)},
+		{ID: "C", Title: "C", Status: model.StatusOpen, IssueType: model.TypeTask, CreatedAt: base},
+	}
+}
+
+func TestFlowRender(t *testing.T) {
+	f := NewFlowModel(flowTestIssues(), DefaultTheme(lipgloss.NewRenderer(nil)))
+	f.SetSize(100, 40)
+
+	out := f.Render()
+	for _, want := range []string{"2 closed", "1 with started_at", "Distribution (lead time)", "≤4d", ">90d", "By type", "bug", "feature"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in flow view", want)
+		}
+	}
+
+	f.ToggleMetric()
+	f.CycleDimension()
+	f.CycleDimension()
+	out = f.Render()
+	if !strings.Contains(out, "By assignee (cycle time)") || !strings.Contains(out, "unassigned") {
+		t.Fatalf("expected assignee cycle breakdown, got:\n%s", out)
+	}
+}
+
+func TestFlowRenderEmpty(t *testing.T) {
+	f := NewFlowModel([]model.Issue{{ID: "A", Status: model.StatusOpen}}, DefaultTheme(lipgloss.NewRenderer(nil)))
+	f.SetSize(80, 20)
+	if !strings.Contains(f.Render(), "No closed issues yet") {
+		t.Fatalf("expected empty state")
+	}
+}
+
+func TestFlowViewKeys(t *testing.T) {
+	m := NewModel(flowTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if !m.isFlowView || m.focused != focusFlow {
+		t.Fatalf("expected flow view focused")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(Model)
+	if m.flowView.Dimension() != FlowByPriority {
+		t.Fatalf("expected d to switch breakdown to priority")
+	}
+	if !strings.Contains(m.View(), "lead/cycle") {
+		t.Fatalf("expected flow key hints in footer")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = updated.(Model)
+	if m.isFlowView || !m.isBoardView {
+		t.Fatalf("expected board toggle to replace flow view")
+	}
+}
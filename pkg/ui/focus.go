@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxFocusHops is the widest hop limit ctrl+f offers before "all"
+const maxFocusHops = 3
+
+// subgraphFocus narrows every view to the issues around one: its blockers
+// and dependents up to hops links away, on top of the filter or recipe
+type subgraphFocus struct {
+	root string
+	hops int // 0 for no limit
+}
+
+// label is the focus as shown in the status bar, e.g. "bv-12 ±2"
+func (f subgraphFocus) label() string {
+	if f.hops <= 0 {
+		return f.root + " ±all"
+	}
+	return fmt.Sprintf("%s ±%d", f.root, f.hops)
+}
+
+// viewSelection returns the issue selected in the current view, the one
+// ctrl+f and alt+e act on
+func (m *Model) viewSelection() *model.Issue {
+	switch {
+	case m.isGraphView:
+		return m.graphView.SelectedIssue()
+	case m.isBoardView:
+		return m.board.SelectedIssue()
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		return m.issueMap[item.Issue.ID]
+	}
+	return nil
+}
+
+// cycleSubgraphFocus steps the focus on the selected issue through 1, 2 and
+// 3 hops, all connected issues, then off. Focusing another issue keeps the
+// hop limit.
+func (m *Model) cycleSubgraphFocus() {
+	issue := m.viewSelection()
+	switch {
+	case issue == nil && m.focus == nil:
+		m.statusMsg = "Select an issue to focus on"
+		m.statusIsError = true
+		return
+	case m.focus == nil:
+		m.focus = &subgraphFocus{root: issue.ID, hops: 1}
+	case issue != nil && issue.ID != m.focus.root:
+		m.focus.root = issue.ID
+	case m.focus.hops == 0:
+		m.focus = nil
+	case m.focus.hops == maxFocusHops:
+		m.focus.hops = 0
+	default:
+		m.focus.hops++
+	}
+	m.applyScope()
+
+	if m.focus == nil {
+		m.statusMsg = "Subgraph focus off"
+	} else {
+		m.statusMsg = fmt.Sprintf("Focused on %s: %d issues", m.focus.label(), len(m.scopeSet()))
+	}
+	m.statusIsError = false
+}
+
+// toggleEpicScope scopes every view, metric and export to the selected
+// epic's subtree, or to the epic the selected issue belongs to, and clears
+// the scope when one is set
+func (m *Model) toggleEpicScope() {
+	if m.epicScope != "" {
+		m.epicScope = ""
+		m.applyScope()
+		m.statusMsg = "Epic scope cleared"
+		m.statusIsError = false
+		return
+	}
+	issue := m.viewSelection()
+	epic := ""
+	if issue != nil {
+		epic = parentEpicID(*issue, m.issueMap)
+	}
+	if epic == "" {
+		m.statusMsg = "Select an epic, or an issue in one, to scope to"
+		m.statusIsError = true
+		return
+	}
+	m.epicScope = epic
+	m.applyScope()
+	m.statusMsg = fmt.Sprintf("Scoped to epic %s: %d issues (alt+e to clear)", epic, len(m.scopeSet()))
+	m.statusIsError = false
+}
+
+// toggleMine narrows every view, metric and export to the issues assigned
+// to the current user (SetNotifyAssignee), reporting how many are ready and
+// when the schedule has them done, and widens back to everyone's
+func (m *Model) toggleMine() {
+	if m.mineOnly {
+		m.mineOnly = false
+		m.applyScope()
+		m.statusMsg = "Showing everyone's issues"
+		m.statusIsError = false
+		return
+	}
+	if m.notifyAssignee == "" {
+		m.statusMsg = "No current user: set user.name in the config or pass --me"
+		m.statusIsError = true
+		return
+	}
+	m.mineOnly = true
+	m.applyScope()
+	m.statusMsg = m.mineSummary(time.Now())
+	m.statusIsError = false
+}
+
+// mineSummary describes the current user's open work: how much of it can be
+// worked on now and when the schedule, which knows about everyone else's
+// work and the blockers between, projects the last of it done
+func (m *Model) mineSummary(now time.Time) string {
+	open, ready := 0, 0
+	for _, issue := range m.issues {
+		if issue.Status.IsClosed() || !m.isMine(issue) {
+			continue
+		}
+		open++
+		if issue.Status != model.StatusBlocked && len(m.openBlockers(issue)) == 0 {
+			ready++
+		}
+	}
+	msg := fmt.Sprintf("Mine (@%s): %d open, %d ready", m.notifyAssignee, open, ready)
+
+	var finish time.Time
+	schedule := analysis.BuildSchedule(m.issues, analysis.ScheduleOptions{Now: now, Limits: analysis.CurrentWIPLimits()})
+	for _, item := range schedule.Items {
+		if !item.Suggested && m.isMine(model.Issue{Assignee: item.Assignee}) && item.Finish.After(finish) {
+			finish = item.Finish
+		}
+	}
+	if !finish.IsZero() {
+		msg += ", all done by " + timefmt.Day(finish) + " on the schedule"
+	}
+	return msg + " (@ for everyone)"
+}
+
+// scopeSet returns the issues inside the subgraph focus, epic scope and
+// mine toggle, or nil when none is set. A focus or scope whose issue is gone
+// is dropped.
+func (m *Model) scopeSet() map[string]bool {
+	if m.focus != nil {
+		if _, ok := m.issueMap[m.focus.root]; !ok {
+			m.focus = nil
+		}
+	}
+	if m.epicScope != "" {
+		if _, ok := m.issueMap[m.epicScope]; !ok {
+			m.epicScope = ""
+		}
+	}
+
+	var set map[string]bool
+	narrow := func(to map[string]bool) {
+		if set == nil {
+			set = to
+			return
+		}
+		for id := range set {
+			if !to[id] {
+				delete(set, id)
+			}
+		}
+	}
+	if m.epicScope != "" {
+		narrow(analysis.Subtree(m.issues, m.epicScope))
+	}
+	if m.focus != nil {
+		narrow(analysis.Subgraph(m.issues, m.focus.root, m.focus.hops))
+	}
+	if m.mineOnly && m.notifyAssignee != "" {
+		mine := make(map[string]bool)
+		for _, issue := range m.issues {
+			if m.isMine(issue) {
+				mine[issue.ID] = true
+			}
+		}
+		narrow(mine)
+	}
+	return set
+}
+
+// scopedIssues returns the issues inside the focus, epic scope and mine
+// toggle, which views, metrics and exports work from
+func (m *Model) scopedIssues() []model.Issue {
+	set := m.scopeSet()
+	if set == nil {
+		return m.issues
+	}
+	issues := make([]model.Issue, 0, len(set))
+	for _, issue := range m.issues {
+		if set[issue.ID] {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// scopedInsights restricts ins to the focus, epic scope and mine toggle, if any
+func (m *Model) scopedInsights(ins analysis.Insights) analysis.Insights {
+	if ids := m.scopeSet(); ids != nil {
+		return ins.Restrict(ids)
+	}
+	return ins
+}
+
+// scopeLabel describes the focus, epic scope and mine toggle for the status
+// bar and tab labels, or "" when none is set
+func (m *Model) scopeLabel() string {
+	return scopeLabel(m.focus, m.epicScope, m.mineName())
+}
+
+// mineName returns the current user while the mine toggle is on, else ""
+func (m *Model) mineName() string {
+	if !m.mineOnly {
+		return ""
+	}
+	return m.notifyAssignee
+}
+
+func scopeLabel(focus *subgraphFocus, epic, mine string) string {
+	var parts []string
+	if mine != "" {
+		parts = append(parts, "👤 "+mine)
+	}
+	if epic != "" {
+		parts = append(parts, "📦 "+epic)
+	}
+	if focus != nil {
+		parts = append(parts, "🎯 "+focus.label())
+	}
+	return strings.Join(parts, " ")
+}
+
+// applyScope re-applies the recipe or filter within the new focus or epic
+// scope and refreshes the insights panel to match
+func (m *Model) applyScope() {
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	} else {
+		m.applyFilter()
+	}
+	if m.analysis != nil && m.focused == focusInsights {
+		m.insightsPanel = NewInsightsModel(m.scopedInsights(m.analysis.GenerateInsights(len(m.issues))), m.issueMap, m.theme)
+		m.insightsPanel.SetSize(m.width, max(m.height-2, 3))
+	}
+}
+
+// reopenScopedView rebuilds the analysis view on screen, if any, from the
+// issues now in scope. The list, board, graph and insights follow the scope
+// through applyScope.
+func (m Model) reopenScopedView() (Model, tea.Cmd) {
+	switch key := m.currentViewKey(); key {
+	case "a", "M", "B", "F", "V", "W", "X", "U", "K", "J", "Q", "alt+s", "alt+a", "alt+l":
+		status, isErr := m.statusMsg, m.statusIsError
+		m, _ = m.pressDefaultKey(key) // Close
+		m, cmd := m.pressDefaultKey(key)
+		m.statusMsg, m.statusIsError = status, isErr
+		return m, cmd
+	}
+	return m, nil
+}
@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Focus sessions run for defaultFocusLength unless changed with + and -
+const (
+	defaultFocusLength = 25 * time.Minute
+	focusLengthStep    = 5 * time.Minute
+)
+
+// FocusTickMsg counts down a focus session; ticks from an earlier session
+// are ignored
+type FocusTickMsg struct {
+	Session int
+}
+
+// focusTickCmd schedules the next second of session's countdown
+func focusTickCmd(session int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return FocusTickMsg{Session: session}
+	})
+}
+
+// StatusChangedMsg reports the outcome of changing an issue's status
+type StatusChangedMsg struct {
+	ID     string
+	Status model.Status
+	Err    error
+}
+
+// SetStatusCmd changes the status of base, the issue as loaded, using the
+// bd CLI: bd close for closed, bd update --status otherwise. It won't
+// overwrite a status set since (see writeIssueCmd).
+func SetStatusCmd(beadsPath string, base model.Issue, status model.Status) tea.Cmd {
+	ours := base
+	ours.Status = status
+	return writeIssueCmd(beadsPath, issueEdit{base: base, ours: ours, done: func(err error) tea.Msg {
+		return StatusChangedMsg{ID: base.ID, Status: status, Err: err}
+	}})
+}
+
+// FocusModeModel is a pomodoro on one ready issue: a countdown with the
+// rest of the UI out of the way, then a prompt to log the time or move the
+// issue on
+type FocusModeModel struct {
+	issue   model.Issue
+	session int // Tells this session's ticks from earlier ones
+	started time.Time
+	length  time.Duration
+	ended   time.Time // When the countdown finished or was stopped; zero while it runs
+	logged  bool      // The session's time is in the worklog
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewFocusModeModel creates the focus mode screen
+func NewFocusModeModel(theme Theme) FocusModeModel {
+	return FocusModeModel{length: defaultFocusLength, theme: theme}
+}
+
+// SetSize updates the screen dimensions
+func (m *FocusModeModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Start begins a session on issue, keeping the length of the last one
+func (m *FocusModeModel) Start(issue model.Issue, now time.Time) tea.Cmd {
+	m.issue = issue
+	m.session++
+	m.started = now
+	m.ended = time.Time{}
+	m.logged = false
+	return focusTickCmd(m.session)
+}
+
+// Running reports whether the countdown is still going
+func (m *FocusModeModel) Running() bool {
+	return m.ended.IsZero()
+}
+
+// Remaining returns the time left at now
+func (m *FocusModeModel) Remaining(now time.Time) time.Duration {
+	return max(m.started.Add(m.length).Sub(now), 0)
+}
+
+// Elapsed returns the time the session has run, up to its end
+func (m *FocusModeModel) Elapsed(now time.Time) time.Duration {
+	if !m.Running() {
+		now = m.ended
+	}
+	return min(max(now.Sub(m.started), 0), m.length)
+}
+
+// Tick advances the countdown, reporting whether it just ran out
+func (m *FocusModeModel) Tick(now time.Time) bool {
+	if !m.Running() || m.Remaining(now) > 0 {
+		return false
+	}
+	m.ended = m.started.Add(m.length)
+	return true
+}
+
+// Stop ends the countdown early
+func (m *FocusModeModel) Stop(now time.Time) {
+	if m.Running() {
+		m.ended = now
+	}
+}
+
+// Adjust lengthens or shortens the session by a step, keeping at least one
+func (m *FocusModeModel) Adjust(steps int) {
+	m.length = max(m.length+time.Duration(steps)*focusLengthStep, focusLengthStep)
+}
+
+// Entry returns the worklog entry for the finished session
+func (m *FocusModeModel) Entry(author string) worklog.Entry {
+	end := m.started.Add(m.Elapsed(m.ended))
+	return worklog.Entry{IssueID: m.issue.ID, Author: author, Start: m.started, End: &end}
+}
+
+// formatCountdown renders d as minutes and seconds, "24:59"
+func formatCountdown(d time.Duration) string {
+	secs := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+// View renders the focus screen: the issue, the countdown, and once it is
+// over what to do next
+func (m *FocusModeModel) View(now time.Time) string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	keyStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	width := min(max(m.width-12, 30), 72)
+
+	lines := []string{
+		subtle.Render("🍅 FOCUS"),
+		"",
+		titleStyle.Render(m.issue.ID) + "  " + truncateToWidth(m.issue.Title, width-displayWidth(m.issue.ID)-2, "…"),
+	}
+	if checklist := analysis.ParseChecklist(m.issue.Description); len(checklist) > 0 {
+		lines = append(lines, subtle.Render("checklist ")+renderChecklistProgress(t, checklist, 10))
+	}
+	lines = append(lines, "")
+
+	barWidth := width
+	if m.Running() {
+		remaining := m.Remaining(now)
+		filled := int((m.length - remaining) * time.Duration(barWidth) / m.length)
+		lines = append(lines,
+			t.Renderer.NewStyle().Bold(true).Render(formatCountdown(remaining))+subtle.Render(" of "+worklog.FormatDuration(m.length)),
+			t.Renderer.NewStyle().Foreground(t.InProgress).Render(repeatToWidth("█", filled))+
+				t.Renderer.NewStyle().Foreground(t.Secondary).Render(repeatToWidth("░", barWidth-filled)),
+			"",
+			subtle.Italic(true).Render("+/-: 5 more / fewer minutes • esc: stop early • ctrl+c: quit"))
+	} else {
+		elapsed := worklog.FormatDuration(m.Elapsed(now))
+		heading := "Time's up: " + elapsed + " on " + m.issue.ID
+		if m.Elapsed(now) < m.length {
+			heading = "Stopped after " + elapsed + " on " + m.issue.ID
+		}
+		lines = append(lines, titleStyle.Render(heading), "")
+		logLine := keyStyle.Render("l") + "  log " + elapsed + " to the worklog"
+		if m.logged {
+			logLine = subtle.Render("✓  logged " + elapsed)
+		}
+		lines = append(lines,
+			logLine,
+			keyStyle.Render("i")+"  mark in progress",
+			keyStyle.Render("c")+"  close the issue",
+			keyStyle.Render("r")+"  another round",
+			keyStyle.Render("esc")+" back to the list")
+	}
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 3).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// isReady reports whether issue can be worked on: not closed or blocked, and
+// no open blockers
+func (m *Model) isReady(issue model.Issue) bool {
+	if issue.Status == model.StatusClosed || issue.Status == model.StatusBlocked {
+		return false
+	}
+	for _, dep := range issue.Dependencies {
+		if dep.Type.IsBlocking() {
+			if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// startFocusMode pins the selected issue, or the first ready one in the list
+// when it isn't ready, and starts a focus session on it
+func (m *Model) startFocusMode() tea.Cmd {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return nil
+	}
+	if !m.isReady(issue) {
+		found := false
+		for _, listItem := range m.list.Items() {
+			if item, ok := listItem.(IssueItem); ok && m.isReady(item.Issue) {
+				issue, found = item.Issue, true
+				break
+			}
+		}
+		if !found {
+			m.statusMsg = "❌ No ready issue to focus on"
+			m.statusIsError = true
+			return nil
+		}
+	}
+	m.showFocusMode = true
+	m.focusMode.SetSize(m.width, m.height)
+	return m.focusMode.Start(issue, time.Now())
+}
+
+// handleFocusModeKeys handles keyboard input in focus mode, which takes
+// every key so nothing else interrupts
+func (m Model) handleFocusModeKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	f := &m.focusMode
+	now := time.Now()
+	if f.Running() {
+		switch msg.String() {
+		case "+", "=":
+			f.Adjust(1)
+		case "-":
+			f.Adjust(-1)
+		case "esc":
+			f.Stop(now)
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.showFocusMode = false
+		m.selectIssueInList(f.issue.ID)
+		m.updateViewportContent()
+	case "r":
+		return m, f.Start(f.issue, now)
+	case "l":
+		if f.logged {
+			break
+		}
+		if m.worklog == nil {
+			m.statusMsg = "❌ No worklog: time tracking needs a project directory"
+			m.statusIsError = true
+			break
+		}
+		m.worklog.Entries = append(m.worklog.Entries, f.Entry(m.notifyAssignee))
+		if err := m.worklog.Save(); err != nil {
+			m.worklog.Entries = m.worklog.Entries[:len(m.worklog.Entries)-1]
+			m.statusMsg = fmt.Sprintf("❌ Saving the worklog failed: %v", err)
+			m.statusIsError = true
+			break
+		}
+		f.logged = true
+		m.statusMsg = fmt.Sprintf("⏱ Logged %s on %s", worklog.FormatDuration(f.Elapsed(now)), f.issue.ID)
+		m.statusIsError = false
+	case "i", "c":
+		if m.refuseEdit() {
+			break
+		}
+		status := model.StatusInProgress
+		if msg.String() == "c" {
+			status = model.StatusClosed
+		}
+		m.statusMsg = fmt.Sprintf("Marking %s %s…", f.issue.ID, status)
+		m.statusIsError = false
+		base := f.issue
+		if issue, ok := m.issueMap[base.ID]; ok {
+			base = *issue
+		}
+		return m, SetStatusCmd(m.beadsPath, base, status)
+	}
+	return m, nil
+}
+
+// focusTimeUp ends the countdown and returns the desktop notification, if
+// enabled
+func (m *Model) focusTimeUp() tea.Cmd {
+	title := fmt.Sprintf("Focus session on %s is over", m.focusMode.issue.ID)
+	m.statusMsg = "🍅 " + title
+	m.statusIsError = false
+	if !m.desktopNotify || m.remoteTerm != nil {
+		return nil
+	}
+	body := m.focusMode.issue.Title
+	return func() tea.Msg {
+		notifyDesktop("bv: "+title, body) // Best effort, like unblock alerts
+		return nil
+	}
+}
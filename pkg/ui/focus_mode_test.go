@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFocusModeSession(t *testing.T) {
+	var calls []string
+	origCLI, origNotify := runBeadsCLI, notifyDesktop
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, strings.Join(args, " "))
+		return nil
+	}
+	var notified []string
+	notifyDesktop = func(title, body string) error {
+		notified = append(notified, title)
+		return nil
+	}
+	defer func() { runBeadsCLI, notifyDesktop = origCLI, origNotify }()
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Blocked work", Status: model.StatusOpen, Priority: 0,
+			Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Title: "Ready work", Status: model.StatusOpen, Priority: 1},
+	}
+	wl, err := worklog.Load(worklog.DefaultPath(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, "")
+	m.beadsPath = filepath.Join("proj", ".beads", "beads.jsonl") // bd writes need a beads file
+	m.SetNotifyAssignee("alice", true)
+	m.SetWorklog(wl)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(Model)
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	key := func(k string) tea.Cmd { return press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}) }
+
+	// A is blocked, so focus mode pins the first ready issue
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "A" {
+		t.Fatalf("expected A selected first, got %v", m.list.SelectedItem())
+	}
+	tick := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}, Alt: true})
+	if !m.showFocusMode || m.focusMode.issue.ID != "B" || tick == nil {
+		t.Fatalf("expected a focus session on B, got %q", m.focusMode.issue.ID)
+	}
+	out := m.View()
+	if !strings.Contains(out, "FOCUS") || !strings.Contains(out, "25:00") || strings.Contains(out, "Blocked work") {
+		t.Fatalf("expected only the focus screen:\n%s", out)
+	}
+	// Keys don't reach the list while focusing
+	key("j")
+	key("+")
+	if m.focusMode.length != 30*time.Minute || !m.showFocusMode {
+		t.Fatalf("expected + to lengthen the session, got %v", m.focusMode.length)
+	}
+
+	// A tick once the time is up ends the countdown
+	m.focusMode.started = time.Now().Add(-31 * time.Minute)
+	updated, cmd := m.Update(FocusTickMsg{Session: m.focusMode.session - 1})
+	m = updated.(Model)
+	if cmd != nil || !m.focusMode.Running() {
+		t.Fatalf("expected a stale tick ignored")
+	}
+	updated, cmd = m.Update(FocusTickMsg{Session: m.focusMode.session})
+	m = updated.(Model)
+	if m.focusMode.Running() || cmd == nil {
+		t.Fatalf("expected the session over with a notification")
+	}
+	cmd()
+	if len(notified) != 1 || !strings.Contains(notified[0], "B") {
+		t.Errorf("expected a desktop notification, got %q", notified)
+	}
+	if !strings.Contains(m.View(), "Time's up: 30m on B") {
+		t.Errorf("expected the end-of-session prompt:\n%s", m.View())
+	}
+
+	key("l")
+	key("l")
+	if len(wl.Entries) != 1 || wl.Entries[0].IssueID != "B" || wl.Entries[0].Author != "alice" ||
+		wl.Entries[0].Duration(time.Now()) != 30*time.Minute {
+		t.Fatalf("expected one 30m entry logged, got %+v", wl.Entries)
+	}
+
+	cmd = key("c")
+	if cmd == nil {
+		t.Fatalf("expected a command closing B")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if strings.Join(calls, "\n") != "close B" || m.issueMap["B"].Status != model.StatusClosed {
+		t.Fatalf("unexpected bd calls %q, status %s", calls, m.issueMap["B"].Status)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showFocusMode {
+		t.Fatalf("expected esc to leave focus mode")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "B" {
+		t.Errorf("expected B selected after the session, got %v", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSubgraphFocusCyclesHops(t *testing.T) {
+	dep := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	// A <- B <- C <- D <- E, and X on its own
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: dep("A")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Dependencies: dep("B")},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Dependencies: dep("C")},
+		{ID: "E", Title: "E", Status: model.StatusOpen, Dependencies: dep("D")},
+		{ID: "X", Title: "X", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+	for i, item := range m.list.Items() {
+		if item.(IssueItem).Issue.ID == "B" {
+			m.list.Select(i)
+		}
+	}
+
+	press := func() {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+		m = updated.(Model)
+	}
+	for _, want := range []int{3, 4, 5, 5, 6} {
+		press()
+		if got := len(m.list.Items()); got != want {
+			t.Fatalf("expected %d issues in focus, got %d (focus %+v)", want, got, m.focus)
+		}
+		if got := m.board.TotalCount(); got != want {
+			t.Fatalf("expected the board narrowed to %d issues, got %d", want, got)
+		}
+	}
+	if m.focus != nil {
+		t.Fatalf("expected the fifth press to turn focus off, got %+v", m.focus)
+	}
+
+	press()
+	if footer := m.renderFooter(); !strings.Contains(footer, "🎯 B ±1") {
+		t.Errorf("expected the focus in the status bar:\n%s", footer)
+	}
+	// The filter still applies within the focus
+	m.currentFilter = "closed"
+	m.applyFilter()
+	if len(m.list.Items()) != 0 {
+		t.Errorf("expected no closed issues in focus, got %d", len(m.list.Items()))
+	}
+}
+
+func TestEpicScope(t *testing.T) {
+	child := func(of string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: of, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		{ID: "EP", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "C1", Title: "Child", Status: model.StatusOpen, Assignee: "alice", Dependencies: child("EP")},
+		{ID: "C2", Title: "Grandchild", Status: model.StatusOpen, Dependencies: child("C1")},
+		{ID: "O", Title: "Other", Status: model.StatusOpen, Assignee: "bob"},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+	altE := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e"), Alt: true}
+
+	// Not in an epic: nothing to scope to
+	for i, item := range m.list.Items() {
+		if item.(IssueItem).Issue.ID == "O" {
+			m.list.Select(i)
+		}
+	}
+	updated, _ = m.Update(altE)
+	m = updated.(Model)
+	if m.epicScope != "" || !m.statusIsError {
+		t.Fatalf("expected no scope outside an epic, got %q", m.epicScope)
+	}
+
+	// A grandchild's epic is found through its parent-child links
+	for i, item := range m.list.Items() {
+		if item.(IssueItem).Issue.ID == "C1" {
+			m.list.Select(i)
+		}
+	}
+	updated, _ = m.Update(altE)
+	m = updated.(Model)
+	if m.epicScope != "EP" || len(m.list.Items()) != 3 || len(m.scopedIssues()) != 3 {
+		t.Fatalf("expected the list scoped to EP's 3 issues, got %q with %d", m.epicScope, len(m.list.Items()))
+	}
+	if footer := m.renderFooter(); !strings.Contains(footer, "📦 EP") {
+		t.Errorf("expected the scope in the status bar:\n%s", footer)
+	}
+
+	// Views opened while scoped see only the epic, and follow the scope
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	m = updated.(Model)
+	if view := m.workloadView.Render(); strings.Contains(view, "bob") {
+		t.Errorf("expected bob's issue outside the epic left out of the workload:\n%s", view)
+	}
+	updated, _ = m.Update(altE)
+	m = updated.(Model)
+	if m.epicScope != "" || !m.isWorkloadView || len(m.list.Items()) != 4 {
+		t.Fatalf("expected alt+e to clear the scope and keep the workload view open")
+	}
+	if view := m.workloadView.Render(); !strings.Contains(view, "bob") {
+		t.Errorf("expected the workload rebuilt with every issue:\n%s", view)
+	}
+}
+
+func TestMineToggle(t *testing.T) {
+	blocks := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A1", Title: "Mine, ready", Status: model.StatusOpen, Assignee: "Alice"},
+		{ID: "A2", Title: "Mine, waiting on bob", Status: model.StatusOpen, Assignee: "@alice", Dependencies: blocks("B1")},
+		{ID: "A3", Title: "Mine, done", Status: model.StatusClosed, Assignee: "alice"},
+		{ID: "B1", Title: "Bob's", Status: model.StatusOpen, Assignee: "bob"},
+		{ID: "U1", Title: "Nobody's", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+	at := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("@")}
+
+	// Nobody to be
+	updated, _ = m.Update(at)
+	m = updated.(Model)
+	if m.mineOnly || !m.statusIsError {
+		t.Fatalf("expected no mine toggle without a current user")
+	}
+
+	m.SetNotifyAssignee("alice", false)
+	m.currentFilter = "all"
+	updated, _ = m.Update(at)
+	m = updated.(Model)
+	if !m.mineOnly || len(m.list.Items()) != 3 || len(m.scopedIssues()) != 3 {
+		t.Fatalf("expected the list narrowed to alice's 3 issues, got %d", len(m.list.Items()))
+	}
+	// A2 waits on B1, so it finishes after bob's day of work and its own
+	for _, want := range []string{"Mine (@alice): 2 open, 1 ready", "all done by"} {
+		if !strings.Contains(m.statusMsg, want) {
+			t.Errorf("expected %q in the status, got %q", want, m.statusMsg)
+		}
+	}
+	if footer := m.renderFooter(); !strings.Contains(footer, "👤 alice") {
+		t.Errorf("expected the toggle in the status bar:\n%s", footer)
+	}
+
+	// Views opened while narrowed see only alice's work, and widen with it
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	m = updated.(Model)
+	if view := m.workloadView.Render(); strings.Contains(view, "bob") {
+		t.Errorf("expected bob left out of the workload:\n%s", view)
+	}
+	updated, _ = m.Update(at)
+	m = updated.(Model)
+	if m.mineOnly || !m.isWorkloadView || len(m.list.Items()) != 5 {
+		t.Fatalf("expected @ again to show everyone's issues and keep the workload open")
+	}
+}
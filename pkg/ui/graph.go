@@ -9,8 +9,69 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
 )
 
+// GraphSortMetric selects the centrality metric that orders the graph node list
+type GraphSortMetric int
+
+const (
+	GraphSortCriticalPath GraphSortMetric = iota // Default: deepest downstream chains first
+	GraphSortPageRank
+	GraphSortBetweenness
+	GraphSortEigenvector
+	GraphSortCloseness
+	GraphSortHubs
+	GraphSortAuthorities
+	graphSortMetricCount // Sentinel for cycling
+)
+
+// String returns the display name of the metric
+func (s GraphSortMetric) String() string {
+	switch s {
+	case GraphSortPageRank:
+		return "PageRank"
+	case GraphSortBetweenness:
+		return "Betweenness"
+	case GraphSortEigenvector:
+		return "Eigenvector"
+	case GraphSortCloseness:
+		return "Closeness"
+	case GraphSortHubs:
+		return "Hub"
+	case GraphSortAuthorities:
+		return "Authority"
+	default:
+		return "Critical Path"
+	}
+}
+
+// Next returns the following metric, wrapping back to critical path
+func (s GraphSortMetric) Next() GraphSortMetric {
+	return (s + 1) % graphSortMetricCount
+}
+
+// score returns the metric value for id
+func (s GraphSortMetric) score(stats *analysis.GraphStats, id string) float64 {
+	switch s {
+	case GraphSortPageRank:
+		return stats.GetPageRankScore(id)
+	case GraphSortBetweenness:
+		return stats.GetBetweennessScore(id)
+	case GraphSortEigenvector:
+		return stats.GetEigenvectorScore(id)
+	case GraphSortCloseness:
+		return stats.GetClosenessScore(id)
+	case GraphSortHubs:
+		return stats.GetHubScore(id)
+	case GraphSortAuthorities:
+		return stats.GetAuthorityScore(id)
+	default:
+		return stats.GetCriticalPathScore(id)
+	}
+}
+
 // GraphModel represents the dependency graph view with visual ASCII art visualization
 type GraphModel struct {
 	issues       []model.Issue
@@ -20,14 +81,46 @@ type GraphModel struct {
 	scrollOffset int
 	width        int
 	height       int
+	listWidth    int // Fixed node list width, 0 to size it to the window
 	theme        Theme
 
+	// Horizontal panning of the visual graph, reset when the selection moves
+	panOffset int
+	panID     string
+
+	// Breadcrumbs: the issues followed through to the selected one, oldest
+	// first, and which of its blockers and dependents f follows next. picked
+	// is set once [ or ] has moved the cursor onto one, so enter follows it.
+	trail    []string
+	neighbor int
+	picked   bool
+
 	// Precomputed graph relationships
 	blockers   map[string][]string // What each issue depends on (blocks this issue)
 	dependents map[string][]string // What depends on each issue (this issue blocks)
+	edgeTypes  map[graphEdge]model.DependencyType
+
+	// Dependency types linked into blockers and dependents, and the one
+	// under the legend cursor
+	edgeFilter map[model.DependencyType]bool
+	edgeCursor int
 
-	// Flat list for navigation
-	sortedIDs []string
+	// Issues each issue mentions without a dependency, linked as blockers
+	// while mentions edges are on, nil while they're off
+	mentions map[string][]string
+
+	// Blocking dependencies implied by longer chains, left unlinked while
+	// hideRedundant is set
+	redundant     map[graphEdge]bool
+	hideRedundant bool
+
+	// Cluster of each node while nodes are colored and grouped by cluster,
+	// nil otherwise
+	clusters *analysis.Clusters
+
+	// Flat list for navigation, ordered by sortMetric
+	sortedIDs  []string
+	sortMetric GraphSortMetric
 
 	// Precomputed rankings for all metrics (id -> rank, 1-indexed)
 	rankPageRank     map[string]int
@@ -35,6 +128,7 @@ type GraphModel struct {
 	rankEigenvector  map[string]int
 	rankHubs         map[string]int
 	rankAuthorities  map[string]int
+	rankCloseness    map[string]int
 	rankCriticalPath map[string]int
 	rankInDegree     map[string]int
 	rankOutDegree    map[string]int
@@ -43,9 +137,10 @@ type GraphModel struct {
 // NewGraphModel creates a new graph view from issues
 func NewGraphModel(issues []model.Issue, insights *analysis.Insights, theme Theme) GraphModel {
 	g := GraphModel{
-		issues:   issues,
-		insights: insights,
-		theme:    theme,
+		issues:     issues,
+		insights:   insights,
+		theme:      theme,
+		edgeFilter: defaultEdgeTypes(),
 	}
 	g.rebuildGraph()
 	return g
@@ -62,7 +157,19 @@ func (g *GraphModel) rebuildGraph() {
 	g.issueMap = make(map[string]*model.Issue)
 	g.blockers = make(map[string][]string)
 	g.dependents = make(map[string][]string)
+	g.edgeTypes = make(map[graphEdge]model.DependencyType)
 	g.sortedIDs = nil
+	g.redundant = nil
+	g.mentions = nil
+	if g.edgeFilter[analysis.DepMentions] {
+		g.mentions = analysis.Mentions(g.issues)
+	}
+	if g.hideRedundant {
+		g.redundant = make(map[graphEdge]bool)
+		for _, r := range analysis.RedundantDependencies(g.issues) {
+			g.redundant[graphEdge{r.IssueID, r.DependsOnID}] = true
+		}
+	}
 
 	for i := range g.issues {
 		issue := &g.issues[i]
@@ -71,34 +178,123 @@ func (g *GraphModel) rebuildGraph() {
 	}
 
 	// Build relationships
-	for _, issue := range g.issues {
-		for _, dep := range issue.Dependencies {
-			if dep.Type == model.DepBlocks || dep.Type == model.DepParentChild {
-				g.blockers[issue.ID] = append(g.blockers[issue.ID], dep.DependsOnID)
-				g.dependents[dep.DependsOnID] = append(g.dependents[dep.DependsOnID], issue.ID)
-			}
-		}
+	for i := range g.issues {
+		g.linkIssue(&g.issues[i])
+	}
+	if g.clusters != nil {
+		clusters := analysis.DetectClusters(g.issues)
+		g.clusters = &clusters
 	}
 
 	// Compute rankings for all metrics
 	g.computeRankings()
 
-	// Sort by critical path score if available, else by ID
-	if g.insights != nil && g.insights.Stats != nil {
-		sort.Slice(g.sortedIDs, func(i, j int) bool {
-			scoreI := g.insights.Stats.GetCriticalPathScore(g.sortedIDs[i])
-			scoreJ := g.insights.Stats.GetCriticalPathScore(g.sortedIDs[j])
-			if scoreI != scoreJ {
-				return scoreI > scoreJ
+	g.sortNodes()
+
+	if g.selectedIdx >= len(g.sortedIDs) {
+		g.selectedIdx = 0
+	}
+}
+
+// linkIssue records issue's blockers and adds it to their dependents,
+// following the dependency types turned on in edgeFilter and skipping
+// redundant ones while they are hidden. Issues it mentions count as
+// blockers while mentions are on.
+func (g *GraphModel) linkIssue(issue *model.Issue) {
+	link := func(to string, t model.DependencyType) {
+		g.blockers[issue.ID] = append(g.blockers[issue.ID], to)
+		g.dependents[to] = append(g.dependents[to], issue.ID)
+		g.edgeTypes[graphEdge{issue.ID, to}] = t
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !g.edgeFilter[dep.Type] || g.redundant[graphEdge{issue.ID, dep.DependsOnID}] {
+			continue
+		}
+		link(dep.DependsOnID, dep.Type)
+	}
+	for _, id := range g.mentions[issue.ID] {
+		link(id, analysis.DepMentions)
+	}
+}
+
+// unlinkIssue removes id from the relationships recorded by linkIssue
+func (g *GraphModel) unlinkIssue(id string) {
+	for _, blocker := range g.blockers[id] {
+		delete(g.edgeTypes, graphEdge{id, blocker})
+		deps := g.dependents[blocker]
+		for i, d := range deps {
+			if d == id {
+				deps = append(deps[:i], deps[i+1:]...)
+				break
 			}
-			return g.sortedIDs[i] < g.sortedIDs[j]
-		})
-	} else {
+		}
+		if len(deps) == 0 {
+			delete(g.dependents, blocker)
+		} else {
+			g.dependents[blocker] = deps
+		}
+	}
+	delete(g.blockers, id)
+}
+
+// UpdateIssues swaps in a reloaded issue set whose graph structure, and so its
+// metrics, is unchanged. Only the modified issues' relationships are relinked;
+// rankings, node order, sort metric and selection are kept.
+func (g *GraphModel) UpdateIssues(issues []model.Issue, insights *analysis.Insights, modified []string) {
+	g.issues = issues
+	g.insights = insights
+	g.issueMap = make(map[string]*model.Issue, len(issues))
+	for i := range g.issues {
+		g.issueMap[g.issues[i].ID] = &g.issues[i]
+	}
+	if g.mentions != nil {
+		// Edited text may mention other issues
+		g.mentions = analysis.Mentions(g.issues)
+	}
+	for _, id := range modified {
+		g.unlinkIssue(id)
+		if issue, ok := g.issueMap[id]; ok {
+			g.linkIssue(issue)
+		}
+	}
+}
+
+// sortNodes orders the node list by the active metric if available, else by ID
+func (g *GraphModel) sortNodes() {
+	defer g.groupByCluster()
+	if g.insights == nil || g.insights.Stats == nil {
 		sort.Strings(g.sortedIDs)
+		return
 	}
+	stats := g.insights.Stats
+	sort.Slice(g.sortedIDs, func(i, j int) bool {
+		scoreI := g.sortMetric.score(stats, g.sortedIDs[i])
+		scoreJ := g.sortMetric.score(stats, g.sortedIDs[j])
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
+		}
+		return g.sortedIDs[i] < g.sortedIDs[j]
+	})
+}
 
-	if g.selectedIdx >= len(g.sortedIDs) {
-		g.selectedIdx = 0
+// SortMetric returns the metric currently ordering the node list
+func (g *GraphModel) SortMetric() GraphSortMetric {
+	return g.sortMetric
+}
+
+// CycleSortMetric switches to the next sort metric, keeping the selected issue selected
+func (g *GraphModel) CycleSortMetric() {
+	var selectedID string
+	if g.selectedIdx < len(g.sortedIDs) {
+		selectedID = g.sortedIDs[g.selectedIdx]
+	}
+	g.sortMetric = g.sortMetric.Next()
+	g.sortNodes()
+	for i, id := range g.sortedIDs {
+		if id == selectedID {
+			g.selectedIdx = i
+			break
+		}
 	}
 }
 
@@ -109,6 +305,7 @@ func (g *GraphModel) computeRankings() {
 	g.rankEigenvector = make(map[string]int)
 	g.rankHubs = make(map[string]int)
 	g.rankAuthorities = make(map[string]int)
+	g.rankCloseness = make(map[string]int)
 	g.rankCriticalPath = make(map[string]int)
 	g.rankInDegree = make(map[string]int)
 	g.rankOutDegree = make(map[string]int)
@@ -164,6 +361,7 @@ func (g *GraphModel) computeRankings() {
 	g.rankEigenvector = computeFloatRanks(stats.Eigenvector())
 	g.rankHubs = computeFloatRanks(stats.Hubs())
 	g.rankAuthorities = computeFloatRanks(stats.Authorities())
+	g.rankCloseness = computeFloatRanks(stats.Closeness())
 	g.rankCriticalPath = computeFloatRanks(stats.CriticalPathScore())
 	g.rankInDegree = computeIntRanks(stats.InDegree)
 	g.rankOutDegree = computeIntRanks(stats.OutDegree)
@@ -173,6 +371,7 @@ func (g *GraphModel) computeRankings() {
 func (g *GraphModel) MoveUp() {
 	if g.selectedIdx > 0 {
 		g.selectedIdx--
+		g.leaveTrail()
 		g.ensureVisible()
 	}
 }
@@ -180,6 +379,7 @@ func (g *GraphModel) MoveUp() {
 func (g *GraphModel) MoveDown() {
 	if g.selectedIdx < len(g.sortedIDs)-1 {
 		g.selectedIdx++
+		g.leaveTrail()
 		g.ensureVisible()
 	}
 }
@@ -192,6 +392,7 @@ func (g *GraphModel) PageUp() {
 	if g.selectedIdx < 0 {
 		g.selectedIdx = 0
 	}
+	g.leaveTrail()
 	g.ensureVisible()
 }
 
@@ -203,11 +404,22 @@ func (g *GraphModel) PageDown() {
 	if g.selectedIdx >= len(g.sortedIDs) {
 		g.selectedIdx = len(g.sortedIDs) - 1
 	}
+	g.leaveTrail()
 	g.ensureVisible()
 }
 
-func (g *GraphModel) ScrollLeft()  {}
-func (g *GraphModel) ScrollRight() {}
+// graphPanStep is how many columns H and L pan the visual graph
+const graphPanStep = 12
+
+// ScrollLeft pans the visual graph toward its left edge
+func (g *GraphModel) ScrollLeft() {
+	g.panOffset = max(g.panOffset-graphPanStep, 0)
+}
+
+// ScrollRight pans the visual graph right; rendering stops it at the right edge
+func (g *GraphModel) ScrollRight() {
+	g.panOffset += graphPanStep
+}
 
 func (g *GraphModel) ensureVisible() {}
 
@@ -245,10 +457,7 @@ func (g *GraphModel) View(width, height int) string {
 	}
 
 	// Layout: Left panel (node list) | Right panel (visual graph + metrics)
-	listWidth := 28
-	if width < 120 {
-		listWidth = 24
-	}
+	listWidth := g.ListWidth(width)
 	if width < 80 {
 		// Narrow: just show visual graph
 		return g.renderVisualGraph(selectedID, selectedIssue, width, height, t)
@@ -274,6 +483,23 @@ func (g *GraphModel) View(width, height int) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, listView, separator, graphView)
 }
 
+// SetListWidth fixes the node list at width columns; 0 sizes it to the window
+func (g *GraphModel) SetListWidth(width int) {
+	g.listWidth = width
+}
+
+// ListWidth returns the node list's width in a view width columns wide. A
+// fixed width still leaves the graph at least half the view.
+func (g *GraphModel) ListWidth(width int) int {
+	if g.listWidth > 0 {
+		return min(g.listWidth, width/2)
+	}
+	if width < 120 {
+		return 24
+	}
+	return 28
+}
+
 // renderNodeList renders the left panel with all nodes
 func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 	var lines []string
@@ -283,9 +509,11 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 		Foreground(t.Primary).
 		Width(width)
 	lines = append(lines, headerStyle.Render(fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))))
-	lines = append(lines, strings.Repeat("─", width))
+	lines = append(lines, t.Renderer.NewStyle().Foreground(t.Secondary).Width(width).Render(
+		truncateToWidth("by "+g.sortMetric.String(), width, "…")))
+	lines = append(lines, repeatToWidth("─", width))
 
-	visibleItems := height - 4
+	visibleItems := height - 5
 	if visibleItems < 1 {
 		visibleItems = 1
 	}
@@ -303,6 +531,7 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 		endIdx = len(g.sortedIDs)
 	}
 
+	var rows []string
 	for i := startIdx; i < endIdx; i++ {
 		id := g.sortedIDs[i]
 		issue := g.issueMap[id]
@@ -312,7 +541,8 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 
 		isSelected := i == g.selectedIdx
 		statusIcon := getStatusIcon(issue.Status)
-		maxIDLen := width - 4
+		// Leave room for the icon, a space and the scrollbar
+		maxIDLen := width - displayWidth(statusIcon) - 1 - displayWidth("│")
 		displayID := smartTruncateID(id, maxIDLen)
 		line := fmt.Sprintf("%s %s", statusIcon, displayID)
 
@@ -325,10 +555,13 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 				Width(width)
 		} else {
 			style = t.Renderer.NewStyle().
-				Foreground(getStatusColor(issue.Status, t)).
+				Foreground(g.nodeColor(id, getStatusColor(issue.Status, t), t)).
 				Width(width)
 		}
-		lines = append(lines, style.Render(line))
+		rows = append(rows, style.Render(line))
+	}
+	if len(rows) > 0 {
+		lines = append(lines, withScrollbar(strings.Join(rows, "\n"), width, len(g.sortedIDs), visibleItems, startIdx, t))
 	}
 
 	if len(g.sortedIDs) > visibleItems {
@@ -355,7 +588,7 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	// BLOCKERS SECTION (what this issue depends on)
 	// ═══════════════════════════════════════════════════════════════════════
 	if len(blockerIDs) > 0 {
-		sections = append(sections, g.renderBlockersVisual(blockerIDs, width, t))
+		sections = append(sections, g.renderBlockersVisual(id, blockerIDs, width, t))
 		// Connecting lines down to ego
 		sections = append(sections, g.renderConnectorDown(len(blockerIDs), width, t))
 	}
@@ -371,28 +604,71 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	if len(dependentIDs) > 0 {
 		// Connecting lines down from ego
 		sections = append(sections, g.renderConnectorDown(len(dependentIDs), width, t))
-		sections = append(sections, g.renderDependentsVisual(dependentIDs, width, t))
+		sections = append(sections, g.renderDependentsVisual(id, dependentIDs, width, t))
 	}
 
 	sections = append(sections, "")
 
 	// ═══════════════════════════════════════════════════════════════════════
-	// COMPREHENSIVE METRICS PANEL - ALL 8 metrics with values AND ranks
+	// COMPREHENSIVE METRICS PANEL - ALL 9 metrics with values AND ranks
 	// ═══════════════════════════════════════════════════════════════════════
 	sections = append(sections, g.renderMetricsPanel(id, width, t))
 
+	body, panInfo := g.pan(id, strings.Join(sections, "\n"), width)
+	if trail := g.renderTrail(id, width, t); trail != "" {
+		body = trail + "\n" + body
+	}
+
 	// Navigation hint
 	navStyle := t.Renderer.NewStyle().
 		Foreground(t.Secondary).
 		Italic(true)
-	sections = append(sections, "")
-	sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • g: back to list"))
+	hint := navStyle.Render("j/k: navigate • m: sort metric • enter: view details • g: back to list")
+	if len(g.neighbors(id)) > 0 || len(g.trail) > 0 {
+		trailHint := "[/]: pick blocker or dependent • f: follow"
+		if g.picked {
+			trailHint = "[/]: pick blocker or dependent • enter/f: re-center on it"
+		}
+		if len(g.trail) > 0 {
+			trailHint += " • ⌫: back • alt+1-9: jump to crumb"
+		}
+		hint += "\n" + navStyle.Render(trailHint)
+	}
+	if panInfo != "" {
+		hint += "\n" + navStyle.Render("H/L: pan "+panInfo)
+	}
+	hint += "\n" + g.renderEdgeLegend(t)
+	if cluster := g.renderClusterLegend(id, t); cluster != "" {
+		hint += "\n" + cluster
+	}
+	return body + "\n\n" + hint
+}
 
-	return strings.Join(sections, "\n")
+// pan shows the width columns of content at the pan offset, for rows of nodes
+// too wide to fit. It returns the columns shown, e.g. "(1-80 of 130)", or ""
+// when content fits.
+func (g *GraphModel) pan(id, content string, width int) (string, string) {
+	if id != g.panID {
+		g.panID, g.panOffset = id, 0
+	}
+	lines := strings.Split(content, "\n")
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, lipgloss.Width(line))
+	}
+	if widest <= width {
+		g.panOffset = 0
+		return content, ""
+	}
+	g.panOffset = min(g.panOffset, widest-width)
+	for i, line := range lines {
+		lines[i] = ansi.Cut(line, g.panOffset, g.panOffset+width)
+	}
+	return strings.Join(lines, "\n"), fmt.Sprintf("(%d-%d of %d)", g.panOffset+1, g.panOffset+width, widest)
 }
 
-// renderBlockersVisual renders blocker nodes as boxes
-func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Theme) string {
+// renderBlockersVisual renders the blocker nodes of id as boxes
+func (g *GraphModel) renderBlockersVisual(id string, blockerIDs []string, width int, t Theme) string {
 	headerStyle := t.Renderer.NewStyle().
 		Bold(true).
 		Foreground(t.Feature).
@@ -424,27 +700,19 @@ func (g *GraphModel) renderBlockersVisual(blockerIDs []string, width int, t Them
 		boxWidth = 8
 	}
 
+	// Every node gets a box; a row wider than the panel is panned with H/L
 	var boxes []string
-	for i, bid := range blockerIDs {
-		if i >= 5 {
-			remaining := len(blockerIDs) - 5
-			boxes = append(boxes, t.Renderer.NewStyle().
-				Foreground(t.Secondary).
-				Italic(true).
-				Render(fmt.Sprintf("+%d more", remaining)))
-			break
-		}
-		boxes = append(boxes, g.renderNodeBox(bid, boxWidth, t, false))
+	for _, bid := range blockerIDs {
+		boxes = append(boxes, g.renderNodeBox(bid, boxWidth, t, false, g.edgeTypes[graphEdge{id, bid}]))
 	}
 
-	boxRow := lipgloss.JoinHorizontal(lipgloss.Center, boxes...)
-	centered := t.Renderer.NewStyle().Width(width).Align(lipgloss.Center).Render(boxRow)
+	centered := centerBoxRow(boxes, width, t)
 
 	return header + "\n" + centered
 }
 
-// renderDependentsVisual renders dependent nodes as boxes
-func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t Theme) string {
+// renderDependentsVisual renders the dependent nodes of id as boxes
+func (g *GraphModel) renderDependentsVisual(id string, dependentIDs []string, width int, t Theme) string {
 	maxBoxes := 5
 	if len(dependentIDs) < maxBoxes {
 		maxBoxes = len(dependentIDs)
@@ -467,21 +735,13 @@ func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t
 		boxWidth = 8
 	}
 
+	// Every node gets a box; a row wider than the panel is panned with H/L
 	var boxes []string
-	for i, did := range dependentIDs {
-		if i >= 5 {
-			remaining := len(dependentIDs) - 5
-			boxes = append(boxes, t.Renderer.NewStyle().
-				Foreground(t.Secondary).
-				Italic(true).
-				Render(fmt.Sprintf("+%d more", remaining)))
-			break
-		}
-		boxes = append(boxes, g.renderNodeBox(did, boxWidth, t, false))
+	for _, did := range dependentIDs {
+		boxes = append(boxes, g.renderNodeBox(did, boxWidth, t, false, g.edgeTypes[graphEdge{did, id}]))
 	}
 
-	boxRow := lipgloss.JoinHorizontal(lipgloss.Center, boxes...)
-	centered := t.Renderer.NewStyle().Width(width).Align(lipgloss.Center).Render(boxRow)
+	centered := centerBoxRow(boxes, width, t)
 
 	headerStyle := t.Renderer.NewStyle().
 		Bold(true).
@@ -494,30 +754,33 @@ func (g *GraphModel) renderDependentsVisual(dependentIDs []string, width int, t
 	return centered + "\n" + header
 }
 
-// renderNodeBox renders a single node as an ASCII box
-func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool) string {
+// centerBoxRow lays boxes side by side, centered when the row fits in width
+func centerBoxRow(boxes []string, width int, t Theme) string {
+	boxRow := lipgloss.JoinHorizontal(lipgloss.Center, boxes...)
+	if lipgloss.Width(boxRow) > width {
+		return boxRow
+	}
+	return t.Renderer.NewStyle().Width(width).Align(lipgloss.Center).Render(boxRow)
+}
+
+// renderNodeBox renders a single node as an ASCII box, labelled with the type
+// of the edge linking it to the selected issue unless edge is ""
+func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool, edge model.DependencyType) string {
 	issue := g.issueMap[id]
 
-	var statusIcon, displayID, title string
+	var statusIcon, title string
 	var statusColor lipgloss.AdaptiveColor
 
 	if issue != nil {
 		statusIcon = getStatusIcon(issue.Status)
 		statusColor = getStatusColor(issue.Status, t)
-		displayID = smartTruncateID(id, boxWidth-4)
-		if issue.Title != "" {
-			title = truncateRunesHelper(issue.Title, boxWidth-4, "…")
-		}
+		title = issue.Title
 	} else {
 		statusIcon = "❓"
 		statusColor = t.Secondary
-		displayID = smartTruncateID(id, boxWidth-4)
 		title = "(not in filter)"
 	}
 
-	// Build box content
-	line1 := fmt.Sprintf("%s %s", statusIcon, displayID)
-
 	var boxStyle lipgloss.Style
 	if isEgo {
 		// Ego node gets double-line border and highlight
@@ -529,19 +792,35 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 			Width(boxWidth).
 			Align(lipgloss.Center).
 			Padding(0, 1)
+	} else if g.picked && id == g.highlightedNeighbor() {
+		// The node picked with [ and ]
+		boxStyle = t.Renderer.NewStyle().
+			Border(lipgloss.ThickBorder()).
+			BorderForeground(t.Primary).
+			Foreground(statusColor).
+			Bold(true).
+			Width(boxWidth).
+			Align(lipgloss.Center).
+			Padding(0, 0)
 	} else {
 		boxStyle = t.Renderer.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(statusColor).
+			BorderForeground(g.nodeColor(id, statusColor, t)).
 			Foreground(statusColor).
 			Width(boxWidth).
 			Align(lipgloss.Center).
 			Padding(0, 0)
 	}
 
-	content := line1
+	// Fit each line inside the padding so none wraps
+	inner := boxWidth - boxStyle.GetHorizontalPadding()
+	content := fmt.Sprintf("%s %s", statusIcon, smartTruncateID(id, inner-displayWidth(statusIcon)-1))
 	if title != "" && boxWidth > 14 {
-		content = line1 + "\n" + title
+		content += "\n" + truncateToWidth(title, inner, "…")
+	}
+	if edge != "" {
+		edgeStyle := t.Renderer.NewStyle().Foreground(edgeColor(edge, t)).Italic(true)
+		content += "\n" + edgeStyle.Render(truncateToWidth(edgeLabel(edge), inner, "…"))
 	}
 
 	return boxStyle.Render(content)
@@ -569,10 +848,12 @@ func (g *GraphModel) renderEgoNode(id string, issue *model.Issue, width int, t T
 	}
 
 	icons := fmt.Sprintf("%s %s %s", statusIcon, prioIcon, typeIcon)
-	displayID := smartTruncateID(id, egoWidth-4)
+	// Inside the padding: the icons, a space and the ID on the first line
+	inner := egoWidth - 2
+	displayID := smartTruncateID(id, inner-displayWidth(icons)-1)
 	title := ""
 	if issue.Title != "" {
-		title = truncateRunesHelper(issue.Title, egoWidth-4, "…")
+		title = truncateToWidth(issue.Title, inner, "…")
 	}
 
 	content := icons + " " + displayID
@@ -651,7 +932,11 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 		Padding(0, 2).
 		Width(width - 4)
 
-	panelTitle := panelHeaderStyle.Render("📊 GRAPH METRICS")
+	title := "📊 GRAPH METRICS"
+	if g.insights != nil && g.insights.Stats != nil && !g.insights.Stats.IsPhase2Ready() {
+		title += "  ⏳ computing…"
+	}
+	panelTitle := panelHeaderStyle.Render(title)
 
 	if g.insights == nil || g.insights.Stats == nil {
 		noDataStyle := t.Renderer.NewStyle().
@@ -671,6 +956,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 	eigenvector := stats.GetEigenvectorScore(id)
 	hubs := stats.GetHubScore(id)
 	authorities := stats.GetAuthorityScore(id)
+	closeness := stats.GetClosenessScore(id)
 	critPath := stats.GetCriticalPathScore(id)
 	inDeg := float64(stats.InDegree[id])
 	outDeg := float64(stats.OutDegree[id])
@@ -680,6 +966,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 	rankEV := g.rankEigenvector[id]
 	rankHub := g.rankHubs[id]
 	rankAuth := g.rankAuthorities[id]
+	rankCL := g.rankCloseness[id]
 	rankCP := g.rankCriticalPath[id]
 	rankIn := g.rankInDegree[id]
 	rankOut := g.rankOutDegree[id]
@@ -700,6 +987,9 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 	if rankAuth == 0 {
 		rankAuth = total
 	}
+	if rankCL == 0 {
+		rankCL = total
+	}
 	if rankCP == 0 {
 		rankCP = total
 	}
@@ -741,7 +1031,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 
 	// Find max values for normalization (using thread-safe accessors)
 	maxCP, maxPR, maxBW, maxEV := 0.0, 0.0, 0.0, 0.0
-	maxHub, maxAuth, maxIn, maxOut := 0.0, 0.0, 0.0, 0.0
+	maxHub, maxAuth, maxCL, maxIn, maxOut := 0.0, 0.0, 0.0, 0.0, 0.0
 	for _, issueID := range g.sortedIDs {
 		if v := stats.GetCriticalPathScore(issueID); v > maxCP {
 			maxCP = v
@@ -761,6 +1051,9 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 		if v := stats.GetAuthorityScore(issueID); v > maxAuth {
 			maxAuth = v
 		}
+		if v := stats.GetClosenessScore(issueID); v > maxCL {
+			maxCL = v
+		}
 		if v := float64(stats.InDegree[issueID]); v > maxIn {
 			maxIn = v
 		}
@@ -788,6 +1081,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 	// Section: Flow Metrics
 	rows = append(rows, sectionStyle.Render("Flow & Connectivity"))
 	rows = append(rows, "  "+renderMetricRow("Betweenness", betweenness, rankBW, maxBW, false))
+	rows = append(rows, "  "+renderMetricRow("Closeness", closeness, rankCL, maxCL, false))
 	rows = append(rows, "  "+renderMetricRow("Hub Score", hubs, rankHub, maxHub, false))
 	rows = append(rows, "  "+renderMetricRow("Authority", authorities, rankAuth, maxAuth, false))
 
@@ -875,53 +1169,35 @@ func getTypeIcon(itype model.IssueType) string {
 	}
 }
 
+// smartTruncateID fits id into maxLen terminal columns. IDs made of three or
+// more underscore-separated parts keep each part's first character and as
+// much of the last part as fits (a_b_long…); others are cut with an
+// ellipsis.
 func smartTruncateID(id string, maxLen int) string {
 	if maxLen <= 0 {
 		return ""
 	}
-
-	runes := []rune(id)
-	if len(runes) <= maxLen {
+	if displayWidth(id) <= maxLen {
 		return id
 	}
 
 	parts := strings.Split(id, "_")
 	if len(parts) > 2 {
 		var abbrev strings.Builder
-		runeCount := 0
-		for i, part := range parts {
-			partRunes := []rune(part)
-			if i == len(parts)-1 {
-				// Last part: keep as much as possible
-				remaining := maxLen - runeCount
-				if remaining > 0 {
-					if len(partRunes) <= remaining {
-						abbrev.WriteString(part)
-					} else if remaining > 1 {
-						abbrev.WriteString(string(partRunes[:remaining-1]))
-						abbrev.WriteRune('…')
-					} else {
-						abbrev.WriteRune('…')
-					}
-				}
-			} else {
-				// Non-last parts: just first char + underscore
-				if len(partRunes) > 0 {
-					abbrev.WriteRune(partRunes[0])
-					abbrev.WriteRune('_')
-					runeCount += 2
-				}
+		for _, part := range parts[:len(parts)-1] {
+			// Non-last parts: just the first character + underscore
+			if part != "" {
+				first, _, _, _ := uniseg.FirstGraphemeClusterInString(part, -1)
+				abbrev.WriteString(first + "_")
 			}
 		}
-		result := abbrev.String()
-		if len([]rune(result)) <= maxLen {
-			return result
+		// Last part: keep as much as possible
+		if remaining := maxLen - displayWidth(abbrev.String()); remaining > 0 {
+			abbrev.WriteString(truncateToWidth(parts[len(parts)-1], remaining, "…"))
+			return abbrev.String()
 		}
 	}
 
 	// Fallback: simple truncation
-	if maxLen > 1 {
-		return string(runes[:maxLen-1]) + "…"
-	}
-	return string(runes[:maxLen])
+	return truncateToWidth(id, maxLen, "…")
 }
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// clusterColor tells clusters apart, cycling through the palette from the
+// largest cluster down
+func clusterColor(id int, t Theme) lipgloss.AdaptiveColor {
+	palette := []lipgloss.AdaptiveColor{t.Primary, t.Feature, t.Epic, t.InProgress, t.Bug, t.Open, t.Chore, t.Task}
+	return palette[(id-1)%len(palette)]
+}
+
+// ToggleClusters colors the nodes by the cluster of linked work they fall
+// in and lists them cluster by cluster, or goes back to status colors and
+// the metric order. It returns the clusters, or nil when turned off.
+func (g *GraphModel) ToggleClusters() *analysis.Clusters {
+	if g.clusters != nil {
+		g.clusters = nil
+	} else {
+		clusters := analysis.DetectClusters(g.issues)
+		g.clusters = &clusters
+	}
+
+	var selected string
+	if issue := g.SelectedIssue(); issue != nil {
+		selected = issue.ID
+	}
+	g.sortNodes()
+	if selected != "" {
+		g.selectID(selected)
+	}
+	return g.clusters
+}
+
+// nodeColor returns the color of node id: its cluster's while clusters are
+// shown (secondary when it is in none), otherwise fallback
+func (g *GraphModel) nodeColor(id string, fallback lipgloss.AdaptiveColor, t Theme) lipgloss.AdaptiveColor {
+	if g.clusters == nil {
+		return fallback
+	}
+	if n := g.clusters.Of[id]; n > 0 {
+		return clusterColor(n, t)
+	}
+	return t.Secondary
+}
+
+// groupByCluster reorders the sorted nodes cluster by cluster, largest
+// first and unclustered last, keeping the metric order within each
+func (g *GraphModel) groupByCluster() {
+	if g.clusters == nil {
+		return
+	}
+	rank := func(id string) int {
+		if n := g.clusters.Of[id]; n > 0 {
+			return n
+		}
+		return len(g.clusters.Clusters) + 1
+	}
+	sort.SliceStable(g.sortedIDs, func(i, j int) bool {
+		return rank(g.sortedIDs[i]) < rank(g.sortedIDs[j])
+	})
+}
+
+// renderClusterLegend names the selected node's cluster while clusters are
+// shown, or "" otherwise
+func (g *GraphModel) renderClusterLegend(id string, t Theme) string {
+	if g.clusters == nil {
+		return ""
+	}
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	legend := labelStyle.Render(fmt.Sprintf("clusters (c): %d, modularity %.2f •", len(g.clusters.Clusters), g.clusters.Modularity))
+	cl := g.clusters.Cluster(g.clusters.Of[id])
+	if cl == nil {
+		return legend + labelStyle.Render(" this issue is in none")
+	}
+	style := t.Renderer.NewStyle().Foreground(clusterColor(cl.ID, t)).Bold(true)
+	return legend + " " + style.Render(fmt.Sprintf("■ %s, %d issues", clusterLabel(*cl, g.issueMap), len(cl.Members)))
+}
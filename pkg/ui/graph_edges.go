@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphEdgeTypes returns the dependency types the graph can follow, in
+// legend order: the built-in ones, any declared in config by name, then the
+// implicit mentions of one issue in another's text
+func graphEdgeTypes() []model.DependencyType {
+	types := []model.DependencyType{
+		model.DepBlocks,
+		model.DepParentChild,
+		model.DepRelated,
+		model.DepDiscoveredFrom,
+	}
+	var custom []model.DependencyType
+	for t := range model.CustomDependencyTypes() {
+		custom = append(custom, t)
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i] < custom[j] })
+	return append(append(types, custom...), analysis.DepMentions)
+}
+
+// graphEdge is a dependency from an issue to the issue it depends on
+type graphEdge struct {
+	from, to string
+}
+
+// defaultEdgeTypes are the types that make up blockers and dependents until
+// toggled: the ones that order work, including custom blocking types
+func defaultEdgeTypes() map[model.DependencyType]bool {
+	types := map[model.DependencyType]bool{
+		model.DepBlocks:      true,
+		model.DepParentChild: true,
+	}
+	for t, blocking := range model.CustomDependencyTypes() {
+		types[t] = blocking
+	}
+	return types
+}
+
+// EdgeTypeEnabled reports whether edges of type t count as blockers and dependents
+func (g *GraphModel) EdgeTypeEnabled(t model.DependencyType) bool {
+	return g.edgeFilter[t]
+}
+
+// NextEdgeType moves the legend cursor to the next dependency type, the one
+// ToggleEdgeType toggles
+func (g *GraphModel) NextEdgeType() {
+	g.edgeCursor = (g.edgeCursor + 1) % len(graphEdgeTypes())
+}
+
+// ToggleEdgeType turns the dependency type under the legend cursor on or off
+// and relinks the graph, keeping the selection. It returns the type and
+// whether it is now on.
+func (g *GraphModel) ToggleEdgeType() (model.DependencyType, bool) {
+	types := graphEdgeTypes()
+	t := types[min(g.edgeCursor, len(types)-1)]
+	g.edgeFilter[t] = !g.edgeFilter[t]
+
+	var selected string
+	if issue := g.SelectedIssue(); issue != nil {
+		selected = issue.ID
+	}
+	g.rebuildGraph()
+	if selected != "" {
+		g.selectID(selected)
+	}
+	return t, g.edgeFilter[t]
+}
+
+// ToggleRedundant hides or shows the blocking dependencies implied by longer
+// chains (the transitive reduction), keeping the selection. It returns
+// whether they are now hidden and how many are.
+func (g *GraphModel) ToggleRedundant() (bool, int) {
+	g.hideRedundant = !g.hideRedundant
+
+	var selected string
+	if issue := g.SelectedIssue(); issue != nil {
+		selected = issue.ID
+	}
+	g.rebuildGraph()
+	if selected != "" {
+		g.selectID(selected)
+	}
+	return g.hideRedundant, len(g.redundant)
+}
+
+// edgeLabel is the short name shown for an edge of type t
+func edgeLabel(t model.DependencyType) string {
+	switch t {
+	case model.DepParentChild:
+		return "parent"
+	case model.DepDiscoveredFrom:
+		return "found"
+	default:
+		return string(t)
+	}
+}
+
+// edgeColor tells dependency types apart in node boxes and the legend
+func edgeColor(dt model.DependencyType, t Theme) lipgloss.AdaptiveColor {
+	switch dt {
+	case model.DepBlocks:
+		return t.Blocked
+	case model.DepParentChild:
+		return t.Epic
+	case model.DepRelated:
+		return t.Feature
+	}
+	if dt.IsBlocking() {
+		return t.Blocked
+	}
+	return t.Subtext
+}
+
+// renderEdgeLegend lists the dependency types, marking those followed and
+// the one under the cursor
+func (g *GraphModel) renderEdgeLegend(t Theme) string {
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	parts := []string{labelStyle.Render("edges (e/x):")}
+	for i, dt := range graphEdgeTypes() {
+		mark := "□"
+		if g.edgeFilter[dt] {
+			mark = "■"
+		}
+		style := t.Renderer.NewStyle().Foreground(edgeColor(dt, t))
+		if !g.edgeFilter[dt] {
+			style = style.Faint(true)
+		}
+		if i == g.edgeCursor {
+			style = style.Underline(true).Bold(true)
+		}
+		parts = append(parts, style.Render(mark+" "+string(dt)))
+	}
+	if g.hideRedundant {
+		parts = append(parts, labelStyle.Render(fmt.Sprintf("• r: %d redundant hidden", len(g.redundant))))
+	} else {
+		parts = append(parts, labelStyle.Render("• r: hide redundant"))
+	}
+	return strings.Join(parts, " ")
+}
@@ -1,6 +1,7 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -415,3 +416,318 @@ func TestGraphModelStatusColors(t *testing.T) {
 		t.Errorf("Expected 4 nodes, got %d", g.TotalCount())
 	}
 }
+
+// TestGraphModelCycleSortMetric verifies the node list reorders by the chosen metric
+func TestGraphModelCycleSortMetric(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen},
+		{ID: "C", Title: "C", Status: model.StatusOpen},
+	}
+	ins := analysis.Insights{
+		Stats: analysis.NewGraphStatsForTest(
+			map[string]float64{"A": 0.1, "B": 0.2, "C": 0.7}, // pageRank
+			nil, nil, nil, nil,
+			map[string]float64{"A": 3, "B": 2, "C": 1}, // criticalPathScore
+			nil, nil, nil, 0, nil,
+		),
+	}
+
+	g := ui.NewGraphModel(issues, &ins, theme)
+	if g.SortMetric() != ui.GraphSortCriticalPath {
+		t.Fatalf("expected critical path sort by default, got %s", g.SortMetric())
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "A" {
+		t.Fatalf("expected A first by critical path, got %v", sel)
+	}
+
+	// Selection follows the issue across re-sorts
+	g.CycleSortMetric()
+	if g.SortMetric() != ui.GraphSortPageRank {
+		t.Fatalf("expected PageRank after cycling, got %s", g.SortMetric())
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "A" {
+		t.Fatalf("expected selection to stay on A, got %v", sel)
+	}
+	g.PageUp()
+	g.MoveUp()
+	g.MoveUp()
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "C" {
+		t.Fatalf("expected C first by PageRank, got %v", sel)
+	}
+
+	if !strings.Contains(g.View(120, 40), "by PageRank") || !strings.Contains(g.View(120, 40), "Closeness") {
+		t.Errorf("expected sort label and closeness row in graph view")
+	}
+
+	// Cycling wraps back to critical path
+	for i := 0; i < 6; i++ {
+		g.CycleSortMetric()
+	}
+	if g.SortMetric() != ui.GraphSortCriticalPath {
+		t.Errorf("expected sort metric to wrap, got %s", g.SortMetric())
+	}
+}
+
+// TestGraphModelPansWideRows verifies H/L pan rows of nodes wider than the view
+func TestGraphModelPansWideRows(t *testing.T) {
+	theme := createTheme()
+
+	issues := []model.Issue{{ID: "HUB", Title: "Hub"}}
+	for _, id := range []string{"D1", "D2", "D3", "D4", "D5", "D6", "D7", "D8"} {
+		issues = append(issues, model.Issue{ID: id, Title: id,
+			Dependencies: []*model.Dependency{{DependsOnID: "HUB", Type: model.DepBlocks}}})
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	for i := 0; i < len(issues) && g.SelectedIssue().ID != "HUB"; i++ {
+		g.MoveDown()
+	}
+	if g.SelectedIssue().ID != "HUB" {
+		t.Fatalf("expected to reach HUB")
+	}
+
+	// D8 shows once in the node list and, once in view, once as a box
+	view := g.View(100, 40)
+	if !strings.Contains(view, "H/L: pan (1-") {
+		t.Fatalf("expected a row too wide for the view to offer panning:\n%s", view)
+	}
+	if strings.Contains(view, "more") {
+		t.Fatalf("expected every dependent boxed, not summarized:\n%s", view)
+	}
+	if strings.Count(view, "D8") != 1 {
+		t.Fatalf("expected D8 past the right edge before panning:\n%s", view)
+	}
+
+	for i := 0; i < 20; i++ {
+		g.ScrollRight()
+	}
+	view = g.View(100, 40)
+	if strings.Count(view, "D8") != 2 {
+		t.Fatalf("expected D8 in view after panning right:\n%s", view)
+	}
+	if strings.Contains(view, "(1-") {
+		t.Fatalf("expected the pan position to move:\n%s", view)
+	}
+
+	for i := 0; i < 20; i++ {
+		g.ScrollLeft()
+	}
+	if view = g.View(100, 40); !strings.Contains(view, "H/L: pan (1-") {
+		t.Fatalf("expected panning back to the left edge:\n%s", view)
+	}
+}
+
+func TestGraphModelFollowsBreadcrumbTrail(t *testing.T) {
+	theme := createTheme()
+
+	// A blocks B blocks C
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha"},
+		{ID: "B", Title: "Beta", Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Gamma", Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	for i := 0; i < len(issues) && g.SelectedIssue().ID != "C"; i++ {
+		g.MoveDown()
+	}
+	if g.SelectedIssue().ID != "C" {
+		t.Fatalf("expected to reach C")
+	}
+
+	if !g.FollowNeighbor() || g.SelectedIssue().ID != "B" {
+		t.Fatalf("expected to follow C's blocker B, got %s", g.SelectedIssue().ID)
+	}
+	if !g.FollowNeighbor() || g.SelectedIssue().ID != "A" {
+		t.Fatalf("expected to follow B's blocker A, got %s", g.SelectedIssue().ID)
+	}
+	if trail := g.Trail(); len(trail) != 2 || trail[0] != "C" || trail[1] != "B" {
+		t.Fatalf("expected trail [C B], got %v", trail)
+	}
+	if view := g.View(100, 40); !strings.Contains(view, "🧭") || !strings.Contains(view, "1 C") {
+		t.Fatalf("expected the breadcrumb trail in the view:\n%s", view)
+	}
+
+	if !g.Back() || g.SelectedIssue().ID != "B" {
+		t.Fatalf("expected Back to return to B, got %s", g.SelectedIssue().ID)
+	}
+	g.FollowNeighbor()
+	if !g.JumpToCrumb(1) || g.SelectedIssue().ID != "C" || len(g.Trail()) != 0 {
+		t.Fatalf("expected crumb 1 to return to C with an empty trail, got %s %v", g.SelectedIssue().ID, g.Trail())
+	}
+	if g.Back() || g.JumpToCrumb(1) {
+		t.Fatalf("expected nothing to return to at the start of the trail")
+	}
+
+	// B has a blocker and a dependent; the first ] picks the blocker, the
+	// second the dependent
+	g.FollowNeighbor()
+	g.NextNeighbor(1)
+	g.NextNeighbor(1)
+	if !g.FollowNeighbor() || g.SelectedIssue().ID != "C" {
+		t.Fatalf("expected ] then f to follow B's dependent C, got %s", g.SelectedIssue().ID)
+	}
+	g.MoveUp()
+	if len(g.Trail()) != 0 {
+		t.Fatalf("expected moving the selection to start a new trail, got %v", g.Trail())
+	}
+}
+
+func TestGraphModelTogglesEdgeTypes(t *testing.T) {
+	theme := createTheme()
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha"},
+		{ID: "B", Title: "Beta", Dependencies: []*model.Dependency{
+			{DependsOnID: "A", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepRelated},
+		}},
+		{ID: "C", Title: "Gamma"},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	if !g.EdgeTypeEnabled(model.DepBlocks) || !g.EdgeTypeEnabled(model.DepParentChild) || g.EdgeTypeEnabled(model.DepRelated) {
+		t.Fatalf("expected blocks and parent-child edges by default")
+	}
+	for i := 0; i < len(issues) && g.SelectedIssue().ID != "B"; i++ {
+		g.MoveDown()
+	}
+	if g.SelectedIssue().ID != "B" {
+		t.Fatalf("expected to reach B")
+	}
+
+	view := g.View(120, 40)
+	if !strings.Contains(view, "blocks") || strings.Contains(view, "Gamma") {
+		t.Fatalf("expected only the blocks edge drawn:\n%s", view)
+	}
+
+	// Cursor: blocks → parent-child → related
+	g.NextEdgeType()
+	g.NextEdgeType()
+	if edge, on := g.ToggleEdgeType(); edge != model.DepRelated || !on {
+		t.Fatalf("expected related edges turned on, got %s %v", edge, on)
+	}
+	if g.SelectedIssue().ID != "B" {
+		t.Fatalf("expected the selection kept, got %s", g.SelectedIssue().ID)
+	}
+	if view = g.View(120, 40); !strings.Contains(view, "Gamma") || !strings.Contains(view, "related") {
+		t.Fatalf("expected the related edge drawn and labelled:\n%s", view)
+	}
+
+	if edge, on := g.ToggleEdgeType(); edge != model.DepRelated || on {
+		t.Fatalf("expected related edges turned off again, got %s %v", edge, on)
+	}
+	if view = g.View(120, 40); strings.Contains(view, "Gamma") {
+		t.Fatalf("expected the related edge gone:\n%s", view)
+	}
+}
+
+func TestGraphModelLinksMentions(t *testing.T) {
+	theme := createTheme()
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Description: "Can't start until C lands"},
+		{ID: "B", Title: "Beta"},
+		{ID: "C", Title: "Gamma"},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	if g.EdgeTypeEnabled(analysis.DepMentions) {
+		t.Fatalf("expected mentions off by default")
+	}
+	for i := 0; i < len(issues) && g.SelectedIssue().ID != "A"; i++ {
+		g.MoveDown()
+	}
+	if view := g.View(120, 40); strings.Contains(view, "Gamma") {
+		t.Fatalf("expected no edges drawn yet:\n%s", view)
+	}
+
+	// Cursor: blocks → parent-child → related → discovered-from → mentions
+	for i := 0; i < 4; i++ {
+		g.NextEdgeType()
+	}
+	if edge, on := g.ToggleEdgeType(); edge != analysis.DepMentions || !on {
+		t.Fatalf("expected mentions turned on, got %s %v", edge, on)
+	}
+	if view := g.View(120, 40); !strings.Contains(view, "Gamma") || !strings.Contains(view, "mentions") {
+		t.Fatalf("expected C drawn as A's blocker, labelled mentions:\n%s", view)
+	}
+}
+
+func TestGraphModelHidesRedundantEdges(t *testing.T) {
+	theme := createTheme()
+
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepBlocks},
+		}},
+		{ID: "B", Title: "Beta", Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Gamma"},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	for i := 0; i < len(issues) && g.SelectedIssue().ID != "A"; i++ {
+		g.MoveDown()
+	}
+	if g.SelectedIssue().ID != "A" {
+		t.Fatalf("expected to reach A")
+	}
+	if view := g.View(140, 40); !strings.Contains(view, "Gamma") {
+		t.Fatalf("expected A's direct blocker C drawn:\n%s", view)
+	}
+
+	if hidden, n := g.ToggleRedundant(); !hidden || n != 1 {
+		t.Fatalf("expected the one redundant edge A→C hidden, got %v %d", hidden, n)
+	}
+	if g.SelectedIssue().ID != "A" {
+		t.Fatalf("expected the selection kept, got %s", g.SelectedIssue().ID)
+	}
+	view := g.View(140, 40)
+	if strings.Contains(view, "Gamma") || !strings.Contains(view, "1 redundant hidden") {
+		t.Fatalf("expected only B drawn as A's blocker:\n%s", view)
+	}
+
+	if hidden, _ := g.ToggleRedundant(); hidden {
+		t.Fatalf("expected redundant edges shown again")
+	}
+}
+
+func TestGraphModelGroupsNodesByCluster(t *testing.T) {
+	theme := createTheme()
+
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Dependencies: blocks("C")},
+		{ID: "B", Title: "Beta"},
+		{ID: "C", Title: "Gamma"},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+	if g.SelectedIssue().ID != "A" {
+		t.Fatalf("expected ID order without metrics, got %s first", g.SelectedIssue().ID)
+	}
+	g.MoveDown()
+
+	clusters := g.ToggleClusters()
+	if clusters == nil || len(clusters.Clusters) != 1 {
+		t.Fatalf("expected the one cluster A-C, got %+v", clusters)
+	}
+	if g.SelectedIssue().ID != "B" {
+		t.Fatalf("expected the selection kept, got %s", g.SelectedIssue().ID)
+	}
+	// A and C come first as a cluster, then the unclustered B
+	g.MoveUp()
+	if g.SelectedIssue().ID != "C" {
+		t.Errorf("expected C just above B, got %s", g.SelectedIssue().ID)
+	}
+	if view := g.View(140, 40); !strings.Contains(view, "Cluster 1") {
+		t.Errorf("expected the cluster legend:\n%s", view)
+	}
+
+	if g.ToggleClusters() != nil {
+		t.Errorf("expected clusters turned off")
+	}
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxTrailCrumbs is how many of the latest steps the breadcrumb trail shows
+const maxTrailCrumbs = 9
+
+// neighbors returns the issues id can be followed to in the node list: its
+// blockers, then its dependents
+func (g *GraphModel) neighbors(id string) []string {
+	var ids []string
+	for _, list := range [][]string{g.blockers[id], g.dependents[id]} {
+		for _, n := range list {
+			if _, ok := g.issueMap[n]; ok {
+				ids = append(ids, n)
+			}
+		}
+	}
+	return ids
+}
+
+// highlightedNeighbor returns the blocker or dependent that f would follow,
+// or "" when the selected issue has none
+func (g *GraphModel) highlightedNeighbor() string {
+	issue := g.SelectedIssue()
+	if issue == nil {
+		return ""
+	}
+	ids := g.neighbors(issue.ID)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[min(g.neighbor, len(ids)-1)]
+}
+
+// NextNeighbor moves the highlight to the next (delta 1) or previous (delta
+// -1) blocker or dependent of the selected issue, wrapping around
+func (g *GraphModel) NextNeighbor(delta int) {
+	issue := g.SelectedIssue()
+	if issue == nil {
+		return
+	}
+	n := len(g.neighbors(issue.ID))
+	if n == 0 {
+		return
+	}
+	if !g.picked {
+		// The first press picks the first or last neighbor
+		g.picked = true
+		g.neighbor = 0
+		if delta < 0 {
+			g.neighbor = n - 1
+		}
+		return
+	}
+	g.neighbor = ((min(g.neighbor, n-1)+delta)%n + n) % n
+}
+
+// NeighborPicked reports whether [ or ] has put the cursor on one of the
+// selected issue's blockers or dependents
+func (g *GraphModel) NeighborPicked() bool {
+	return g.picked && g.highlightedNeighbor() != ""
+}
+
+// FollowNeighbor selects the highlighted blocker or dependent, adding the
+// issue it was reached from to the trail. It reports whether there was one.
+func (g *GraphModel) FollowNeighbor() bool {
+	issue := g.SelectedIssue()
+	target := g.highlightedNeighbor()
+	if issue == nil || target == "" {
+		return false
+	}
+	g.trail = append(g.trail, issue.ID)
+	g.selectID(target)
+	return true
+}
+
+// Back returns to the issue the selected one was followed from, reporting
+// whether the trail had one
+func (g *GraphModel) Back() bool {
+	return g.returnTo(len(g.trail) - 1)
+}
+
+// JumpToCrumb returns to the crumb numbered n in the trail (see renderTrail),
+// dropping the steps taken after it. It reports whether the crumb exists.
+func (g *GraphModel) JumpToCrumb(n int) bool {
+	if n < 1 {
+		return false
+	}
+	return g.returnTo(g.firstCrumb() + n - 1)
+}
+
+// returnTo selects the i-th issue of the trail, 0 being where it began
+func (g *GraphModel) returnTo(i int) bool {
+	if i < 0 || i >= len(g.trail) {
+		return false
+	}
+	id := g.trail[i]
+	g.trail = g.trail[:i]
+	g.selectID(id)
+	return true
+}
+
+// firstCrumb is the index in the trail of the oldest crumb shown
+func (g *GraphModel) firstCrumb() int {
+	return max(len(g.trail)-maxTrailCrumbs, 0)
+}
+
+// Trail returns the issues followed through to reach the selected one, oldest first
+func (g *GraphModel) Trail() []string {
+	return g.trail
+}
+
+// leaveTrail forgets the trail, once the selection moves by other means
+func (g *GraphModel) leaveTrail() {
+	g.trail = nil
+	g.neighbor = 0
+	g.picked = false
+}
+
+// selectID selects id in the node list, dropping the neighbor cursor
+func (g *GraphModel) selectID(id string) {
+	for i, sid := range g.sortedIDs {
+		if sid == id {
+			g.selectedIdx = i
+			break
+		}
+	}
+	g.neighbor = 0
+	g.picked = false
+}
+
+// renderTrail renders the breadcrumb trail ending at the selected issue,
+// numbered for alt+1-9, or "" before anything has been followed
+func (g *GraphModel) renderTrail(current string, width int, t Theme) string {
+	if len(g.trail) == 0 {
+		return ""
+	}
+	crumbStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	currentStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	sep := crumbStyle.Render(" › ")
+
+	start := g.firstCrumb()
+	var crumbs []string
+	if start > 0 {
+		crumbs = append(crumbs, crumbStyle.Render("…"))
+	}
+	for i := start; i < len(g.trail); i++ {
+		crumbs = append(crumbs, crumbStyle.Render(fmt.Sprintf("%d %s", i-start+1, g.trail[i])))
+	}
+	crumbs = append(crumbs, currentStyle.Render(current))
+	trail := strings.Join(crumbs, sep)
+
+	// Keep the latest steps when the trail is too long
+	if over := lipgloss.Width(trail) + 3 - width; over > 0 {
+		trail = ansi.TruncateLeft(trail, over+1, "…")
+	}
+	return "🧭 " + trail
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Register the decoders image attachments need
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"strings"
+)
+
+// Graphics protocols for previewing images in the terminal
+const (
+	graphicsKitty = "kitty"
+	graphicsITerm = "iterm"
+	graphicsSixel = "sixel"
+)
+
+// imagePreviews are the view.image_preview settings: a protocol, "off" for
+// the text fallback, or "auto" to detect the terminal
+var imagePreviews = []string{"auto", graphicsKitty, graphicsITerm, graphicsSixel, "off"}
+
+// Cell size assumed when sizing Sixel images, which are drawn in pixels. It
+// errs small so images stay inside the cells reserved for them.
+const (
+	sixelCellWidth  = 8
+	sixelCellHeight = 16
+)
+
+// maxPreviewBytes is the largest image file the preview decodes
+const maxPreviewBytes = 32 << 20
+
+// detectGraphics picks the graphics protocol the terminal described by the
+// environment supports, or "" if none is known to work. tmux swallows the
+// escapes unless told to pass them through, so previews stay off inside it.
+func detectGraphics(getenv func(string) string) string {
+	if getenv("TMUX") != "" {
+		return ""
+	}
+	term := getenv("TERM")
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty",
+		getenv("TERM_PROGRAM") == "ghostty":
+		return graphicsKitty
+	case getenv("TERM_PROGRAM") == "iTerm.app", getenv("LC_TERMINAL") == "iTerm2",
+		getenv("TERM_PROGRAM") == "WezTerm":
+		return graphicsITerm
+	case strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"):
+		return graphicsSixel
+	}
+	return ""
+}
+
+// decodeImageFile reads and decodes a PNG, JPEG or GIF
+func decodeImageFile(path string) (image.Image, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxPreviewBytes {
+		return nil, fmt.Errorf("too large to preview (%s)", formatBytes(info.Size()))
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode image: %w", err)
+	}
+	return img, nil
+}
+
+// fitImage scales img down, keeping its aspect ratio, to at most maxW by
+// maxH pixels. Images that already fit are returned as they are.
+func fitImage(img image.Image, maxW, maxH int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= 0 || h <= 0 || (w <= maxW && h <= maxH) {
+		return img
+	}
+	scale := min(float64(maxW)/float64(w), float64(maxH)/float64(h))
+	nw, nh := max(int(float64(w)*scale), 1), max(int(float64(h)*scale), 1)
+	out := image.NewNRGBA(image.Rect(0, 0, nw, nh))
+	for y := 0; y < nh; y++ {
+		sy := b.Min.Y + y*h/nh
+		for x := 0; x < nw; x++ {
+			out.Set(x, y, img.At(b.Min.X+x*w/nw, sy))
+		}
+	}
+	return out
+}
+
+// encodeGraphics returns the escape sequence that draws img in cols by rows
+// cells at the cursor, leaving the cursor where it was
+func encodeGraphics(protocol string, img image.Image, cols, rows int) (string, error) {
+	switch protocol {
+	case graphicsKitty:
+		data, err := encodePNG(fitImage(img, cols*sixelCellWidth*2, rows*sixelCellHeight*2))
+		if err != nil {
+			return "", err
+		}
+		return "\x1b7" + kittyImage(data, cols, rows) + "\x1b8", nil
+	case graphicsITerm:
+		data, err := encodePNG(fitImage(img, cols*sixelCellWidth*2, rows*sixelCellHeight*2))
+		if err != nil {
+			return "", err
+		}
+		return "\x1b7" + itermImage(data, cols, rows) + "\x1b8", nil
+	case graphicsSixel:
+		return "\x1b7" + sixelImage(fitImage(img, cols*sixelCellWidth, rows*sixelCellHeight)) + "\x1b8", nil
+	}
+	return "", fmt.Errorf("unknown graphics protocol %q", protocol)
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// kittyDeleteImages removes every image the kitty protocol has placed
+const kittyDeleteImages = "\x1b_Ga=d,q=2\x1b\\"
+
+// kittyImage transmits a PNG and places it over cols by rows cells, in the
+// 4096-byte chunks the protocol allows, replacing any image placed before.
+// q=2 stops the terminal answering, which would arrive as key presses.
+func kittyImage(data []byte, cols, rows int) string {
+	payload := base64.StdEncoding.EncodeToString(data)
+	var sb strings.Builder
+	sb.WriteString(kittyDeleteImages)
+	for first := true; first || payload != ""; first = false {
+		chunk := payload[:min(len(payload), 4096)]
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&sb, "\x1b_Gf=100,a=T,q=2,C=1,c=%d,r=%d,m=%d;%s\x1b\\", cols, rows, more, chunk)
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return sb.String()
+}
+
+// itermImage draws an image inline with iTerm2's protocol, which WezTerm
+// also speaks, scaled to fit cols by rows cells
+func itermImage(data []byte, cols, rows int) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=1:%s\a",
+		len(data), cols, rows, base64.StdEncoding.EncodeToString(data))
+}
+
+// sixelImage encodes img as Sixel graphics in a 6×6×6 color cube, leaving
+// transparent pixels unpainted
+func sixelImage(img image.Image) string {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+
+	// Palette index per pixel, -1 for transparent
+	pixels := make([]int, w*h)
+	used := make([]bool, 216)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBAModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.NRGBA)
+			if c.A < 128 {
+				pixels[y*w+x] = -1
+				continue
+			}
+			i := int(c.R)*6/256*36 + int(c.G)*6/256*6 + int(c.B)*6/256
+			pixels[y*w+x] = i
+			used[i] = true
+		}
+	}
+	for i, ok := range used {
+		if ok {
+			fmt.Fprintf(&sb, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+		}
+	}
+
+	// Six rows per band, one pass per color in the band
+	for top := 0; top < h; top += 6 {
+		inBand := make([]bool, 216)
+		for y := top; y < min(top+6, h); y++ {
+			for x := 0; x < w; x++ {
+				if p := pixels[y*w+x]; p >= 0 {
+					inBand[p] = true
+				}
+			}
+		}
+		firstPass := true
+		for i, ok := range inBand {
+			if !ok {
+				continue
+			}
+			if !firstPass {
+				sb.WriteByte('$')
+			}
+			firstPass = false
+			fmt.Fprintf(&sb, "#%d", i)
+			run, last := 0, byte(0)
+			flush := func() {
+				switch {
+				case run > 3:
+					fmt.Fprintf(&sb, "!%d%c", run, last)
+				case run > 0:
+					sb.WriteString(strings.Repeat(string(last), run))
+				}
+			}
+			for x := 0; x < w; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && top+dy < h; dy++ {
+					if pixels[(top+dy)*w+x] == i {
+						bits |= 1 << dy
+					}
+				}
+				ch := byte(63 + bits)
+				if ch != last {
+					flush()
+					run, last = 0, ch
+				}
+				run++
+			}
+			flush()
+		}
+		sb.WriteByte('-')
+	}
+	sb.WriteString("\x1b\\")
+	return sb.String()
+}
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// GroupBy selects how the list is partitioned into sections
+type GroupBy int
+
+const (
+	GroupNone     GroupBy = iota // Flat list
+	GroupStatus                  // One section per status
+	GroupAssignee                // One section per assignee
+	GroupEpic                    // One section per parent epic
+	GroupLabel                   // One section per label (issues may appear in several)
+	GroupCluster                 // One section per detected cluster of linked work
+	groupByCount                 // Sentinel for cycling
+)
+
+// String returns the display name of the grouping
+func (g GroupBy) String() string {
+	switch g {
+	case GroupStatus:
+		return "Status"
+	case GroupAssignee:
+		return "Assignee"
+	case GroupEpic:
+		return "Epic"
+	case GroupLabel:
+		return "Label"
+	case GroupCluster:
+		return "Cluster"
+	default:
+		return "None"
+	}
+}
+
+// Next returns the following grouping mode, wrapping back to GroupNone
+func (g GroupBy) Next() GroupBy {
+	return (g + 1) % groupByCount
+}
+
+// GroupHeaderItem is a section header row inserted between issue rows
+type GroupHeaderItem struct {
+	Key       string // Stable identity used for collapse state
+	Label     string // Human-readable section name
+	Count     int    // Number of issues in the section
+	Collapsed bool
+}
+
+// FilterValue returns an empty string so headers drop out of fuzzy search results
+func (g GroupHeaderItem) FilterValue() string { return "" }
+
+// Title implements list.DefaultItem for consistency with IssueItem
+func (g GroupHeaderItem) Title() string { return g.Label }
+
+// Description implements list.DefaultItem for consistency with IssueItem
+func (g GroupHeaderItem) Description() string { return fmt.Sprintf("%d issues", g.Count) }
+
+// groupKey identifies a section and its position in the report
+type groupKey struct {
+	key   string
+	label string
+	order int
+}
+
+// Sentinel labels for issues that have no value for the grouping field
+const (
+	groupUnassigned = "Unassigned"
+	groupNoEpic     = "No epic"
+	groupNoLabel    = "No label"
+	groupNoCluster  = "Unclustered"
+)
+
+// groupKeysFor returns the sections an issue belongs to under the given
+// grouping. clusters is only used by GroupCluster.
+func groupKeysFor(issue model.Issue, by GroupBy, issueMap map[string]*model.Issue, clusters *analysis.Clusters) []groupKey {
+	switch by {
+	case GroupStatus:
+		return []groupKey{{key: string(issue.Status), label: string(issue.Status), order: statusGroupOrder(issue.Status)}}
+	case GroupAssignee:
+		if issue.Assignee == "" {
+			return []groupKey{{key: "", label: groupUnassigned, order: 1}}
+		}
+		return []groupKey{{key: issue.Assignee, label: "@" + issue.Assignee}}
+	case GroupEpic:
+		if epicID := parentEpicID(issue, issueMap); epicID != "" {
+			label := epicID
+			if epic, ok := issueMap[epicID]; ok && epic.Title != "" {
+				label = epicID + " " + epic.Title
+			}
+			return []groupKey{{key: epicID, label: label}}
+		}
+		return []groupKey{{key: "", label: groupNoEpic, order: 1}}
+	case GroupLabel:
+		if len(issue.Labels) == 0 {
+			return []groupKey{{key: "", label: groupNoLabel, order: 1}}
+		}
+		keys := make([]groupKey, 0, len(issue.Labels))
+		for _, l := range issue.Labels {
+			keys = append(keys, groupKey{key: l, label: l})
+		}
+		return keys
+	case GroupCluster:
+		if clusters == nil {
+			return []groupKey{{key: "", label: groupNoCluster}}
+		}
+		cl := clusters.Cluster(clusters.Of[issue.ID])
+		if cl == nil {
+			return []groupKey{{key: "", label: groupNoCluster, order: len(clusters.Clusters) + 1}}
+		}
+		// Largest first, rather than by label
+		return []groupKey{{key: fmt.Sprint(cl.ID), label: clusterLabel(*cl, issueMap), order: cl.ID}}
+	}
+	return nil
+}
+
+// clusterLabel names a cluster after the epic most of it belongs to, e.g.
+// "Cluster 2 · mostly E1 Billing (67%)"
+func clusterLabel(cl analysis.Cluster, issueMap map[string]*model.Issue) string {
+	label := fmt.Sprintf("Cluster %d", cl.ID)
+	if cl.Epic == "" {
+		return label + " · no epic"
+	}
+	epic := cl.Epic
+	if issue, ok := issueMap[cl.Epic]; ok && issue.Title != "" {
+		epic += " " + issue.Title
+	}
+	if cl.EpicShare >= 1 {
+		return label + " · " + epic
+	}
+	return fmt.Sprintf("%s · mostly %s (%.0f%%)", label, epic, cl.EpicShare*100)
+}
+
+// statusGroupOrder orders status sections in workflow order
+func statusGroupOrder(s model.Status) int {
+	switch s {
+	case model.StatusInProgress:
+		return 0
+	case model.StatusOpen:
+		return 1
+	case model.StatusBlocked:
+		return 2
+	case model.StatusClosed:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// parentEpicID returns the ID of the epic an issue belongs to via a parent-child dependency.
+// Epics are their own group so they sit alongside their children.
+func parentEpicID(issue model.Issue, issueMap map[string]*model.Issue) string {
+	if issue.IssueType == model.TypeEpic {
+		return issue.ID
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.Type != model.DepParentChild {
+			continue
+		}
+		if parent, ok := issueMap[dep.DependsOnID]; ok && parent.IssueType == model.TypeEpic {
+			return parent.ID
+		}
+	}
+	return ""
+}
+
+// groupIssueItems partitions already-sorted items into sections with header rows.
+// Item order within each section is preserved; collapsed sections keep only their header.
+func groupIssueItems(items []list.Item, by GroupBy, issueMap map[string]*model.Issue, clusters *analysis.Clusters, collapsed map[string]bool) []list.Item {
+	if by == GroupNone {
+		return items
+	}
+
+	type section struct {
+		groupKey
+		items []list.Item
+	}
+	sections := make(map[string]*section)
+	var order []*section
+
+	for _, it := range items {
+		issueItem, ok := it.(IssueItem)
+		if !ok {
+			continue
+		}
+		for _, gk := range groupKeysFor(issueItem.Issue, by, issueMap, clusters) {
+			sec, exists := sections[gk.key]
+			if !exists {
+				sec = &section{groupKey: gk}
+				sections[gk.key] = sec
+				order = append(order, sec)
+			}
+			sec.items = append(sec.items, it)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].order != order[j].order {
+			return order[i].order < order[j].order
+		}
+		return strings.ToLower(order[i].label) < strings.ToLower(order[j].label)
+	})
+
+	result := make([]list.Item, 0, len(items)+len(order))
+	for _, sec := range order {
+		// Namespace the key by grouping so collapse state doesn't leak between modes
+		key := by.String() + ":" + sec.key
+		isCollapsed := collapsed[key]
+		result = append(result, GroupHeaderItem{
+			Key:       key,
+			Label:     sec.label,
+			Count:     len(sec.items),
+			Collapsed: isCollapsed,
+		})
+		if !isCollapsed {
+			result = append(result, sec.items...)
+		}
+	}
+	return result
+}
+
+// renderGroupHeader writes a section header row for the list delegate
+func (d IssueDelegate) renderGroupHeader(w io.Writer, m list.Model, index int, h GroupHeaderItem) {
+	t := d.Theme
+	width := m.Width()
+	if width <= 0 {
+		width = 80
+	}
+	width = width - 1
+
+	arrow := "▾"
+	if h.Collapsed {
+		arrow = "▸"
+	}
+	text := fmt.Sprintf("%s %s (%d)", arrow, h.Label, h.Count)
+	text = truncateToWidth(text, width, "…")
+
+	style := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Width(width).
+		MaxWidth(width)
+	if index == m.Index() {
+		style = style.Background(t.Highlight)
+	}
+	fmt.Fprint(w, style.Render(text))
+}
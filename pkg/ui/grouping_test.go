@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func groupTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "E1", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "T1", Title: "Child", Status: model.StatusInProgress, IssueType: model.TypeTask, Assignee: "alice", Labels: []string{"ui", "api"},
+			Dependencies: []*model.Dependency{{IssueID: "T1", DependsOnID: "E1", Type: model.DepParentChild}}},
+		{ID: "T2", Title: "Loose", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+}
+
+func headerLabels(m Model) []string {
+	var labels []string
+	for _, item := range m.list.Items() {
+		if h, ok := item.(GroupHeaderItem); ok {
+			labels = append(labels, h.Label)
+		}
+	}
+	return labels
+}
+
+func TestGroupByCyclesAndBuildsSections(t *testing.T) {
+	m := NewModel(groupTestIssues(), nil, "")
+	// Show closed issues too
+	m.SetFilter("all")
+
+	want := map[GroupBy]string{
+		GroupStatus:   "in_progress,open,closed",
+		GroupAssignee: "@alice,Unassigned",
+		GroupEpic:     "E1 Epic,No epic",
+		GroupLabel:    "api,ui,No label",
+		GroupCluster:  "Unclustered", // Parent-child links don't cluster
+	}
+	for _, by := range []GroupBy{GroupStatus, GroupAssignee, GroupEpic, GroupLabel, GroupCluster} {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+		m = updated.(Model)
+		if m.GroupBy() != by {
+			t.Fatalf("expected grouping %s, got %s", by, m.GroupBy())
+		}
+		if got := strings.Join(headerLabels(m), ","); got != want[by] {
+			t.Errorf("%s: got sections %s, want %s", by, got, want[by])
+		}
+	}
+
+	// Label grouping repeats T1 under each label but FilteredIssues stays unique
+	if got := len(m.FilteredIssues()); got != 3 {
+		t.Errorf("expected 3 unique issues, got %d", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	m = updated.(Model)
+	if m.GroupBy() != GroupNone || len(headerLabels(m)) != 0 {
+		t.Fatalf("expected grouping to wrap back to none")
+	}
+}
+
+func TestGroupByCluster(t *testing.T) {
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		{ID: "E1", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Title: "A", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: append(blocks("B", "C"), &model.Dependency{DependsOnID: "E1", Type: model.DepParentChild})},
+		{ID: "B", Title: "B", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: blocks("C")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "D", Title: "D", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	m.groupBy = GroupLabel
+	m.cycleGroupBy()
+	if m.GroupBy() != GroupCluster {
+		t.Fatalf("expected cluster grouping after label, got %s", m.GroupBy())
+	}
+	if got := strings.Join(headerLabels(m), ","); got != "Cluster 1 · no epic,Unclustered" {
+		t.Errorf("unexpected sections %s", got)
+	}
+	if !strings.Contains(m.statusMsg, "1 cluster(s)") {
+		t.Errorf("expected the cluster count reported, got %q", m.statusMsg)
+	}
+}
+
+func TestGroupCollapseToggle(t *testing.T) {
+	m := NewModel(groupTestIssues(), nil, "")
+	m.SetFilter("all")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	m = updated.(Model)
+
+	m.list.Select(0)
+	header, ok := m.list.SelectedItem().(GroupHeaderItem)
+	if !ok {
+		t.Fatalf("expected first row to be a section header")
+	}
+	before := len(m.list.Items())
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if got := len(m.list.Items()); got != before-header.Count {
+		t.Fatalf("expected collapsed section to hide %d rows, got %d -> %d", header.Count, before, got)
+	}
+	if h, ok := m.list.SelectedItem().(GroupHeaderItem); !ok || h.Key != header.Key || !h.Collapsed {
+		t.Fatalf("expected cursor to stay on collapsed header")
+	}
+	if m.showDetails {
+		t.Fatalf("enter on a header should not open details")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(Model)
+	if got := len(m.list.Items()); got != before {
+		t.Fatalf("expected section expanded again, got %d rows", got)
+	}
+}
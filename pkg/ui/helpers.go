@@ -4,57 +4,128 @@ import (
 	"fmt"
 	"strings"
 	"time"
-	"unicode/utf8"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	"github.com/rivo/uniseg"
 )
 
-// FormatTimeRel returns a relative time string (e.g., "2h ago", "3d ago")
+// FormatTimeRel returns a relative time string (e.g., "2h ago", "3d ago"),
+// or the date when time.style is absolute
 func FormatTimeRel(t time.Time) string {
-	if t.IsZero() {
-		return "unknown"
+	return timefmt.Age(t, time.Now())
+}
+
+// FormatDueRel formats a due date relative to now in calendar days:
+// "3d late", "today", "in 2d", or a short date when more than a week out.
+func FormatDueRel(due, now time.Time) string {
+	days := calendarDaysBetween(now, due)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd late", -days)
+	case days == 0:
+		return "today"
+	case days <= 7:
+		return fmt.Sprintf("in %dd", days)
+	default:
+		return due.Format("Jan 02")
+	}
+}
+
+// calendarDaysBetween returns the number of calendar days from a to b, counted in
+// b's location so date-only due dates stored as UTC midnight don't shift a day.
+func calendarDaysBetween(a, b time.Time) int {
+	a = a.In(b.Location())
+	ad := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	bd := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(bd.Sub(ad).Hours() / 24)
+}
+
+// FormatMinutes formats an estimate compactly: "45m", "3h", "2h30m"
+func FormatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	h, m := minutes/60, minutes%60
+	if m == 0 {
+		return fmt.Sprintf("%dh", h)
 	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
 
-	d := time.Since(t)
-	if d < 0 {
-		// Future timestamps treated as now
-		return "now"
+// FormatSlack formats slack in working days of analysis.DefaultDayMinutes:
+// "1 day", "2.5 days", or hours and minutes under a day
+func FormatSlack(minutes int) string {
+	if minutes < analysis.DefaultDayMinutes {
+		return FormatMinutes(minutes)
+	}
+	days := strings.TrimSuffix(fmt.Sprintf("%.1f", float64(minutes)/analysis.DefaultDayMinutes), ".0")
+	if days == "1" {
+		return "1 day"
 	}
+	return days + " days"
+}
+
+// FormatSpan formats an elapsed time at a resolution suited to flow metrics:
+// "45m", "5h", "3.5d", "12d"
+func FormatSpan(d time.Duration) string {
 	switch {
-	case d < time.Minute:
-		return "now"
 	case d < time.Hour:
-		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+		return fmt.Sprintf("%dm", int(d.Minutes()))
 	case d < 24*time.Hour:
-		return fmt.Sprintf("%dh ago", int(d.Hours()))
-	case d < 7*24*time.Hour:
-		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
-	case d < 30*24*time.Hour:
-		return fmt.Sprintf("%dw ago", int(d.Hours()/(24*7)))
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 10*24*time.Hour:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", d.Hours()/24), ".0") + "d"
 	default:
-		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
 	}
 }
 
-// truncateRunesHelper truncates a string to maxRunes runes, adding suffix if needed.
-// This is UTF-8 safe.
-func truncateRunesHelper(s string, maxRunes int, suffix string) string {
-	if maxRunes <= 0 {
+// displayWidth returns the terminal columns s takes: two for wide
+// characters such as CJK and most emoji, none for combining marks
+func displayWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// truncateToWidth cuts s to at most width terminal columns, ending it with
+// suffix when anything was cut. It cuts between grapheme clusters, so an
+// emoji sequence or a letter with its combining marks stays whole, and a
+// wide character that would straddle the limit is left out.
+func truncateToWidth(s string, width int, suffix string) string {
+	if width <= 0 {
 		return ""
 	}
-
-	runeCount := utf8.RuneCountInString(s)
-	if runeCount <= maxRunes {
+	if uniseg.StringWidth(s) <= width {
 		return s
 	}
+	room := width - uniseg.StringWidth(suffix)
+	if room < 0 {
+		return truncateToWidth(suffix, width, "")
+	}
 
-	suffixLen := utf8.RuneCountInString(suffix)
-	if maxRunes <= suffixLen {
-		return suffix
+	var sb strings.Builder
+	used, state := 0, -1
+	for rest := s; rest != ""; {
+		var cluster string
+		var w int
+		cluster, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > room {
+			break
+		}
+		sb.WriteString(cluster)
+		used += w
 	}
+	return sb.String() + suffix
+}
 
-	runes := []rune(s)
-	return string(runes[:maxRunes-suffixLen]) + suffix
+// fitWidth truncates s with an ellipsis or pads it with spaces to exactly
+// width terminal columns, for fixed-width columns of user text that
+// fmt's rune-counted %-*s would misalign
+func fitWidth(s string, width int) string {
+	s = truncateToWidth(s, width, "…")
+	return s + strings.Repeat(" ", max(width-uniseg.StringWidth(s), 0))
 }
 
 // DependencyNode represents a visual node in the dependency tree
@@ -153,7 +224,7 @@ func renderTreeNode(sb *strings.Builder, node *DependencyNode, prefix string, is
 	typeIcon := getDepTypeIcon(node.Type)
 
 	// Truncate title if too long (UTF-8 safe)
-	title := truncateRunesHelper(node.Title, 40, "...")
+	title := truncateToWidth(node.Title, 40, "...")
 
 	// Render this node
 	sb.WriteString(fmt.Sprintf("%s%s%s %s %s %s (%s) [%s]\n",
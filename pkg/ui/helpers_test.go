@@ -11,7 +11,7 @@ import (
 // TestTruncateRunesHelper tests UTF-8 safe truncation
 func TestTruncateRunesHelper(t *testing.T) {
 	// Access the helper via the package - it's exported through visuals.go or similar
-	// Since truncateRunesHelper is not exported, we test it indirectly through View methods
+	// Since truncateToWidth is not exported, we test it indirectly through View methods
 	// that use it. However, let's test what we can access.
 
 	// For now, test through the public interface that uses truncation
@@ -48,10 +48,10 @@ var metricDescriptions = map[MetricPanel]MetricInfo{
 		Icon:        "🏛️",
 		Title:       "Keystones",
 		ShortDesc:   "Impact Depth",
-		WhatIs:      "Measures how deep in the dependency chain a bead sits (downstream chain length).",
+		WhatIs:      "Measures how deep in the dependency chain a bead sits (downstream chain length, weighted by estimates when present).",
 		WhyUseful:   "Keystones are foundational. Everything above them depends on their completion.",
 		HowToUse:    "Complete these first. Blocking a keystone blocks the entire chain above it.",
-		FormulaHint: "Impact(v) = 1 + max(Impact(u)) for all u that depend on v",
+		FormulaHint: "Impact(v) = effort(v) + max(Impact(u)) for all u that depend on v",
 	},
 	PanelInfluencers: {
 		Icon:        "🌐",
@@ -93,9 +93,11 @@ var metricDescriptions = map[MetricPanel]MetricInfo{
 
 // InsightsModel is an interactive insights dashboard
 type InsightsModel struct {
-	insights analysis.Insights
-	issueMap map[string]*model.Issue
-	theme    Theme
+	insights     analysis.Insights
+	issueMap     map[string]*model.Issue
+	busFactor    []analysis.BusFactorRisk // Subgraphs owned by a single assignee
+	connectivity analysis.Connectivity    // Islands and detached clusters
+	theme        Theme
 
 	// Navigation state
 	focusedPanel  MetricPanel
@@ -115,9 +117,12 @@ type InsightsModel struct {
 
 // NewInsightsModel creates a new interactive insights model
 func NewInsightsModel(ins analysis.Insights, issueMap map[string]*model.Issue, theme Theme) InsightsModel {
+	issues := issueValues(issueMap)
 	return InsightsModel{
 		insights:         ins,
 		issueMap:         issueMap,
+		busFactor:        analysis.ComputeBusFactorRisks(issues, analysis.DefaultBusFactorMinIssues),
+		connectivity:     analysis.ComputeConnectivity(issues),
 		theme:            theme,
 		showExplanations: true, // Visible by default
 		showCalculation:  true, // Always show calculation details
@@ -280,7 +285,24 @@ func (m *InsightsModel) View() string {
 		colWidth = 25
 	}
 
-	rowHeight := (m.height - 4) / 2
+	// Computing, bus-factor, connectivity and per-label strips below the metric panels (only when present)
+	var strips []string
+	for _, strip := range []string{
+		m.renderComputingStrip(mainWidth, t),
+		m.renderBusFactorStrip(mainWidth, t),
+		m.renderConnectivityStrip(mainWidth, t),
+		m.renderLabelStrip(mainWidth, t),
+	} {
+		if strip != "" {
+			strips = append(strips, strip)
+		}
+	}
+	stripHeight := 0
+	for _, strip := range strips {
+		stripHeight += lipgloss.Height(strip)
+	}
+
+	rowHeight := (m.height - 4 - stripHeight) / 2
 	if rowHeight < 8 {
 		rowHeight = 8
 	}
@@ -298,7 +320,7 @@ func (m *InsightsModel) View() string {
 	topRow := lipgloss.JoinHorizontal(lipgloss.Top, panels[0], panels[1], panels[2])
 	btmRow := lipgloss.JoinHorizontal(lipgloss.Top, panels[3], panels[4], panels[5])
 
-	mainContent := lipgloss.JoinVertical(lipgloss.Left, topRow, btmRow)
+	mainContent := lipgloss.JoinVertical(lipgloss.Left, append([]string{topRow, btmRow}, strips...)...)
 
 	// Add detail panel if enabled
 	if detailWidth > 0 {
@@ -309,6 +331,163 @@ func (m *InsightsModel) View() string {
 	return mainContent
 }
 
+// issueValues flattens an issue map into a slice
+func issueValues(issueMap map[string]*model.Issue) []model.Issue {
+	issues := make([]model.Issue, 0, len(issueMap))
+	for _, issue := range issueMap {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues
+}
+
+// labelStats computes per-label counts over the issues known to the dashboard
+func (m *InsightsModel) labelStats() []analysis.LabelStats {
+	return analysis.ComputeLabelStats(issueValues(m.issueMap))
+}
+
+// renderComputingStrip notes that Phase 2 metrics are still being computed, so
+// the panels ranked by PageRank, betweenness and critical path are provisional
+func (m *InsightsModel) renderComputingStrip(width int, t Theme) string {
+	stats := m.insights.Stats
+	if stats == nil || stats.IsPhase2Ready() {
+		return ""
+	}
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	subStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+
+	line := titleStyle.Render("⏳ Metrics computing…") +
+		subStyle.Render("  PageRank, betweenness and critical path fill in when ready")
+	return t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Width(width-2).
+		Padding(0, 1).
+		Render(line)
+}
+
+// renderConnectivityStrip summarizes open islands and clusters detached from the
+// main plan. Returns "" when everything is connected.
+func (m *InsightsModel) renderConnectivityStrip(width int, t Theme) string {
+	c := m.connectivity
+	detached := c.DetachedComponents()
+	if len(c.Islands) == 0 && len(detached) == 0 {
+		return ""
+	}
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	subStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+
+	var parts []string
+	if n := len(c.Islands); n > 0 {
+		ids := strings.Join(c.Islands[:min(n, 5)], ", ")
+		if n > 5 {
+			ids += ", …"
+		}
+		parts = append(parts, fmt.Sprintf("%d islands (%s)", n, ids))
+	}
+	if n := len(detached); n > 0 {
+		sizes := make([]string, 0, min(n, 5))
+		for _, comp := range detached[:min(n, 5)] {
+			sizes = append(sizes, fmt.Sprintf("%d", len(comp)))
+		}
+		if n > 5 {
+			sizes = append(sizes, "…")
+		}
+		parts = append(parts, fmt.Sprintf("%d detached clusters (sizes %s)", n, strings.Join(sizes, ", ")))
+	}
+
+	line := titleStyle.Render("🏝️ Unconnected") + "  " + textStyle.Render(strings.Join(parts, " · ")) +
+		subStyle.Render("  N in list to filter")
+	return t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Width(width-2).
+		Padding(0, 1).
+		Render(line)
+}
+
+// maxBusFactorRows caps how many risks the bus-factor strip lists
+const maxBusFactorRows = 3
+
+// renderBusFactorStrip lists subgraphs whose open work belongs to one person.
+// Returns "" when there are no risks.
+func (m *InsightsModel) renderBusFactorStrip(width int, t Theme) string {
+	if len(m.busFactor) == 0 {
+		return ""
+	}
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Blocked)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	subStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("🚌 Bus factor risks (%d)", len(m.busFactor))) +
+		subStyle.Render("  single owner of all open work")}
+	for i, r := range m.busFactor {
+		if i == maxBusFactorRows {
+			lines = append(lines, subStyle.Render(fmt.Sprintf("  +%d more", len(m.busFactor)-i)))
+			break
+		}
+		what := "open blockers of"
+		if r.Kind == "epic" {
+			what = "open issues under epic"
+		}
+		line := fmt.Sprintf("  ⚠ @%s holds all %d %s %s %s", r.Assignee, len(r.IssueIDs), what, r.RootID, m.getBeadTitle(r.RootID, 40))
+		if r.Unassigned > 0 {
+			line += fmt.Sprintf(" (+%d unassigned)", r.Unassigned)
+		}
+		lines = append(lines, textStyle.Render(truncateToWidth(line, width-6, "…")))
+	}
+
+	return t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Blocked).
+		Width(width-2).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// renderLabelStrip renders a single-row panel of label chips with open/total counts.
+// Returns "" when no issue has labels.
+func (m *InsightsModel) renderLabelStrip(width int, t Theme) string {
+	stats := m.labelStats()
+	if len(stats) == 0 {
+		return ""
+	}
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	countStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+	blockedStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
+
+	line := titleStyle.Render(fmt.Sprintf("🏷️ Labels (%d)", len(stats)))
+	avail := width - 6
+	for i, s := range stats {
+		entry := RenderLabelChip(t.Renderer, s.Label) + countStyle.Render(fmt.Sprintf(" %d/%d", s.Active(), s.Total))
+		if s.Blocked > 0 {
+			entry += blockedStyle.Render(fmt.Sprintf(" ⛔%d", s.Blocked))
+		}
+		more := ""
+		if rest := len(stats) - i - 1; rest > 0 {
+			more = fmt.Sprintf("  +%d more", rest)
+		}
+		if lipgloss.Width(line)+2+lipgloss.Width(entry)+len(more) > avail {
+			line += countStyle.Render(fmt.Sprintf("  +%d more", len(stats)-i))
+			break
+		}
+		line += "  " + entry
+	}
+
+	return t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Width(width-2).
+		Padding(0, 1).
+		Render(line)
+}
+
 func (m *InsightsModel) renderMetricPanel(panel MetricPanel, width, height int, t Theme) string {
 	info := metricDescriptions[panel]
 	items := m.getPanelItems(panel)
@@ -502,7 +681,7 @@ func (m *InsightsModel) renderInsightRow(id string, value float64, width int, is
 			descWidth = 0 // Don't show description if not enough space
 		}
 
-		title := truncateRunesHelper(issue.Title, titleWidth, "…")
+		title := truncateToWidth(issue.Title, titleWidth, "…")
 
 		titleStyle := t.Renderer.NewStyle()
 		if isSelected {
@@ -514,14 +693,14 @@ func (m *InsightsModel) renderInsightRow(id string, value float64, width int, is
 		if descWidth > 0 && issue.Description != "" {
 			// Clean up description - remove newlines, trim whitespace
 			desc := strings.Join(strings.Fields(issue.Description), " ")
-			desc = truncateRunesHelper(desc, descWidth, "…")
+			desc = truncateToWidth(desc, descWidth, "…")
 			descStyle := t.Renderer.NewStyle().Foreground(t.Subtext).Italic(true)
 			rowBuilder.WriteString(t.Renderer.NewStyle().Foreground(t.Secondary).Render(" - "))
 			rowBuilder.WriteString(descStyle.Render(desc))
 		}
 	} else {
 		// Fallback: just show ID
-		idTrunc := truncateRunesHelper(id, width-12-len(valueStr), "…")
+		idTrunc := truncateToWidth(id, width-12-len(valueStr), "…")
 		idStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
 		if isSelected {
 			idStyle = idStyle.Foreground(t.Primary).Bold(true)
@@ -687,10 +866,10 @@ func (m *InsightsModel) renderCycleChain(cycle []string, maxWidth int, t Theme)
 	for _, id := range cycle {
 		// Try to get short title (check both key existence and nil value)
 		if issue, ok := m.issueMap[id]; ok && issue != nil {
-			shortTitle := truncateRunesHelper(issue.Title, 15, "…")
+			shortTitle := truncateToWidth(issue.Title, 15, "…")
 			parts = append(parts, shortTitle)
 		} else {
-			parts = append(parts, truncateRunesHelper(id, 12, "…"))
+			parts = append(parts, truncateToWidth(id, 12, "…"))
 		}
 	}
 	// Close the cycle
@@ -699,8 +878,8 @@ func (m *InsightsModel) renderCycleChain(cycle []string, maxWidth int, t Theme)
 	}
 
 	chain := strings.Join(parts, " → ")
-	if len([]rune(chain)) > maxWidth {
-		chain = truncateRunesHelper(chain, maxWidth, "…")
+	if displayWidth(chain) > maxWidth {
+		chain = truncateToWidth(chain, maxWidth, "…")
 	}
 	return chain
 }
@@ -747,7 +926,7 @@ func (m *InsightsModel) renderDetailPanel(width, height int, t Theme) string {
 
 	// === ID (short) ===
 	idStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
-	sb.WriteString(idStyle.Render(truncateRunesHelper(issue.ID, contentWidth, "…")))
+	sb.WriteString(idStyle.Render(truncateToWidth(issue.ID, contentWidth, "…")))
 	sb.WriteString("\n\n")
 
 	// === TITLE ===
@@ -809,16 +988,16 @@ func (m *InsightsModel) renderDetailPanel(width, height int, t Theme) string {
 			depIssue := m.issueMap[dep.DependsOnID]
 			depTypeStr := string(dep.Type)
 			// Calculate prefix width: "  • " (4) + type + ": " (2)
-			prefixWidth := 6 + len([]rune(depTypeStr))
+			prefixWidth := 6 + displayWidth(depTypeStr)
 			titleWidth := contentWidth - prefixWidth
 			if titleWidth < 10 {
 				titleWidth = 10
 			}
 			if depIssue != nil {
-				depTitle := truncateRunesHelper(depIssue.Title, titleWidth, "…")
+				depTitle := truncateToWidth(depIssue.Title, titleWidth, "…")
 				sb.WriteString(fmt.Sprintf("  • %s: %s\n", depTypeStr, depTitle))
 			} else {
-				sb.WriteString(fmt.Sprintf("  • %s: %s\n", depTypeStr, truncateRunesHelper(dep.DependsOnID, titleWidth, "…")))
+				sb.WriteString(fmt.Sprintf("  • %s: %s\n", depTypeStr, truncateToWidth(dep.DependsOnID, titleWidth, "…")))
 			}
 		}
 		sb.WriteString("\n")
@@ -1095,9 +1274,9 @@ type scoredItem struct {
 // getBeadTitle returns a truncated title for a bead ID
 func (m *InsightsModel) getBeadTitle(id string, maxWidth int) string {
 	if issue, ok := m.issueMap[id]; ok && issue != nil {
-		return truncateRunesHelper(issue.Title, maxWidth, "…")
+		return truncateToWidth(issue.Title, maxWidth, "…")
 	}
-	return truncateRunesHelper(id, maxWidth, "…")
+	return truncateToWidth(id, maxWidth, "…")
 }
 
 // findDependents returns IDs of beads that depend on the given bead (sorted for consistent order)
@@ -1255,7 +1434,7 @@ func wrapText(s string, maxWidth int) string {
 	currentLen := 0
 
 	for _, word := range words {
-		wordLen := len([]rune(word))
+		wordLen := displayWidth(word)
 		if currentLen+wordLen+1 > maxWidth && currentLen > 0 {
 			lines = append(lines, currentLine.String())
 			currentLine.Reset()
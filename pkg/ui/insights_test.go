@@ -1,6 +1,7 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -554,3 +555,79 @@ func TestInsightsModelScrolling(t *testing.T) {
 		_ = m.View()
 	}
 }
+
+// TestInsightsModelBusFactorStrip verifies single-owner subgraphs are listed as risks
+func TestInsightsModelBusFactorStrip(t *testing.T) {
+	theme := createTheme()
+	issueMap := map[string]*model.Issue{
+		"EPIC": {ID: "EPIC", Title: "Payments", IssueType: model.TypeEpic, Status: model.StatusOpen},
+	}
+	for _, id := range []string{"P1", "P2", "P3"} {
+		issueMap[id] = &model.Issue{ID: id, Title: "Part " + id, Assignee: "ann", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: "EPIC", Type: model.DepParentChild}}}
+	}
+
+	m := ui.NewInsightsModel(analysis.Insights{}, issueMap, theme)
+	m.SetSize(160, 50)
+	view := m.View()
+	if !strings.Contains(view, "Bus factor risks (1)") || !strings.Contains(view, "@ann holds all 3 open issues under epic EPIC Payments") {
+		t.Fatalf("expected bus factor risk in insights view")
+	}
+
+	// A second owner removes the risk
+	issueMap["P3"].Assignee = "bob"
+	m = ui.NewInsightsModel(analysis.Insights{}, issueMap, theme)
+	m.SetSize(160, 50)
+	if strings.Contains(m.View(), "Bus factor") {
+		t.Fatalf("expected no bus factor strip with two owners")
+	}
+}
+
+func TestInsightsModelConnectivityStrip(t *testing.T) {
+	theme := createTheme()
+	issueMap := map[string]*model.Issue{
+		"A":    {ID: "A", Title: "Core", Status: model.StatusOpen},
+		"B":    {ID: "B", Title: "Follow-up", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		"C":    {ID: "C", Title: "More", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks}}},
+		"D":    {ID: "D", Title: "Side", Status: model.StatusOpen},
+		"E":    {ID: "E", Title: "Side dep", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "E", DependsOnID: "D", Type: model.DepRelated}}},
+		"LONE": {ID: "LONE", Title: "Orphan", Status: model.StatusOpen},
+	}
+
+	m := ui.NewInsightsModel(analysis.Insights{}, issueMap, theme)
+	m.SetSize(160, 50)
+	view := m.View()
+	if !strings.Contains(view, "1 islands (LONE)") || !strings.Contains(view, "1 detached clusters (sizes 2)") {
+		t.Fatalf("expected island and detached cluster summary in insights view")
+	}
+
+	// Connecting everything removes the strip
+	issueMap["D"].Dependencies = []*model.Dependency{{IssueID: "D", DependsOnID: "A", Type: model.DepBlocks}}
+	issueMap["LONE"].Dependencies = []*model.Dependency{{IssueID: "LONE", DependsOnID: "A", Type: model.DepRelated}}
+	m = ui.NewInsightsModel(analysis.Insights{}, issueMap, theme)
+	m.SetSize(160, 50)
+	if strings.Contains(m.View(), "Unconnected") {
+		t.Fatalf("expected no connectivity strip for a fully connected plan")
+	}
+}
+
+func TestInsightsModelComputingStrip(t *testing.T) {
+	theme := createTheme()
+	issueMap := map[string]*model.Issue{"A": {ID: "A", Title: "Core", Status: model.StatusOpen}}
+
+	// Phase 2 still running
+	m := ui.NewInsightsModel(analysis.Insights{Stats: &analysis.GraphStats{}}, issueMap, theme)
+	m.SetSize(160, 50)
+	if !strings.Contains(m.View(), "Metrics computing") {
+		t.Fatalf("expected computing indicator while Phase 2 runs")
+	}
+
+	// Phase 2 finished
+	stats := analysis.NewAnalyzer([]model.Issue{*issueMap["A"]}).AnalyzeAsync()
+	stats.WaitForPhase2()
+	m = ui.NewInsightsModel(analysis.Insights{Stats: stats}, issueMap, theme)
+	m.SetSize(160, 50)
+	if strings.Contains(m.View(), "Metrics computing") {
+		t.Fatalf("expected no computing indicator once Phase 2 is ready")
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LinksConfigFilename is the per-project file holding the issue URL template
+const LinksConfigFilename = "links.yaml"
+
+// linksConfig is the contents of .bv/links.yaml
+type linksConfig struct {
+	// IssueURL is a Go template executed with the issue, e.g.
+	// "https://github.com/owner/repo/issues/{{.ID}}". .ID is path-escaped.
+	IssueURL string `yaml:"issue_url"`
+}
+
+// LoadIssueURLTemplate reads the issue URL template from .bv/links.yaml in
+// projectDir. It returns nil without an error when none is configured.
+func LoadIssueURLTemplate(projectDir string) (*template.Template, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, ".bv", LinksConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading links config: %w", err)
+	}
+
+	var cfg linksConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing links config: %w", err)
+	}
+	return ParseIssueURLTemplate(cfg.IssueURL)
+}
+
+// ParseIssueURLTemplate compiles an issue_url template; blank text gives nil
+func ParseIssueURLTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("issue_url").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing issue_url: %w", err)
+	}
+	return tmpl, nil
+}
+
+// IssueURL fills in the template for issue. The ID is path-escaped, so one
+// with "/", "#", "?" or spaces (fine as a Markdown file name) stays a single
+// path segment.
+func IssueURL(tmpl *template.Template, issue model.Issue) (string, error) {
+	issue.ID = url.PathEscape(issue.ID)
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, issue); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// openIssueInBrowser opens the selected issue's page from the project's URL template
+func (m *Model) openIssueInBrowser() {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return
+	}
+	if m.issueURL == nil {
+		m.statusMsg = "❌ No issue URL configured (set issue_url in .bv/" + LinksConfigFilename + ")"
+		m.statusIsError = true
+		return
+	}
+	link, err := IssueURL(m.issueURL, issue)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Bad issue_url template: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.openInBrowser(link)
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeLinksConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", LinksConfigFilename), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadIssueURLTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if tmpl, err := LoadIssueURLTemplate(dir); tmpl != nil || err != nil {
+		t.Fatalf("expected no template without a config file, got %v, %v", tmpl, err)
+	}
+
+	writeLinksConfig(t, dir, `issue_url: "https://tracker.example.com/browse/{{.ID}}?q={{urlquery .Title}}"`)
+	tmpl, err := LoadIssueURLTemplate(dir)
+	if err != nil || tmpl == nil {
+		t.Fatalf("expected a template, got %v, %v", tmpl, err)
+	}
+	url, err := IssueURL(tmpl, model.Issue{ID: "bv-7", Title: "Fix it"})
+	if err != nil || url != "https://tracker.example.com/browse/bv-7?q=Fix+it" {
+		t.Errorf("unexpected URL %q (%v)", url, err)
+	}
+
+	// IDs that aren't URL-safe stay one path segment
+	url, err = IssueURL(tmpl, model.Issue{ID: "team/bv 1?#", Title: "Fix it"})
+	if err != nil || url != "https://tracker.example.com/browse/team%2Fbv%201%3F%23?q=Fix+it" {
+		t.Errorf("expected the ID escaped, got %q (%v)", url, err)
+	}
+
+	writeLinksConfig(t, dir, `issue_url: "https://x/{{.ID"`)
+	if _, err := LoadIssueURLTemplate(dir); err == nil {
+		t.Error("expected an error for a malformed template")
+	}
+}
+
+func TestOpenIssueInBrowserFromDetails(t *testing.T) {
+	dir := t.TempDir()
+	writeLinksConfig(t, dir, `issue_url: "https://github.com/owner/repo/issues/{{.ID}}"`)
+	beads := filepath.Join(dir, ".beads", "beads.jsonl")
+	os.MkdirAll(filepath.Dir(beads), 0755)
+	if err := os.WriteFile(beads, []byte(`{"id":"bv-1","title":"One","status":"open","issue_type":"task"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var opened string
+	orig := openURL
+	openURL = func(url string) error { opened = url; return nil }
+	defer func() { openURL = orig }()
+
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}, nil, beads)
+	defer m.Stop()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = updated.(Model)
+
+	// In the list, o still filters to open issues
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = updated.(Model)
+	if opened != "" || m.currentFilter != "open" {
+		t.Fatalf("expected o to filter the list, got filter %q, opened %q", m.currentFilter, opened)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = updated.(Model)
+	if opened != "https://github.com/owner/repo/issues/bv-1" {
+		t.Errorf("expected the issue opened from its details, got %q (status %q)", opened, m.statusMsg)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
 )
 
 // DiffStatus represents the diff state of an issue in time-travel mode
@@ -36,8 +37,9 @@ type IssueItem struct {
 	Issue      model.Issue
 	GraphScore float64
 	Impact     float64
-	DiffStatus DiffStatus // Diff state for time-travel mode
-	RepoPrefix string     // Repository prefix for workspace mode (e.g., "api", "web")
+	DiffStatus DiffStatus     // Diff state for time-travel mode
+	RepoPrefix string         // Repository prefix for workspace mode (e.g., "api", "web")
+	Script     *script.Result // Values of the user's scripts, nil when none are configured
 }
 
 func (i IssueItem) Title() string {
@@ -0,0 +1,336 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelSorts are the orders the label table cycles through with m
+var labelSorts = []string{"most used", "most stuck", "oldest"}
+
+// labelCellWidth is the width of one co-occurrence matrix column
+const labelCellWidth = 5
+
+// LabelAnalyticsModel is the label analytics view: per-label counts, age,
+// throughput and how stuck each label's work is, above a matrix of how
+// often the most used labels appear together
+type LabelAnalyticsModel struct {
+	metrics      []analysis.LabelMetrics
+	together     map[[2]string]int // Sorted label pair -> issues carrying both
+	top          []string          // Most used labels, the matrix axes
+	sortBy       int               // Index into labelSorts
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewLabelAnalyticsModel computes label metrics over the issues in scope
+func NewLabelAnalyticsModel(issues []model.Issue, now time.Time, theme Theme) LabelAnalyticsModel {
+	m := LabelAnalyticsModel{
+		metrics:  analysis.ComputeLabelMetrics(issues, now),
+		together: make(map[[2]string]int),
+		theme:    theme,
+	}
+	for _, p := range analysis.ComputeLabelPairs(issues) {
+		m.together[[2]string{p.A, p.B}] = p.Count
+	}
+	// Metrics start most used first
+	for _, lm := range m.metrics {
+		m.top = append(m.top, lm.Label)
+	}
+	return m
+}
+
+// SetSize updates the view dimensions
+func (m *LabelAnalyticsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *LabelAnalyticsModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *LabelAnalyticsModel) MoveDown() {
+	if m.selected < len(m.metrics)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// SelectedLabel returns the highlighted label, "" when there are none
+func (m *LabelAnalyticsModel) SelectedLabel() string {
+	if m.selected < 0 || m.selected >= len(m.metrics) {
+		return ""
+	}
+	return m.metrics[m.selected].Label
+}
+
+// CycleSort moves to the next table order, keeping the selected label, and
+// returns the order's name
+func (m *LabelAnalyticsModel) CycleSort() string {
+	label := m.SelectedLabel()
+	m.sortBy = (m.sortBy + 1) % len(labelSorts)
+	sort.SliceStable(m.metrics, func(i, j int) bool {
+		a, b := m.metrics[i], m.metrics[j]
+		switch labelSorts[m.sortBy] {
+		case "most stuck":
+			if a.StuckShare() != b.StuckShare() {
+				return a.StuckShare() > b.StuckShare()
+			}
+			if a.Stuck != b.Stuck {
+				return a.Stuck > b.Stuck
+			}
+		case "oldest":
+			if a.AvgAge != b.AvgAge {
+				return a.AvgAge > b.AvgAge
+			}
+		default:
+			if a.Total != b.Total {
+				return a.Total > b.Total
+			}
+		}
+		return a.Label < b.Label
+	})
+	for i, lm := range m.metrics {
+		if lm.Label == label {
+			m.selected = i
+		}
+	}
+	m.ensureVisible()
+	return labelSorts[m.sortBy]
+}
+
+// Together returns how many issues carry both labels
+func (m *LabelAnalyticsModel) Together(a, b string) int {
+	if b < a {
+		a, b = b, a
+	}
+	return m.together[[2]string{a, b}]
+}
+
+// matrixSize returns how many labels the co-occurrence matrix shows, as many
+// as fit the width and half the height, at most 12
+func (m *LabelAnalyticsModel) matrixSize() int {
+	n := min(len(m.top), 12, (m.width-labelRowWidth-4)/labelCellWidth, max((m.height-12)/2, 0))
+	if n < 2 {
+		return 0
+	}
+	return n
+}
+
+// labelRowWidth is the width of the label names heading the matrix rows
+const labelRowWidth = 16
+
+// visibleRows returns how many table rows fit above the matrix
+func (m *LabelAnalyticsModel) visibleRows() int {
+	// header, blank, column titles; blank, title, matrix header, rows,
+	// blank, co-labels, legend
+	rest := 6
+	if n := m.matrixSize(); n > 0 {
+		rest += 3 + n
+	}
+	return max(m.height-rest, 3)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *LabelAnalyticsModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the label table and the co-occurrence matrix
+func (m *LabelAnalyticsModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	warn := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	stuck := 0
+	for _, lm := range m.metrics {
+		if lm.IsStuck() {
+			stuck++
+		}
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🏷️ LABELS  │  %d labels  │  %d mostly stuck  │  sorted by %s",
+		len(m.metrics), stuck, labelSorts[m.sortBy])))
+	lines = append(lines, "")
+
+	if len(m.metrics) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width-4).
+			Align(lipgloss.Center).
+			Render("No labels in scope."))
+		return strings.Join(lines, "\n")
+	}
+
+	weeks := int(analysis.LabelWindow / (7 * 24 * time.Hour))
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-*s %6s %6s %11s %8s %9s %7s",
+		labelRowWidth, "LABEL", "TOTAL", "ACTIVE", "STUCK", "AVG AGE", "CLOSED/WK", "NEW/WK")))
+	end := min(m.scrollOffset+m.visibleRows(), len(m.metrics))
+	for i := m.scrollOffset; i < end; i++ {
+		lm := m.metrics[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		age := "—"
+		if lm.Active() > 0 {
+			age = FormatSpan(lm.AvgAge)
+		}
+		stuckCol := "—"
+		if lm.Active() > 0 {
+			stuckCol = fmt.Sprintf("%d (%d%%)", lm.Stuck, int(lm.StuckShare()*100+0.5))
+		}
+		row := rowStyle.Render(fmt.Sprintf("%s%s %6d %6d %11s %8s %9.1f %7.1f", prefix,
+			fitWidth(lm.Label, labelRowWidth), lm.Total, lm.Active(), stuckCol, age,
+			lm.ClosedPerWeek(), float64(lm.CreatedRecent)/float64(weeks)))
+		var flags []string
+		if lm.IsStuck() {
+			flags = append(flags, warn.Render("⚠ stuck"))
+		}
+		if lm.CreatedRecent > lm.ClosedRecent && lm.Active() > 0 {
+			flags = append(flags, subtle.Render("↑ growing"))
+		}
+		lines = append(lines, row+"  "+strings.Join(flags, " "))
+	}
+	if len(m.metrics) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.metrics)-end)))
+	}
+
+	if n := m.matrixSize(); n > 0 {
+		lines = append(lines, "")
+		lines = append(lines, subtle.Render(fmt.Sprintf("  TOGETHER  (issues carrying both, top %d labels)", n)))
+		lines = append(lines, m.renderMatrix(n)...)
+	}
+
+	lines = append(lines, "", m.describeSelected())
+	lines = append(lines, subtle.Render(fmt.Sprintf(" Stuck: blocked or waiting on an open blocker  ·  per week over the last %d weeks  ·  ⚠ half or more stuck", weeks)))
+	return strings.Join(lines, "\n")
+}
+
+// renderMatrix renders the co-occurrence counts of the n most used labels,
+// highlighting the selected label's row and column
+func (m *LabelAnalyticsModel) renderMatrix(n int) []string {
+	t := m.theme
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	selStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	labels := m.top[:n]
+	selected := m.SelectedLabel()
+
+	peak := 0
+	for i, a := range labels {
+		for _, b := range labels[i+1:] {
+			peak = max(peak, m.Together(a, b))
+		}
+	}
+
+	var header strings.Builder
+	header.WriteString(strings.Repeat(" ", labelRowWidth+2))
+	for _, label := range labels {
+		name := fmt.Sprintf("%*s", labelCellWidth, truncateToWidth(label, labelCellWidth-1, "…"))
+		if label == selected {
+			header.WriteString(selStyle.Render(name))
+		} else {
+			header.WriteString(subtle.Render(name))
+		}
+	}
+	lines := []string{header.String()}
+
+	for _, a := range labels {
+		var row strings.Builder
+		name := "  " + fitWidth(a, labelRowWidth)
+		if a == selected {
+			row.WriteString(selStyle.Render(name))
+		} else {
+			row.WriteString(name)
+		}
+		for _, b := range labels {
+			cell := fmt.Sprintf("%*s", labelCellWidth, "·")
+			style := subtle
+			if a == b {
+				cell = fmt.Sprintf("%*s", labelCellWidth, "╲")
+			} else if count := m.Together(a, b); count > 0 {
+				cell = fmt.Sprintf("%*d", labelCellWidth, count)
+				style = t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+				if count*2 >= peak {
+					style = style.Foreground(t.Primary).Bold(true)
+				}
+			}
+			if a == selected || b == selected {
+				style = style.Background(t.Highlight)
+			}
+			row.WriteString(style.Render(cell))
+		}
+		lines = append(lines, row.String())
+	}
+	return lines
+}
+
+// describeSelected lists the labels seen most often with the selected one
+func (m *LabelAnalyticsModel) describeSelected() string {
+	t := m.theme
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	label := m.SelectedLabel()
+	total := m.metrics[m.selected].Total
+
+	type partner struct {
+		label string
+		count int
+	}
+	var partners []partner
+	for _, other := range m.top {
+		if count := m.Together(label, other); other != label && count > 0 {
+			partners = append(partners, partner{other, count})
+		}
+	}
+	sort.SliceStable(partners, func(i, j int) bool { return partners[i].count > partners[j].count })
+
+	head := " " + RenderLabelChip(m.theme.Renderer, label)
+	if len(partners) == 0 {
+		return head + subtle.Render(" never appears with another label")
+	}
+	var parts []string
+	for _, p := range partners[:min(len(partners), 5)] {
+		parts = append(parts, fmt.Sprintf("%s %d (%d%%)", p.label, p.count, (p.count*100+total/2)/total))
+	}
+	more := ""
+	if len(partners) > 5 {
+		more = fmt.Sprintf(" … %d more", len(partners)-5)
+	}
+	return head + subtle.Render(" often with: ") + strings.Join(parts, subtle.Render(" · ")) + subtle.Render(more)
+}
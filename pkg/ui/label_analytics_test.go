@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLabelAnalyticsViewSortsAndFilters(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	blockedBy := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A1", Title: "API one", Status: model.StatusOpen, Labels: []string{"api", "backend"}, CreatedAt: now.Add(-2 * day)},
+		{ID: "A2", Title: "API two", Status: model.StatusClosed, Labels: []string{"api", "backend"}, CreatedAt: now.Add(-3 * day)},
+		{ID: "A3", Title: "API three", Status: model.StatusOpen, Labels: []string{"api"}, CreatedAt: now.Add(-day)},
+		{ID: "U1", Title: "UI one", Status: model.StatusBlocked, Labels: []string{"ui"}, CreatedAt: now.Add(-40 * day)},
+		{ID: "U2", Title: "UI two", Status: model.StatusOpen, Labels: []string{"ui"}, CreatedAt: now.Add(-40 * day), Dependencies: blockedBy("A1")},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}, Alt: true})
+	m = updated.(Model)
+	if !m.isLabelsView || m.focused != focusLabels {
+		t.Fatalf("expected alt+l to open label analytics")
+	}
+	out := m.View()
+	for _, want := range []string{"LABELS", "3 labels", "1 mostly stuck", "⚠ stuck", "TOGETHER", "often with: backend 2 (67%)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the view:\n%s", want, out)
+		}
+	}
+	if got := m.labelsView.Together("backend", "api"); got != 2 {
+		t.Errorf("expected api and backend together on 2 issues, got %d", got)
+	}
+
+	// Most stuck first puts ui on top and keeps api selected
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	m = updated.(Model)
+	if m.labelsView.metrics[0].Label != "ui" || m.labelsView.SelectedLabel() != "api" {
+		t.Fatalf("expected ui first with api still selected, got %s first and %s selected",
+			m.labelsView.metrics[0].Label, m.labelsView.SelectedLabel())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isLabelsView || m.focused != focusList {
+		t.Fatalf("expected enter to return to the list")
+	}
+	if got := strings.Join(visibleIDs(m), ","); got != "U1,U2" {
+		t.Errorf("expected the ui issues, got %s", got)
+	}
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelFilterPrefix marks a currentFilter value that restricts the list to one label
+const labelFilterPrefix = "label:"
+
+// LabelPickerModel is the overlay menu for filtering the list by label
+type LabelPickerModel struct {
+	stats         []analysis.LabelStats
+	selectedIndex int // 0 is "All labels"; 1..n index into stats
+	scrollOffset  int
+	active        string
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewLabelPickerModel creates a label menu from the issues' labels
+func NewLabelPickerModel(issues []model.Issue, theme Theme) LabelPickerModel {
+	return LabelPickerModel{
+		stats: analysis.ComputeLabelStats(issues),
+		theme: theme,
+	}
+}
+
+// SetIssues refreshes the label list (e.g. after a reload)
+func (m *LabelPickerModel) SetIssues(issues []model.Issue) {
+	m.stats = analysis.ComputeLabelStats(issues)
+	if m.selectedIndex > len(m.stats) {
+		m.selectedIndex = len(m.stats)
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *LabelPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetActive records the current label filter and moves the cursor onto it
+func (m *LabelPickerModel) SetActive(label string) {
+	m.active = label
+	m.selectedIndex = 0
+	for i, s := range m.stats {
+		if s.Label == label {
+			m.selectedIndex = i + 1
+			break
+		}
+	}
+}
+
+// LabelCount returns the number of distinct labels
+func (m *LabelPickerModel) LabelCount() int {
+	return len(m.stats)
+}
+
+// MoveUp moves selection up
+func (m *LabelPickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *LabelPickerModel) MoveDown() {
+	if m.selectedIndex < len(m.stats) {
+		m.selectedIndex++
+	}
+}
+
+// SelectedLabel returns the highlighted label, or "" for "All labels"
+func (m *LabelPickerModel) SelectedLabel() string {
+	if m.selectedIndex <= 0 || m.selectedIndex > len(m.stats) {
+		return ""
+	}
+	return m.stats[m.selectedIndex-1].Label
+}
+
+// View renders the label menu overlay
+func (m *LabelPickerModel) View() string {
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Filter by Label"))
+	lines = append(lines, "")
+
+	if len(m.stats) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render("No labels in this project"))
+	}
+
+	// Keep the cursor visible in long label lists
+	visible := m.height - 10
+	if visible < 5 {
+		visible = 5
+	}
+	total := len(m.stats) + 1
+	if m.selectedIndex < m.scrollOffset {
+		m.scrollOffset = m.selectedIndex
+	}
+	if m.selectedIndex >= m.scrollOffset+visible {
+		m.scrollOffset = m.selectedIndex - visible + 1
+	}
+	end := m.scrollOffset + visible
+	if end > total {
+		end = total
+	}
+
+	for i := m.scrollOffset; i < end; i++ {
+		prefix := "  "
+		if i == m.selectedIndex {
+			prefix = "▸ "
+		}
+		var line string
+		if i == 0 {
+			style := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+			if i == m.selectedIndex {
+				style = style.Foreground(t.Primary).Bold(true)
+			}
+			line = style.Render(prefix + "All labels")
+		} else {
+			s := m.stats[i-1]
+			counts := t.Renderer.NewStyle().Foreground(t.Secondary).
+				Render(fmt.Sprintf(" %d open / %d total", s.Active(), s.Total))
+			line = prefix + RenderLabelChip(t.Renderer, s.Label) + counts
+		}
+		if i > 0 && m.stats[i-1].Label == m.active {
+			line += " (active)"
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("j/k: navigate • enter: filter • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
+
+// issueHasLabel reports whether issue carries label
+func issueHasLabel(issue model.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func labelTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Labels: []string{"ui", "api"}},
+		{ID: "B", Title: "B", Status: model.StatusClosed, Labels: []string{"ui"}},
+		{ID: "C", Title: "C", Status: model.StatusOpen},
+	}
+}
+
+func TestLabelPickerOrdersByCount(t *testing.T) {
+	p := NewLabelPickerModel(labelTestIssues(), DefaultTheme(lipgloss.NewRenderer(nil)))
+	if p.LabelCount() != 2 {
+		t.Fatalf("expected 2 labels, got %d", p.LabelCount())
+	}
+	if p.SelectedLabel() != "" {
+		t.Fatalf("expected cursor on 'All labels' initially")
+	}
+	p.MoveDown()
+	if got := p.SelectedLabel(); got != "ui" {
+		t.Fatalf("expected most used label first, got %q", got)
+	}
+	p.SetActive("api")
+	if got := p.SelectedLabel(); got != "api" {
+		t.Fatalf("expected SetActive to move cursor, got %q", got)
+	}
+}
+
+func TestLabelFilterKeys(t *testing.T) {
+	m := NewModel(labelTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = updated.(Model)
+	if !m.showLabelPicker || m.focused != focusLabelPicker {
+		t.Fatalf("expected label picker open")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.showLabelPicker {
+		t.Fatalf("expected label picker closed after enter")
+	}
+	if got := strings.Join(visibleIDs(m), ","); got != "A,B" {
+		t.Fatalf("expected issues labelled ui (A,B), got %s", got)
+	}
+
+	// Choosing "All labels" clears the filter
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if len(visibleIDs(m)) != 3 {
+		t.Fatalf("expected label filter cleared, got %v", visibleIDs(m))
+	}
+}
+
+func TestInsightsLabelStrip(t *testing.T) {
+	issues := labelTestIssues()
+	issueMap := make(map[string]*model.Issue)
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	m := NewInsightsModel(analysis.Insights{}, issueMap, DefaultTheme(lipgloss.NewRenderer(nil)))
+	strip := m.renderLabelStrip(120, m.theme)
+	if !strings.Contains(strip, "Labels (2)") || !strings.Contains(strip, "ui") || !strings.Contains(strip, "1/2") {
+		t.Fatalf("unexpected label strip: %q", strip)
+	}
+
+	delete(issueMap, "A")
+	delete(issueMap, "B")
+	if strip := m.renderLabelStrip(120, m.theme); strip != "" {
+		t.Fatalf("expected no strip without labels, got %q", strip)
+	}
+}
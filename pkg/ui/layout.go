@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Layout is the user's pane sizes, remembered between sessions
+type Layout struct {
+	ListPercent    int `json:"list_percent"`     // The list's share of the split view's width
+	GraphListWidth int `json:"graph_list_width"` // Columns of the graph view's node list, 0 to fit the window
+}
+
+// Pane size limits and the step each < or > press moves by
+const (
+	minListPercent     = 20
+	maxListPercent     = 80
+	listPercentStep    = 5
+	minGraphListWidth  = 16
+	graphListWidthStep = 4
+)
+
+// DefaultLayout gives the list 40% of the split view and sizes the graph's
+// node list to the window
+func DefaultLayout() Layout {
+	return Layout{ListPercent: 40}
+}
+
+// LoadLayout reads the layout saved at path. A missing or unreadable file
+// gives the default layout, and out-of-range sizes are clamped.
+func LoadLayout(path string) Layout {
+	l := DefaultLayout()
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, &l) != nil {
+		return DefaultLayout()
+	}
+	l.ListPercent = min(max(l.ListPercent, minListPercent), maxListPercent)
+	if l.GraphListWidth != 0 {
+		l.GraphListWidth = max(l.GraphListWidth, minGraphListWidth)
+	}
+	return l
+}
+
+// SaveLayout writes l to path, creating its directory
+func SaveLayout(path string, l Layout) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// SetLayout sizes the panes by l, saving changes made with < and > to path
+// ("" keeps them for this session only)
+func (m *Model) SetLayout(l Layout, path string) {
+	m.layout = l
+	m.layoutPath = path
+	if m.ready && m.isSplitView {
+		m.sizeSplitPanes()
+		m.updateViewportContent()
+	}
+}
+
+// Layout returns the current pane sizes
+func (m Model) Layout() Layout {
+	return m.layout
+}
+
+// bodyHeight returns the rows left for the body after the footer
+func (m *Model) bodyHeight() int {
+	return max(m.height-1, 5)
+}
+
+// sizeSplitPanes divides the split view between the list and the details
+// by the layout's list share
+func (m *Model) sizeSplitPanes() {
+	// 2 panels with borders(2)+padding(2) = 4 overhead each
+	availWidth := max(m.width-8, 10)
+	listInnerWidth := availWidth * m.layout.ListPercent / 100
+	detailInnerWidth := availWidth - listInnerWidth
+
+	// listHeight fits header (1) + page line (1) inside a panel with Border (2)
+	bodyHeight := m.bodyHeight()
+	m.list.SetSize(listInnerWidth, max(bodyHeight-4, 3))
+	m.viewport.Width, m.viewport.Height = detailInnerWidth, bodyHeight-2 // Account for border
+
+	if r, err := newMarkdownRenderer(m.theme.Renderer, detailInnerWidth); err == nil {
+		m.renderer = r
+	}
+}
+
+// resizePane grows (delta > 0) or shrinks the left pane of the graph view
+// or the split view, and saves the new layout
+func (m *Model) resizePane(delta int) {
+	switch {
+	case m.isGraphView:
+		width := m.layout.GraphListWidth
+		if width == 0 {
+			width = m.graphView.ListWidth(m.width)
+		}
+		m.layout.GraphListWidth = min(max(width+delta*graphListWidthStep, minGraphListWidth), m.width/2)
+		m.statusMsg = fmt.Sprintf("Node list %d columns", m.layout.GraphListWidth)
+	case m.isSplitView && (m.focused == focusList || m.focused == focusDetail):
+		m.layout.ListPercent = min(max(m.layout.ListPercent+delta*listPercentStep, minListPercent), maxListPercent)
+		m.sizeSplitPanes()
+		m.list.SetDelegate(m.issueDelegate())
+		m.updateViewportContent()
+		m.statusMsg = fmt.Sprintf("List %d%% · details %d%%", m.layout.ListPercent, 100-m.layout.ListPercent)
+	default:
+		return
+	}
+	m.statusIsError = false
+	if m.layoutPath != "" {
+		if err := SaveLayout(m.layoutPath, m.layout); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Saving layout: %v", err)
+			m.statusIsError = true
+		}
+	}
+}
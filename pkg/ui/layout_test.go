@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestResizePanes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv", "layout.json")
+	issues := []model.Issue{{ID: "A", Title: "a", Status: model.StatusOpen, IssueType: model.TypeTask}}
+
+	m := NewModel(issues, nil, "")
+	m.SetLayout(LoadLayout(path), path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+	m = updated.(Model)
+	press := func(k string) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = updated.(Model)
+	}
+
+	before := m.list.Width()
+	press(">")
+	if m.Layout().ListPercent != 45 || m.list.Width() <= before {
+		t.Fatalf("expected > to widen the list to 45%%, got %d%% (%d → %d columns)", m.Layout().ListPercent, before, m.list.Width())
+	}
+	if m.viewport.Width+m.list.Width() != 200-8 {
+		t.Errorf("panes should fill the width: list %d + details %d", m.list.Width(), m.viewport.Width)
+	}
+	for i := 0; i < 20; i++ {
+		press("<")
+	}
+	if m.Layout().ListPercent != minListPercent {
+		t.Fatalf("expected the list clamped at %d%%, got %d%%", minListPercent, m.Layout().ListPercent)
+	}
+
+	// The graph's node list starts at its automatic width
+	press("g")
+	press(">")
+	if got := m.Layout().GraphListWidth; got != 28+graphListWidthStep {
+		t.Fatalf("expected the node list widened from 28 columns, got %d", got)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the layout saved: %v", err)
+	}
+	if got := LoadLayout(path); got != m.Layout() {
+		t.Fatalf("saved layout %+v, want %+v", got, m.Layout())
+	}
+
+	// Corrupt or out-of-range files fall back or clamp
+	os.WriteFile(path, []byte(`{"list_percent": 95, "graph_list_width": 3}`), 0644)
+	if got := LoadLayout(path); got.ListPercent != maxListPercent || got.GraphListWidth != minGraphListWidth {
+		t.Fatalf("expected clamped layout, got %+v", got)
+	}
+	os.WriteFile(path, []byte(`not json`), 0644)
+	if got := LoadLayout(path); got != DefaultLayout() {
+		t.Fatalf("expected default layout, got %+v", got)
+	}
+}
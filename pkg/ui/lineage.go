@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lineageRow is a line of the flattened discovered-from trees
+type lineageRow struct {
+	node   *analysis.LineageNode
+	prefix string // Tree connectors drawn before the issue
+}
+
+// LineageModel is the discovered-from lineage view: the trees of work found
+// while doing other work, with how much of the backlog is emergent rather
+// than planned
+type LineageModel struct {
+	lineage      analysis.Lineage
+	rows         []lineageRow
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewLineageModel traces the discovered-from links between issues
+func NewLineageModel(issues []model.Issue, theme Theme) LineageModel {
+	m := LineageModel{
+		lineage: analysis.ComputeLineage(issues),
+		theme:   theme,
+	}
+	for _, root := range m.lineage.Roots {
+		m.flatten(root, "", "")
+	}
+	return m
+}
+
+// flatten appends node and its discoveries to the rows, depth first
+func (m *LineageModel) flatten(node *analysis.LineageNode, prefix, childPrefix string) {
+	m.rows = append(m.rows, lineageRow{node: node, prefix: prefix})
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			m.flatten(child, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			m.flatten(child, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *LineageModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *LineageModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *LineageModel) MoveDown() {
+	if m.selected < len(m.rows)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the highlighted issue, or nil when nothing was discovered
+func (m *LineageModel) Selected() *analysis.LineageNode {
+	if m.selected < 0 || m.selected >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.selected].node
+}
+
+// visibleRows returns how many tree lines fit
+func (m *LineageModel) visibleRows() int {
+	// header, blank, two stat lines, blank, trees, blank, legend
+	return max(m.height-8, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *LineageModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// shareBar renders emergent out of total as a bar, emergent work filled
+func (m *LineageModel) shareBar(emergent, total, width int) string {
+	t := m.theme
+	filled := 0
+	if total > 0 {
+		filled = (emergent*width + total/2) / total
+	}
+	return t.Renderer.NewStyle().Foreground(t.InProgress).Render(repeatToWidth("█", filled)) +
+		t.Renderer.NewStyle().Foreground(t.Secondary).Render(repeatToWidth("░", width-filled))
+}
+
+// Render renders the emergent-work stats and the discovered-from trees
+func (m *LineageModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	l := m.lineage
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	total := l.Planned + l.Emergent
+	open := l.OpenPlanned + l.OpenEmergent
+	header := fmt.Sprintf("🌱 LINEAGE  │  %d of %d issues emergent (%.0f%%)  │  %d of %d open (%.0f%%)",
+		l.Emergent, total, l.EmergentShare()*100, l.OpenEmergent, open, l.OpenEmergentShare()*100)
+	var lines []string
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	barWidth := min(max(m.width/3, 10), 40)
+	lines = append(lines, fmt.Sprintf("  %-9s %s %s", "All", m.shareBar(l.Emergent, total, barWidth),
+		subtle.Render(fmt.Sprintf("%d planned · %d emergent", l.Planned, l.Emergent))))
+	lines = append(lines, fmt.Sprintf("  %-9s %s %s", "Backlog", m.shareBar(l.OpenEmergent, open, barWidth),
+		subtle.Render(fmt.Sprintf("%d planned · %d emergent · trees: %d · deepest chain: %d", l.OpenPlanned, l.OpenEmergent, len(l.Roots), l.MaxDepth))))
+	lines = append(lines, "")
+
+	if len(m.rows) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width-4).
+			Align(lipgloss.Center).
+			Render("No issues were discovered from others (discovered-from dependencies)."))
+		return strings.Join(lines, "\n")
+	}
+
+	end := min(m.scrollOffset+m.visibleRows(), len(m.rows))
+	for i := m.scrollOffset; i < end; i++ {
+		row := m.rows[i]
+		node := row.node
+		cursor := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			cursor = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		if node.Status.IsClosed() && i != m.selected {
+			rowStyle = rowStyle.Foreground(t.Subtext)
+		}
+		count := ""
+		if node.Descendants > 0 {
+			count = fmt.Sprintf("  +%d", node.Descendants)
+		}
+		titleWidth := max(m.width-displayWidth(row.prefix)-displayWidth(node.ID)-displayWidth(count)-10, 10)
+		lines = append(lines, cursor+subtle.Render(row.prefix)+
+			t.Renderer.NewStyle().Foreground(t.GetStatusColor(string(node.Status))).Render("●")+" "+
+			rowStyle.Render(node.ID+"  "+truncateToWidth(node.Title, titleWidth, "…"))+
+			subtle.Render(count))
+	}
+	if len(m.rows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.rows)-end)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, subtle.Render(" Each issue sits under the one it was discovered from  ·  +n issues discovered from it, directly or not  ·  ● status"))
+	return strings.Join(lines, "\n")
+}
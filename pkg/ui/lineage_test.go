@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLineageViewTreesAndJumps(t *testing.T) {
+	found := func(from string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: from, Type: model.DepDiscoveredFrom}}
+	}
+	issues := []model.Issue{
+		{ID: "P1", Title: "Rewrite importer", Status: model.StatusClosed},
+		{ID: "E1", Title: "Importer drops tags", Status: model.StatusOpen, Dependencies: found("P1")},
+		{ID: "E2", Title: "Tag index is stale", Status: model.StatusOpen, Dependencies: found("E1")},
+		{ID: "P2", Title: "Planned work", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}, Alt: true})
+	m = updated.(Model)
+	if !m.isLineageView || m.focused != focusLineage {
+		t.Fatalf("expected alt+d to open the lineage view")
+	}
+	out := m.View()
+	for _, want := range []string{"LINEAGE", "2 of 4 issues emergent (50%)", "2 of 3 open (67%)", "trees: 1 · deepest chain: 2",
+		"P1  Rewrite importer", "└── ", "E2  Tag index is stale"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the lineage:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Planned work") {
+		t.Errorf("issues that led to no discoveries should not be drawn")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isLineageView {
+		t.Fatalf("expected enter to leave the lineage view")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "E1" {
+		t.Errorf("expected E1 selected, got %v", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// detailLink is a URL or a mention of another issue found in an issue's text
+type detailLink struct {
+	URL     string // Set for web links
+	IssueID string // Set for issue mentions
+}
+
+// urlPattern matches web URLs in free text and as Markdown link targets
+var urlPattern = regexp.MustCompile("https?://[^\\s<>\"'`()\\[\\]]+")
+
+// issueLinks returns the URLs and the other issues mentioned in an issue's
+// description, design, acceptance criteria, notes and comments, in the
+// order they appear and without repeats. Mentions are of the IDs in index
+// (see analysis.MentionIndex).
+func issueLinks(issue model.Issue, index map[string]string) []detailLink {
+	var links []detailLink
+	seen := map[string]bool{issue.ID: true}
+	mentions := func(text string) {
+		for _, id := range analysis.MentionedIDs(text, index) {
+			if !seen[id] {
+				seen[id] = true
+				links = append(links, detailLink{IssueID: id})
+			}
+		}
+	}
+	for _, text := range analysis.IssueTexts(issue) {
+		last := 0
+		for _, span := range urlPattern.FindAllStringIndex(text, -1) {
+			mentions(text[last:span[0]])
+			last = span[1]
+			url := strings.TrimRight(text[span[0]:span[1]], ".,;:!?*_")
+			if !seen[url] {
+				seen[url] = true
+				links = append(links, detailLink{URL: url})
+			}
+		}
+		mentions(text[last:])
+	}
+	return links
+}
+
+// selectedLinks returns the links in the issue highlighted in the list
+func (m *Model) selectedLinks() []detailLink {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return nil
+	}
+	return issueLinks(item.Issue, m.mentionIndex())
+}
+
+// mentionIndex returns the lookup for issue IDs mentioned in text, building
+// it on first use
+func (m *Model) mentionIndex() map[string]string {
+	if m.mentionIDs == nil {
+		m.mentionIDs = analysis.MentionIndex(m.issues)
+	}
+	return m.mentionIDs
+}
+
+// issueMentions returns the issues each issue mentions without a dependency
+// between them, computing them on first use
+func (m *Model) issueMentions() map[string][]string {
+	if m.mentions == nil {
+		m.mentions = analysis.Mentions(m.issues)
+	}
+	return m.mentions
+}
+
+// linkCursorIndex returns the highlighted link's index among n links of the
+// issue id
+func (m *Model) linkCursorIndex(id string, n int) int {
+	if m.linkCursorIssue != id || m.linkCursor >= n {
+		return 0
+	}
+	return m.linkCursor
+}
+
+// handleLinkKey handles the detail view's link keys: { and } choose a link,
+// f follows it, opening URLs in the browser and jumping to mentioned
+// issues. It reports whether key was one of them.
+func (m *Model) handleLinkKey(key string) bool {
+	switch key {
+	case "{", "}":
+		id := m.selectedIssueID()
+		links := m.selectedLinks()
+		if len(links) == 0 {
+			return true
+		}
+		cursor := m.linkCursorIndex(id, len(links))
+		if key == "}" {
+			cursor = (cursor + 1) % len(links)
+		} else {
+			cursor = (cursor + len(links) - 1) % len(links)
+		}
+		m.linkCursorIssue, m.linkCursor = id, cursor
+		m.updateViewportContent()
+	case "f":
+		links := m.selectedLinks()
+		if len(links) == 0 {
+			m.statusMsg = "❌ No links in this issue"
+			m.statusIsError = true
+			return true
+		}
+		link := links[m.linkCursorIndex(m.selectedIssueID(), len(links))]
+		if link.URL != "" {
+			m.openInBrowser(link.URL)
+		} else if m.revealIssue(link.IssueID) {
+			m.viewport.GotoTop()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// linksMarkdown lists the links in an issue for the detail view
+func (m *Model) linksMarkdown(issue model.Issue) string {
+	links := issueLinks(issue, m.mentionIndex())
+	if len(links) == 0 {
+		return ""
+	}
+	cursor := m.linkCursorIndex(issue.ID, len(links))
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Links (%d)\n", len(links)))
+	for i, l := range links {
+		marker := "  "
+		if i == cursor {
+			marker = "▸ "
+		}
+		if l.URL != "" {
+			sb.WriteString(fmt.Sprintf("- %s🔗 %s\n", marker, l.URL))
+			continue
+		}
+		title := ""
+		if target := m.issueMap[l.IssueID]; target != nil {
+			title = " " + target.Title
+		}
+		sb.WriteString(fmt.Sprintf("- %s`%s`%s\n", marker, l.IssueID, title))
+	}
+	hint := "*f follow"
+	if len(links) > 1 {
+		hint += " • { } choose link"
+	}
+	sb.WriteString("\n" + hint + "*\n\n")
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIssueLinks(t *testing.T) {
+	index := analysis.MentionIndex([]model.Issue{{ID: "bv-1"}, {ID: "bv-12"}, {ID: "bv-2"}})
+	issue := model.Issue{
+		ID: "bv-1",
+		Description: "Split from BV-12. See https://example.com/bv-2/spec, and the " +
+			"[design doc](https://docs.example.com/d?id=7). Not bv-123 or bv-1.",
+		Comments: []*model.Comment{{Text: "Done in bv-2 (https://example.com/bv-2/spec)."}},
+	}
+	got := issueLinks(issue, index)
+	want := []detailLink{
+		{IssueID: "bv-12"},
+		{URL: "https://example.com/bv-2/spec"},
+		{URL: "https://docs.example.com/d?id=7"},
+		{IssueID: "bv-2"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d links, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("link %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFollowLinks(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser", Status: model.StatusOpen,
+			Description: "Spec at https://example.com/spec; blocked on bv-2."},
+		{ID: "bv-2", Title: "Lexer", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 40})
+	m = updated.(Model)
+	press := func(msg tea.KeyMsg) {
+		t.Helper()
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+
+	m.selectIssueInList("bv-1")
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	content := m.viewport.View()
+	for _, want := range []string{"Links (2)", "https://example.com/spec", "bv-2", "Lexer"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("detail view missing %q:\n%s", want, content)
+		}
+	}
+
+	var opened string
+	orig := openURL
+	openURL = func(url string) error { opened = url; return nil }
+	defer func() { openURL = orig }()
+
+	press(key("f"))
+	if opened != "https://example.com/spec" {
+		t.Errorf("expected f to open the URL, got %q", opened)
+	}
+
+	// The mention jumps to the issue, staying in the details
+	press(key("}"))
+	press(key("f"))
+	if m.selectedIssueID() != "bv-2" || !m.showDetails {
+		t.Fatalf("expected f to show bv-2's details, on %q", m.selectedIssueID())
+	}
+	press(key("f"))
+	if m.statusMsg != "❌ No links in this issue" {
+		t.Errorf("expected no links in bv-2, got status %q", m.statusMsg)
+	}
+}
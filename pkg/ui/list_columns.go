@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Widths of the list's fixed columns. The header names must fit them.
+const (
+	typeColumnWidth      = 4  // TYPE: the type icon
+	priorityColumnWidth  = 3  // PRI: P0-P4, with room for the sort arrow
+	hintColumnWidth      = 1  // Priority hint arrow
+	statusColumnWidth    = 4  // STAT: OPEN, PROG, BLKD or DONE
+	diffColumnWidth      = 2  // Time-travel badge
+	dueColumnWidth       = 12 // DUE: countdown badge such as "⏰ 3d late"
+	ageColumnWidth       = 8  // AGE: time since creation, "11mo ago"
+	commentsColumnWidth  = 4  // 💬 and a count
+	checklistColumnWidth = 9  // CHECKLIST: progress bar and count, "███░ 3/10"
+	scriptColumnWidth    = 16 // A computed column, name:value
+	fieldColumnWidth     = 16 // A field another tool added
+	assigneeColumnWidth  = 13 // @ and 12 characters of assignee
+	maxIDColumnWidth     = 35
+)
+
+// ageWidth is the AGE column's width: an age, or when time.style is
+// absolute, the creation date in the configured format
+func ageWidth() int {
+	if !timefmt.Absolute() {
+		return ageColumnWidth
+	}
+	// The longest weekday and month names, two-digit day and hour
+	widest := time.Date(2000, 9, 27, 22, 0, 0, 0, time.UTC)
+	return max(displayWidth(timefmt.Day(widest)), len("CREATED"))
+}
+
+// ageHeader labels the AGE column, CREATED when it shows dates
+func ageHeader() string {
+	if timefmt.Absolute() {
+		return "CREATED"
+	}
+	return "AGE"
+}
+
+// columnFit records which optional columns the listed issues need and how
+// wide the variable ones must be, so every row lines up under the header
+type columnFit struct {
+	id        int  // Widest ID, up to maxIDColumnWidth
+	repo      int  // Widest repo badge (workspace mode)
+	diff      bool // Some issue carries a time-travel badge
+	due       bool // Some open issue has a due date
+	assignee  bool
+	labels    bool
+	checklist bool // Some issue's description has a task list
+}
+
+// fitColumns measures the issues in items
+func fitColumns(items []list.Item) columnFit {
+	var f columnFit
+	for _, item := range items {
+		i, ok := item.(IssueItem)
+		if !ok {
+			continue
+		}
+		f.id = max(f.id, min(lipgloss.Width(i.Issue.ID), maxIDColumnWidth))
+		if i.RepoPrefix != "" {
+			f.repo = max(f.repo, lipgloss.Width(RenderRepoBadge(i.RepoPrefix)))
+		}
+		f.diff = f.diff || i.DiffStatus.Badge() != ""
+		f.due = f.due || (i.Issue.DueDate != nil && !i.Issue.Status.IsClosed())
+		f.assignee = f.assignee || i.Issue.Assignee != ""
+		f.labels = f.labels || len(i.Issue.Labels) > 0
+		f.checklist = f.checklist || len(analysis.ParseChecklist(i.Issue.Description)) > 0
+	}
+	return f
+}
+
+// listLayout is the width of each column of the list at one list width;
+// 0 hides an optional column
+type listLayout struct {
+	width     int // Whole row
+	gutter    int // Before the first column, for the selection marker
+	repo      int
+	typ       bool
+	hint      bool
+	id        int
+	diff      bool
+	title     int
+	due       int
+	age       int
+	comments  int
+	checklist int
+	scripts   int // Computed columns shown
+	fields    int // Custom field columns shown
+	assignee  int
+	labels    int
+}
+
+// layout fits the columns into a row of width, sized by fit
+func (d IssueDelegate) layout(width int, fit columnFit) listLayout {
+	l := listLayout{width: width, gutter: d.Density.gutter(), id: fit.id, diff: fit.diff}
+	// Compact rows drop the decorations
+	l.typ = d.Density != DensityCompact
+	l.hint = d.ShowPriorityHints && d.Density != DensityCompact
+	if d.WorkspaceMode {
+		l.repo = fit.repo
+	}
+
+	// Optional right-hand columns appear as the row widens
+	if width > 60 {
+		if fit.due && d.showColumn("due") {
+			l.due = dueColumnWidth
+		}
+		if d.showColumn("age") {
+			l.age = ageWidth()
+		}
+		if d.showColumn("comments") {
+			l.comments = commentsColumnWidth
+		}
+		if fit.checklist && d.showColumn("checklist") {
+			l.checklist = checklistColumnWidth
+		}
+	}
+	if width > 80 {
+		l.scripts = len(d.ScriptColumns)
+		l.fields = len(d.FieldColumns)
+	}
+	if width > 100 && fit.assignee && d.showColumn("assignee") {
+		l.assignee = assigneeColumnWidth
+	}
+	if width > 120 && fit.labels && d.showColumn("labels") {
+		l.labels = 20
+		if width > 160 {
+			l.labels = 32
+		}
+	}
+
+	l.title = max(width-l.leftWidth()-l.rightWidth()-1, 5)
+	return l
+}
+
+// leftWidth is the width of the columns before the title, separators included
+func (l listLayout) leftWidth() int {
+	w := l.gutter + priorityColumnWidth + 1 + statusColumnWidth + 1 + l.id + 1
+	if l.typ {
+		w += typeColumnWidth + 1
+	}
+	if l.repo > 0 {
+		w += l.repo + 1
+	}
+	if l.hint {
+		w += hintColumnWidth + 1
+	}
+	if l.diff {
+		w += diffColumnWidth + 1
+	}
+	return w
+}
+
+// rightCells returns the widths of the columns after the title, in order
+func (l listLayout) rightCells() []int {
+	var cells []int
+	for _, w := range []int{l.due, l.age, l.comments, l.checklist} {
+		if w > 0 {
+			cells = append(cells, w)
+		}
+	}
+	for range l.scripts {
+		cells = append(cells, scriptColumnWidth)
+	}
+	for range l.fields {
+		cells = append(cells, fieldColumnWidth)
+	}
+	for _, w := range []int{l.assignee, l.labels} {
+		if w > 0 {
+			cells = append(cells, w)
+		}
+	}
+	return cells
+}
+
+// rightWidth is the width of the columns after the title, each preceded by a space
+func (l listLayout) rightWidth() int {
+	w := 0
+	for _, c := range l.rightCells() {
+		w += c + 1
+	}
+	return w
+}
+
+// padCell pads or cuts s, which may be styled, to width. Right-aligned cells
+// are padded on the left.
+func padCell(s string, width int, right bool) string {
+	if w := lipgloss.Width(s); w > width {
+		s = lipgloss.NewStyle().MaxWidth(width).Render(s)
+	}
+	gap := strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+	if right {
+		return gap + s
+	}
+	return s + gap
+}
+
+// leftHeaderCell labels a column before the title, filling its separator.
+// The sort arrow may take the separator.
+func leftHeaderCell(label string, width int, arrow string) string {
+	return padCell(truncateToWidth(label+arrow, width+1, ""), width+1, false)
+}
+
+// rightHeaderCell labels a column after the title
+func rightHeaderCell(label string, width int, arrow string, right bool) string {
+	return padCell(truncateToWidth(label+arrow, width, ""), width, right)
+}
+
+// RenderHeader returns the header row naming the columns of rows width wide,
+// fitted to the listed issues like the rows themselves. The sorted column's
+// name carries the sort direction; indicator (the sort and grouping) goes at
+// the right of the title column.
+func (d IssueDelegate) RenderHeader(width int, fit columnFit, sort SortMode, indicator string) string {
+	width-- // Rows leave the last column free too
+	l := d.layout(width, fit)
+	arrow := func(field SortField) string {
+		if sort.Field != field {
+			return ""
+		}
+		if sort.Descending {
+			return "↓"
+		}
+		return "↑"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", l.gutter))
+	if l.repo > 0 {
+		sb.WriteString(leftHeaderCell("REPO", l.repo, ""))
+	}
+	if l.typ {
+		sb.WriteString(leftHeaderCell("TYPE", typeColumnWidth, ""))
+	}
+	sb.WriteString(leftHeaderCell("PRI", priorityColumnWidth, arrow(SortPriority)))
+	if l.hint {
+		sb.WriteString(leftHeaderCell("", hintColumnWidth, ""))
+	}
+	sb.WriteString(leftHeaderCell("STAT", statusColumnWidth, ""))
+	sb.WriteString(leftHeaderCell("ID", l.id, ""))
+	if l.diff {
+		sb.WriteString(leftHeaderCell("", diffColumnWidth, ""))
+	}
+	sb.WriteString(padCell(withHeaderIndicator("TITLE", indicator, l.title), l.title, false))
+
+	if l.due > 0 {
+		sb.WriteString(" " + rightHeaderCell("DUE", l.due, "", false))
+	}
+	if l.age > 0 {
+		sb.WriteString(" " + rightHeaderCell(ageHeader(), l.age, arrow(SortAge), true))
+	}
+	if l.comments > 0 {
+		sb.WriteString(" " + rightHeaderCell("💬", l.comments, "", false))
+	}
+	if l.checklist > 0 {
+		sb.WriteString(" " + rightHeaderCell("CHECKLIST", l.checklist, "", false))
+	}
+	for n := range l.scripts {
+		sb.WriteString(" " + rightHeaderCell(strings.ToUpper(d.ScriptColumns[n]), scriptColumnWidth, "", false))
+	}
+	for n := range l.fields {
+		sb.WriteString(" " + rightHeaderCell(strings.ToUpper(d.FieldColumns[n]), fieldColumnWidth, "", false))
+	}
+	if l.assignee > 0 {
+		sb.WriteString(" " + rightHeaderCell("ASSIGNEE", l.assignee, "", false))
+	}
+	if l.labels > 0 {
+		sb.WriteString(" " + rightHeaderCell("LABELS", l.labels, "", false))
+	}
+	return padCell(sb.String(), width, false)
+}
@@ -1,11 +1,15 @@
 package ui
 
 import (
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // White-box testing of UI model logic
@@ -176,3 +180,199 @@ func TestFormatTimeRel(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatDueRel(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time {
+		return time.Date(2025, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		due      time.Time
+		expected string
+	}{
+		{day(-3), "3d late"},
+		{day(0), "today"},
+		{day(2), "in 2d"},
+		{day(20), "Mar 30"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDueRel(tt.due, now); got != tt.expected {
+			t.Errorf("FormatDueRel(%v): expected %s, got %s", tt.due, tt.expected, got)
+		}
+	}
+}
+
+func TestApplyFilter_DueAndOverdue(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-72 * time.Hour)
+	soon := now.Add(48 * time.Hour)
+	later := now.Add(30 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "late", Status: model.StatusOpen, DueDate: &past},
+		{ID: "soon", Status: model.StatusOpen, DueDate: &soon},
+		{ID: "later", Status: model.StatusOpen, DueDate: &later},
+		{ID: "done", Status: model.StatusClosed, DueDate: &past},
+		{ID: "none", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+
+	m.SetFilter("overdue")
+	if got := m.FilteredIssues(); len(got) != 1 || got[0].ID != "late" {
+		t.Errorf("overdue filter: expected [late], got %v", got)
+	}
+
+	m.SetFilter("due")
+	if got := m.FilteredIssues(); len(got) != 1 || got[0].ID != "soon" {
+		t.Errorf("due filter: expected [soon], got %v", got)
+	}
+}
+
+func TestApplyFilter_IslandsAndDetached(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "X", Status: model.StatusOpen},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "Y", DependsOnID: "X", Type: model.DepRelated}}},
+		{ID: "lone", Status: model.StatusOpen},
+		{ID: "done", Status: model.StatusClosed},
+	}
+	m := NewModel(issues, nil, "")
+
+	// N toggles islands, then detached clusters
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	m = updated.(Model)
+	if got := strings.Join(visibleIDs(m), ","); got != "lone" {
+		t.Errorf("islands filter: expected [lone], got %s", got)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	m = updated.(Model)
+	if got := visibleIDs(m); len(got) != 2 || !containsID(got, "X") || !containsID(got, "Y") {
+		t.Errorf("detached filter: expected [X Y], got %v", got)
+	}
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPhase2TickStopsWhenReady(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "A", Status: model.StatusOpen}}, nil, "")
+	m.analysis.WaitForPhase2()
+
+	if got := m.phase2ProgressText(); got != "" {
+		t.Errorf("expected no progress badge once Phase 2 is ready, got %q", got)
+	}
+	if _, cmd := m.Update(Phase2TickMsg{}); cmd != nil {
+		t.Errorf("expected ticking to stop once Phase 2 is ready")
+	}
+}
+
+func TestFormatMinutes(t *testing.T) {
+	tests := map[int]string{45: "45m", 60: "1h", 150: "2h30m", 0: "0m"}
+	for in, want := range tests {
+		if got := FormatMinutes(in); got != want {
+			t.Errorf("FormatMinutes(%d): expected %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestFormatSpan(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Minute: "30m",
+		5 * time.Hour:    "5h",
+		36 * time.Hour:   "1.5d",
+		48 * time.Hour:   "2d",
+		300 * time.Hour:  "12d",
+	}
+	for in, want := range tests {
+		if got := FormatSpan(in); got != want {
+			t.Errorf("FormatSpan(%v): expected %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestEffortMarkdown(t *testing.T) {
+	est := func(n int) *int { return &n }
+	issues := []model.Issue{
+		{ID: "EPIC", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Title: "A", Status: model.StatusOpen, EstimatedMinutes: est(90),
+			Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "EPIC", Type: model.DepParentChild}}},
+		{ID: "B", Title: "B", Status: model.StatusOpen, EstimatedMinutes: est(30),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "N", Title: "N", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+
+	if md := m.effortMarkdown("EPIC"); !strings.Contains(md, "1h30m total") {
+		t.Errorf("expected epic tree rollup, got %q", md)
+	}
+	if md := m.effortMarkdown("B"); !strings.Contains(md, "**Estimate**: 30m") || !strings.Contains(md, "2h until done") {
+		t.Errorf("expected estimate and chain rollup, got %q", md)
+	}
+	if md := m.effortMarkdown("N"); md != "" {
+		t.Errorf("expected no effort section for unestimated standalone issue, got %q", md)
+	}
+}
+
+func TestSlackMarkdownAndZeroSlackFilter(t *testing.T) {
+	est := func(n int) *int { return &n }
+	child := func(id string) *model.Dependency {
+		return &model.Dependency{IssueID: id, DependsOnID: "EPIC", Type: model.DepParentChild}
+	}
+	issues := []model.Issue{
+		{ID: "EPIC", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Title: "A", Status: model.StatusOpen, EstimatedMinutes: est(90), Dependencies: []*model.Dependency{child("A")}},
+		{ID: "B", Title: "B", Status: model.StatusOpen, EstimatedMinutes: est(30),
+			Dependencies: []*model.Dependency{child("B"), {IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen, EstimatedMinutes: est(30), Dependencies: []*model.Dependency{child("C")}},
+		{ID: "N", Title: "N", Status: model.StatusOpen, EstimatedMinutes: est(60)},
+		{ID: "S", Title: "S", Status: model.StatusOpen, EstimatedMinutes: est(30)},
+		{ID: "done", Title: "Done", Status: model.StatusClosed},
+	}
+	m := NewModel(issues, nil, "")
+
+	if md := m.slackMarkdown("C"); md != "- **Slack**: can slip 1h30m without delaying epic EPIC\n" {
+		t.Errorf("unexpected slack for C: %q", md)
+	}
+	if md := m.slackMarkdown("A"); !strings.Contains(md, "none, any slip delays epic EPIC") {
+		t.Errorf("expected A on the critical path, got %q", md)
+	}
+	if md := m.slackMarkdown("S"); !strings.Contains(md, "can slip 1h30m without delaying the rest of the open work") {
+		t.Errorf("expected S bounded by all open work, got %q", md)
+	}
+	if md := m.slackMarkdown("done"); md != "" {
+		t.Errorf("expected no slack for a closed issue, got %q", md)
+	}
+
+	m.SetFilter("zero_slack")
+	var ids []string
+	for _, issue := range m.FilteredIssues() {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "A,B" {
+		t.Errorf("zero slack filter: expected [A B], got %v", ids)
+	}
+}
+
+func TestFormatSlack(t *testing.T) {
+	day := analysis.DefaultDayMinutes
+	tests := map[int]string{
+		45:          "45m",
+		day:         "1 day",
+		day * 5 / 2: "2.5 days",
+		day * 3:     "3 days",
+	}
+	for in, want := range tests {
+		if got := FormatSlack(in); got != want {
+			t.Errorf("FormatSlack(%d): expected %s, got %s", in, want, got)
+		}
+	}
+}
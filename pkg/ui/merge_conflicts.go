@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MergeConflictLoadedMsg carries the beads file split at its git conflict
+// markers
+type MergeConflictLoadedMsg struct {
+	Conflict loader.MergeConflict
+	Err      error
+}
+
+// LoadMergeConflictCmd reads the beads file and splits it at its conflict
+// markers
+func LoadMergeConflictCmd(beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := os.ReadFile(beadsPath)
+		if err != nil {
+			return MergeConflictLoadedMsg{Err: err}
+		}
+		c, err := loader.ParseMergeConflict(data)
+		return MergeConflictLoadedMsg{Conflict: c, Err: err}
+	}
+}
+
+// MergeConflictResolvedMsg reports the outcome of writing the resolved
+// beads file
+type MergeConflictResolvedMsg struct {
+	Records int // Records that were in conflict
+	Err     error
+}
+
+// WriteResolvedCmd replaces the beads file with data, the file resolved in
+// the merge assistant
+func WriteResolvedCmd(beadsPath string, data []byte, records int) tea.Cmd {
+	return func() tea.Msg {
+		// Write then rename, so a crash can't leave half a beads file
+		tmp := filepath.Join(filepath.Dir(beadsPath), "."+filepath.Base(beadsPath)+".resolved")
+		err := os.WriteFile(tmp, data, 0644)
+		if err == nil {
+			err = os.Rename(tmp, beadsPath)
+		}
+		return MergeConflictResolvedMsg{Records: records, Err: err}
+	}
+}
+
+// MergeAssistantModel is the overlay for resolving a beads file left with
+// git conflict markers, record by record: for each issue the two sides
+// wrote differently, keep ours or take theirs
+type MergeAssistantModel struct {
+	conflict  loader.MergeConflict
+	useTheirs []bool // Per record
+	differing []int  // Records whose sides differ, the ones listed
+	cursor    int    // Into differing
+	width     int
+	height    int
+	theme     Theme
+}
+
+// NewMergeAssistantModel creates the merge assistant
+func NewMergeAssistantModel(theme Theme) MergeAssistantModel {
+	return MergeAssistantModel{theme: theme}
+}
+
+// SetSize updates the overlay dimensions
+func (m *MergeAssistantModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Open shows conflict, each record starting at its default choice
+func (m *MergeAssistantModel) Open(conflict loader.MergeConflict) {
+	m.conflict = conflict
+	m.useTheirs = conflict.DefaultChoices()
+	m.differing = nil
+	for i, r := range conflict.Records {
+		if !r.Same() {
+			m.differing = append(m.differing, i)
+		}
+	}
+	m.cursor = 0
+}
+
+// Move moves the cursor by delta records
+func (m *MergeAssistantModel) Move(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.differing)-1, 0))
+}
+
+// Choose keeps our copy of the highlighted record, or takes theirs
+func (m *MergeAssistantModel) Choose(theirs bool) {
+	if m.cursor < len(m.differing) {
+		m.useTheirs[m.differing[m.cursor]] = theirs
+	}
+}
+
+// ChooseAll keeps our copy of every record, or takes theirs
+func (m *MergeAssistantModel) ChooseAll(theirs bool) {
+	for _, i := range m.differing {
+		m.useTheirs[i] = theirs
+	}
+}
+
+// Records returns how many records were in conflict
+func (m *MergeAssistantModel) Records() int {
+	return len(m.differing)
+}
+
+// Resolved returns the beads file with each record as chosen
+func (m *MergeAssistantModel) Resolved() []byte {
+	return m.conflict.Resolve(m.useTheirs)
+}
+
+// sideLabel names a side by its marker label, falling back to ours/theirs
+func sideLabel(label, side string) string {
+	if label == "" || label == side {
+		return side
+	}
+	return side + " (" + label + ")"
+}
+
+// versionLine summarizes one side's copy of a record
+func versionLine(v *loader.ConflictVersion, width int) string {
+	switch {
+	case v == nil:
+		return "(not there: dropped)"
+	case v.Issue.ID == "":
+		return truncateToWidth(v.Line, width, "…")
+	}
+	meta := fmt.Sprintf("%s  %s  ", timefmt.Date(v.Issue.UpdatedAt), v.Issue.Status)
+	return meta + truncateToWidth(v.Issue.Title, max(width-displayWidth(meta), 10), "…")
+}
+
+// View renders the assistant
+func (m *MergeAssistantModel) View() string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	chosen := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	width := min(max(m.width-16, 40), 96)
+
+	c := m.conflict
+	ours := sideLabel(c.OursLabel, "ours")
+	theirs := sideLabel(c.TheirsLabel, "theirs")
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("⚠ The beads file has %d merge conflicts", c.Hunks)),
+		subtle.Render(fmt.Sprintf("%d records differ between %s and %s; %d are the same on both sides",
+			len(m.differing), ours, theirs, len(c.Records)-len(m.differing))),
+	}
+
+	// Each record takes three lines; keep the cursor in view
+	rows := max((m.height-10)/3, 1)
+	start := min(max(m.cursor-rows/2, 0), max(len(m.differing)-rows, 0))
+	end := min(start+rows, len(m.differing))
+	labelWidth := max(displayWidth(ours), displayWidth(theirs))
+	for row := start; row < end; row++ {
+		i := m.differing[row]
+		r := c.Records[i]
+		pointer := "  "
+		id := r.ID
+		if id == "" {
+			id = "(not an issue)"
+		}
+		if row == m.cursor {
+			pointer = titleStyle.Render("▸ ")
+			id = titleStyle.Render(id)
+		}
+		lines = append(lines, pointer+id)
+		for _, side := range []struct {
+			label   string
+			version *loader.ConflictVersion
+			theirs  bool
+		}{{ours, r.Ours, false}, {theirs, r.Theirs, true}} {
+			mark := "  "
+			label := subtle.Render(padCell(side.label, labelWidth, false))
+			if m.useTheirs[i] == side.theirs {
+				mark = chosen.Render("✓ ")
+				label = chosen.Render(padCell(side.label, labelWidth, false))
+			}
+			lines = append(lines, "  "+mark+label+"  "+versionLine(side.version, width-labelWidth-6))
+		}
+	}
+	if len(m.differing) == 0 {
+		lines = append(lines, "", subtle.Render("Nothing to choose: ⏎ writes the file without the markers"))
+	} else if end-start < len(m.differing) {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(m.differing))))
+	}
+	lines = append(lines, "", subtle.Italic(true).Render(
+		"j/k: record • o/t: keep ours / take theirs • O/T: all • enter: write the file • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorWarning).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// handleMergeAssistantKeys handles keyboard input in the merge assistant:
+// enter writes the resolved file, esc leaves it conflicted
+func (m Model) handleMergeAssistantKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	a := &m.mergeAssistant
+	switch msg.String() {
+	case "j", "down":
+		a.Move(1)
+	case "k", "up":
+		a.Move(-1)
+	case "o", "left", "h":
+		a.Choose(false)
+	case "t", "right", "l":
+		a.Choose(true)
+	case "O":
+		a.ChooseAll(false)
+	case "T":
+		a.ChooseAll(true)
+	case "enter":
+		m.showMergeAssistant = false
+		m.statusMsg = "Writing the resolved beads file…"
+		m.statusIsError = false
+		return m, WriteResolvedCmd(m.beadsPath, a.Resolved(), a.Records())
+	case "esc":
+		m.showMergeAssistant = false
+		m.statusMsg = "The beads file still has conflict markers · alt+m to resolve"
+		m.statusIsError = true
+	}
+	return m, nil
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMergeAssistantResolvesConflictMarkers(t *testing.T) {
+	dir := t.TempDir()
+	beads := filepath.Join(dir, ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beads), 0755); err != nil {
+		t.Fatal(err)
+	}
+	a := `{"id":"A","title":"Untouched","status":"open","priority":2,"issue_type":"task"}`
+	oursB := `{"id":"B","title":"Fix login","status":"closed","priority":1,"issue_type":"bug","updated_at":"2025-06-03T00:00:00Z"}`
+	theirsB := `{"id":"B","title":"Fix login redirect","status":"open","priority":1,"issue_type":"bug","updated_at":"2025-06-02T00:00:00Z"}`
+	c := `{"id":"C","title":"Added on the branch","status":"open","priority":2,"issue_type":"task"}`
+	data := a + "\n<<<<<<< HEAD\n" + oursB + "\n=======\n" + theirsB + "\n" + c + "\n>>>>>>> feature\n"
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := loader.LoadIssuesFromFileWithProgress(beads, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(res.Issues, nil, beads)
+	defer m.Stop()
+	m.ReportSkippedLines(res.Skipped)
+	if !strings.Contains(m.statusMsg, "git conflict markers") {
+		t.Errorf("expected the conflict markers reported, got %q", m.statusMsg)
+	}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m"), Alt: true})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected alt+m to read the conflicts")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !m.showMergeAssistant {
+		t.Fatal("expected the merge assistant")
+	}
+	out := m.View()
+	for _, want := range []string{"1 merge conflicts", "ours (HEAD)", "theirs (feature)", "Fix login redirect", "(not there: dropped)", "Added on the branch"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the assistant:\n%s", want, out)
+		}
+	}
+
+	// B: the newer, ours, is kept; take theirs instead. C stays.
+	m = typeKeys(t, m, "t")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showMergeAssistant || cmd == nil {
+		t.Fatal("expected enter to write the file")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.statusIsError || !strings.Contains(m.statusMsg, "Resolved 2 conflicting records") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	got, err := os.ReadFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := a + "\n" + theirsB + "\n" + c + "\n"; string(got) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
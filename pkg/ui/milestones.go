@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// milestoneFilterPrefix marks a currentFilter value that restricts the list to one milestone
+const milestoneFilterPrefix = "milestone:"
+
+// milestoneCardHeight is the number of lines each milestone occupies (incl. spacing)
+const milestoneCardHeight = 3
+
+// MilestonesModel is the milestone dashboard: one progress card per milestone
+type MilestonesModel struct {
+	stats        []analysis.MilestoneStats
+	throughput   float64 // Closed issues per day used for projections
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewMilestonesModel computes milestone progress for the given issues
+func NewMilestonesModel(issues []model.Issue, now time.Time, theme Theme) MilestonesModel {
+	return MilestonesModel{
+		stats:      analysis.ComputeMilestoneStats(issues, now),
+		throughput: analysis.RecentThroughput(issues, now, analysis.DefaultThroughputWindow),
+		theme:      theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *MilestonesModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *MilestonesModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *MilestonesModel) MoveDown() {
+	if m.selected < len(m.stats)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// SelectedMilestone returns the name of the highlighted milestone
+func (m *MilestonesModel) SelectedMilestone() string {
+	if m.selected < 0 || m.selected >= len(m.stats) {
+		return ""
+	}
+	return m.stats[m.selected].Name
+}
+
+// ensureVisible adjusts scroll to keep the selected card on screen
+func (m *MilestonesModel) ensureVisible() {
+	visible := (m.height - 3) / milestoneCardHeight
+	if visible < 1 {
+		visible = 1
+	}
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the milestone dashboard
+func (m *MilestonesModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	t := m.theme
+	var lines []string
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+
+	header := fmt.Sprintf("🎯 MILESTONES  │  %d milestones  │  throughput %.1f/day (last %dd)",
+		len(m.stats), m.throughput, int(analysis.DefaultThroughputWindow.Hours()/24))
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	if len(m.stats) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No milestones. Set the milestone field or add a milestone:<name> label."))
+		return strings.Join(lines, "\n")
+	}
+
+	nameWidth := 12
+	for _, s := range m.stats {
+		if w := lipgloss.Width(s.Name); w > nameWidth {
+			nameWidth = min(w, 30)
+		}
+	}
+	barWidth := m.width - nameWidth - 40
+	if barWidth > 40 {
+		barWidth = 40
+	}
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	visible := (m.height - 3) / milestoneCardHeight
+	if visible < 1 {
+		visible = 1
+	}
+	end := m.scrollOffset + visible
+	if end > len(m.stats) {
+		end = len(m.stats)
+	}
+
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	for i := m.scrollOffset; i < end; i++ {
+		s := m.stats[i]
+		isSelected := i == m.selected
+
+		// Line 1: name, progress bar, percentage, counts
+		prefix := "  "
+		nameStyle := t.Renderer.NewStyle().Bold(true)
+		if isSelected {
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+			nameStyle = nameStyle.Foreground(t.Primary)
+		}
+		name := fitWidth(s.Name, nameWidth)
+		line1 := prefix + nameStyle.Render(name) + " " +
+			RenderMiniBar(s.Progress(), barWidth) +
+			fmt.Sprintf(" %3.0f%%  ", s.Progress()*100) +
+			subtle.Render(fmt.Sprintf("%d/%d done", s.Done, s.Total))
+		lines = append(lines, line1)
+
+		// Line 2: remaining work, blockers, projection
+		var details []string
+		if s.Remaining() == 0 {
+			details = append(details, t.Renderer.NewStyle().Foreground(t.Open).Render("✓ complete"))
+		} else {
+			details = append(details, fmt.Sprintf("%d remaining", s.Remaining()))
+			if s.InProgress > 0 {
+				details = append(details, fmt.Sprintf("%d in progress", s.InProgress))
+			}
+			if n := len(s.RemainingBlockers); n > 0 {
+				ids := strings.Join(s.RemainingBlockers, ", ")
+				ids = truncateToWidth(ids, 40, "…")
+				details = append(details, t.Renderer.NewStyle().Foreground(t.Blocked).
+					Render(fmt.Sprintf("⛔ %d blockers (%s)", n, ids)))
+			}
+			if s.ProjectedCompletion != nil {
+				details = append(details, fmt.Sprintf("ETA %s", s.ProjectedCompletion.Format("Jan 02")))
+			} else {
+				details = append(details, subtle.Render("ETA unknown (no recent throughput)"))
+			}
+		}
+		line2 := "    " + subtle.Render("└ ") + strings.Join(details, subtle.Render(" · "))
+		lines = append(lines, t.Renderer.NewStyle().MaxWidth(m.width).Render(line2))
+		lines = append(lines, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func milestoneTestIssues() []model.Issue {
+	now := time.Now()
+	closed := now.Add(-24 * time.Hour)
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusClosed, Milestone: "v1", ClosedAt: &closed, UpdatedAt: closed},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Milestone: "v1",
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Labels: []string{"milestone:v2"}},
+		{ID: "D", Title: "D", Status: model.StatusOpen},
+	}
+}
+
+func TestMilestoneViewRendersProgress(t *testing.T) {
+	m := NewModel(milestoneTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m = updated.(Model)
+	if !m.isMilestoneView || m.focused != focusMilestones {
+		t.Fatalf("expected milestone view focused")
+	}
+	view := m.View()
+	for _, want := range []string{"v1", "v2", "1/2 done", "50%", "blockers (C)", "ETA"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in milestone view", want)
+		}
+	}
+
+	// Switching to another view closes the dashboard
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = updated.(Model)
+	if m.isMilestoneView {
+		t.Fatalf("expected board toggle to close milestone view")
+	}
+}
+
+func TestMilestoneViewEnterScopesList(t *testing.T) {
+	m := NewModel(milestoneTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("M")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isMilestoneView || m.focused != focusList {
+		t.Fatalf("expected list focused after enter")
+	}
+	if got := strings.Join(visibleIDs(m), ","); got != "C" {
+		t.Fatalf("expected v2 scope C, got %s", got)
+	}
+	if view := m.View(); !strings.Contains(view, "Scoped to milestone v2") {
+		t.Fatalf("expected scope confirmation in footer")
+	}
+}
@@ -2,12 +2,15 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -15,10 +18,12 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -44,7 +49,28 @@ const (
 	focusGraph
 	focusInsights
 	focusActionable
+	focusMilestones
+	focusBurndown
+	focusFlow
+	focusVelocity
+	focusWorkload
+	focusDuplicates
+	focusProblems
+	focusActivity
+	focusCoupling
+	focusDSM
+	focusRisk
+	focusSchedule
+	focusAging
+	focusLabels
+	focusLineage
+	focusWorklog
+	focusCompare
 	focusRecipePicker
+	focusSortPicker
+	focusLabelPicker
+	focusAssigneePicker
+	focusSprintPicker
 	focusHelp
 	focusQuitConfirm
 	focusTimeTravelInput
@@ -58,20 +84,179 @@ type UpdateMsg struct {
 
 // Phase2ReadyMsg is sent when async graph analysis Phase 2 completes
 type Phase2ReadyMsg struct {
-	Stats *analysis.GraphStats // The stats that completed, to detect stale messages
+	Stats           *analysis.GraphStats // The stats that completed, to detect stale messages
+	Analyzer        *analysis.Analyzer   // The analyzer Recommendations came from; stats can outlive it on reload
+	Recommendations []analysis.PriorityRecommendation
 }
 
-// WaitForPhase2Cmd returns a command that waits for Phase 2 and sends Phase2ReadyMsg
-func WaitForPhase2Cmd(stats *analysis.GraphStats) tea.Cmd {
+// WaitForPhase2Cmd returns a command that waits for Phase 2 and sends Phase2ReadyMsg.
+// Priority recommendations are derived from the finished stats in the same worker
+// so the UI goroutine only has to swap them in.
+func WaitForPhase2Cmd(analyzer *analysis.Analyzer, stats *analysis.GraphStats) tea.Cmd {
 	return func() tea.Msg {
 		stats.WaitForPhase2()
-		return Phase2ReadyMsg{Stats: stats}
+		msg := Phase2ReadyMsg{Stats: stats, Analyzer: analyzer}
+		if analyzer != nil {
+			msg.Recommendations = analyzer.GenerateRecommendationsFromStats(stats)
+		}
+		return msg
 	}
 }
 
+// phase2TickInterval is how often the footer refreshes Phase 2 progress
+const phase2TickInterval = 150 * time.Millisecond
+
+// Phase2TickMsg drives the metrics progress indicator while Phase 2 runs
+type Phase2TickMsg struct{}
+
+// Phase2TickCmd schedules the next progress refresh
+func Phase2TickCmd() tea.Cmd {
+	return tea.Tick(phase2TickInterval, func(time.Time) tea.Msg {
+		return Phase2TickMsg{}
+	})
+}
+
 // FileChangedMsg is sent when the beads file changes on disk
 type FileChangedMsg struct{}
 
+// IssuesReloadedMsg carries issues re-read from disk along with their analyzer,
+// whose Phase 1 is already done and whose Phase 2 is running in the background
+type IssuesReloadedMsg struct {
+	Issues        []model.Issue
+	Analyzer      *analysis.Analyzer
+	Stats         *analysis.GraphStats
+	CacheHit      bool
+	Reused        bool // Graph structure unchanged, so Stats is the previous load's
+	Changes       analysis.IssueChanges
+	StructureHash string
+	IssueHashes   map[string]string
+	Skipped       []loader.LineError // Lines the loader could not read
+	Problems      []analysis.DataProblem
+	Collisions    []analysis.IDCollision
+	Extra         int           // Issues merged in from reloadBase.extraPaths
+	Archived      int           // Issues merged in from the archive file
+	Rewatch       bool          // Triggered by the file watcher, which must be restarted
+	Snapshot      *SnapshotInfo // Set when Issues are a historical state (see SnapshotCmd)
+	Err           error
+}
+
+// reloadBase is the previous load that a reload is compared against. The hash
+// map is never mutated after it is built, so the worker can read it safely.
+type reloadBase struct {
+	stats         *analysis.GraphStats
+	structureHash string
+	issueHashes   map[string]string
+	extraPaths    []string          // Other beads files whose issues are merged in
+	withArchive   bool              // Merge the archive file next to the beads file
+	rewatch       bool              // Set when the file watcher triggered the reload
+	pins          map[string]string // Records chosen for colliding IDs
+}
+
+// currentLoad describes the loaded data for ReloadIssuesCmd to compare against
+func (m *Model) currentLoad() reloadBase {
+	return reloadBase{
+		stats:         m.analysis,
+		structureHash: m.structureHash,
+		issueHashes:   m.issueHashes,
+		extraPaths:    m.extraPaths,
+		withArchive:   m.includeArchived,
+		pins:          m.collisionPins,
+	}
+}
+
+// ReloadIssuesCmd loads, sorts and analyzes the beads file off the UI goroutine.
+// When only non-structural fields changed since base, the previous stats are
+// reused instead of rerunning Phase 2.
+func ReloadIssuesCmd(beadsPath string, base reloadBase) tea.Cmd {
+	return func() tea.Msg {
+		// Stream rather than LoadIssuesFromFile, whose warnings would land on the TUI
+		res, err := loader.LoadIssuesFromFileWithProgress(beadsPath, nil)
+		if err != nil {
+			return IssuesReloadedMsg{Err: err, Rewatch: base.rewatch}
+		}
+		issues := res.Issues
+		var archived int
+		if base.withArchive {
+			if path := loader.FindArchivePath(filepath.Dir(beadsPath)); path != "" {
+				issues, archived = mergeExtraIssues(issues, []string{path})
+			}
+		}
+		issues, extra := mergeExtraIssues(issues, base.extraPaths)
+
+		msg := analyzeLoad(issues, base)
+		msg.Skipped = res.Skipped
+		msg.Problems = append(skippedLineProblems(res.Skipped), msg.Problems...)
+		msg.Extra = extra
+		msg.Archived = archived
+		return msg
+	}
+}
+
+// analyzeLoad sorts issues and runs Phase 1 for them, reusing base's stats when
+// the structure is unchanged. It runs on a worker goroutine.
+func analyzeLoad(issues []model.Issue, base reloadBase) IssuesReloadedMsg {
+	// Duplicate IDs are found before all but one record of each is dropped
+	problems := analysis.FindDataProblems(issues)
+	issues, collisions := analysis.ResolveIDCollisions(issues, base.pins)
+
+	// Apply default sorting (Open first, Priority, Date)
+	sort.Slice(issues, func(i, j int) bool {
+		iClosed := issues[i].Status == model.StatusClosed
+		jClosed := issues[j].Status == model.StatusClosed
+		if iClosed != jClosed {
+			return !iClosed
+		}
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].CreatedAt.After(issues[j].CreatedAt)
+	})
+
+	cachedAnalyzer := analysis.NewCachedAnalyzer(issues, nil)
+	stats := cachedAnalyzer.AnalyzeReusing(base.stats, base.structureHash)
+	hashes := analysis.IssueHashes(issues)
+	return IssuesReloadedMsg{
+		Issues:        issues,
+		Analyzer:      cachedAnalyzer.Analyzer,
+		Stats:         stats,
+		CacheHit:      cachedAnalyzer.WasCacheHit(),
+		Reused:        cachedAnalyzer.WasReused(),
+		Changes:       analysis.DiffIssueHashes(base.issueHashes, hashes),
+		StructureHash: cachedAnalyzer.StructureHash(),
+		IssueHashes:   hashes,
+		Problems:      problems,
+		Collisions:    collisions,
+		Rewatch:       base.rewatch,
+	}
+}
+
+// mergeExtraIssues appends issues from paths whose IDs aren't already loaded,
+// returning how many were added. Files that fail to load are skipped.
+func mergeExtraIssues(issues []model.Issue, paths []string) ([]model.Issue, int) {
+	if len(paths) == 0 {
+		return issues, 0
+	}
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		seen[issue.ID] = true
+	}
+	added := 0
+	for _, path := range paths {
+		res, err := loader.LoadIssuesFromFileWithProgress(path, nil)
+		if err != nil {
+			continue
+		}
+		for _, issue := range res.Issues {
+			if !seen[issue.ID] {
+				seen[issue.ID] = true
+				issues = append(issues, issue)
+				added++
+			}
+		}
+	}
+	return issues, added
+}
+
 // WatchFileCmd returns a command that waits for file changes and sends FileChangedMsg
 func WatchFileCmd(w *watcher.Watcher) tea.Cmd {
 	return func() tea.Msg {
@@ -100,9 +285,15 @@ type Model struct {
 	analysis  *analysis.GraphStats
 	beadsPath string           // Path to beads.jsonl for reloading
 	watcher   *watcher.Watcher // File watcher for live reload
+	reloading bool             // A reload or snapshot load is running in the background
+
+	// Fingerprints of the loaded data, so reloads can tell what changed
+	structureHash string
+	issueHashes   map[string]string
 
 	// UI Components
 	list          list.Model
+	listFit       columnFit // Column widths fitted to the listed issues, shared by rows and header
 	viewport      viewport.Model
 	renderer      *glamour.TermRenderer
 	board         BoardModel
@@ -115,21 +306,82 @@ type Model struct {
 	updateTag       string
 	updateURL       string
 
+	// Spinner frame for the Phase 2 progress badge
+	phase2Frame int
+
 	// Focus and View State
 	focused          focus
 	isSplitView      bool
 	isBoardView      bool
 	isGraphView      bool
 	isActionableView bool
+	isMilestoneView  bool
+	isBurndownView   bool
+	isFlowView       bool
+	isVelocityView   bool
+	isWorkloadView   bool
+	isDuplicatesView bool
+	isProblemsView   bool
+	isActivityView   bool
+	isCouplingView   bool
+	isDSMView        bool
+	isRiskView       bool
+	isScheduleView   bool
+	isAgingView      bool
+	isLabelsView     bool
+	isLineageView    bool
+	isWorklogView    bool
+	isCompareView    bool
 	showDetails      bool
 	showHelp         bool
 	showQuitConfirm  bool
+	showQuickLook    bool // Summary popup over the list (space)
 	ready            bool
 	width            int
 	height           int
 
 	// Actionable view
 	actionableView ActionableModel
+	milestoneView  MilestonesModel
+	burndownView   BurndownModel
+	flowView       FlowModel
+	velocityView   VelocityModel
+	workloadView   WorkloadModel
+	duplicatesView DuplicatesModel
+	problemsView   ProblemsModel
+	activityView   ActivityModel
+	couplingView   CouplingModel
+	dsmView        DSMModel
+	riskView       RiskModel
+	scheduleView   ScheduleModel
+	agingView      AgingModel
+	labelsView     LabelAnalyticsModel
+	lineageView    LineageModel
+	worklogView    WorklogModel
+
+	// Side-by-side comparison (=): the issue marked first, and the focus to
+	// return to when the comparison closes
+	compareView   ComparisonModel
+	compareMark   string
+	compareReturn focus
+
+	// Data problems in the loaded file (unreadable lines first), shown with P
+	dataProblems []analysis.DataProblem
+
+	// IDs several records share, and the records chosen for some of them
+	// (ID -> analysis.IssueHash), saved at collisionPinsPath
+	collisions        []analysis.IDCollision
+	collisionPins     map[string]string
+	collisionPinsPath string
+
+	// Other beads files loaded from the problems panel to resolve dangling dependencies
+	extraPaths []string
+
+	// Archived issues are only loaded on request (A)
+	includeArchived bool
+
+	// Duplicate pairs marked "not a duplicate" this session (see duplicatePairKey)
+	dismissedDuplicates map[string]bool
 
 	// Filter state
 	currentFilter string
@@ -149,8 +401,67 @@ type Model struct {
 	showRecipePicker bool
 	recipePicker     RecipePickerModel
 	activeRecipe     *recipe.Recipe
+	focus            *subgraphFocus // ctrl+f: only the selected issue's subgraph, nil for all
+	epicScope        string         // alt+e: only this epic's subtree, "" for all
+	mineOnly         bool           // @: only the current user's issues
 	recipeLoader     *recipe.Loader
 
+	// List sort (overrides default/recipe ordering when set)
+	sortMode       SortMode
+	showSortPicker bool
+	sortPicker     SortPickerModel
+
+	// Label filter menu
+	showLabelPicker bool
+	labelPicker     LabelPickerModel
+
+	// Assignee menu, for filtering the list or assigning the selected issue
+	showAssigneePicker bool
+	assigneePicker     AssigneePickerModel
+
+	// New-issue form (n), prefilled from the configured templates
+	showCreateForm bool
+	createForm     CreateFormModel
+
+	// Comment composer (m) for the selected issue
+	showCommentComposer bool
+	commentComposer     CommentComposerModel
+
+	// Focus mode (alt+f): a pomodoro on one ready issue, in place of the UI
+	showFocusMode bool
+	focusMode     FocusModeModel
+
+	// Conflict resolver for an edit that clashes with changes saved outside bv
+	showConflictResolver bool
+	conflictResolver     ConflictResolverModel
+
+	// Merge assistant (alt+m) for a beads file left with git conflict markers
+	showMergeAssistant bool
+	mergeAssistant     MergeAssistantModel
+
+	// Sprint scope menu (returns focus to the list or board it was opened from)
+	showSprintPicker   bool
+	sprintPicker       SprintPickerModel
+	sprintPickerReturn focus
+
+	// Estimate rollups (computed lazily, reset on reload)
+	effort map[string]analysis.EffortRollup
+
+	// Slack of each open issue (computed lazily, reset on reload)
+	slack map[string]analysis.Slack
+
+	// Issues named in each issue's text without a dependency, and the ID
+	// lookup used to find them (computed lazily, reset on reload)
+	mentions   map[string][]string
+	mentionIDs map[string]string
+
+	// Communities of linked work, detected when first grouped by cluster
+	clusters *analysis.Clusters
+
+	// List grouping (section headers)
+	groupBy         GroupBy
+	collapsedGroups map[string]bool // GroupHeaderItem.Key -> collapsed
+
 	// Time-travel mode
 	timeTravelMode   bool
 	timeTravelDiff   *analysis.SnapshotDiff
@@ -162,6 +473,96 @@ type Model struct {
 	// Time-travel input prompt
 	timeTravelInput      textinput.Model
 	showTimeTravelPrompt bool
+	timeTravelAsOf       bool // The prompt is for "view as of" (H) rather than compare (t)
+
+	// Scrolling and / search in the details
+	pager detailPager
+
+	// Pane sizes (< and >), saved to layoutPath when set
+	layout     Layout
+	layoutPath string
+
+	// Tabs (ctrl+n, gt/gT, 1-9): the saved state of each, the current tab's
+	// entry being refreshed when leaving it. Empty until a second tab opens.
+	tabs      []workspaceTab
+	activeTab int
+	// The current tab as it was before g, in case g starts gt or gT
+	pendingTab *workspaceTab
+
+	// Historical state shown instead of the live issues (H), nil when live
+	snapshot *SnapshotInfo
+
+	// Commits whose messages mention each issue ID, newest first
+	commitLinks       map[string][]loader.CommitLink
+	gitRemote         string // Origin remote URL, for forge links
+	commitCursor      int    // Highlighted linked commit ([ and ])
+	commitCursorIssue string // Issue the commit cursor belongs to
+
+	// Highlighted URL or issue mention in the details ({ and }), followed with f
+	linkCursor      int
+	linkCursorIssue string
+
+	// Highlighted item of the description's task list (( and )), toggled with x
+	checklistCursor      int
+	checklistCursorIssue string
+
+	// Attachment preview (v in the details), over the body
+	showAttachmentPreview bool
+	attachmentIndex       int
+	imagePreview          string // view.image_preview: a protocol, "off" or "auto"
+	previewSeq            int    // Bumped by each load, so stale images are dropped
+	previewEscape         string // The current image, encoded for the terminal
+	previewErr            error  // Why the current image couldn't be encoded
+
+	// Status changes reconstructed from git snapshots of the beads file,
+	// applied to issues that carry no status_history of their own
+	statusHistory map[string][]model.StatusChange
+
+	// Project's issue URL template (.bv/links.yaml), nil when not configured
+	issueURL *template.Template
+
+	// User configuration (SetKeyTranslation, SetListColumns): rebound keys
+	// mapped to the default keys the handlers expect, and the optional list
+	// columns to show (nil for all)
+	keyTranslation map[string]string
+	listColumns    []string
+
+	// Fields other tools add to issues, shown as list columns and detail
+	// sections (SetCustomFields)
+	fieldColumns []string
+	fieldDetails []string
+
+	// List row style: how tightly rows pack (SetDensity, d), lines per
+	// issue, 2 or 3 for cards (SetCardLines, x), and for cards the open
+	// issues each issue blocks
+	density      Density
+	cardLines    int
+	listUnblocks map[string]int
+
+	// User scripts for computed columns, the custom sort and impact (SetScripts)
+	scripts *script.Engine
+
+	// External commands bound to keys (SetPlugins), and the pager showing
+	// the last one's output
+	plugins          map[string]Plugin
+	showPluginPager  bool
+	pluginPager      viewport.Model
+	pluginPagerTitle string
+
+	// Unblock alerts (SetNotifyAssignee): whose issues to watch, and
+	// whether to raise a desktop notification as well as the status bar
+	notifyAssignee string
+	desktopNotify  bool
+
+	// Time tracking (.bv/worklog.json); nil when there is no project directory
+	worklog *worklog.Log
+
+	// Terminal of a remote (SSH) session, nil when running locally. Copies
+	// go to it via OSC 52 and URLs are shown rather than opened.
+	remoteTerm io.Writer
+
+	// Why the issues can't be edited, "" when they can (SetReadOnly)
+	readOnly string
 
 	// Status message (for temporary feedback)
 	statusMsg     string
@@ -186,9 +587,29 @@ type WorkspaceInfo struct {
 // NewModel creates a new Model from the given issues
 // beadsPath is the path to the beads.jsonl file for live reload support
 func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string) Model {
+	return NewModelWithRenderer(issues, activeRecipe, beadsPath, lipgloss.NewRenderer(os.Stdout))
+}
+
+// NewModelWithRenderer is NewModel for a terminal other than the process's
+// own, such as an SSH session: colors and the light/dark palette come from lr.
+func NewModelWithRenderer(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string, lr *lipgloss.Renderer) Model {
+	projectDir := beadsProjectDir(beadsPath)
+	if projectDir == "" {
+		projectDir, _ = os.Getwd()
+	}
+
+	// One record per ID: the one updated last, or the one chosen in the
+	// problems panel. The others are reported as data problems.
+	pinsPath := collisionPinsPath(projectDir)
+	pins, pinsErr := LoadCollisionPins(pinsPath)
+	dataProblems := analysis.FindDataProblems(issues)
+	issues, collisions := analysis.ResolveIDCollisions(issues, pins)
+
 	// Graph Analysis - Phase 1 is instant, Phase 2 runs in background
-	analyzer := analysis.NewAnalyzer(issues)
-	graphStats := analyzer.AnalyzeAsync()
+	// (or is skipped when the insights cache already has this data)
+	cachedAnalyzer := analysis.NewCachedAnalyzer(issues, nil)
+	analyzer := cachedAnalyzer.Analyzer
+	graphStats := cachedAnalyzer.AnalyzeAsync()
 
 	// Sort issues
 	if activeRecipe != nil && activeRecipe.Sort.Field != "" {
@@ -265,7 +686,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		// Check if blocked by open dependencies
 		isBlocked := false
 		for _, dep := range issue.Dependencies {
-			if dep.Type != model.DepBlocks {
+			if !dep.Type.IsBlocking() {
 				continue
 			}
 			if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
@@ -279,10 +700,11 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	}
 
 	// Theme
-	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	theme := DefaultTheme(lr)
 
 	// List setup
-	delegate := IssueDelegate{Theme: theme, WorkspaceMode: false}
+	listFit := fitColumns(items)
+	delegate := IssueDelegate{Theme: theme, WorkspaceMode: false, Fit: &listFit}
 	l := list.New(items, delegate, 0, 0)
 	l.Title = ""
 	l.SetShowTitle(false)
@@ -291,24 +713,24 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	l.SetShowPagination(false)
 	l.SetFilteringEnabled(true)
 	l.DisableQuitKeybindings()
+	// u (assignee filter) and d (row density) are list keys of our own
+	l.KeyMap.PrevPage.SetKeys("left", "h", "pgup", "b")
+	l.KeyMap.NextPage.SetKeys("right", "l", "pgdown", "f")
 	// Clear all default styles that might add extra lines
-	l.Styles.Title = lipgloss.NewStyle()
-	l.Styles.TitleBar = lipgloss.NewStyle()
-	l.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(theme.Primary)
-	l.Styles.FilterCursor = lipgloss.NewStyle().Foreground(theme.Primary)
-	l.Styles.StatusBar = lipgloss.NewStyle()
-	l.Styles.StatusEmpty = lipgloss.NewStyle()
-	l.Styles.StatusBarActiveFilter = lipgloss.NewStyle()
-	l.Styles.StatusBarFilterCount = lipgloss.NewStyle()
-	l.Styles.NoItems = lipgloss.NewStyle()
-	l.Styles.PaginationStyle = lipgloss.NewStyle()
-	l.Styles.HelpStyle = lipgloss.NewStyle()
+	l.Styles.Title = theme.Renderer.NewStyle()
+	l.Styles.TitleBar = theme.Renderer.NewStyle()
+	l.Styles.FilterPrompt = theme.Renderer.NewStyle().Foreground(theme.Primary)
+	l.Styles.FilterCursor = theme.Renderer.NewStyle().Foreground(theme.Primary)
+	l.Styles.StatusBar = theme.Renderer.NewStyle()
+	l.Styles.StatusEmpty = theme.Renderer.NewStyle()
+	l.Styles.StatusBarActiveFilter = theme.Renderer.NewStyle()
+	l.Styles.StatusBarFilterCount = theme.Renderer.NewStyle()
+	l.Styles.NoItems = theme.Renderer.NewStyle()
+	l.Styles.PaginationStyle = theme.Renderer.NewStyle()
+	l.Styles.HelpStyle = theme.Renderer.NewStyle()
 
 	// Glamour markdown renderer
-	renderer, _ := glamour.NewTermRenderer(
-		glamour.WithAutoStyle(),
-		glamour.WithWordWrap(80),
-	)
+	renderer, _ := newMarkdownRenderer(lr, 80)
 
 	// Initialize sub-components
 	board := NewBoardModel(issues, theme)
@@ -331,8 +753,8 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	ti.CharLimit = 100
 	ti.Width = 40
 	ti.Prompt = "⏱️  Revision: "
-	ti.PromptStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
-	ti.TextStyle = lipgloss.NewStyle().Foreground(theme.Base.GetForeground())
+	ti.PromptStyle = theme.Renderer.NewStyle().Foreground(theme.Primary).Bold(true)
+	ti.TextStyle = theme.Renderer.NewStyle().Foreground(theme.Base.GetForeground())
 
 	// Initialize file watcher for live reload
 	var fileWatcher *watcher.Watcher
@@ -350,6 +772,14 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		}
 	}
 
+	// Fingerprint the data so live reloads can tell what changed
+	var structureHash string
+	var issueHashes map[string]string
+	if fileWatcher != nil {
+		structureHash = cachedAnalyzer.StructureHash()
+		issueHashes = analysis.IssueHashes(issues)
+	}
+
 	// Build initial status message if watcher failed
 	var initialStatus string
 	var initialStatusErr bool
@@ -358,41 +788,81 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		initialStatusErr = true
 	}
 
+	// Issue URL template for opening issues in the browser (o)
+	issueURL, err := LoadIssueURLTemplate(projectDir)
+	if err != nil && initialStatus == "" {
+		initialStatus = fmt.Sprintf("Issue links unavailable: %v", err)
+		initialStatusErr = true
+	}
+	if pinsErr != nil && initialStatus == "" {
+		initialStatus = fmt.Sprintf("Record choices for duplicate IDs unavailable: %v", pinsErr)
+		initialStatusErr = true
+	}
+	if len(collisions) > 0 && initialStatus == "" {
+		initialStatus = fmt.Sprintf("⚠️ %d IDs are shared by several records; using the ones updated last · P to choose", len(collisions))
+		initialStatusErr = true
+	}
+
 	return Model{
-		issues:            issues,
-		issueMap:          issueMap,
-		analyzer:          analyzer,
-		analysis:          graphStats,
-		beadsPath:         beadsPath,
-		watcher:           fileWatcher,
-		list:              l,
-		renderer:          renderer,
-		board:             board,
-		graphView:         graphView,
-		insightsPanel:     insightsPanel,
-		theme:             theme,
-		currentFilter:     "all",
-		focused:           focusList,
-		countOpen:         cOpen,
-		countReady:        cReady,
-		countBlocked:      cBlocked,
-		countClosed:       cClosed,
-		priorityHints:     priorityHints,
-		showPriorityHints: false, // Off by default, toggle with 'p'
-		recipeLoader:      recipeLoader,
-		recipePicker:      recipePicker,
-		activeRecipe:      activeRecipe,
-		timeTravelInput:   ti,
-		statusMsg:         initialStatus,
-		statusIsError:     initialStatusErr,
+		issues:              issues,
+		issueMap:            issueMap,
+		analyzer:            analyzer,
+		analysis:            graphStats,
+		beadsPath:           beadsPath,
+		watcher:             fileWatcher,
+		structureHash:       structureHash,
+		issueHashes:         issueHashes,
+		dataProblems:        dataProblems,
+		collisions:          collisions,
+		collisionPins:       pins,
+		collisionPinsPath:   pinsPath,
+		list:                l,
+		listFit:             listFit,
+		cardLines:           1,
+		renderer:            renderer,
+		board:               board,
+		graphView:           graphView,
+		insightsPanel:       insightsPanel,
+		theme:               theme,
+		currentFilter:       "all",
+		focused:             focusList,
+		countOpen:           cOpen,
+		countReady:          cReady,
+		countBlocked:        cBlocked,
+		countClosed:         cClosed,
+		priorityHints:       priorityHints,
+		showPriorityHints:   false, // Off by default, toggle with 'p'
+		recipeLoader:        recipeLoader,
+		recipePicker:        recipePicker,
+		activeRecipe:        activeRecipe,
+		sortPicker:          NewSortPickerModel(theme),
+		labelPicker:         NewLabelPickerModel(issues, theme),
+		assigneePicker:      NewAssigneePickerModel(issues, theme),
+		createForm:          NewCreateFormModel(nil, theme),
+		commentComposer:     NewCommentComposerModel(theme),
+		focusMode:           NewFocusModeModel(theme),
+		conflictResolver:    NewConflictResolverModel(theme),
+		mergeAssistant:      NewMergeAssistantModel(theme),
+		sprintPicker:        NewSprintPickerModel(issues, theme),
+		collapsedGroups:     make(map[string]bool),
+		dismissedDuplicates: make(map[string]bool),
+		timeTravelInput:     ti,
+		pager:               detailPager{input: newDetailSearchInput(theme)},
+		layout:              DefaultLayout(),
+		issueURL:            issueURL,
+		statusMsg:           initialStatus,
+		statusIsError:       initialStatusErr,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
-	cmds := []tea.Cmd{CheckUpdateCmd(), WaitForPhase2Cmd(m.analysis)}
+	cmds := []tea.Cmd{CheckUpdateCmd(), WaitForPhase2Cmd(m.analyzer, m.analysis), Phase2TickCmd()}
 	if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
 	}
+	if m.beadsPath != "" {
+		cmds = append(cmds, LinkCommitsCmd(m.beadsPath, m.issues), StatusHistoryCmd(m.beadsPath))
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -406,14 +876,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateTag = msg.TagName
 		m.updateURL = msg.URL
 
+	case Phase2TickMsg:
+		// Keep the progress badge animating until Phase 2 finishes
+		if m.analysis == nil || m.analysis.IsPhase2Ready() {
+			return m, nil
+		}
+		m.phase2Frame++
+		return m, Phase2TickCmd()
+
 	case Phase2ReadyMsg:
 		// Ignore stale Phase2 completions (from before a file reload)
-		if msg.Stats != m.analysis {
+		if msg.Stats != m.analysis || (msg.Analyzer != nil && msg.Analyzer != m.analyzer) {
 			return m, nil
 		}
 		// Phase 2 analysis complete - regenerate insights with full data
 		ins := m.analysis.GenerateInsights(len(m.issues))
-		m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
+		m.insightsPanel = NewInsightsModel(m.scopedInsights(ins), m.issueMap, m.theme)
 		bodyHeight := m.height - 1
 		if bodyHeight < 5 {
 			bodyHeight = 5
@@ -421,8 +899,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.insightsPanel.SetSize(m.width, bodyHeight)
 		m.graphView = NewGraphModel(m.issues, &ins, m.theme)
 
-		// Generate priority recommendations now that Phase 2 is ready
-		recommendations := m.analyzer.GenerateRecommendations()
+		// Swap in the priority recommendations built by the Phase 2 worker
+		recommendations := msg.Recommendations
 		m.priorityHints = make(map[string]*analysis.PriorityRecommendation, len(recommendations))
 		for i := range recommendations {
 			m.priorityHints[recommendations[i].IssueID] = &recommendations[i]
@@ -449,6 +927,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				for i := range m.issues {
 					m.issueMap[m.issues[i].ID] = &m.issues[i]
 				}
+				m.scripts.Invalidate()
 			}
 		}
 
@@ -457,18 +936,150 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.activeRecipe != nil {
 			m.applyRecipe(m.activeRecipe)
 		} else {
-			// Update list items with new scores (PageRank, Impact now available)
-			items := make([]list.Item, len(m.issues))
-			for i := range m.issues {
-				items[i] = IssueItem{
-					Issue:      m.issues[i],
-					GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
-					Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
-					RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
-				}
-			}
-			m.list.SetItems(items)
+			// Update list items with new scores (PageRank, Impact now available),
+			// keeping the current filter and sort
+			m.applyFilter()
+		}
+
+	case DedupResultMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Dedup failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.duplicatesView.Remove(msg.Pair)
+		m.statusMsg = fmt.Sprintf("Closed %s as duplicate of %s", msg.Pair.Duplicate, msg.Pair.Keep)
+		m.statusIsError = false
+
+	case DanglingLocationsMsg:
+		if m.isProblemsView {
+			m.problemsView.SetLocations(msg.Locations, filepath.Dir(beadsProjectDir(m.beadsPath)))
+		}
+
+	case AssigneeSetMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Assigning %s failed: %v", msg.ID, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Assigned %s to @%s", msg.ID, msg.Assignee)
+		if msg.Assignee == "" {
+			m.statusMsg = "Unassigned " + msg.ID
+		}
+		m.statusIsError = false
+
+	case IssueCreatedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Creating %q failed: %v", msg.Title, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Created %q", msg.Title)
+		m.statusIsError = false
+
+	case CommentAddedMsg:
+		if msg.Err != nil {
+			m.patchIssue(msg.ID, func(issue *model.Issue) {
+				issue.Comments = slices.DeleteFunc(slices.Clone(issue.Comments), func(c *model.Comment) bool { return c == msg.Comment })
+			})
+			m.statusMsg = fmt.Sprintf("❌ Commenting on %s failed: %v", msg.ID, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Commented on %s", msg.ID)
+		m.statusIsError = false
+
+	case FocusTickMsg:
+		if !m.showFocusMode || msg.Session != m.focusMode.session || !m.focusMode.Running() {
+			return m, nil
+		}
+		if m.focusMode.Tick(time.Now()) {
+			return m, m.focusTimeUp()
+		}
+		return m, focusTickCmd(msg.Session)
+
+	case StatusChangedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Marking %s %s failed: %v", msg.ID, msg.Status, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.patchIssue(msg.ID, func(issue *model.Issue) { issue.Status = msg.Status })
+		if m.focusMode.issue.ID == msg.ID {
+			m.focusMode.issue.Status = msg.Status
+		}
+		m.statusMsg = fmt.Sprintf("Marked %s %s", msg.ID, msg.Status)
+		m.statusIsError = false
+
+	case WriteConflictMsg:
+		m.openConflictResolver(msg)
+
+	case MergeConflictLoadedMsg:
+		switch {
+		case msg.Err != nil:
+			m.statusMsg = fmt.Sprintf("❌ Reading the merge conflicts failed: %v", msg.Err)
+			m.statusIsError = true
+		case msg.Conflict.Hunks == 0:
+			m.statusMsg = "The beads file has no conflict markers"
+			m.statusIsError = false
+		default:
+			m.mergeAssistant.Open(msg.Conflict)
+			m.mergeAssistant.SetSize(m.width, m.height-1)
+			m.showMergeAssistant = true
+		}
+		return m, nil
+
+	case MergeConflictResolvedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Writing the resolved beads file failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Resolved %d conflicting records · git add the beads file to finish the merge", msg.Records)
+		m.statusIsError = false
+		if m.watcher == nil {
+			m.reloading = true
+			return m, ReloadIssuesCmd(m.beadsPath, m.currentLoad())
+		}
+		return m, nil
+
+	case ConflictResolvedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Saving %s failed: %v", msg.ID, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Saved %s, merged with the changes made outside bv", msg.ID)
+		m.statusIsError = false
+
+	case CommentEditedMsg:
+		if msg.Err != nil {
+			m.commentComposer.SetError(fmt.Errorf("editor: %w", msg.Err))
+		} else {
+			m.commentComposer.SetText(msg.Text)
+		}
+
+	case ChecklistToggledMsg:
+		if msg.Err != nil {
+			m.setIssueDescription(msg.ID, msg.Previous)
+			m.statusMsg = fmt.Sprintf("❌ Saving the checklist of %s failed: %v", msg.ID, msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Checked off %q", msg.Item)
+		if !msg.Done {
+			m.statusMsg = fmt.Sprintf("Unchecked %q", msg.Item)
+		}
+		m.statusIsError = false
+
+	case DependencyRemovedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Removing dependency failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
 		}
+		m.statusMsg = fmt.Sprintf("Removed dependency %s → %s", msg.From, msg.To)
+		m.statusIsError = false
 
 	case FileChangedMsg:
 		// File changed on disk - reload issues and recompute analysis
@@ -480,6 +1091,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Load and analyze in the background; IssuesReloadedMsg swaps the results in
+		base := m.currentLoad()
+		base.rewatch = true
+		m.reloading = true
+		return m, ReloadIssuesCmd(m.beadsPath, base)
+
+	case PluginResultMsg:
+		m.handlePluginResult(msg)
+		return m, nil
+
+	case attachmentPreviewMsg:
+		if msg.seq == m.previewSeq && m.showAttachmentPreview {
+			m.previewEscape, m.previewErr = msg.escape, msg.err
+		}
+		return m, nil
+
+	case CommitLinksMsg:
+		// Not a git repo (or no history) just means no linked commits
+		if msg.Err == nil {
+			m.commitLinks = msg.Links
+			m.gitRemote = msg.RemoteURL
+			m.updateViewportContent()
+		}
+		return m, nil
+
+	case StatusHistoryMsg:
+		// Without git history the timeline falls back to timestamps
+		if msg.Err == nil {
+			m.statusHistory = msg.History
+			m.updateViewportContent()
+		}
+		return m, nil
+
+	case IssuesReloadedMsg:
+		m.reloading = false
+		if msg.Err != nil && msg.Snapshot != nil {
+			m.statusMsg = fmt.Sprintf("❌ Time-travel failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Reload error: %v", msg.Err)
+			m.statusIsError = true
+			// Re-start watch for next change
+			if m.watcher != nil && msg.Rewatch {
+				cmds = append(cmds, WatchFileCmd(m.watcher))
+			}
+			return m, tea.Batch(cmds...)
+		}
+		newIssues := msg.Issues
+		changes := msg.Changes
+
+		// Nothing we track changed (e.g. the file was touched or rewritten as-is)
+		if changes.Count() == 0 && !m.timeTravelMode && slices.Equal(msg.Problems, m.dataProblems) &&
+			msg.Snapshot == nil && m.snapshot == nil {
+			m.collisions = msg.Collisions // A record chosen by hand may have the same content
+			m.problemsView.SetCollisions(msg.Collisions)
+			if m.watcher != nil && msg.Rewatch {
+				cmds = append(cmds, WatchFileCmd(m.watcher))
+			}
+			return m, tea.Batch(cmds...)
+		}
+
 		// Exit time-travel mode if active (file changed, show current state)
 		if m.timeTravelMode {
 			m.timeTravelMode = false
@@ -490,16 +1164,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.modifiedIssueIDs = nil
 		}
 
-		// Reload issues from disk
-		newIssues, err := loader.LoadIssuesFromFile(m.beadsPath)
-		if err != nil {
-			m.statusMsg = fmt.Sprintf("Reload error: %v", err)
-			m.statusIsError = true
-			// Re-start watch for next change
-			if m.watcher != nil {
-				cmds = append(cmds, WatchFileCmd(m.watcher))
-			}
-			return m, tea.Batch(cmds...)
+		// My issues waiting on blockers, to spot the ones this reload unblocks
+		var waiting map[string]bool
+		if msg.Snapshot == nil && m.snapshot == nil {
+			waiting = m.waitingIssues()
 		}
 
 		// Store selected issue ID to restore position after reload
@@ -510,34 +1178,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Apply default sorting (Open first, Priority, Date)
-		sort.Slice(newIssues, func(i, j int) bool {
-			iClosed := newIssues[i].Status == model.StatusClosed
-			jClosed := newIssues[j].Status == model.StatusClosed
-			if iClosed != jClosed {
-				return !iClosed
-			}
-			if newIssues[i].Priority != newIssues[j].Priority {
-				return newIssues[i].Priority < newIssues[j].Priority
-			}
-			return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
-		})
-
-		// Recompute analysis (async Phase 1/Phase 2) with caching
+		// Swap in the analysis computed by the reload worker (Phase 2 still running)
 		m.issues = newIssues
-		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
-		m.analyzer = cachedAnalyzer.Analyzer
-		m.analysis = cachedAnalyzer.AnalyzeAsync()
-		cacheHit := cachedAnalyzer.WasCacheHit()
-
-		// Rebuild lookup map
+		m.analyzer = msg.Analyzer
+		m.analysis = msg.Stats
+		m.structureHash = msg.StructureHash
+		m.issueHashes = msg.IssueHashes
+		m.dataProblems = msg.Problems
+		m.collisions = msg.Collisions
+		m.snapshot = msg.Snapshot
+		cacheHit := msg.CacheHit
+
+		// Rebuild lookup map. A reload that keeps the graph's structure
+		// reuses the analysis, so scripts must drop their results explicitly.
+		m.scripts.Invalidate()
+		m.effort = nil
+		m.slack = nil
+		m.clusters = nil
+		m.mentions, m.mentionIDs = nil, nil
 		m.issueMap = make(map[string]*model.Issue, len(newIssues))
 		for i := range m.issues {
 			m.issueMap[m.issues[i].ID] = &m.issues[i]
 		}
+		if m.board.Swimlanes() != GroupNone {
+			m.board.SetSwimlanes(m.board.Swimlanes(), m.issueMap)
+		}
 
-		// Clear stale priority hints (will be repopulated after Phase 2)
-		m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
+		// Clear stale priority hints (will be repopulated after Phase 2). With
+		// reused stats the old hints stay until the new analyzer's arrive.
+		if !msg.Reused {
+			m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
+		}
 
 		// Recompute stats
 		m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
@@ -554,7 +1225,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			isBlocked := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type != model.DepBlocks {
+				if !dep.Type.IsBlocking() {
 					continue
 				}
 				if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
@@ -567,57 +1238,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Rebuild list items
-		items := make([]list.Item, len(m.issues))
-		for i := range m.issues {
-			items[i] = IssueItem{
-				Issue:      m.issues[i],
-				GraphScore: m.analysis.GetPageRankScore(m.issues[i].ID),
-				Impact:     m.analysis.GetCriticalPathScore(m.issues[i].ID),
-				RepoPrefix: ExtractRepoPrefix(m.issues[i].ID),
-			}
-		}
-		m.list.SetItems(items)
-
-		// Restore selection position
-		if selectedID != "" {
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
-					m.list.Select(i)
-					break
-				}
-			}
-		}
-
 		// Regenerate sub-views (with Phase 1 data; Phase 2 will update via Phase2ReadyMsg)
 		ins := m.analysis.GenerateInsights(len(m.issues))
-		m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
+		m.insightsPanel = NewInsightsModel(m.scopedInsights(ins), m.issueMap, m.theme)
 		bodyHeight := m.height - 1
 		if bodyHeight < 5 {
 			bodyHeight = 5
 		}
 		m.insightsPanel.SetSize(m.width, bodyHeight)
-		m.graphView = NewGraphModel(m.issues, &ins, m.theme)
+		if msg.Reused {
+			// Same structure and metrics: patch only the changed issues' links
+			m.graphView.UpdateIssues(m.issues, &ins, changes.Modified)
+		} else {
+			m.graphView = NewGraphModel(m.issues, &ins, m.theme)
+		}
 		m.board = NewBoardModel(m.issues, m.theme)
+		if m.isProblemsView {
+			selected := m.problemsView.selected
+			cmds = append(cmds, m.openProblemsView())
+			m.problemsView.Select(selected)
+		}
 
-		// Re-apply recipe filter if active
+		// Rebuild list items, re-applying the active recipe or filter and sort
 		if m.activeRecipe != nil {
 			m.applyRecipe(m.activeRecipe)
+		} else {
+			m.applyFilter()
+		}
+
+		// Restore selection position
+		if selectedID != "" {
+			for i, item := range m.list.Items() {
+				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selectedID {
+					m.list.Select(i)
+					break
+				}
+			}
 		}
 
-		if cacheHit {
+		if s := msg.Snapshot; s != nil {
+			d := s.Diff.Summary
+			m.statusMsg = fmt.Sprintf("🕰 Viewing %d issues as of %s · since then +%d new, %d closed, %d modified (H for today)",
+				len(newIssues), s.Label(), d.IssuesAdded, d.IssuesClosed, d.IssuesModified)
+		} else if msg.Reused {
+			m.statusMsg = fmt.Sprintf("Reloaded %d issues (%d changed, metrics reused)", len(newIssues), changes.Count())
+		} else if cacheHit {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues (cached)", len(newIssues))
 		} else {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues", len(newIssues))
 		}
 		m.statusIsError = false
+		if msg.Archived > 0 {
+			m.statusMsg += fmt.Sprintf(" · %d archived", msg.Archived)
+		}
+		if msg.Extra > 0 {
+			m.statusMsg += fmt.Sprintf(" · %d from other files", msg.Extra)
+		}
+		if n := len(msg.Skipped); n > 0 {
+			m.statusMsg += fmt.Sprintf(" · %d unreadable lines skipped", n)
+		}
+		if loader.HasConflictMarkers(msg.Skipped) {
+			m.statusMsg += " · git conflict markers: alt+m to resolve"
+			m.statusIsError = true
+		}
+		cmds = append(cmds, m.announceUnblocked(m.newlyReady(waiting)))
 		m.updateViewportContent()
 
-		// Re-start watching for next change + wait for Phase 2
-		if m.watcher != nil {
+		// Re-start watching for next change + wait for Phase 2. The file
+		// usually changes alongside a commit, so look for new linked commits too.
+		if m.watcher != nil && msg.Rewatch {
 			cmds = append(cmds, WatchFileCmd(m.watcher))
+			cmds = append(cmds, LinkCommitsCmd(m.beadsPath, m.issues))
+		}
+		cmds = append(cmds, WaitForPhase2Cmd(m.analyzer, m.analysis))
+		if !m.analysis.IsPhase2Ready() {
+			cmds = append(cmds, Phase2TickCmd())
 		}
-		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
@@ -625,6 +1321,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = ""
 		m.statusIsError = false
 
+		// Rebound keys stand in for their action's default key, except where
+		// keys are typed as text or drive an open menu
+		if to, ok := m.keyTranslation[msg.String()]; ok && m.acceptsGlobalKeys() {
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(to)}
+		}
+
+		// g followed by t or T switches tabs rather than opening the graph
+		pendingTab := m.pendingTab
+		m.pendingTab = nil
+
+		if m.showPluginPager {
+			return m.handlePluginPagerKeys(msg)
+		}
+		// The detail search prompt takes every key
+		if m.pager.searching {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleDetailSearchKeys(msg)
+		}
+		if p, ok := m.plugins[msg.String()]; ok && m.acceptsGlobalKeys() && m.focused != focusHelp {
+			return m, m.runPlugin(p)
+		}
+
 		// Handle quit confirmation first
 		if m.showQuitConfirm {
 			switch msg.String() {
@@ -637,6 +1357,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.showQuickLook {
+			return m.handleQuickLookKeys(msg)
+		}
+		if m.showAttachmentPreview {
+			return m.handleAttachmentPreviewKeys(msg)
+		}
+		if m.isCompareView {
+			return m.handleCompareKeys(msg)
+		}
+
 		// Handle help overlay toggle (? or F1)
 		if (msg.String() == "?" || msg.String() == "f1") && m.list.FilterState() != list.Filtering {
 			m.showHelp = !m.showHelp
@@ -655,29 +1385,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// A write conflict must be settled first, even over focus mode
+		if m.showConflictResolver {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleConflictResolverKeys(msg)
+		}
+
+		if m.showMergeAssistant {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleMergeAssistantKeys(msg)
+		}
+
+		// Focus mode takes every key until it is left
+		if m.showFocusMode {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleFocusModeKeys(msg)
+		}
+
 		// Handle time-travel input first (before global keys intercept letters)
 		// But allow ctrl+c to always quit
 		if m.focused == focusTimeTravelInput {
 			if msg.String() == "ctrl+c" {
 				return m, tea.Quit
 			}
-			m = m.handleTimeTravelInputKeys(msg)
-			return m, nil
+			return m.handleTimeTravelInputKeys(msg)
 		}
 
-		// Handle keys when not filtering
-		if m.list.FilterState() != list.Filtering {
-			switch msg.String() {
-			case "ctrl+c":
+		// The assignee menu takes typed text too
+		if m.focused == focusAssigneePicker {
+			if msg.String() == "ctrl+c" {
 				return m, tea.Quit
+			}
+			return m.handleAssigneePickerKeys(msg)
+		}
 
-			case "q":
-				// q closes current view or quits if at top level
-				if m.showDetails && !m.isSplitView {
-					m.showDetails = false
-					return m, nil
-				}
-				if m.focused == focusInsights {
+		// So does the new-issue form
+		if m.showCreateForm {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleCreateFormKeys(msg)
+		}
+
+		// And the comment composer
+		if m.showCommentComposer {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleCommentComposerKeys(msg)
+		}
+
+		// Scrolling and searching the details come before the list's keys
+		if m.detailPagerActive() && m.list.FilterState() != list.Filtering {
+			if updated, cmd, ok := m.handleDetailPagerKeys(msg); ok {
+				return updated, cmd
+			}
+		}
+
+		// Handle keys when not filtering
+		if m.list.FilterState() != list.Filtering {
+			if pendingTab != nil && (msg.String() == "t" || msg.String() == "T") {
+				// Undo the graph toggle before leaving the tab
+				m, _ = m.loadTab(*pendingTab)
+				if msg.String() == "t" {
+					return m.cycleTab(1)
+				}
+				return m.cycleTab(-1)
+			}
+
+			switch msg.String() {
+			case "ctrl+c":
+				return m, tea.Quit
+
+			case "q":
+				// q closes current view or quits if at top level
+				if m.showDetails && !m.isSplitView {
+					m.showDetails = false
+					return m, nil
+				}
+				if m.focused == focusInsights {
 					m.focused = focusList
 					return m, nil
 				}
@@ -718,6 +1510,86 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.isMilestoneView {
+					m.isMilestoneView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isBurndownView {
+					m.isBurndownView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isFlowView {
+					m.isFlowView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isVelocityView {
+					m.isVelocityView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isWorkloadView {
+					m.isWorkloadView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isDuplicatesView {
+					m.isDuplicatesView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isProblemsView {
+					m.isProblemsView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isActivityView {
+					m.isActivityView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isCouplingView {
+					m.isCouplingView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isDSMView {
+					m.isDSMView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isRiskView {
+					m.isRiskView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isScheduleView {
+					m.isScheduleView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isAgingView {
+					m.isAgingView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isLabelsView {
+					m.isLabelsView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isLineageView {
+					m.isLineageView = false
+					m.focused = focusList
+					return m, nil
+				}
+				if m.isWorklogView {
+					m.isWorklogView = false
+					m.focused = focusList
+					return m, nil
+				}
 				// At main list - show quit confirmation
 				m.showQuitConfirm = true
 				m.focused = focusQuitConfirm
@@ -733,9 +1605,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 			case "b":
-				m.isBoardView = !m.isBoardView
-				m.isGraphView = false
-				m.isActionableView = false
+				m.toggleView(&m.isBoardView)
 				if m.isBoardView {
 					m.focused = focusBoard
 				} else {
@@ -744,9 +1614,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "g":
 				// Toggle graph view
-				m.isGraphView = !m.isGraphView
-				m.isBoardView = false
-				m.isActionableView = false
+				tab := m.captureTab()
+				m.pendingTab = &tab
+				m.toggleView(&m.isGraphView)
 				if m.isGraphView {
 					m.focused = focusGraph
 				} else {
@@ -756,12 +1626,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "a":
 				// Toggle actionable view
-				m.isActionableView = !m.isActionableView
-				m.isGraphView = false
-				m.isBoardView = false
+				m.toggleView(&m.isActionableView)
 				if m.isActionableView {
 					// Build execution plan
-					analyzer := analysis.NewAnalyzer(m.issues)
+					analyzer := analysis.NewAnalyzer(m.scopedIssues())
 					plan := analyzer.GetExecutionPlan()
 					m.actionableView = NewActionableModel(plan, m.theme)
 					m.actionableView.SetSize(m.width, m.height-2)
@@ -776,13 +1644,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 				} else {
 					m.focused = focusInsights
-					m.isGraphView = false
-					m.isBoardView = false
-					m.isActionableView = false
+					m.clearViews()
 					// Refresh insights using latest analysis snapshot
 					if m.analysis != nil {
 						ins := m.analysis.GenerateInsights(len(m.issues))
-						m.insightsPanel = NewInsightsModel(ins, m.issueMap, m.theme)
+						m.insightsPanel = NewInsightsModel(m.scopedInsights(ins), m.issueMap, m.theme)
 						panelHeight := m.height - 2
 						if panelHeight < 3 {
 							panelHeight = 3
@@ -796,12 +1662,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Toggle priority hints
 				m.showPriorityHints = !m.showPriorityHints
 				// Update delegate with new state
-				m.list.SetDelegate(IssueDelegate{
-					Theme:             m.theme,
-					ShowPriorityHints: m.showPriorityHints,
-					PriorityHints:     m.priorityHints,
-					WorkspaceMode:     m.workspaceMode,
-				})
+				m.list.SetDelegate(m.issueDelegate())
 				return m, nil
 
 			case "R":
@@ -819,6 +1680,307 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Export to Markdown file
 				m.exportToMarkdown()
 				return m, nil
+
+			case "s":
+				// Toggle sort menu overlay
+				m.showSortPicker = !m.showSortPicker
+				if m.showSortPicker {
+					m.sortPicker.SetSize(m.width, m.height-1)
+					m.sortPicker.SetCurrent(m.sortMode)
+					m.focused = focusSortPicker
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "M":
+				// Toggle milestone dashboard
+				m.toggleView(&m.isMilestoneView)
+				if m.isMilestoneView {
+					m.milestoneView = NewMilestonesModel(m.scopedIssues(), time.Now(), m.theme)
+					m.milestoneView.SetSize(m.width, m.height-2)
+					m.focused = focusMilestones
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "B":
+				// Toggle burndown chart
+				m.toggleView(&m.isBurndownView)
+				if m.isBurndownView {
+					m.burndownView = NewBurndownModel(m.scopedIssues(), time.Now(), m.theme)
+					m.burndownView.SetSize(m.width, m.height-2)
+					m.focused = focusBurndown
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "F":
+				// Toggle lead/cycle time (flow) metrics
+				m.toggleView(&m.isFlowView)
+				if m.isFlowView {
+					m.flowView = NewFlowModel(m.withStatusHistory(m.scopedIssues()), m.theme)
+					m.flowView.SetSize(m.width, m.height-2)
+					m.focused = focusFlow
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "V":
+				// Toggle throughput/velocity dashboard
+				m.toggleView(&m.isVelocityView)
+				if m.isVelocityView {
+					m.velocityView = NewVelocityModel(m.scopedIssues(), time.Now(), m.theme)
+					m.velocityView.SetSize(m.width, m.height-2)
+					m.focused = focusVelocity
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "W":
+				// Toggle assignee workload dashboard
+				m.toggleView(&m.isWorkloadView)
+				if m.isWorkloadView {
+					m.workloadView = NewWorkloadModel(m.scopedIssues(), time.Now(), m.theme)
+					m.workloadView.SetSize(m.width, m.height-2)
+					m.focused = focusWorkload
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "X":
+				// Toggle duplicate candidate review
+				m.toggleView(&m.isDuplicatesView)
+				if m.isDuplicatesView {
+					m.duplicatesView = NewDuplicatesModel(m.scopedIssues(), m.issueMap, m.dismissedDuplicates, m.theme)
+					m.duplicatesView.SetSize(m.width, m.height-2)
+					m.focused = focusDuplicates
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "P":
+				// Toggle data problems panel
+				m.toggleView(&m.isProblemsView)
+				if m.isProblemsView {
+					m.focused = focusProblems
+					return m, m.openProblemsView()
+				}
+				m.focused = focusList
+				return m, nil
+
+			case "U":
+				// Toggle recent activity feed
+				m.toggleView(&m.isActivityView)
+				if m.isActivityView {
+					m.activityView = NewActivityModel(m.scopedIssues(), time.Now(), m.theme)
+					m.activityView.SetSize(m.width, m.height-2)
+					m.focused = focusActivity
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "K":
+				// Toggle cross-epic dependency report
+				m.toggleView(&m.isCouplingView)
+				if m.isCouplingView {
+					m.couplingView = NewCouplingModel(m.scopedIssues(), m.theme)
+					m.couplingView.SetSize(m.width, m.height-2)
+					m.focused = focusCoupling
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "J":
+				// Toggle dependency structure matrix
+				m.toggleView(&m.isDSMView)
+				if m.isDSMView {
+					m.dsmView = NewDSMModel(m.scopedIssues(), m.theme)
+					m.dsmView.SetSize(m.width, m.height-2)
+					m.focused = focusDSM
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "Q":
+				// Toggle ranked risk panel
+				m.toggleView(&m.isRiskView)
+				if m.isRiskView {
+					m.riskView = NewRiskModel(m.scopedIssues(), time.Now(), m.theme)
+					m.riskView.SetSize(m.width, m.height-2)
+					m.focused = focusRisk
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+s":
+				// Toggle the projected schedule timeline
+				m.toggleView(&m.isScheduleView)
+				if m.isScheduleView {
+					m.scheduleView = NewScheduleModel(m.scopedIssues(), time.Now(), m.theme)
+					m.scheduleView.SetSize(m.width, m.height-2)
+					m.focused = focusSchedule
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+a":
+				// Toggle the aging-WIP chart
+				m.toggleView(&m.isAgingView)
+				if m.isAgingView {
+					m.agingView = NewAgingModel(m.withStatusHistory(m.scopedIssues()), time.Now(), m.theme)
+					m.agingView.SetSize(m.width, m.height-2)
+					m.focused = focusAging
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+l":
+				// Toggle label analytics
+				m.toggleView(&m.isLabelsView)
+				if m.isLabelsView {
+					m.labelsView = NewLabelAnalyticsModel(m.scopedIssues(), time.Now(), m.theme)
+					m.labelsView.SetSize(m.width, m.height-2)
+					m.focused = focusLabels
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+d":
+				// Toggle the discovered-from lineage
+				m.toggleView(&m.isLineageView)
+				if m.isLineageView {
+					m.lineageView = NewLineageModel(m.scopedIssues(), m.theme)
+					m.lineageView.SetSize(m.width, m.height-2)
+					m.focused = focusLineage
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+w":
+				// Toggle the time report
+				m.toggleView(&m.isWorklogView)
+				if m.isWorklogView {
+					var entries []worklog.Entry
+					if m.worklog != nil {
+						entries = m.worklog.Entries
+					}
+					m.worklogView = NewWorklogModel(m.scopedIssues(), entries, time.Now(), m.theme)
+					m.worklogView.SetSize(m.width, m.height-2)
+					m.focused = focusWorklog
+				} else {
+					m.focused = focusList
+				}
+				return m, nil
+
+			case "alt+m":
+				// Resolve git conflict markers in the beads file
+				if m.beadsPath == "" {
+					m.statusMsg = "Resolving merge conflicts needs a beads file"
+					m.statusIsError = true
+					return m, nil
+				}
+				if m.refuseEdit() {
+					return m, nil
+				}
+				return m, LoadMergeConflictCmd(m.beadsPath)
+
+			case "A":
+				// Toggle merging the archive file (closed issues moved out of the working set)
+				if m.beadsPath == "" {
+					m.statusMsg = "Archived issues need a beads file"
+					m.statusIsError = true
+					return m, nil
+				}
+				if !m.includeArchived {
+					path := loader.FindArchivePath(filepath.Dir(m.beadsPath))
+					if path == "" {
+						m.statusMsg = "No archive file in " + filepath.Dir(m.beadsPath) + " (archive.jsonl, closed.jsonl or *.archive.jsonl)"
+						m.statusIsError = true
+						return m, nil
+					}
+					m.statusMsg = "Loading archived issues from " + filepath.Base(path) + "…"
+				} else {
+					m.statusMsg = "Hiding archived issues…"
+				}
+				m.includeArchived = !m.includeArchived
+				m.statusIsError = false
+				m.reloading = true
+				return m, ReloadIssuesCmd(m.beadsPath, m.currentLoad())
+
+			case "I":
+				// Toggle sprint (iteration) menu; also scopes the board
+				if m.showSprintPicker {
+					m.showSprintPicker = false
+					m.focused = m.sprintPickerReturn
+					return m, nil
+				}
+				m.showSprintPicker = true
+				m.sprintPickerReturn = focusList
+				if m.isBoardView {
+					m.sprintPickerReturn = focusBoard
+				}
+				m.sprintPicker.SetIssues(m.scopedIssues())
+				m.sprintPicker.SetSize(m.width, m.height-1)
+				m.sprintPicker.SetActive(strings.TrimPrefix(m.currentFilter, sprintFilterPrefix))
+				m.focused = focusSprintPicker
+				return m, nil
+
+			case "<", ">":
+				// Resize the left pane of the graph or split view
+				if m.isGraphView || (m.isSplitView && (m.focused == focusList || m.focused == focusDetail)) {
+					delta := 1
+					if msg.String() == "<" {
+						delta = -1
+					}
+					m.resizePane(delta)
+					return m, nil
+				}
+
+			case "ctrl+n":
+				// Open a tab starting as a copy of this one
+				m.newTab()
+				return m, nil
+
+			case "ctrl+w":
+				return m.closeTab()
+
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				return m.switchTab(int(msg.String()[0] - '1'))
+
+			case "S":
+				// Reverse the current sort direction
+				if !m.sortMode.IsDefault() {
+					m.sortMode.Descending = !m.sortMode.Descending
+					m.rebuildListWithDiffInfo()
+				}
+				return m, nil
+
+			case "ctrl+f":
+				m.cycleSubgraphFocus()
+				return m.reopenScopedView()
+
+			case "alt+e":
+				m.toggleEpicScope()
+				return m.reopenScopedView()
+
+			case "@":
+				m.toggleMine()
+				return m.reopenScopedView()
 			}
 
 			// Focus-specific key handling
@@ -826,6 +1988,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case focusRecipePicker:
 				m = m.handleRecipePickerKeys(msg)
 
+			case focusSortPicker:
+				m = m.handleSortPickerKeys(msg)
+
+			case focusLabelPicker:
+				m = m.handleLabelPickerKeys(msg)
+
+			case focusSprintPicker:
+				m = m.handleSprintPickerKeys(msg)
+
 			case focusInsights:
 				m = m.handleInsightsKeys(msg)
 
@@ -838,12 +2009,70 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case focusActionable:
 				m = m.handleActionableKeys(msg)
 
+			case focusMilestones:
+				m = m.handleMilestoneKeys(msg)
+
+			case focusBurndown:
+				m = m.handleBurndownKeys(msg)
+
+			case focusFlow:
+				m = m.handleFlowKeys(msg)
+
+			case focusVelocity:
+				m = m.handleVelocityKeys(msg)
+
+			case focusWorkload:
+				m = m.handleWorkloadKeys(msg)
+
+			case focusDuplicates:
+				m, cmd = m.handleDuplicateKeys(msg)
+				cmds = append(cmds, cmd)
+
+			case focusProblems:
+				m, cmd = m.handleProblemKeys(msg)
+				cmds = append(cmds, cmd)
+
+			case focusActivity:
+				m = m.handleActivityKeys(msg)
+
+			case focusCoupling:
+				m = m.handleCouplingKeys(msg)
+
+			case focusDSM:
+				m = m.handleDSMKeys(msg)
+
+			case focusRisk:
+				m = m.handleRiskKeys(msg)
+			case focusSchedule:
+				m = m.handleScheduleKeys(msg)
+			case focusAging:
+				m = m.handleAgingKeys(msg)
+			case focusLabels:
+				m = m.handleLabelsKeys(msg)
+			case focusLineage:
+				m = m.handleLineageKeys(msg)
+			case focusWorklog:
+				m = m.handleWorklogKeys(msg)
+
 			case focusList:
-				m = m.handleListKeys(msg)
+				m, cmd = m.handleListKeys(msg)
+				cmds = append(cmds, cmd)
 
 			case focusDetail:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
+				if msg.String() == "o" {
+					m.openIssueInBrowser()
+				} else if msg.String() == "v" {
+					cmds = append(cmds, m.openAttachmentPreview())
+				} else if msg.String() == "m" {
+					cmds = append(cmds, m.openCommentComposer())
+				} else if msg.String() == "alt+t" {
+					m.toggleTimer()
+				} else if msg.String() == "alt+f" {
+					cmds = append(cmds, m.startFocusMode())
+				} else if !m.handleCommitKey(msg.String()) {
+					m.viewport, cmd = m.viewport.Update(msg)
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 
@@ -854,6 +2083,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Scroll up based on current focus
 			switch m.focused {
 			case focusList:
+				if m.showDetails && !m.isSplitView {
+					m.viewport.ScrollUp(3)
+					break
+				}
 				if m.list.Index() > 0 {
 					m.list.Select(m.list.Index() - 1)
 					// Sync detail panel in split view mode
@@ -871,12 +2104,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.graphView.PageUp()
 			case focusActionable:
 				m.actionableView.MoveUp()
+			case focusMilestones:
+				m.milestoneView.MoveUp()
+			case focusWorkload:
+				m.workloadView.MoveUp()
+			case focusDuplicates:
+				m.duplicatesView.MoveUp()
+			case focusProblems:
+				m.problemsView.MoveUp()
+			case focusActivity:
+				m.activityView.MoveUp()
+			case focusCoupling:
+				m.couplingView.MoveUp()
+			case focusDSM:
+				m.dsmView.MoveUp()
+			case focusRisk:
+				m.riskView.MoveUp()
+			case focusSchedule:
+				m.scheduleView.MoveUp()
+			case focusAging:
+				m.agingView.MoveUp()
+			case focusLabels:
+				m.labelsView.MoveUp()
+			case focusLineage:
+				m.lineageView.MoveUp()
+			case focusWorklog:
+				m.worklogView.MoveUp()
 			}
 			return m, nil
 		case tea.MouseButtonWheelDown:
 			// Scroll down based on current focus
 			switch m.focused {
 			case focusList:
+				if m.showDetails && !m.isSplitView {
+					m.viewport.ScrollDown(3)
+					break
+				}
 				if m.list.Index() < len(m.list.Items())-1 {
 					m.list.Select(m.list.Index() + 1)
 					// Sync detail panel in split view mode
@@ -894,6 +2157,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.graphView.PageDown()
 			case focusActionable:
 				m.actionableView.MoveDown()
+			case focusMilestones:
+				m.milestoneView.MoveDown()
+			case focusWorkload:
+				m.workloadView.MoveDown()
+			case focusDuplicates:
+				m.duplicatesView.MoveDown()
+			case focusProblems:
+				m.problemsView.MoveDown()
+			case focusActivity:
+				m.activityView.MoveDown()
+			case focusCoupling:
+				m.couplingView.MoveDown()
+			case focusDSM:
+				m.dsmView.MoveDown()
+			case focusRisk:
+				m.riskView.MoveDown()
+			case focusSchedule:
+				m.scheduleView.MoveDown()
+			case focusAging:
+				m.agingView.MoveDown()
+			case focusLabels:
+				m.labelsView.MoveDown()
+			case focusLineage:
+				m.lineageView.MoveDown()
+			case focusWorklog:
+				m.worklogView.MoveDown()
 			}
 			return m, nil
 		}
@@ -903,37 +2192,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		m.isSplitView = msg.Width > SplitViewThreshold
 		m.ready = true
-		bodyHeight := m.height - 1 // keep 1 row for footer
-		if bodyHeight < 5 {
-			bodyHeight = 5
-		}
+		bodyHeight := m.bodyHeight() // keep 1 row for footer
 
 		if m.isSplitView {
-			// Calculate dimensions accounting for 2 panels with borders(2)+padding(2) = 4 overhead each
-			// Total overhead = 8
-			availWidth := msg.Width - 8
-			if availWidth < 10 {
-				availWidth = 10
-			}
-
-			listInnerWidth := int(float64(availWidth) * 0.4)
-			detailInnerWidth := availWidth - listInnerWidth
-
-			// listHeight fits header (1) + page line (1) inside a panel with Border (2)
-			listHeight := bodyHeight - 4
-			if listHeight < 3 {
-				listHeight = 3
-			}
-
-			m.list.SetSize(listInnerWidth, listHeight)
-			m.viewport = viewport.New(detailInnerWidth, bodyHeight-2) // Account for border
-
-			if r, err := glamour.NewTermRenderer(
-				glamour.WithAutoStyle(),
-				glamour.WithWordWrap(detailInnerWidth),
-			); err == nil {
-				m.renderer = r
-			}
+			m.viewport = viewport.New(0, 0)
+			m.sizeSplitPanes()
 		} else {
 			listHeight := bodyHeight - 2
 			if listHeight < 3 {
@@ -943,23 +2206,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport = viewport.New(msg.Width, bodyHeight-1)
 
 			// Update renderer for full width
-			if r, err := glamour.NewTermRenderer(
-				glamour.WithAutoStyle(),
-				glamour.WithWordWrap(msg.Width),
-			); err == nil {
+			if r, err := newMarkdownRenderer(m.theme.Renderer, msg.Width); err == nil {
 				m.renderer = r
 			}
 		}
 
-		m.list.SetDelegate(IssueDelegate{
-			Theme:             m.theme,
-			ShowPriorityHints: m.showPriorityHints,
-			PriorityHints:     m.priorityHints,
-			WorkspaceMode:     m.workspaceMode,
-		})
+		m.list.SetDelegate(m.issueDelegate())
 
 		m.insightsPanel.SetSize(m.width, bodyHeight)
+		m.pluginPager.Width, m.pluginPager.Height = msg.Width, msg.Height-3
 		m.updateViewportContent()
+		if m.showAttachmentPreview {
+			// Redraw the image at the new size
+			cmds = append(cmds, m.loadAttachmentPreview())
+		}
 	}
 
 	// Update list for filtering input, but NOT for WindowSizeMsg
@@ -996,6 +2256,15 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 		m.board.PageDown(m.height / 3)
 	case "ctrl+u":
 		m.board.PageUp(m.height / 3)
+	case "Z":
+		// Cycle swimlanes: none → assignee → epic
+		m.board.SetSwimlanes(NextSwimlanes(m.board.Swimlanes()), m.issueMap)
+		if m.board.Swimlanes() == GroupNone {
+			m.statusMsg = "Swimlanes off"
+		} else {
+			m.statusMsg = "Swimlanes by " + m.board.Swimlanes().String()
+		}
+		m.statusIsError = false
 	case "enter":
 		if selected := m.board.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -1037,28 +2306,534 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "[":
+		m.graphView.NextNeighbor(-1)
+	case "]":
+		m.graphView.NextNeighbor(1)
+	case "f":
+		if !m.graphView.FollowNeighbor() {
+			m.statusMsg = "No blockers or dependents to follow"
+			m.statusIsError = true
+		}
+	case "backspace":
+		if !m.graphView.Back() {
+			m.statusMsg = "Already at the start of the trail"
+			m.statusIsError = true
+		}
+	case "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7", "alt+8", "alt+9":
+		n := int(msg.String()[len("alt+")] - '0')
+		if !m.graphView.JumpToCrumb(n) {
+			m.statusMsg = fmt.Sprintf("No crumb %d in the trail", n)
+			m.statusIsError = true
+		}
+	case "m":
+		m.graphView.CycleSortMetric()
+		m.statusMsg = "Graph sorted by " + m.graphView.SortMetric().String()
+		m.statusIsError = false
+	case "e":
+		m.graphView.NextEdgeType()
+	case "x":
+		edge, on := m.graphView.ToggleEdgeType()
+		if on {
+			m.statusMsg = fmt.Sprintf("Graph follows %s edges", edge)
+		} else {
+			m.statusMsg = fmt.Sprintf("Graph ignores %s edges", edge)
+		}
+		m.statusIsError = false
+	case "c":
+		if clusters := m.graphView.ToggleClusters(); clusters != nil {
+			m.statusMsg = fmt.Sprintf("Coloring %d cluster(s) of linked work (modularity %.2f)", len(clusters.Clusters), clusters.Modularity)
+		} else {
+			m.statusMsg = "Coloring nodes by status"
+		}
+		m.statusIsError = false
+	case "r":
+		if hidden, n := m.graphView.ToggleRedundant(); hidden {
+			m.statusMsg = fmt.Sprintf("Hiding %d redundant blocking edges (transitive reduction)", n)
+		} else {
+			m.statusMsg = "Showing every blocking edge"
+		}
+		m.statusIsError = false
+	case "enter":
+		// With a blocker or dependent picked, re-center on it instead
+		if m.graphView.NeighborPicked() {
+			m.graphView.FollowNeighbor()
+			return m
+		}
+		if selected := m.graphView.SelectedIssue(); selected != nil {
+			// Find and select in list
+			for i, item := range m.list.Items() {
+				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selected.ID {
+					m.list.Select(i)
+					break
+				}
+			}
+			m.isGraphView = false
+			m.focused = focusList
+			if m.isSplitView {
+				m.focused = focusDetail
+			} else {
+				m.showDetails = true
+			}
+			m.updateViewportContent()
+		}
+	}
+	return m
+}
+
+// handleMilestoneKeys handles keyboard input when the milestone dashboard is focused
+func (m Model) handleMilestoneKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.milestoneView.MoveDown()
+	case "k", "up":
+		m.milestoneView.MoveUp()
+	case "enter":
+		// Scope the list to the selected milestone
+		if name := m.milestoneView.SelectedMilestone(); name != "" {
+			m.activeRecipe = nil
+			m.currentFilter = milestoneFilterPrefix + name
+			m.applyFilter()
+			m.isMilestoneView = false
+			m.focused = focusList
+			m.statusMsg = "Scoped to milestone " + name
+			m.statusIsError = false
+		}
+	}
+	return m
+}
+
+// handleBurndownKeys handles keyboard input when the burndown chart is focused
+func (m Model) handleBurndownKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "w":
+		m.burndownView.ToggleGranularity()
+	case "u":
+		m.burndownView.TogglePoints()
+	case "m":
+		m.burndownView.ToggleBurnup()
+	}
+	return m
+}
+
+// openProblemsView rebuilds the data problems panel and starts looking for
+// the targets of dangling dependencies in nearby beads files
+func (m *Model) openProblemsView() tea.Cmd {
+	m.problemsView = NewProblemsModel(m.dataProblems, dominantPrefix(m.issues), m.theme)
+	m.problemsView.SetCollisions(m.collisions)
+	m.problemsView.SetSize(m.width, m.height-2)
+	ids := m.problemsView.MissingIDs()
+	if len(ids) == 0 || m.beadsPath == "" {
+		return nil
+	}
+	m.problemsView.StartLocating()
+	return LocateDanglingCmd(m.beadsPath, ids)
+}
+
+// handleProblemKeys handles keyboard input when the data problems panel is focused
+func (m Model) handleProblemKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.problemsView.MoveDown()
+	case "k", "up":
+		m.problemsView.MoveUp()
+	case "d":
+		// Drop a dangling dependency edge via bd
+		if p, ok := m.problemsView.SelectedProblem(); ok && p.Kind == analysis.ProblemDanglingDep {
+			if m.refuseEdit() {
+				return m, nil
+			}
+			m.statusMsg = fmt.Sprintf("Removing dependency %s → %s…", p.IssueID, p.DependsOn)
+			m.statusIsError = false
+			return m, RemoveDependencyCmd(m.beadsPath, p.IssueID, p.DependsOn)
+		}
+	case "l":
+		// Merge the file holding the missing issue into the loaded set
+		if path, ok := m.problemsView.LoadablePath(); ok && !slices.Contains(m.extraPaths, path) {
+			m.extraPaths = append(m.extraPaths, path)
+			m.statusMsg = fmt.Sprintf("Loading issues from %s…", path)
+			m.statusIsError = false
+			m.reloading = true
+			return m, ReloadIssuesCmd(m.beadsPath, m.currentLoad())
+		}
+	case "u", "n":
+		// Choose which of a duplicate ID's records to use: the next one, or
+		// back to the one updated last
+		c, ok := m.problemsView.SelectedCollision()
+		if !ok {
+			break
+		}
+		index := -1
+		if msg.String() == "u" {
+			index = (c.Kept + 1) % len(c.Records)
+		}
+		return m, m.pinCollisionRecord(c.ID, index)
+	case "enter":
+		p, ok := m.problemsView.SelectedProblem()
+		if !ok {
+			break
+		}
+		if p.IssueID == "" {
+			m.statusMsg = fmt.Sprintf("Line %d was skipped at load time; fix it in %s", p.Line, filepath.Base(m.beadsPath))
+			m.statusIsError = true
+			break
+		}
+		if m.revealIssue(p.IssueID) {
+			m.isProblemsView = false
+		}
+	}
+	return m, nil
+}
+
+// revealIssue selects the issue in the list and shows its details, clearing
+// whatever recipe or filter hides it. It reports false, with a status
+// message, when the issue is not loaded.
+func (m *Model) revealIssue(id string) bool {
+	if !m.selectIssueInList(id) {
+		// Hidden by a recipe, filter or collapsed group: show everything
+		m.activeRecipe = nil
+		m.currentFilter = "all"
+		m.collapsedGroups = make(map[string]bool)
+		if m.list.FilterState() != list.Unfiltered {
+			m.list.ResetFilter()
+		}
+		m.applyFilter()
+		if !m.selectIssueInList(id) {
+			m.statusMsg = fmt.Sprintf("%s is not in the loaded issues", id)
+			m.statusIsError = true
+			return false
+		}
+		m.statusMsg = fmt.Sprintf("Cleared filters to show %s", id)
+		m.statusIsError = false
+	}
+	m.focused = focusList
+	if m.isSplitView {
+		m.focused = focusDetail
+	} else {
+		m.showDetails = true
+	}
+	m.updateViewportContent()
+	return true
+}
+
+// selectIssueInList selects the issue in the list, reporting whether it is listed
+func (m *Model) selectIssueInList(id string) bool {
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+			m.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// handleFlowKeys handles keyboard input when the flow metrics view is focused
+func (m Model) handleFlowKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "c":
+		m.flowView.ToggleMetric()
+	case "d":
+		m.flowView.CycleDimension()
+	}
+	return m
+}
+
+// handleVelocityKeys handles keyboard input when the velocity dashboard is focused
+func (m Model) handleVelocityKeys(msg tea.KeyMsg) Model {
+	if msg.String() == "w" {
+		m.velocityView.CycleWindow()
+	}
+	return m
+}
+
+// handleDuplicateKeys handles keyboard input when the duplicate review panel is focused
+func (m Model) handleDuplicateKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.duplicatesView.MoveDown()
+	case "k", "up":
+		m.duplicatesView.MoveUp()
+	case "r":
+		m.duplicatesView.Swap()
+	case "n":
+		m.duplicatesView.Dismiss()
+	case "=":
+		// Compare the pair side by side before deciding
+		if pair, ok := m.duplicatesView.SelectedPair(); ok {
+			keep, okKeep := m.issueMap[pair.Keep]
+			dup, okDup := m.issueMap[pair.Duplicate]
+			if okKeep && okDup {
+				m.openComparison(*keep, *dup)
+			}
+		}
+	case "x":
+		// Close the duplicate and link it to the kept issue via bd
+		if pair, ok := m.duplicatesView.SelectedPair(); ok {
+			if m.refuseEdit() {
+				return m, nil
+			}
+			m.statusMsg = fmt.Sprintf("Closing %s as duplicate of %s…", pair.Duplicate, pair.Keep)
+			m.statusIsError = false
+			return m, DedupCmd(m.beadsPath, pair)
+		}
+	}
+	return m, nil
+}
+
+// handleActivityKeys handles keyboard input when the activity feed is focused
+func (m Model) handleActivityKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.activityView.MoveDown()
+	case "k", "up":
+		m.activityView.MoveUp()
+	case "f":
+		m.activityView.CycleActor()
+		m.statusMsg = "Showing everyone's activity"
+		if actor := m.activityView.Actor(); actor != "" {
+			m.statusMsg = "Showing activity by @" + actor
+		}
+		m.statusIsError = false
+	case "enter":
+		if id := m.activityView.SelectedIssueID(); id != "" && m.revealIssue(id) {
+			m.isActivityView = false
+		}
+	}
+	return m
+}
+
+// handleCouplingKeys handles keyboard input when the cross-epic report is focused
+func (m Model) handleCouplingKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.couplingView.MoveDown()
+	case "k", "up":
+		m.couplingView.MoveUp()
+	case "enter":
+		if c := m.couplingView.Selected(); c != nil && m.revealIssue(c.IssueID) {
+			m.isCouplingView = false
+		}
+	case "o":
+		if c := m.couplingView.Selected(); c != nil && m.revealIssue(c.DependsOnID) {
+			m.isCouplingView = false
+		}
+	}
+	return m
+}
+
+// handleRiskKeys handles keyboard input when the risk panel is focused
+func (m Model) handleRiskKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.riskView.MoveDown()
+	case "k", "up":
+		m.riskView.MoveUp()
+	case "e":
+		if m.riskView.ToggleEpics() {
+			m.statusMsg = "Risk: epics only"
+		} else {
+			m.statusMsg = "Risk: all open issues and epics"
+		}
+		m.statusIsError = false
+	case "enter":
+		if r := m.riskView.Selected(); r != nil && m.revealIssue(r.ID) {
+			m.isRiskView = false
+		}
+	}
+	return m
+}
+
+// handleScheduleKeys handles keyboard input when the schedule timeline is focused
+func (m Model) handleScheduleKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.scheduleView.MoveDown()
+	case "k", "up":
+		m.scheduleView.MoveUp()
+	case "a":
+		if m.scheduleView.ToggleByAssignee() {
+			m.statusMsg = "Schedule: by assignee"
+		} else {
+			m.statusMsg = "Schedule: by start date"
+		}
+		m.statusIsError = false
+	case "enter":
+		if item := m.scheduleView.Selected(); item != nil && m.revealIssue(item.ID) {
+			m.isScheduleView = false
+		}
+	}
+	return m
+}
+
+// handleAgingKeys handles keyboard input when the aging-WIP chart is focused
+func (m Model) handleAgingKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.agingView.MoveDown()
+	case "k", "up":
+		m.agingView.MoveUp()
+	case "enter":
+		if item := m.agingView.Selected(); item != nil && m.revealIssue(item.ID) {
+			m.isAgingView = false
+		}
+	}
+	return m
+}
+
+// handleLabelsKeys handles keyboard input when label analytics is focused
+func (m Model) handleLabelsKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.labelsView.MoveDown()
+	case "k", "up":
+		m.labelsView.MoveUp()
+	case "m":
+		m.statusMsg = "Labels sorted by " + m.labelsView.CycleSort()
+		m.statusIsError = false
+	case "enter":
+		// Filter the list by the selected label
+		label := m.labelsView.SelectedLabel()
+		if label == "" {
+			break
+		}
+		m.activeRecipe = nil
+		m.currentFilter = labelFilterPrefix + label
+		m.applyFilter()
+		m.isLabelsView = false
+		m.focused = focusList
+		m.statusMsg = "Filtered by label " + label
+		m.statusIsError = false
+	}
+	return m
+}
+
+// handleLineageKeys handles keyboard input when the lineage view is focused
+func (m Model) handleLineageKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.lineageView.MoveDown()
+	case "k", "up":
+		m.lineageView.MoveUp()
+	case "enter":
+		if node := m.lineageView.Selected(); node != nil && m.revealIssue(node.ID) {
+			m.isLineageView = false
+		}
+	}
+	return m
+}
+
+// handleWorklogKeys handles keyboard input when the time report is focused
+func (m Model) handleWorklogKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.worklogView.MoveDown()
+	case "k", "up":
+		m.worklogView.MoveUp()
+	case "enter":
+		if id := m.worklogView.SelectedIssue(); id != "" && m.revealIssue(id) {
+			m.isWorklogView = false
+		}
+	}
+	return m
+}
+
+// handleDSMKeys handles keyboard input when the dependency matrix is focused
+func (m Model) handleDSMKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.dsmView.MoveDown()
+	case "k", "up":
+		m.dsmView.MoveUp()
+	case "h", "left":
+		m.dsmView.MoveLeft()
+	case "l", "right":
+		m.dsmView.MoveRight()
+	case "n", "N":
+		delta := 1
+		if msg.String() == "N" {
+			delta = -1
+		}
+		if !m.dsmView.JumpToMark(delta) {
+			m.statusMsg = "No dependencies in the matrix"
+			m.statusIsError = true
+		}
+	case "e":
+		m.statusMsg = "Showing open issues"
+		if m.dsmView.ToggleEpics() {
+			m.statusMsg = "Showing epics: counts of issues waiting across epics"
+		}
+		m.statusIsError = false
+	case "enter", "o":
+		row, col := m.dsmView.Selected()
+		id := row
+		if msg.String() == "o" {
+			id = col
+		}
+		if id != "" && m.revealIssue(id) {
+			m.isDSMView = false
+		}
+	}
+	return m
+}
+
+// handleWorkloadKeys handles keyboard input when the workload dashboard is focused
+func (m Model) handleWorkloadKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.workloadView.MoveDown()
+	case "k", "up":
+		m.workloadView.MoveUp()
+	case "t":
+		m.statusIsError = false
+		switch {
+		case m.workloadView.ToggleTeams():
+			m.statusMsg = "Showing teams"
+		case len(analysis.CurrentTeams()) == 0:
+			m.statusMsg = "No teams defined: add a [teams] section to the config"
+			m.statusIsError = true
+		default:
+			m.statusMsg = "Showing assignees"
+		}
 	case "enter":
-		if selected := m.graphView.SelectedIssue(); selected != nil {
-			// Find and select in list
-			for i, item := range m.list.Items() {
-				if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == selected.ID {
-					m.list.Select(i)
-					break
-				}
+		// Scope the list to the selected team's issues
+		if team, ok := m.workloadView.SelectedTeam(); ok {
+			m.activeRecipe = nil
+			m.currentFilter = teamFilterPrefix + team
+			m.applyFilter()
+			m.isWorkloadView = false
+			m.focused = focusList
+			m.statusMsg = "Showing issues of team " + team
+			if team == "" {
+				m.statusMsg = "Showing issues outside any team"
 			}
-			m.isGraphView = false
+			m.statusIsError = false
+			break
+		}
+		// Scope the list to the selected assignee's issues
+		if assignee, ok := m.workloadView.SelectedAssignee(); ok {
+			m.activeRecipe = nil
+			m.currentFilter = assigneeFilterPrefix + assignee
+			m.applyFilter()
+			m.isWorkloadView = false
 			m.focused = focusList
-			if m.isSplitView {
-				m.focused = focusDetail
-			} else {
-				m.showDetails = true
+			m.statusMsg = "Showing issues assigned to @" + assignee
+			if assignee == "" {
+				m.statusMsg = "Showing unassigned issues"
 			}
-			m.updateViewportContent()
+			m.statusIsError = false
 		}
 	}
 	return m
 }
 
+// assigneeBadgeText formats an assignee for the filter badge
+func assigneeBadgeText(assignee string) string {
+	if assignee == "" {
+		return "UNASSIGNED"
+	}
+	return "@" + assignee
+}
+
 // handleActionableKeys handles keyboard input when actionable view is focused
 func (m Model) handleActionableKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -1111,6 +2886,253 @@ func (m Model) handleRecipePickerKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// handleSortPickerKeys handles keyboard input when the sort menu is focused
+func (m Model) handleSortPickerKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.sortPicker.MoveDown()
+	case "k", "up":
+		m.sortPicker.MoveUp()
+	case "esc":
+		m.showSortPicker = false
+		m.focused = focusList
+	case "enter":
+		m.sortMode = m.sortPicker.Choose()
+		m.showSortPicker = false
+		m.focused = focusList
+		m.rebuildListWithDiffInfo()
+		if label := m.sortMode.Label(); label != "" {
+			m.statusMsg = "Sorted by " + label
+		} else {
+			m.statusMsg = "Default sort restored"
+		}
+		m.statusIsError = false
+	}
+	return m
+}
+
+// handleLabelPickerKeys handles keyboard input when the label menu is focused
+func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.labelPicker.MoveDown()
+	case "k", "up":
+		m.labelPicker.MoveUp()
+	case "esc":
+		m.showLabelPicker = false
+		m.focused = focusList
+	case "enter":
+		m.showLabelPicker = false
+		m.focused = focusList
+		// A label filter replaces any active recipe
+		m.activeRecipe = nil
+		if label := m.labelPicker.SelectedLabel(); label != "" {
+			m.currentFilter = labelFilterPrefix + label
+			m.statusMsg = "Filtered by label " + label
+		} else {
+			m.currentFilter = "all"
+			m.statusMsg = "Label filter cleared"
+		}
+		m.statusIsError = false
+		m.applyFilter()
+	}
+	return m
+}
+
+// handleAssigneePickerKeys handles keyboard input when the assignee menu is
+// focused: letters search, so only arrows move
+func (m Model) handleAssigneePickerKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showAssigneePicker = false
+		m.focused = focusList
+	case "enter":
+		row, ok := m.assigneePicker.Selected()
+		if !ok {
+			m.statusMsg = "No assignee matches " + m.assigneePicker.Query()
+			m.statusIsError = true
+			return m, nil
+		}
+		m.showAssigneePicker = false
+		m.focused = focusList
+		m.statusIsError = false
+		if m.assigneePicker.Assigning() {
+			id := m.assigneePicker.IssueID()
+			m.statusMsg = fmt.Sprintf("Assigning %s to @%s…", id, row.Name)
+			if row.Name == "" {
+				m.statusMsg = fmt.Sprintf("Unassigning %s…", id)
+			}
+			base := model.Issue{ID: id}
+			if issue, ok := m.issueMap[id]; ok {
+				base = *issue
+			}
+			return m, AssignCmd(m.beadsPath, base, row.Name)
+		}
+		// An assignee filter replaces any active recipe
+		m.activeRecipe = nil
+		m.currentFilter = row.filter()
+		switch {
+		case row.All:
+			m.statusMsg = "Assignee filter cleared"
+		case row.Team:
+			m.statusMsg = "Showing issues of team " + row.Name
+		case row.Name == "":
+			m.statusMsg = "Showing unassigned issues"
+		default:
+			m.statusMsg = "Showing issues assigned to @" + row.Name
+		}
+		m.applyFilter()
+	default:
+		m.assigneePicker.Update(msg)
+	}
+	return m, nil
+}
+
+// openAssigneePicker opens the assignee menu, to filter the list or, when
+// assign is set, to assign the selected issue
+func (m *Model) openAssigneePicker(assign bool) {
+	var issue model.Issue
+	if assign {
+		var ok bool
+		if issue, ok = m.selectedIssue(); !ok || m.refuseEdit() {
+			return
+		}
+	}
+	m.assigneePicker.SetIssues(m.scopedIssues())
+	m.assigneePicker.SetSize(m.width, m.height-1)
+	if assign {
+		m.assigneePicker.OpenAssign(issue)
+	} else {
+		m.assigneePicker.OpenFilter(m.currentFilter)
+	}
+	m.showAssigneePicker = true
+	m.focused = focusAssigneePicker
+}
+
+// openCreateForm opens the new-issue form
+func (m *Model) openCreateForm() {
+	if m.refuseEdit() {
+		return
+	}
+	m.createForm.SetSize(m.width, m.height-1)
+	m.createForm.Open()
+	m.showCreateForm = true
+}
+
+// handleCreateFormKeys handles keyboard input when the new-issue form is
+// open: letters go to the fields, so only arrows and tab move between them
+func (m Model) handleCreateFormKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	f := &m.createForm
+	if f.Choosing() {
+		switch msg.String() {
+		case "esc":
+			m.showCreateForm = false
+		case "j", "down":
+			f.MoveDown()
+		case "k", "up":
+			f.MoveUp()
+		case "enter":
+			f.Choose()
+		}
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc":
+		if !f.Back() {
+			m.showCreateForm = false
+		}
+	case "tab", "down":
+		f.NextField()
+	case "shift+tab", "up":
+		f.PrevField()
+	case "enter":
+		draft, err := f.Draft()
+		if err != nil {
+			f.SetError(err)
+			return m, nil
+		}
+		m.showCreateForm = false
+		m.statusMsg = fmt.Sprintf("Creating %q…", draft.Title)
+		m.statusIsError = false
+		return m, CreateIssueCmd(m.beadsPath, draft)
+	default:
+		return m, f.Update(msg)
+	}
+	return m, nil
+}
+
+// openCommentComposer opens the composer for a comment on the selected issue
+func (m *Model) openCommentComposer() tea.Cmd {
+	issue, ok := m.selectedIssue()
+	if !ok || m.refuseEdit() {
+		return nil
+	}
+	m.commentComposer.SetSize(m.width, m.height-1)
+	m.showCommentComposer = true
+	return m.commentComposer.Open(issue, m.notifyAssignee)
+}
+
+// handleCommentComposerKeys handles keyboard input when the comment composer
+// is open: enter starts a new line, so ctrl+s saves
+func (m Model) handleCommentComposerKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	c := &m.commentComposer
+	switch msg.String() {
+	case "esc":
+		m.showCommentComposer = false
+	case "ctrl+e":
+		return m, editCommentCmd(c.Text())
+	case "ctrl+s":
+		comment, err := c.Comment()
+		if err != nil {
+			c.SetError(err)
+			return m, nil
+		}
+		m.showCommentComposer = false
+		m.patchIssue(comment.IssueID, func(issue *model.Issue) {
+			issue.Comments = append(slices.Clone(issue.Comments), comment)
+		})
+		m.statusMsg = fmt.Sprintf("Commenting on %s…", comment.IssueID)
+		m.statusIsError = false
+		return m, AddCommentCmd(m.beadsPath, comment)
+	default:
+		return m, c.Update(msg)
+	}
+	return m, nil
+}
+
+// SetIssueTemplates sets the templates the new-issue form offers
+func (m *Model) SetIssueTemplates(templates []IssueTemplate) {
+	m.createForm.templates = templates
+}
+
+// handleSprintPickerKeys handles keyboard input when the sprint menu is focused
+func (m Model) handleSprintPickerKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.sprintPicker.MoveDown()
+	case "k", "up":
+		m.sprintPicker.MoveUp()
+	case "esc":
+		m.showSprintPicker = false
+		m.focused = m.sprintPickerReturn
+	case "enter":
+		m.showSprintPicker = false
+		m.focused = m.sprintPickerReturn
+		// A sprint scope replaces any active recipe
+		m.activeRecipe = nil
+		if name := m.sprintPicker.SelectedSprint(); name != "" {
+			m.currentFilter = sprintFilterPrefix + name
+			m.statusMsg = "Scoped to sprint " + name
+		} else {
+			m.currentFilter = "all"
+			m.statusMsg = "Sprint scope cleared"
+		}
+		m.statusIsError = false
+		m.applyFilter()
+	}
+	return m
+}
+
 // handleInsightsKeys handles keyboard input when insights panel is focused
 func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -1153,13 +3175,64 @@ func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 }
 
 // handleListKeys handles keyboard input when the list is focused
-func (m Model) handleListKeys(msg tea.KeyMsg) Model {
+func (m Model) handleListKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
+		if m.toggleSelectedGroup() {
+			break
+		}
 		if !m.isSplitView {
 			m.showDetails = true
 			m.updateViewportContent()
 		}
+	case " ":
+		// Quick look at the issue without leaving the list
+		m.openQuickLook()
+	case "=":
+		// Mark an issue, then compare it side by side with another
+		m.markForComparison()
+	case "z":
+		// Collapse/expand the section under the cursor
+		m.toggleSelectedGroup()
+	case "Z":
+		// Cycle grouping: none → status → assignee → epic → label → cluster
+		m.cycleGroupBy()
+	case "L":
+		// Open label filter menu (graph view uses L for scrolling, so list only)
+		m.showLabelPicker = true
+		m.labelPicker.SetIssues(m.scopedIssues())
+		m.labelPicker.SetSize(m.width, m.height-1)
+		m.labelPicker.SetActive(strings.TrimPrefix(m.currentFilter, labelFilterPrefix))
+		m.focused = focusLabelPicker
+	case "u":
+		// Filter by assignee, searching the assignees as you type
+		m.openAssigneePicker(false)
+	case "w":
+		// Assign the selected issue, picking or typing a name
+		m.openAssigneePicker(true)
+	case "n":
+		// Create an issue, starting from a template
+		m.openCreateForm()
+	case "m":
+		// Comment on the selected issue
+		return m, m.openCommentComposer()
+	case "alt+t":
+		// Start or stop the timer on the selected issue
+		m.toggleTimer()
+	case "alt+f":
+		// Focus mode: a pomodoro on the selected issue, or the first ready one
+		return m, m.startFocusMode()
+	case "d":
+		// Cycle density: compact → comfortable → spacious
+		m.density = m.density.Next()
+		m.list.SetDelegate(m.issueDelegate())
+		m.statusMsg = "Density: " + m.density.String()
+		m.statusIsError = false
+	case "x":
+		// Cycle rows: single lines → two-line cards → three-line cards
+		m.SetCardLines(m.cardLines%3 + 1)
+		m.statusMsg = "Rows: " + cardLineNames[m.cardLines]
+		m.statusIsError = false
 	case "home":
 		m.list.Select(0)
 	case "G", "end":
@@ -1171,7 +3244,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		itemCount := len(m.list.Items())
 		if itemCount > 0 {
 			currentIdx := m.list.Index()
-			newIdx := currentIdx + m.height/3
+			newIdx := currentIdx + max(m.height/3/m.rowHeight(), 1)
 			if newIdx >= itemCount {
 				newIdx = itemCount - 1
 			}
@@ -1181,15 +3254,25 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		// Page up
 		if len(m.list.Items()) > 0 {
 			currentIdx := m.list.Index()
-			newIdx := currentIdx - m.height/3
+			newIdx := currentIdx - max(m.height/3/m.rowHeight(), 1)
 			if newIdx < 0 {
 				newIdx = 0
 			}
 			m.list.Select(newIdx)
 		}
 	case "o":
+		// Open the issue in the browser from its details, otherwise filter to open issues
+		if m.showDetails && !m.isSplitView {
+			m.openIssueInBrowser()
+			break
+		}
 		m.currentFilter = "open"
 		m.applyFilter()
+	case "v":
+		// Preview the attachments, from the details
+		if m.showDetails && !m.isSplitView {
+			return m, m.openAttachmentPreview()
+		}
 	case "c":
 		m.currentFilter = "closed"
 		m.applyFilter()
@@ -1199,6 +3282,25 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "a":
 		m.currentFilter = "all"
 		m.applyFilter()
+	case "D":
+		// Due within the next 7 days
+		m.currentFilter = "due"
+		m.applyFilter()
+	case "!":
+		m.currentFilter = "overdue"
+		m.applyFilter()
+	case "0":
+		// Zero slack: any slip delays an epic or the issues waiting on it
+		m.currentFilter = "zero_slack"
+		m.applyFilter()
+	case "N":
+		// Unconnected work: islands first, pressing again shows detached clusters
+		if m.currentFilter == "islands" {
+			m.currentFilter = "detached"
+		} else {
+			m.currentFilter = "islands"
+		}
+		m.applyFilter()
 	case "t":
 		// Toggle time-travel mode off, or show prompt for custom revision
 		if m.timeTravelMode {
@@ -1206,6 +3308,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		} else {
 			// Show input prompt for revision
 			m.showTimeTravelPrompt = true
+			m.timeTravelAsOf = false
 			m.timeTravelInput.SetValue("")
 			m.timeTravelInput.Focus()
 			m.focused = focusTimeTravelInput
@@ -1217,18 +3320,44 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 		} else {
 			m.enterTimeTravelMode("HEAD~5")
 		}
+	case "H":
+		// View the issues as of a past date or revision, or return to today
+		if m.snapshot != nil {
+			m.statusMsg = "Returning to today…"
+			m.statusIsError = false
+			return m, leaveSnapshotCmd(m.beadsPath, m.snapshot, m.currentLoad())
+		}
+		m.showTimeTravelPrompt = true
+		m.timeTravelAsOf = true
+		m.timeTravelInput.SetValue("")
+		m.timeTravelInput.Focus()
+		m.focused = focusTimeTravelInput
 	case "C":
 		// Copy selected issue to clipboard
 		m.copyIssueToClipboard()
+	case "ctrl+y":
+		// Copy just the issue ID
+		m.copyIssueID()
+	case "ctrl+k":
+		// Copy a Markdown link to the issue
+		m.copyIssueLink()
 	case "O":
 		// Open beads.jsonl in editor
 		m.openInEditor()
+	case "e":
+		// Export the rows in view, as filtered, sorted and grouped
+		m.exportView("csv")
+	case "ctrl+e":
+		m.exportView("xlsx")
+	case "y", "Y", "[", "]":
+		// Copy, open or choose a commit linked to the selected issue
+		m.handleCommitKey(msg.String())
 	}
-	return m
+	return m, nil
 }
 
 // handleTimeTravelInputKeys handles keyboard input for the time-travel revision prompt
-func (m Model) handleTimeTravelInputKeys(msg tea.KeyMsg) Model {
+func (m Model) handleTimeTravelInputKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		// Submit the revision
@@ -1239,6 +3368,9 @@ func (m Model) handleTimeTravelInputKeys(msg tea.KeyMsg) Model {
 		m.showTimeTravelPrompt = false
 		m.timeTravelInput.Blur()
 		m.focused = focusList
+		if m.timeTravelAsOf {
+			return m, m.enterSnapshot(revision)
+		}
 		m.enterTimeTravelMode(revision)
 	case "esc":
 		// Cancel
@@ -1249,7 +3381,7 @@ func (m Model) handleTimeTravelInputKeys(msg tea.KeyMsg) Model {
 		// Update the textinput
 		m.timeTravelInput, _ = m.timeTravelInput.Update(msg)
 	}
-	return m
+	return m, nil
 }
 
 func (m Model) View() string {
@@ -1257,6 +3389,25 @@ func (m Model) View() string {
 		return "Initializing..."
 	}
 
+	// Focus mode hides everything else, the status bar down to its message
+	if m.showFocusMode {
+		m.focusMode.SetSize(m.width, m.height-1)
+		status := ""
+		if m.statusMsg != "" {
+			style := m.theme.Renderer.NewStyle().Foreground(ColorMuted)
+			if m.statusIsError {
+				style = style.Foreground(ColorPrioCritical)
+			}
+			status = style.Render(" " + truncateToWidth(m.statusMsg, max(m.width-2, 1), "…"))
+		}
+		screen := m.focusMode.View(time.Now())
+		if m.showConflictResolver { // From marking the issue
+			m.conflictResolver.SetSize(m.width, m.height-1)
+			screen = m.conflictResolver.View()
+		}
+		return screen + "\n" + status
+	}
+
 	var body string
 
 	// Quit confirmation overlay takes highest priority
@@ -1266,23 +3417,101 @@ func (m Model) View() string {
 		body = m.renderTimeTravelPrompt()
 	} else if m.showRecipePicker {
 		body = m.recipePicker.View()
+	} else if m.showSortPicker {
+		body = m.sortPicker.View()
+	} else if m.showLabelPicker {
+		body = m.labelPicker.View()
+	} else if m.showAssigneePicker {
+		body = m.assigneePicker.View()
+	} else if m.showCreateForm {
+		m.createForm.SetSize(m.width, m.height-1)
+		body = m.createForm.View()
+	} else if m.showCommentComposer {
+		m.commentComposer.SetSize(m.width, m.height-1)
+		body = m.commentComposer.View()
+	} else if m.showConflictResolver {
+		m.conflictResolver.SetSize(m.width, m.height-1)
+		body = m.conflictResolver.View()
+	} else if m.showMergeAssistant {
+		m.mergeAssistant.SetSize(m.width, m.height-1)
+		body = m.mergeAssistant.View()
+	} else if m.showSprintPicker {
+		body = m.sprintPicker.View()
+	} else if m.showPluginPager {
+		body = m.renderPluginPager()
 	} else if m.showHelp {
 		body = m.renderHelpOverlay()
+	} else if m.showQuickLook {
+		body = m.renderQuickLook()
+	} else if m.showAttachmentPreview {
+		body = m.renderAttachmentPreview()
+	} else if m.isCompareView {
+		m.compareView.SetSize(m.width, m.height-2)
+		body = m.compareView.Render()
 	} else if m.focused == focusInsights {
 		body = m.insightsPanel.View()
 	} else if m.isGraphView {
+		m.graphView.SetListWidth(m.layout.GraphListWidth)
 		body = m.graphView.View(m.width, m.height-1)
 	} else if m.isBoardView {
 		body = m.board.View(m.width, m.height-1)
 	} else if m.isActionableView {
 		m.actionableView.SetSize(m.width, m.height-2)
 		body = m.actionableView.Render()
+	} else if m.isMilestoneView {
+		m.milestoneView.SetSize(m.width, m.height-2)
+		body = m.milestoneView.Render()
+	} else if m.isBurndownView {
+		m.burndownView.SetSize(m.width, m.height-2)
+		body = m.burndownView.Render()
+	} else if m.isFlowView {
+		m.flowView.SetSize(m.width, m.height-2)
+		body = m.flowView.Render()
+	} else if m.isVelocityView {
+		m.velocityView.SetSize(m.width, m.height-2)
+		body = m.velocityView.Render()
+	} else if m.isWorkloadView {
+		m.workloadView.SetSize(m.width, m.height-2)
+		body = m.workloadView.Render()
+	} else if m.isDuplicatesView {
+		m.duplicatesView.SetSize(m.width, m.height-2)
+		body = m.duplicatesView.Render()
+	} else if m.isProblemsView {
+		m.problemsView.SetSize(m.width, m.height-2)
+		body = m.problemsView.Render()
+	} else if m.isActivityView {
+		m.activityView.SetSize(m.width, m.height-2)
+		body = m.activityView.Render()
+	} else if m.isCouplingView {
+		m.couplingView.SetSize(m.width, m.height-2)
+		body = m.couplingView.Render()
+	} else if m.isDSMView {
+		m.dsmView.SetSize(m.width, m.height-2)
+		body = m.dsmView.Render()
+	} else if m.isRiskView {
+		m.riskView.SetSize(m.width, m.height-2)
+		body = m.riskView.Render()
+	} else if m.isScheduleView {
+		m.scheduleView.SetSize(m.width, m.height-2)
+		body = m.scheduleView.Render()
+	} else if m.isAgingView {
+		m.agingView.SetSize(m.width, m.height-2)
+		body = m.agingView.Render()
+	} else if m.isLabelsView {
+		m.labelsView.SetSize(m.width, m.height-2)
+		body = m.labelsView.Render()
+	} else if m.isLineageView {
+		m.lineageView.SetSize(m.width, m.height-2)
+		body = m.lineageView.Render()
+	} else if m.isWorklogView {
+		m.worklogView.SetSize(m.width, m.height-2)
+		body = m.worklogView.Render()
 	} else if m.isSplitView {
 		body = m.renderSplitView()
 	} else {
 		// Mobile view
 		if m.showDetails {
-			body = m.viewport.View()
+			body = m.detailScrollbar(m.viewport.View())
 		} else {
 			body = m.renderListWithHeader()
 		}
@@ -1292,12 +3521,18 @@ func (m Model) View() string {
 
 	// Ensure the final output fits exactly in the terminal height
 	// This prevents the header from being pushed off the top
-	finalStyle := lipgloss.NewStyle().
+	finalStyle := m.theme.Renderer.NewStyle().
 		Width(m.width).
 		Height(m.height).
 		MaxHeight(m.height)
 
-	return finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	view := finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	if m.previewEscape == "" && m.graphicsProtocol() == graphicsKitty {
+		// Kitty keeps images until told otherwise, so clear the preview's
+		// once it closes. The line doesn't change, so it's only sent once.
+		view = kittyDeleteImages + view
+	}
+	return view
 }
 
 func (m Model) renderQuitConfirm() string {
@@ -1351,12 +3586,12 @@ func (m Model) renderListWithHeader() string {
 		Bold(true).
 		Width(m.width - 2)
 
-	header := headerStyle.Render("  TYPE PRI STATUS      ID                                   TITLE")
+	header := headerStyle.Render(m.issueDelegate().RenderHeader(m.list.Width(), m.listFit, m.sortMode, m.listHeaderIndicator()))
 
 	// Page info
 	totalItems := len(m.list.Items())
 	currentIdx := m.list.Index()
-	itemsPerPage := availableHeight
+	itemsPerPage := availableHeight / m.rowHeight()
 	if itemsPerPage < 1 {
 		itemsPerPage = 1
 	}
@@ -1386,8 +3621,8 @@ func (m Model) renderListWithHeader() string {
 		header,
 	)
 
-	// List view - just render it normally since bubbles handles scrolling
-	listView := m.list.View()
+	// List view - bubbles handles scrolling; the scrollbar shows where the page sits
+	listView := m.listScrollbar(m.list.View())
 
 	// Page indicator line
 	pageLine := pageStyle.Render(pageInfo)
@@ -1404,7 +3639,7 @@ func (m Model) renderListWithHeader() string {
 	content := lipgloss.JoinVertical(lipgloss.Left, headerLine, listView, pageLine)
 
 	// Force exact height to prevent overflow
-	return lipgloss.NewStyle().
+	return m.theme.Renderer.NewStyle().
 		Width(m.width).
 		Height(bodyHeight).
 		MaxHeight(bodyHeight).
@@ -1435,7 +3670,7 @@ func (m Model) renderSplitView() string {
 		Bold(true).
 		Width(listInnerWidth)
 
-	header := headerStyle.Render("  TYPE PRI STATUS      ID                     TITLE")
+	header := headerStyle.Render(m.issueDelegate().RenderHeader(listInnerWidth, m.listFit, m.sortMode, m.listHeaderIndicator()))
 
 	// Page info for list
 	totalItems := len(m.list.Items())
@@ -1444,6 +3679,7 @@ func (m Model) renderSplitView() string {
 	if listHeight == 0 {
 		listHeight = panelHeight - 3 // fallback
 	}
+	listHeight /= m.rowHeight()
 	if listHeight < 1 {
 		listHeight = 1
 	}
@@ -1471,7 +3707,7 @@ func (m Model) renderSplitView() string {
 	pageLine := pageStyle.Render(pageInfo)
 
 	// Combine header + list + page indicator
-	listContent := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View(), pageLine)
+	listContent := lipgloss.JoinVertical(lipgloss.Left, header, m.listScrollbar(m.list.View()), pageLine)
 
 	// List Panel Width: Inner + 2 (Padding). Border adds another 2.
 	// Use MaxHeight to ensure content doesn't overflow
@@ -1486,7 +3722,7 @@ func (m Model) renderSplitView() string {
 		Width(m.viewport.Width + 2).
 		Height(panelHeight).
 		MaxHeight(panelHeight).
-		Render(m.viewport.View())
+		Render(m.detailScrollbar(m.viewport.View()))
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
 }
@@ -1529,6 +3765,8 @@ func (m Model) renderHelpOverlay() string {
 		{"Ctrl+u", "Page up"},
 		{"Tab", "Switch focus (split view)"},
 		{"Enter", "View details"},
+		{"Space", "Quick look (blockers, dependents, metrics)"},
+		{"=", "Mark issue, then = on another to compare"},
 		{"Esc", "Back / close"},
 	}
 	for _, s := range shortcuts {
@@ -1544,7 +3782,30 @@ func (m Model) renderHelpOverlay() string {
 		{"b", "Toggle Kanban board"},
 		{"g", "Toggle Graph view"},
 		{"i", "Toggle Insights dashboard"},
+		{"M", "Toggle Milestones dashboard"},
+		{"B", "Toggle Burndown chart"},
+		{"F", "Toggle Lead/cycle time (flow)"},
+		{"V", "Toggle Throughput/velocity"},
+		{"W", "Toggle Assignee workload"},
+		{"X", "Toggle Duplicate candidates"},
+		{"P", "Toggle Data problems"},
+		{"U", "Toggle Recent activity feed"},
+		{"K", "Toggle Cross-epic dependency report"},
+		{"J", "Toggle Dependency structure matrix"},
+		{"Q", "Toggle Risk ranking"},
+		{"alt+s", "Toggle Schedule timeline"},
+		{"alt+a", "Toggle Aging WIP chart"},
+		{"alt+l", "Toggle label analytics"},
+		{"alt+d", "Toggle discovered-from lineage"},
+		{"alt+w", "Toggle time report: time logged by epic, assignee and issue"},
+		{"alt+m", "Resolve git conflict markers in the beads file, record by record"},
+		{"A", "Include/hide archived issues"},
 		{"R", "Open Recipe picker"},
+		{"s", "Open Sort menu"},
+		{"S", "Reverse sort direction"},
+		{"Ctrl+F", "Focus every view on the selected issue's subgraph (1/2/3/all hops, off)"},
+		{"Alt+E", "Scope every view and export to the selected epic (again: clear)"},
+		{"@", "Show only my issues, with my ready count and forecast (again: everyone's)"},
 		{"?", "Toggle this help"},
 	}
 	for _, s := range views {
@@ -1558,13 +3819,35 @@ func (m Model) renderHelpOverlay() string {
 	graphKeys := []struct{ key, desc string }{
 		{"h/j/k/l", "Navigate nodes"},
 		{"H/L", "Scroll canvas left/right"},
+		{"[ / ]", "Pick a blocker or dependent (Enter re-centers on it)"},
+		{"f", "Follow it, extending the breadcrumb trail"},
+		{"Backspace", "Back one step along the trail"},
+		{"Alt+1-9", "Jump back to a numbered crumb"},
 		{"PgUp/PgDn", "Scroll canvas up/down"},
+		{"m", "Cycle sort metric"},
+		{"e / x", "Pick / toggle an edge type for blockers and dependents"},
+		{"r", "Hide / show blocking edges implied by longer chains"},
+		{"c", "Color and group nodes by cluster of linked work"},
 		{"Enter", "Jump to selected issue"},
 	}
 	for _, s := range graphKeys {
 		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
 	}
 
+	// Board keys
+	sb.WriteString("\n")
+	sb.WriteString(sectionStyle.Render("Board"))
+	sb.WriteString("\n")
+	boardKeys := []struct{ key, desc string }{
+		{"h/j/k/l", "Navigate cards (j/k cross lanes)"},
+		{"Z", "Swimlanes by assignee/epic/off"},
+		{"Ctrl+d/u", "Next/previous lane"},
+		{"Enter", "Jump to selected issue"},
+	}
+	for _, s := range boardKeys {
+		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
+	}
+
 	// Insights (when in insights view)
 	sb.WriteString("\n")
 	sb.WriteString(sectionStyle.Render("Insights Panel"))
@@ -1580,6 +3863,23 @@ func (m Model) renderHelpOverlay() string {
 		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
 	}
 
+	// Detail pager (full-screen details, or the focused detail pane)
+	sb.WriteString("\n")
+	sb.WriteString(sectionStyle.Render("Details"))
+	sb.WriteString("\n")
+	detailKeys := []struct{ key, desc string }{
+		{"j/k", "Scroll line by line"},
+		{"Space/PgDn", "Page down (PgUp back)"},
+		{"Ctrl+d/u", "Half page down/up"},
+		{"Home/G", "Top/bottom"},
+		{"/", "Search the details"},
+		{"n/N", "Next/previous match"},
+		{"Esc", "Clear search, then go back"},
+	}
+	for _, s := range detailKeys {
+		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
+	}
+
 	// Filters
 	sb.WriteString("\n")
 	sb.WriteString(sectionStyle.Render("Filters"))
@@ -1590,6 +3890,22 @@ func (m Model) renderHelpOverlay() string {
 		{"r", "Show Ready (unblocked)"},
 		{"a", "Show All issues"},
 		{"/", "Fuzzy search"},
+		{"D", "Show issues Due this week"},
+		{"!", "Show Overdue issues"},
+		{"0", "Show zero-slack issues"},
+		{"N", "Show unconnected (again: detached)"},
+		{"L", "Filter by Label"},
+		{"u", "Filter by assignee (type to search)"},
+		{"w", "Assign the selected issue (pick or type a name)"},
+		{"n", "New issue, from a template if any are configured (bd create)"},
+		{"m", "Comment on the selected issue; ctrl+e writes it in $EDITOR (bd comments add)"},
+		{"alt+t", "Start / stop the timer on the selected issue (.bv/worklog.json)"},
+		{"alt+f", "Focus mode: a 25-minute pomodoro on the selected (or first ready) issue, then log the time or update its status"},
+		{"d", "Cycle density (compact/comfortable/spacious)"},
+		{"x", "Cycle rows (single line, 2- or 3-line cards)"},
+		{"I", "Sprint menu (scopes list & board)"},
+		{"Z", "Group by status/assignee/epic/label/cluster"},
+		{"z", "Collapse/expand group"},
 	}
 	for _, s := range filters {
 		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
@@ -1602,9 +3918,22 @@ func (m Model) renderHelpOverlay() string {
 	general := []struct{ key, desc string }{
 		{"t", "Time-travel (custom revision)"},
 		{"T", "Time-travel (HEAD~5)"},
+		{"H", "View as of a date/revision (H again: today)"},
 		{"E", "Export to Markdown"},
-		{"C", "Copy issue to clipboard"},
+		{"e / Ctrl+e", "Export list as shown to CSV / Excel"},
+		{"C", "Copy issue summary (Markdown)"},
+		{"Ctrl+y", "Copy issue ID"},
+		{"Ctrl+k", "Copy Markdown link to issue"},
 		{"O", "Open in editor"},
+		{"o", "Open issue in browser (from details)"},
+		{"v", "Preview attachments (from details)"},
+		{"y / Y", "Copy linked commit hash / open it on the forge"},
+		{"[ / ]", "Previous / next linked commit"},
+		{"{ / } / f", "Choose / follow a URL or issue mentioned (details)"},
+		{"( / ) / x", "Choose / check off a checklist item, saved via bd (details)"},
+		{"< / >", "Narrow / widen the list pane (split & graph)"},
+		{"Ctrl+n / Ctrl+w", "New tab (copy of this one) / close tab"},
+		{"gt / gT / 1-9", "Next / previous tab, or tab N"},
 		{"q", "Back / Quit"},
 		{"Ctrl+c", "Force quit"},
 	}
@@ -1612,6 +3941,21 @@ func (m Model) renderHelpOverlay() string {
 		sb.WriteString(keyStyle.Render(s.key) + descStyle.Render(s.desc) + "\n")
 	}
 
+	// Plugins from the config file
+	if len(m.plugins) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(sectionStyle.Render("Plugins"))
+		sb.WriteString("\n")
+		keys := make([]string, 0, len(m.plugins))
+		for key := range m.plugins {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			sb.WriteString(keyStyle.Render(key) + descStyle.Render("Run "+m.plugins[key].Name) + "\n")
+		}
+	}
+
 	sb.WriteString("\n")
 	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render("Press any key to close"))
 
@@ -1638,31 +3982,6 @@ func (m *Model) renderFooter() string {
 	// POLISHED FOOTER - Stripe-level status bar with visual hierarchy
 	// ══════════════════════════════════════════════════════════════════════════
 
-	// If there's a status message, show it prominently with polished styling
-	if m.statusMsg != "" {
-		var msgStyle lipgloss.Style
-		if m.statusIsError {
-			msgStyle = lipgloss.NewStyle().
-				Background(ColorPrioCriticalBg).
-				Foreground(ColorPrioCritical).
-				Bold(true).
-				Padding(0, 2)
-		} else {
-			msgStyle = lipgloss.NewStyle().
-				Background(ColorStatusOpenBg).
-				Foreground(ColorSuccess).
-				Bold(true).
-				Padding(0, 2)
-		}
-		msgSection := msgStyle.Render("✓ " + m.statusMsg)
-		remaining := m.width - lipgloss.Width(msgSection)
-		if remaining < 0 {
-			remaining = 0
-		}
-		filler := lipgloss.NewStyle().Background(ColorBgDark).Width(remaining).Render("")
-		return lipgloss.JoinHorizontal(lipgloss.Bottom, msgSection, filler)
-	}
-
 	// ─────────────────────────────────────────────────────────────────────────
 	// FILTER BADGE - Current view/filter state
 	// ─────────────────────────────────────────────────────────────────────────
@@ -1681,17 +4000,54 @@ func (m *Model) renderFooter() string {
 	case "ready":
 		filterTxt = "READY"
 		filterIcon = "🚀"
+	case "due":
+		filterTxt = "DUE THIS WEEK"
+		filterIcon = "⏳"
+	case "overdue":
+		filterTxt = "OVERDUE"
+		filterIcon = "⏰"
+	case "zero_slack":
+		filterTxt = "ZERO SLACK"
+		filterIcon = "🎯"
+	case "islands":
+		filterTxt = "ISLANDS"
+		filterIcon = "🏝️"
+	case "detached":
+		filterTxt = "DETACHED"
+		filterIcon = "🧩"
 	default:
 		if strings.HasPrefix(m.currentFilter, "recipe:") {
 			filterTxt = strings.ToUpper(m.currentFilter[7:])
 			filterIcon = "📑"
+		} else if strings.HasPrefix(m.currentFilter, labelFilterPrefix) {
+			filterTxt = strings.TrimPrefix(m.currentFilter, labelFilterPrefix)
+			filterIcon = "🏷️"
+		} else if strings.HasPrefix(m.currentFilter, sprintFilterPrefix) {
+			filterTxt = m.sprintBadgeText(strings.TrimPrefix(m.currentFilter, sprintFilterPrefix))
+			filterIcon = "🏃"
+		} else if strings.HasPrefix(m.currentFilter, milestoneFilterPrefix) {
+			filterTxt = strings.TrimPrefix(m.currentFilter, milestoneFilterPrefix)
+			filterIcon = "🎯"
+		} else if strings.HasPrefix(m.currentFilter, assigneeFilterPrefix) {
+			filterTxt = assigneeBadgeText(strings.TrimPrefix(m.currentFilter, assigneeFilterPrefix))
+			filterIcon = "👤"
+		} else if strings.HasPrefix(m.currentFilter, teamFilterPrefix) {
+			filterTxt = strings.ToUpper(strings.TrimPrefix(m.currentFilter, teamFilterPrefix))
+			if filterTxt == "" {
+				filterTxt = "NO TEAM"
+			}
+			filterIcon = "👥"
 		} else {
 			filterTxt = m.currentFilter
 			filterIcon = "🔍"
 		}
 	}
 
-	filterBadge := lipgloss.NewStyle().
+	if scope := m.scopeLabel(); scope != "" {
+		filterTxt += " · " + scope
+	}
+
+	filterBadge := m.theme.Renderer.NewStyle().
 		Background(ColorPrimary).
 		Foreground(ColorText).
 		Bold(true).
@@ -1702,9 +4058,17 @@ func (m *Model) renderFooter() string {
 	// STATS SECTION - Issue counts with visual indicators
 	// ─────────────────────────────────────────────────────────────────────────
 	var statsSection string
-	if m.timeTravelMode && m.timeTravelDiff != nil {
+	if m.snapshot != nil {
+		d := m.snapshot.Diff.Summary
+		snapshotStyle := m.theme.Renderer.NewStyle().
+			Background(ColorPrioHighBg).
+			Foreground(ColorWarning).
+			Padding(0, 1)
+		statsSection = snapshotStyle.Render(fmt.Sprintf("🕰 as of %s · since: +%d ✅%d ~%d",
+			m.snapshot.Label(), d.IssuesAdded, d.IssuesClosed, d.IssuesModified))
+	} else if m.timeTravelMode && m.timeTravelDiff != nil {
 		d := m.timeTravelDiff.Summary
-		timeTravelStyle := lipgloss.NewStyle().
+		timeTravelStyle := m.theme.Renderer.NewStyle().
 			Background(ColorPrioHighBg).
 			Foreground(ColorWarning).
 			Padding(0, 1)
@@ -1712,15 +4076,15 @@ func (m *Model) renderFooter() string {
 			m.timeTravelSince, d.IssuesAdded, d.IssuesClosed, d.IssuesModified))
 	} else {
 		// Polished stats with mini indicators
-		statsStyle := lipgloss.NewStyle().
+		statsStyle := m.theme.Renderer.NewStyle().
 			Background(ColorBgHighlight).
 			Foreground(ColorText).
 			Padding(0, 1)
 
-		openStyle := lipgloss.NewStyle().Foreground(ColorStatusOpen)
-		readyStyle := lipgloss.NewStyle().Foreground(ColorSuccess)
-		blockedStyle := lipgloss.NewStyle().Foreground(ColorWarning)
-		closedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+		openStyle := m.theme.Renderer.NewStyle().Foreground(ColorStatusOpen)
+		readyStyle := m.theme.Renderer.NewStyle().Foreground(ColorSuccess)
+		blockedStyle := m.theme.Renderer.NewStyle().Foreground(ColorWarning)
+		closedStyle := m.theme.Renderer.NewStyle().Foreground(ColorMuted)
 
 		statsContent := fmt.Sprintf("%s%d %s%d %s%d %s%d",
 			openStyle.Render("○"),
@@ -1734,12 +4098,24 @@ func (m *Model) renderFooter() string {
 		statsSection = statsStyle.Render(statsContent)
 	}
 
+	// ─────────────────────────────────────────────────────────────────────────
+	// SORT BADGE - Explicit list ordering
+	// ─────────────────────────────────────────────────────────────────────────
+	sortSection := ""
+	if label := m.sortMode.Label(); label != "" {
+		sortStyle := m.theme.Renderer.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorSubtext).
+			Padding(0, 1)
+		sortSection = sortStyle.Render("⇅ " + label)
+	}
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// UPDATE BADGE - New version available
 	// ─────────────────────────────────────────────────────────────────────────
 	updateSection := ""
 	if m.updateAvailable {
-		updateStyle := lipgloss.NewStyle().
+		updateStyle := m.theme.Renderer.NewStyle().
 			Background(ColorTypeFeature).
 			Foreground(ColorBg).
 			Bold(true).
@@ -1747,12 +4123,60 @@ func (m *Model) renderFooter() string {
 		updateSection = updateStyle.Render(fmt.Sprintf("⭐ %s", m.updateTag))
 	}
 
+	// ─────────────────────────────────────────────────────────────────────────
+	// PROGRESS BADGE - Background reloads and metrics still computing
+	// ─────────────────────────────────────────────────────────────────────────
+	progressSection := ""
+	if text := m.backgroundWorkText(); text != "" {
+		progressStyle := m.theme.Renderer.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorSecondary).
+			Padding(0, 1)
+		progressSection = progressStyle.Render(text)
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// TIMER BADGE - The issue the timer runs on, and for how long
+	// ─────────────────────────────────────────────────────────────────────────
+	timerSection := ""
+	if text := m.runningTimer(); text != "" {
+		timerStyle := m.theme.Renderer.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorWarning).
+			Padding(0, 1)
+		timerSection = timerStyle.Render(text)
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// PROBLEMS BADGE - Data problems in the beads file
+	// ─────────────────────────────────────────────────────────────────────────
+	problemsSection := ""
+	if n := len(m.dataProblems); n > 0 && !m.isProblemsView {
+		problemsStyle := m.theme.Renderer.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorWarning).
+			Padding(0, 1)
+		problemsSection = problemsStyle.Render(fmt.Sprintf("⚠ %d data problems (P)", n))
+	}
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// ARCHIVE BADGE - Archived issues merged into the working set
+	// ─────────────────────────────────────────────────────────────────────────
+	archiveSection := ""
+	if m.includeArchived {
+		archiveStyle := m.theme.Renderer.NewStyle().
+			Background(ColorBgHighlight).
+			Foreground(ColorSubtext).
+			Padding(0, 1)
+		archiveSection = archiveStyle.Render("🗄 +archive")
+	}
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// WORKSPACE BADGE - Multi-repo mode indicator
 	// ─────────────────────────────────────────────────────────────────────────
 	workspaceSection := ""
 	if m.workspaceMode && m.workspaceSummary != "" {
-		workspaceStyle := lipgloss.NewStyle().
+		workspaceStyle := m.theme.Renderer.NewStyle().
 			Background(lipgloss.Color("#45B7D1")).
 			Foreground(ColorBg).
 			Bold(true).
@@ -1763,85 +4187,226 @@ func (m *Model) renderFooter() string {
 	// ─────────────────────────────────────────────────────────────────────────
 	// KEYBOARD HINTS - Context-aware navigation help
 	// ─────────────────────────────────────────────────────────────────────────
-	keyStyle := lipgloss.NewStyle().
+	keyStyle := m.theme.Renderer.NewStyle().
 		Foreground(ColorSecondary).
 		Background(ColorBgSubtle).
 		Padding(0, 0)
-	sepStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	sepStyle := m.theme.Renderer.NewStyle().Foreground(ColorMuted)
 	sep := sepStyle.Render(" │ ")
 
 	var keyHints []string
 	if m.showHelp {
 		keyHints = append(keyHints, "Press any key to close")
-	} else if m.showRecipePicker {
+	} else if m.pager.searching {
+		keyHints = m.detailPagerHints(func(k string) string { return keyStyle.Render(k) })
+	} else if m.isCompareView {
+		keyHints = append(keyHints, keyStyle.Render("s")+" swap sides", keyStyle.Render("esc")+" back")
+	} else if m.showQuickLook {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" next issue", keyStyle.Render("⏎")+" details", keyStyle.Render("esc")+" close")
+	} else if m.showAttachmentPreview {
+		keyHints = append(keyHints, keyStyle.Render("n/p")+" next attachment", keyStyle.Render("esc")+" close")
+	} else if m.showAssigneePicker {
+		keyHints = append(keyHints, keyStyle.Render("type")+" search", keyStyle.Render("tab")+" complete", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+	} else if m.showCreateForm && m.createForm.Choosing() {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" choose template", keyStyle.Render("esc")+" cancel")
+	} else if m.showCreateForm {
+		keyHints = append(keyHints, keyStyle.Render("tab")+" next field", keyStyle.Render("⏎")+" create", keyStyle.Render("esc")+" back")
+	} else if m.showCommentComposer {
+		keyHints = append(keyHints, keyStyle.Render("ctrl+s")+" save", keyStyle.Render("ctrl+e")+" $EDITOR", keyStyle.Render("esc")+" cancel")
+	} else if m.showConflictResolver {
+		keyHints = append(keyHints, keyStyle.Render("m/t")+" mine/theirs", keyStyle.Render("⏎")+" save", keyStyle.Render("esc")+" discard edit")
+	} else if m.showMergeAssistant {
+		keyHints = append(keyHints, keyStyle.Render("o/t")+" ours/theirs", keyStyle.Render("⏎")+" write file", keyStyle.Render("esc")+" cancel")
+	} else if m.showRecipePicker || m.showSortPicker || m.showLabelPicker || m.showSprintPicker {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
 	} else if m.focused == focusInsights {
 		keyHints = append(keyHints, keyStyle.Render("h/l")+" panels", keyStyle.Render("e")+" explain", keyStyle.Render("⏎")+" jump", keyStyle.Render("?")+" help")
 	} else if m.isGraphView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
+		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("[]f")+" follow", keyStyle.Render("m")+" sort metric", keyStyle.Render("e/x")+" edges", keyStyle.Render("r")+" reduce", keyStyle.Render("c")+" clusters", keyStyle.Render("</>")+" resize", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
 	} else if m.isBoardView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("G")+" bottom", keyStyle.Render("⏎")+" view", keyStyle.Render("b")+" list")
+		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("Z")+" lanes", keyStyle.Render("⏎")+" view", keyStyle.Render("b")+" list")
 	} else if m.isActionableView {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" view", keyStyle.Render("a")+" list", keyStyle.Render("?")+" help")
+	} else if m.isMilestoneView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" scope list", keyStyle.Render("M")+" list", keyStyle.Render("?")+" help")
+	} else if m.isBurndownView {
+		keyHints = append(keyHints, keyStyle.Render("w")+" day/week", keyStyle.Render("u")+" issues/points", keyStyle.Render("m")+" burnup", keyStyle.Render("B")+" list")
+	} else if m.isFlowView {
+		keyHints = append(keyHints, keyStyle.Render("c")+" lead/cycle", keyStyle.Render("d")+" breakdown", keyStyle.Render("F")+" list", keyStyle.Render("?")+" help")
+	} else if m.isVelocityView {
+		keyHints = append(keyHints, keyStyle.Render("w")+" rolling window", keyStyle.Render("V")+" list", keyStyle.Render("?")+" help")
+	} else if m.isWorkloadView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" show issues", keyStyle.Render("t")+" teams", keyStyle.Render("W")+" list", keyStyle.Render("?")+" help")
+	} else if m.isDuplicatesView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("x")+" close dup", keyStyle.Render("r")+" swap", keyStyle.Render("n")+" dismiss", keyStyle.Render("=")+" compare", keyStyle.Render("X")+" list")
+	} else if m.isProblemsView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("d")+" drop dep", keyStyle.Render("l")+" load file", keyStyle.Render("P")+" list")
+	} else if m.isActivityView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("f")+" actor", keyStyle.Render("U")+" list", keyStyle.Render("?")+" help")
+	} else if m.isCouplingView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("o")+" blocker", keyStyle.Render("K")+" list", keyStyle.Render("?")+" help")
+	} else if m.isDSMView {
+		keyHints = append(keyHints, keyStyle.Render("hjkl")+" move", keyStyle.Render("n/N")+" next dep", keyStyle.Render("e")+" epics", keyStyle.Render("⏎/o")+" row/col issue", keyStyle.Render("J")+" list")
+	} else if m.isRiskView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("e")+" epics only", keyStyle.Render("Q")+" list", keyStyle.Render("?")+" help")
+	} else if m.isScheduleView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("a")+" by assignee", keyStyle.Render("alt+s")+" list", keyStyle.Render("?")+" help")
+	} else if m.isAgingView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("alt+a")+" list", keyStyle.Render("?")+" help")
+	} else if m.isLabelsView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("m")+" sort", keyStyle.Render("⏎")+" filter", keyStyle.Render("alt+l")+" list", keyStyle.Render("?")+" help")
+	} else if m.isLineageView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("alt+d")+" list", keyStyle.Render("?")+" help")
+	} else if m.isWorklogView {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" jump", keyStyle.Render("alt+w")+" list", keyStyle.Render("?")+" help")
 	} else if m.list.FilterState() == list.Filtering {
 		keyHints = append(keyHints, keyStyle.Render("esc")+" cancel", keyStyle.Render("⏎")+" select")
 	} else if m.showTimeTravelPrompt {
 		keyHints = append(keyHints, keyStyle.Render("⏎")+" compare", keyStyle.Render("esc")+" cancel")
 	} else {
-		if m.timeTravelMode {
+		if m.snapshot != nil {
+			keyHints = append(keyHints, keyStyle.Render("H")+" back to today", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
+		} else if m.timeTravelMode {
 			keyHints = append(keyHints, keyStyle.Render("t")+" exit diff", keyStyle.Render("C")+" copy", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
 		} else if m.isSplitView {
+			// The pager's position comes first, ahead of hints that make way
+			if m.focused == focusDetail {
+				keyHints = append(keyHints, m.detailPagerHints(func(k string) string { return keyStyle.Render(k) })...)
+			}
 			keyHints = append(keyHints, keyStyle.Render("tab")+" focus", keyStyle.Render("C")+" copy", keyStyle.Render("E")+" export", keyStyle.Render("?")+" help")
 		} else if m.showDetails {
-			keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("C")+" copy", keyStyle.Render("O")+" edit", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, m.detailPagerHints(func(k string) string { return keyStyle.Render(k) })...)
+			keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("C")+" copy", keyStyle.Render("O")+" edit")
+			if m.issueURL != nil {
+				keyHints = append(keyHints, keyStyle.Render("o")+" browser")
+			}
+			keyHints = append(keyHints, keyStyle.Render("?")+" help")
 		} else {
 			keyHints = append(keyHints, keyStyle.Render("⏎")+" details", keyStyle.Render("t")+" diff", keyStyle.Render("ECO")+" actions", keyStyle.Render("?")+" help")
 		}
+		if m.mineOnly {
+			keyHints = append(keyHints, keyStyle.Render("@")+" everyone")
+		}
+		// Offer the epic scope on an epic, and the way out once scoped
+		if m.epicScope != "" {
+			keyHints = append(keyHints, keyStyle.Render("alt+e")+" unscope")
+		} else if item, ok := m.list.SelectedItem().(IssueItem); ok && item.Issue.IssueType == model.TypeEpic {
+			keyHints = append(keyHints, keyStyle.Render("alt+e")+" scope to epic")
+		}
 	}
 
-	keysSection := lipgloss.NewStyle().
+	keysStyle := m.theme.Renderer.NewStyle().
 		Foreground(ColorSubtext).
+		Padding(0, 1)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// COUNT BADGE - Issues shown out of all loaded
+	// ─────────────────────────────────────────────────────────────────────────
+	countText := fmt.Sprintf("%d issues", len(m.issues))
+	if shown := m.visibleIssueCount(); shown != len(m.issues) {
+		countText = fmt.Sprintf("%d of %d issues", shown, len(m.issues))
+	}
+	countBadge := m.theme.Renderer.NewStyle().
+		Foreground(ColorSecondary).
 		Padding(0, 1).
-		Render(strings.Join(keyHints, sep))
+		Render(countText)
+
+	// ─────────────────────────────────────────────────────────────────────────
+	// ASSEMBLE FOOTER with proper spacing
+	// ─────────────────────────────────────────────────────────────────────────
+	var left []string
+	for _, section := range []string{m.tabStrip(), filterBadge, workspaceSection, updateSection,
+		statsSection, sortSection, progressSection, timerSection, problemsSection, archiveSection} {
+		if section != "" {
+			left = append(left, section)
+		}
+	}
+	// Key hints that don't fit are dropped from the end rather than wrapping
+	// the footer onto a second line
+	keysSection := keysStyle.Render(strings.Join(keyHints, sep))
+	for len(keyHints) > 1 && lipgloss.Width(strings.Join(left, ""))+lipgloss.Width(countBadge)+lipgloss.Width(keysSection)+1 > m.width {
+		keyHints = keyHints[:len(keyHints)-1]
+		keysSection = keysStyle.Render(strings.Join(keyHints, sep))
+	}
+	right := []string{countBadge, keysSection}
+
+	// A status message takes the place of the count and key hints, keeping
+	// the badges in view; when it needs more room the last badges make way
+	if m.statusMsg != "" {
+		var msgStyle lipgloss.Style
+		if m.statusIsError {
+			msgStyle = m.theme.Renderer.NewStyle().
+				Background(ColorPrioCriticalBg).
+				Foreground(ColorPrioCritical).
+				Bold(true).
+				Padding(0, 2)
+		} else {
+			msgStyle = m.theme.Renderer.NewStyle().
+				Background(ColorStatusOpenBg).
+				Foreground(ColorSuccess).
+				Bold(true).
+				Padding(0, 2)
+		}
+		msg := "✓ " + m.statusMsg
+		for len(left) > 1 && lipgloss.Width(strings.Join(left, ""))+lipgloss.Width(msg)+4 > m.width {
+			left = left[:len(left)-1]
+		}
+		msg = truncateToWidth(msg, max(m.width-lipgloss.Width(strings.Join(left, ""))-4, 1), "…")
+		right = []string{msgStyle.Render(msg)}
+	}
 
-	// ─────────────────────────────────────────────────────────────────────────
-	// COUNT BADGE - Total issues displayed
-	// ─────────────────────────────────────────────────────────────────────────
-	countBadge := lipgloss.NewStyle().
-		Foreground(ColorSecondary).
-		Padding(0, 1).
-		Render(fmt.Sprintf("%d issues", len(m.list.Items())))
+	remaining := max(m.width-lipgloss.Width(strings.Join(left, ""))-lipgloss.Width(strings.Join(right, ""))-1, 0)
+	filler := m.theme.Renderer.NewStyle().Background(ColorBgDark).Width(remaining).Render("")
 
-	// ─────────────────────────────────────────────────────────────────────────
-	// ASSEMBLE FOOTER with proper spacing
-	// ─────────────────────────────────────────────────────────────────────────
-	leftWidth := lipgloss.Width(filterBadge) + lipgloss.Width(statsSection)
-	if updateSection != "" {
-		leftWidth += lipgloss.Width(updateSection) + 1
-	}
-	if workspaceSection != "" {
-		leftWidth += lipgloss.Width(workspaceSection) + 1
-	}
-	rightWidth := lipgloss.Width(countBadge) + lipgloss.Width(keysSection)
+	parts := append(append(left, filler), right...)
+	return lipgloss.JoinHorizontal(lipgloss.Bottom, parts...)
+}
 
-	remaining := m.width - leftWidth - rightWidth - 1
-	if remaining < 0 {
-		remaining = 0
+// visibleIssueCount counts the issues the list shows, leaving out section
+// headers and issues hidden by the search
+func (m *Model) visibleIssueCount() int {
+	n := 0
+	for _, item := range m.list.VisibleItems() {
+		if _, ok := item.(IssueItem); ok {
+			n++
+		}
 	}
-	filler := lipgloss.NewStyle().Background(ColorBgDark).Width(remaining).Render("")
+	return n
+}
 
-	// Build the footer
+// backgroundWorkText describes the work running in the background, e.g.
+// "⟳ reloading · ⠹ betweenness 40/200", or "" when there is none
+func (m *Model) backgroundWorkText() string {
 	var parts []string
-	parts = append(parts, filterBadge)
-	if workspaceSection != "" {
-		parts = append(parts, workspaceSection)
+	if m.reloading {
+		parts = append(parts, "⟳ reloading")
 	}
-	if updateSection != "" {
-		parts = append(parts, updateSection)
+	if text := m.phase2ProgressText(); text != "" {
+		parts = append(parts, text)
 	}
-	parts = append(parts, statsSection, filler, countBadge, keysSection)
+	return strings.Join(parts, " · ")
+}
 
-	return lipgloss.JoinHorizontal(lipgloss.Bottom, parts...)
+// spinnerFrames animate the Phase 2 progress badge
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// phase2ProgressText describes the running background analysis, e.g.
+// "⠹ betweenness 40/200", or "" once Phase 2 is complete
+func (m *Model) phase2ProgressText() string {
+	if m.analysis == nil || m.analysis.IsPhase2Ready() {
+		return ""
+	}
+	text := spinnerFrames[m.phase2Frame%len(spinnerFrames)] + " "
+	p := m.analysis.Progress()
+	switch {
+	case p.Stage == "":
+		text += "metrics"
+	case p.Total > 0:
+		text += fmt.Sprintf("%s %d/%d", p.Stage, p.Done, p.Total)
+	default:
+		text += p.Stage
+	}
+	return text
 }
 
 // getDiffStatus returns the diff status for an issue if time-travel mode is active
@@ -1864,8 +4429,18 @@ func (m Model) getDiffStatus(id string) DiffStatus {
 func (m *Model) applyFilter() {
 	var filteredItems []list.Item
 	var filteredIssues []model.Issue
+	now := time.Now()
+	var conn analysis.Connectivity
+	if m.currentFilter == "islands" || m.currentFilter == "detached" {
+		conn = analysis.ComputeConnectivity(m.issues)
+	}
+	scope := m.scopeSet()
+	teams := analysis.CurrentTeams()
 
 	for _, issue := range m.issues {
+		if scope != nil && !scope[issue.ID] {
+			continue
+		}
 		include := false
 		switch m.currentFilter {
 		case "all":
@@ -1876,34 +4451,41 @@ func (m *Model) applyFilter() {
 			include = issue.Status == model.StatusClosed
 		case "ready":
 			// Ready = Open/InProgress AND No Open Blockers
-			if issue.Status != model.StatusClosed && issue.Status != model.StatusBlocked {
-				isBlocked := false
-				for _, dep := range issue.Dependencies {
-					if dep.Type == model.DepBlocks {
-						if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-							isBlocked = true
-							break
-						}
-					}
-				}
-				include = !isBlocked
+			include = m.isReady(issue)
+		case "due":
+			include = issue.IsDueWithin(now, 7*24*time.Hour)
+		case "overdue":
+			include = issue.IsOverdue(now)
+		case "zero_slack":
+			s, ok := m.issueSlack()[issue.ID]
+			include = ok && s.Critical()
+		case "islands":
+			include = conn.IsIsland(issue.ID)
+		case "detached":
+			include = conn.IsDetached(issue.ID) && !issue.Status.IsClosed()
+		default:
+			if strings.HasPrefix(m.currentFilter, labelFilterPrefix) {
+				include = issueHasLabel(issue, strings.TrimPrefix(m.currentFilter, labelFilterPrefix))
+			} else if strings.HasPrefix(m.currentFilter, sprintFilterPrefix) {
+				include = issue.SprintName() == strings.TrimPrefix(m.currentFilter, sprintFilterPrefix)
+			} else if strings.HasPrefix(m.currentFilter, milestoneFilterPrefix) {
+				include = issue.MilestoneName() == strings.TrimPrefix(m.currentFilter, milestoneFilterPrefix)
+			} else if strings.HasPrefix(m.currentFilter, assigneeFilterPrefix) {
+				include = issue.Assignee == strings.TrimPrefix(m.currentFilter, assigneeFilterPrefix)
+			} else if strings.HasPrefix(m.currentFilter, teamFilterPrefix) {
+				include = teams.TeamOf(issue.Assignee) == strings.TrimPrefix(m.currentFilter, teamFilterPrefix)
 			}
 		}
 
 		if include {
-			// Use pre-computed graph scores (avoid redundant calculation)
-			filteredItems = append(filteredItems, IssueItem{
-				Issue:      issue,
-				GraphScore: m.analysis.GetPageRankScore(issue.ID),
-				Impact:     m.analysis.GetCriticalPathScore(issue.ID),
-				DiffStatus: m.getDiffStatus(issue.ID),
-				RepoPrefix: ExtractRepoPrefix(issue.ID),
-			})
+			filteredItems = append(filteredItems, m.issueItem(issue))
 			filteredIssues = append(filteredIssues, issue)
 		}
 	}
 
+	filteredItems = m.arrangeListItems(filteredItems)
 	m.list.SetItems(filteredItems)
+	m.refitListColumns()
 	m.board.SetIssues(filteredIssues)
 	// Generate insights for graph view (for metric rankings and sorting)
 	filterIns := m.analysis.GenerateInsights(len(filteredIssues))
@@ -1924,8 +4506,12 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 
 	var filteredItems []list.Item
 	var filteredIssues []model.Issue
+	scope := m.scopeSet()
 
 	for _, issue := range m.issues {
+		if scope != nil && !scope[issue.ID] {
+			continue
+		}
 		include := true
 
 		// Apply status filter
@@ -1971,7 +4557,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			// Check if issue is blocked
 			isBlocked := false
 			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks {
+				if dep.Type.IsBlocking() {
 					if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
 						isBlocked = true
 						break
@@ -1982,13 +4568,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 		}
 
 		if include {
-			filteredItems = append(filteredItems, IssueItem{
-				Issue:      issue,
-				GraphScore: m.analysis.GetPageRankScore(issue.ID),
-				Impact:     m.analysis.GetCriticalPathScore(issue.ID),
-				DiffStatus: m.getDiffStatus(issue.ID),
-				RepoPrefix: ExtractRepoPrefix(issue.ID),
-			})
+			filteredItems = append(filteredItems, m.issueItem(issue))
 			filteredIssues = append(filteredIssues, issue)
 		}
 	}
@@ -2048,7 +4628,10 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 		})
 	}
 
+	// An explicit user sort takes precedence over the recipe's ordering
+	filteredItems = m.arrangeListItems(filteredItems)
 	m.list.SetItems(filteredItems)
+	m.refitListColumns()
 	m.board.SetIssues(filteredIssues)
 	// Generate insights for graph view (for metric rankings and sorting)
 	recipeIns := m.analysis.GenerateInsights(len(filteredIssues))
@@ -2064,17 +4647,142 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 	m.updateViewportContent()
 }
 
+// issueItem builds the list item for issue from the current analysis,
+// with the values of any configured scripts
+func (m *Model) issueItem(issue model.Issue) IssueItem {
+	item := IssueItem{
+		Issue:      issue,
+		GraphScore: m.analysis.GetPageRankScore(issue.ID),
+		Impact:     m.analysis.GetCriticalPathScore(issue.ID),
+		DiffStatus: m.getDiffStatus(issue.ID),
+		RepoPrefix: ExtractRepoPrefix(issue.ID),
+	}
+	if !m.scripts.Empty() {
+		m.scripts.Bind(m.issues, m.analysis)
+		item.Script = m.scripts.Eval(issue)
+		item.Impact = m.scripts.ImpactScore(issue, item.Impact)
+	}
+	return item
+}
+
+// arrangeListItems applies the active sort and grouping to freshly filtered items
+func (m *Model) arrangeListItems(items []list.Item) []list.Item {
+	m.sortListItems(items)
+	var clusters *analysis.Clusters
+	if m.groupBy == GroupCluster {
+		clusters = m.detectClusters()
+	}
+	return groupIssueItems(items, m.groupBy, m.issueMap, clusters, m.collapsedGroups)
+}
+
+// detectClusters returns the communities of linked work among every issue,
+// detecting them on first use
+func (m *Model) detectClusters() *analysis.Clusters {
+	if m.clusters == nil {
+		clusters := analysis.DetectClusters(m.issues)
+		m.clusters = &clusters
+	}
+	return m.clusters
+}
+
+// cycleGroupBy advances to the next grouping mode and rebuilds the list
+func (m *Model) cycleGroupBy() {
+	m.groupBy = m.groupBy.Next()
+	m.rebuildListWithDiffInfo()
+	if m.groupBy == GroupNone {
+		m.statusMsg = "Grouping off"
+	} else if m.groupBy == GroupCluster {
+		clusters := m.detectClusters()
+		m.statusMsg = fmt.Sprintf("Grouped by Cluster: %d cluster(s) of linked work (modularity %.2f)", len(clusters.Clusters), clusters.Modularity)
+	} else {
+		m.statusMsg = "Grouped by " + m.groupBy.String()
+	}
+	m.statusIsError = false
+}
+
+// toggleSelectedGroup collapses or expands the section under the cursor.
+// Returns false if the cursor is not on a section header.
+func (m *Model) toggleSelectedGroup() bool {
+	header, ok := m.list.SelectedItem().(GroupHeaderItem)
+	if !ok {
+		return false
+	}
+	m.collapsedGroups[header.Key] = !m.collapsedGroups[header.Key]
+	m.rebuildListWithDiffInfo()
+	// Keep the cursor on the toggled header
+	for i, item := range m.list.Items() {
+		if h, ok := item.(GroupHeaderItem); ok && h.Key == header.Key {
+			m.list.Select(i)
+			break
+		}
+	}
+	m.updateViewportContent()
+	return true
+}
+
+// listHeaderIndicator describes the active sort and grouping for the list header
+func (m Model) listHeaderIndicator() string {
+	parts := []string{}
+	if m.groupBy != GroupNone {
+		parts = append(parts, "by "+m.groupBy.String())
+	}
+	if label := m.sortMode.Label(); label != "" {
+		parts = append(parts, label)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// GroupBy returns the active list grouping (exposed for testing)
+func (m Model) GroupBy() GroupBy {
+	return m.groupBy
+}
+
+// sortListItems orders list items by the active sort mode (no-op for default)
+func (m *Model) sortListItems(items []list.Item) {
+	sortIssueItems(items, m.sortMode,
+		func(id string) int { return countOpenBlockers(m.issueMap[id], m.issueMap) },
+		func(id string) int { return m.analysis.InDegree[id] },
+	)
+}
+
+// SortMode returns the active list sort (exposed for testing)
+func (m Model) SortMode() SortMode {
+	return m.sortMode
+}
+
+// SetSortMode sets the list sort and re-applies it (exposed for testing)
+func (m *Model) SetSortMode(mode SortMode) {
+	m.sortMode = mode
+	m.rebuildListWithDiffInfo()
+}
+
 func (m *Model) updateViewportContent() {
 	selectedItem := m.list.SelectedItem()
 	if selectedItem == nil {
-		m.viewport.SetContent("No issues selected")
+		m.setDetailContent("No issues selected")
+		return
+	}
+
+	// Section headers show a summary of their group
+	if header, ok := selectedItem.(GroupHeaderItem); ok {
+		state := "expanded"
+		if header.Collapsed {
+			state = "collapsed"
+		}
+		md := fmt.Sprintf("## %s\n\n**%d** issues grouped by %s (%s).\n\nPress **enter** or **z** to expand/collapse, **Z** to change grouping.\n",
+			header.Label, header.Count, strings.ToLower(m.groupBy.String()), state)
+		if rendered, err := m.renderer.Render(md); err == nil {
+			m.setDetailContent(rendered)
+		} else {
+			m.setDetailContent(md)
+		}
 		return
 	}
 
 	// Safe type assertion
 	issueItem, ok := selectedItem.(IssueItem)
 	if !ok {
-		m.viewport.SetContent("Error: invalid item type")
+		m.setDetailContent("Error: invalid item type")
 		return
 	}
 	item := issueItem.Issue
@@ -2095,8 +4803,10 @@ func (m *Model) updateViewportContent() {
 		strings.ToUpper(string(item.Status)),
 		GetPriorityIcon(item.Priority),
 		item.Assignee,
-		item.CreatedAt.Format("2006-01-02"),
+		timefmt.Date(item.CreatedAt),
 	))
+	// Due/label badges are spliced in after the meta table (glamour would strip their colors)
+	metaEnd := sb.Len()
 
 	// Graph Analysis (using thread-safe accessors)
 	pr := m.analysis.GetPageRankScore(item.ID)
@@ -2107,14 +4817,21 @@ func (m *Model) updateViewportContent() {
 	auth := m.analysis.GetAuthorityScore(item.ID)
 
 	sb.WriteString("### Graph Analysis\n")
-	sb.WriteString(fmt.Sprintf("- **Impact Depth**: %.0f (downstream chain length)\n", imp))
+	sb.WriteString(fmt.Sprintf("- **Impact Depth**: %.1f (downstream chain length, weighted by estimates)\n", imp))
 	sb.WriteString(fmt.Sprintf("- **Centrality**: PR %.4f • BW %.4f • EV %.4f\n", pr, bt, ev))
-	sb.WriteString(fmt.Sprintf("- **Flow Role**: Hub %.4f • Authority %.4f\n\n", hub, auth))
+	sb.WriteString(fmt.Sprintf("- **Flow Role**: Hub %.4f • Authority %.4f\n", hub, auth))
+	sb.WriteString(m.slackMarkdown(item.ID))
+	sb.WriteString(m.teamMarkdown(item))
+	sb.WriteString("\n")
+
+	// Effort (estimate rollups)
+	sb.WriteString(m.effortMarkdown(item.ID))
 
-	// Description
+	// Description, marking the task list item x toggles
+	checklist := analysis.ParseChecklist(item.Description)
 	if item.Description != "" {
 		sb.WriteString("### Description\n")
-		sb.WriteString(item.Description + "\n\n")
+		sb.WriteString(m.checklistDescription(item.ID, item.Description, checklist) + "\n\n")
 	}
 
 	// Acceptance Criteria
@@ -2129,6 +4846,9 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString(item.Notes + "\n\n")
 	}
 
+	// Fields added by other tools
+	sb.WriteString(m.customFieldsMarkdown(item))
+
 	// Dependency Graph (Tree)
 	if len(item.Dependencies) > 0 {
 		rootNode := BuildDependencyTree(item.ID, m.issueMap, 3) // Max depth 3
@@ -2136,6 +4856,21 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString("```\n" + treeStr + "```\n\n")
 	}
 
+	// Non-blocking links, apart from the dependency graph
+	sb.WriteString(m.relatedMarkdown(item.ID))
+
+	// Time spent in each status
+	sb.WriteString(statusTimelineMarkdown(m.withStatusHistory([]model.Issue{item})[0], time.Now()))
+
+	// Commits mentioning this issue
+	sb.WriteString(m.commitsMarkdown(item.ID))
+
+	// Images and files the issue references
+	sb.WriteString(m.attachmentsMarkdown(item))
+
+	// URLs and other issues mentioned in the text
+	sb.WriteString(m.linksMarkdown(item))
+
 	// Comments
 	if len(item.Comments) > 0 {
 		sb.WriteString(fmt.Sprintf("### Comments (%d)\n", len(item.Comments)))
@@ -2147,12 +4882,143 @@ func (m *Model) updateViewportContent() {
 		}
 	}
 
-	rendered, err := m.renderer.Render(sb.String())
+	md := sb.String()
+	var badges []string
+	if item.DueDate != nil {
+		dueDate := m.theme.Renderer.NewStyle().Foreground(ColorMuted).Render("due " + timefmt.Day(*item.DueDate))
+		if badge := RenderDueBadge(m.theme.Renderer, &item, time.Now()); badge != "" {
+			badges = append(badges, badge+" "+dueDate)
+		} else {
+			badges = append(badges, dueDate)
+		}
+	}
+	if len(item.Labels) > 0 {
+		chipWidth := m.viewport.Width - 4
+		if chipWidth < 20 {
+			chipWidth = 76 // viewport not sized yet
+		}
+		badges = append(badges, RenderLabelChips(m.theme.Renderer, item.Labels, chipWidth))
+	}
+	if len(checklist) > 0 {
+		badges = append(badges, m.theme.Renderer.NewStyle().Foreground(ColorMuted).Render("checklist ")+
+			renderChecklistProgress(m.theme, checklist, 10))
+	}
+	if badge := m.timeSpentBadge(item.ID); badge != "" {
+		badges = append(badges, badge)
+	}
+	if len(badges) == 0 {
+		rendered, err := m.renderer.Render(md)
+		if err != nil {
+			m.setDetailContent(fmt.Sprintf("Error rendering markdown: %v", err))
+		} else {
+			m.setDetailContent(rendered)
+		}
+		return
+	}
+
+	head, err := m.renderer.Render(md[:metaEnd])
 	if err != nil {
-		m.viewport.SetContent(fmt.Sprintf("Error rendering markdown: %v", err))
-	} else {
-		m.viewport.SetContent(rendered)
+		m.setDetailContent(fmt.Sprintf("Error rendering markdown: %v", err))
+		return
+	}
+	body, err := m.renderer.Render(md[metaEnd:])
+	if err != nil {
+		m.setDetailContent(fmt.Sprintf("Error rendering markdown: %v", err))
+		return
+	}
+	m.setDetailContent(strings.TrimRight(head, "\n") + "\n\n  " + strings.Join(badges, "\n  ") + "\n" + body)
+}
+
+// sprintBadgeText describes the scoped sprint and its progress for the status bar
+func (m Model) sprintBadgeText(name string) string {
+	for _, s := range analysis.ComputeSprintStats(m.issues) {
+		if s.Name == name {
+			return fmt.Sprintf("%s %.0f%% (%s)", strings.ToUpper(name), s.Progress()*100, FormatSprintProgress(s))
+		}
+	}
+	return strings.ToUpper(name)
+}
+
+// effortMarkdown renders the estimate and its epic/chain rollups for the detail view.
+// Returns "" when there is nothing to show.
+func (m *Model) effortMarkdown(id string) string {
+	if m.effort == nil {
+		m.effort = analysis.ComputeEffortRollups(m.issues)
+	}
+	r, ok := m.effort[id]
+	if !ok {
+		return ""
+	}
+
+	unestimated := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return fmt.Sprintf(" (+%d unestimated)", n)
+	}
+
+	var lines []string
+	if r.Estimated {
+		lines = append(lines, fmt.Sprintf("- **Estimate**: %s", FormatMinutes(r.OwnMinutes)))
+	}
+	if r.TreeSize > 1 {
+		lines = append(lines, fmt.Sprintf("- **Tree**: %s total • %s remaining across %d issues%s",
+			FormatMinutes(r.TreeMinutes), FormatMinutes(r.TreeRemainingMinutes), r.TreeSize, unestimated(r.TreeUnestimated)))
+	}
+	if r.ChainSize > 1 {
+		lines = append(lines, fmt.Sprintf("- **Chain**: %s until done, including %d open blockers%s",
+			FormatMinutes(r.ChainMinutes), r.ChainSize-1, unestimated(r.ChainUnestimated)))
+	}
+	if len(lines) == 0 || (!r.Estimated && r.TreeMinutes == 0 && r.ChainMinutes == 0) {
+		return ""
+	}
+	return "### Effort\n" + strings.Join(lines, "\n") + "\n\n"
+}
+
+// issueSlack returns the slack of every open issue, computing it on first use
+func (m *Model) issueSlack() map[string]analysis.Slack {
+	if m.slack == nil {
+		m.slack = analysis.ComputeSlack(m.issues)
+	}
+	return m.slack
+}
+
+// slackMarkdown renders the slack bullet of the detail view's Graph Analysis.
+// Returns "" for issues without slack (closed, epics, stuck behind a cycle).
+func (m *Model) slackMarkdown(id string) string {
+	s, ok := m.issueSlack()[id]
+	if !ok {
+		return ""
+	}
+	against := "the rest of the open work"
+	if s.Epic != "" {
+		against = "epic " + s.Epic
+	}
+	if s.Critical() {
+		return fmt.Sprintf("- **Slack**: none, any slip delays %s\n", against)
+	}
+	return fmt.Sprintf("- **Slack**: can slip %s without delaying %s\n", FormatSlack(s.Minutes), against)
+}
+
+// teamMarkdown renders the team bullet of the detail view's Graph Analysis,
+// flagging open blockers owned by other teams. Returns "" when the assignee
+// is in no team.
+func (m *Model) teamMarkdown(issue model.Issue) string {
+	teams := analysis.CurrentTeams()
+	team := teams.TeamOf(issue.Assignee)
+	if team == "" {
+		return ""
+	}
+	var waits []string
+	for _, b := range m.openBlockers(issue) {
+		if other := teams.TeamOf(b.Assignee); other != "" && other != team && !issue.Status.IsClosed() {
+			waits = append(waits, fmt.Sprintf("%s (@%s, %s)", b.ID, b.Assignee, other))
+		}
+	}
+	if len(waits) == 0 {
+		return fmt.Sprintf("- **Team**: %s\n", team)
 	}
+	return fmt.Sprintf("- **Team**: %s, ⚠ blocked by other teams: %s\n", team, strings.Join(waits, ", "))
 }
 
 // GetTypeIconMD returns the emoji icon for an issue type (for markdown)
@@ -2183,14 +5049,128 @@ func (m *Model) SetFilter(f string) {
 func (m Model) FilteredIssues() []model.Issue {
 	items := m.list.Items()
 	issues := make([]model.Issue, 0, len(items))
+	seen := make(map[string]bool, len(items))
 	for _, item := range items {
-		if issueItem, ok := item.(IssueItem); ok {
+		// Label grouping can list an issue in several sections
+		if issueItem, ok := item.(IssueItem); ok && !seen[issueItem.Issue.ID] {
+			seen[issueItem.Issue.ID] = true
 			issues = append(issues, issueItem.Issue)
 		}
 	}
 	return issues
 }
 
+// SetRemoteTerminal marks the model as serving a remote session whose
+// terminal is w, so clipboard and browser actions target the viewer's machine
+func (m *Model) SetRemoteTerminal(w io.Writer) {
+	m.remoteTerm = w
+}
+
+// clearViews closes every full-screen view, leaving the list (or the split
+// view) showing. The views are mutually exclusive, so every toggle goes
+// through here or toggleView.
+func (m *Model) clearViews() {
+	m.isBoardView = false
+	m.isGraphView = false
+	m.isActionableView = false
+	m.isMilestoneView = false
+	m.isBurndownView = false
+	m.isFlowView = false
+	m.isVelocityView = false
+	m.isWorkloadView = false
+	m.isDuplicatesView = false
+	m.isProblemsView = false
+	m.isActivityView = false
+	m.isCouplingView = false
+	m.isDSMView = false
+	m.isRiskView = false
+	m.isScheduleView = false
+	m.isAgingView = false
+	m.isLabelsView = false
+	m.isLineageView = false
+	m.isWorklogView = false
+	m.isCompareView = false
+}
+
+// toggleView shows the view whose flag is view, closing any other, or closes
+// it when it's already showing
+func (m *Model) toggleView(view *bool) {
+	show := !*view
+	m.clearViews()
+	*view = show
+}
+
+// patchIssue applies a change made through bd to the issue id right away,
+// ahead of the reload that picks it up
+func (m *Model) patchIssue(id string, patch func(*model.Issue)) {
+	if issue, ok := m.issueMap[id]; ok {
+		patch(issue)
+	}
+	for i, listItem := range m.list.Items() {
+		if item, ok := listItem.(IssueItem); ok && item.Issue.ID == id {
+			patch(&item.Issue)
+			m.list.SetItem(i, item)
+		}
+	}
+	m.updateViewportContent()
+}
+
+// refuseRemoteEdit reports whether this is a remote session, where the issues
+// are read-only, setting an error status if so
+func (m *Model) refuseRemoteEdit() bool {
+	if m.remoteTerm == nil {
+		return false
+	}
+	m.statusMsg = "❌ Issues are read-only in a remote session"
+	m.statusIsError = true
+	return true
+}
+
+// SetReadOnly marks the issues as loaded from somewhere bd can't write back
+// to, such as a directory of Markdown files; reason completes "Issues are
+// read-only when …" in the status shown for a refused edit
+func (m *Model) SetReadOnly(reason string) {
+	m.readOnly = reason
+}
+
+// refuseReadOnly reports whether the issues are read-only (SetReadOnly),
+// setting an error status if so
+func (m *Model) refuseReadOnly() bool {
+	if m.readOnly == "" {
+		return false
+	}
+	m.statusMsg = "❌ Issues are read-only when " + m.readOnly
+	m.statusIsError = true
+	return true
+}
+
+// refuseRemoteExport reports whether this is a remote session, where exports
+// would write files into the server's working directory, setting an error
+// status if so
+func (m *Model) refuseRemoteExport() bool {
+	if m.remoteTerm == nil {
+		return false
+	}
+	m.statusMsg = "❌ Exports are disabled in a remote session"
+	m.statusIsError = true
+	return true
+}
+
+// refuseEdit reports whether the issues can't be changed through bd, setting
+// an error status if so: in a remote session, when read-only, or with no
+// beads file, where bd would run in whatever directory bv was started from
+func (m *Model) refuseEdit() bool {
+	if m.refuseRemoteEdit() || m.refuseReadOnly() {
+		return true
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "❌ Issues are read-only without a beads file"
+		m.statusIsError = true
+		return true
+	}
+	return false
+}
+
 // EnableWorkspaceMode configures the model for workspace (multi-repo) view
 func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	m.workspaceMode = info.Enabled
@@ -2206,12 +5186,202 @@ func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	}
 
 	// Update delegate to show repo badges
-	m.list.SetDelegate(IssueDelegate{
+	m.list.SetDelegate(m.issueDelegate())
+}
+
+// issueDelegate returns the list renderer for the current display settings
+func (m *Model) issueDelegate() IssueDelegate {
+	fit := m.listFit
+	return IssueDelegate{
 		Theme:             m.theme,
 		ShowPriorityHints: m.showPriorityHints,
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
-	})
+		Columns:           m.listColumns,
+		ScriptColumns:     m.scriptColumnNames(),
+		FieldColumns:      m.fieldColumns,
+		Fit:               &fit,
+		Lines:             m.cardLines,
+		IssueMap:          m.issueMap,
+		Unblocks:          m.listUnblocks,
+		Density:           m.density,
+	}
+}
+
+// rowHeight is the lines each list item takes, the gap after it included
+func (m *Model) rowHeight() int {
+	d := m.issueDelegate()
+	return d.Height() + d.Spacing()
+}
+
+// refitListColumns fits the list's columns to the issues it now holds
+func (m *Model) refitListColumns() {
+	m.listFit = fitColumns(m.list.Items())
+	if m.cardLines > 1 {
+		m.listUnblocks = openDependents(m.issueMap)
+	}
+	m.list.SetDelegate(m.issueDelegate())
+}
+
+// scriptColumnNames returns the headers of the computed list columns
+func (m *Model) scriptColumnNames() []string {
+	if m.scripts == nil {
+		return nil
+	}
+	names := make([]string, len(m.scripts.Columns))
+	for i, c := range m.scripts.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// SetScripts installs user scripts (nil for none) and recomputes the list.
+// A script that fails on some issues is reported in the status bar.
+func (m *Model) SetScripts(e *script.Engine) {
+	if e == nil && m.scripts == nil {
+		return
+	}
+	m.scripts = e
+	m.sortPicker.SetCustomSort(e != nil && e.Sort != nil)
+	if m.sortMode.Field == SortCustom && (e == nil || e.Sort == nil) {
+		m.sortMode = SortMode{}
+	}
+	m.list.SetDelegate(m.issueDelegate())
+	m.rebuildListWithDiffInfo()
+	if n, err := m.scripts.Errors(); n > 0 {
+		m.statusMsg = fmt.Sprintf("⚠️ %v (%d failed evaluations)", err, n)
+		m.statusIsError = true
+	}
+}
+
+// acceptsGlobalKeys reports whether a keypress would reach the top-level
+// shortcuts rather than a text field or menu
+func (m *Model) acceptsGlobalKeys() bool {
+	return m.list.FilterState() != list.Filtering && !m.pager.searching &&
+		m.focused != focusTimeTravelInput && !m.showQuitConfirm && !m.showQuickLook && !m.showAttachmentPreview && !m.isCompareView &&
+		!m.showRecipePicker && !m.showSortPicker && !m.showLabelPicker && !m.showSprintPicker && !m.showAssigneePicker &&
+		!m.showCreateForm && !m.showCommentComposer && !m.showFocusMode && !m.showConflictResolver &&
+		!m.showMergeAssistant
+}
+
+// SetKeyTranslation rebinds keys: each key in t acts as the default key it
+// maps to (for example "v" -> "b" opens the board with v)
+func (m *Model) SetKeyTranslation(t map[string]string) {
+	m.keyTranslation = t
+}
+
+// SetListColumns limits the list's optional columns to cols (due, age,
+// comments, checklist, assignee, labels); nil shows every column that fits
+func (m *Model) SetListColumns(cols []string) {
+	m.listColumns = cols
+	m.list.SetDelegate(m.issueDelegate())
+}
+
+// SetDensity sets how tightly the list packs its rows: compact,
+// comfortable or spacious
+func (m *Model) SetDensity(name string) error {
+	d, err := ParseDensity(name)
+	if err != nil {
+		return err
+	}
+	m.density = d
+	m.list.SetDelegate(m.issueDelegate())
+	return nil
+}
+
+// SetCardLines sets the lines per list row: 1, or 2 or 3 for cards. The
+// list refits, its page holding fewer issues as cards grow.
+func (m *Model) SetCardLines(n int) {
+	m.cardLines = min(max(n, 1), 3)
+	m.refitListColumns()
+}
+
+// SetDefaultView opens the model on the named view: list, board, graph or
+// insights
+func (m *Model) SetDefaultView(view string) error {
+	switch view {
+	case "", "list":
+	case "board":
+		m.isBoardView = true
+		m.focused = focusBoard
+	case "graph":
+		m.isGraphView = true
+		m.focused = focusGraph
+	case "insights":
+		m.focused = focusInsights
+	default:
+		return fmt.Errorf("unknown view %q", view)
+	}
+	return nil
+}
+
+// SetIssueURLTemplate replaces the issue URL template from .bv/links.yaml;
+// an empty text leaves it unchanged
+func (m *Model) SetIssueURLTemplate(text string) error {
+	tmpl, err := ParseIssueURLTemplate(text)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		m.issueURL = tmpl
+	}
+	return nil
+}
+
+// SetFileWatch sets how live reload notices the beads file changing: mode
+// auto uses fsnotify except on network filesystems (NFS, SMB, SSHFS), where
+// it polls; notify always uses fsnotify and poll always polls. Polling
+// checks every interval, comparing contents too when hash is set. It
+// replaces the watcher, so call it before the program starts.
+func (m *Model) SetFileWatch(mode string, interval time.Duration, hash bool) error {
+	opts := []watcher.WatcherOption{
+		watcher.WithDebounceDuration(200 * time.Millisecond),
+		watcher.WithHashCheck(hash),
+	}
+	if interval > 0 {
+		opts = append(opts, watcher.WithPollInterval(interval))
+	}
+	switch mode {
+	case "", "auto":
+	case "notify":
+		opts = append(opts, watcher.WithNetworkFSDetection(false))
+	case "poll":
+		opts = append(opts, watcher.WithForcePoll(true))
+	default:
+		return fmt.Errorf("unknown watch mode %q (known: auto, notify, poll)", mode)
+	}
+	if m.watcher == nil {
+		return nil // No beads file, or live reload is already unavailable
+	}
+
+	w, err := watcher.NewWatcher(m.beadsPath, opts...)
+	if err == nil {
+		err = w.Start()
+	}
+	if err != nil {
+		return fmt.Errorf("live reload: %w", err)
+	}
+	m.watcher.Stop()
+	m.watcher = w
+	// Say why when polling wasn't asked for
+	if mode != "poll" && w.IsPolling() && m.statusMsg == "" {
+		m.statusMsg = fmt.Sprintf("Live reload: polling every %s (%s)", w.PollInterval(), w.PollReason())
+		m.statusIsError = false
+	}
+	return nil
+}
+
+// ReportSkippedLines shows a warning for lines the loader couldn't read
+func (m *Model) ReportSkippedLines(skipped []loader.LineError) {
+	if len(skipped) == 0 {
+		return
+	}
+	m.statusMsg = fmt.Sprintf("⚠️ Skipped %d unreadable lines in beads file (first: %v) · P for details", len(skipped), skipped[0])
+	if loader.HasConflictMarkers(skipped) {
+		m.statusMsg = "⚠️ The beads file has git conflict markers from a merge · alt+m to resolve, P for details"
+	}
+	m.statusIsError = true
+	m.dataProblems = append(skippedLineProblems(skipped), m.dataProblems...)
 }
 
 // IsWorkspaceMode returns whether workspace mode is active
@@ -2287,6 +5457,29 @@ func (m *Model) enterTimeTravelMode(revision string) {
 	m.rebuildListWithDiffInfo()
 }
 
+// enterSnapshot starts loading the issues as of spec from git history; the
+// IssuesReloadedMsg it produces swaps them in
+func (m *Model) enterSnapshot(spec string) tea.Cmd {
+	repoDir := beadsProjectDir(m.beadsPath)
+	if repoDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			m.statusMsg = "❌ Time-travel failed: cannot get working directory"
+			m.statusIsError = true
+			return nil
+		}
+		repoDir = cwd
+	}
+	today := m.issues
+	if m.snapshot != nil {
+		today = m.snapshot.today
+	}
+	m.statusMsg = fmt.Sprintf("🕰 Loading issues as of %s…", spec)
+	m.statusIsError = false
+	m.reloading = true
+	return SnapshotCmd(repoDir, spec, today, m.currentLoad())
+}
+
 // exitTimeTravelMode clears time-travel state
 func (m *Model) exitTimeTravelMode() {
 	m.timeTravelMode = false
@@ -2325,23 +5518,33 @@ func (m Model) TimeTravelDiff() *analysis.SnapshotDiff {
 
 // exportToMarkdown exports all issues to a Markdown file with auto-generated filename
 func (m *Model) exportToMarkdown() {
+	if m.refuseRemoteExport() {
+		return
+	}
+
 	// Generate smart filename: beads_report_<project>_YYYY-MM-DD.md
 	filename := m.generateExportFilename()
 
-	// Export the issues
-	err := export.SaveMarkdownToFile(m.issues, filename)
+	// Export the issues in scope
+	issues := m.scopedIssues()
+	err := export.SaveMarkdownToFile(issues, filename)
 	if err != nil {
 		m.statusMsg = fmt.Sprintf("❌ Export failed: %v", err)
 		m.statusIsError = true
 		return
 	}
 
-	m.statusMsg = fmt.Sprintf("✅ Exported %d issues to %s", len(m.issues), filename)
+	m.statusMsg = fmt.Sprintf("✅ Exported %d issues to %s", len(issues), filename)
 	m.statusIsError = false
 }
 
 // generateExportFilename creates a smart filename based on project and date
 func (m *Model) generateExportFilename() string {
+	return m.exportFilename("report", "md")
+}
+
+// exportFilename names an export beads_<kind>_<project>_YYYY-MM-DD.<ext>
+func (m *Model) exportFilename(kind, ext string) string {
 	// Get project name from current directory
 	projectName := "beads"
 	if cwd, err := os.Getwd(); err == nil {
@@ -2355,9 +5558,8 @@ func (m *Model) generateExportFilename() string {
 		}, projectName)
 	}
 
-	// Format: beads_report_<project>_YYYY-MM-DD.md
 	timestamp := time.Now().Format("2006-01-02")
-	return fmt.Sprintf("beads_report_%s_%s.md", projectName, timestamp)
+	return fmt.Sprintf("beads_%s_%s_%s.%s", kind, projectName, timestamp, ext)
 }
 
 // renderTimeTravelPrompt renders the time-travel revision input overlay
@@ -2389,11 +5591,15 @@ func (m Model) renderTimeTravelPrompt() string {
 		Foreground(t.Base.GetForeground())
 
 	// Build content
-	content := titleStyle.Render("⏱️  Time-Travel Mode") + "\n\n" +
-		subtitleStyle.Render("Compare current state with a historical revision") + "\n\n" +
+	title, subtitle, action := "⏱️  Time-Travel Mode", "Compare current state with a historical revision", " to compare, "
+	if m.timeTravelAsOf {
+		title, subtitle, action = "🕰  View As Of", "Show the issues, statuses and graph as they were then", " to view, "
+	}
+	content := titleStyle.Render(title) + "\n\n" +
+		subtitleStyle.Render(subtitle) + "\n\n" +
 		m.timeTravelInput.View() + "\n\n" +
 		exampleStyle.Render("Examples: HEAD~5, main, v1.0.0, 2024-01-01, abc123") + "\n\n" +
-		textStyle.Render("Press ") + keyStyle.Render("Enter") + textStyle.Render(" to compare, ") +
+		textStyle.Render("Press ") + keyStyle.Render("Enter") + textStyle.Render(action) +
 		keyStyle.Render("Esc") + textStyle.Render(" to cancel")
 
 	box := boxStyle.Render(content)
@@ -2409,20 +5615,10 @@ func (m Model) renderTimeTravelPrompt() string {
 
 // copyIssueToClipboard copies the selected issue to clipboard as Markdown
 func (m *Model) copyIssueToClipboard() {
-	selectedItem := m.list.SelectedItem()
-	if selectedItem == nil {
-		m.statusMsg = "❌ No issue selected"
-		m.statusIsError = true
-		return
-	}
-
-	issueItem, ok := selectedItem.(IssueItem)
+	issue, ok := m.selectedIssue()
 	if !ok {
-		m.statusMsg = "❌ Invalid item type"
-		m.statusIsError = true
 		return
 	}
-	issue := issueItem.Issue
 
 	// Format issue as Markdown
 	var sb strings.Builder
@@ -2434,7 +5630,10 @@ func (m *Model) copyIssueToClipboard() {
 	if issue.Assignee != "" {
 		sb.WriteString(fmt.Sprintf("**Assignee:** @%s  \n", issue.Assignee))
 	}
-	sb.WriteString(fmt.Sprintf("**Created:** %s  \n", issue.CreatedAt.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("**Created:** %s  \n", timefmt.Date(issue.CreatedAt)))
+	if issue.DueDate != nil {
+		sb.WriteString(fmt.Sprintf("**Due:** %s  \n", timefmt.Day(*issue.DueDate)))
+	}
 
 	if len(issue.Labels) > 0 {
 		sb.WriteString(fmt.Sprintf("**Labels:** %s  \n", strings.Join(issue.Labels, ", ")))
@@ -2448,6 +5647,14 @@ func (m *Model) copyIssueToClipboard() {
 		sb.WriteString(fmt.Sprintf("\n## Acceptance Criteria\n\n%s\n", issue.AcceptanceCriteria))
 	}
 
+	// Open blockers, with their titles so the summary stands on its own
+	if blockers := m.openBlockers(issue); len(blockers) > 0 {
+		sb.WriteString("\n## Blocked By\n\n")
+		for _, blocker := range blockers {
+			sb.WriteString(fmt.Sprintf("- %s: %s (%s)\n", blocker.ID, blocker.Title, blocker.Status))
+		}
+	}
+
 	// Dependencies
 	if len(issue.Dependencies) > 0 {
 		sb.WriteString("\n## Dependencies\n\n")
@@ -2459,20 +5666,15 @@ func (m *Model) copyIssueToClipboard() {
 		}
 	}
 
-	// Copy to clipboard
-	err := clipboard.WriteAll(sb.String())
-	if err != nil {
-		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-		m.statusIsError = true
-		return
-	}
-
-	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", issue.ID)
-	m.statusIsError = false
+	m.copyText(sb.String(), issue.ID)
 }
 
 // openInEditor opens the beads.jsonl file in the user's preferred editor
 func (m *Model) openInEditor() {
+	if m.refuseRemoteEdit() || m.refuseReadOnly() {
+		return
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		m.statusMsg = "❌ Cannot get working directory"
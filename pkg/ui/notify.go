@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notifyDesktop shows a desktop notification. It is a variable so tests can stub it.
+var notifyDesktop = func(title, body string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		return exec.Command("osascript", "-e", script).Run()
+	case "linux", "freebsd", "openbsd":
+		return exec.Command("notify-send", "--app-name=bv", title, body).Run()
+	default:
+		return errors.New("desktop notifications are not supported on " + runtime.GOOS)
+	}
+}
+
+// SetNotifyAssignee turns on unblock alerts for issues assigned to me: when
+// a reload shows one of them has lost its last open blocker, the status bar
+// says so and, if desktop is set, a desktop notification fires too.
+func (m *Model) SetNotifyAssignee(me string, desktop bool) {
+	m.notifyAssignee = strings.TrimPrefix(strings.TrimSpace(me), "@")
+	m.desktopNotify = desktop
+}
+
+// isMine reports whether issue is assigned to the notify assignee
+func (m *Model) isMine(issue model.Issue) bool {
+	return m.notifyAssignee != "" &&
+		strings.EqualFold(strings.TrimPrefix(issue.Assignee, "@"), m.notifyAssignee)
+}
+
+// waitingIssues returns the IDs of the notify assignee's open issues that
+// still wait on an open blocker
+func (m *Model) waitingIssues() map[string]bool {
+	if m.notifyAssignee == "" {
+		return nil
+	}
+	waiting := make(map[string]bool)
+	for _, issue := range m.issues {
+		if issue.Status != model.StatusClosed && m.isMine(issue) && len(m.openBlockers(issue)) > 0 {
+			waiting[issue.ID] = true
+		}
+	}
+	return waiting
+}
+
+// newlyReady returns the issues in waiting that can now be started: still
+// open, not marked blocked and with every blocker closed
+func (m *Model) newlyReady(waiting map[string]bool) []model.Issue {
+	var ready []model.Issue
+	for _, issue := range m.issues {
+		if !waiting[issue.ID] || !m.isMine(issue) {
+			continue
+		}
+		if issue.Status == model.StatusClosed || issue.Status == model.StatusBlocked {
+			continue
+		}
+		if len(m.openBlockers(issue)) == 0 {
+			ready = append(ready, issue)
+		}
+	}
+	return ready
+}
+
+// announceUnblocked reports newly ready issues in the status bar and returns
+// the command that sends the desktop notification, if enabled
+func (m *Model) announceUnblocked(ready []model.Issue) tea.Cmd {
+	if len(ready) == 0 {
+		return nil
+	}
+	var title, body string
+	if len(ready) == 1 {
+		title = fmt.Sprintf("%s is ready to start", ready[0].ID)
+		body = ready[0].Title
+	} else {
+		ids := make([]string, len(ready))
+		for i, issue := range ready {
+			ids[i] = issue.ID
+		}
+		title = fmt.Sprintf("%d of your issues are ready to start", len(ready))
+		body = strings.Join(ids, ", ")
+	}
+	m.statusMsg = fmt.Sprintf("🔓 %s: %s", title, body)
+	m.statusIsError = false
+
+	// A remote session's desktop belongs to the server, not the viewer
+	if !m.desktopNotify || m.remoteTerm != nil {
+		return nil
+	}
+	return func() tea.Msg {
+		notifyDesktop("bv: "+title, body) // Best effort; the status bar already says it
+		return nil
+	}
+}
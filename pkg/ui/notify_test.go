@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestUnblockedIssueNotifies(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), ".beads", "beads.jsonl")
+	if err := os.MkdirAll(filepath.Dir(beads), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(blockerStatus, extra string) {
+		t.Helper()
+		data := `{"id":"bv-1","title":"Foundation","status":"` + blockerStatus + `","issue_type":"task","assignee":"bob"}
+{"id":"bv-2","title":"My feature","status":"open","issue_type":"feature","assignee":"Alice","dependencies":[{"depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Their feature","status":"open","issue_type":"feature","assignee":"bob","dependencies":[{"depends_on_id":"bv-1","type":"blocks"}]}
+` + extra
+		if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("open", "")
+
+	var notified []string
+	orig := notifyDesktop
+	notifyDesktop = func(title, body string) error { notified = append(notified, title+"|"+body); return nil }
+	defer func() { notifyDesktop = orig }()
+
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	m.SetNotifyAssignee("@alice", true)
+
+	write("closed", "")
+	waiting := m.waitingIssues()
+	if len(waiting) != 1 || !waiting["bv-2"] {
+		t.Fatalf("expected only bv-2 waiting for alice, got %v", waiting)
+	}
+	updated, _ := m.Update(ReloadIssuesCmd(beads, m.currentLoad())())
+	m = updated.(Model)
+	if !strings.Contains(m.statusMsg, "bv-2 is ready to start") || strings.Contains(m.statusMsg, "bv-3") {
+		t.Errorf("expected a status alert for bv-2 only, got %q", m.statusMsg)
+	}
+	ready := m.newlyReady(waiting)
+	if len(ready) != 1 {
+		t.Fatalf("expected bv-2 newly ready, got %v", ready)
+	}
+	m.announceUnblocked(ready)()
+	if len(notified) != 1 || notified[0] != "bv: bv-2 is ready to start|My feature" {
+		t.Errorf("expected one desktop notification for bv-2, got %v", notified)
+	}
+
+	m.SetRemoteTerminal(os.Stderr)
+	if m.announceUnblocked(ready) != nil {
+		t.Error("remote sessions must not notify the server's desktop")
+	}
+	m.SetRemoteTerminal(nil)
+
+	// Already ready: a further reload says nothing new
+	write("closed", `{"id":"bv-4","title":"New","status":"open","issue_type":"task"}`+"\n")
+	updated, _ = m.Update(ReloadIssuesCmd(beads, m.currentLoad())())
+	m = updated.(Model)
+	if strings.Contains(m.statusMsg, "ready to start") {
+		t.Errorf("no alert expected once the issue is ready, got %q", m.statusMsg)
+	}
+}
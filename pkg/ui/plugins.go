@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Plugin is an external command bound to a key. It runs from the project
+// root with the selected issue as JSON on stdin.
+type Plugin struct {
+	Name    string
+	Key     string
+	Command string
+	Output  string // toast, pager or none
+	Timeout time.Duration
+}
+
+// PluginResultMsg reports a finished plugin run
+type PluginResultMsg struct {
+	Plugin  Plugin
+	IssueID string
+	Output  string // Stdout, trimmed
+	Err     error
+}
+
+// runPluginCommand runs command through the shell. It is a variable so tests
+// can stub it.
+var runPluginCommand = func(ctx context.Context, dir, command string, stdin []byte, env []string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return stdout.String(), fmt.Errorf("timed out")
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%s", firstLine(msg))
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// PluginCmd runs p on issue in the background
+func PluginCmd(p Plugin, issue model.Issue, beadsPath string) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		data, err := json.Marshal(issue)
+		if err != nil {
+			return PluginResultMsg{Plugin: p, IssueID: issue.ID, Err: err}
+		}
+		timeout := p.Timeout
+		if timeout <= 0 {
+			timeout = 30 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		env := []string{
+			"BV_ISSUE_ID=" + issue.ID,
+			"BV_ISSUE_TITLE=" + issue.Title,
+			"BV_ISSUE_STATUS=" + string(issue.Status),
+			"BV_BEADS_PATH=" + beadsPath,
+		}
+		out, err := runPluginCommand(ctx, dir, p.Command, data, env)
+		return PluginResultMsg{Plugin: p, IssueID: issue.ID, Output: strings.TrimSpace(out), Err: err}
+	}
+}
+
+// firstLine returns s up to its first newline
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// SetPlugins binds plugins to their keys, which config.Validate keeps off
+// the built-in ones. They apply wherever the top-level shortcuts do.
+func (m *Model) SetPlugins(plugins []Plugin) {
+	m.plugins = make(map[string]Plugin, len(plugins))
+	for _, p := range plugins {
+		m.plugins[p.Key] = p
+	}
+}
+
+// pluginIssue returns the issue selected in the current view
+func (m *Model) pluginIssue() (model.Issue, bool) {
+	var id string
+	switch {
+	case m.focused == focusInsights:
+		id = m.insightsPanel.SelectedIssueID()
+	case m.isGraphView:
+		if issue := m.graphView.SelectedIssue(); issue != nil {
+			return *issue, true
+		}
+	case m.isBoardView:
+		if issue := m.board.SelectedIssue(); issue != nil {
+			return *issue, true
+		}
+	case m.isActionableView:
+		id = m.actionableView.SelectedIssueID()
+	default:
+		return m.selectedIssue()
+	}
+	if issue, ok := m.issueMap[id]; ok {
+		return *issue, true
+	}
+	m.statusMsg = "❌ No issue selected"
+	m.statusIsError = true
+	return model.Issue{}, false
+}
+
+// runPlugin starts p on the selected issue
+func (m *Model) runPlugin(p Plugin) tea.Cmd {
+	if m.remoteTerm != nil {
+		m.statusMsg = "❌ Plugins don't run in a remote session"
+		m.statusIsError = true
+		return nil
+	}
+	issue, ok := m.pluginIssue()
+	if !ok {
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("Running %s on %s…", p.Name, issue.ID)
+	m.statusIsError = false
+	return PluginCmd(p, issue, m.beadsPath)
+}
+
+// handlePluginResult shows a plugin's output as its config asks
+func (m *Model) handlePluginResult(msg PluginResultMsg) {
+	if msg.Err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %s failed on %s: %v", msg.Plugin.Name, msg.IssueID, msg.Err)
+		m.statusIsError = true
+		return
+	}
+	m.statusIsError = false
+	switch {
+	case msg.Plugin.Output == "pager" && msg.Output != "":
+		m.showPluginPager = true
+		m.pluginPagerTitle = fmt.Sprintf("%s · %s", msg.Plugin.Name, msg.IssueID)
+		m.pluginPager = viewport.New(m.width, m.height-3)
+		m.pluginPager.SetContent(msg.Output)
+		m.statusMsg = ""
+	case msg.Plugin.Output == "toast" && msg.Output != "":
+		m.statusMsg = fmt.Sprintf("%s: %s", msg.Plugin.Name, firstLine(msg.Output))
+	default:
+		m.statusMsg = fmt.Sprintf("✓ %s finished on %s", msg.Plugin.Name, msg.IssueID)
+	}
+}
+
+// handlePluginPagerKeys scrolls or closes the plugin output pager
+func (m Model) handlePluginPagerKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "enter":
+		m.showPluginPager = false
+		return m, nil
+	case "g", "home":
+		m.pluginPager.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.pluginPager.GotoBottom()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.pluginPager, cmd = m.pluginPager.Update(msg)
+	return m, cmd
+}
+
+// renderPluginPager draws the plugin output pager
+func (m Model) renderPluginPager() string {
+	t := m.theme
+	title := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary).Render(m.pluginPagerTitle)
+	hint := t.Renderer.NewStyle().Foreground(t.Secondary).
+		Render(fmt.Sprintf("j/k scroll · g/G top/bottom · q close · %d%%", int(m.pluginPager.ScrollPercent()*100)))
+	return lipgloss.JoinVertical(lipgloss.Left, title, m.pluginPager.View(), hint)
+}
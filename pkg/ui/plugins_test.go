@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/config"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPluginRunsOnSelectedIssue(t *testing.T) {
+	var gotStdin []byte
+	var gotEnv []string
+	orig := runPluginCommand
+	runPluginCommand = func(ctx context.Context, dir, command string, stdin []byte, env []string) (string, error) {
+		gotStdin, gotEnv = stdin, env
+		return "started timer\nsecond line\n", nil
+	}
+	defer func() { runPluginCommand = orig }()
+
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	m.SetPlugins([]Plugin{{Name: "timer", Key: "ctrl+t", Command: "timer start", Output: "toast"}})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("expected the plugin to start")
+	}
+	msg := cmd()
+	var sent model.Issue
+	if err := json.Unmarshal(gotStdin, &sent); err != nil || sent.ID != "bv-1" {
+		t.Fatalf("expected the issue as JSON on stdin, got %q (%v)", gotStdin, err)
+	}
+	if !strings.Contains(strings.Join(gotEnv, " "), "BV_ISSUE_ID=bv-1") {
+		t.Errorf("expected BV_ISSUE_ID in %v", gotEnv)
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if m.statusMsg != "timer: started timer" {
+		t.Errorf("expected the first line as a toast, got %q", m.statusMsg)
+	}
+}
+
+func TestPluginPager(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	p := Plugin{Name: "notes", Key: "n", Output: "pager"}
+	updated, _ = m.Update(PluginResultMsg{Plugin: p, IssueID: "bv-1", Output: "line one\nline two"})
+	m = updated.(Model)
+	if !m.showPluginPager || !strings.Contains(m.View(), "line two") {
+		t.Fatalf("expected the output in a pager")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showPluginPager {
+		t.Errorf("expected esc to close the pager")
+	}
+
+	updated, _ = m.Update(PluginResultMsg{Plugin: p, IssueID: "bv-1", Err: context.DeadlineExceeded})
+	m = updated.(Model)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "notes failed on bv-1") {
+		t.Errorf("expected an error status, got %q", m.statusMsg)
+	}
+}
+
+func TestPluginRefusedInRemoteSession(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	m.SetRemoteTerminal(&strings.Builder{})
+	if cmd := m.runPlugin(Plugin{Name: "timer", Command: "true"}); cmd != nil || !m.statusIsError {
+		t.Errorf("expected plugins to be refused in a remote session")
+	}
+}
+
+func TestPluginCmdRunsShell(t *testing.T) {
+	issue := model.Issue{ID: "bv-7", Title: "Seven", Status: model.StatusOpen}
+	p := Plugin{Name: "echo", Command: `printf '%s ' "$BV_ISSUE_ID"; head -c 12`}
+	msg := PluginCmd(p, issue, "")().(PluginResultMsg)
+	if msg.Err != nil || msg.Output != `bv-7 {"id":"bv-7"` {
+		t.Errorf("unexpected result %+v", msg)
+	}
+
+	p.Command = "echo broken >&2; exit 3"
+	msg = PluginCmd(p, issue, "")().(PluginResultMsg)
+	if msg.Err == nil || msg.Err.Error() != "broken" {
+		t.Errorf("expected stderr as the error, got %v", msg.Err)
+	}
+}
+
+// Every ctrl+ and alt+ shortcut the TUI handles must be one the config
+// refuses for plugins, or a plugin could shadow it
+func TestBuiltinShortcutsAreReservedFromPlugins(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyLine := regexp.MustCompile(`^\s*case "|String\(\) == "`) // Key switches and comparisons
+	shortcut := regexp.MustCompile(`"((?:ctrl|alt)\+[^" ]+)"`)
+	seen := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range strings.Split(string(src), "\n") {
+			if !keyLine.MatchString(line) {
+				continue
+			}
+			for _, m := range shortcut.FindAllStringSubmatch(line, -1) {
+				seen++
+				if !config.IsBuiltinKey(m[1]) {
+					t.Errorf("%s handles %s, which config.IsBuiltinKey doesn't reserve", file, m[1])
+				}
+			}
+		}
+	}
+	if seen == 0 {
+		t.Fatal("expected to find the TUI's shortcuts")
+	}
+}
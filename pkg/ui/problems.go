@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DanglingLocationsMsg carries where the targets of dangling dependencies were found
+type DanglingLocationsMsg struct {
+	Locations map[string][]loader.IDLocation
+}
+
+// LocateDanglingCmd searches the files around beadsPath for the missing IDs
+func LocateDanglingCmd(beadsPath string, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		return DanglingLocationsMsg{Locations: loader.LocateIssueIDs(beadsPath, ids)}
+	}
+}
+
+// DependencyRemovedMsg reports the outcome of dropping a dependency edge
+type DependencyRemovedMsg struct {
+	From, To string
+	Err      error
+}
+
+// RemoveDependencyCmd drops the From -> To dependency using the bd CLI. The
+// file watcher picks up the change and reloads the list.
+func RemoveDependencyCmd(beadsPath, from, to string) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		err := runBeadsCLI(dir, "dep", "remove", from, to)
+		return DependencyRemovedMsg{From: from, To: to, Err: err}
+	}
+}
+
+// dominantPrefix returns the most common ID prefix among issues, or "" if none
+func dominantPrefix(issues []model.Issue) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, issue := range issues {
+		p := ExtractRepoPrefix(issue.ID)
+		if p == "" {
+			continue
+		}
+		counts[p]++
+		if counts[p] > counts[best] || (counts[p] == counts[best] && p < best) {
+			best = p
+		}
+	}
+	return best
+}
+
+// skippedLineProblems turns lines the loader couldn't read into data problems
+func skippedLineProblems(skipped []loader.LineError) []analysis.DataProblem {
+	problems := make([]analysis.DataProblem, 0, len(skipped))
+	for _, s := range skipped {
+		problems = append(problems, analysis.DataProblem{
+			Kind:   analysis.ProblemUnreadableLine,
+			Line:   s.Line,
+			Detail: s.Err.Error(),
+		})
+	}
+	return problems
+}
+
+// ProblemsModel is the panel listing data problems in the loaded issues
+type ProblemsModel struct {
+	problems     []analysis.DataProblem
+	homePrefix   string                         // Usual ID prefix of the loaded issues
+	locations    map[string][]loader.IDLocation // Missing ID -> other files containing it
+	locating     bool
+	collisions   []analysis.IDCollision // Records of each duplicate ID
+	baseDir      string                 // Location paths are shown relative to this
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewProblemsModel creates the data problems panel. homePrefix is the usual
+// ID prefix of the loaded issues, used to spot dependencies on other repos.
+func NewProblemsModel(problems []analysis.DataProblem, homePrefix string, theme Theme) ProblemsModel {
+	return ProblemsModel{problems: problems, homePrefix: homePrefix, theme: theme}
+}
+
+// MissingIDs returns the distinct targets of dangling dependencies
+func (m *ProblemsModel) MissingIDs() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, p := range m.problems {
+		if p.Kind == analysis.ProblemDanglingDep && !seen[p.DependsOn] {
+			seen[p.DependsOn] = true
+			ids = append(ids, p.DependsOn)
+		}
+	}
+	return ids
+}
+
+// StartLocating marks the search for missing IDs as running
+func (m *ProblemsModel) StartLocating() {
+	m.locating = true
+}
+
+// SetLocations records where missing IDs were found; paths are shown relative to baseDir
+func (m *ProblemsModel) SetLocations(locations map[string][]loader.IDLocation, baseDir string) {
+	m.locations = locations
+	m.baseDir = baseDir
+	m.locating = false
+}
+
+// LoadablePath returns a file holding the selected dangling dependency's
+// target, if one was found outside a deletions manifest
+func (m *ProblemsModel) LoadablePath() (string, bool) {
+	p, ok := m.SelectedProblem()
+	if !ok || p.Kind != analysis.ProblemDanglingDep {
+		return "", false
+	}
+	for _, loc := range m.locations[p.DependsOn] {
+		if !loc.Deleted {
+			return loc.Path, true
+		}
+	}
+	return "", false
+}
+
+// danglingHints explains where a missing dependency target might live
+func (m *ProblemsModel) danglingHints(p analysis.DataProblem) []string {
+	var hints []string
+	for _, loc := range m.locations[p.DependsOn] {
+		path := loc.Path
+		if rel, err := filepath.Rel(m.baseDir, loc.Path); err == nil && m.baseDir != "" {
+			path = rel
+		}
+		if loc.Deleted {
+			hints = append(hints, fmt.Sprintf("listed as deleted in %s", path))
+		} else {
+			hints = append(hints, fmt.Sprintf("found in %s", path))
+		}
+	}
+	if prefix := ExtractRepoPrefix(p.DependsOn); prefix != "" && m.homePrefix != "" && prefix != m.homePrefix {
+		hints = append(hints, fmt.Sprintf("prefix %q differs from this project's %q, so it may belong to another repo", prefix, m.homePrefix))
+	}
+	switch {
+	case m.locating:
+		hints = append(hints, "searching nearby beads files…")
+	case len(m.locations[p.DependsOn]) == 0:
+		hints = append(hints, "not found in nearby beads files")
+	}
+	return hints
+}
+
+// SetSize updates the view dimensions
+func (m *ProblemsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *ProblemsModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *ProblemsModel) MoveDown() {
+	if m.selected < len(m.problems)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Select highlights the i'th problem, or the last when there are fewer
+func (m *ProblemsModel) Select(i int) {
+	m.selected = max(min(i, len(m.problems)-1), 0)
+	m.ensureVisible()
+}
+
+// SelectedProblem returns the highlighted problem and whether there is one
+func (m *ProblemsModel) SelectedProblem() (analysis.DataProblem, bool) {
+	if m.selected < 0 || m.selected >= len(m.problems) {
+		return analysis.DataProblem{}, false
+	}
+	return m.problems[m.selected], true
+}
+
+// visibleRows returns how many problems fit below the header, the hint line
+// and the dangling dependency hints
+func (m *ProblemsModel) visibleRows() int {
+	return max(m.height-10, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected problem on screen
+func (m *ProblemsModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the data problems panel
+func (m *ProblemsModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("🩺 DATA PROBLEMS  │  %d found", len(m.problems))))
+	lines = append(lines, "")
+
+	if len(m.problems) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No problems found in the beads file."))
+		return strings.Join(lines, "\n")
+	}
+
+	kindStyle := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+	detailWidth := max(m.width-40, 10)
+
+	end := min(m.scrollOffset+m.visibleRows(), len(m.problems))
+	for i := m.scrollOffset; i < end; i++ {
+		p := m.problems[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		where := p.IssueID
+		if where == "" {
+			where = fmt.Sprintf("line %d", p.Line)
+		}
+		lines = append(lines, rowStyle.Render(prefix)+
+			kindStyle.Render(fmt.Sprintf("%-20s", p.Kind.Label()))+
+			rowStyle.Render(fitWidth(where, 14)+" "+truncateToWidth(p.Detail, detailWidth, "…")))
+	}
+	if len(m.problems) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.problems)-end)))
+	}
+	lines = append(lines, subtle.Render("  ⏎ jump to issue (clears filters that hide it)"))
+
+	if p, ok := m.SelectedProblem(); ok && p.Kind == analysis.ProblemDanglingDep {
+		lines = append(lines, "", t.Renderer.NewStyle().Bold(true).Render(fmt.Sprintf("  Where %s might be:", p.DependsOn)))
+		for _, hint := range m.danglingHints(p) {
+			lines = append(lines, "    • "+truncateToWidth(hint, max(m.width-8, 10), "…"))
+		}
+		fixes := "  d drop this dependency"
+		if _, ok := m.LoadablePath(); ok {
+			fixes += "  │  l load the file that has it"
+		}
+		lines = append(lines, subtle.Render(fixes))
+	}
+	if c, ok := m.SelectedCollision(); ok {
+		lines = append(lines, m.collisionLines(c)...)
+	}
+
+	return strings.Join(lines, "\n")
+}
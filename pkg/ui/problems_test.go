@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func problemTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "A", Title: "Open work", Status: model.StatusOpen},
+		{ID: "B", Title: "Shipped", Status: model.StatusClosed, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "GONE", Type: model.DepBlocks},
+		}},
+	}
+}
+
+func TestProblemsPanelJumpsToHiddenIssue(t *testing.T) {
+	m := NewModel(problemTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	m.ReportSkippedLines([]loader.LineError{{Line: 7, Err: errors.New("invalid status")}})
+
+	// Hide the closed issue behind the open filter
+	m.currentFilter = "open"
+	m.applyFilter()
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	m = updated.(Model)
+	if !m.isProblemsView || m.focused != focusProblems {
+		t.Fatalf("expected problems view focused")
+	}
+	out := m.View()
+	for _, want := range []string{"2 found", "line 7", "invalid status", "depends on GONE"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in problems view:\n%s", want, out)
+		}
+	}
+
+	// Unreadable lines have no record to jump to
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.isProblemsView || !m.statusIsError || !strings.Contains(m.statusMsg, "Line 7") {
+		t.Fatalf("expected to stay in panel with a line status, got %q", m.statusMsg)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isProblemsView {
+		t.Fatalf("expected panel closed after jump")
+	}
+	if m.currentFilter != "all" || m.statusMsg != "Cleared filters to show B" {
+		t.Fatalf("expected filter cleared, got filter=%q status=%q", m.currentFilter, m.statusMsg)
+	}
+	if sel, ok := m.list.SelectedItem().(IssueItem); !ok || sel.Issue.ID != "B" {
+		t.Fatalf("expected B selected, got %+v", m.list.SelectedItem())
+	}
+}
+
+func TestProblemsPanelResolvesDanglingDependency(t *testing.T) {
+	var calls []string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, strings.Join(args, " "))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	root := t.TempDir()
+	beadsDir := filepath.Join(root, "app", ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	beads := filepath.Join(beadsDir, "beads.jsonl")
+	data := `{"id":"app-1","title":"Feature","status":"open","issue_type":"task","dependencies":[{"issue_id":"app-1","depends_on_id":"app-9","type":"blocks"}]}` + "\n"
+	archive := `{"id":"app-9","title":"Archived prerequisite","status":"closed","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "archive.jsonl"), []byte(archive), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "searching nearby beads files") {
+		t.Fatalf("expected a search indicator:\n%s", m.View())
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if out := m.View(); !strings.Contains(out, "found in "+filepath.Join("app", ".beads", "archive.jsonl")) || !strings.Contains(out, "l load the file") {
+		t.Fatalf("expected archive hint:\n%s", out)
+	}
+
+	// d drops the edge via bd
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if strings.Join(calls, "\n") != "dep remove app-1 app-9" || m.statusMsg != "Removed dependency app-1 → app-9" {
+		t.Fatalf("unexpected bd calls %v, status %q", calls, m.statusMsg)
+	}
+
+	// l merges the archive, which resolves the dependency
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if _, ok := m.issueMap["app-9"]; !ok {
+		t.Fatalf("expected app-9 merged in")
+	}
+	if !strings.Contains(m.statusMsg, "1 from other files") || len(m.dataProblems) != 0 {
+		t.Fatalf("unexpected status %q, problems %+v", m.statusMsg, m.dataProblems)
+	}
+}
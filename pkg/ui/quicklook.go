@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// quickLookRelated is how many blockers and dependents the quick look lists
+const quickLookRelated = 3
+
+// openQuickLook shows the popup for the selected issue, reporting whether
+// the cursor is on an issue
+func (m *Model) openQuickLook() bool {
+	if _, ok := m.list.SelectedItem().(IssueItem); !ok {
+		return false
+	}
+	m.showQuickLook = true
+	return true
+}
+
+// handleQuickLookKeys handles keys while the quick look is open: j/k move
+// through the list underneath, enter opens the full details
+func (m Model) handleQuickLookKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", " ", "q":
+		m.showQuickLook = false
+	case "j", "down":
+		m.stepQuickLook(1)
+	case "k", "up":
+		m.stepQuickLook(-1)
+	case "enter":
+		m.showQuickLook = false
+		if m.isSplitView {
+			m.focused = focusDetail
+		} else {
+			m.showDetails = true
+		}
+		m.updateViewportContent()
+	}
+	return m, nil
+}
+
+// stepQuickLook moves the list cursor to the next (delta 1) or previous
+// (delta -1) issue, skipping section headers
+func (m *Model) stepQuickLook(delta int) {
+	items := m.list.VisibleItems()
+	for i := m.list.Index() + delta; i >= 0 && i < len(items); i += delta {
+		if _, ok := items[i].(IssueItem); ok {
+			m.list.Select(i)
+			m.updateViewportContent()
+			return
+		}
+	}
+}
+
+// openDependents returns the open issues that wait on id, highest impact first
+func (m *Model) openDependents(id string) []*model.Issue {
+	var dependents []*model.Issue
+	for i := range m.issues {
+		issue := &m.issues[i]
+		if issue.Status.IsClosed() {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID == id && dep.Type.IsBlockingOrLegacy() {
+				dependents = append(dependents, issue)
+				break
+			}
+		}
+	}
+	sort.SliceStable(dependents, func(i, j int) bool {
+		return m.analysis.GetCriticalPathScore(dependents[i].ID) > m.analysis.GetCriticalPathScore(dependents[j].ID)
+	})
+	return dependents
+}
+
+// renderQuickLook draws the selected issue's summary, what blocks it, what it
+// unblocks and its graph metrics in a box centered over the body
+func (m Model) renderQuickLook() string {
+	t := m.theme
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return ""
+	}
+	issue := item.Issue
+	width := min(m.width-8, 76)
+	inner := width - 4
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	headingStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(truncateToWidth(
+		fmt.Sprintf("%s %s %s", GetTypeIconMD(string(issue.IssueType)), issue.ID, issue.Title), inner, "…")))
+
+	meta := []string{RenderStatusBadge(string(issue.Status)), RenderPriorityBadge(issue.Priority)}
+	if issue.Assignee != "" {
+		meta = append(meta, textStyle.Render("@"+issue.Assignee))
+	}
+	meta = append(meta, mutedStyle.Render("created "+FormatTimeRel(issue.CreatedAt)))
+	if badge := RenderDueBadge(t.Renderer, &issue, time.Now()); badge != "" {
+		meta = append(meta, badge)
+	}
+	lines = append(lines, strings.Join(meta, " "))
+	if len(issue.Labels) > 0 {
+		lines = append(lines, RenderLabelChips(t.Renderer, issue.Labels, inner))
+	}
+
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		lines = append(lines, "")
+		descLines := strings.Split(desc, "\n")
+		for i, line := range descLines {
+			if i == 3 {
+				lines = append(lines, mutedStyle.Render("…"))
+				break
+			}
+			lines = append(lines, textStyle.Render(truncateToWidth(line, inner, "…")))
+		}
+	}
+
+	related := func(heading string, issues []*model.Issue) {
+		lines = append(lines, "", headingStyle.Render(fmt.Sprintf("%s (%d)", heading, len(issues))))
+		if len(issues) == 0 {
+			lines = append(lines, mutedStyle.Render("  none"))
+			return
+		}
+		for i, r := range issues {
+			if i == quickLookRelated {
+				lines = append(lines, mutedStyle.Render(fmt.Sprintf("  … %d more", len(issues)-quickLookRelated)))
+				break
+			}
+			lines = append(lines, "  "+RenderStatusBadge(string(r.Status))+" "+
+				textStyle.Render(truncateToWidth(r.ID+" "+r.Title, inner-7, "…")))
+		}
+	}
+	related("⛔ Blocked by", m.openBlockers(issue))
+	related("🔓 Unblocks", m.openDependents(issue.ID))
+
+	lines = append(lines, "", headingStyle.Render("📊 Metrics"))
+	lines = append(lines, textStyle.Render(fmt.Sprintf("  Impact %.1f · PageRank %.4f · Betweenness %.4f",
+		m.analysis.GetCriticalPathScore(issue.ID),
+		m.analysis.GetPageRankScore(issue.ID),
+		m.analysis.GetBetweennessScore(issue.ID))))
+	lines = append(lines, textStyle.Render(fmt.Sprintf("  %d comments · updated %s",
+		len(issue.Comments), FormatTimeRel(issue.UpdatedAt))))
+
+	lines = append(lines, "", mutedStyle.Italic(true).Render("j/k: next issue • enter: full details • esc: close"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestQuickLook(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "foundation", Status: model.StatusOpen, IssueType: model.TypeTask, Description: "lay the groundwork"},
+		{ID: "B", Title: "walls", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+	press := func(k string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case " ":
+			msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	press(" ")
+	if !m.showQuickLook {
+		t.Fatalf("expected space to open the quick look")
+	}
+	out := m.View()
+	for _, want := range []string{"A foundation", "lay the groundwork", "Blocked by (0)", "Unblocks (1)", "B walls", "PageRank"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in quick look:\n%s", want, out)
+		}
+	}
+
+	// Moving on keeps the popup open over the next issue
+	press("j")
+	if !m.showQuickLook || !strings.Contains(m.View(), "Blocked by (1)") {
+		t.Fatalf("expected the quick look to follow the cursor to B")
+	}
+	// Global keys don't reach the views underneath
+	press("b")
+	if m.isBoardView {
+		t.Fatalf("expected b to be ignored while the quick look is open")
+	}
+
+	press("esc")
+	if m.showQuickLook || m.showDetails || m.showQuitConfirm {
+		t.Fatalf("expected esc to just close the quick look")
+	}
+
+	press(" ")
+	press("enter")
+	if m.showQuickLook || !(m.showDetails || m.focused == focusDetail) {
+		t.Fatalf("expected enter to open the full details")
+	}
+}
@@ -116,7 +116,7 @@ func (m *RecipePickerModel) View() string {
 			descStyle := t.Renderer.NewStyle().
 				Foreground(t.Secondary).
 				Italic(true)
-			desc := "    " + truncateRunesHelper(r.Description, boxWidth-8, "…")
+			desc := "    " + truncateToWidth(r.Description, boxWidth-8, "…")
 			lines = append(lines, descStyle.Render(desc))
 		}
 
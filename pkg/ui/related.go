@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// relatedMarkdown lists the issue's non-blocking links (related,
+// discovered-from and custom non-blocking types) for the detail pane, apart
+// from the blockers and hierarchy, then the issues it mentions or is
+// mentioned by without a dependency, or "" when there are none
+func (m *Model) relatedMarkdown(id string) string {
+	rels := append(analysis.RelationsOf(m.issues, id), analysis.MentionsOf(m.issueMentions(), id)...)
+	if len(rels) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Related (%d)\n", len(rels)))
+	for _, r := range rels {
+		arrow := "←"
+		if r.Outgoing {
+			arrow = "→"
+		}
+		title, status := "(not found)", "?"
+		if issue, ok := m.issueMap[r.ID]; ok {
+			title, status = issue.Title, string(issue.Status)
+		}
+		icon := "🔗"
+		if r.Type == analysis.DepMentions {
+			icon = "💬" // Implicit, from the text
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s %s `%s` %s (%s)\n", icon, r.Type, arrow, r.ID, title, status))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRelatedSectionInDetailView(t *testing.T) {
+	model.SetCustomDependencyTypes(map[model.DependencyType]bool{"supersedes": true})
+	defer model.SetCustomDependencyTypes(nil)
+
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "bv-2", Type: model.DepRelated},
+			{DependsOnID: "bv-3", Type: "supersedes"},
+		}},
+		{ID: "bv-2", Title: "Lexer", Status: model.StatusOpen},
+		{ID: "bv-3", Title: "Old parser", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = updated.(Model)
+
+	if got := m.relatedMarkdown("bv-1"); !strings.Contains(got, "Related (1)") || !strings.Contains(got, "related → `bv-2` Lexer") {
+		t.Errorf("expected bv-2 related from bv-1:\n%s", got)
+	}
+	if got := m.relatedMarkdown("bv-2"); !strings.Contains(got, "related ← `bv-1` Parser") {
+		t.Errorf("expected bv-2 to see the link from bv-1:\n%s", got)
+	}
+	// A custom blocking type is a blocker, not a relation
+	if got := m.relatedMarkdown("bv-3"); got != "" {
+		t.Errorf("expected no related section for a blocking link:\n%s", got)
+	}
+	if m.countReady != 2 {
+		t.Errorf("expected the supersedes link to block bv-1, got %d ready", m.countReady)
+	}
+
+	for i, item := range m.list.Items() {
+		if item.(IssueItem).Issue.ID == "bv-1" {
+			m.list.Select(i)
+		}
+	}
+	m.updateViewportContent()
+	if !strings.Contains(m.viewport.View(), "Related (1)") {
+		t.Errorf("detail view missing the related section:\n%s", m.viewport.View())
+	}
+}
+
+func TestMentionsInRelatedSection(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser", Status: model.StatusOpen, Description: "Needs bv-2's token stream."},
+		{ID: "bv-2", Title: "Lexer", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = updated.(Model)
+
+	if got := m.relatedMarkdown("bv-1"); !strings.Contains(got, "💬 mentions → `bv-2` Lexer") {
+		t.Errorf("expected bv-1 to mention bv-2:\n%s", got)
+	}
+	if got := m.relatedMarkdown("bv-2"); !strings.Contains(got, "💬 mentions ← `bv-1` Parser") {
+		t.Errorf("expected bv-2 to see the mention from bv-1:\n%s", got)
+	}
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// riskFactorLabels names the risk factors in the breakdown below the ranking
+var riskFactorLabels = map[string]string{
+	analysis.RiskImpact:    "Impact",
+	analysis.RiskProgress:  "Not done",
+	analysis.RiskOwnership: "One owner",
+	analysis.RiskDue:       "Due date",
+}
+
+// RiskModel is the ranked risk panel: open issues and epics by composite
+// risk score, with the factors behind the selected one
+type RiskModel struct {
+	scores       []analysis.RiskScore
+	epicsOnly    bool
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewRiskModel scores the issues in scope
+func NewRiskModel(issues []model.Issue, now time.Time, theme Theme) RiskModel {
+	return RiskModel{
+		scores: analysis.ComputeRiskScores(issues, now),
+		theme:  theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *RiskModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// rows returns the scores listed: all of them, or only the epics
+func (m *RiskModel) rows() []analysis.RiskScore {
+	if !m.epicsOnly {
+		return m.scores
+	}
+	var epics []analysis.RiskScore
+	for _, s := range m.scores {
+		if s.Epic {
+			epics = append(epics, s)
+		}
+	}
+	return epics
+}
+
+// ToggleEpics switches between every open issue and epics alone, and reports
+// whether only epics are listed now
+func (m *RiskModel) ToggleEpics() bool {
+	m.epicsOnly = !m.epicsOnly
+	m.selected = 0
+	m.scrollOffset = 0
+	return m.epicsOnly
+}
+
+// MoveUp moves selection up
+func (m *RiskModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *RiskModel) MoveDown() {
+	if m.selected < len(m.rows())-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the highlighted score, or nil when nothing is listed
+func (m *RiskModel) Selected() *analysis.RiskScore {
+	rows := m.rows()
+	if m.selected < 0 || m.selected >= len(rows) {
+		return nil
+	}
+	return &rows[m.selected]
+}
+
+// visibleRows returns how many ranked rows fit above the factor breakdown
+func (m *RiskModel) visibleRows() int {
+	return max(m.height-11, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *RiskModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the ranking and the selected score's breakdown
+func (m *RiskModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	rows := m.rows()
+	scope := "issues and epics"
+	if m.epicsOnly {
+		scope = "epics"
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("⚠️  RISK  │  %d open %s  │  impact × not done × one owner × due date", len(rows), scope)))
+	lines = append(lines, "")
+
+	if len(rows) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render(fmt.Sprintf("No open %s to score.", scope)))
+		return strings.Join(lines, "\n")
+	}
+
+	epicStyle := t.Renderer.NewStyle().Foreground(t.Epic)
+	titleWidth := min(max((m.width-40)/2, 12), 50)
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %4s %5s  %-12s  %-*s  %s", "#", "RISK", "ISSUE", titleWidth+2, "TITLE", "DRIVEN BY")))
+
+	end := min(m.scrollOffset+m.visibleRows(), len(rows))
+	for i := m.scrollOffset; i < end; i++ {
+		r := rows[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		kind := "  "
+		if r.Epic {
+			kind = epicStyle.Render("◆ ")
+		}
+		scoreStyle := t.Renderer.NewStyle().Foreground(GetHeatmapColor(r.Score / 100))
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("%s%4d ", prefix, i+1))+
+			scoreStyle.Render(fmt.Sprintf("%5.1f", r.Score))+
+			rowStyle.Render("  "+fitWidth(r.ID, 12)+"  ")+
+			kind+
+			rowStyle.Render(fitWidth(r.Title, titleWidth)+"  ")+
+			subtle.Render(truncateToWidth(r.Explain(), max(m.width-titleWidth-34, 10), "…")))
+	}
+	if len(rows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(rows)-end)))
+	}
+
+	if sel := m.Selected(); sel != nil {
+		lines = append(lines, "")
+		lines = append(lines, t.Renderer.NewStyle().Bold(true).Render(fmt.Sprintf("%s  %.1f", sel.ID, sel.Score))+
+			subtle.Render("  — factors, strongest first"))
+		dominant := make(map[string]bool)
+		for _, f := range sel.Dominant() {
+			dominant[f.Name] = true
+		}
+		barWidth := min(max(m.width/4, 8), 24)
+		for _, f := range sel.Factors {
+			label := fmt.Sprintf("  %-10s", riskFactorLabels[f.Name])
+			reason := subtle.Render(f.Reason)
+			if dominant[f.Name] {
+				label = t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true).Render(label)
+				reason = t.Renderer.NewStyle().Foreground(t.Blocked).Render(f.Reason)
+			}
+			lines = append(lines, fmt.Sprintf("%s %s %3.0f%%  %s", label, RenderMiniBar(f.Value, barWidth), f.Value*100, reason))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
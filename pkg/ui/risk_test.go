@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRiskViewRanksAndExplains(t *testing.T) {
+	soon := time.Now().Add(36 * time.Hour)
+	issues := []model.Issue{
+		{ID: "R1", Title: "Cut over billing", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 0, Assignee: "sam", DueDate: &soon},
+		{ID: "R2", Title: "Tidy docs", Status: model.StatusInProgress, IssueType: model.TypeTask, Priority: 4},
+		{ID: "EP", Title: "Billing", Status: model.StatusOpen, IssueType: model.TypeEpic, Priority: 1},
+		{ID: "R3", Title: "Invoices", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2,
+			Dependencies: []*model.Dependency{{DependsOnID: "EP", Type: model.DepParentChild}}},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Q'}})
+	m = updated.(Model)
+	if !m.isRiskView || m.focused != focusRisk {
+		t.Fatalf("expected Q to open the risk panel")
+	}
+	if sel := m.riskView.Selected(); sel == nil || sel.ID != "R1" {
+		t.Fatalf("expected R1 ranked first, got %+v", sel)
+	}
+	out := m.View()
+	for _, want := range []string{"RISK", "R1", "DRIVEN BY", "due tomorrow", "Due date"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the panel:\n%s", want, out)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(Model)
+	if sel := m.riskView.Selected(); sel == nil || sel.ID != "EP" || len(m.riskView.rows()) != 1 {
+		t.Fatalf("expected e to list the epic alone, got %+v", m.riskView.rows())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isRiskView {
+		t.Fatalf("expected enter to leave the panel")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "EP" {
+		t.Errorf("expected EP selected, got %v", m.list.SelectedItem())
+	}
+}
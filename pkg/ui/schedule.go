@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scheduleDayWidth is the width of one working day on the timeline
+const scheduleDayWidth = 2
+
+// ScheduleModel is the projected timeline: open work placed on each
+// assignee's working days by analysis.BuildSchedule, one bar per issue
+type ScheduleModel struct {
+	schedule     analysis.Schedule
+	items        []analysis.ScheduledIssue // In display order
+	byAssignee   bool
+	now          time.Time
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewScheduleModel schedules the issues in scope under the WIP limits in use
+func NewScheduleModel(issues []model.Issue, now time.Time, theme Theme) ScheduleModel {
+	m := ScheduleModel{
+		schedule: analysis.BuildSchedule(issues, analysis.ScheduleOptions{Now: now, Limits: analysis.CurrentWIPLimits()}),
+		now:      now,
+		theme:    theme,
+	}
+	m.arrange()
+	return m
+}
+
+// arrange orders the bars by start, or by assignee and then start
+func (m *ScheduleModel) arrange() {
+	m.items = append(m.items[:0], m.schedule.Items...)
+	if m.byAssignee {
+		sort.SliceStable(m.items, func(i, j int) bool { return m.items[i].Assignee < m.items[j].Assignee })
+	}
+}
+
+// ToggleByAssignee switches between one timeline ordered by start and a
+// lane per assignee, and reports whether lanes are on now
+func (m *ScheduleModel) ToggleByAssignee() bool {
+	id := ""
+	if sel := m.Selected(); sel != nil {
+		id = sel.ID
+	}
+	m.byAssignee = !m.byAssignee
+	m.arrange()
+	for i := range m.items {
+		if m.items[i].ID == id {
+			m.selected = i
+		}
+	}
+	m.ensureVisible()
+	return m.byAssignee
+}
+
+// SetSize updates the view dimensions
+func (m *ScheduleModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up
+func (m *ScheduleModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *ScheduleModel) MoveDown() {
+	if m.selected < len(m.items)-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// Selected returns the highlighted issue, or nil when nothing is scheduled
+func (m *ScheduleModel) Selected() *analysis.ScheduledIssue {
+	if m.selected < 0 || m.selected >= len(m.items) {
+		return nil
+	}
+	return &m.items[m.selected]
+}
+
+// visibleRows returns how many bars fit between the header and the legend
+func (m *ScheduleModel) visibleRows() int {
+	return max(m.height-7, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *ScheduleModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the timeline
+func (m *ScheduleModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	people := make(map[string]bool)
+	for _, item := range m.items {
+		people[item.Assignee] = true
+	}
+	header := fmt.Sprintf("🗓️  SCHEDULE  │  %d issues  │  %d people  │  %s a day each",
+		len(m.items), len(people), FormatMinutes(analysis.DefaultDayMinutes))
+	if len(m.items) > 0 {
+		header += "  │  done " + timefmt.Day(m.schedule.Finish)
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(header))
+	lines = append(lines, "")
+
+	if len(m.items) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		msg := "No open work to schedule."
+		if n := len(m.schedule.Unscheduled); n > 0 {
+			msg = fmt.Sprintf("All %d open issues wait on a dependency cycle.", n)
+		}
+		lines = append(lines, emptyStyle.Render(msg))
+		return strings.Join(lines, "\n")
+	}
+
+	const idWidth, whoWidth, datesWidth = 12, 12, 16
+	labelWidth := 2 + idWidth + 1 + whoWidth + 1
+	// Up to a few days past the last finish, as far as the width allows
+	last := 0
+	for _, item := range m.items {
+		last = max(last, item.FinishDay)
+	}
+	days := max(min((m.width-labelWidth-datesWidth-2)/scheduleDayWidth, last+3), 5)
+	dates := m.workdays(days)
+	lines = append(lines, subtle.Render(fmt.Sprintf("%-*s", labelWidth, "  ISSUE        ASSIGNEE")+renderDayScale(dates)))
+
+	estimatedStyle := t.Renderer.NewStyle().Foreground(t.Primary)
+	assumedStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	end := min(m.scrollOffset+m.visibleRows(), len(m.items))
+	lastLane := ""
+	for i := m.scrollOffset; i < end; i++ {
+		item := m.items[i]
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+
+		who := "@" + item.Assignee
+		if item.Assignee == "" {
+			who = "(anyone)"
+		}
+		if item.Suggested {
+			who += "?"
+		}
+		if m.byAssignee && i > m.scrollOffset && item.Assignee == lastLane {
+			who = ""
+		}
+		lastLane = item.Assignee
+
+		bar := "█"
+		barStyle := estimatedStyle
+		if !item.Estimated {
+			bar, barStyle = "▒", assumedStyle
+		}
+		var sb strings.Builder
+		for d, day := range dates {
+			switch {
+			case d >= item.StartDay && d <= item.FinishDay:
+				sb.WriteString(barStyle.Render(strings.Repeat(bar, scheduleDayWidth)))
+			case day.Weekday() == time.Monday:
+				sb.WriteString(subtle.Render("┊" + strings.Repeat(" ", scheduleDayWidth-1)))
+			default:
+				sb.WriteString(strings.Repeat(" ", scheduleDayWidth))
+			}
+		}
+		overflow := " "
+		if item.FinishDay >= days {
+			overflow = subtle.Render("→")
+		}
+
+		span := item.Start.Format("Jan 02")
+		if !item.Finish.Equal(item.Start) {
+			span += "–" + item.Finish.Format("Jan 02")
+		}
+		lines = append(lines, rowStyle.Render(prefix+fitWidth(item.ID, idWidth)+" "+fitWidth(who, whoWidth)+" ")+
+			sb.String()+overflow+" "+subtle.Render(span))
+	}
+	if len(m.items) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.items)-end)))
+	}
+
+	lines = append(lines, "")
+	legend := "█ estimated  ▒ no estimate, one day assumed  ? suggested owner"
+	if n := len(m.schedule.Unscheduled); n > 0 {
+		legend += fmt.Sprintf("  ·  %d waiting on a dependency cycle: %s", n, strings.Join(m.schedule.Unscheduled, ", "))
+	}
+	if sel := m.Selected(); sel != nil {
+		lines = append(lines, " "+t.Renderer.NewStyle().Bold(true).Render(sel.ID)+" "+
+			truncateToWidth(sel.Title, max(m.width-40, 10), "…")+
+			subtle.Render(fmt.Sprintf("  ·  %s of work", FormatMinutes(sel.Minutes))))
+	}
+	lines = append(lines, subtle.Render(" "+truncateToWidth(legend, max(m.width-2, 10), "…")))
+	return strings.Join(lines, "\n")
+}
+
+// workdays returns the dates of the first n working days from today
+func (m *ScheduleModel) workdays(n int) []time.Time {
+	dates := make([]time.Time, 0, n)
+	day := time.Date(m.now.Year(), m.now.Month(), m.now.Day(), 0, 0, 0, 0, m.now.Location())
+	for len(dates) < n {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			dates = append(dates, day)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return dates
+}
+
+// renderDayScale labels today and each Monday along the timeline
+func renderDayScale(dates []time.Time) string {
+	row := []rune(strings.Repeat(" ", len(dates)*scheduleDayWidth))
+	lastLabel := -len(row)
+	for d, day := range dates {
+		label := []rune(day.Format("Jan 02"))
+		col := d * scheduleDayWidth
+		if (d == 0 || day.Weekday() == time.Monday) && col-lastLabel > len(label) && col+len(label) <= len(row) {
+			copy(row[col:], label)
+			lastLabel = col
+		}
+	}
+	return strings.TrimRight(string(row), " ")
+}
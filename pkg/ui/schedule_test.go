@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestScheduleViewPlotsAndJumps(t *testing.T) {
+	est := func(minutes int) *int { return &minutes }
+	issues := []model.Issue{
+		{ID: "S1", Title: "Schema", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 0, Assignee: "sam", EstimatedMinutes: est(12 * 60)},
+		{ID: "S2", Title: "Migrate", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 1, Assignee: "kim",
+			Dependencies: []*model.Dependency{{DependsOnID: "S1", Type: model.DepBlocks}}},
+		{ID: "S3", Title: "Docs", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}, Alt: true})
+	m = updated.(Model)
+	if !m.isScheduleView || m.focused != focusSchedule {
+		t.Fatalf("expected alt+s to open the schedule")
+	}
+	out := m.View()
+	for _, want := range []string{"SCHEDULE", "3 issues", "@sam", "@kim?", "██", "▒▒", "suggested owner"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the timeline:\n%s", want, out)
+		}
+	}
+
+	// S3 and S1 both start today; by assignee, kim's two issues come
+	// first and the selection stays on S1
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	if sel := m.scheduleView.Selected(); sel == nil || sel.ID != "S1" || m.scheduleView.items[0].Assignee != "kim" {
+		t.Fatalf("expected lanes with S1 still selected, got %+v", m.scheduleView.items)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isScheduleView {
+		t.Fatalf("expected enter to leave the schedule")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "S1" {
+		t.Errorf("expected S1 selected, got %v", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// scrollbarCells returns the height cells of a vertical scrollbar for content
+// of total lines (or items), visible of them shown from offset. The thumb is
+// sized to the visible share and never smaller than one cell. It returns nil
+// when everything fits.
+func scrollbarCells(height, total, visible, offset int) []bool {
+	if height < 1 || total <= visible || visible < 1 {
+		return nil
+	}
+	thumb := max(height*visible/total, 1)
+	pos := 0
+	if span := total - visible; span > 0 {
+		pos = ((height-thumb)*min(max(offset, 0), span) + span/2) / span
+	}
+	cells := make([]bool, height)
+	for i := pos; i < pos+thumb && i < height; i++ {
+		cells[i] = true
+	}
+	return cells
+}
+
+// withScrollbar draws a scrollbar in the last column of view, which is width
+// wide, giving the position of visible of total lines (or items) scrolled to
+// offset. The view is returned unchanged when everything fits.
+func withScrollbar(view string, width, total, visible, offset int, t Theme) string {
+	if width < 2 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	cells := scrollbarCells(len(lines), total, visible, offset)
+	if cells == nil {
+		return view
+	}
+	track := t.Renderer.NewStyle().Foreground(ColorBgHighlight).Render("│")
+	thumb := t.Renderer.NewStyle().Foreground(t.Secondary).Render("┃")
+	// The bar is two columns wide where box drawing is
+	room := width - lipgloss.Width(track)
+	for i, line := range lines {
+		line = ansi.Truncate(line, room, "")
+		line += strings.Repeat(" ", max(room-lipgloss.Width(line), 0))
+		if cells[i] {
+			lines[i] = line + thumb
+		} else {
+			lines[i] = line + track
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// listScrollbar draws the list's scrollbar beside its rendered page
+func (m *Model) listScrollbar(view string) string {
+	p := m.list.Paginator
+	return withScrollbar(view, m.list.Width(), len(m.list.VisibleItems()), p.PerPage, p.Page*p.PerPage, m.theme)
+}
+
+// detailScrollbar draws the detail viewport's scrollbar beside its view
+func (m *Model) detailScrollbar(view string) string {
+	return withScrollbar(view, m.viewport.Width, m.viewport.TotalLineCount(), m.viewport.Height, m.viewport.YOffset, m.theme)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestScrollbarCells(t *testing.T) {
+	if cells := scrollbarCells(10, 5, 10, 0); cells != nil {
+		t.Fatalf("expected no scrollbar when everything fits, got %v", cells)
+	}
+
+	thumb := func(cells []bool) (first, size int) {
+		first = -1
+		for i, on := range cells {
+			if on {
+				if first < 0 {
+					first = i
+				}
+				size++
+			}
+		}
+		return first, size
+	}
+
+	// 10 rows showing a quarter of the content
+	if first, size := thumb(scrollbarCells(10, 40, 10, 0)); first != 0 || size != 2 {
+		t.Errorf("expected a 2-cell thumb at the top, got %d cells at %d", size, first)
+	}
+	if first, size := thumb(scrollbarCells(10, 40, 10, 30)); first != 8 || size != 2 {
+		t.Errorf("expected the thumb at the bottom, got %d cells at %d", size, first)
+	}
+	if first, _ := thumb(scrollbarCells(10, 40, 10, 15)); first != 4 {
+		t.Errorf("expected the thumb halfway, got %d", first)
+	}
+	// Very long content still gets a visible thumb
+	if _, size := thumb(scrollbarCells(10, 100000, 10, 500)); size != 1 {
+		t.Errorf("expected a 1-cell thumb, got %d", size)
+	}
+}
+
+func TestWithScrollbar(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	view := "first line that is too long\nsecond\nthird"
+
+	if got := withScrollbar(view, 10, 3, 3, 0, theme); got != view {
+		t.Fatalf("expected the view unchanged when it all fits, got %q", got)
+	}
+
+	lines := strings.Split(withScrollbar(view, 10, 9, 3, 0, theme), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w != 10 {
+			t.Errorf("line %d: expected width 10, got %d (%q)", i, w, line)
+		}
+	}
+	if !strings.HasSuffix(lines[0], "┃") || !strings.HasSuffix(lines[2], "│") {
+		t.Errorf("expected the thumb at the top and track below: %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "first lin") {
+		t.Errorf("expected the long line cut to make room: %q", lines[0])
+	}
+}
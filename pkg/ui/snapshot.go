@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SnapshotInfo describes a historical state loaded with "view as of" (H)
+type SnapshotInfo struct {
+	Spec   string                 // The date or revision the user asked for
+	Commit loader.RevisionInfo    // The commit it resolved to
+	Diff   *analysis.SnapshotDiff // What changed between the snapshot and today
+
+	today []model.Issue // The live issues, restored when leaving the snapshot
+}
+
+// Label returns a short description like "2024-03-01 (a1b2c3d)"
+func (s *SnapshotInfo) Label() string {
+	sha := shortSHA(s.Commit.SHA)
+	if s.Commit.Timestamp.IsZero() {
+		return fmt.Sprintf("%s (%s)", s.Spec, sha)
+	}
+	return fmt.Sprintf("%s (%s)", timefmt.Date(s.Commit.Timestamp), sha)
+}
+
+// SnapshotCmd loads the issues as they were at spec (a date or git revision)
+// from the git history in repoDir and analyzes them off the UI goroutine. The
+// result arrives as an IssuesReloadedMsg with Snapshot set, diffed against today.
+func SnapshotCmd(repoDir, spec string, today []model.Issue, base reloadBase) tea.Cmd {
+	return func() tea.Msg {
+		info := &SnapshotInfo{Spec: spec, today: today}
+		gitLoader := loader.NewGitLoader(repoDir)
+
+		commit, err := gitLoader.DescribeRevision(spec)
+		if err != nil {
+			return IssuesReloadedMsg{Snapshot: info, Err: fmt.Errorf("no commit for %q", spec)}
+		}
+		info.Commit = commit
+		issues, err := gitLoader.LoadAt(commit.SHA)
+		if err != nil {
+			return IssuesReloadedMsg{Snapshot: info, Err: fmt.Errorf("no beads history at %s", spec)}
+		}
+
+		info.Diff = analysis.CompareSnapshots(analysis.NewSnapshot(issues), analysis.NewSnapshot(today))
+		msg := analyzeLoad(issues, base)
+		msg.Snapshot = info
+		return msg
+	}
+}
+
+// leaveSnapshotCmd returns to the live issues: re-read from disk when there is
+// a beads file, or the issues saved when the snapshot was taken
+func leaveSnapshotCmd(beadsPath string, snapshot *SnapshotInfo, base reloadBase) tea.Cmd {
+	if beadsPath != "" {
+		return ReloadIssuesCmd(beadsPath, base)
+	}
+	today := slices.Clone(snapshot.today)
+	return func() tea.Msg {
+		return analyzeLoad(today, base)
+	}
+}
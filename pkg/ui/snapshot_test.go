@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestViewAsOfSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init")
+	git("config", "user.email", "test@test.com")
+	git("config", "user.name", "Test User")
+
+	beads := filepath.Join(dir, ".beads", "beads.jsonl")
+	os.MkdirAll(filepath.Dir(beads), 0755)
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(beads, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"id":"A","title":"Alpha","status":"open","issue_type":"task"}` + "\n")
+	git("add", ".")
+	git("commit", "-m", "first")
+	write(`{"id":"A","title":"Alpha","status":"closed","issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"Beta","status":"open","issue_type":"task"}` + "\n")
+	git("add", ".")
+	git("commit", "-m", "second")
+
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = updated.(Model)
+	if !m.showTimeTravelPrompt || !m.timeTravelAsOf {
+		t.Fatalf("expected the view-as-of prompt")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("HEAD~1")})
+	m = updated.(Model)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	if m.snapshot == nil || len(m.issues) != 1 || m.issues[0].Status != "open" {
+		t.Fatalf("expected the first commit's state, got %+v", m.issues)
+	}
+	if !strings.Contains(m.statusMsg, "since then +1 new, 1 closed") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	m.statusMsg = "" // The footer shows once the status clears
+	if !strings.Contains(m.View(), "🕰 as of") {
+		t.Errorf("expected the snapshot badge in the footer:\n%s", m.View())
+	}
+
+	// H again returns to today
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.snapshot != nil || len(m.issues) != 2 {
+		t.Fatalf("expected the live issues back, got %d", len(m.issues))
+	}
+}
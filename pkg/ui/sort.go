@@ -0,0 +1,325 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SortField identifies the key used to order the issue list
+type SortField int
+
+const (
+	SortDefault    SortField = iota // Built-in ordering (or recipe ordering when active)
+	SortPriority                    // Priority number (P0 first when ascending)
+	SortAge                         // Time since creation
+	SortUpdated                     // Last update time
+	SortImpact                      // Critical path score
+	SortBlockers                    // Number of open blockers
+	SortDependents                  // Number of issues depending on this one
+	SortCustom                      // The user's sort script (listed only when configured)
+	sortFieldCount                  // Sentinel for cycling
+)
+
+// String returns the display name of the sort field
+func (f SortField) String() string {
+	switch f {
+	case SortPriority:
+		return "Priority"
+	case SortAge:
+		return "Age"
+	case SortUpdated:
+		return "Updated"
+	case SortImpact:
+		return "Impact"
+	case SortBlockers:
+		return "Blockers"
+	case SortDependents:
+		return "Dependents"
+	case SortCustom:
+		return "Custom"
+	default:
+		return "Default"
+	}
+}
+
+// DefaultDescending reports the natural direction for a field when first selected.
+// Priority reads best P0-first and a custom key smallest-first, like a
+// sorted() call; everything else reads best largest-first.
+func (f SortField) DefaultDescending() bool {
+	return f != SortPriority && f != SortDefault && f != SortCustom
+}
+
+// SortMode is the active list ordering
+type SortMode struct {
+	Field      SortField
+	Descending bool
+}
+
+// IsDefault returns true when no explicit sort has been chosen
+func (s SortMode) IsDefault() bool {
+	return s.Field == SortDefault
+}
+
+// Label returns a compact header label such as "Impact ↓" (empty for default)
+func (s SortMode) Label() string {
+	if s.IsDefault() {
+		return ""
+	}
+	arrow := "↑"
+	if s.Descending {
+		arrow = "↓"
+	}
+	return s.Field.String() + " " + arrow
+}
+
+// sortIssueItems orders list items in place according to mode.
+// openBlockers and dependents supply per-issue counts for the graph-based keys.
+// Ties are broken by ID so the order is deterministic across reloads.
+func sortIssueItems(items []list.Item, mode SortMode, openBlockers, dependents func(id string) int) {
+	if mode.IsDefault() {
+		return
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, aok := items[i].(IssueItem)
+		b, bok := items[j].(IssueItem)
+		if !aok || !bok {
+			return false
+		}
+
+		// Issues the sort script gave no value sort last either way
+		if mode.Field == SortCustom {
+			if aNone, bNone := scriptSortKey(a) == nil, scriptSortKey(b) == nil; aNone != bNone {
+				return bNone
+			}
+		}
+
+		cmp := compareIssueItems(a, b, mode.Field, openBlockers, dependents)
+		if cmp == 0 {
+			return a.Issue.ID < b.Issue.ID
+		}
+		if mode.Descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
+// compareIssueItems returns -1, 0 or 1 comparing a and b on field
+func compareIssueItems(a, b IssueItem, field SortField, openBlockers, dependents func(id string) int) int {
+	switch field {
+	case SortPriority:
+		return compareInts(a.Issue.Priority, b.Issue.Priority)
+	case SortAge:
+		// Older issues have a larger age
+		return compareInts(int(b.Issue.CreatedAt.Unix()), int(a.Issue.CreatedAt.Unix()))
+	case SortUpdated:
+		return compareInts(int(a.Issue.UpdatedAt.Unix()), int(b.Issue.UpdatedAt.Unix()))
+	case SortImpact:
+		return compareFloats(a.Impact, b.Impact)
+	case SortBlockers:
+		return compareInts(openBlockers(a.Issue.ID), openBlockers(b.Issue.ID))
+	case SortDependents:
+		return compareInts(dependents(a.Issue.ID), dependents(b.Issue.ID))
+	case SortCustom:
+		// Keys of mixed types (a script returning numbers and strings) tie
+		cmp, _ := script.Compare(scriptSortKey(a), scriptSortKey(b))
+		return cmp
+	}
+	return 0
+}
+
+// scriptSortKey returns the sort script's value for an item, nil if none
+func scriptSortKey(i IssueItem) any {
+	if i.Script == nil {
+		return nil
+	}
+	return i.Script.Sort
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// countOpenBlockers returns how many blocking dependencies of id are still open
+func countOpenBlockers(issue *model.Issue, issueMap map[string]*model.Issue) int {
+	if issue == nil {
+		return 0
+	}
+	count := 0
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlockingOrLegacy() {
+			continue
+		}
+		if blocker, ok := issueMap[dep.DependsOnID]; ok && blocker.Status != model.StatusClosed {
+			count++
+		}
+	}
+	return count
+}
+
+// withHeaderIndicator right-aligns indicator inside a header line of the given width
+func withHeaderIndicator(header, indicator string, width int) string {
+	if indicator == "" {
+		return header
+	}
+	gap := width - lipgloss.Width(header) - lipgloss.Width(indicator) - 1
+	if gap < 1 {
+		return header
+	}
+	return header + strings.Repeat(" ", gap) + indicator + " "
+}
+
+// SortPickerModel is the overlay menu for choosing the list sort
+type SortPickerModel struct {
+	fields        []SortField
+	selectedIndex int
+	current       SortMode
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewSortPickerModel creates a sort menu listing every sort field
+func NewSortPickerModel(theme Theme) SortPickerModel {
+	fields := make([]SortField, 0, int(sortFieldCount))
+	for f := SortDefault; f < sortFieldCount; f++ {
+		if f != SortCustom {
+			fields = append(fields, f)
+		}
+	}
+	return SortPickerModel{fields: fields, theme: theme}
+}
+
+// SetCustomSort lists or hides the Custom field, which needs a sort script
+func (m *SortPickerModel) SetCustomSort(enabled bool) {
+	fields := m.fields[:0:0]
+	for _, f := range m.fields {
+		if f != SortCustom {
+			fields = append(fields, f)
+		}
+	}
+	if enabled {
+		fields = append(fields, SortCustom)
+	}
+	m.fields = fields
+	if m.selectedIndex >= len(m.fields) {
+		m.selectedIndex = 0
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *SortPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetCurrent records the active mode and moves the cursor onto it
+func (m *SortPickerModel) SetCurrent(mode SortMode) {
+	m.current = mode
+	for i, f := range m.fields {
+		if f == mode.Field {
+			m.selectedIndex = i
+			return
+		}
+	}
+}
+
+// MoveUp moves selection up
+func (m *SortPickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *SortPickerModel) MoveDown() {
+	if m.selectedIndex < len(m.fields)-1 {
+		m.selectedIndex++
+	}
+}
+
+// Choose returns the mode resulting from selecting the highlighted field.
+// Choosing the field that is already active flips its direction.
+func (m *SortPickerModel) Choose() SortMode {
+	if len(m.fields) == 0 {
+		return m.current
+	}
+	field := m.fields[m.selectedIndex]
+	if field == m.current.Field && field != SortDefault {
+		return SortMode{Field: field, Descending: !m.current.Descending}
+	}
+	return SortMode{Field: field, Descending: field.DefaultDescending()}
+}
+
+// View renders the sort menu overlay
+func (m *SortPickerModel) View() string {
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Sort By"))
+	lines = append(lines, "")
+
+	for i, f := range m.fields {
+		style := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+		prefix := "  "
+		if i == m.selectedIndex {
+			style = style.Foreground(t.Primary).Bold(true)
+			prefix = "▸ "
+		}
+		name := f.String()
+		if f == m.current.Field {
+			if label := m.current.Label(); label != "" {
+				name = label
+			}
+			name += " (active)"
+		}
+		lines = append(lines, style.Render(prefix+name))
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("j/k: navigate • enter: apply (again to reverse) • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
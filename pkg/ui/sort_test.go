@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func sortTestIssues() []model.Issue {
+	now := time.Now()
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen, Priority: 2, CreatedAt: now.Add(-1 * time.Hour), UpdatedAt: now.Add(-3 * time.Hour)},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Priority: 0, CreatedAt: now.Add(-5 * time.Hour), UpdatedAt: now.Add(-1 * time.Hour),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Priority: 1, CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour),
+			Dependencies: []*model.Dependency{
+				{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks},
+				{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks},
+			}},
+	}
+}
+
+func visibleIDs(m Model) []string {
+	var ids []string
+	for _, issue := range m.FilteredIssues() {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestSortModeOrdersList(t *testing.T) {
+	m := NewModel(sortTestIssues(), nil, "")
+
+	tests := []struct {
+		mode SortMode
+		want string
+	}{
+		{SortMode{Field: SortPriority}, "B,C,A"},
+		{SortMode{Field: SortPriority, Descending: true}, "A,C,B"},
+		{SortMode{Field: SortAge, Descending: true}, "B,C,A"},
+		{SortMode{Field: SortUpdated, Descending: true}, "B,C,A"},
+		{SortMode{Field: SortBlockers, Descending: true}, "C,B,A"},
+		{SortMode{Field: SortDependents, Descending: true}, "A,B,C"},
+	}
+	for _, tt := range tests {
+		m.SetSortMode(tt.mode)
+		if got := strings.Join(visibleIDs(m), ","); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.mode.Label(), got, tt.want)
+		}
+	}
+}
+
+func TestSortByBlockersCountsUntypedDependencies(t *testing.T) {
+	// Older beads files leave the type of blocks dependencies empty
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{
+				{IssueID: "C", DependsOnID: "A"},
+				{IssueID: "C", DependsOnID: "B"},
+			}},
+		{ID: "D", Title: "D", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "D", DependsOnID: "A", Type: model.DepRelated}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetSortMode(SortMode{Field: SortBlockers, Descending: true})
+	if got := strings.Join(visibleIDs(m), ","); !strings.HasPrefix(got, "C,B,") {
+		t.Fatalf("expected C's two untyped blockers to sort it first, got %s", got)
+	}
+}
+
+func TestSortSurvivesFilter(t *testing.T) {
+	issues := sortTestIssues()
+	issues[0].Status = model.StatusClosed
+	m := NewModel(issues, nil, "")
+	m.SetSortMode(SortMode{Field: SortPriority, Descending: true})
+	m.SetFilter("open")
+	if got := strings.Join(visibleIDs(m), ","); got != "C,B" {
+		t.Fatalf("expected filtered list sorted C,B, got %s", got)
+	}
+}
+
+func TestSortModeLabel(t *testing.T) {
+	if (SortMode{}).Label() != "" {
+		t.Fatalf("default sort should have empty label")
+	}
+	if got := (SortMode{Field: SortImpact, Descending: true}).Label(); got != "Impact ↓" {
+		t.Fatalf("unexpected label %q", got)
+	}
+}
+
+func TestSortPickerChooseFlipsActiveField(t *testing.T) {
+	p := NewSortPickerModel(DefaultTheme(lipgloss.NewRenderer(nil)))
+	p.SetCurrent(SortMode{Field: SortImpact, Descending: true})
+	if got := p.Choose(); got.Field != SortImpact || got.Descending {
+		t.Fatalf("choosing active field should flip direction, got %+v", got)
+	}
+	p.MoveDown()
+	if got := p.Choose(); got.Field != SortBlockers || !got.Descending {
+		t.Fatalf("choosing new field should use its default direction, got %+v", got)
+	}
+}
+
+func TestSortKeysAndHeader(t *testing.T) {
+	m := NewModel(sortTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 90, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = updated.(Model)
+	if !m.showSortPicker || m.focused != focusSortPicker {
+		t.Fatalf("expected sort picker open")
+	}
+	// Move to Priority and apply
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showSortPicker || m.SortMode().Field != SortPriority {
+		t.Fatalf("expected priority sort applied, got %+v", m.SortMode())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	m = updated.(Model)
+	if !m.SortMode().Descending {
+		t.Fatalf("expected S to reverse direction")
+	}
+	if !strings.Contains(m.View(), "Priority ↓") {
+		t.Fatalf("expected active sort shown in header")
+	}
+}
+
+func TestCustomSortScript(t *testing.T) {
+	now := time.Now()
+	soon, later := now.Add(24*time.Hour), now.Add(10*24*time.Hour)
+	issues := []model.Issue{
+		{ID: "a", Title: "No due date", Status: model.StatusOpen, CreatedAt: now},
+		{ID: "b", Title: "Due later", Status: model.StatusOpen, CreatedAt: now, DueDate: &later},
+		{ID: "c", Title: "Due soon", Status: model.StatusOpen, CreatedAt: now, DueDate: &soon},
+	}
+	m := NewModel(issues, nil, "")
+	sortKey, err := script.Compile("due_days")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hours, _ := script.Compile("len(title)")
+	m.SetScripts(&script.Engine{Sort: sortKey, Columns: []script.Column{{Name: "len", Program: hours}}})
+	m.SetSortMode(SortMode{Field: SortCustom})
+
+	var ids []string
+	for _, item := range m.list.Items() {
+		ids = append(ids, item.(IssueItem).Issue.ID)
+	}
+	if strings.Join(ids, ",") != "c,b,a" {
+		t.Errorf("expected soonest first and no due date last, got %v", ids)
+	}
+
+	m.SetSortMode(SortMode{Field: SortCustom, Descending: true})
+	ids = ids[:0]
+	for _, item := range m.list.Items() {
+		ids = append(ids, item.(IssueItem).Issue.ID)
+	}
+	if strings.Join(ids, ",") != "b,c,a" {
+		t.Errorf("expected no due date last when reversed too, got %v", ids)
+	}
+
+	table := m.viewTable()
+	last := len(table.Header) - 1
+	if table.Header[last] != "len" || table.Rows[0][last] != "9" || !table.Numeric[last] {
+		t.Errorf("expected the computed column in exports, got %v / %v", table.Header, table.Rows[0])
+	}
+
+	picker := NewSortPickerModel(m.theme)
+	for _, f := range picker.fields {
+		if f == SortCustom {
+			t.Errorf("Custom should be hidden without a sort script")
+		}
+	}
+	picker.SetCustomSort(true)
+	if picker.fields[len(picker.fields)-1] != SortCustom {
+		t.Errorf("expected Custom to be listed with a sort script")
+	}
+}
+
+func TestScriptColumnsFollowReloadWithSameStructure(t *testing.T) {
+	issues := sortTestIssues()
+	m := NewModel(issues, nil, "")
+	status, err := script.Compile("status + ' p' + str(priority)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetScripts(&script.Engine{Columns: []script.Column{{Name: "state", Program: status}}})
+	column := func(m Model, id string) any {
+		for _, item := range m.list.Items() {
+			if item := item.(IssueItem); item.Issue.ID == id {
+				return item.Script.Columns[0]
+			}
+		}
+		t.Fatalf("%s not listed", id)
+		return nil
+	}
+	if got := column(m, "A"); got != "open p2" {
+		t.Fatalf("expected the initial status, got %v", got)
+	}
+
+	// An edit that leaves the graph alone reuses the previous analysis
+	reloaded := sortTestIssues()
+	reloaded[0].Status, reloaded[0].Priority = model.StatusInProgress, 0
+	updated, _ := m.Update(IssuesReloadedMsg{
+		Issues:   reloaded,
+		Analyzer: m.analyzer,
+		Stats:    m.analysis,
+		Reused:   true,
+		Changes:  analysis.IssueChanges{Modified: []string{"A"}},
+	})
+	if got := column(updated.(Model), "A"); got != "in_progress p0" {
+		t.Errorf("expected the script to see the reloaded status, got %v", got)
+	}
+}
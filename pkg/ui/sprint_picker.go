@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sprintFilterPrefix marks a currentFilter value that restricts the list and board to one sprint
+const sprintFilterPrefix = "sprint:"
+
+// SprintPickerModel is the overlay menu for scoping the views to a sprint
+type SprintPickerModel struct {
+	stats         []analysis.SprintStats
+	selectedIndex int // 0 is "All issues"; 1..n index into stats
+	active        string
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewSprintPickerModel creates a sprint menu from the issues' sprints
+func NewSprintPickerModel(issues []model.Issue, theme Theme) SprintPickerModel {
+	return SprintPickerModel{
+		stats: analysis.ComputeSprintStats(issues),
+		theme: theme,
+	}
+}
+
+// SetIssues refreshes the sprint list (e.g. after a reload)
+func (m *SprintPickerModel) SetIssues(issues []model.Issue) {
+	m.stats = analysis.ComputeSprintStats(issues)
+	if m.selectedIndex > len(m.stats) {
+		m.selectedIndex = len(m.stats)
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *SprintPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetActive records the current sprint scope and moves the cursor onto it.
+// With no active sprint the cursor starts on the latest sprint.
+func (m *SprintPickerModel) SetActive(name string) {
+	m.active = name
+	m.selectedIndex = 0
+	if len(m.stats) > 0 {
+		m.selectedIndex = 1
+	}
+	for i, s := range m.stats {
+		if s.Name == name {
+			m.selectedIndex = i + 1
+			break
+		}
+	}
+}
+
+// SprintCount returns the number of sprints found
+func (m *SprintPickerModel) SprintCount() int {
+	return len(m.stats)
+}
+
+// MoveUp moves selection up
+func (m *SprintPickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *SprintPickerModel) MoveDown() {
+	if m.selectedIndex < len(m.stats) {
+		m.selectedIndex++
+	}
+}
+
+// SelectedSprint returns the highlighted sprint name, or "" for "All issues"
+func (m *SprintPickerModel) SelectedSprint() string {
+	if m.selectedIndex <= 0 || m.selectedIndex > len(m.stats) {
+		return ""
+	}
+	return m.stats[m.selectedIndex-1].Name
+}
+
+// FormatSprintProgress summarizes committed vs done work, e.g. "3/8 done · 6h/14h"
+func FormatSprintProgress(s analysis.SprintStats) string {
+	text := fmt.Sprintf("%d/%d done", s.Done, s.Committed)
+	if s.HasPoints() {
+		text += fmt.Sprintf(" · %s/%s", FormatMinutes(s.DonePoints), FormatMinutes(s.CommittedPoints))
+	}
+	return text
+}
+
+// View renders the sprint menu overlay
+func (m *SprintPickerModel) View() string {
+	t := m.theme
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Sprints"))
+	lines = append(lines, "")
+
+	if len(m.stats) == 0 {
+		hint := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+		lines = append(lines, hint.Render("No sprints found."))
+		lines = append(lines, hint.Render("Set the sprint field or add a sprint:<name> label."))
+	}
+
+	nameWidth := 8
+	for _, s := range m.stats {
+		if w := lipgloss.Width(s.Name); w > nameWidth {
+			nameWidth = min(w, 24)
+		}
+	}
+
+	for i := 0; i <= len(m.stats); i++ {
+		style := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+		prefix := "  "
+		if i == m.selectedIndex {
+			style = style.Foreground(t.Primary).Bold(true)
+			prefix = "▸ "
+		}
+		if i == 0 {
+			lines = append(lines, style.Render(prefix+"All issues"))
+			continue
+		}
+
+		s := m.stats[i-1]
+		name := fitWidth(s.Name, nameWidth)
+		progress := t.Renderer.NewStyle().Foreground(t.Secondary).Render(FormatSprintProgress(s))
+		line := style.Render(prefix+name) + " " + RenderMiniBar(s.Progress(), 10) +
+			fmt.Sprintf(" %3.0f%% ", s.Progress()*100) + progress
+		if s.Name == m.active {
+			line += " (active)"
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("j/k: navigate • enter: scope list & board • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func sprintTestIssues() []model.Issue {
+	est := 60
+	return []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusClosed, Labels: []string{"sprint:2"}, EstimatedMinutes: &est},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Labels: []string{"sprint:2"}, EstimatedMinutes: &est},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Sprint: "1"},
+		{ID: "D", Title: "D", Status: model.StatusOpen},
+	}
+}
+
+func TestSprintPickerScopesListAndBoard(t *testing.T) {
+	m := NewModel(sprintTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+
+	// Open from the board so focus returns there
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	m = updated.(Model)
+	if !m.showSprintPicker || m.focused != focusSprintPicker {
+		t.Fatalf("expected sprint picker open")
+	}
+	if m.sprintPicker.SprintCount() != 2 || m.sprintPicker.SelectedSprint() != "2" {
+		t.Fatalf("expected cursor on latest sprint, got %q of %d", m.sprintPicker.SelectedSprint(), m.sprintPicker.SprintCount())
+	}
+	if view := m.View(); !strings.Contains(view, "1/2 done") {
+		t.Fatalf("expected sprint progress in picker")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.focused != focusBoard || !m.isBoardView {
+		t.Fatalf("expected focus back on board, got %v", m.focused)
+	}
+	// Default order lists open work before closed
+	if got := strings.Join(visibleIDs(m), ","); got != "B,A" {
+		t.Fatalf("expected sprint 2 issues B,A, got %s", got)
+	}
+	if badge := m.sprintBadgeText("2"); !strings.Contains(badge, "50%") {
+		t.Fatalf("expected sprint progress in badge, got %q", badge)
+	}
+}
+
+func TestSprintPickerAllIssuesClearsScope(t *testing.T) {
+	m := NewModel(sprintTestIssues(), nil, "")
+	m.SetFilter(sprintFilterPrefix + "1")
+	if got := strings.Join(visibleIDs(m), ","); got != "C" {
+		t.Fatalf("expected sprint 1 scope, got %s", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	m = updated.(Model)
+	for i := 0; i < 3; i++ {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+		m = updated.(Model)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if len(visibleIDs(m)) != 4 || m.focused != focusList {
+		t.Fatalf("expected scope cleared and list focused, got %v", visibleIDs(m))
+	}
+}
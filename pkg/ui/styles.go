@@ -3,6 +3,9 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -148,6 +151,34 @@ func RenderStatusBadge(status string) string {
 		Render(label)
 }
 
+// RenderDueBadge returns a countdown/overdue badge for an issue's due date,
+// styled for r's terminal.
+// Returns "" when the issue has no due date or is closed.
+func RenderDueBadge(r *lipgloss.Renderer, issue *model.Issue, now time.Time) string {
+	if issue.DueDate == nil || issue.Status.IsClosed() {
+		return ""
+	}
+
+	days := calendarDaysBetween(now, *issue.DueDate)
+	var fg lipgloss.Color
+	icon := "📅"
+	switch {
+	case issue.IsOverdue(now):
+		fg, icon = ColorDanger, "⏰"
+	case days <= 2:
+		fg, icon = ColorWarning, "⏳"
+	case days <= 7:
+		fg = ColorPrioMedium
+	default:
+		fg = ColorMuted
+	}
+
+	return r.NewStyle().
+		Foreground(fg).
+		Bold(days <= 0).
+		Render(icon + " " + FormatDueRel(*issue.DueDate, now))
+}
+
 // ══════════════════════════════════════════════════════════════════════════════
 // METRIC VISUALIZATION - Mini-bars and rank badges
 // ══════════════════════════════════════════════════════════════════════════════
@@ -178,7 +209,8 @@ func RenderMiniBar(value float64, width int) string {
 		barColor = ColorMuted
 	}
 
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	bar := repeatToWidth("█", filled)
+	bar += repeatToWidth("░", width-displayWidth(bar))
 	return lipgloss.NewStyle().Foreground(barColor).Render(bar)
 }
 
@@ -217,7 +249,7 @@ func RenderDivider(width int) string {
 	}
 	return lipgloss.NewStyle().
 		Foreground(ColorBgHighlight).
-		Render(strings.Repeat("─", width))
+		Render(repeatToWidth("─", width))
 }
 
 // RenderSubtleDivider renders a more subtle divider using dots
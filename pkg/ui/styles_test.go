@@ -1,8 +1,14 @@
 package ui
 
 import (
+	"io"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 func TestRenderPriorityBadge(t *testing.T) {
@@ -91,3 +97,51 @@ func TestRenderRankBadge(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderDueBadge(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-72 * time.Hour)
+
+	overdue := &model.Issue{Status: model.StatusOpen, DueDate: &past}
+	if got := RenderDueBadge(lipgloss.DefaultRenderer(), overdue, now); !strings.Contains(got, "3d late") {
+		t.Errorf("expected overdue badge, got %q", got)
+	}
+
+	closed := &model.Issue{Status: model.StatusClosed, DueDate: &past}
+	if got := RenderDueBadge(lipgloss.DefaultRenderer(), closed, now); got != "" {
+		t.Errorf("expected no badge for closed issue, got %q", got)
+	}
+
+	if got := RenderDueBadge(lipgloss.DefaultRenderer(), &model.Issue{Status: model.StatusOpen}, now); got != "" {
+		t.Errorf("expected no badge without due date, got %q", got)
+	}
+}
+
+func TestBadgesFollowTheSessionRenderer(t *testing.T) {
+	due := time.Now().Add(-24 * time.Hour)
+	issue := &model.Issue{Status: model.StatusOpen, DueDate: &due}
+
+	color := lipgloss.NewRenderer(io.Discard)
+	color.SetColorProfile(termenv.TrueColor)
+	plain := lipgloss.NewRenderer(io.Discard)
+	plain.SetColorProfile(termenv.Ascii)
+	// The host's terminal has colors, which a plain session must not pick up
+	host := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(host)
+
+	for name, render := range map[string]func(r *lipgloss.Renderer) string{
+		"due badge":  func(r *lipgloss.Renderer) string { return RenderDueBadge(r, issue, time.Now()) },
+		"label chip": func(r *lipgloss.Renderer) string { return RenderLabelChip(r, "backend") },
+		"label overflow": func(r *lipgloss.Renderer) string {
+			return RenderLabelChips(r, []string{"backend", "frontend", "database"}, 20)
+		},
+	} {
+		if !strings.Contains(render(color), "\x1b[") {
+			t.Errorf("%s: expected colors for a true-color session", name)
+		}
+		if strings.Contains(render(plain), "\x1b[") {
+			t.Errorf("%s: expected no colors for a plain session", name)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxTabs is the most tabs that can be open, one per number key
+const maxTabs = 9
+
+// workspaceTab is the state a tab keeps while another one is shown: its view,
+// what the list shows and which issue is selected
+type workspaceTab struct {
+	view        string // Default key of the view shown, "" for the list (see tabViewNames)
+	showDetails bool
+	filter      string
+	recipe      *recipe.Recipe
+	focus       *subgraphFocus
+	epicScope   string
+	mine        string // The current user while the mine toggle is on
+	sortMode    SortMode
+	groupBy     GroupBy
+	search      string // Fuzzy search applied to the list
+	selectedID  string
+}
+
+// tabViewNames names the views a tab can show, by the default key that opens them
+var tabViewNames = map[string]string{
+	"b":     "board",
+	"g":     "graph",
+	"a":     "actionable",
+	"i":     "insights",
+	"M":     "milestones",
+	"B":     "burndown",
+	"F":     "flow",
+	"V":     "velocity",
+	"W":     "workload",
+	"X":     "duplicates",
+	"P":     "problems",
+	"U":     "activity",
+	"K":     "coupling",
+	"J":     "dsm",
+	"Q":     "risk",
+	"alt+s": "schedule",
+	"alt+a": "aging",
+	"alt+l": "labels",
+	"alt+d": "lineage",
+	"alt+w": "worklog",
+}
+
+// label describes the tab in the status bar
+func (t workspaceTab) label() string {
+	var parts []string
+	if t.recipe != nil {
+		parts = append(parts, t.recipe.Name)
+	} else {
+		parts = append(parts, t.filter)
+	}
+	if t.search != "" {
+		parts = append(parts, fmt.Sprintf("%q", t.search))
+	}
+	if scope := scopeLabel(t.focus, t.epicScope, t.mine); scope != "" {
+		parts = append(parts, scope)
+	}
+	if name := tabViewNames[t.view]; name != "" {
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// currentViewKey returns the default key of the view shown, "" for the list
+func (m *Model) currentViewKey() string {
+	switch {
+	case m.focused == focusInsights:
+		return "i"
+	case m.isBoardView:
+		return "b"
+	case m.isGraphView:
+		return "g"
+	case m.isActionableView:
+		return "a"
+	case m.isMilestoneView:
+		return "M"
+	case m.isBurndownView:
+		return "B"
+	case m.isFlowView:
+		return "F"
+	case m.isVelocityView:
+		return "V"
+	case m.isWorkloadView:
+		return "W"
+	case m.isDuplicatesView:
+		return "X"
+	case m.isProblemsView:
+		return "P"
+	case m.isActivityView:
+		return "U"
+	case m.isCouplingView:
+		return "K"
+	case m.isDSMView:
+		return "J"
+	case m.isRiskView:
+		return "Q"
+	case m.isScheduleView:
+		return "alt+s"
+	case m.isAgingView:
+		return "alt+a"
+	case m.isLabelsView:
+		return "alt+l"
+	case m.isLineageView:
+		return "alt+d"
+	case m.isWorklogView:
+		return "alt+w"
+	}
+	return ""
+}
+
+// captureTab records the current view, filter and selection as a tab
+func (m *Model) captureTab() workspaceTab {
+	t := workspaceTab{
+		view:        m.currentViewKey(),
+		showDetails: m.showDetails,
+		filter:      m.currentFilter,
+		recipe:      m.activeRecipe,
+		epicScope:   m.epicScope,
+		mine:        m.mineName(),
+		sortMode:    m.sortMode,
+		groupBy:     m.groupBy,
+	}
+	if m.focus != nil {
+		focus := *m.focus
+		t.focus = &focus
+	}
+	if m.list.FilterState() == list.FilterApplied {
+		t.search = m.list.FilterValue()
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		t.selectedID = item.Issue.ID
+	}
+	return t
+}
+
+// loadTab shows a tab's view, filter and selection in place of the current ones
+func (m Model) loadTab(t workspaceTab) (Model, tea.Cmd) {
+	m.clearViews()
+	m.showDetails = false
+	m.focused = focusList
+	m.pager.query = ""
+
+	m.currentFilter = t.filter
+	m.activeRecipe = t.recipe
+	m.epicScope = t.epicScope
+	m.mineOnly = t.mine != ""
+	m.focus = nil
+	if t.focus != nil {
+		focus := *t.focus
+		m.focus = &focus
+	}
+	m.sortMode = t.sortMode
+	m.groupBy = t.groupBy
+	m.list.ResetFilter()
+	m.rebuildListWithDiffInfo()
+	if t.search != "" {
+		m.list.SetFilterText(t.search)
+	}
+	for i, item := range m.list.VisibleItems() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == t.selectedID {
+			m.list.Select(i)
+			break
+		}
+	}
+
+	var cmd tea.Cmd
+	if t.view != "" {
+		m, cmd = m.pressDefaultKey(t.view)
+	} else if t.showDetails && !m.isSplitView {
+		m.showDetails = true
+	}
+	m.updateViewportContent()
+	return m, cmd
+}
+
+// pressDefaultKey runs the top-level handling of key as if it had been
+// pressed, ignoring rebound keys and plugins so key means its default action
+func (m Model) pressDefaultKey(key string) (Model, tea.Cmd) {
+	translation, plugins := m.keyTranslation, m.plugins
+	m.keyTranslation, m.plugins = nil, nil
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	m = updated.(Model)
+	m.keyTranslation, m.plugins = translation, plugins
+	m.pendingTab = nil
+	return m, cmd
+}
+
+// tabCount returns how many tabs are open; there is always at least one
+func (m *Model) tabCount() int {
+	return max(len(m.tabs), 1)
+}
+
+// switchTab saves the current tab and shows tab i
+func (m Model) switchTab(i int) (Model, tea.Cmd) {
+	if i < 0 || i >= m.tabCount() {
+		m.statusMsg = fmt.Sprintf("No tab %d (%d open)", i+1, m.tabCount())
+		m.statusIsError = true
+		return m, nil
+	}
+	if len(m.tabs) == 0 {
+		m.tabs = make([]workspaceTab, 1)
+	}
+	if i != m.activeTab {
+		m.tabs[m.activeTab] = m.captureTab()
+		m.activeTab = i
+		var cmd tea.Cmd
+		m, cmd = m.loadTab(m.tabs[i])
+		m.announceTab()
+		return m, cmd
+	}
+	m.announceTab()
+	return m, nil
+}
+
+// cycleTab shows the next (delta 1) or previous (delta -1) tab, wrapping around
+func (m Model) cycleTab(delta int) (Model, tea.Cmd) {
+	n := m.tabCount()
+	return m.switchTab((m.activeTab + delta + n) % n)
+}
+
+// newTab opens a tab after the current one, starting as a copy of it
+func (m *Model) newTab() {
+	if m.tabCount() >= maxTabs {
+		m.statusMsg = fmt.Sprintf("At most %d tabs can be open", maxTabs)
+		m.statusIsError = true
+		return
+	}
+	if len(m.tabs) == 0 {
+		m.tabs = make([]workspaceTab, 1)
+	}
+	t := m.captureTab()
+	m.tabs[m.activeTab] = t
+	m.tabs = append(m.tabs[:m.activeTab+1], append([]workspaceTab{t}, m.tabs[m.activeTab+1:]...)...)
+	m.activeTab++
+	m.announceTab()
+}
+
+// closeTab closes the current tab and shows the one that takes its place
+func (m Model) closeTab() (Model, tea.Cmd) {
+	if m.tabCount() == 1 {
+		m.statusMsg = "Only one tab is open"
+		m.statusIsError = true
+		return m, nil
+	}
+	m.tabs = append(m.tabs[:m.activeTab], m.tabs[m.activeTab+1:]...)
+	m.activeTab = min(m.activeTab, len(m.tabs)-1)
+	m, cmd := m.loadTab(m.tabs[m.activeTab])
+	m.announceTab()
+	return m, cmd
+}
+
+// announceTab reports the current tab in the status bar
+func (m *Model) announceTab() {
+	m.statusMsg = fmt.Sprintf("Tab %d/%d · %s", m.activeTab+1, m.tabCount(), m.captureTab().label())
+	m.statusIsError = false
+}
+
+// tabStrip renders the tab numbers for the footer, the current one
+// highlighted, or "" while only one tab is open
+func (m *Model) tabStrip() string {
+	if m.tabCount() < 2 {
+		return ""
+	}
+	base := m.theme.Renderer.NewStyle().
+		Background(ColorBgHighlight).
+		Foreground(ColorSubtext)
+	active := base.
+		Background(ColorSecondary).
+		Foreground(ColorBg).
+		Bold(true)
+	var sb strings.Builder
+	for i := range m.tabs {
+		style := base
+		if i == m.activeTab {
+			style = active
+		}
+		sb.WriteString(style.Render(fmt.Sprintf(" %d ", i+1)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTabsKeepTheirOwnState(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "open one", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "B", Title: "open two", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "C", Title: "done", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "ctrl+n":
+				msg = tea.KeyMsg{Type: tea.KeyCtrlN}
+			case "ctrl+w":
+				msg = tea.KeyMsg{Type: tea.KeyCtrlW}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}
+	selected := func() string {
+		if item, ok := m.list.SelectedItem().(IssueItem); ok {
+			return item.Issue.ID
+		}
+		return ""
+	}
+
+	// Tab 1: open issues with the second one selected
+	press("o", "j")
+	if selected() != "B" {
+		t.Fatalf("expected B selected, got %q", selected())
+	}
+	if m.tabStrip() != "" {
+		t.Fatalf("expected no tab strip with one tab")
+	}
+
+	// Tab 2: closed issues in the graph
+	press("ctrl+n")
+	if m.tabCount() != 2 || m.activeTab != 1 || m.currentFilter != "open" {
+		t.Fatalf("expected a copy of tab 1 as tab 2, got %d tabs, active %d, filter %q", m.tabCount(), m.activeTab, m.currentFilter)
+	}
+	press("c", "g")
+	if !m.isGraphView || len(m.list.Items()) != 1 {
+		t.Fatalf("expected the graph of closed issues")
+	}
+
+	press("1")
+	if m.isGraphView || m.currentFilter != "open" || selected() != "B" || m.activeTab != 0 {
+		t.Fatalf("expected tab 1's list of open issues with B selected, got graph=%v filter %q selected %q", m.isGraphView, m.currentFilter, selected())
+	}
+	if !strings.Contains(m.statusMsg, "Tab 1/2") {
+		t.Errorf("expected the tab announced, got %q", m.statusMsg)
+	}
+
+	// gt moves on without leaving the graph toggled in tab 1
+	press("g", "t")
+	if m.activeTab != 1 || !m.isGraphView || m.currentFilter != "closed" {
+		t.Fatalf("expected gt to show tab 2's graph, got tab %d graph=%v filter %q", m.activeTab+1, m.isGraphView, m.currentFilter)
+	}
+	if m.tabs[0].view != "" {
+		t.Fatalf("expected tab 1 saved as the list, got view %q", m.tabs[0].view)
+	}
+	press("g", "T")
+	if m.activeTab != 0 || m.isGraphView {
+		t.Fatalf("expected gT to return to tab 1's list")
+	}
+
+	press("5")
+	if !m.statusIsError || m.activeTab != 0 {
+		t.Fatalf("expected an error for a missing tab, got %q", m.statusMsg)
+	}
+
+	press("2", "ctrl+w")
+	if m.tabCount() != 1 || m.activeTab != 0 || m.currentFilter != "open" {
+		t.Fatalf("expected closing tab 2 to leave tab 1, got %d tabs, filter %q", m.tabCount(), m.currentFilter)
+	}
+	press("ctrl+w")
+	if !m.statusIsError {
+		t.Fatalf("expected the last tab to stay open")
+	}
+}
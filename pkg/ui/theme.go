@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 type Theme struct {
@@ -36,8 +38,51 @@ type Theme struct {
 	Header   lipgloss.Style
 }
 
-// DefaultTheme returns the standard Dracula-inspired theme (adaptive)
+// themeMode and themeColors are the user's overrides, see SetThemeOverrides
+var (
+	themeMode   string
+	themeColors map[string]string
+)
+
+// SetThemeOverrides customizes every theme built afterwards. mode "dark" or
+// "light" forces that palette instead of detecting the terminal's
+// background; colors replaces palette entries by name (primary, open, bug,
+// ...) with one color for both backgrounds. Call it before creating models.
+func SetThemeOverrides(mode string, colors map[string]string) {
+	themeMode = mode
+	themeColors = colors
+
+	// Badges and charts draw from the package palette, so keep it in step
+	for name, target := range map[string]*lipgloss.Color{
+		"primary":     &ColorPrimary,
+		"secondary":   &ColorSecondary,
+		"subtext":     &ColorSubtext,
+		"open":        &ColorStatusOpen,
+		"in_progress": &ColorStatusInProgress,
+		"blocked":     &ColorStatusBlocked,
+		"closed":      &ColorStatusClosed,
+		"bug":         &ColorTypeBug,
+		"feature":     &ColorTypeFeature,
+		"task":        &ColorTypeTask,
+		"epic":        &ColorTypeEpic,
+		"chore":       &ColorTypeChore,
+	} {
+		if c, ok := colors[name]; ok {
+			*target = lipgloss.Color(c)
+		}
+	}
+}
+
+// DefaultTheme returns the standard Dracula-inspired theme (adaptive),
+// with any overrides from SetThemeOverrides applied
 func DefaultTheme(r *lipgloss.Renderer) Theme {
+	switch themeMode {
+	case "dark":
+		r.SetHasDarkBackground(true)
+	case "light":
+		r.SetHasDarkBackground(false)
+	}
+
 	t := Theme{
 		Renderer: r,
 
@@ -60,6 +105,16 @@ func DefaultTheme(r *lipgloss.Renderer) Theme {
 		Border:    lipgloss.AdaptiveColor{Light: "#DDDDDD", Dark: "#44475A"},
 		Highlight: lipgloss.AdaptiveColor{Light: "#EEEEEE", Dark: "#44475A"},
 	}
+	for name, field := range map[string]*lipgloss.AdaptiveColor{
+		"primary": &t.Primary, "secondary": &t.Secondary, "subtext": &t.Subtext,
+		"open": &t.Open, "in_progress": &t.InProgress, "blocked": &t.Blocked, "closed": &t.Closed,
+		"bug": &t.Bug, "feature": &t.Feature, "task": &t.Task, "epic": &t.Epic, "chore": &t.Chore,
+		"border": &t.Border, "highlight": &t.Highlight,
+	} {
+		if c, ok := themeColors[name]; ok {
+			*field = lipgloss.AdaptiveColor{Light: c, Dark: c}
+		}
+	}
 
 	t.Base = r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#F8F8F2"})
 
@@ -110,3 +165,20 @@ func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
 		return "•", t.Subtext
 	}
 }
+
+// newMarkdownRenderer creates the detail view's markdown renderer, picking
+// the glamour style from r's terminal rather than the process's own stdout
+func newMarkdownRenderer(r *lipgloss.Renderer, width int) (*glamour.TermRenderer, error) {
+	style := glamour.WithAutoStyle()
+	if r != nil {
+		switch {
+		case r.ColorProfile() == termenv.Ascii:
+			style = glamour.WithStandardStyle("notty")
+		case r.HasDarkBackground():
+			style = glamour.WithStandardStyle("dark")
+		default:
+			style = glamour.WithStandardStyle("light")
+		}
+	}
+	return glamour.NewTermRenderer(style, glamour.WithWordWrap(width))
+}
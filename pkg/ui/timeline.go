@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StatusHistoryMsg carries status changes reconstructed from the git history
+// of the beads file
+type StatusHistoryMsg struct {
+	History map[string][]model.StatusChange
+	Err     error
+}
+
+// StatusHistoryCmd reads the committed snapshots of the beads file at
+// beadsPath to reconstruct each issue's status changes
+func StatusHistoryCmd(beadsPath string) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		history, err := loader.NewGitLoader(dir).StatusHistory()
+		return StatusHistoryMsg{History: history, Err: err}
+	}
+}
+
+// withStatusHistory returns a copy of issues with the status history
+// reconstructed from git filled in. The loaded issues are shared with the
+// background analysis, so they are never changed in place.
+func (m *Model) withStatusHistory(issues []model.Issue) []model.Issue {
+	if len(m.statusHistory) == 0 {
+		return issues
+	}
+	out := append([]model.Issue(nil), issues...)
+	loader.ApplyStatusHistory(out, m.statusHistory)
+	return out
+}
+
+// timelineBarWidth is the width of the bars in the detail view's status timeline
+const timelineBarWidth = 24
+
+// statusTimelineMarkdown shows how long an issue sat in each status, as a
+// bar per stretch scaled to the issue's whole life. Issues that have never
+// changed status get nothing.
+func statusTimelineMarkdown(issue model.Issue, now time.Time) string {
+	spans, source := analysis.StatusTimeline(issue, now)
+	if len(spans) < 2 {
+		return ""
+	}
+	total := spans[len(spans)-1].End.Sub(spans[0].Start)
+
+	var sb strings.Builder
+	sb.WriteString("### Status History\n```\n")
+	for _, s := range spans {
+		offset, width := 0, 0
+		if total > 0 {
+			offset = int(float64(s.Start.Sub(spans[0].Start)) / float64(total) * timelineBarWidth)
+			width = int(float64(s.Duration)/float64(total)*timelineBarWidth + 0.5)
+		}
+		width = max(min(width, timelineBarWidth-offset), 1)
+		offset = min(offset, timelineBarWidth-width)
+		bar := strings.Repeat("░", offset) + strings.Repeat("█", width) + strings.Repeat("░", timelineBarWidth-offset-width)
+
+		took := FormatSpan(s.Duration)
+		switch {
+		case s.Status.IsClosed():
+			took = "—"
+		case s.Current:
+			took += " so far"
+		}
+		line := fmt.Sprintf("%-12s %s %-12s %s", s.Status, bar, took, timefmt.Date(s.Start))
+		if s.By != "" {
+			line += " @" + s.By
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("```\n")
+	if source == analysis.TimelineTimestamps {
+		sb.WriteString("*From created/started/closed times; commit the beads file to git for a full history.*\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
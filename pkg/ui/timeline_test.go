@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestStatusTimelineMarkdown(t *testing.T) {
+	now := time.Now()
+	issue := model.Issue{ID: "A", Status: model.StatusInProgress, CreatedAt: now.Add(-96 * time.Hour),
+		StatusHistory: []model.StatusChange{
+			{Status: model.StatusOpen, At: now.Add(-96 * time.Hour)},
+			{Status: model.StatusInProgress, At: now.Add(-24 * time.Hour), By: "ann"},
+		}}
+	md := statusTimelineMarkdown(issue, now)
+	for _, want := range []string{"### Status History", "open", "3d", "1d so far", "@ann"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in timeline:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "From created/started/closed times") {
+		t.Errorf("timeline from history should not carry the timestamps note:\n%s", md)
+	}
+
+	// An issue that never changed status has no timeline
+	if md := statusTimelineMarkdown(model.Issue{ID: "B", Status: model.StatusOpen, CreatedAt: now}, now); md != "" {
+		t.Errorf("expected no timeline, got:\n%s", md)
+	}
+
+	// Git history fills in issues without their own
+	m := Model{statusHistory: map[string][]model.StatusChange{"B": issue.StatusHistory}}
+	if got := m.withStatusHistory([]model.Issue{{ID: "B", Status: model.StatusInProgress}}); len(got[0].StatusHistory) != 2 {
+		t.Errorf("expected git history applied, got %+v", got[0].StatusHistory)
+	}
+}
@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// trendMetric is one series bv history can chart
+type trendMetric struct {
+	name  string
+	value func(analysis.TrendPoint) int
+}
+
+var trendMetrics = []trendMetric{
+	{"open issues", func(p analysis.TrendPoint) int { return p.Open }},
+	{"ready issues", func(p analysis.TrendPoint) int { return p.Ready }},
+	{"blocked issues", func(p analysis.TrendPoint) int { return p.Blocked }},
+	{"health score", func(p analysis.TrendPoint) int { return p.Health }},
+}
+
+// TrendModel charts metrics over the git history of the beads file, one bar
+// per commit or per day. It runs on its own as bv history.
+type TrendModel struct {
+	commits []analysis.TrendPoint
+	daily   []analysis.TrendPoint
+	byDay   bool
+	metric  int
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewTrendModel charts points, oldest first, per commit
+func NewTrendModel(points []analysis.TrendPoint, theme Theme) TrendModel {
+	return TrendModel{
+		commits: points,
+		daily:   analysis.DailyTrend(points),
+		theme:   theme,
+	}
+}
+
+// SetSize updates the view dimensions
+func (m *TrendModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CycleMetric switches to the next metric
+func (m *TrendModel) CycleMetric() {
+	m.metric = (m.metric + 1) % len(trendMetrics)
+}
+
+// ToggleByDay switches between a bar per commit and a bar per day
+func (m *TrendModel) ToggleByDay() {
+	m.byDay = !m.byDay
+}
+
+// points returns the series charted
+func (m *TrendModel) points() []analysis.TrendPoint {
+	if m.byDay {
+		return m.daily
+	}
+	return m.commits
+}
+
+// Init implements tea.Model
+func (m TrendModel) Init() tea.Cmd { return nil }
+
+// Update implements tea.Model
+func (m TrendModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "m", "tab":
+			m.CycleMetric()
+		case "d":
+			m.ToggleByDay()
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model
+func (m TrendModel) View() string {
+	return m.Render()
+}
+
+// Render renders the chart of the current metric
+func (m *TrendModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	metric := trendMetrics[m.metric]
+	per := "commit"
+	if m.byDay {
+		per = "day"
+	}
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	axisStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	barStyle := t.Renderer.NewStyle().Foreground(t.Primary)
+
+	all := m.points()
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("📈 HISTORY  │  %s  │  per %s  │  %d %ss", metric.name, per, len(all), per)))
+	lines = append(lines, "")
+	if len(all) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width-4).
+			Align(lipgloss.Center).
+			Render("No commits of the beads file to chart."))
+		return strings.Join(lines, "\n")
+	}
+
+	// The most recent points that fit, one bar and one gap each
+	step := burndownBarWidth + 1
+	maxVal := 0
+	for _, p := range all {
+		maxVal = max(maxVal, metric.value(p))
+	}
+	axisWidth := max(len(fmt.Sprint(maxVal)), 2)
+	points := all[max(len(all)-max((m.width-axisWidth-2)/step, 1), 0):]
+
+	// header, blank, x axis, x labels, blank, summary, keys
+	chartHeight := max(m.height-7, 3)
+	for row := 0; row < chartHeight; row++ {
+		level := float64(chartHeight - row)
+		label := ""
+		switch row {
+		case 0:
+			label = fmt.Sprint(maxVal)
+		case chartHeight / 2:
+			if chartHeight >= 6 {
+				label = fmt.Sprint(int(float64(maxVal) * level / float64(chartHeight)))
+			}
+		}
+		var sb strings.Builder
+		sb.WriteString(axisStyle.Render(fmt.Sprintf("%*s┤", axisWidth, label)))
+		for _, p := range points {
+			bar := 0.0
+			if maxVal > 0 {
+				bar = float64(metric.value(p)) / float64(maxVal) * float64(chartHeight)
+			}
+			cell := " "
+			switch {
+			case bar >= level:
+				cell = "█"
+			case bar > level-1:
+				cell = eighthBlocks[int((bar-(level-1))*8)]
+			}
+			sb.WriteString(barStyle.Render(strings.Repeat(cell, burndownBarWidth)) + " ")
+		}
+		lines = append(lines, sb.String())
+	}
+
+	plotWidth := len(points) * step
+	lines = append(lines, axisStyle.Render(strings.Repeat(" ", axisWidth)+"└"+repeatToWidth("─", plotWidth)))
+	lines = append(lines, axisStyle.Render(strings.Repeat(" ", axisWidth+1)+trendXLabels(points, step, plotWidth)))
+	lines = append(lines, "")
+
+	first, last := points[0], points[len(points)-1]
+	delta := metric.value(last) - metric.value(first)
+	lines = append(lines, fmt.Sprintf(" %s now %d  ·  %+d since %s  ·  latest %s %s",
+		metric.name, metric.value(last), delta, first.Date.Format("Jan 02"),
+		shortSHA(last.Revision), timefmt.DateTime(last.Date)))
+	lines = append(lines, axisStyle.Render(" m metric · d per commit/day · q quit"))
+	return strings.Join(lines, "\n")
+}
+
+// trendXLabels spreads the first, middle and last dates across the plot
+func trendXLabels(points []analysis.TrendPoint, step, width int) string {
+	row := []rune(strings.Repeat(" ", width))
+	place := func(col int, s string) {
+		r := []rune(s)
+		if col+len(r) > len(row) {
+			col = len(row) - len(r)
+		}
+		if col < 0 {
+			return
+		}
+		copy(row[col:], r)
+	}
+	label := func(p analysis.TrendPoint) string { return p.Date.Format("Jan 02") }
+
+	place(0, label(points[0]))
+	if n := len(points); n > 1 {
+		if mid := n / 2; mid*step > 8 && (n-1-mid)*step > 8 {
+			place(mid*step, label(points[mid]))
+		}
+		if (n-1)*step > 8 {
+			place((n-1)*step, label(points[n-1]))
+		}
+	}
+	return strings.TrimRight(string(row), " ")
+}
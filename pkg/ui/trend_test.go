@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTrendModelChartsMetrics(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 6, d, h, 0, 0, 0, time.UTC) }
+	points := []analysis.TrendPoint{
+		{Date: day(1, 9), Revision: "aaaaaaaaaa", Open: 4, Ready: 2, Health: 60},
+		{Date: day(1, 17), Revision: "bbbbbbbbbb", Open: 6, Ready: 3, Health: 70},
+		{Date: day(3, 10), Revision: "cccccccccc", Open: 3, Ready: 3, Health: 90},
+	}
+	var model tea.Model = NewTrendModel(points, newTestTheme())
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+
+	out := model.View()
+	for _, want := range []string{"HISTORY", "open issues", "per commit", "3 commits", "now 3", "-1 since Jun 01", "ccccccc", "█"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the chart:\n%s", want, out)
+		}
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if out := model.View(); !strings.Contains(out, "per day") || !strings.Contains(out, "2 days") {
+		t.Errorf("expected d to chart a bar per day:\n%s", out)
+	}
+
+	for range 3 {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	}
+	if out := model.View(); !strings.Contains(out, "health score now 90") || !strings.Contains(out, "+20 since") {
+		t.Errorf("expected the health score charted per day:\n%s", out)
+	}
+
+	if _, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd == nil {
+		t.Error("expected q to quit")
+	}
+}
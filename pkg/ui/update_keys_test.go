@@ -65,3 +65,132 @@ func TestUpdateMsgSetsUpdateAvailable(t *testing.T) {
 		t.Fatalf("update flag not set")
 	}
 }
+
+func TestKeyTranslationAndDefaultView(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "1", Title: "One", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	if err := m.SetDefaultView("graph"); err != nil {
+		t.Fatal(err)
+	}
+	if !m.isGraphView || m.focused != focusGraph {
+		t.Fatalf("expected to open on the graph")
+	}
+	if err := m.SetDefaultView("gantt"); err == nil {
+		t.Fatalf("expected an error for an unknown view")
+	}
+
+	m = NewModel(issues, nil, "")
+	m.SetKeyTranslation(map[string]string{"v": "b"})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = updated.(Model)
+	if !m.isBoardView {
+		t.Fatalf("expected v to open the board")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = updated.(Model)
+	if m.isBoardView {
+		t.Fatalf("expected v to close the board again")
+	}
+
+	// Menus get the raw key
+	m.showSortPicker = true
+	if m.acceptsGlobalKeys() {
+		t.Fatalf("expected rebinding to be off while a menu is open")
+	}
+}
+
+// Every full-screen view closes the others: opening any view from any other
+// leaves exactly that one showing.
+func TestViewTogglesAreExclusive(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "1", Title: "One", Status: model.StatusOpen},
+		{ID: "2", Title: "Two", Status: model.StatusInProgress, Dependencies: []*model.Dependency{{IssueID: "2", DependsOnID: "1", Type: model.DepBlocks}}},
+	}
+	views := []struct {
+		key  tea.KeyMsg
+		flag func(*Model) *bool
+	}{
+		{runeKey('b', false), func(m *Model) *bool { return &m.isBoardView }},
+		{runeKey('g', false), func(m *Model) *bool { return &m.isGraphView }},
+		{runeKey('a', false), func(m *Model) *bool { return &m.isActionableView }},
+		{runeKey('M', false), func(m *Model) *bool { return &m.isMilestoneView }},
+		{runeKey('B', false), func(m *Model) *bool { return &m.isBurndownView }},
+		{runeKey('F', false), func(m *Model) *bool { return &m.isFlowView }},
+		{runeKey('V', false), func(m *Model) *bool { return &m.isVelocityView }},
+		{runeKey('W', false), func(m *Model) *bool { return &m.isWorkloadView }},
+		{runeKey('X', false), func(m *Model) *bool { return &m.isDuplicatesView }},
+		{runeKey('P', false), func(m *Model) *bool { return &m.isProblemsView }},
+		{runeKey('U', false), func(m *Model) *bool { return &m.isActivityView }},
+		{runeKey('K', false), func(m *Model) *bool { return &m.isCouplingView }},
+		{runeKey('J', false), func(m *Model) *bool { return &m.isDSMView }},
+		{runeKey('Q', false), func(m *Model) *bool { return &m.isRiskView }},
+		{runeKey('s', true), func(m *Model) *bool { return &m.isScheduleView }},
+		{runeKey('a', true), func(m *Model) *bool { return &m.isAgingView }},
+		{runeKey('l', true), func(m *Model) *bool { return &m.isLabelsView }},
+		{runeKey('d', true), func(m *Model) *bool { return &m.isLineageView }},
+		{runeKey('w', true), func(m *Model) *bool { return &m.isWorklogView }},
+	}
+	// showing lists the keys of the views whose flags are set, with "=" for
+	// the comparison
+	showing := func(m *Model) []string {
+		var on []string
+		for _, v := range views {
+			if *v.flag(m) {
+				on = append(on, v.key.String())
+			}
+		}
+		if m.isCompareView {
+			on = append(on, "=")
+		}
+		return on
+	}
+	press := func(m Model, key tea.KeyMsg) Model {
+		updated, _ := m.Update(key)
+		return updated.(Model)
+	}
+
+	base := NewModel(issues, nil, "")
+	updated, _ := base.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	base = updated.(Model)
+
+	for _, from := range views {
+		for _, to := range views {
+			if from.key.String() == to.key.String() {
+				continue
+			}
+			m := press(base, from.key)
+			if on := showing(&m); len(on) != 1 || on[0] != from.key.String() {
+				t.Fatalf("%s: expected only its view, got %v", from.key, on)
+			}
+			m = press(m, to.key)
+			if on := showing(&m); len(on) != 1 || on[0] != to.key.String() {
+				t.Errorf("%s then %s: expected only %s, got %v", from.key, to.key, to.key, on)
+			}
+			m = press(m, to.key)
+			if on := showing(&m); len(on) != 0 || m.focused != focusList {
+				t.Errorf("%s then %s twice: expected the list, got %v focused %v", from.key, to.key, on, m.focused)
+			}
+		}
+		m := press(base, from.key)
+		m = press(m, runeKey('i', false))
+		if on := showing(&m); len(on) != 0 || m.focused != focusInsights {
+			t.Errorf("%s then i: expected only insights, got %v", from.key, on)
+		}
+	}
+
+	// A comparison left showing doesn't survive another view opening
+	m := base
+	m.openComparison(issues[0], issues[1])
+	m.toggleView(&m.isGraphView)
+	if on := showing(&m); len(on) != 1 || on[0] != "g" {
+		t.Errorf("expected the graph alone after the comparison, got %v", on)
+	}
+}
+
+func runeKey(r rune, alt bool) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: alt}
+}
@@ -3,11 +3,16 @@ package ui
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // exercise Phase2Ready and FileChanged branches of Update for coverage.
@@ -17,7 +22,7 @@ func TestModelUpdatePhase2AndFileChanged(t *testing.T) {
 	m.width, m.height = 120, 40
 
 	// Phase2ReadyMsg should rebuild insights/graph without error
-	updated, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	updated, _ := m.Update(WaitForPhase2Cmd(m.analyzer, m.analysis)())
 	m2 := updated.(Model)
 	if m2.insightsPanel.insights.Stats == nil {
 		t.Fatalf("expected insights to be regenerated")
@@ -96,9 +101,283 @@ func TestUpdateFileChangedReloadsSelection(t *testing.T) {
 	m.list.Select(0)
 
 	updated, cmd := m.Update(FileChangedMsg{})
-	_ = cmd
+	if cmd == nil {
+		t.Fatalf("expected a reload command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
 	m2 := updated.(Model)
 	if m2.statusIsError {
 		t.Fatalf("expected successful reload, got error %q", m2.statusMsg)
 	}
 }
+
+func TestUpdateFileChangedLoadsOffUIGoroutine(t *testing.T) {
+	tmp := t.TempDir()
+	beads := filepath.Join(tmp, "beads.jsonl")
+	if err := os.WriteFile(beads, []byte(`{"id":"ONE","title":"One","status":"open","issue_type":"task"}`), 0644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+	m := NewModel(nil, nil, beads)
+
+	// Update must not touch the file itself; the returned command does the work
+	updated, cmd := m.Update(FileChangedMsg{})
+	if len(updated.(Model).issues) != 0 {
+		t.Fatalf("expected issues unchanged until the reload worker reports back")
+	}
+	if !updated.(Model).reloading {
+		t.Fatalf("expected the reload marked as running")
+	}
+	msg, ok := cmd().(IssuesReloadedMsg)
+	if !ok {
+		t.Fatalf("expected IssuesReloadedMsg from reload command")
+	}
+	if msg.Err != nil || len(msg.Issues) != 1 || msg.Analyzer == nil || msg.Stats == nil {
+		t.Fatalf("unexpected reload result: %+v", msg)
+	}
+
+	updated, _ = updated.(Model).Update(msg)
+	m2 := updated.(Model)
+	if len(m2.issues) != 1 || m2.analysis != msg.Stats {
+		t.Fatalf("expected reloaded issues and analysis to be swapped in")
+	}
+	if m2.reloading {
+		t.Fatalf("expected the reload marked as finished")
+	}
+	if m2.statusMsg != "Reloaded 1 issues" {
+		t.Fatalf("unexpected status %q", m2.statusMsg)
+	}
+
+	// A failed load reports an error without replacing the current issues
+	updated, _ = m2.Update(IssuesReloadedMsg{Err: os.ErrNotExist})
+	if m3 := updated.(Model); !m3.statusIsError || len(m3.issues) != 1 {
+		t.Fatalf("expected reload error to keep existing issues")
+	}
+}
+
+func TestFooterShowsLiveStats(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen},
+		{ID: "C", Title: "Gamma", Status: model.StatusClosed},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+	press := func(key string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+	}
+
+	if footer := m.renderFooter(); !strings.Contains(footer, "3 issues") || strings.Contains(footer, " of ") {
+		t.Fatalf("expected every issue counted when all are shown:\n%s", footer)
+	}
+	press("o")
+	footer := m.renderFooter()
+	for _, want := range []string{"OPEN", "2 of 3 issues", "◉2"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("expected %q in the footer:\n%s", want, footer)
+		}
+	}
+	if strings.Contains(footer, "⇅") {
+		t.Errorf("expected no sort badge for the default order:\n%s", footer)
+	}
+
+	m.sortMode = SortMode{Field: SortImpact, Descending: true}
+	m.reloading = true
+	footer = m.renderFooter()
+	for _, want := range []string{"⇅ Impact ↓", "⟳ reloading"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("expected %q in the footer:\n%s", want, footer)
+		}
+	}
+
+	// A status message keeps the badges, and only trims them when short of room
+	m.statusMsg = "Saved"
+	footer = m.renderFooter()
+	for _, want := range []string{"Saved", "OPEN", "⇅ Impact ↓"} {
+		if !strings.Contains(footer, want) {
+			t.Errorf("expected %q in the footer with a status message:\n%s", want, footer)
+		}
+	}
+	m.width = 50
+	m.statusMsg = strings.Repeat("long message ", 10)
+	footer = m.renderFooter()
+	if !strings.Contains(footer, "OPEN") || lipgloss.Width(footer) > 50 {
+		t.Errorf("expected the filter and a cut message within 50 columns:\n%s", footer)
+	}
+}
+
+func TestUpdateReloadReusesMetricsForContentEdits(t *testing.T) {
+	tmp := t.TempDir()
+	beads := filepath.Join(tmp, "beads.jsonl")
+	write := func(data string) {
+		if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+	}
+	write(`{"id":"A","title":"Core","status":"open","issue_type":"task"}
+{"id":"B","title":"Dep","status":"open","issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`)
+
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	m.analysis.WaitForPhase2()
+	reload := func(m Model) (Model, IssuesReloadedMsg) {
+		_, cmd := m.Update(FileChangedMsg{})
+		msg := cmd().(IssuesReloadedMsg)
+		updated, _ := m.Update(msg)
+		return updated.(Model), msg
+	}
+
+	// Title edit keeps the structure, so the stats object is reused
+	write(`{"id":"A","title":"Core renamed","status":"open","issue_type":"task"}
+{"id":"B","title":"Dep","status":"open","issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`)
+	prev := m.analysis
+	m2, msg := reload(m)
+	if !msg.Reused || m2.analysis != prev {
+		t.Fatalf("expected metrics reuse for a title edit")
+	}
+	if len(msg.Changes.Modified) != 1 || msg.Changes.Modified[0] != "A" {
+		t.Fatalf("expected only A modified, got %+v", msg.Changes)
+	}
+	if m2.issueMap["A"].Title != "Core renamed" {
+		t.Fatalf("expected reloaded title in issueMap")
+	}
+	if m2.statusMsg != "Reloaded 2 issues (1 changed, metrics reused)" {
+		t.Fatalf("unexpected status %q", m2.statusMsg)
+	}
+
+	// Rewriting identical data is a no-op
+	m2.statusMsg = ""
+	m3, msg := reload(m2)
+	if msg.Changes.Count() != 0 || m3.statusMsg != "" {
+		t.Fatalf("expected unchanged data to be ignored, got %+v / %q", msg.Changes, m3.statusMsg)
+	}
+
+	// Dropping the dependency changes the structure and reanalyzes
+	write(`{"id":"A","title":"Core renamed","status":"open","issue_type":"task"}
+{"id":"B","title":"Dep","status":"open","issue_type":"task"}`)
+	m4, msg := reload(m3)
+	if msg.Reused || m4.analysis == prev {
+		t.Fatalf("expected fresh analysis after a structural change")
+	}
+	if m4.analysis.InDegree["A"] != 0 {
+		t.Fatalf("expected dropped edge in new stats")
+	}
+}
+
+func TestGraphModelUpdateIssuesRelinksModified(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen},
+	}
+	ins := analysis.Insights{Stats: analysis.NewAnalyzer(issues).AnalyzeAsync()}
+	ins.Stats.WaitForPhase2()
+	g := NewGraphModel(issues, &ins, DefaultTheme(lipgloss.NewRenderer(os.Stdout)))
+	g.CycleSortMetric()
+	g.MoveDown()
+	order := append([]string(nil), g.sortedIDs...)
+	selected := g.selectedIdx
+
+	// C gains a parent-child link to A (not a blocking edge, so stats are unchanged)
+	updated := []model.Issue{issues[0], issues[1], issues[2]}
+	updated[2].Title = "C edited"
+	updated[2].Dependencies = []*model.Dependency{{IssueID: "C", DependsOnID: "A", Type: model.DepParentChild}}
+	g.UpdateIssues(updated, &ins, []string{"C"})
+
+	if g.issueMap["C"].Title != "C edited" {
+		t.Errorf("expected issueMap to point at reloaded issues")
+	}
+	if got := g.dependents["A"]; len(got) != 2 || got[0] != "B" || got[1] != "C" {
+		t.Errorf("expected A's dependents to be [B C], got %v", got)
+	}
+	if got := g.blockers["C"]; len(got) != 1 || got[0] != "A" {
+		t.Errorf("expected C blocked by A, got %v", got)
+	}
+	if g.sortMetric != GraphSortPageRank || g.selectedIdx != selected || !reflect.DeepEqual(g.sortedIDs, order) {
+		t.Errorf("expected sort metric, order and selection to be kept")
+	}
+
+	// Removing the link again unlinks it
+	updated[2].Dependencies = nil
+	g.UpdateIssues(updated, &ins, []string{"C"})
+	if got := g.dependents["A"]; len(got) != 1 || got[0] != "B" {
+		t.Errorf("expected A's dependents back to [B], got %v", got)
+	}
+	if _, ok := g.blockers["C"]; ok {
+		t.Errorf("expected no blockers for C")
+	}
+}
+
+func TestReloadReportsSkippedLines(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "beads.jsonl")
+	data := `{"id":"ONE","title":"One","status":"open","issue_type":"task"}
+{broken`
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+	m := NewModel(nil, nil, beads)
+	defer m.Stop()
+
+	_, cmd := m.Update(FileChangedMsg{})
+	updated, _ := m.Update(cmd())
+	if got := updated.(Model).statusMsg; got != "Reloaded 1 issues · 1 unreadable lines skipped" {
+		t.Fatalf("unexpected status %q", got)
+	}
+
+	m.ReportSkippedLines([]loader.LineError{{Line: 7, Err: os.ErrInvalid, Malformed: true}})
+	if !m.statusIsError || m.statusMsg != "⚠️ Skipped 1 unreadable lines in beads file (first: line 7: invalid argument) · P for details" {
+		t.Fatalf("unexpected startup warning %q", m.statusMsg)
+	}
+}
+
+func TestToggleArchivedIssues(t *testing.T) {
+	dir := t.TempDir()
+	beads := filepath.Join(dir, "beads.jsonl")
+	data := `{"id":"NEW","title":"New","status":"open","issue_type":"task","dependencies":[{"issue_id":"NEW","depends_on_id":"OLD","type":"blocks"}]}`
+	if err := os.WriteFile(beads, []byte(data), 0644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beads)
+	defer m.Stop()
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")}
+
+	// Without an archive file nothing is loaded
+	updated, cmd := m.Update(key)
+	m = updated.(Model)
+	if cmd != nil || m.includeArchived || !m.statusIsError {
+		t.Fatalf("expected an error status without an archive, got %q", m.statusMsg)
+	}
+
+	archive := `{"id":"OLD","title":"Old","status":"closed","issue_type":"task"}`
+	if err := os.WriteFile(filepath.Join(dir, "archive.jsonl"), []byte(archive), 0644); err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd = m.Update(key)
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if _, ok := m.issueMap["OLD"]; !ok || m.statusMsg != "Reloaded 2 issues · 1 archived" {
+		t.Fatalf("expected archived issue merged, status %q", m.statusMsg)
+	}
+	if len(m.dataProblems) != 0 || m.countReady != 1 {
+		t.Errorf("expected the archived dependency to resolve, problems %+v, ready %d", m.dataProblems, m.countReady)
+	}
+
+	// Toggling off drops the archive again
+	updated, cmd = m.Update(key)
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if _, ok := m.issueMap["OLD"]; ok || m.includeArchived {
+		t.Fatalf("expected archived issue hidden")
+	}
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+)
+
+// velocityWindows are the rolling-average windows (in weeks) cycled by the velocity view
+var velocityWindows = []int{analysis.DefaultVelocityWindow, 8, 2}
+
+// VelocityModel is the throughput dashboard: weekly closed counts, rolling
+// velocity and a forecast for clearing the open set
+type VelocityModel struct {
+	issues    []model.Issue
+	now       time.Time
+	windowIdx int
+	width     int
+	height    int
+	theme     Theme
+}
+
+// NewVelocityModel creates a velocity dashboard using the default rolling window
+func NewVelocityModel(issues []model.Issue, now time.Time, theme Theme) VelocityModel {
+	return VelocityModel{issues: issues, now: now, theme: theme}
+}
+
+// SetSize updates the view dimensions
+func (m *VelocityModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// CycleWindow switches to the next rolling-average window
+func (m *VelocityModel) CycleWindow() {
+	m.windowIdx = (m.windowIdx + 1) % len(velocityWindows)
+}
+
+// Window returns the rolling-average window in weeks
+func (m *VelocityModel) Window() int {
+	return velocityWindows[m.windowIdx]
+}
+
+// weekCount returns how many weekly rows fit below the summary
+func (m *VelocityModel) weekCount() int {
+	// header, blank, 3 summary lines, blank, section title
+	n := m.height - 7
+	if n > 26 {
+		n = 26
+	}
+	return max(n, m.Window())
+}
+
+// Render renders the velocity dashboard
+func (m *VelocityModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+	r := analysis.ComputeVelocity(m.issues, m.now, m.weekCount(), m.Window())
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	sectionStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	strong := t.Renderer.NewStyle().Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("📊 THROUGHPUT & VELOCITY  │  %d-week rolling window", r.Window)))
+	lines = append(lines, "")
+
+	velocity := fmt.Sprintf(" Velocity  %s issues/week", strong.Render(fmt.Sprintf("%.1f", r.Velocity)))
+	if r.PointsVelocity > 0 {
+		velocity += subtle.Render(fmt.Sprintf("  ·  %s estimated/week", FormatMinutes(int(math.Round(r.PointsVelocity)))))
+	}
+	lines = append(lines, velocity)
+
+	open := fmt.Sprintf(" Open      %s issues", strong.Render(fmt.Sprintf("%d", r.Open)))
+	if r.OpenPoints > 0 {
+		open += subtle.Render(fmt.Sprintf("  ·  %s estimated", FormatMinutes(r.OpenPoints)))
+	}
+	lines = append(lines, open)
+	lines = append(lines, " Forecast  "+m.forecastText(r))
+	lines = append(lines, "")
+
+	lines = append(lines, sectionStyle.Render("Closed per week")+subtle.Render(fmt.Sprintf("  (avg = %d-week rolling velocity)", r.Window)))
+	maxClosed := 0
+	for _, w := range r.Weeks {
+		maxClosed = max(maxClosed, w.Closed)
+	}
+	barWidth := min(m.width-30, 50)
+	if barWidth < 5 {
+		barWidth = 5
+	}
+	barStyle := t.Renderer.NewStyle().Foreground(t.Open)
+	// Newest week first so the current pace sits next to the summary
+	for i := len(r.Weeks) - 1; i >= 0; i-- {
+		w := r.Weeks[i]
+		n := 0
+		if maxClosed > 0 {
+			n = w.Closed * barWidth / maxClosed
+		}
+		if w.Closed > 0 && n == 0 {
+			n = 1
+		}
+		label := w.End.AddDate(0, 0, -1).Format("Jan 02")
+		bar := repeatToWidth("█", n)
+		bar = barStyle.Render(bar) + strings.Repeat(" ", barWidth-displayWidth(bar))
+		lines = append(lines, fmt.Sprintf(" %s │%s %3d  %s", label, bar, w.Closed, subtle.Render(fmt.Sprintf("avg %.1f", w.Velocity))))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// forecastText phrases the clear-down forecast
+func (m *VelocityModel) forecastText(r analysis.VelocityReport) string {
+	switch {
+	case r.Open == 0:
+		return "nothing open"
+	case r.Forecast == nil:
+		return fmt.Sprintf("no issues closed in the last %d weeks, so no forecast", r.Window)
+	}
+	weeks := int(math.Ceil(r.WeeksToClear))
+	unit := "weeks"
+	if weeks == 1 {
+		unit = "week"
+	}
+	return fmt.Sprintf("at current pace, the current open set clears in ~%d %s (%s)", weeks, unit, timefmt.Date(*r.Forecast))
+}
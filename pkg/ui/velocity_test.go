@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func velocityTestIssues(now time.Time) []model.Issue {
+	var issues []model.Issue
+	for i := 0; i < 4; i++ {
+		closed := now.AddDate(0, 0, -i*7)
+		issues = append(issues, model.Issue{ID: string(rune('A' + i)), Title: "done", Status: model.StatusClosed,
+			CreatedAt: now.AddDate(0, 0, -60), ClosedAt: &closed})
+	}
+	for i := 0; i < 3; i++ {
+		issues = append(issues, model.Issue{ID: string(rune('X' + i)), Title: "open", Status: model.StatusOpen, CreatedAt: now})
+	}
+	return issues
+}
+
+func TestVelocityRender(t *testing.T) {
+	now := time.Now()
+	v := NewVelocityModel(velocityTestIssues(now), now, DefaultTheme(lipgloss.NewRenderer(nil)))
+	v.SetSize(100, 20)
+
+	out := v.Render()
+	for _, want := range []string{"4-week rolling window", "1.0 issues/week", "3 issues", "clears in ~3 weeks", "Closed per week"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in velocity view:\n%s", want, out)
+		}
+	}
+
+	v.CycleWindow()
+	if v.Window() != 8 || !strings.Contains(v.Render(), "0.5 issues/week") {
+		t.Fatalf("expected 8-week window to halve velocity")
+	}
+}
+
+func TestVelocityRenderNoThroughput(t *testing.T) {
+	now := time.Now()
+	v := NewVelocityModel([]model.Issue{{ID: "A", Status: model.StatusOpen, CreatedAt: now}}, now, DefaultTheme(lipgloss.NewRenderer(nil)))
+	v.SetSize(80, 20)
+	if !strings.Contains(v.Render(), "no forecast") {
+		t.Fatalf("expected no forecast without throughput")
+	}
+}
+
+func TestVelocityViewKeys(t *testing.T) {
+	m := NewModel(velocityTestIssues(time.Now()), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	m = updated.(Model)
+	if !m.isVelocityView || m.focused != focusVelocity {
+		t.Fatalf("expected velocity view focused")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(Model)
+	if m.velocityView.Window() != 8 {
+		t.Fatalf("expected w to cycle rolling window")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	m = updated.(Model)
+	if m.isVelocityView || m.focused != focusList {
+		t.Fatalf("expected V to close velocity view")
+	}
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/script"
+)
+
+// viewTable returns the rows the list currently shows, in order, as a
+// spreadsheet table. Filters, fuzzy search, sorting and collapsed sections
+// all carry over; the section name becomes a column when the list is grouped.
+func (m *Model) viewTable() export.Table {
+	grouped := m.groupBy != GroupNone
+	t := export.Table{}
+	if m.workspaceMode {
+		t.Header = append(t.Header, "Repo")
+	}
+	if grouped {
+		t.Header = append(t.Header, m.groupBy.String())
+	}
+	t.Header = append(t.Header, "ID", "Title", "Type", "Priority", "Status", "Assignee", "Labels",
+		"Created", "Updated", "Due", "Comments", "Impact")
+	t.Numeric = make([]bool, len(t.Header))
+	for i, h := range t.Header {
+		t.Numeric[i] = h == "Priority" || h == "Comments" || h == "Impact"
+	}
+	// Computed columns are numeric when every value is a number or None
+	scriptCols := m.scriptColumnNames()
+	firstScriptCol := len(t.Header)
+	t.Header = append(t.Header, scriptCols...)
+	for range scriptCols {
+		t.Numeric = append(t.Numeric, true)
+	}
+
+	date := func(tm time.Time) string {
+		if tm.IsZero() {
+			return ""
+		}
+		return tm.Format("2006-01-02")
+	}
+	section := ""
+	for _, item := range m.list.VisibleItems() {
+		if header, ok := item.(GroupHeaderItem); ok {
+			section = header.Label
+			continue
+		}
+		i, ok := item.(IssueItem)
+		if !ok {
+			continue
+		}
+		issue := i.Issue
+		var row []string
+		if m.workspaceMode {
+			row = append(row, i.RepoPrefix)
+		}
+		if grouped {
+			row = append(row, section)
+		}
+		due := ""
+		if issue.DueDate != nil {
+			due = date(*issue.DueDate)
+		}
+		row = append(row, issue.ID, issue.Title, string(issue.IssueType), strconv.Itoa(issue.Priority),
+			string(issue.Status), issue.Assignee, strings.Join(issue.Labels, ", "),
+			date(issue.CreatedAt), date(issue.UpdatedAt), due, strconv.Itoa(len(issue.Comments)),
+			strconv.FormatFloat(i.Impact, 'f', 2, 64))
+		for n := range scriptCols {
+			var v any
+			if i.Script != nil && n < len(i.Script.Columns) {
+				v = i.Script.Columns[n]
+			}
+			if v == nil {
+				row = append(row, "")
+				continue
+			}
+			if _, ok := v.(float64); !ok {
+				t.Numeric[firstScriptCol+n] = false
+			}
+			row = append(row, script.Format(v))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// exportView saves the current list view as CSV or .xlsx (ext) in the
+// working directory
+func (m *Model) exportView(ext string) {
+	if m.refuseRemoteExport() {
+		return
+	}
+	t := m.viewTable()
+	if len(t.Rows) == 0 {
+		m.statusMsg = "No issues in view to export"
+		m.statusIsError = true
+		return
+	}
+	filename := m.exportFilename("view", ext)
+	if err := export.SaveTable(t, filename); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Export failed: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✅ Exported %d issues in view to %s", len(t.Rows), filename)
+	m.statusIsError = false
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestExportViewFollowsFilterAndGrouping(t *testing.T) {
+	tmp := t.TempDir()
+	origWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(origWD) })
+	_ = os.Chdir(tmp)
+
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Alpha, with comma", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask, Assignee: "alice", Labels: []string{"api", "ui"}},
+		{ID: "bv-2", Title: "Beta", Status: model.StatusInProgress, Priority: 1, IssueType: model.TypeBug},
+		{ID: "bv-3", Title: "Gamma", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	m.currentFilter = "open"
+	m.applyFilter()
+	m.cycleGroupBy() // Status
+
+	updated, _ := m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated
+	if m.statusIsError || !strings.Contains(m.statusMsg, "Exported 2 issues") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	files, _ := filepath.Glob("beads_view_*.csv")
+	if len(files) != 1 {
+		t.Fatalf("expected one CSV, got %v", files)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "Status" || rows[0][1] != "ID" {
+		t.Fatalf("expected a Status column and two rows, got %v", rows)
+	}
+	for _, row := range rows[1:] {
+		if row[1] == "bv-3" {
+			t.Error("closed issue is filtered out of the view and should not be exported")
+		}
+		if row[1] == "bv-1" && (row[2] != "Alpha, with comma" || row[7] != "api, ui" || row[6] != "alice") {
+			t.Errorf("unexpected row for bv-1: %v", row)
+		}
+	}
+
+	m.handleListKeys(tea.KeyMsg{Type: tea.KeyCtrlE})
+	if files, _ := filepath.Glob("beads_view_*.xlsx"); len(files) != 1 {
+		t.Errorf("expected an xlsx export, got %v", files)
+	}
+}
+
+func TestExportsRefusedInRemoteSession(t *testing.T) {
+	tmp := t.TempDir()
+	origWD, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(origWD) })
+	_ = os.Chdir(tmp)
+
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "Alpha", Status: model.StatusOpen}}, nil, "")
+	m.SetRemoteTerminal(&strings.Builder{})
+
+	m.exportView("csv")
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "remote session") {
+		t.Fatalf("expected the view export refused, got %q", m.statusMsg)
+	}
+	m.statusMsg, m.statusIsError = "", false
+	m.exportToMarkdown()
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "remote session") {
+		t.Fatalf("expected the Markdown export refused, got %q", m.statusMsg)
+	}
+	if files, _ := os.ReadDir(tmp); len(files) != 0 {
+		t.Fatalf("expected no files written, got %d", len(files))
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -115,3 +116,85 @@ func RenderRepoBadge(prefix string) string {
 		Bold(true).
 		Render("[" + display + "]")
 }
+
+// LabelColors is the palette label chips are hashed into
+var LabelColors = []lipgloss.Color{
+	lipgloss.Color("#FF79C6"), // Pink
+	lipgloss.Color("#8BE9FD"), // Cyan
+	lipgloss.Color("#50FA7B"), // Green
+	lipgloss.Color("#FFB86C"), // Orange
+	lipgloss.Color("#BD93F9"), // Purple
+	lipgloss.Color("#F1FA8C"), // Yellow
+	lipgloss.Color("#FF6E6E"), // Red
+	lipgloss.Color("#69FF94"), // Mint
+	lipgloss.Color("#D6ACFF"), // Lilac
+	lipgloss.Color("#A4FFFF"), // Ice
+}
+
+// GetLabelColor returns a consistent color for a label based on hash
+func GetLabelColor(label string) lipgloss.Color {
+	if label == "" {
+		return ColorMuted
+	}
+	hash := 0
+	for _, c := range label {
+		hash = (hash*31 + int(c)) % len(LabelColors)
+	}
+	if hash < 0 {
+		hash = -hash
+	}
+	return LabelColors[hash%len(LabelColors)]
+}
+
+// RenderLabelChip renders a single label as a colored chip for r's terminal
+func RenderLabelChip(r *lipgloss.Renderer, label string) string {
+	return r.NewStyle().
+		Foreground(GetLabelColor(label)).
+		Background(ColorBgSubtle).
+		Padding(0, 1).
+		Render(label)
+}
+
+// RenderLabelChips renders as many label chips as fit in maxWidth,
+// followed by a "+N" marker for any that were left out.
+// Returns an empty string if not even one chip fits.
+func RenderLabelChips(r *lipgloss.Renderer, labels []string, maxWidth int) string {
+	if len(labels) == 0 || maxWidth <= 0 {
+		return ""
+	}
+
+	var chips []string
+	used := 0
+	for i, label := range labels {
+		chip := RenderLabelChip(r, label)
+		w := lipgloss.Width(chip)
+		if used > 0 {
+			w++ // separating space
+		}
+
+		// Reserve room for the overflow marker unless this is the last label
+		reserve := 0
+		if i < len(labels)-1 {
+			reserve = len(fmt.Sprintf(" +%d", len(labels)-i-1))
+		}
+		if used+w+reserve > maxWidth {
+			if len(chips) == 0 {
+				// Truncate the first chip rather than showing nothing
+				avail := maxWidth - reserve - 2
+				if avail < 2 {
+					return ""
+				}
+				chips = append(chips, RenderLabelChip(r, truncateToWidth(label, avail, "…")))
+				i++
+			}
+			if rest := len(labels) - i; rest > 0 {
+				more := r.NewStyle().Foreground(ColorMuted).Render(fmt.Sprintf("+%d", rest))
+				chips = append(chips, more)
+			}
+			return strings.Join(chips, " ")
+		}
+		chips = append(chips, chip)
+		used += w
+	}
+	return strings.Join(chips, " ")
+}
@@ -93,3 +93,35 @@ func TestRenderSparkline(t *testing.T) {
 		}
 	}
 }
+
+func TestGetLabelColor(t *testing.T) {
+	if GetLabelColor("backend") != GetLabelColor("backend") {
+		t.Error("GetLabelColor should be deterministic")
+	}
+	if GetLabelColor("") != ColorMuted {
+		t.Errorf("GetLabelColor(\"\") = %v, want %v", GetLabelColor(""), ColorMuted)
+	}
+}
+
+func TestRenderLabelChips(t *testing.T) {
+	labels := []string{"backend", "security", "perf"}
+
+	all := RenderLabelChips(lipgloss.DefaultRenderer(), labels, 80)
+	for _, l := range labels {
+		if !strings.Contains(all, l) {
+			t.Errorf("expected chip for %q in %q", l, all)
+		}
+	}
+
+	narrow := RenderLabelChips(lipgloss.DefaultRenderer(), labels, 16)
+	if !strings.Contains(narrow, "backend") || !strings.Contains(narrow, "+2") {
+		t.Errorf("expected first chip and overflow marker, got %q", narrow)
+	}
+	if w := lipgloss.Width(narrow); w > 16 {
+		t.Errorf("chips exceed max width: %d > 16", w)
+	}
+
+	if got := RenderLabelChips(lipgloss.DefaultRenderer(), nil, 80); got != "" {
+		t.Errorf("expected empty output for no labels, got %q", got)
+	}
+}
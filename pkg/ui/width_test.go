@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTruncateToWidth(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"hello world", 6, "hello…"},
+		// Wide characters take two columns; one that would straddle the limit is dropped
+		{"日本語テキスト", 7, "日本語…"},
+		{"日本語テキスト", 6, "日本…"},
+		// Combining marks stay with their letter
+		{"café au lait", 5, "café…"},
+		// Emoji sequences are never split
+		{"👩‍💻👩‍💻👩‍💻", 5, "👩‍💻👩‍💻…"},
+		{"abc", 0, ""},
+		{"abcdef", 1, "…"},
+	}
+	for _, tt := range tests {
+		got := truncateToWidth(tt.in, tt.width, "…")
+		if got != tt.want {
+			t.Errorf("truncateToWidth(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+		if w := displayWidth(got); w > tt.width {
+			t.Errorf("truncateToWidth(%q, %d) is %d columns wide", tt.in, tt.width, w)
+		}
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	for _, s := range []string{"ascii", "日本語", "日本語テキストです", "éé", "🚀 launch", ""} {
+		if got := displayWidth(fitWidth(s, 8)); got != 8 {
+			t.Errorf("fitWidth(%q, 8) is %d columns wide", s, got)
+		}
+	}
+}
+
+func TestSmartTruncateIDWide(t *testing.T) {
+	if got := smartTruncateID("前端_组件_按钮样式重构", 9); got != "前_组_按…" {
+		t.Errorf("got %q", got)
+	}
+	if got := smartTruncateID("日本語テキスト", 5); displayWidth(got) > 5 || got != "日本…" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestIssueDelegate_WideTitlesLineUp(t *testing.T) {
+	ascii, wide := newTestIssueItem("A-1"), newTestIssueItem("B-2")
+	wide.Issue.Title = "日本語のタイトル 🚀 with é and a very long tail that must be truncated somewhere"
+	items := []list.Item{ascii, wide}
+	fit := fitColumns(items)
+	delegate := IssueDelegate{Theme: DefaultTheme(lipgloss.NewRenderer(os.Stdout)), Fit: &fit}
+	l := list.New(items, delegate, 0, 0)
+	l.SetWidth(120)
+
+	var cols []int
+	for i, item := range items {
+		var buf bytes.Buffer
+		delegate.Render(&buf, l, i, item)
+		row := buf.String()
+		if w := lipgloss.Width(row); w != 119 {
+			t.Errorf("row %d is %d columns wide, want 119", i, w)
+		}
+		at := strings.Index(row, "@alice")
+		if at < 0 {
+			t.Fatalf("expected the assignee in row %d: %q", i, row)
+		}
+		cols = append(cols, lipgloss.Width(row[:at]))
+	}
+	if cols[0] != cols[1] {
+		t.Errorf("expected the assignee column to line up, got %v", cols)
+	}
+}
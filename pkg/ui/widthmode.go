@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// ambiguousWidths are the ways to measure East Asian ambiguous characters
+// (box drawing, block elements, arrows, "…"): "narrow" takes one column,
+// "wide" two as CJK terminals draw them, and "auto" picks from the
+// environment
+var ambiguousWidths = []string{"auto", "narrow", "wide"}
+
+// SetAmbiguousWidth sets how wide every view, lipgloss included, measures
+// ambiguous characters, so borders, rules and columns line up on terminals
+// that draw them two columns wide
+func SetAmbiguousWidth(mode string) error {
+	switch mode {
+	case "auto", "":
+		uniseg.EastAsianAmbiguousWidth = ambiguousWidthFromEnv(os.Getenv)
+	case "narrow":
+		uniseg.EastAsianAmbiguousWidth = 1
+	case "wide":
+		uniseg.EastAsianAmbiguousWidth = 2
+	default:
+		return fmt.Errorf("unknown ambiguous width %q (known: %s)", mode, strings.Join(ambiguousWidths, ", "))
+	}
+	return nil
+}
+
+// ambiguousWidthFromEnv follows RUNEWIDTH_EASTASIAN when set (as other
+// terminal programs do), else treats Chinese, Japanese and Korean locales as
+// wide
+func ambiguousWidthFromEnv(getenv func(string) string) int {
+	switch getenv("RUNEWIDTH_EASTASIAN") {
+	case "1":
+		return 2
+	case "0":
+		return 1
+	}
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale = getenv(name); locale != "" {
+			break
+		}
+	}
+	for _, lang := range []string{"zh", "ja", "ko"} {
+		if strings.HasPrefix(strings.ToLower(locale), lang) {
+			return 2
+		}
+	}
+	return 1
+}
+
+// repeatToWidth repeats s to fill at most width columns, for rules and bars
+// drawn with characters that are two columns wide in the wide mode
+func repeatToWidth(s string, width int) string {
+	w := displayWidth(s)
+	if w == 0 || width <= 0 {
+		return ""
+	}
+	return strings.Repeat(s, width/w)
+}
@@ -0,0 +1,355 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// assigneeFilterPrefix marks a currentFilter value that restricts the list to one assignee
+const assigneeFilterPrefix = "assignee:"
+
+// teamFilterPrefix marks a currentFilter value that restricts the list to
+// one team's issues; "team:" alone is work outside any team
+const teamFilterPrefix = "team:"
+
+// WorkloadModel is the per-assignee workload dashboard, which can roll up
+// into the teams defined in the config
+type WorkloadModel struct {
+	rows         []analysis.AssigneeWorkload
+	teamRows     []analysis.TeamWorkload
+	crossTeam    []analysis.CrossTeamBlocker
+	byTeam       bool
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewWorkloadModel computes workload for every assignee and team
+func NewWorkloadModel(issues []model.Issue, now time.Time, theme Theme) WorkloadModel {
+	m := WorkloadModel{
+		rows:  analysis.ComputeWorkload(issues, now),
+		theme: theme,
+	}
+	if teams := analysis.CurrentTeams(); len(teams) > 0 {
+		m.teamRows = analysis.ComputeTeamWorkload(issues, now, teams)
+		m.crossTeam = analysis.ComputeCrossTeamBlockers(issues, teams)
+	}
+	return m
+}
+
+// ToggleTeams switches between assignees and teams, returning whether teams
+// are shown. It stays on assignees when no teams are defined.
+func (m *WorkloadModel) ToggleTeams() bool {
+	m.byTeam = !m.byTeam && len(m.teamRows) > 0
+	m.selected, m.scrollOffset = 0, 0
+	return m.byTeam
+}
+
+// rowCount returns the number of rows in the table shown
+func (m *WorkloadModel) rowCount() int {
+	if m.byTeam {
+		return len(m.teamRows)
+	}
+	return len(m.rows)
+}
+
+// SetSize updates the view dimensions
+func (m *WorkloadModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *WorkloadModel) MoveUp() {
+	if m.selected > 0 {
+		m.selected--
+	}
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down
+func (m *WorkloadModel) MoveDown() {
+	if m.selected < m.rowCount()-1 {
+		m.selected++
+	}
+	m.ensureVisible()
+}
+
+// SelectedAssignee returns the highlighted assignee ("" for unassigned) and
+// whether there is a selection at all
+func (m *WorkloadModel) SelectedAssignee() (string, bool) {
+	if m.byTeam || m.selected < 0 || m.selected >= len(m.rows) {
+		return "", false
+	}
+	return m.rows[m.selected].Assignee, true
+}
+
+// SelectedTeam returns the highlighted team ("" for work outside any team)
+// and whether a team row is selected at all
+func (m *WorkloadModel) SelectedTeam() (string, bool) {
+	if !m.byTeam || m.selected < 0 || m.selected >= len(m.teamRows) {
+		return "", false
+	}
+	return m.teamRows[m.selected].Team, true
+}
+
+// visibleRows returns how many table rows fit below the header and column
+// titles, and in team mode above the cross-team blockers
+func (m *WorkloadModel) visibleRows() int {
+	if m.byTeam {
+		return max(min(m.height-5-m.crossTeamLines(), len(m.teamRows)), 1)
+	}
+	return max(m.height-5, 1)
+}
+
+// crossTeamLines returns how many lines the cross-team section wants:
+// a blank, a title and one line per pair of teams, at most 8
+func (m *WorkloadModel) crossTeamLines() int {
+	if len(m.crossTeam) == 0 {
+		return 0
+	}
+	return 2 + min(len(crossTeamPairs(m.crossTeam)), 8)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen
+func (m *WorkloadModel) ensureVisible() {
+	visible := m.visibleRows()
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the workload table
+func (m *WorkloadModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	if m.byTeam {
+		return m.renderTeams()
+	}
+	t := m.theme
+
+	overloaded, idle := 0, 0
+	maxOpen := 0
+	for _, r := range m.rows {
+		switch r.Level {
+		case analysis.LoadOverloaded:
+			overloaded++
+		case analysis.LoadIdle:
+			idle++
+		}
+		maxOpen = max(maxOpen, r.Open)
+	}
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("👥 WORKLOAD  │  %d assignees  │  %d overloaded  │  %d idle", len(m.rows), overloaded, idle)))
+	lines = append(lines, "")
+
+	if len(m.rows) == 0 {
+		emptyStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width - 4).
+			Align(lipgloss.Center)
+		lines = append(lines, emptyStyle.Render("No issues loaded."))
+		return strings.Join(lines, "\n")
+	}
+
+	nameWidth := 18
+	barWidth := min(max(m.width-nameWidth-64, 6), 30)
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-*s %5s %5s %7s %10s %8s  %-*s  %s",
+		nameWidth, "ASSIGNEE", "OPEN", "WIP", "BLOCKED", "DOWNSTREAM", "AVG AGE", barWidth, "LOAD", "")))
+
+	end := min(m.scrollOffset+m.visibleRows(), len(m.rows))
+	for i := m.scrollOffset; i < end; i++ {
+		r := m.rows[i]
+		name := "@" + r.Assignee
+		if r.Assignee == "" {
+			name = "(unassigned)"
+		}
+
+		age := "—"
+		if r.Open > 0 {
+			age = FormatSpan(r.AvgAge)
+		}
+		fill := 0.0
+		if maxOpen > 0 {
+			fill = float64(r.Open) / float64(maxOpen)
+		}
+
+		wip := fmt.Sprint(r.InProgress)
+		if r.WIPLimit > 0 {
+			wip = fmt.Sprintf("%d/%d", r.InProgress, r.WIPLimit)
+		}
+
+		var flag string
+		switch {
+		case r.WIPLimit > 0 && r.InProgress > r.WIPLimit:
+			flag = t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true).Render("⚠ over WIP limit")
+		case r.Level == analysis.LoadOverloaded:
+			flag = t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true).Render("⚠ overloaded")
+		case r.Level == analysis.LoadIdle:
+			flag = subtle.Render("○ idle")
+		}
+
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		row := rowStyle.Render(fmt.Sprintf("%s%s %5d %5s %7d %10d %8s",
+			prefix, fitWidth(name, nameWidth), r.Open, wip, r.Blocked, r.Downstream, age))
+		lines = append(lines, row+"  "+RenderMiniBar(fill, barWidth)+"  "+flag)
+	}
+	if len(m.rows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.rows)-end)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// teamPair is the issues of one team waiting on another team's work
+type teamPair struct {
+	team, blockerTeam string
+	issueIDs          []string
+}
+
+// crossTeamPairs groups cross-team blockers by the pair of teams, most
+// waiting issues first
+func crossTeamPairs(blockers []analysis.CrossTeamBlocker) []teamPair {
+	var pairs []teamPair
+	for _, b := range blockers {
+		// Blockers come sorted by team then blocking team
+		n := len(pairs)
+		if n == 0 || pairs[n-1].team != b.Team || pairs[n-1].blockerTeam != b.BlockerTeam {
+			pairs = append(pairs, teamPair{team: b.Team, blockerTeam: b.BlockerTeam})
+			n++
+		}
+		if ids := pairs[n-1].issueIDs; len(ids) == 0 || ids[len(ids)-1] != b.IssueID {
+			pairs[n-1].issueIDs = append(ids, b.IssueID)
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return len(pairs[i].issueIDs) > len(pairs[j].issueIDs)
+	})
+	return pairs
+}
+
+// renderTeams renders the team rollup and the cross-team blockers
+func (m *WorkloadModel) renderTeams() string {
+	t := m.theme
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	warn := t.Renderer.NewStyle().Foreground(t.Blocked).Bold(true)
+
+	teams := 0
+	maxOpen := 0
+	for _, r := range m.teamRows {
+		if r.Team != "" {
+			teams++
+		}
+		maxOpen = max(maxOpen, r.Open)
+	}
+	var lines []string
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("👥 TEAMS  │  %d teams  │  %d waiting on another team", teams, len(crossTeamIssues(m.crossTeam)))))
+	lines = append(lines, "")
+
+	nameWidth := 18
+	barWidth := min(max(m.width-nameWidth-72, 6), 30)
+	lines = append(lines, subtle.Render(fmt.Sprintf("  %-*s %7s %5s %5s %7s %7s %8s %8s  %-*s  %s",
+		nameWidth, "TEAM", "MEMBERS", "OPEN", "WIP", "BLOCKED", "WAITING", "BLOCKING", "AVG AGE", barWidth, "LOAD", "")))
+
+	end := min(m.scrollOffset+m.visibleRows(), len(m.teamRows))
+	for i := m.scrollOffset; i < end; i++ {
+		r := m.teamRows[i]
+		name, members := r.Team, fmt.Sprint(len(r.Members))
+		if r.Team == "" {
+			name, members = "(no team)", "—"
+		}
+		age := "—"
+		if r.Open > 0 {
+			age = FormatSpan(r.AvgAge)
+		}
+		fill := 0.0
+		if maxOpen > 0 {
+			fill = float64(r.Open) / float64(maxOpen)
+		}
+
+		var flags []string
+		if r.Overloaded > 0 {
+			flags = append(flags, warn.Render(fmt.Sprintf("⚠ %d overloaded", r.Overloaded)))
+		}
+		if r.Idle > 0 {
+			flags = append(flags, subtle.Render(fmt.Sprintf("○ %d idle", r.Idle)))
+		}
+
+		prefix := "  "
+		rowStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			prefix = "▸ "
+			rowStyle = rowStyle.Foreground(t.Primary).Bold(true)
+		}
+		row := rowStyle.Render(fmt.Sprintf("%s%s %7s %5d %5d %7d %7d %8d %8s",
+			prefix, fitWidth(name, nameWidth), members, r.Open, r.InProgress, r.Blocked,
+			r.WaitingOnOthers, r.BlockingOthers, age))
+		lines = append(lines, row+"  "+RenderMiniBar(fill, barWidth)+"  "+strings.Join(flags, "  "))
+	}
+	if len(m.teamRows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.teamRows)-end)))
+	}
+
+	if len(m.crossTeam) > 0 {
+		pairs := crossTeamPairs(m.crossTeam)
+		lines = append(lines, "")
+		lines = append(lines, subtle.Render("  CROSS-TEAM BLOCKERS  (team ← team it waits on)"))
+		shown := min(len(pairs), 8)
+		if len(pairs) > shown {
+			shown-- // Room for the overflow line
+		}
+		for _, p := range pairs[:shown] {
+			ids := strings.Join(p.issueIDs, ", ")
+			lines = append(lines, fmt.Sprintf("  %s %s %s  %s", p.team, warn.Render("←"), p.blockerTeam,
+				subtle.Render(fmt.Sprintf("%d waiting: %s", len(p.issueIDs), truncateToWidth(ids, max(m.width-40, 10), "…")))))
+		}
+		if len(pairs) > shown {
+			lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more pairs", len(pairs)-shown)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// crossTeamIssues returns the distinct issues waiting on another team
+func crossTeamIssues(blockers []analysis.CrossTeamBlocker) map[string]bool {
+	ids := make(map[string]bool, len(blockers))
+	for _, b := range blockers {
+		ids[b.IssueID] = true
+	}
+	return ids
+}
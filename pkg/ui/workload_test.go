@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func workloadTestIssues() []model.Issue {
+	now := time.Now()
+	var issues []model.Issue
+	for i := 0; i < 5; i++ {
+		issues = append(issues, model.Issue{ID: string(rune('A' + i)), Title: "ann work", Assignee: "ann", Status: model.StatusOpen, CreatedAt: now})
+	}
+	return append(issues,
+		model.Issue{ID: "B1", Title: "bob work", Assignee: "bob", Status: model.StatusOpen, CreatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B1", DependsOnID: "A", Type: model.DepBlocks}}},
+		model.Issue{ID: "C1", Title: "cat done", Assignee: "cat", Status: model.StatusClosed, CreatedAt: now},
+		model.Issue{ID: "U1", Title: "nobody", Status: model.StatusOpen, CreatedAt: now},
+	)
+}
+
+func TestWorkloadRender(t *testing.T) {
+	w := NewWorkloadModel(workloadTestIssues(), time.Now(), DefaultTheme(lipgloss.NewRenderer(nil)))
+	w.SetSize(120, 20)
+	out := w.Render()
+	for _, want := range []string{"4 assignees", "1 overloaded", "1 idle", "@ann", "⚠ overloaded", "○ idle", "(unassigned)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in workload view:\n%s", want, out)
+		}
+	}
+}
+
+func TestWorkloadEnterFiltersByAssignee(t *testing.T) {
+	m := NewModel(workloadTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	m = updated.(Model)
+	if !m.isWorkloadView || m.focused != focusWorkload {
+		t.Fatalf("expected workload view focused")
+	}
+	// Rows are busiest first: ann, then unassigned and bob (1 each, by name), then idle cat
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isWorkloadView || m.focused != focusList {
+		t.Fatalf("expected list focused after enter")
+	}
+	if got := strings.Join(visibleIDs(m), ","); got != "U1" {
+		t.Fatalf("expected unassigned issue U1, got %s", got)
+	}
+}
+
+func TestWorkloadTeams(t *testing.T) {
+	analysis.SetTeams(analysis.Teams{"web": {"ann"}, "infra": {"bob", "cat"}})
+	defer analysis.SetTeams(analysis.Teams{})
+
+	m := NewModel(workloadTestIssues(), nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 30})
+	m = updated.(Model)
+	m = typeKeys(t, m, "Wt")
+	out := m.workloadView.Render()
+	// B1 (infra) waits on A (web)
+	for _, want := range []string{"2 teams", "1 waiting on another team", "web", "infra", "(no team)", "infra ← web", "1 waiting: B1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in team view:\n%s", want, out)
+		}
+	}
+
+	// Teams are busiest first: web, infra, then work outside any team
+	m = typeKeys(t, m, "j")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if got := strings.Join(visibleIDs(m), ","); got != "B1,C1" {
+		t.Fatalf("expected infra's issues, got %s", got)
+	}
+	if md := m.teamMarkdown(*m.issueMap["B1"]); !strings.Contains(md, "blocked by other teams: A (@ann, web)") {
+		t.Errorf("unexpected team bullet %q", md)
+	}
+
+	// Without teams, t says how to define them
+	analysis.SetTeams(analysis.Teams{})
+	m = typeKeys(t, m, "Wt")
+	if m.workloadView.byTeam || !m.statusIsError {
+		t.Errorf("expected no team mode without teams")
+	}
+}
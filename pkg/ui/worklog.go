@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// worklogRow is a line of the time report: a section heading or a total
+type worklogRow struct {
+	heading string
+	total   analysis.TimeTotal
+	issue   bool          // The total's key is an issue to jump to
+	none    string        // Shown for an empty key
+	scale   time.Duration // The section's largest total, a full bar
+}
+
+// WorklogModel is the time report: the worklog totalled by epic, by
+// assignee and by issue
+type WorklogModel struct {
+	spent        analysis.TimeSpent
+	rows         []worklogRow
+	selected     int
+	scrollOffset int
+	width        int
+	height       int
+	theme        Theme
+}
+
+// NewWorklogModel totals the worklog entries for issues
+func NewWorklogModel(issues []model.Issue, entries []worklog.Entry, now time.Time, theme Theme) WorklogModel {
+	m := WorklogModel{
+		spent: analysis.ComputeTimeSpent(issues, entries, now),
+		theme: theme,
+	}
+	m.addSection("By epic", "(no epic)", m.spent.ByEpic, true)
+	m.addSection("By assignee", "(unassigned)", m.spent.ByAssignee, false)
+	m.addSection("By issue", "", m.spent.ByIssue, true)
+	m.selected = m.nextTotal(0, 1)
+	return m
+}
+
+// addSection appends a heading and its totals to the rows
+func (m *WorklogModel) addSection(heading, none string, totals []analysis.TimeTotal, issues bool) {
+	if len(totals) == 0 {
+		return
+	}
+	m.rows = append(m.rows, worklogRow{heading: heading})
+	for _, t := range totals {
+		m.rows = append(m.rows, worklogRow{total: t, issue: issues && t.Key != "", none: none, scale: totals[0].Spent})
+	}
+}
+
+// nextTotal returns the first total row from i in direction step, or the
+// current selection when there is none
+func (m *WorklogModel) nextTotal(i, step int) int {
+	for ; i >= 0 && i < len(m.rows); i += step {
+		if m.rows[i].heading == "" {
+			return i
+		}
+	}
+	return m.selected
+}
+
+// SetSize updates the view dimensions
+func (m *WorklogModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.ensureVisible()
+}
+
+// MoveUp moves selection up, past headings
+func (m *WorklogModel) MoveUp() {
+	m.selected = m.nextTotal(m.selected-1, -1)
+	m.ensureVisible()
+}
+
+// MoveDown moves selection down, past headings
+func (m *WorklogModel) MoveDown() {
+	m.selected = m.nextTotal(m.selected+1, 1)
+	m.ensureVisible()
+}
+
+// SelectedIssue returns the ID of the highlighted issue or epic, or ""
+// for an assignee or when nothing was logged
+func (m *WorklogModel) SelectedIssue() string {
+	if m.selected < 0 || m.selected >= len(m.rows) || !m.rows[m.selected].issue {
+		return ""
+	}
+	return m.rows[m.selected].total.Key
+}
+
+// visibleRows returns how many report lines fit
+func (m *WorklogModel) visibleRows() int {
+	// header, blank, rows, blank, legend
+	return max(m.height-5, 1)
+}
+
+// ensureVisible adjusts scroll to keep the selected row on screen, with its
+// section heading when it is the first
+func (m *WorklogModel) ensureVisible() {
+	visible := m.visibleRows()
+	top := m.selected
+	if top > 0 && m.rows[top-1].heading != "" {
+		top--
+	}
+	if top < m.scrollOffset {
+		m.scrollOffset = top
+	}
+	if m.selected >= m.scrollOffset+visible {
+		m.scrollOffset = m.selected - visible + 1
+	}
+}
+
+// Render renders the time report
+func (m *WorklogModel) Render() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	t := m.theme
+
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Base.GetForeground()).
+		Background(t.Primary).
+		Padding(0, 2).
+		Width(m.width - 4)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	header := fmt.Sprintf("⏱ TIME SPENT  │  %s logged on %d issues", worklog.FormatDuration(m.spent.Total), len(m.spent.ByIssue))
+	lines := []string{headerStyle.Render(header), ""}
+
+	if len(m.rows) == 0 {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Italic(true).
+			Padding(2, 4).
+			Width(m.width-4).
+			Align(lipgloss.Center).
+			Render("No time logged yet. Press alt+t on an issue to start its timer."))
+		return strings.Join(lines, "\n")
+	}
+
+	barWidth := min(max(m.width/5, 8), 24)
+	end := min(m.scrollOffset+m.visibleRows(), len(m.rows))
+	for i := m.scrollOffset; i < end; i++ {
+		row := m.rows[i]
+		if row.heading != "" {
+			lines = append(lines, t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render(row.heading))
+			continue
+		}
+		cursor := "  "
+		nameStyle := t.Renderer.NewStyle()
+		if i == m.selected {
+			cursor = "▸ "
+			nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+		}
+		name := row.total.Key
+		if name == "" {
+			name = row.none
+		}
+		if row.total.Title != "" {
+			name += "  " + row.total.Title
+		}
+		spent := worklog.FormatDuration(row.total.Spent)
+		if row.total.Running {
+			spent += " ⏱"
+		}
+		filled := 0
+		if row.scale > 0 {
+			filled = int((row.total.Spent*time.Duration(barWidth) + row.scale/2) / row.scale)
+		}
+		bar := t.Renderer.NewStyle().Foreground(t.InProgress).Render(repeatToWidth("█", filled)) +
+			t.Renderer.NewStyle().Foreground(t.Secondary).Render(repeatToWidth("░", barWidth-filled))
+		nameWidth := max(m.width-barWidth-22, 10)
+		lines = append(lines, cursor+nameStyle.Render(padCell(truncateToWidth(name, nameWidth, "…"), nameWidth, false))+
+			" "+bar+" "+subtle.Render(spent))
+	}
+	if len(m.rows) > end {
+		lines = append(lines, subtle.Render(fmt.Sprintf("  … %d more", len(m.rows)-end)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, subtle.Render(" Time from the worklog (.bv/worklog.json)  ·  by assignee is who logged it  ·  ⏱ a timer is running"))
+	return strings.Join(lines, "\n")
+}
+
+// SetWorklog sets the project's worklog, enabling the timer (alt+t)
+func (m *Model) SetWorklog(l *worklog.Log) {
+	m.worklog = l
+}
+
+// toggleTimer starts the timer on the selected issue, or stops it when it
+// is already running there. Starting one stops any running elsewhere.
+func (m *Model) toggleTimer() {
+	if m.worklog == nil {
+		m.statusMsg = "❌ No worklog: time tracking needs a project directory"
+		m.statusIsError = true
+		return
+	}
+	issue, ok := m.selectedIssue()
+	if !ok || m.refuseRemoteEdit() {
+		return
+	}
+	now := time.Now()
+	var status string
+	if running := m.worklog.Running(m.notifyAssignee); running != nil && running.IssueID == issue.ID {
+		stopped := m.worklog.Stop(m.notifyAssignee, now)
+		status = fmt.Sprintf("⏱ Stopped %s after %s (%s in all)", issue.ID,
+			worklog.FormatDuration(stopped.Duration(now)), worklog.FormatDuration(m.worklog.Spent(issue.ID, now)))
+	} else if stopped := m.worklog.Start(issue.ID, m.notifyAssignee, now); stopped != nil {
+		status = fmt.Sprintf("⏱ Started %s, stopped %s after %s", issue.ID, stopped.IssueID, worklog.FormatDuration(stopped.Duration(now)))
+	} else {
+		status = fmt.Sprintf("⏱ Started %s", issue.ID)
+	}
+	if err := m.worklog.Save(); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Saving the worklog failed: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = status
+	m.statusIsError = false
+	m.updateViewportContent()
+}
+
+// timeSpentBadge returns the details badge for the time logged on id, or ""
+func (m *Model) timeSpentBadge(id string) string {
+	if m.worklog == nil {
+		return ""
+	}
+	now := time.Now()
+	spent := m.worklog.Spent(id, now)
+	if spent == 0 {
+		return ""
+	}
+	text := "⏱ " + worklog.FormatDuration(spent) + " spent"
+	if running := m.worklog.Running(m.notifyAssignee); running != nil && running.IssueID == id {
+		text += ", timer running"
+	}
+	return m.theme.Renderer.NewStyle().Foreground(ColorWarning).Render(text)
+}
+
+// runningTimer returns the footer badge for the running timer, or ""
+func (m *Model) runningTimer() string {
+	if m.worklog == nil {
+		return ""
+	}
+	running := m.worklog.Running(m.notifyAssignee)
+	if running == nil {
+		return ""
+	}
+	return fmt.Sprintf("⏱ %s %s", running.IssueID, worklog.FormatDuration(running.Duration(time.Now())))
+}
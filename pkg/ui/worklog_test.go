@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/worklog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWorklogTimerAndReport(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "E", Title: "Checkout", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "A", Title: "Cart totals", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{DependsOnID: "E", Type: model.DepParentChild}}},
+	}
+	path := worklog.DefaultPath(t.TempDir())
+	wl, err := worklog.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An hour already logged on A
+	end := time.Now().Add(-time.Hour)
+	wl.Entries = append(wl.Entries, worklog.Entry{IssueID: "A", Author: "alice", Start: end.Add(-time.Hour), End: &end})
+
+	m := NewModel(issues, nil, "")
+	m.SetNotifyAssignee("alice", false)
+	m.SetWorklog(wl)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m = updated.(Model)
+	m.list.Select(1)
+
+	alt := func(r rune) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true})
+		m = updated.(Model)
+	}
+	alt('t')
+	if r := wl.Running("alice"); r == nil || r.IssueID != "A" || m.statusMsg != "⏱ Started A" {
+		t.Fatalf("expected alice's timer started on A, got %+v / %q", r, m.statusMsg)
+	}
+	if !strings.Contains(m.View(), "⏱ A 0m") {
+		t.Errorf("expected the running timer in the status bar:\n%s", m.View())
+	}
+	saved, err := worklog.Load(path)
+	if err != nil || saved.Running("alice") == nil {
+		t.Fatalf("expected the running timer saved, got %+v, %v", saved, err)
+	}
+
+	alt('w')
+	if !m.isWorklogView || m.focused != focusWorklog {
+		t.Fatalf("expected alt+w to open the time report")
+	}
+	out := m.View()
+	for _, want := range []string{"TIME SPENT", "1h logged on 1 issues", "By epic", "E  Checkout", "By assignee", "alice", "By issue", "A  Cart totals", "1h ⏱"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the time report:\n%s", want, out)
+		}
+	}
+	// The epic comes first; enter jumps to it
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.isWorklogView {
+		t.Fatalf("expected enter to leave the time report")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "E" {
+		t.Errorf("expected E selected, got %v", m.list.SelectedItem())
+	}
+
+	m.list.Select(1)
+	alt('t')
+	if wl.Running("alice") != nil || !strings.HasPrefix(m.statusMsg, "⏱ Stopped A after 0m (1h in all)") {
+		t.Fatalf("expected the timer stopped, got %q", m.statusMsg)
+	}
+}
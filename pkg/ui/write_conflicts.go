@@ -0,0 +1,322 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// issueEdit is a change to some of an issue's fields, made in bv against
+// base, the issue as it was loaded
+type issueEdit struct {
+	base model.Issue
+	ours model.Issue         // base with the change applied
+	done func(error) tea.Msg // Reports the outcome of saving it
+}
+
+// WriteConflictMsg reports an edit that clashes with changes saved outside
+// bv, with bd for instance, since the issues were loaded
+type WriteConflictMsg struct {
+	Theirs    model.Issue // The issue as it is now
+	Merged    model.Issue // Theirs with the edit's changes that merged cleanly
+	Conflicts []analysis.FieldConflict
+}
+
+// ConflictResolvedMsg reports the outcome of saving an issue merged in the
+// conflict resolver
+type ConflictResolvedMsg struct {
+	ID  string
+	Err error
+}
+
+// currentIssue reads the issue id as the beads file has it now. It is a
+// variable so tests can stub it.
+var currentIssue = func(beadsPath, id string) (model.Issue, bool) {
+	if beadsPath == "" {
+		return model.Issue{}, false
+	}
+	res, err := loader.LoadIssuesFromFileWithProgress(beadsPath, nil)
+	if err != nil {
+		return model.Issue{}, false
+	}
+	for _, issue := range res.Issues {
+		if issue.ID == id {
+			return issue, true
+		}
+	}
+	return model.Issue{}, false
+}
+
+// writeIssueCmd saves edit without clobbering changes made to the issue
+// since it was loaded: it rereads the issue, merges the edit into it, and
+// saves only the fields that differ from what is on disk. Changes it can't
+// merge come back as a WriteConflictMsg instead.
+func writeIssueCmd(beadsPath string, edit issueEdit) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		theirs, ok := currentIssue(beadsPath, edit.base.ID)
+		if !ok {
+			theirs = edit.base // Not on disk to compare with: save the edit as made
+		}
+		merged, conflicts := analysis.MergeIssue(edit.base, edit.ours, theirs)
+		if len(conflicts) > 0 {
+			return WriteConflictMsg{Theirs: theirs, Merged: merged, Conflicts: conflicts}
+		}
+		return edit.done(saveIssueFields(dir, theirs, merged))
+	}
+}
+
+// SaveMergedCmd saves an issue merged in the conflict resolver
+func SaveMergedCmd(beadsPath string, theirs, merged model.Issue) tea.Cmd {
+	dir := beadsProjectDir(beadsPath)
+	return func() tea.Msg {
+		return ConflictResolvedMsg{ID: merged.ID, Err: saveIssueFields(dir, theirs, merged)}
+	}
+}
+
+// saveIssueFields saves the fields of merged that differ from theirs using
+// the bd CLI: bd close to close the issue, bd update for the rest
+func saveIssueFields(dir string, theirs, merged model.Issue) error {
+	args := []string{"update", merged.ID}
+	closing := false
+	for _, field := range analysis.MergeFields {
+		value := analysis.IssueField(merged, field)
+		if value == analysis.IssueField(theirs, field) {
+			continue
+		}
+		if field == "status" && merged.Status == model.StatusClosed {
+			closing = true
+			continue
+		}
+		args = append(args, "--"+field, value)
+	}
+	if len(args) > 2 {
+		if err := runBeadsCLI(dir, args...); err != nil {
+			return err
+		}
+	}
+	if closing {
+		return runBeadsCLI(dir, "close", merged.ID)
+	}
+	return nil
+}
+
+// ConflictResolverModel is the overlay for settling an edit that clashes
+// with changes saved outside bv: for each field both changed, keep mine or
+// take theirs
+type ConflictResolverModel struct {
+	conflict WriteConflictMsg
+	mine     []bool // Per conflict, whether to keep our value
+	cursor   int
+	width    int
+	height   int
+	theme    Theme
+}
+
+// NewConflictResolverModel creates the conflict resolver
+func NewConflictResolverModel(theme Theme) ConflictResolverModel {
+	return ConflictResolverModel{theme: theme}
+}
+
+// SetSize updates the overlay dimensions
+func (m *ConflictResolverModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Open shows conflict, every field keeping our value to start with
+func (m *ConflictResolverModel) Open(conflict WriteConflictMsg) {
+	m.conflict = conflict
+	m.mine = make([]bool, len(conflict.Conflicts))
+	for i := range m.mine {
+		m.mine[i] = true
+	}
+	m.cursor = 0
+}
+
+// Move moves the cursor by delta fields
+func (m *ConflictResolverModel) Move(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), len(m.mine)-1)
+}
+
+// Choose keeps our value of the highlighted field, or takes theirs
+func (m *ConflictResolverModel) Choose(mine bool) {
+	if m.cursor < len(m.mine) {
+		m.mine[m.cursor] = mine
+	}
+}
+
+// ChooseAll keeps our value of every field, or takes theirs
+func (m *ConflictResolverModel) ChooseAll(mine bool) {
+	for i := range m.mine {
+		m.mine[i] = mine
+	}
+}
+
+// Theirs returns the issue as saved outside bv
+func (m *ConflictResolverModel) Theirs() model.Issue {
+	return m.conflict.Theirs
+}
+
+// Resolved returns the issue to save: the clean merge with each conflicting
+// field as chosen
+func (m *ConflictResolverModel) Resolved() model.Issue {
+	issue := m.conflict.Merged
+	for i, c := range m.conflict.Conflicts {
+		value := c.Theirs
+		if m.mine[i] {
+			value = c.Ours
+		}
+		analysis.SetIssueField(&issue, c.Field, value)
+	}
+	return issue
+}
+
+// conflictPreview renders a field's value on up to three lines, the
+// changed ones for a description
+func conflictPreview(c analysis.FieldConflict, value string, width int) []string {
+	lines := strings.Split(value, "\n")
+	if c.Field == "description" {
+		baseLines := make(map[string]bool)
+		for _, line := range strings.Split(c.Base, "\n") {
+			baseLines[line] = true
+		}
+		var changed []string
+		for _, line := range lines {
+			if !baseLines[line] {
+				changed = append(changed, line)
+			}
+		}
+		if len(changed) == 0 {
+			changed = []string{"(lines removed)"}
+		}
+		lines = changed
+	}
+	if value == "" {
+		lines = []string{"(none)"}
+	}
+	if len(lines) > 3 {
+		lines = append(lines[:2:2], fmt.Sprintf("… %d more lines", len(lines)-2))
+	}
+	for i, line := range lines {
+		lines[i] = truncateToWidth(line, width, "…")
+	}
+	return lines
+}
+
+// View renders the resolver
+func (m *ConflictResolverModel) View() string {
+	t := m.theme
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	subtle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	chosen := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+	width := min(max(m.width-16, 30), 76)
+
+	issue := m.conflict.Theirs
+	lines := []string{
+		titleStyle.Render("⚠ "+issue.ID+" changed since it was loaded") + " " +
+			subtle.Render(truncateToWidth(issue.Title, max(width-displayWidth(issue.ID)-30, 10), "…")),
+		subtle.Render("Your edit and the saved issue both changed these fields:"),
+	}
+	for i, c := range m.conflict.Conflicts {
+		pointer := "  "
+		name := c.Field
+		if i == m.cursor {
+			pointer = titleStyle.Render("▸ ")
+			name = titleStyle.Render(name)
+		}
+		lines = append(lines, "", pointer+name)
+		for _, side := range []struct {
+			label string
+			value string
+			mine  bool
+		}{{"mine  ", c.Ours, true}, {"theirs", c.Theirs, false}} {
+			mark := "  "
+			label := subtle.Render(side.label)
+			if m.mine[i] == side.mine {
+				mark = chosen.Render("✓ ")
+				label = chosen.Render(side.label)
+			}
+			for j, line := range conflictPreview(c, side.value, width-12) {
+				if j == 0 {
+					lines = append(lines, "  "+mark+label+"  "+line)
+				} else {
+					lines = append(lines, strings.Repeat(" ", 12)+line)
+				}
+			}
+		}
+	}
+	lines = append(lines, "", subtle.Italic(true).Render(
+		"j/k: field • m/t: keep mine / take theirs • M/T: all • enter: save • esc: discard my edit"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorWarning).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openConflictResolver shows an edit's conflict with the saved issue
+func (m *Model) openConflictResolver(conflict WriteConflictMsg) {
+	m.conflictResolver.Open(conflict)
+	m.conflictResolver.SetSize(m.width, m.height-1)
+	m.showConflictResolver = true
+	m.statusMsg = fmt.Sprintf("⚠ %s was changed outside bv: choose what to keep", conflict.Theirs.ID)
+	m.statusIsError = true
+}
+
+// setMergeFields shows issue's MergeFields for it right away, ahead of the
+// reload that picks up what was saved
+func (m *Model) setMergeFields(issue model.Issue) {
+	m.patchIssue(issue.ID, func(shown *model.Issue) {
+		for _, field := range analysis.MergeFields {
+			analysis.SetIssueField(shown, field, analysis.IssueField(issue, field))
+		}
+	})
+	if m.focusMode.issue.ID == issue.ID {
+		for _, field := range analysis.MergeFields {
+			analysis.SetIssueField(&m.focusMode.issue, field, analysis.IssueField(issue, field))
+		}
+	}
+}
+
+// handleConflictResolverKeys handles keyboard input in the conflict
+// resolver: enter saves the chosen values, esc keeps the saved issue
+func (m Model) handleConflictResolverKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	r := &m.conflictResolver
+	switch msg.String() {
+	case "j", "down":
+		r.Move(1)
+	case "k", "up":
+		r.Move(-1)
+	case "m", "left", "h":
+		r.Choose(true)
+	case "t", "right", "l":
+		r.Choose(false)
+	case "M":
+		r.ChooseAll(true)
+	case "T":
+		r.ChooseAll(false)
+	case "enter":
+		m.showConflictResolver = false
+		resolved := r.Resolved()
+		m.setMergeFields(resolved)
+		m.statusMsg = fmt.Sprintf("Saving %s…", resolved.ID)
+		m.statusIsError = false
+		return m, SaveMergedCmd(m.beadsPath, r.Theirs(), resolved)
+	case "esc":
+		m.showConflictResolver = false
+		m.setMergeFields(r.Theirs())
+		m.statusMsg = fmt.Sprintf("Discarded your edit of %s, keeping the saved issue", r.Theirs().ID)
+		m.statusIsError = false
+	}
+	return m, nil
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestWriteBackMergesConcurrentChanges(t *testing.T) {
+	var calls []string
+	orig := runBeadsCLI
+	runBeadsCLI = func(dir string, args ...string) error {
+		calls = append(calls, strings.Join(args, "|"))
+		return nil
+	}
+	defer func() { runBeadsCLI = orig }()
+
+	loaded := model.Issue{ID: "A", Title: "Crash on save", Status: model.StatusOpen, IssueType: model.TypeBug,
+		Description: "Steps:\n- [ ] Reproduce\n- [ ] Fix", CreatedAt: time.Now()}
+	beads := filepath.Join(t.TempDir(), "beads.jsonl")
+	save := func(issue model.Issue) {
+		t.Helper()
+		data, err := json.Marshal(issue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(beads, append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save(loaded)
+	m := NewModel([]model.Issue{loaded}, nil, beads)
+	defer m.Stop()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	// bd changes the issue behind bv's back: a new step, a new assignee
+	theirs := loaded
+	theirs.Description = "Steps:\n- [ ] Reproduce\n- [ ] Bisect\n- [ ] Fix"
+	theirs.Assignee = "bob"
+	save(theirs)
+
+	// Checking off "Reproduce" merges into their description
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	want := "update|A|--description|Steps:\n- [x] Reproduce\n- [ ] Bisect\n- [ ] Fix"
+	if strings.Join(calls, "\n") != want || m.statusIsError {
+		t.Fatalf("expected the toggle merged into their description, got %q / %q", calls, m.statusMsg)
+	}
+	theirs.Description = "Steps:\n- [x] Reproduce\n- [ ] Bisect\n- [ ] Fix"
+	save(theirs)
+
+	// Assigning alice clashes with bob
+	calls = nil
+	m = typeKeys(t, m, "walice")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if !m.showConflictResolver || len(calls) != 0 {
+		t.Fatalf("expected the conflict resolver and nothing saved, got %q", calls)
+	}
+	out := m.View()
+	for _, want := range []string{"A changed since it was loaded", "assignee", "mine", "alice", "theirs", "bob"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the resolver:\n%s", want, out)
+		}
+	}
+	// Keys go to the resolver, not the list
+	m = typeKeys(t, m, "jt")
+	if !m.showConflictResolver || m.conflictResolver.Resolved().Assignee != "bob" {
+		t.Fatalf("expected t to take their assignee")
+	}
+	m = typeKeys(t, m, "m")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showConflictResolver || m.issueMap["A"].Assignee != "alice" ||
+		m.issueMap["A"].Description != theirs.Description {
+		t.Fatalf("expected the merged issue shown, got %+v", *m.issueMap["A"])
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if strings.Join(calls, "\n") != "update|A|--assignee|alice" || m.statusIsError {
+		t.Fatalf("expected only the assignee saved, got %q / %q", calls, m.statusMsg)
+	}
+
+	// esc keeps their version
+	calls = nil
+	m = typeKeys(t, m, "wcarol")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showConflictResolver || len(calls) != 0 || m.issueMap["A"].Assignee != "bob" {
+		t.Fatalf("expected the edit discarded, got %q, assignee %q", calls, m.issueMap["A"].Assignee)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build darwin
+
+package watcher
+
+import (
+	"strings"
+	"syscall"
+)
+
+// networkFS returns the kind of network filesystem dir is on, or "" for a
+// local one
+func networkFS(dir string) string {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return ""
+	}
+	var name strings.Builder
+	for _, c := range st.Fstypename {
+		if c == 0 {
+			break
+		}
+		name.WriteByte(byte(c))
+	}
+	switch fs := name.String(); {
+	case fs == "nfs", fs == "smbfs", fs == "afpfs", fs == "webdav":
+		return fs
+	case strings.Contains(fs, "fuse"): // macfuse, osxfuse (sshfs)
+		return "fuse"
+	}
+	return ""
+}
@@ -0,0 +1,27 @@
+//go:build linux
+
+package watcher
+
+import "syscall"
+
+// Filesystem magic numbers from statfs(2) for mounts whose changes fsnotify
+// can miss
+var networkFSMagic = map[uint32]string{
+	0x6969:     "nfs",
+	0x517B:     "smb",
+	0xFF534D42: "cifs",
+	0xFE534D42: "smb2",
+	0x65735546: "fuse", // sshfs, rclone and friends
+}
+
+// networkFS returns the kind of network filesystem dir is on, or "" for a
+// local one
+func networkFS(dir string) string {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil {
+		return ""
+	}
+	// f_type is a signed word whose width varies by architecture; the magic
+	// numbers are its low 32 bits, which don't sign-extend this way
+	return networkFSMagic[uint32(st.Type)]
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package watcher
+
+// networkFS can't tell network filesystems apart on this platform
+func networkFS(dir string) string {
+	return ""
+}
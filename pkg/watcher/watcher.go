@@ -3,6 +3,8 @@ package watcher
 import (
 	"context"
 	"errors"
+	"hash/fnv"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -59,6 +61,25 @@ func WithForcePoll(force bool) WatcherOption {
 	}
 }
 
+// WithHashCheck makes polling compare the file's contents as well as its
+// mtime and size, catching rewrites that keep both (coarse mtimes on
+// network filesystems, attribute caching).
+func WithHashCheck(check bool) WatcherOption {
+	return func(w *Watcher) {
+		w.hashCheck = check
+	}
+}
+
+// WithNetworkFSDetection sets whether a file on a network filesystem (NFS,
+// SMB, FUSE mounts such as SSHFS) is polled rather than watched with
+// fsnotify, whose events don't arrive for changes made on other machines.
+// It is on by default.
+func WithNetworkFSDetection(detect bool) WatcherOption {
+	return func(w *Watcher) {
+		w.detectNetworkFS = detect
+	}
+}
+
 // Watcher monitors a file for changes using fsnotify with polling fallback.
 type Watcher struct {
 	path             string
@@ -67,12 +88,16 @@ type Watcher struct {
 	onChange         func()
 	onError          func(error)
 	forcePoll        bool
+	hashCheck        bool
+	detectNetworkFS  bool
 
 	fsWatcher   *fsnotify.Watcher
 	debouncer   *Debouncer
 	useFallback bool
+	pollReason  string
 	lastMtime   time.Time
 	lastSize    int64
+	lastHash    uint64
 
 	ctx      context.Context
 	cancel   context.CancelFunc
@@ -94,6 +119,7 @@ func NewWatcher(path string, opts ...WatcherOption) (*Watcher, error) {
 		pollInterval:     DefaultPollInterval,
 		onChange:         func() {},
 		onError:          func(error) {},
+		detectNetworkFS:  true,
 		changeCh:         make(chan struct{}, 1),
 	}
 
@@ -108,6 +134,13 @@ func NewWatcher(path string, opts ...WatcherOption) (*Watcher, error) {
 
 // Start begins watching the file for changes.
 func (w *Watcher) Start() error {
+	// Hash outside the lock, as the poll loop does (hashCheck is fixed
+	// at construction)
+	var initialHash uint64
+	if w.hashCheck {
+		initialHash, _ = hashFile(w.path)
+	}
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
@@ -129,10 +162,20 @@ func (w *Watcher) Start() error {
 	} else {
 		w.lastMtime = info.ModTime()
 		w.lastSize = info.Size()
+		w.lastHash = initialHash
+	}
+
+	w.pollReason = ""
+	if w.forcePoll {
+		w.pollReason = "configured"
+	} else if w.detectNetworkFS {
+		if fs := networkFS(filepath.Dir(w.path)); fs != "" {
+			w.pollReason = fs
+		}
 	}
 
 	// Try to use fsnotify
-	if !w.forcePoll {
+	if w.pollReason == "" {
 		fsw, err := fsnotify.NewWatcher()
 		if err == nil {
 			// Watch the directory containing the file (more reliable for atomic writes)
@@ -148,6 +191,9 @@ func (w *Watcher) Start() error {
 		} else {
 			w.useFallback = true
 		}
+		if w.useFallback {
+			w.pollReason = "fsnotify unavailable"
+		}
 	} else {
 		w.useFallback = true
 	}
@@ -195,6 +241,15 @@ func (w *Watcher) IsPolling() bool {
 	return w.useFallback
 }
 
+// PollReason says why the watcher polls: "configured", the network
+// filesystem the file is on (e.g. "nfs"), or "fsnotify unavailable". It is
+// "" when fsnotify is in use.
+func (w *Watcher) PollReason() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.pollReason
+}
+
 // IsStarted returns true if the watcher is running.
 func (w *Watcher) IsStarted() bool {
 	w.mu.RLock()
@@ -213,6 +268,11 @@ func (w *Watcher) Path() string {
 	return w.path
 }
 
+// PollInterval returns how often the file is checked when polling.
+func (w *Watcher) PollInterval() time.Duration {
+	return w.pollInterval
+}
+
 // watchFsnotify monitors using fsnotify events.
 func (w *Watcher) watchFsnotify() {
 	targetFile := filepath.Base(w.path)
@@ -289,8 +349,23 @@ func (w *Watcher) watchPolling() {
 				continue
 			}
 
+			// Read the file before taking the lock, so a slow mount doesn't
+			// block Stop or other readers for the length of the read
+			var sum uint64
+			hashed := false
+			if w.hashCheck {
+				var err error
+				sum, err = hashFile(w.path)
+				hashed = err == nil
+			}
+
+			// Any mtime change counts: clocks on network mounts can run behind
 			w.mu.Lock()
-			changed := info.ModTime().After(w.lastMtime) || info.Size() != w.lastSize
+			changed := !info.ModTime().Equal(w.lastMtime) || info.Size() != w.lastSize
+			if hashed {
+				changed = changed || sum != w.lastHash
+				w.lastHash = sum
+			}
 			if changed {
 				w.lastMtime = info.ModTime()
 				w.lastSize = info.Size()
@@ -304,6 +379,20 @@ func (w *Watcher) watchPolling() {
 	}
 }
 
+// hashFile returns a hash of the file's contents
+func hashFile(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	h := fnv.New64a()
+	if _, err := io.Copy(h, f); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
 // notifyChange invokes the onChange callback and signals the change channel.
 func (w *Watcher) notifyChange() {
 	w.mu.RLock()
@@ -163,6 +163,52 @@ func TestWatcher_PollingFallback(t *testing.T) {
 	}
 }
 
+func TestWatcher_PollingHashCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "test.jsonl")
+
+	if err := os.WriteFile(tmpFile, []byte("aaaa"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(tmpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(tmpFile,
+		WithDebounceDuration(50*time.Millisecond),
+		WithPollInterval(100*time.Millisecond),
+		WithForcePoll(true),
+		WithHashCheck(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	if w.PollReason() != "configured" {
+		t.Errorf("expected poll reason %q, got %q", "configured", w.PollReason())
+	}
+
+	// Same size and mtime, as a coarse-grained network mount might report
+	if err := os.WriteFile(tmpFile, []byte("bbbb"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(tmpFile, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-w.Changed():
+	case <-time.After(time.Second):
+		t.Error("expected the content change to be detected by hash")
+	}
+}
+
 func TestWatcher_ChangedChannel(t *testing.T) {
 	tmpDir := t.TempDir()
 	tmpFile := filepath.Join(tmpDir, "test.jsonl")
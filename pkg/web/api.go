@@ -0,0 +1,298 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// registerAPI adds the JSON endpoints. They serve the same snapshot as the
+// pages, so a dashboard and a browser never disagree.
+func (s *Server) registerAPI(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/summary", s.handleAPISummary)
+	mux.HandleFunc("GET /api/issues", s.handleAPIIssues)
+	mux.HandleFunc("GET /api/issues/{id}", s.handleAPIIssue)
+	mux.HandleFunc("GET /api/ready", s.handleAPIReady)
+	mux.HandleFunc("GET /api/cycles", s.handleAPICycles)
+	mux.HandleFunc("GET /api/critical-path", s.handleAPICriticalPath)
+	mux.HandleFunc("GET /api/metrics", s.handleAPIMetrics)
+	mux.HandleFunc("GET /api/insights", s.handleAPIInsights)
+	mux.HandleFunc("GET /api/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "no such endpoint: "+r.URL.Path)
+	})
+}
+
+// apiIssue is an issue as the API returns it, with its place in the graph
+type apiIssue struct {
+	model.Issue
+	Ready     bool     `json:"ready"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // Open issues it waits on
+}
+
+// MarshalJSON encodes the issue, its custom fields included, with Ready
+// and BlockedBy alongside. Without it the embedded issue's MarshalJSON
+// would encode the issue alone.
+func (a apiIssue) MarshalJSON() ([]byte, error) {
+	return mergedJSON{a.Issue, struct {
+		Ready     bool     `json:"ready"`
+		BlockedBy []string `json:"blocked_by,omitempty"`
+	}{a.Ready, a.BlockedBy}}.MarshalJSON()
+}
+
+// mergedJSON encodes as one object holding the fields of each of its
+// values, which must encode as objects. It stands in for embedding types
+// that have their own MarshalJSON.
+type mergedJSON []any
+
+func (m mergedJSON) MarshalJSON() ([]byte, error) {
+	out := []byte{'{'}
+	for _, v := range m {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) < 2 || data[0] != '{' {
+			return nil, fmt.Errorf("merging JSON: %T is not an object", v)
+		}
+		if fields := data[1 : len(data)-1]; len(fields) > 0 {
+			if len(out) > 1 {
+				out = append(out, ',')
+			}
+			out = append(out, fields...)
+		}
+	}
+	return append(out, '}'), nil
+}
+
+func (snap *snapshot) apiIssue(issue model.Issue) apiIssue {
+	return apiIssue{
+		Issue:     issue,
+		Ready:     issue.Status != model.StatusClosed && snap.ready[issue.ID],
+		BlockedBy: snap.blockers[issue.ID],
+	}
+}
+
+// issueMetrics are the graph scores for one issue
+type issueMetrics struct {
+	PageRank          float64 `json:"pagerank"`
+	Betweenness       float64 `json:"betweenness"`
+	Eigenvector       float64 `json:"eigenvector"`
+	Hub               float64 `json:"hub"`
+	Authority         float64 `json:"authority"`
+	Closeness         float64 `json:"closeness"`
+	CriticalPathScore float64 `json:"critical_path_score"`
+	InDegree          int     `json:"in_degree"`  // Issues that depend on it
+	OutDegree         int     `json:"out_degree"` // Issues it depends on
+}
+
+func (snap *snapshot) metrics(id string) issueMetrics {
+	st := snap.stats
+	return issueMetrics{
+		PageRank:          st.GetPageRankScore(id),
+		Betweenness:       st.GetBetweennessScore(id),
+		Eigenvector:       st.GetEigenvectorScore(id),
+		Hub:               st.GetHubScore(id),
+		Authority:         st.GetAuthorityScore(id),
+		Closeness:         st.GetClosenessScore(id),
+		CriticalPathScore: st.GetCriticalPathScore(id),
+		InDegree:          st.InDegree[id],
+		OutDegree:         st.OutDegree[id],
+	}
+}
+
+func (s *Server) handleAPISummary(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	writeJSON(w, struct {
+		Project            string    `json:"project"`
+		LoadedAt           time.Time `json:"loaded_at"`
+		Counts             counts    `json:"counts"`
+		NodeCount          int       `json:"node_count"`
+		EdgeCount          int       `json:"edge_count"`
+		Density            float64   `json:"density"`
+		CycleCount         int       `json:"cycle_count"`
+		CriticalPathLength int       `json:"critical_path_length"`
+	}{s.title, snap.loadedAt, snap.counts(), snap.stats.NodeCount, snap.stats.EdgeCount,
+		snap.stats.Density, len(snap.stats.Cycles()), len(snap.critical)})
+}
+
+// handleAPIIssues lists issues, optionally narrowed with the list page's
+// ?status= filters
+func (s *Server) handleAPIIssues(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	filter := r.URL.Query().Get("status")
+	if filter == "" {
+		filter = "all"
+	}
+	if !slices.Contains(listFilters, filter) {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("unknown status filter %q (want one of %v)", filter, listFilters))
+		return
+	}
+
+	issues := []apiIssue{}
+	for _, issue := range snap.issues {
+		if snap.matches(issue, filter) {
+			issues = append(issues, snap.apiIssue(issue))
+		}
+	}
+	writeJSON(w, struct {
+		Filter string     `json:"filter"`
+		Count  int        `json:"count"`
+		Issues []apiIssue `json:"issues"`
+	}{filter, len(issues), issues})
+}
+
+func (s *Server) handleAPIIssue(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	issue, found := snap.byID[r.PathValue("id")]
+	if !found {
+		writeAPIError(w, http.StatusNotFound, "no issue "+r.PathValue("id"))
+		return
+	}
+	writeJSON(w, mergedJSON{snap.apiIssue(*issue), struct {
+		Dependents []string     `json:"dependents,omitempty"` // Issues that depend on it
+		Metrics    issueMetrics `json:"metrics"`
+	}{snap.dependents[issue.ID], snap.metrics(issue.ID)}})
+}
+
+// handleAPIReady lists open issues with no open blockers, highest priority
+// first, with how many issues each one directly unblocks
+func (s *Server) handleAPIReady(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	type unblocks struct {
+		Unblocks int `json:"unblocks"`
+	}
+	issues := []mergedJSON{}
+	for _, issue := range snap.issues {
+		if snap.matches(issue, "ready") {
+			issues = append(issues, mergedJSON{snap.apiIssue(issue), unblocks{len(snap.dependents[issue.ID])}})
+		}
+	}
+	writeJSON(w, struct {
+		Count  int          `json:"count"`
+		Issues []mergedJSON `json:"issues"`
+	}{len(issues), issues})
+}
+
+func (s *Server) handleAPICycles(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	cycles := snap.stats.Cycles()
+	if cycles == nil {
+		cycles = [][]string{}
+	}
+	writeJSON(w, struct {
+		Count  int        `json:"count"`
+		Cycles [][]string `json:"cycles"`
+	}{len(cycles), cycles})
+}
+
+// handleAPICriticalPath returns the longest chain of open blocking work,
+// starting with the issue to do first
+func (s *Server) handleAPICriticalPath(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	type step struct {
+		ID       string       `json:"id"`
+		Title    string       `json:"title"`
+		Status   model.Status `json:"status"`
+		Priority int          `json:"priority"`
+	}
+	path := []step{}
+	for _, id := range snap.critical {
+		if issue, ok := snap.byID[id]; ok {
+			path = append(path, step{issue.ID, issue.Title, issue.Status, issue.Priority})
+		}
+	}
+	writeJSON(w, struct {
+		Length int    `json:"length"`
+		Issues []step `json:"issues"`
+	}{len(path), path})
+}
+
+// handleAPIMetrics returns the graph scores of every issue, keyed by ID
+func (s *Server) handleAPIMetrics(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	byIssue := make(map[string]issueMetrics, len(snap.issues))
+	for _, issue := range snap.issues {
+		byIssue[issue.ID] = snap.metrics(issue.ID)
+	}
+	writeJSON(w, struct {
+		NodeCount int                     `json:"node_count"`
+		EdgeCount int                     `json:"edge_count"`
+		Density   float64                 `json:"density"`
+		Issues    map[string]issueMetrics `json:"issues"`
+	}{snap.stats.NodeCount, snap.stats.EdgeCount, snap.stats.Density, byIssue})
+}
+
+// handleAPIInsights returns the same insights as --robot-insights; ?limit=
+// sets the length of each top list (default 50)
+func (s *Server) handleAPIInsights(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.loadAPI(w)
+	if !ok {
+		return
+	}
+	limit := 50
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %q", v))
+			return
+		}
+		limit = n
+	}
+	writeJSON(w, snap.stats.GenerateInsights(limit))
+}
+
+// loadAPI is load for the API, reporting failures as JSON
+func (s *Server) loadAPI(w http.ResponseWriter) (*snapshot, bool) {
+	snap, err := s.current()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, fmt.Sprintf("loading %s: %v", s.beadsPath, err))
+		return nil, false
+	}
+	return snap, true
+}
+
+// writeJSON sends v as indented JSON
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "encoding response: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(data, '\n'))
+}
+
+// writeAPIError sends {"error": msg} with the given status code
+func writeAPIError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{msg})
+}
@@ -0,0 +1,194 @@
+package web
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Graph layout, in SVG user units
+const (
+	maxGraphNodes = 300
+	nodeWidth     = 200
+	nodeHeight    = 42
+	columnGap     = 70
+	rowGap        = 14
+	graphMargin   = 16
+)
+
+// statusFill matches the TUI's dark theme status colors
+var statusFill = map[model.Status]string{
+	model.StatusOpen:       "#50FA7B",
+	model.StatusInProgress: "#8BE9FD",
+	model.StatusBlocked:    "#FF5555",
+	model.StatusClosed:     "#6272A4",
+}
+
+// graphEdge is a dependency drawn from the issue depended on to the dependent
+type graphEdge struct {
+	from, to string
+	blocking bool
+}
+
+// renderGraphSVG draws the dependency graph as layered SVG: each issue sits
+// one column right of its furthest-right blocker, so chains read left to
+// right. Closed issues are left out unless includeClosed is set, and past
+// maxGraphNodes only the highest-impact issues are drawn; omitted says how
+// many were dropped. Nodes link to href(issue) unless it returns "".
+func renderGraphSVG(snap *snapshot, includeClosed bool, href func(model.Issue) string) (svg string, omitted int) {
+	var nodes []model.Issue
+	for _, issue := range snap.issues {
+		if includeClosed || issue.Status != model.StatusClosed {
+			nodes = append(nodes, issue)
+		}
+	}
+	if len(nodes) > maxGraphNodes {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return snap.stats.GetCriticalPathScore(nodes[i].ID) > snap.stats.GetCriticalPathScore(nodes[j].ID)
+		})
+		omitted = len(nodes) - maxGraphNodes
+		nodes = nodes[:maxGraphNodes]
+	}
+
+	shown := make(map[string]bool, len(nodes))
+	for _, issue := range nodes {
+		shown[issue.ID] = true
+	}
+	var edges []graphEdge
+	blockers := make(map[string][]string)
+	for _, issue := range nodes {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !shown[dep.DependsOnID] || dep.DependsOnID == issue.ID {
+				continue
+			}
+			blocking := dep.Type.IsBlockingOrLegacy()
+			edges = append(edges, graphEdge{from: dep.DependsOnID, to: issue.ID, blocking: blocking})
+			if blocking {
+				blockers[issue.ID] = append(blockers[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+
+	// Column = length of the longest blocking chain leading to the issue.
+	// Edges back into the chain being walked (cycles) are ignored.
+	column := make(map[string]int, len(nodes))
+	visiting := make(map[string]bool)
+	var depth func(id string) int
+	depth = func(id string) int {
+		if c, ok := column[id]; ok {
+			return c
+		}
+		visiting[id] = true
+		c := 0
+		for _, b := range blockers[id] {
+			if !visiting[b] {
+				c = max(c, depth(b)+1)
+			}
+		}
+		visiting[id] = false
+		column[id] = c
+		return c
+	}
+
+	columns := make(map[int][]model.Issue)
+	maxColumn, maxRows := 0, 0
+	for _, issue := range nodes {
+		c := depth(issue.ID)
+		columns[c] = append(columns[c], issue)
+		maxColumn = max(maxColumn, c)
+		maxRows = max(maxRows, len(columns[c]))
+	}
+
+	type point struct{ x, y int }
+	pos := make(map[string]point, len(nodes))
+	for c, issues := range columns {
+		for row, issue := range issues {
+			pos[issue.ID] = point{
+				x: graphMargin + c*(nodeWidth+columnGap),
+				y: graphMargin + row*(nodeHeight+rowGap),
+			}
+		}
+	}
+
+	width := 2*graphMargin + (maxColumn+1)*(nodeWidth+columnGap) - columnGap
+	height := 2*graphMargin + max(maxRows, 1)*(nodeHeight+rowGap) - rowGap
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`,
+		width, height, width, height)
+	sb.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="7" markerHeight="7" orient="auto-start-reverse">` +
+		`<path d="M 0 0 L 10 5 L 0 10 z" fill="#BFBFBF"/></marker></defs>`)
+	if len(nodes) == 0 {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" fill="#6272A4" font-size="13">No issues to draw</text>`, graphMargin, graphMargin+14)
+	}
+
+	for _, e := range edges {
+		from, to := pos[e.from], pos[e.to]
+		x1, y1 := from.x+nodeWidth, from.y+nodeHeight/2
+		x2, y2 := to.x, to.y+nodeHeight/2
+		dash := ""
+		if !e.blocking {
+			dash = ` stroke-dasharray="4 3"`
+		}
+		fmt.Fprintf(&sb, `<path d="M %d %d C %d %d, %d %d, %d %d" fill="none" stroke="#BFBFBF" stroke-width="1.2"%s marker-end="url(#arrow)"/>`,
+			x1, y1, x1+columnGap/2, y1, x2-columnGap/2, y2, x2, y2, dash)
+	}
+
+	for _, issue := range nodes {
+		p := pos[issue.ID]
+		fill := statusFill[issue.Status]
+		if fill == "" {
+			fill = "#BFBFBF"
+		}
+		link := href(issue)
+		if link != "" {
+			fmt.Fprintf(&sb, `<a href="%s">`, html.EscapeString(link))
+		}
+		fmt.Fprintf(&sb, `<g><title>%s</title>`, html.EscapeString(issue.ID+" · "+issue.Title))
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" fill="#44475A" stroke="%s" stroke-width="2"/>`,
+			p.x, p.y, nodeWidth, nodeHeight, fill)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" fill="%s" font-size="12" font-weight="bold">%s</text>`,
+			p.x+8, p.y+16, fill, html.EscapeString(truncate(fmt.Sprintf("%s  P%d", issue.ID, issue.Priority), 28)))
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" fill="#F8F8F2" font-size="12">%s</text>`,
+			p.x+8, p.y+33, html.EscapeString(truncate(issue.Title, 30)))
+		sb.WriteString(`</g>`)
+		if link != "" {
+			sb.WriteString(`</a>`)
+		}
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String(), omitted
+}
+
+// issuePageLink links graph nodes to the issue pages of the web UI, escaping
+// the ID as the /issue/{id} route expects
+func issuePageLink(issue model.Issue) string { return "/issue/" + url.PathEscape(issue.ID) }
+
+// WriteGraphSVG writes the dependency graph of issues as a standalone SVG
+// file, laid out as on the web UI's graph page. Nodes link to href(issue)
+// when href is non-nil and returns a URL. It returns how many issues were
+// too low-impact to draw.
+func WriteGraphSVG(w io.Writer, issues []model.Issue, includeClosed bool, href func(model.Issue) string) (omitted int, err error) {
+	if href == nil {
+		href = func(model.Issue) string { return "" }
+	}
+	svg, omitted := renderGraphSVG(newSnapshot(issues), includeClosed, href)
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+svg+"\n"); err != nil {
+		return 0, err
+	}
+	return omitted, nil
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,184 @@
+package web
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// reportTopIssues is how many issues the report's "Top impact" list shows
+const reportTopIssues = 10
+
+// reportIssue is a row in the report's issue table
+type reportIssue struct {
+	issueRow
+	Impact     float64 // Critical path depth
+	PageRank   float64
+	Dependents int
+	Updated    time.Time
+}
+
+// WriteReport writes a self-contained HTML report of issues to w: summary
+// counts, the open issues with the most work behind them, the dependency
+// graph as inline SVG and a sortable table of every issue. It needs no
+// server or network access, so it can be mailed or published as a file.
+func WriteReport(w io.Writer, issues []model.Issue, project string) error {
+	snap := newSnapshot(issues)
+	svg, omitted := renderGraphSVG(snap, false, func(issue model.Issue) string { return "#" + issue.ID })
+
+	var rows, top []reportIssue
+	for _, issue := range snap.issues {
+		row := reportIssue{
+			issueRow:   snap.row(issue),
+			Impact:     snap.stats.GetCriticalPathScore(issue.ID),
+			PageRank:   snap.stats.GetPageRankScore(issue.ID),
+			Dependents: len(snap.dependents[issue.ID]),
+			Updated:    issue.UpdatedAt,
+		}
+		rows = append(rows, row)
+		if issue.Status != model.StatusClosed {
+			top = append(top, row)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].Impact != top[j].Impact {
+			return top[i].Impact > top[j].Impact
+		}
+		return top[i].PageRank > top[j].PageRank
+	})
+	if len(top) > reportTopIssues {
+		top = top[:reportTopIssues]
+	}
+
+	var critical []issueLink
+	for _, id := range snap.critical {
+		if issue, ok := snap.byID[id]; ok {
+			critical = append(critical, issueLink{ID: id, Title: issue.Title, Status: string(issue.Status)})
+		}
+	}
+
+	return reportPage.Execute(w, struct {
+		Project     string
+		GeneratedAt time.Time
+		Counts      counts
+		Top         []reportIssue
+		Critical    []issueLink
+		SVG         template.HTML
+		Omitted     int
+		Rows        []reportIssue
+	}{project, snap.loadedAt, snap.counts(), top, critical, template.HTML(svg), omitted, rows})
+}
+
+// reportTemplate shares the web UI's palette but inlines everything, and a
+// few lines of script make the table sort on a header click
+const reportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Project}} · Issue Report</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0 auto; max-width: 1200px; padding: 16px 24px; background: #282A36; color: #F8F8F2; }
+  h1 { color: #BD93F9; margin-bottom: 4px; }
+  h2 { font-size: 16px; color: #BFBFBF; text-transform: uppercase; margin-top: 32px; }
+  a { color: #8BE9FD; }
+  .stats { display: flex; gap: 12px; flex-wrap: wrap; }
+  .stat { background: #44475A; border-radius: 6px; padding: 10px 16px; min-width: 90px; }
+  .stat b { display: block; font-size: 24px; }
+  .stat span { font-size: 12px; color: #BFBFBF; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #44475A; font-size: 14px; vertical-align: top; }
+  th { color: #BFBFBF; font-weight: normal; }
+  table.sortable th { cursor: pointer; user-select: none; }
+  table.sortable th:hover { color: #BD93F9; }
+  tr:target { background: #44475A; }
+  .status { padding: 1px 6px; border-radius: 4px; font-size: 12px; color: #282A36; white-space: nowrap; }
+  .status-open { background: #50FA7B; } .status-in_progress { background: #8BE9FD; }
+  .status-blocked { background: #FF5555; } .status-closed { background: #6272A4; color: #F8F8F2; }
+  .label { background: #44475A; border-radius: 4px; padding: 1px 5px; font-size: 12px; margin-right: 4px; }
+  .ready { color: #50FA7B; font-size: 12px; }
+  .muted { color: #6272A4; font-size: 12px; }
+  .graph { overflow: auto; background: #21222C; border-radius: 6px; padding: 8px; }
+</style>
+</head>
+<body>
+<h1>{{.Project}}</h1>
+<p class="muted">Issue report generated {{.GeneratedAt.Format "2006-01-02 15:04"}}</p>
+
+<div class="stats">
+  <div class="stat"><b>{{.Counts.Total}}</b><span>issues</span></div>
+  <div class="stat"><b>{{.Counts.Open}}</b><span>open</span></div>
+  <div class="stat"><b>{{.Counts.Ready}}</b><span>ready</span></div>
+  <div class="stat"><b>{{.Counts.Blocked}}</b><span>blocked</span></div>
+  <div class="stat"><b>{{.Counts.Closed}}</b><span>closed</span></div>
+</div>
+
+<h2>Top impact</h2>
+<p class="muted">Open issues with the longest chains of work waiting on them.</p>
+<table>
+<tr><th>ID</th><th>Title</th><th>Status</th><th>P</th><th>Impact</th><th>Needed by</th></tr>
+{{range .Top}}<tr>
+  <td><a href="#{{.ID}}">{{.ID}}</a></td>
+  <td>{{.Title}}{{if .Ready}} <span class="ready">● ready</span>{{end}}</td>
+  <td>{{template "status" .Status}}</td>
+  <td>P{{.Priority}}</td>
+  <td>{{printf "%.1f" .Impact}}</td>
+  <td>{{.Dependents}}</td>
+</tr>{{else}}<tr><td colspan="6" class="muted">No open issues.</td></tr>{{end}}
+</table>
+{{if .Critical}}<p class="muted">Critical path: {{range $i, $c := .Critical}}{{if $i}} → {{end}}<a href="#{{$c.ID}}">{{$c.ID}}</a>{{end}}</p>{{end}}
+
+<h2>Dependency graph</h2>
+<p class="muted">Open issues, blockers on the left and the work they block to the right. Dashed lines are non-blocking links.{{if .Omitted}} {{.Omitted}} lower-impact issues are not drawn.{{end}}</p>
+<div class="graph">{{.SVG}}</div>
+
+<h2>All issues</h2>
+<p class="muted">Click a column header to sort.</p>
+<table class="sortable">
+<thead><tr><th>ID</th><th>Title</th><th>Status</th><th>P</th><th>Type</th><th>Assignee</th><th>Labels</th><th>Blocked by</th><th>Impact</th><th>Updated</th></tr></thead>
+<tbody>
+{{range .Rows}}<tr id="{{.ID}}">
+  <td>{{.ID}}</td>
+  <td>{{.Title}}{{if .Ready}} <span class="ready">● ready</span>{{end}}</td>
+  <td>{{template "status" .Status}}</td>
+  <td data-sort="{{.Priority}}">P{{.Priority}}</td>
+  <td>{{.Type}}</td>
+  <td>{{.Assignee}}</td>
+  <td>{{range .Labels}}<span class="label">{{.}}</span>{{end}}</td>
+  <td>{{range .BlockedBy}}<a href="#{{.}}">{{.}}</a> {{end}}</td>
+  <td data-sort="{{.Impact}}">{{printf "%.1f" .Impact}}</td>
+  <td data-sort="{{.Updated.Unix}}">{{if not .Updated.IsZero}}{{.Updated.Format "2006-01-02"}}{{end}}</td>
+</tr>{{end}}
+</tbody>
+</table>
+
+<script>
+document.querySelectorAll("table.sortable th").forEach(function (th, col) {
+  th.addEventListener("click", function () {
+    var tbody = th.closest("table").tBodies[0];
+    var asc = th.dataset.dir !== "asc";
+    th.closest("tr").querySelectorAll("th").forEach(function (h) { delete h.dataset.dir; });
+    th.dataset.dir = asc ? "asc" : "desc";
+    var key = function (row) {
+      var cell = row.cells[col];
+      return cell.dataset.sort !== undefined ? cell.dataset.sort : cell.textContent.trim();
+    };
+    var rows = Array.prototype.slice.call(tbody.rows);
+    rows.sort(function (a, b) {
+      var x = key(a), y = key(b);
+      var nx = parseFloat(x), ny = parseFloat(y);
+      var c = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+      return asc ? c : -c;
+    });
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+{{define "status"}}<span class="status status-{{.}}">{{.}}</span>{{end}}`
+
+var reportPage = template.Must(template.New("report").Parse(reportTemplate))
@@ -0,0 +1,412 @@
+// Package web serves a read-only HTML rendering of the issues (list, board
+// and dependency graph) for stakeholders who don't use the terminal UI, and
+// the same data as JSON under /api for dashboards and bots. It reads the
+// same beads file and runs the same analysis as the TUI. WriteReport renders
+// a static, single-file version for exporting.
+package web
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Server renders the issues in a beads file as HTML. The file is re-read
+// whenever it changes, so pages always show the current state.
+type Server struct {
+	beadsPath string
+	title     string
+
+	mu      sync.Mutex
+	snap    *snapshot
+	modTime time.Time
+	size    int64
+}
+
+// snapshot is one load of the beads file with its analysis
+type snapshot struct {
+	issues     []model.Issue // Open work first, then by priority and ID
+	byID       map[string]*model.Issue
+	stats      *analysis.GraphStats
+	ready      map[string]bool
+	blockers   map[string][]string // Issue -> open issues blocking it
+	dependents map[string][]string // Issue -> issues that depend on it
+	critical   []string            // Longest chain of open blocking work
+	loadedAt   time.Time
+}
+
+// New creates a server for the beads file at beadsPath
+func New(beadsPath string) *Server {
+	title := filepath.Base(filepath.Dir(filepath.Dir(beadsPath)))
+	if title == "." || title == string(filepath.Separator) {
+		title = "beads"
+	}
+	return &Server{beadsPath: beadsPath, title: title}
+}
+
+// Handler returns the HTTP handler for the web UI. Only GET requests are
+// served; there is nothing that changes the issues.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleList)
+	mux.HandleFunc("GET /board", s.handleBoard)
+	mux.HandleFunc("GET /graph", s.handleGraph)
+	mux.HandleFunc("GET /graph.svg", s.handleGraphSVG)
+	mux.HandleFunc("GET /issue/{id}", s.handleIssue)
+	mux.HandleFunc("GET /calendar.ics", s.handleCalendar)
+	s.registerAPI(mux)
+	return mux
+}
+
+// current returns the latest snapshot, reloading the beads file if it changed
+func (s *Server) current() (*snapshot, error) {
+	info, err := os.Stat(s.beadsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.snap != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.snap, nil
+	}
+
+	issues, err := loader.LoadIssuesFromFile(s.beadsPath)
+	if err != nil {
+		return nil, err
+	}
+	s.snap = newSnapshot(issues)
+	s.modTime, s.size = info.ModTime(), info.Size()
+	return s.snap, nil
+}
+
+// newSnapshot analyzes issues for rendering
+func newSnapshot(issues []model.Issue) *snapshot {
+	cached := analysis.NewCachedAnalyzer(issues, nil)
+	stats := cached.Analyze()
+
+	snap := &snapshot{
+		issues:     slices.Clone(issues), // Sorted below; the caller's slice stays as it was
+		byID:       make(map[string]*model.Issue, len(issues)),
+		stats:      &stats,
+		ready:      make(map[string]bool),
+		blockers:   make(map[string][]string),
+		dependents: make(map[string][]string),
+		critical:   analysis.OpenCriticalPath(issues),
+		loadedAt:   time.Now(),
+	}
+	sort.SliceStable(snap.issues, func(i, j int) bool {
+		a, b := snap.issues[i], snap.issues[j]
+		if (a.Status == model.StatusClosed) != (b.Status == model.StatusClosed) {
+			return b.Status == model.StatusClosed
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	for i := range snap.issues {
+		snap.byID[snap.issues[i].ID] = &snap.issues[i]
+	}
+
+	for _, issue := range cached.Analyzer.GetActionableIssues() {
+		snap.ready[issue.ID] = true
+	}
+	for _, issue := range snap.issues {
+		if issue.Status != model.StatusClosed {
+			if open := cached.Analyzer.GetOpenBlockers(issue.ID); len(open) > 0 {
+				snap.blockers[issue.ID] = open
+			}
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID != "" {
+				snap.dependents[dep.DependsOnID] = append(snap.dependents[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	return snap
+}
+
+// counts summarizes the snapshot for the page header
+type counts struct {
+	Total   int `json:"total"`
+	Open    int `json:"open"`
+	Ready   int `json:"ready"`
+	Blocked int `json:"blocked"`
+	Closed  int `json:"closed"`
+}
+
+func (snap *snapshot) counts() counts {
+	var c counts
+	for _, issue := range snap.issues {
+		c.Total++
+		switch {
+		case issue.Status == model.StatusClosed:
+			c.Closed++
+		case snap.isBlocked(issue):
+			c.Open++
+			c.Blocked++
+		default:
+			c.Open++
+			if snap.ready[issue.ID] {
+				c.Ready++
+			}
+		}
+	}
+	return c
+}
+
+// isBlocked reports whether an open issue is marked blocked or waits on another
+func (snap *snapshot) isBlocked(issue model.Issue) bool {
+	return issue.Status == model.StatusBlocked || len(snap.blockers[issue.ID]) > 0
+}
+
+// issueRow is an issue prepared for the list, board and issue pages
+type issueRow struct {
+	ID, Title, Status, Type, Assignee string
+	Priority                          int
+	Labels                            []string
+	BlockedBy                         []string
+	Ready                             bool
+}
+
+func (snap *snapshot) row(issue model.Issue) issueRow {
+	return issueRow{
+		ID:        issue.ID,
+		Title:     issue.Title,
+		Status:    string(issue.Status),
+		Type:      string(issue.IssueType),
+		Assignee:  issue.Assignee,
+		Priority:  issue.Priority,
+		Labels:    issue.Labels,
+		BlockedBy: snap.blockers[issue.ID],
+		Ready:     issue.Status != model.StatusClosed && snap.ready[issue.ID],
+	}
+}
+
+// listFilters are the ?status= values the list page accepts
+var listFilters = []string{"all", "open", "ready", "blocked", "closed"}
+
+func (snap *snapshot) matches(issue model.Issue, filter string) bool {
+	closed := issue.Status == model.StatusClosed
+	switch filter {
+	case "open":
+		return !closed
+	case "ready":
+		return !closed && snap.ready[issue.ID]
+	case "blocked":
+		return !closed && snap.isBlocked(issue)
+	case "closed":
+		return closed
+	default:
+		return true
+	}
+}
+
+// page is the data shared by every page template
+type page struct {
+	Title    string
+	Project  string
+	Active   string
+	Counts   counts
+	LoadedAt time.Time
+}
+
+func (s *Server) page(snap *snapshot, active, title string) page {
+	return page{Title: title, Project: s.title, Active: active, Counts: snap.counts(), LoadedAt: snap.loadedAt}
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+	filter := r.URL.Query().Get("status")
+	if !slices.Contains(listFilters, filter) {
+		filter = "all"
+	}
+
+	var rows []issueRow
+	for _, issue := range snap.issues {
+		if snap.matches(issue, filter) {
+			rows = append(rows, snap.row(issue))
+		}
+	}
+	s.render(w, "list", struct {
+		page
+		Filter  string
+		Filters []string
+		Rows    []issueRow
+	}{s.page(snap, "list", "Issues"), filter, listFilters, rows})
+}
+
+// boardColumn is one status lane on the board page
+type boardColumn struct {
+	Status string
+	Cards  []issueRow
+	Hidden int // Closed cards beyond maxClosedCards
+}
+
+// maxClosedCards keeps the closed lane from dwarfing the others
+const maxClosedCards = 50
+
+func (s *Server) handleBoard(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+
+	columns := []boardColumn{{Status: "open"}, {Status: "in_progress"}, {Status: "blocked"}, {Status: "closed"}}
+	var closed []model.Issue
+	for _, issue := range snap.issues {
+		switch issue.Status {
+		case model.StatusInProgress:
+			columns[1].Cards = append(columns[1].Cards, snap.row(issue))
+		case model.StatusBlocked:
+			columns[2].Cards = append(columns[2].Cards, snap.row(issue))
+		case model.StatusClosed:
+			closed = append(closed, issue)
+		default:
+			columns[0].Cards = append(columns[0].Cards, snap.row(issue))
+		}
+	}
+
+	// Most recently closed first
+	sort.SliceStable(closed, func(i, j int) bool { return closedTime(closed[i]).After(closedTime(closed[j])) })
+	for i, issue := range closed {
+		if i >= maxClosedCards {
+			columns[3].Hidden = len(closed) - maxClosedCards
+			break
+		}
+		columns[3].Cards = append(columns[3].Cards, snap.row(issue))
+	}
+
+	s.render(w, "board", struct {
+		page
+		Columns []boardColumn
+	}{s.page(snap, "board", "Board"), columns})
+}
+
+func closedTime(issue model.Issue) time.Time {
+	if issue.ClosedAt != nil {
+		return *issue.ClosedAt
+	}
+	return issue.UpdatedAt
+}
+
+func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+	includeClosed := r.URL.Query().Get("closed") == "1"
+	svg, omitted := renderGraphSVG(snap, includeClosed, issuePageLink)
+	s.render(w, "graph", struct {
+		page
+		SVG           template.HTML
+		Omitted       int
+		IncludeClosed bool
+	}{s.page(snap, "graph", "Dependency Graph"), template.HTML(svg), omitted, includeClosed})
+}
+
+func (s *Server) handleGraphSVG(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+	svg, _ := renderGraphSVG(snap, r.URL.Query().Get("closed") == "1", issuePageLink)
+	w.Header().Set("Content-Type", "image/svg+xml")
+	fmt.Fprint(w, svg)
+}
+
+// handleCalendar serves due dates and milestones as an iCalendar feed that
+// calendar apps can subscribe to
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+	var sb strings.Builder
+	if err := export.WriteICS(&sb, snap.issues, s.title, time.Now()); err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering calendar: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	fmt.Fprint(w, sb.String())
+}
+
+// issueLink is a related issue shown on the issue page
+type issueLink struct {
+	ID, Title, Status string
+}
+
+func (s *Server) handleIssue(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.load(w)
+	if !ok {
+		return
+	}
+	issue, found := snap.byID[r.PathValue("id")]
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	link := func(id string) issueLink {
+		if other, ok := snap.byID[id]; ok {
+			return issueLink{ID: id, Title: other.Title, Status: string(other.Status)}
+		}
+		return issueLink{ID: id, Title: "(not in this file)"}
+	}
+	var dependsOn, dependents []issueLink
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.DependsOnID != "" {
+			dependsOn = append(dependsOn, link(dep.DependsOnID))
+		}
+	}
+	for _, id := range snap.dependents[issue.ID] {
+		dependents = append(dependents, link(id))
+	}
+
+	s.render(w, "issue", struct {
+		page
+		Issue      issueRow
+		Full       *model.Issue
+		DependsOn  []issueLink
+		Dependents []issueLink
+		Impact     float64
+	}{s.page(snap, "", issue.ID+" · "+issue.Title), snap.row(*issue), issue, dependsOn, dependents,
+		snap.stats.GetCriticalPathScore(issue.ID)})
+}
+
+// load returns the current snapshot, writing an error page if the file can't be read
+func (s *Server) load(w http.ResponseWriter) (*snapshot, bool) {
+	snap, err := s.current()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error loading %s: %v", s.beadsPath, err), http.StatusInternalServerError)
+		return nil, false
+	}
+	return snap, true
+}
+
+// render executes a page template, reporting failures as a server error
+func (s *Server) render(w http.ResponseWriter, name string, data any) {
+	var sb strings.Builder
+	if err := pages[name].ExecuteTemplate(&sb, "layout", data); err != nil {
+		http.Error(w, fmt.Sprintf("Error rendering %s: %v", name, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, sb.String())
+}
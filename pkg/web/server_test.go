@@ -0,0 +1,319 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeBeads(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func get(t *testing.T, h http.Handler, target string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec.Code, rec.Body.String()
+}
+
+func TestServerPages(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "proj", ".beads", "beads.jsonl")
+	writeBeads(t, beads, `{"id":"bv-1","title":"Foundation <core>","status":"open","priority":0,"issue_type":"task"}
+{"id":"bv-2","title":"Feature","status":"open","priority":1,"issue_type":"feature","dependencies":[{"depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Old work","status":"closed","priority":2,"issue_type":"task"}
+`)
+	h := New(beads).Handler()
+
+	code, body := get(t, h, "/")
+	if code != http.StatusOK {
+		t.Fatalf("list: status %d", code)
+	}
+	for _, want := range []string{"proj", "Foundation &lt;core&gt;", `href="/issue/bv-2"`, "3 issues", "1 ready", "1 blocked"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("list page missing %q", want)
+		}
+	}
+
+	_, body = get(t, h, "/?status=ready")
+	if !strings.Contains(body, "bv-1") || strings.Contains(body, `href="/issue/bv-2"`) {
+		t.Errorf("ready filter should show only bv-1")
+	}
+
+	_, body = get(t, h, "/board")
+	if !strings.Contains(body, "in_progress (0)") || !strings.Contains(body, "closed (1)") {
+		t.Errorf("board page missing lanes:\n%s", body)
+	}
+
+	_, body = get(t, h, "/graph")
+	if !strings.Contains(body, "<svg") || strings.Contains(body, "bv-3") {
+		t.Errorf("graph should draw open issues only")
+	}
+	code, body = get(t, h, "/graph.svg?closed=1")
+	if code != http.StatusOK || !strings.HasPrefix(body, "<svg") || !strings.Contains(body, "bv-3") || !strings.Contains(body, "marker-end") {
+		t.Errorf("graph.svg with closed issues: status %d\n%s", code, body)
+	}
+
+	_, body = get(t, h, "/issue/bv-2")
+	if !strings.Contains(body, "Depends on") || !strings.Contains(body, "bv-1") {
+		t.Errorf("issue page should list its blocker")
+	}
+	if code, _ := get(t, h, "/issue/nope"); code != http.StatusNotFound {
+		t.Errorf("unknown issue: expected 404, got %d", code)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") ||
+		!strings.Contains(rec.Body.String(), "X-WR-CALNAME:proj deadlines") {
+		t.Errorf("calendar feed: %d %q\n%s", rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("the web UI is read-only; POST got %d", rec.Code)
+	}
+}
+
+func TestServerReloadsChangedFile(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), ".beads", "beads.jsonl")
+	writeBeads(t, beads, `{"id":"A","title":"First","status":"open","issue_type":"task"}`+"\n")
+	h := New(beads).Handler()
+	if _, body := get(t, h, "/"); !strings.Contains(body, "First") {
+		t.Fatal("expected the first load")
+	}
+
+	writeBeads(t, beads, `{"id":"A","title":"Renamed","status":"open","issue_type":"task"}`+"\n")
+	later := time.Now().Add(2 * time.Second)
+	os.Chtimes(beads, later, later)
+	if _, body := get(t, h, "/"); !strings.Contains(body, "Renamed") {
+		t.Error("expected the page to pick up the changed file")
+	}
+}
+
+func TestGraphLayersFollowBlockers(t *testing.T) {
+	blockedBy := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	snap := newSnapshot([]model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Dependencies: blockedBy("B")},
+		{ID: "D", Title: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+	})
+	svg, _ := renderGraphSVG(snap, false, issuePageLink)
+
+	step := nodeWidth + columnGap
+	for id, column := range map[string]int{"A": 0, "B": 1, "C": 2, "D": 0} {
+		want := fmt.Sprintf(`<title>%s · %s</title><rect x="%d"`, id, id, graphMargin+column*step)
+		if !strings.Contains(svg, want) {
+			t.Errorf("expected %s in column %d", id, column)
+		}
+	}
+	if strings.Count(svg, "stroke-dasharray") != 1 {
+		t.Errorf("expected only the related link dashed")
+	}
+
+	if svg, omitted := renderGraphSVG(newSnapshot(nil), false, issuePageLink); !strings.Contains(svg, "No issues to draw") || omitted != 0 {
+		t.Errorf("empty graph: %q, %d", svg, omitted)
+	}
+}
+
+func TestIssuePageLinkEscapesID(t *testing.T) {
+	if got := issuePageLink(model.Issue{ID: "team/bv 1?#"}); got != "/issue/team%2Fbv%201%3F%23" {
+		t.Fatalf("unexpected link %q", got)
+	}
+}
+
+func TestAPIEndpoints(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "proj", ".beads", "beads.jsonl")
+	writeBeads(t, beads, `{"id":"bv-1","title":"Foundation","status":"open","priority":0,"issue_type":"task"}
+{"id":"bv-2","title":"Feature","status":"open","priority":1,"issue_type":"feature","dependencies":[{"depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Loop A","status":"open","priority":2,"issue_type":"task","dependencies":[{"depends_on_id":"bv-4","type":"blocks"}]}
+{"id":"bv-4","title":"Loop B","status":"open","priority":2,"issue_type":"task","dependencies":[{"depends_on_id":"bv-3","type":"blocks"}]}
+`)
+	h := New(beads).Handler()
+
+	getJSON := func(target string, v any) int {
+		t.Helper()
+		code, body := get(t, h, target)
+		if err := json.Unmarshal([]byte(body), v); err != nil {
+			t.Fatalf("%s: invalid JSON: %v\n%s", target, err, body)
+		}
+		return code
+	}
+
+	var summary struct {
+		Project            string `json:"project"`
+		Counts             counts `json:"counts"`
+		CycleCount         int    `json:"cycle_count"`
+		CriticalPathLength int    `json:"critical_path_length"`
+	}
+	getJSON("/api/summary", &summary)
+	if summary.Project != "proj" || summary.Counts.Total != 4 || summary.Counts.Ready != 1 || summary.CycleCount != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	var list struct {
+		Count  int `json:"count"`
+		Issues []struct {
+			ID        string   `json:"id"`
+			Ready     bool     `json:"ready"`
+			BlockedBy []string `json:"blocked_by"`
+		} `json:"issues"`
+	}
+	getJSON("/api/issues?status=blocked", &list)
+	if list.Count != 3 || list.Issues[0].ID != "bv-2" || len(list.Issues[0].BlockedBy) != 1 {
+		t.Errorf("blocked issues: %+v", list)
+	}
+	getJSON("/api/ready", &list)
+	if list.Count != 1 || list.Issues[0].ID != "bv-1" || !list.Issues[0].Ready {
+		t.Errorf("ready queue: %+v", list)
+	}
+
+	var issue struct {
+		ID         string   `json:"id"`
+		Dependents []string `json:"dependents"`
+		Metrics    struct {
+			InDegree int `json:"in_degree"`
+		} `json:"metrics"`
+	}
+	getJSON("/api/issues/bv-1", &issue)
+	if issue.ID != "bv-1" || len(issue.Dependents) != 1 || issue.Metrics.InDegree != 1 {
+		t.Errorf("issue detail: %+v", issue)
+	}
+
+	var path struct {
+		Length int `json:"length"`
+		Issues []struct {
+			ID string `json:"id"`
+		} `json:"issues"`
+	}
+	getJSON("/api/critical-path", &path)
+	if path.Length != 2 || path.Issues[0].ID != "bv-1" || path.Issues[1].ID != "bv-2" {
+		t.Errorf("critical path: %+v", path)
+	}
+
+	var cycles struct {
+		Cycles [][]string `json:"cycles"`
+	}
+	getJSON("/api/cycles", &cycles)
+	if len(cycles.Cycles) != 1 {
+		t.Errorf("cycles: %+v", cycles)
+	}
+
+	var metrics struct {
+		Issues map[string]json.RawMessage `json:"issues"`
+	}
+	getJSON("/api/metrics", &metrics)
+	if len(metrics.Issues) != 4 {
+		t.Errorf("metrics should cover every issue, got %d", len(metrics.Issues))
+	}
+
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	for target, want := range map[string]int{
+		"/api/issues/nope":       http.StatusNotFound,
+		"/api/issues?status=odd": http.StatusBadRequest,
+		"/api/insights?limit=x":  http.StatusBadRequest,
+		"/api/nothing":           http.StatusNotFound,
+	} {
+		apiErr.Error = ""
+		if code := getJSON(target, &apiErr); code != want || apiErr.Error == "" {
+			t.Errorf("%s: expected %d with an error message, got %d %q", target, want, code, apiErr.Error)
+		}
+	}
+	if code, body := get(t, h, "/api/insights?limit=5"); code != http.StatusOK || !strings.Contains(body, "Bottlenecks") {
+		t.Errorf("insights: status %d", code)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Foundation <core>", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-2", Title: "Feature", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Old work", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+	}
+	var sb strings.Builder
+	if err := WriteReport(&sb, issues, "proj"); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	report := sb.String()
+	for _, want := range []string{"<h1>proj</h1>", "<b>3</b><span>issues</span>", "Foundation &lt;core&gt;", "<svg",
+		`<a href="#bv-1"><g>`, `<tr id="bv-3">`, `Critical path: <a href="#bv-1">bv-1</a> → <a href="#bv-2">bv-2</a>`, "table.sortable th"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(report, "/issue/") || strings.Contains(report, `http-equiv="refresh"`) {
+		t.Error("the report should link within itself and not refresh")
+	}
+	// bv-1 holds up bv-2, so it comes first in the top impact list
+	top := report[strings.Index(report, "Top impact"):strings.Index(report, "Dependency graph")]
+	if strings.Index(top, "bv-1") > strings.Index(top, "bv-2") || strings.Contains(top, "bv-3") {
+		t.Errorf("top impact should list open issues by impact:\n%s", top)
+	}
+}
+
+func TestWriteGraphSVGLeavesIssuesInOrder(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-3", Title: "Done", Status: model.StatusClosed},
+		{ID: "bv-2", Title: "Feature", Status: model.StatusOpen, Priority: 2},
+		{ID: "bv-1", Title: "Foundation", Status: model.StatusOpen},
+	}
+	if _, err := WriteGraphSVG(io.Discard, issues, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	if issues[0].ID != "bv-3" || issues[1].ID != "bv-2" || issues[2].ID != "bv-1" {
+		t.Fatalf("expected the caller's issues left in order, got %s %s %s", issues[0].ID, issues[1].ID, issues[2].ID)
+	}
+}
+
+func TestWriteGraphSVG(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Foundation", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Feature", Status: model.StatusBlocked, Dependencies: []*model.Dependency{{DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Done", Status: model.StatusClosed},
+	}
+	var sb strings.Builder
+	href := func(issue model.Issue) string { return "https://example.com/issues/" + issue.ID + "?a=1&b=2" }
+	if _, err := WriteGraphSVG(&sb, issues, false, href); err != nil {
+		t.Fatal(err)
+	}
+	svg := sb.String()
+	if !strings.HasPrefix(svg, `<?xml version="1.0" encoding="UTF-8"?>`) || !strings.Contains(svg, `xmlns="http://www.w3.org/2000/svg"`) {
+		t.Errorf("expected a standalone SVG document:\n%s", svg)
+	}
+	if !strings.Contains(svg, `<a href="https://example.com/issues/bv-2?a=1&amp;b=2">`) || !strings.Contains(svg, statusFill[model.StatusBlocked]) {
+		t.Errorf("expected linked, status-colored nodes:\n%s", svg)
+	}
+	if strings.Contains(svg, "bv-3") {
+		t.Error("closed issues are left out unless asked for")
+	}
+
+	sb.Reset()
+	if _, err := WriteGraphSVG(&sb, issues, true, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sb.String(), "bv-3") || strings.Contains(sb.String(), "<a ") {
+		t.Errorf("expected closed issues and no links without href:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,131 @@
+package web
+
+import (
+	"html/template"
+	"strings"
+)
+
+// layoutTemplate is the page chrome shared by every page. Pages refresh
+// themselves so a dashboard left open keeps up with the beads file.
+const layoutTemplate = `{{define "layout"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta http-equiv="refresh" content="30">
+<title>{{.Title}} · {{.Project}}</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 0; background: #282A36; color: #F8F8F2; }
+  header { background: #44475A; padding: 12px 24px; display: flex; gap: 24px; align-items: baseline; flex-wrap: wrap; }
+  header h1 { font-size: 18px; margin: 0; color: #BD93F9; }
+  nav a { color: #F8F8F2; margin-right: 16px; text-decoration: none; }
+  nav a.active { color: #BD93F9; font-weight: bold; border-bottom: 2px solid #BD93F9; }
+  .counts span { margin-right: 12px; font-size: 13px; color: #BFBFBF; }
+  main { padding: 16px 24px; }
+  a { color: #8BE9FD; }
+  table { border-collapse: collapse; width: 100%; }
+  th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #44475A; font-size: 14px; vertical-align: top; }
+  th { color: #BFBFBF; font-weight: normal; }
+  .status { padding: 1px 6px; border-radius: 4px; font-size: 12px; color: #282A36; white-space: nowrap; }
+  .status-open { background: #50FA7B; } .status-in_progress { background: #8BE9FD; }
+  .status-blocked { background: #FF5555; } .status-closed { background: #6272A4; color: #F8F8F2; }
+  .label { background: #44475A; border-radius: 4px; padding: 1px 5px; font-size: 12px; margin-right: 4px; }
+  .ready { color: #50FA7B; font-size: 12px; }
+  .muted { color: #6272A4; font-size: 12px; }
+  .filters a { margin-right: 12px; }
+  .filters a.active { font-weight: bold; color: #BD93F9; }
+  .board { display: grid; grid-template-columns: repeat(4, minmax(200px, 1fr)); gap: 12px; }
+  .lane h2 { font-size: 14px; text-transform: uppercase; color: #BFBFBF; }
+  .card { background: #44475A; border-radius: 6px; padding: 8px; margin-bottom: 8px; font-size: 13px; }
+  .card a { text-decoration: none; }
+  .graph { overflow: auto; background: #21222C; border-radius: 6px; padding: 8px; }
+  pre.text { white-space: pre-wrap; font-family: inherit; background: #21222C; padding: 12px; border-radius: 6px; }
+</style>
+</head>
+<body>
+<header>
+  <h1>{{.Project}}</h1>
+  <nav>
+    <a href="/"{{if eq .Active "list"}} class="active"{{end}}>List</a>
+    <a href="/board"{{if eq .Active "board"}} class="active"{{end}}>Board</a>
+    <a href="/graph"{{if eq .Active "graph"}} class="active"{{end}}>Graph</a>
+  </nav>
+  <div class="counts">
+    <span>{{.Counts.Total}} issues</span><span>{{.Counts.Open}} open</span><span>{{.Counts.Ready}} ready</span><span>{{.Counts.Blocked}} blocked</span><span>{{.Counts.Closed}} closed</span>
+  </div>
+</header>
+<main>
+{{template "content" .}}
+<p class="muted">Read-only view · loaded {{.LoadedAt.Format "2006-01-02 15:04:05"}} · refreshes every 30s</p>
+</main>
+</body>
+</html>{{end}}
+{{define "status"}}<span class="status status-{{.}}">{{.}}</span>{{end}}`
+
+const listTemplate = `{{define "content"}}
+<p class="filters">{{$f := .Filter}}{{range .Filters}}<a href="/?status={{.}}"{{if eq . $f}} class="active"{{end}}>{{.}}</a>{{end}}</p>
+<table>
+<tr><th>ID</th><th>Title</th><th>Status</th><th>P</th><th>Type</th><th>Assignee</th><th>Labels</th><th>Blocked by</th></tr>
+{{range .Rows}}<tr>
+  <td><a href="/issue/{{.ID}}">{{.ID}}</a></td>
+  <td>{{.Title}}{{if .Ready}} <span class="ready">● ready</span>{{end}}</td>
+  <td>{{template "status" .Status}}</td>
+  <td>P{{.Priority}}</td>
+  <td>{{.Type}}</td>
+  <td>{{.Assignee}}</td>
+  <td>{{range .Labels}}<span class="label">{{.}}</span>{{end}}</td>
+  <td>{{range .BlockedBy}}<a href="/issue/{{.}}">{{.}}</a> {{end}}</td>
+</tr>{{else}}<tr><td colspan="8" class="muted">No issues match this filter.</td></tr>{{end}}
+</table>
+{{end}}`
+
+const boardTemplate = `{{define "content"}}
+<div class="board">
+{{range .Columns}}<div class="lane">
+  <h2>{{.Status}} ({{len .Cards}}{{if .Hidden}}+{{.Hidden}}{{end}})</h2>
+  {{range .Cards}}<div class="card">
+    <a href="/issue/{{.ID}}">{{.ID}}</a> <span class="muted">P{{.Priority}}</span><br>
+    {{.Title}}
+    {{if .Assignee}}<div class="muted">@{{.Assignee}}</div>{{end}}
+    {{if .BlockedBy}}<div class="muted">blocked by {{range .BlockedBy}}{{.}} {{end}}</div>{{end}}
+  </div>{{end}}
+  {{if .Hidden}}<p class="muted">… {{.Hidden}} older closed issues</p>{{end}}
+</div>{{end}}
+</div>
+{{end}}`
+
+const graphTemplate = `{{define "content"}}
+<p class="muted">Blockers on the left, the work they block to the right. Dashed lines are non-blocking links.
+{{if .IncludeClosed}}<a href="/graph">Hide closed issues</a>{{else}}<a href="/graph?closed=1">Show closed issues</a>{{end}} ·
+<a href="/graph.svg{{if .IncludeClosed}}?closed=1{{end}}">SVG</a></p>
+{{if .Omitted}}<p class="muted">{{.Omitted}} lower-impact issues are not drawn.</p>{{end}}
+<div class="graph">{{.SVG}}</div>
+{{end}}`
+
+const issueTemplate = `{{define "content"}}
+<h2>{{.Issue.ID}} · {{.Issue.Title}}</h2>
+<p>{{template "status" .Issue.Status}} P{{.Issue.Priority}} · {{.Issue.Type}}{{if .Issue.Assignee}} · @{{.Issue.Assignee}}{{end}}{{if .Issue.Ready}} · <span class="ready">● ready</span>{{end}}
+{{range .Issue.Labels}}<span class="label">{{.}}</span>{{end}}</p>
+<p class="muted">Impact depth {{printf "%.1f" .Impact}} · created {{.Full.CreatedAt.Format "2006-01-02"}}{{if .Full.DueDate}} · due {{.Full.DueDate.Format "2006-01-02"}}{{end}}</p>
+{{if .Full.Description}}<h3>Description</h3><pre class="text">{{.Full.Description}}</pre>{{end}}
+{{if .Full.AcceptanceCriteria}}<h3>Acceptance Criteria</h3><pre class="text">{{.Full.AcceptanceCriteria}}</pre>{{end}}
+{{if .Full.Notes}}<h3>Notes</h3><pre class="text">{{.Full.Notes}}</pre>{{end}}
+{{if .DependsOn}}<h3>Depends on</h3><ul>{{range .DependsOn}}<li><a href="/issue/{{.ID}}">{{.ID}}</a> {{.Title}} {{if .Status}}{{template "status" .Status}}{{end}}</li>{{end}}</ul>{{end}}
+{{if .Dependents}}<h3>Needed by</h3><ul>{{range .Dependents}}<li><a href="/issue/{{.ID}}">{{.ID}}</a> {{.Title}} {{if .Status}}{{template "status" .Status}}{{end}}</li>{{end}}</ul>{{end}}
+{{if .Full.Comments}}<h3>Comments ({{len .Full.Comments}})</h3>{{range .Full.Comments}}<p><strong>{{.Author}}</strong> <span class="muted">{{.CreatedAt.Format "2006-01-02"}}</span></p><pre class="text">{{.Text}}</pre>{{end}}{{end}}
+{{end}}`
+
+// pages holds each page template combined with the layout
+var pages = func() map[string]*template.Template {
+	layout := template.Must(template.New("layout").Parse(layoutTemplate))
+	out := make(map[string]*template.Template)
+	for name, body := range map[string]string{
+		"list":  listTemplate,
+		"board": boardTemplate,
+		"graph": graphTemplate,
+		"issue": issueTemplate,
+	} {
+		out[name] = template.Must(template.Must(layout.Clone()).Parse(strings.TrimSpace(body)))
+	}
+	return out
+}()
@@ -0,0 +1,147 @@
+// Package worklog records the time spent on issues. Timers are started and
+// stopped from the TUI; each run becomes an entry in .bv/worklog.json,
+// which lives beside the project's issues and can be committed with them.
+package worklog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultFilename is the worklog's file name under .bv
+const DefaultFilename = "worklog.json"
+
+// DefaultPath returns the worklog path for a project
+func DefaultPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", DefaultFilename)
+}
+
+// Entry is a stretch of time someone spent on an issue
+type Entry struct {
+	IssueID string     `json:"issue_id"`
+	Author  string     `json:"author,omitempty"`
+	Start   time.Time  `json:"start"`
+	End     *time.Time `json:"end,omitempty"` // nil while the timer runs
+}
+
+// Running reports whether the entry's timer is still going
+func (e Entry) Running() bool {
+	return e.End == nil
+}
+
+// Duration returns the time the entry covers, up to now while it runs
+func (e Entry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	return max(end.Sub(e.Start), 0)
+}
+
+// Log is a project's worklog
+type Log struct {
+	path    string
+	Entries []Entry
+}
+
+// Load reads the worklog at path; a missing file is an empty worklog
+func Load(path string) (*Log, error) {
+	l := &Log{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading worklog: %w", err)
+	}
+	if err := json.Unmarshal(data, &l.Entries); err != nil {
+		return nil, fmt.Errorf("parsing worklog %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Save writes the worklog back to its file
+func (l *Log) Save() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.MarshalIndent(l.Entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding worklog: %w", err)
+	}
+	// Write then rename, so a crash can't leave half a worklog
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing worklog: %w", err)
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("writing worklog: %w", err)
+	}
+	return nil
+}
+
+// Running returns author's running timer, or nil
+func (l *Log) Running(author string) *Entry {
+	for i := range l.Entries {
+		if e := &l.Entries[i]; e.Running() && e.Author == author {
+			return e
+		}
+	}
+	return nil
+}
+
+// Start starts author's timer on issueID, stopping the one running on
+// another issue, which it returns
+func (l *Log) Start(issueID, author string, now time.Time) *Entry {
+	stopped := l.Stop(author, now)
+	l.Entries = append(l.Entries, Entry{IssueID: issueID, Author: author, Start: now})
+	return stopped
+}
+
+// Stop stops author's running timer, returning its entry, or nil when none
+// runs
+func (l *Log) Stop(author string, now time.Time) *Entry {
+	e := l.Running(author)
+	if e == nil {
+		return nil
+	}
+	end := now
+	e.End = &end
+	stopped := *e
+	return &stopped
+}
+
+// Spent returns the time logged on issueID, counting running timers up to now
+func (l *Log) Spent(issueID string, now time.Time) time.Duration {
+	var total time.Duration
+	for _, e := range l.Entries {
+		if e.IssueID == issueID {
+			total += e.Duration(now)
+		}
+	}
+	return total
+}
+
+// FormatDuration renders a time spent compactly: "45m", "3h 20m", "2d 4h"
+// counting 24-hour days
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes < 24*60:
+		if minutes%60 == 0 {
+			return fmt.Sprintf("%dh", minutes/60)
+		}
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	default:
+		hours := minutes / 60
+		if hours%24 == 0 {
+			return fmt.Sprintf("%dd", hours/24)
+		}
+		return fmt.Sprintf("%dd %dh", hours/24, hours%24)
+	}
+}
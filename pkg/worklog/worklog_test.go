@@ -0,0 +1,66 @@
+package worklog
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStartStopAndPersist(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	l, err := Load(path)
+	if err != nil || len(l.Entries) != 0 {
+		t.Fatalf("expected an empty worklog for a missing file, got %+v, %v", l, err)
+	}
+
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	if stopped := l.Start("A", "alice", start); stopped != nil {
+		t.Fatalf("expected nothing stopped, got %+v", stopped)
+	}
+	l.Start("A", "bob", start)
+	// Starting on another issue stops the running timer
+	stopped := l.Start("B", "alice", start.Add(90*time.Minute))
+	if stopped == nil || stopped.IssueID != "A" || stopped.Duration(time.Time{}) != 90*time.Minute {
+		t.Fatalf("expected alice's timer on A stopped after 90m, got %+v", stopped)
+	}
+	if r := l.Running("alice"); r == nil || r.IssueID != "B" {
+		t.Fatalf("expected alice's timer running on B, got %+v", r)
+	}
+
+	now := start.Add(2 * time.Hour)
+	if got := l.Spent("A", now); got != 90*time.Minute+2*time.Hour {
+		t.Errorf("expected alice's 90m and bob's running 2h on A, got %v", got)
+	}
+	if l.Stop("alice", now) == nil || l.Stop("alice", now) != nil {
+		t.Errorf("expected one timer to stop")
+	}
+
+	if err := l.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != DefaultFilename {
+		t.Fatalf("unexpected path %s", path)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Entries) != 3 || loaded.Running("bob") == nil || loaded.Spent("B", now) != 30*time.Minute {
+		t.Fatalf("expected the entries back, got %+v", loaded.Entries)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                             "0m",
+		45 * time.Minute:              "45m",
+		3*time.Hour + 20*time.Minute:  "3h 20m",
+		5 * time.Hour:                 "5h",
+		52 * time.Hour:                "2d 4h",
+		48*time.Hour + 10*time.Second: "2d",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}